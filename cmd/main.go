@@ -2,15 +2,20 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/ivanvanderbyl/operable/pkg/auth"
 	"github.com/ivanvanderbyl/operable/pkg/tools"
+	"github.com/ivanvanderbyl/operable/pkg/transport"
 	"github.com/mark3labs/mcp-go/server"
 )
 
@@ -19,13 +24,54 @@ const (
 	serverVersion = "0.1.0"
 )
 
+// supportedModes are the values accepted by -mode
+var supportedModes = []string{"stdio", "sse", "streamable"}
+
+// scopeLevels maps the values accepted by -scopes to the OAuth scopes requested
+var scopeLevels = map[string][]string{
+	"read-only":  auth.ReadOnlyScopes,
+	"read-write": auth.ReadWriteScopes,
+}
+
+// httpServer is a transport served over HTTP, such as SSE or streamable HTTP
+type httpServer interface {
+	Start(addr string) error
+	Shutdown(ctx context.Context) error
+}
+
 func main() {
 	// Parse command-line flags
-	mode := flag.String("mode", "stdio", "Server mode: 'stdio' or 'sse'")
-	addr := flag.String("addr", ":8080", "Address to listen on in SSE mode")
-	baseURL := flag.String("base-url", "http://localhost:8080", "Base URL for SSE mode")
+	mode := flag.String("mode", "stdio", "Server mode: 'stdio', 'sse' or 'streamable'")
+	addr := flag.String("addr", ":8080", "Address to listen on in SSE and streamable modes")
+	baseURL := flag.String("base-url", "http://localhost:8080", "Base URL for SSE and streamable modes")
+	warmClusters := flag.String("warm-clusters", "", "Comma-separated list of project/location/cluster whose metadata is cached at startup")
+	warmProjects := flag.String("warm-project", "", "Comma-separated list of projects whose clusters' metadata is cached at startup")
+	scopeLevel := flag.String("scopes", "read-only", "OAuth scope level: 'read-only' or 'read-write'")
 	flag.Parse()
 
+	// Check the mode before doing any setup work
+	if !slices.Contains(supportedModes, *mode) {
+		fmt.Printf("Unknown mode: %s. Supported modes are '%s'.\n", *mode, strings.Join(supportedModes, "', '"))
+		os.Exit(1)
+	}
+	scopes, ok := scopeLevels[*scopeLevel]
+	if !ok {
+		fmt.Printf("Unknown scopes: %s. Supported scopes are 'read-only' and 'read-write'.\n", *scopeLevel)
+		os.Exit(1)
+	}
+
+	clustersToWarm, err := tools.ParseClusterRefs(*warmClusters)
+	if err != nil {
+		fmt.Printf("Invalid -warm-clusters: %v\n", err)
+		os.Exit(1)
+	}
+	var projectsToWarm []string
+	for _, projectID := range strings.Split(*warmProjects, ",") {
+		if projectID = strings.TrimSpace(projectID); projectID != "" {
+			projectsToWarm = append(projectsToWarm, projectID)
+		}
+	}
+
 	// Create a new MCP server
 	s := server.NewMCPServer(
 		serverName,
@@ -36,12 +82,16 @@ func main() {
 	)
 
 	// Set up auth handler
-	authHandler, err := auth.NewOAuthHandler()
+	authHandler, err := auth.NewOAuthHandlerWithScopes(scopes)
 	if err != nil {
 		fmt.Printf("Error setting up auth handler: %v\n", err)
 		os.Exit(1)
 	}
 
+	// Record the permission level for auditing. Print to stderr, as stdout
+	// carries the protocol in stdio mode.
+	fmt.Fprintf(os.Stderr, "Using %s OAuth scopes: %s\n", *scopeLevel, strings.Join(authHandler.Scopes(), ", "))
+
 	// Register all tools
 	if err := tools.RegisterTools(s, authHandler); err != nil {
 		fmt.Printf("Error registering tools: %v\n", err)
@@ -52,6 +102,11 @@ func main() {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
+	// Warm the cluster metadata cache in the background so startup isn't delayed
+	if len(clustersToWarm) > 0 || len(projectsToWarm) > 0 {
+		go tools.WarmClusterCache(ctx, authHandler, clustersToWarm, projectsToWarm)
+	}
+
 	// Start the server in the specified mode
 	fmt.Printf("Starting %s v%s MCP server in %s mode...\n", serverName, serverVersion, *mode)
 
@@ -65,32 +120,38 @@ func main() {
 	case "sse":
 		// Create and start the SSE server
 		sseServer := server.NewSSEServer(s, *baseURL)
+		serveHTTP(ctx, cancel, "SSE", sseServer, *addr, fmt.Sprintf("Base URL: %s", *baseURL))
+	case "streamable":
+		// Create and start the streamable HTTP server
+		streamableServer := transport.NewStreamableHTTPServer(s, *baseURL)
+		serveHTTP(ctx, cancel, "Streamable HTTP", streamableServer, *addr, fmt.Sprintf("Endpoint: %s", streamableServer.EndpointURL()))
+	}
+}
 
-		// Start the server in a goroutine
-		go func() {
-			if err := sseServer.Start(*addr); err != nil {
-				fmt.Printf("SSE server error: %v\n", err)
-				cancel() // Cancel the context to trigger shutdown
-			}
-		}()
+// serveHTTP runs an HTTP transport until ctx is cancelled, then shuts it down
+// gracefully
+func serveHTTP(ctx context.Context, cancel context.CancelFunc, name string, srv httpServer, addr, endpoint string) {
+	// Start the server in a goroutine
+	go func() {
+		if err := srv.Start(addr); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Printf("%s server error: %v\n", name, err)
+			cancel() // Cancel the context to trigger shutdown
+		}
+	}()
 
-		fmt.Printf("SSE server listening on %s\n", *addr)
-		fmt.Printf("Base URL: %s\n", *baseURL)
-		fmt.Println("Press Ctrl+C to stop the server")
+	fmt.Printf("%s server listening on %s\n", name, addr)
+	fmt.Println(endpoint)
+	fmt.Println("Press Ctrl+C to stop the server")
 
-		// Wait for context cancellation (e.g., SIGINT or SIGTERM)
-		<-ctx.Done()
+	// Wait for context cancellation (e.g., SIGINT or SIGTERM)
+	<-ctx.Done()
 
-		// Graceful shutdown
-		fmt.Println("Shutting down server...")
-		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer shutdownCancel()
+	// Graceful shutdown
+	fmt.Println("Shutting down server...")
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
 
-		if err := sseServer.Shutdown(shutdownCtx); err != nil {
-			fmt.Printf("Error during server shutdown: %v\n", err)
-		}
-	default:
-		fmt.Printf("Unknown mode: %s. Supported modes are 'stdio' and 'sse'.\n", *mode)
-		os.Exit(1)
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		fmt.Printf("Error during server shutdown: %v\n", err)
 	}
 }
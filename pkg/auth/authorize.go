@@ -0,0 +1,174 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"syscall"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	// callbackAddr is the address the authorization callback listens on,
+	// matching the redirect URL registered for the OAuth client
+	callbackAddr = "localhost:8085"
+
+	// callbackPath is the path of the redirect URL
+	callbackPath = "/oauth/callback"
+
+	// defaultAuthorizeTimeout bounds how long Authorize waits for consent
+	// when the context has no deadline
+	defaultAuthorizeTimeout = 5 * time.Minute
+)
+
+// Authorize runs the interactive authorization code flow for the current
+// scopes. It listens for the callback on localhost:8085, opens the consent
+// page in a browser and prints its URL for headless environments, then
+// exchanges the returned code for a token, which is also written to the
+// token cache.
+func (h *OAuthHandler) Authorize(ctx context.Context) (*oauth2.Token, error) {
+	return h.authorize(ctx, h.Scopes())
+}
+
+// authorize implements Authorize for the given scopes, so the token is
+// requested and cached for the same scopes even if UpgradePermissions
+// changes them during the flow
+func (h *OAuthHandler) authorize(ctx context.Context, scopes []string) (*oauth2.Token, error) {
+	if h.clientID == "" || h.clientSecret == "" {
+		return nil, fmt.Errorf("GOOGLE_CLIENT_ID and GOOGLE_CLIENT_SECRET must be set to authorize interactively")
+	}
+
+	// Only one flow can hold the callback port at a time
+	h.authorizeMu.Lock()
+	defer h.authorizeMu.Unlock()
+
+	// Another caller may have completed the flow while this one waited
+	if token := loadCachedToken(h.tokenCacheFile, scopes); token != nil {
+		return token, nil
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultAuthorizeTimeout)
+		defer cancel()
+	}
+
+	config := h.oauthConfig(scopes)
+
+	state, err := newState()
+	if err != nil {
+		return nil, fmt.Errorf("error generating state: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", callbackAddr)
+	if err != nil {
+		if errors.Is(err, syscall.EADDRINUSE) {
+			return nil, fmt.Errorf("port %s for the OAuth callback is already in use; stop the process using it and try again", callbackAddr)
+		}
+		return nil, fmt.Errorf("error listening for the OAuth callback: %w", err)
+	}
+
+	type callbackResult struct {
+		code string
+		err  error
+	}
+	results := make(chan callbackResult, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(callbackPath, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		// A mismatched state means the callback wasn't for this flow, so
+		// ignore it and keep waiting
+		if query.Get("state") != state {
+			http.Error(w, "Invalid state parameter", http.StatusBadRequest)
+			return
+		}
+
+		result := callbackResult{code: query.Get("code")}
+		if errParam := query.Get("error"); errParam != "" {
+			result.err = fmt.Errorf("authorization denied: %s", errParam)
+		} else if result.code == "" {
+			result.err = fmt.Errorf("authorization callback had no code")
+		}
+
+		if result.err != nil {
+			http.Error(w, "Authorization failed. You can close this window.", http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "Authorization complete. You can close this window.")
+		}
+
+		select {
+		case results <- result:
+		default:
+		}
+	})
+
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(listener)
+	defer srv.Close()
+
+	// Print to stderr, as stdout carries the protocol in stdio mode
+	authURL := config.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.ApprovalForce)
+	fmt.Fprintf(os.Stderr, "Authorize access to Google Cloud by visiting:\n\n%s\n\n", authURL)
+	openBrowser(authURL)
+
+	var result callbackResult
+	select {
+	case result = <-results:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("timed out waiting for authorization: %w", ctx.Err())
+	}
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	token, err := config.Exchange(ctx, result.code)
+	if err != nil {
+		return nil, fmt.Errorf("error exchanging authorization code: %w", err)
+	}
+
+	if h.tokenCacheFile != "" {
+		if err := saveCachedToken(h.tokenCacheFile, scopes, token); err != nil {
+			fmt.Fprintf(os.Stderr, "Error caching token: %v\n", err)
+		}
+	}
+
+	return token, nil
+}
+
+// newState returns a random state parameter to protect the callback from
+// cross-site request forgery
+func newState() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// openBrowser tries to open url in the user's browser. Failure is ignored,
+// since the URL is also printed.
+func openBrowser(url string) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err == nil {
+		go cmd.Wait()
+	}
+}
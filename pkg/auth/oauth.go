@@ -2,9 +2,12 @@ package auth
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
+	"slices"
+	"sync"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
@@ -36,15 +39,37 @@ var (
 type OAuthHandler struct {
 	clientID        string
 	clientSecret    string
-	currentScopes   []string
 	credentialsFile string
+	tokenCacheFile  string
+
+	// scopesMu guards currentScopes, which UpgradePermissions changes while
+	// other tool calls read it
+	scopesMu      sync.RWMutex
+	currentScopes []string
+
+	// authorizeMu serialises interactive authorization flows
+	authorizeMu sync.Mutex
 }
 
-// NewOAuthHandler creates a new OAuth handler
+// NewOAuthHandler creates a new OAuth handler with read-only scopes
 func NewOAuthHandler() (*OAuthHandler, error) {
+	return NewOAuthHandlerWithScopes(ReadOnlyScopes)
+}
+
+// NewOAuthHandlerWithScopes creates a new OAuth handler that requests the
+// given scopes, such as ReadWriteScopes to start with remediation permissions
+func NewOAuthHandlerWithScopes(scopes []string) (*OAuthHandler, error) {
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("at least one OAuth scope must be requested")
+	}
+
 	clientID := os.Getenv("GOOGLE_CLIENT_ID")
 	clientSecret := os.Getenv("GOOGLE_CLIENT_SECRET")
 	credentialsFile := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+	tokenCacheFile := os.Getenv("GOOGLE_TOKEN_CACHE")
+	if tokenCacheFile == "" {
+		tokenCacheFile = defaultTokenCacheFile()
+	}
 
 	// We need either OAuth client credentials or a service account credentials file
 	if (clientID == "" || clientSecret == "") && credentialsFile == "" {
@@ -55,40 +80,71 @@ func NewOAuthHandler() (*OAuthHandler, error) {
 		clientID:        clientID,
 		clientSecret:    clientSecret,
 		credentialsFile: credentialsFile,
-		currentScopes:   ReadOnlyScopes,
+		tokenCacheFile:  tokenCacheFile,
+		currentScopes:   scopes,
 	}, nil
 }
 
 // GetClient returns an HTTP client with OAuth credentials
 func (h *OAuthHandler) GetClient(ctx context.Context) (*http.Client, error) {
+	ts, err := h.TokenSource(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return oauth2.NewClient(ctx, ts), nil
+}
+
+// TokenSource returns the OAuth token source for the current scopes
+func (h *OAuthHandler) TokenSource(ctx context.Context) (oauth2.TokenSource, error) {
+	scopes := h.Scopes()
+
 	// If credentials file is provided, use it
 	if h.credentialsFile != "" {
-		creds, err := google.FindDefaultCredentials(ctx, h.currentScopes...)
+		creds, err := google.FindDefaultCredentials(ctx, scopes...)
 		if err != nil {
 			return nil, fmt.Errorf("error finding default credentials: %w", err)
 		}
-		return oauth2.NewClient(ctx, creds.TokenSource), nil
+		return creds.TokenSource, nil
 	}
 
-	// Otherwise use the OAuth flow with client ID and secret
-	config := &oauth2.Config{
+	// Otherwise use the OAuth flow with client ID and secret. Start from the
+	// cached token so short-lived processes don't need a fresh flow each run,
+	// and only run the interactive flow when there is none.
+	cached := loadCachedToken(h.tokenCacheFile, scopes)
+	if cached == nil {
+		token, err := h.authorize(ctx, scopes)
+		if err != nil {
+			return nil, fmt.Errorf("error authorizing: %w", err)
+		}
+		cached = token
+	}
+
+	// Cache the token again whenever it refreshes
+	ts := h.oauthConfig(scopes).TokenSource(ctx, cached)
+	if h.tokenCacheFile == "" {
+		return ts, nil
+	}
+	return newCachingTokenSource(ts, h.tokenCacheFile, scopes, cached), nil
+}
+
+// oauthConfig returns the OAuth client configuration for scopes
+func (h *OAuthHandler) oauthConfig(scopes []string) *oauth2.Config {
+	return &oauth2.Config{
 		ClientID:     h.clientID,
 		ClientSecret: h.clientSecret,
 		Endpoint:     google.Endpoint,
-		Scopes:       h.currentScopes,
-		RedirectURL:  "http://localhost:8085/oauth/callback",
+		Scopes:       scopes,
+		RedirectURL:  "http://" + callbackAddr + callbackPath,
 	}
-
-	// For simplicity, since this is just a demo, we'll use the client without token persistence
-	// In a real application, you would handle the OAuth flow and token storage
-	ts := config.TokenSource(ctx, nil)
-	return oauth2.NewClient(ctx, ts), nil
 }
 
 // UpgradePermissions upgrades the permissions to read-write
 func (h *OAuthHandler) UpgradePermissions(ctx context.Context) error {
+	h.scopesMu.Lock()
+	defer h.scopesMu.Unlock()
+
 	// Only upgrade if we're not already at read-write
-	if len(h.currentScopes) == len(ReadWriteScopes) {
+	if slices.Equal(h.currentScopes, ReadWriteScopes) {
 		return nil
 	}
 
@@ -98,6 +154,48 @@ func (h *OAuthHandler) UpgradePermissions(ctx context.Context) error {
 	return nil
 }
 
+// Scopes returns a copy of the scopes currently requested for tokens
+func (h *OAuthHandler) Scopes() []string {
+	h.scopesMu.RLock()
+	defer h.scopesMu.RUnlock()
+
+	return slices.Clone(h.currentScopes)
+}
+
+// ReadWrite reports whether the handler holds read-write scopes
+func (h *OAuthHandler) ReadWrite() bool {
+	h.scopesMu.RLock()
+	defer h.scopesMu.RUnlock()
+
+	return slices.Equal(h.currentScopes, ReadWriteScopes)
+}
+
+// CredentialSource describes where credentials come from: an impersonated
+// service account, another Application Default Credentials file, or an OAuth
+// client ID and secret
+func (h *OAuthHandler) CredentialSource() string {
+	if h.credentialsFile == "" {
+		return "OAuth client ID and secret (GOOGLE_CLIENT_ID)"
+	}
+
+	// The type field distinguishes service account keys, user credentials
+	// and impersonation
+	var file struct {
+		Type string `json:"type"`
+	}
+	if data, err := os.ReadFile(h.credentialsFile); err == nil {
+		json.Unmarshal(data, &file)
+	}
+	switch file.Type {
+	case "impersonated_service_account":
+		return fmt.Sprintf("Service account impersonation via Application Default Credentials file %s", h.credentialsFile)
+	case "":
+		return fmt.Sprintf("Application Default Credentials file %s", h.credentialsFile)
+	default:
+		return fmt.Sprintf("Application Default Credentials file %s (%s)", h.credentialsFile, file.Type)
+	}
+}
+
 // GetClientOptions returns the client options for the GCP SDK
 func (h *OAuthHandler) GetClientOptions(ctx context.Context) ([]option.ClientOption, error) {
 	// Create authentication options
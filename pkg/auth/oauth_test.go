@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// newTestHandler returns a handler holding scopes with an unexpired token
+// cached for them, so no authorization flow is run
+func newTestHandler(t *testing.T, scopes []string) *OAuthHandler {
+	t.Helper()
+
+	cacheFile := filepath.Join(t.TempDir(), "token.json")
+	token := &oauth2.Token{AccessToken: "test-token", TokenType: "Bearer", Expiry: time.Now().Add(time.Hour)}
+	if err := saveCachedToken(cacheFile, scopes, token); err != nil {
+		t.Fatalf("saving token: %v", err)
+	}
+
+	t.Setenv("GOOGLE_CLIENT_ID", "test-client")
+	t.Setenv("GOOGLE_CLIENT_SECRET", "test-secret")
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+	t.Setenv("GOOGLE_TOKEN_CACHE", cacheFile)
+
+	h, err := NewOAuthHandlerWithScopes(scopes)
+	if err != nil {
+		t.Fatalf("creating handler: %v", err)
+	}
+	return h
+}
+
+func TestNewOAuthHandlerWithScopes(t *testing.T) {
+	t.Setenv("GOOGLE_CLIENT_ID", "test-client")
+	t.Setenv("GOOGLE_CLIENT_SECRET", "test-secret")
+
+	if _, err := NewOAuthHandlerWithScopes(nil); err == nil {
+		t.Error("NewOAuthHandlerWithScopes(nil) succeeded, want error")
+	}
+
+	h, err := NewOAuthHandlerWithScopes(ReadWriteScopes)
+	if err != nil {
+		t.Fatalf("NewOAuthHandlerWithScopes: %v", err)
+	}
+	if !h.ReadWrite() {
+		t.Error("handler created with read-write scopes isn't read-write")
+	}
+}
+
+func TestUpgradePermissions(t *testing.T) {
+	h := newTestHandler(t, ReadOnlyScopes)
+	if h.ReadWrite() {
+		t.Fatal("read-only handler reports read-write")
+	}
+
+	if err := h.UpgradePermissions(context.Background()); err != nil {
+		t.Fatalf("UpgradePermissions: %v", err)
+	}
+	if !h.ReadWrite() {
+		t.Error("handler isn't read-write after UpgradePermissions")
+	}
+	if !slices.Equal(h.Scopes(), ReadWriteScopes) {
+		t.Errorf("Scopes() = %v, want %v", h.Scopes(), ReadWriteScopes)
+	}
+}
+
+func TestScopesReturnsCopy(t *testing.T) {
+	h := newTestHandler(t, ReadOnlyScopes)
+
+	scopes := h.Scopes()
+	scopes[0] = "modified"
+
+	if h.Scopes()[0] == "modified" {
+		t.Error("modifying the result of Scopes() changed the handler's scopes")
+	}
+}
+
+// TestUpgradePermissionsConcurrent checks that upgrading while other calls
+// read the scopes is safe. Run with -race.
+func TestUpgradePermissionsConcurrent(t *testing.T) {
+	// Application Default Credentials give a token source for any scopes
+	// without a token cache or an interactive flow
+	credentialsFile := filepath.Join(t.TempDir(), "credentials.json")
+	credentials := `{"type": "authorized_user", "client_id": "test-client", "client_secret": "test-secret", "refresh_token": "test-refresh"}`
+	if err := os.WriteFile(credentialsFile, []byte(credentials), 0o600); err != nil {
+		t.Fatalf("writing credentials: %v", err)
+	}
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", credentialsFile)
+
+	h, err := NewOAuthHandlerWithScopes(ReadOnlyScopes)
+	if err != nil {
+		t.Fatalf("creating handler: %v", err)
+	}
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(4)
+		go func() {
+			defer wg.Done()
+			h.UpgradePermissions(ctx)
+		}()
+		go func() {
+			defer wg.Done()
+			h.Scopes()
+		}()
+		go func() {
+			defer wg.Done()
+			h.ReadWrite()
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := h.TokenSource(ctx); err != nil {
+				t.Errorf("TokenSource: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !h.ReadWrite() {
+		t.Error("handler isn't read-write after concurrent upgrades")
+	}
+}
@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// defaultTokenCacheFile returns the token cache path used when
+// GOOGLE_TOKEN_CACHE isn't set, or an empty string if there is no home
+// directory to put it in
+func defaultTokenCacheFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "operable", "token.json")
+}
+
+// cachedToken is the contents of the token cache file. The scopes are kept
+// with the token so a read-only token isn't reused once read-write scopes
+// are requested.
+type cachedToken struct {
+	Scopes []string      `json:"scopes"`
+	Token  *oauth2.Token `json:"token"`
+}
+
+// loadCachedToken reads the token cached for scopes. It returns nil if there
+// is no cached token, it was issued for other scopes, or the file is corrupt,
+// so the caller falls back to a fresh flow.
+func loadCachedToken(path string, scopes []string) *oauth2.Token {
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "Ignoring token cache %s: %v\n", path, err)
+		}
+		return nil
+	}
+
+	var cached cachedToken
+	if err := json.Unmarshal(data, &cached); err != nil || cached.Token == nil {
+		fmt.Fprintf(os.Stderr, "Ignoring corrupt token cache %s\n", path)
+		return nil
+	}
+	if !slices.Equal(cached.Scopes, scopes) {
+		return nil
+	}
+
+	return cached.Token
+}
+
+// saveCachedToken writes token to path atomically with 0600 permissions, by
+// writing a temporary file alongside it and renaming it into place
+func saveCachedToken(path string, scopes []string, token *oauth2.Token) error {
+	data, err := json.Marshal(cachedToken{Scopes: scopes, Token: token})
+	if err != nil {
+		return fmt.Errorf("error encoding token: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("error creating token cache directory: %w", err)
+	}
+
+	// CreateTemp creates the file with 0600 permissions
+	tmp, err := os.CreateTemp(dir, ".token-*.json")
+	if err != nil {
+		return fmt.Errorf("error creating token cache file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing token cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing token cache file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error replacing token cache file: %w", err)
+	}
+
+	return nil
+}
+
+// cachingTokenSource writes each new token from the underlying source to the
+// token cache, so refreshed tokens survive a restart
+type cachingTokenSource struct {
+	base   oauth2.TokenSource
+	path   string
+	scopes []string
+
+	mu   sync.Mutex
+	last string
+}
+
+// newCachingTokenSource wraps base so new tokens are written to path. The
+// initial token, if any, was loaded from path and isn't written back.
+func newCachingTokenSource(base oauth2.TokenSource, path string, scopes []string, initial *oauth2.Token) *cachingTokenSource {
+	ts := &cachingTokenSource{base: base, path: path, scopes: scopes}
+	if initial != nil {
+		ts.last = initial.AccessToken
+	}
+	return ts
+}
+
+// Token implements oauth2.TokenSource
+func (ts *cachingTokenSource) Token() (*oauth2.Token, error) {
+	token, err := ts.base.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if token.AccessToken != ts.last {
+		// A failed write only costs a fresh flow next run, so don't fail the
+		// request over it
+		if err := saveCachedToken(ts.path, ts.scopes, token); err != nil {
+			fmt.Fprintf(os.Stderr, "Error caching token: %v\n", err)
+		}
+		ts.last = token.AccessToken
+	}
+
+	return token, nil
+}
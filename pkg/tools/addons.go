@@ -0,0 +1,235 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerAddonTools registers tools that check cluster addons
+func registerAddonTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get cluster addon health tool
+	getAddonHealth := mcp.NewTool("get_cluster_addon_health",
+		mcp.WithDescription("Checks the Deployments and DaemonSets of core addons in kube-system (DNS, metrics-server, konnectivity, logging and CSI agents) and flags unhealthy ones that cause cluster-wide failures"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The location of the cluster"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The name of the cluster"),
+		),
+	)
+
+	getAddonHealthHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetClusterAddonHealth(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getAddonHealth, getAddonHealthHandler)
+
+	return nil
+}
+
+// knownAddon describes a kube-system workload and the impact of it failing
+type knownAddon struct {
+	// Name matches the workload name exactly, or as a prefix followed by a
+	// version suffix such as metrics-server-v0.7.1
+	Name     string
+	Critical bool
+	Impact   string
+}
+
+// knownAddons are the kube-system workloads that GKE manages
+var knownAddons = []knownAddon{
+	{Name: "kube-dns", Critical: true, Impact: "DNS resolution fails or is slow across the cluster"},
+	{Name: "coredns", Critical: true, Impact: "DNS resolution fails or is slow across the cluster"},
+	{Name: "node-local-dns", Critical: true, Impact: "Pods on affected nodes can't resolve names through NodeLocal DNSCache"},
+	{Name: "metrics-server", Critical: true, Impact: "HorizontalPodAutoscalers stop scaling and kubectl top fails"},
+	{Name: "konnectivity-agent", Critical: true, Impact: "kubectl logs, exec and port-forward fail, and admission webhooks time out"},
+	{Name: "anetd", Critical: true, Impact: "Pod networking and network policy fail on affected nodes (Dataplane V2)"},
+	{Name: "kube-dns-autoscaler", Impact: "kube-dns no longer scales with the cluster"},
+	{Name: "konnectivity-agent-autoscaler", Impact: "konnectivity-agent no longer scales with the cluster"},
+	{Name: "gke-metadata-server", Impact: "Workload Identity token requests fail on affected nodes"},
+	{Name: "pdcsi-node", Impact: "Persistent Disk volumes can't attach or mount on affected nodes"},
+	{Name: "fluentbit-gke", Impact: "Container logs from affected nodes are missing from Cloud Logging"},
+	{Name: "gke-metrics-agent", Impact: "System and workload metrics from affected nodes are missing"},
+	{Name: "netd", Impact: "Pod networking setup can fail on affected nodes"},
+	{Name: "calico-node", Impact: "Network policy enforcement fails on affected nodes"},
+	{Name: "ip-masq-agent", Impact: "Egress IP masquerading rules may be missing on affected nodes"},
+	{Name: "event-exporter-gke", Impact: "Kubernetes events are no longer exported to Cloud Logging"},
+	{Name: "l7-default-backend", Impact: "Ingress requests that match no rule get errors instead of the default 404"},
+}
+
+// matchAddon returns the known addon for a workload name, if any
+func matchAddon(name string) (knownAddon, bool) {
+	var best knownAddon
+	found := false
+	for _, addon := range knownAddons {
+		if name == addon.Name || strings.HasPrefix(name, addon.Name+"-v") {
+			// Prefer the longest match so kube-dns-autoscaler isn't treated as kube-dns
+			if !found || len(addon.Name) > len(best.Name) {
+				best = addon
+				found = true
+			}
+		}
+	}
+	return best, found
+}
+
+// addonStatus is the health of a single kube-system workload
+type addonStatus struct {
+	Name    string
+	Kind    string
+	Ready   int32
+	Desired int32
+	Addon   knownAddon
+	Known   bool
+}
+
+// healthy reports whether all desired replicas or pods are ready
+func (a addonStatus) healthy() bool {
+	return a.Ready >= a.Desired
+}
+
+// evaluateAddons summarises kube-system workloads, listing unhealthy critical
+// addons first, then other unhealthy workloads, then healthy ones
+func evaluateAddons(deployments []kubeDeployment, daemonSets []kubeDaemonSet) []addonStatus {
+	var statuses []addonStatus
+	for _, d := range deployments {
+		addon, known := matchAddon(d.Metadata.Name)
+		statuses = append(statuses, addonStatus{
+			Name:    d.Metadata.Name,
+			Kind:    "Deployment",
+			Ready:   d.Status.ReadyReplicas,
+			Desired: d.desiredReplicas(),
+			Addon:   addon,
+			Known:   known,
+		})
+	}
+	for _, ds := range daemonSets {
+		addon, known := matchAddon(ds.Metadata.Name)
+		statuses = append(statuses, addonStatus{
+			Name:    ds.Metadata.Name,
+			Kind:    "DaemonSet",
+			Ready:   ds.Status.NumberReady,
+			Desired: ds.Status.DesiredNumberScheduled,
+			Addon:   addon,
+			Known:   known,
+		})
+	}
+
+	rank := func(s addonStatus) int {
+		switch {
+		case !s.healthy() && s.Addon.Critical:
+			return 0
+		case !s.healthy():
+			return 1
+		}
+		return 2
+	}
+	sort.SliceStable(statuses, func(i, j int) bool {
+		if rank(statuses[i]) != rank(statuses[j]) {
+			return rank(statuses[i]) < rank(statuses[j])
+		}
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	return statuses
+}
+
+// handleGetClusterAddonHealth handles the get_cluster_addon_health tool request
+func handleGetClusterAddonHealth(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error connecting to cluster: %v", err)), nil
+	}
+
+	var deployments struct {
+		Items []kubeDeployment `json:"items"`
+	}
+	if err := kube.get(ctx, "/apis/apps/v1/namespaces/kube-system/deployments", nil, &deployments); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing kube-system deployments: %v", err)), nil
+	}
+
+	var daemonSets struct {
+		Items []kubeDaemonSet `json:"items"`
+	}
+	if err := kube.get(ctx, "/apis/apps/v1/namespaces/kube-system/daemonsets", nil, &daemonSets); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing kube-system daemonsets: %v", err)), nil
+	}
+
+	statuses := evaluateAddons(deployments.Items, daemonSets.Items)
+
+	var criticalProblems, otherProblems []addonStatus
+	for _, status := range statuses {
+		if status.healthy() {
+			continue
+		}
+		if status.Addon.Critical {
+			criticalProblems = append(criticalProblems, status)
+		} else {
+			otherProblems = append(otherProblems, status)
+		}
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# Addon Health for Cluster %s\n\n", clusterName)
+	result += fmt.Sprintf("Checked %d kube-system workloads: %d critical and %d other addons unhealthy.\n\n",
+		len(statuses), len(criticalProblems), len(otherProblems))
+
+	if len(criticalProblems) > 0 {
+		result += "## Critical Addons Unhealthy\n\n"
+		for _, status := range criticalProblems {
+			result += fmt.Sprintf("- **%s** (%s): %d/%d ready. %s\n", status.Name, status.Kind, status.Ready, status.Desired, status.Addon.Impact)
+		}
+		result += "\n"
+	}
+
+	result += "## All Addons\n\n"
+	result += "| Name | Kind | Ready | Status |\n"
+	result += "| ---- | ---- | ----- | ------ |\n"
+	for _, status := range statuses {
+		state := "Healthy"
+		if !status.healthy() {
+			state = "**Unhealthy**"
+			if status.Known {
+				state += ": " + status.Addon.Impact
+			}
+		}
+		result += fmt.Sprintf("| %s | %s | %d/%d | %s |\n", status.Name, status.Kind, status.Ready, status.Desired, state)
+	}
+
+	if len(criticalProblems) > 0 || len(otherProblems) > 0 {
+		result += "\n## Recommended Actions\n\n"
+		result += "1. Check the addon's pods in kube-system for Pending, CrashLoopBackOff or OOMKilled states\n"
+		result += "2. Pending addon pods often mean the nodes lack capacity; kube-system pods compete with workloads for resources\n"
+		result += "3. GKE reconciles managed addons, so manual edits are reverted; fix the underlying node or capacity issue instead\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
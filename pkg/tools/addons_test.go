@@ -0,0 +1,73 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMatchAddon(t *testing.T) {
+	tests := []struct {
+		name      string
+		want      string
+		wantFound bool
+	}{
+		{name: "kube-dns", want: "kube-dns", wantFound: true},
+		{name: "metrics-server-v0.7.1", want: "metrics-server", wantFound: true},
+		// The longest match wins, so the autoscaler isn't treated as kube-dns
+		{name: "kube-dns-autoscaler", want: "kube-dns-autoscaler", wantFound: true},
+		{name: "kube-dns-canary", wantFound: false},
+		{name: "my-operator", wantFound: false},
+	}
+
+	for _, tt := range tests {
+		addon, found := matchAddon(tt.name)
+		if found != tt.wantFound || addon.Name != tt.want {
+			t.Errorf("matchAddon(%q) = %q, %t, want %q, %t", tt.name, addon.Name, found, tt.want, tt.wantFound)
+		}
+	}
+}
+
+func TestHandleGetClusterAddonHealth(t *testing.T) {
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/apis/apps/v1/namespaces/kube-system/deployments":
+			w.Write([]byte(`{"items": [
+				{"metadata": {"name": "kube-dns"}, "spec": {"replicas": 2}, "status": {"readyReplicas": 0}},
+				{"metadata": {"name": "kube-dns-autoscaler"}, "spec": {"replicas": 1}, "status": {"readyReplicas": 1}},
+				{"metadata": {"name": "metrics-server-v0.7.1"}, "spec": {"replicas": 1}, "status": {"readyReplicas": 1}},
+				{"metadata": {"name": "my-operator"}, "spec": {"replicas": 1}, "status": {"readyReplicas": 0}}
+			]}`))
+		case "/apis/apps/v1/namespaces/kube-system/daemonsets":
+			w.Write([]byte(`{"items": [
+				{"metadata": {"name": "fluentbit-gke"}, "status": {"desiredNumberScheduled": 3, "numberReady": 2}}
+			]}`))
+		default:
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"kind": "Status", "reason": "NotFound"})
+		}
+	}))
+
+	text := callClusterTool(t, context.Background(), handleGetClusterAddonHealth, clusterName, nil)
+
+	for _, s := range []string{
+		"Checked 5 kube-system workloads: 1 critical and 2 other addons unhealthy.",
+		"## Critical Addons Unhealthy\n\n- **kube-dns** (Deployment): 0/2 ready. DNS resolution fails or is slow across the cluster\n",
+		"| fluentbit-gke | DaemonSet | 2/3 | **Unhealthy**: Container logs from affected nodes are missing from Cloud Logging |",
+		"| my-operator | Deployment | 0/1 | **Unhealthy** |",
+		"| kube-dns-autoscaler | Deployment | 1/1 | Healthy |",
+		"## Recommended Actions",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+
+	// Unhealthy critical addons come first, then other unhealthy workloads,
+	// then healthy ones
+	table := text[strings.Index(text, "## All Addons"):]
+	critical, other, healthy := strings.Index(table, "| kube-dns |"), strings.Index(table, "| fluentbit-gke |"), strings.Index(table, "| kube-dns-autoscaler |")
+	if critical > other || other > healthy {
+		t.Errorf("addons are out of order:\n%s", table)
+	}
+}
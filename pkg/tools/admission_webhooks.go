@@ -0,0 +1,272 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerAdmissionWebhookTools registers tools that check admission webhooks
+func registerAdmissionWebhookTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get admission webhook status tool
+	getWebhookStatus := mcp.NewTool("get_admission_webhook_status",
+		mcp.WithDescription("Lists validating and mutating admission webhooks with their target service, failurePolicy and whether the backing service has ready endpoints, flagging webhooks with failurePolicy Fail whose backend is down, which reject every matching create or update"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+	)
+
+	getWebhookStatusHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetAdmissionWebhookStatus(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getWebhookStatus, getWebhookStatusHandler)
+
+	return nil
+}
+
+// kubeWebhookConfiguration is the subset of an admissionregistration/v1
+// ValidatingWebhookConfiguration or MutatingWebhookConfiguration used by the tools
+type kubeWebhookConfiguration struct {
+	Metadata kubeObjectMeta `json:"metadata"`
+	Webhooks []struct {
+		Name         string `json:"name"`
+		ClientConfig struct {
+			URL     *string `json:"url"`
+			Service *struct {
+				Namespace string `json:"namespace"`
+				Name      string `json:"name"`
+				Port      *int32 `json:"port"`
+			} `json:"service"`
+		} `json:"clientConfig"`
+		FailurePolicy  *string `json:"failurePolicy"`
+		TimeoutSeconds *int32  `json:"timeoutSeconds"`
+	} `json:"webhooks"`
+}
+
+// webhookBackend is the state of the service behind a webhook
+type webhookBackend struct {
+	Exists bool
+	Ready  int
+}
+
+// webhookStatus is the assessed state of a single admission webhook
+type webhookStatus struct {
+	Kind          string
+	Configuration string
+	Name          string
+	Target        string
+	FailurePolicy string
+	Timeout       int32
+	// Service is "namespace/name" for service-backed webhooks, or empty for
+	// webhooks called by URL, whose backend can't be checked from here
+	Service string
+	Backend webhookBackend
+}
+
+// backendDown reports whether the webhook's service is missing or has no
+// ready endpoints
+func (w webhookStatus) backendDown() bool {
+	return w.Service != "" && (!w.Backend.Exists || w.Backend.Ready == 0)
+}
+
+// blocking reports whether the webhook rejects matching requests because its
+// backend is down
+func (w webhookStatus) blocking() bool {
+	return w.backendDown() && w.FailurePolicy == "Fail"
+}
+
+// webhookServices returns the "namespace/name" of every service that backs a
+// webhook in configs
+func webhookServices(configs []kubeWebhookConfiguration) []string {
+	seen := make(map[string]bool)
+	var services []string
+	for _, config := range configs {
+		for _, webhook := range config.Webhooks {
+			if svc := webhook.ClientConfig.Service; svc != nil {
+				key := svc.Namespace + "/" + svc.Name
+				if !seen[key] {
+					seen[key] = true
+					services = append(services, key)
+				}
+			}
+		}
+	}
+	return services
+}
+
+// assessWebhooks combines webhook configurations of a kind with the state of
+// their backing services
+func assessWebhooks(kind string, configs []kubeWebhookConfiguration, backends map[string]webhookBackend) []webhookStatus {
+	var statuses []webhookStatus
+	for _, config := range configs {
+		for _, webhook := range config.Webhooks {
+			status := webhookStatus{
+				Kind:          kind,
+				Configuration: config.Metadata.Name,
+				Name:          webhook.Name,
+				// Both defaults are from the admissionregistration/v1 API
+				FailurePolicy: "Fail",
+				Timeout:       10,
+			}
+			if webhook.FailurePolicy != nil {
+				status.FailurePolicy = *webhook.FailurePolicy
+			}
+			if webhook.TimeoutSeconds != nil {
+				status.Timeout = *webhook.TimeoutSeconds
+			}
+
+			switch {
+			case webhook.ClientConfig.Service != nil:
+				svc := webhook.ClientConfig.Service
+				status.Service = svc.Namespace + "/" + svc.Name
+				port := int32(443)
+				if svc.Port != nil {
+					port = *svc.Port
+				}
+				status.Target = fmt.Sprintf("service %s:%d", status.Service, port)
+				status.Backend = backends[status.Service]
+			case webhook.ClientConfig.URL != nil:
+				status.Target = *webhook.ClientConfig.URL
+			}
+
+			statuses = append(statuses, status)
+		}
+	}
+
+	return statuses
+}
+
+// handleGetAdmissionWebhookStatus handles the get_admission_webhook_status tool request
+func handleGetAdmissionWebhookStatus(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error connecting to cluster: %v", err)), nil
+	}
+
+	var validating, mutating struct {
+		Items []kubeWebhookConfiguration `json:"items"`
+	}
+	if err := kube.get(ctx, "/apis/admissionregistration.k8s.io/v1/validatingwebhookconfigurations", nil, &validating); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing validating webhook configurations: %v", err)), nil
+	}
+	if err := kube.get(ctx, "/apis/admissionregistration.k8s.io/v1/mutatingwebhookconfigurations", nil, &mutating); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing mutating webhook configurations: %v", err)), nil
+	}
+
+	// Check each backing service has ready endpoints; a missing Endpoints
+	// object means the service doesn't exist
+	backends := make(map[string]webhookBackend)
+	var configs []kubeWebhookConfiguration
+	configs = append(configs, validating.Items...)
+	configs = append(configs, mutating.Items...)
+	for _, service := range webhookServices(configs) {
+		namespace, name, _ := strings.Cut(service, "/")
+		var endpoints kubeEndpoints
+		err := kube.get(ctx, fmt.Sprintf("/api/v1/namespaces/%s/endpoints/%s", namespace, name), nil, &endpoints)
+		if isKubeNotFound(err) {
+			backends[service] = webhookBackend{}
+			continue
+		}
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error getting endpoints of %s: %v", service, err)), nil
+		}
+
+		backend := webhookBackend{Exists: true}
+		for _, subset := range endpoints.Subsets {
+			backend.Ready += len(subset.Addresses)
+		}
+		backends[service] = backend
+	}
+
+	statuses := append(
+		assessWebhooks("Validating", validating.Items, backends),
+		assessWebhooks("Mutating", mutating.Items, backends)...,
+	)
+
+	// List blocking webhooks first
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return statuses[i].blocking() && !statuses[j].blocking()
+	})
+
+	// Format the results
+	if len(statuses) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No admission webhooks are configured in cluster %s.", clusterName)), nil
+	}
+
+	blocking, degraded := 0, 0
+	for _, status := range statuses {
+		switch {
+		case status.blocking():
+			blocking++
+		case status.backendDown():
+			degraded++
+		}
+	}
+
+	result := fmt.Sprintf("# Admission Webhooks in Cluster %s\n\n", clusterName)
+	result += fmt.Sprintf("Found %d webhooks: %d blocking requests, %d with a down backend that are being skipped.\n\n", len(statuses), blocking, degraded)
+
+	result += "| Kind | Webhook | Configuration | Target | Failure Policy | Timeout | Backend |\n"
+	result += "| ---- | ------- | ------------- | ------ | -------------- | ------- | ------- |\n"
+	for _, status := range statuses {
+		backend := "not checked (URL)"
+		switch {
+		case status.Service == "":
+		case !status.Backend.Exists:
+			backend = "service not found"
+		case status.Backend.Ready == 0:
+			backend = "no ready endpoints"
+		default:
+			backend = fmt.Sprintf("%d ready endpoints", status.Backend.Ready)
+		}
+		if status.blocking() {
+			backend = "**" + backend + " (BLOCKING)**"
+		}
+		result += fmt.Sprintf("| %s | %s | %s | %s | %s | %ds | %s |\n",
+			status.Kind, status.Name, status.Configuration, status.Target, status.FailurePolicy, status.Timeout, backend)
+	}
+
+	if blocking > 0 || degraded > 0 {
+		result += "\n## Recommended Actions\n\n"
+		if blocking > 0 {
+			result += "1. Webhooks with failurePolicy Fail and a down backend reject every matching create or update; restore the backing deployment first (check its pods with get_recent_restarts_across_cluster)\n"
+			result += "2. If the backend can't be restored quickly, delete the webhook configuration or set its failurePolicy to Ignore as a temporary break-glass measure, and restore it afterwards\n"
+			result += "3. Make sure the webhook's namespaceSelector excludes kube-system and its own namespace, so it can't block its own recovery\n"
+		} else {
+			result += "1. Webhooks with failurePolicy Ignore and a down backend are being skipped: validations aren't enforced and mutations aren't applied, so restore their backends\n"
+		}
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
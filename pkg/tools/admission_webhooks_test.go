@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHandleGetAdmissionWebhookStatus(t *testing.T) {
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/apis/admissionregistration.k8s.io/v1/validatingwebhookconfigurations":
+			w.Write([]byte(`{"items": [
+				{"metadata": {"name": "policy"}, "webhooks": [
+					{"name": "validate.policy.example.com", "clientConfig": {"service": {"namespace": "policy", "name": "policy-webhook"}}},
+					{"name": "audit.policy.example.com", "clientConfig": {"service": {"namespace": "policy", "name": "audit-webhook", "port": 8443}}, "failurePolicy": "Ignore", "timeoutSeconds": 5}
+				]},
+				{"metadata": {"name": "external"}, "webhooks": [
+					{"name": "external.example.com", "clientConfig": {"url": "https://hooks.example.com/validate"}, "failurePolicy": "Fail"}
+				]}
+			]}`))
+		case "/apis/admissionregistration.k8s.io/v1/mutatingwebhookconfigurations":
+			w.Write([]byte(`{"items": [
+				{"metadata": {"name": "istio-sidecar-injector"}, "webhooks": [
+					{"name": "sidecar-injector.istio.io", "clientConfig": {"service": {"namespace": "istio-system", "name": "istiod", "port": 443}}, "failurePolicy": "Fail"}
+				]}
+			]}`))
+		case "/api/v1/namespaces/policy/endpoints/policy-webhook":
+			// The backing deployment is scaled to zero
+			w.Write([]byte(`{"subsets": [{"notReadyAddresses": [{"ip": "10.0.0.9"}]}]}`))
+		case "/api/v1/namespaces/istio-system/endpoints/istiod":
+			w.Write([]byte(`{"subsets": [{"addresses": [{"ip": "10.0.1.1"}, {"ip": "10.0.1.2"}]}]}`))
+		default:
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"kind": "Status", "reason": "NotFound"})
+		}
+	}))
+
+	text := callClusterTool(t, context.Background(), handleGetAdmissionWebhookStatus, clusterName, nil)
+
+	// Blocking webhooks are listed first; unset policy and timeout take the
+	// API defaults
+	for _, s := range []string{
+		"Found 4 webhooks: 1 blocking requests, 1 with a down backend that are being skipped.",
+		"| Validating | validate.policy.example.com | policy | service policy/policy-webhook:443 | Fail | 10s | **no ready endpoints (BLOCKING)** |\n" +
+			"| Validating | audit.policy.example.com | policy | service policy/audit-webhook:8443 | Ignore | 5s | service not found |\n" +
+			"| Validating | external.example.com | external | https://hooks.example.com/validate | Fail | 10s | not checked (URL) |\n" +
+			"| Mutating | sidecar-injector.istio.io | istio-sidecar-injector | service istio-system/istiod:443 | Fail | 10s | 2 ready endpoints |\n",
+		"1. Webhooks with failurePolicy Fail and a down backend reject every matching create or update",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
@@ -0,0 +1,368 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Cloud Audit Logs log IDs
+const (
+	auditLogActivity    = "cloudaudit.googleapis.com/activity"
+	auditLogDataAccess  = "cloudaudit.googleapis.com/data_access"
+	auditLogPolicy      = "cloudaudit.googleapis.com/policy"
+	auditLogSystemEvent = "cloudaudit.googleapis.com/system_event"
+)
+
+// grpcPermissionDenied is the google.rpc.Code for PERMISSION_DENIED
+const grpcPermissionDenied = 7
+
+// registerAuditTools registers tools that analyse Cloud Audit Logs
+func registerAuditTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get audit log access denials tool
+	getAccessDenials := mcp.NewTool("get_audit_log_access_denials",
+		mcp.WithDescription("Lists PERMISSION_DENIED audit log entries grouped by principal, showing the method, resource, and missing permission"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range for audit logs in hours (default: 24)"),
+		),
+	)
+
+	getAccessDenialsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetAuditLogAccessDenials(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getAccessDenials, getAccessDenialsHandler)
+
+	// Register get deleted resources tool
+	getDeletedResources := mcp.NewTool("get_deleted_resources",
+		mcp.WithDescription("Lists resources deleted in a project from Admin Activity audit logs, showing what was deleted, by whom and when"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range for audit logs in hours (default: 24)"),
+		),
+		mcp.WithString("resource_type",
+			mcp.Description("Only include deletions of this monitored resource type (e.g., gce_instance, gke_cluster, gcs_bucket)"),
+		),
+	)
+
+	getDeletedResourcesHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetDeletedResources(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getDeletedResources, getDeletedResourcesHandler)
+
+	if err := registerIAMPolicyChangeTools(s, authHandler); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// auditRecord is the useful content of a Cloud Audit Logs entry
+type auditRecord struct {
+	Timestamp         string
+	Principal         string
+	Service           string
+	Method            string
+	Resource          string
+	ResourceType      string
+	StatusCode        int
+	StatusMessage     string
+	DeniedPermissions []string
+	Request           map[string]interface{}
+	ServiceData       map[string]interface{}
+}
+
+// parseAuditEntry extracts the audit fields from an entry's protoPayload
+func parseAuditEntry(entry logEntry) auditRecord {
+	record := auditRecord{
+		Timestamp:     entry.Timestamp,
+		Principal:     payloadString(entry.ProtoPayload, "authenticationInfo", "principalEmail"),
+		Service:       payloadString(entry.ProtoPayload, "serviceName"),
+		Method:        payloadString(entry.ProtoPayload, "methodName"),
+		Resource:      payloadString(entry.ProtoPayload, "resourceName"),
+		ResourceType:  entry.Resource.Type,
+		StatusMessage: payloadString(entry.ProtoPayload, "status", "message"),
+	}
+
+	if record.Principal == "" {
+		record.Principal = "(unknown principal)"
+	}
+
+	if status, ok := entry.ProtoPayload["status"].(map[string]interface{}); ok {
+		if code, ok := status["code"].(float64); ok {
+			record.StatusCode = int(code)
+		}
+	}
+
+	if authz, ok := entry.ProtoPayload["authorizationInfo"].([]interface{}); ok {
+		for _, item := range authz {
+			info, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if granted, _ := info["granted"].(bool); !granted {
+				if permission, _ := info["permission"].(string); permission != "" {
+					record.DeniedPermissions = append(record.DeniedPermissions, permission)
+				}
+			}
+		}
+	}
+
+	record.Request, _ = entry.ProtoPayload["request"].(map[string]interface{})
+	record.ServiceData, _ = entry.ProtoPayload["serviceData"].(map[string]interface{})
+
+	return record
+}
+
+// fetchAuditLogEntries fetches audit log entries from the given audit logs
+// since startTime, newest first, narrowed by an optional additional filter.
+// Like fetchLogEntries, records collected before an error are returned with it.
+func fetchAuditLogEntries(ctx context.Context, client *http.Client, projectID string, logIDs []string, filter string, startTime time.Time, maxEntries int) ([]auditRecord, bool, error) {
+	logFilters := make([]string, len(logIDs))
+	for i, id := range logIDs {
+		logFilters[i] = fmt.Sprintf(`log_id("%s")`, id)
+	}
+
+	fullFilter := fmt.Sprintf(`(%s) AND timestamp >= "%s"`, strings.Join(logFilters, " OR "), startTime.Format(time.RFC3339))
+	if filter != "" {
+		fullFilter += " AND " + filter
+	}
+
+	entries, nextPageToken, err := fetchLogEntries(ctx, client, projectID, logQuery{
+		Filter:   fullFilter,
+		PageSize: maxEntries,
+	})
+
+	records := make([]auditRecord, len(entries))
+	for i, entry := range entries {
+		records[i] = parseAuditEntry(entry)
+	}
+
+	return records, nextPageToken != "", err
+}
+
+// groupAuditRecordsByPrincipal groups records by principal, with the principals
+// with the most records first
+func groupAuditRecordsByPrincipal(records []auditRecord) ([]string, map[string][]auditRecord) {
+	groups := make(map[string][]auditRecord)
+	for _, record := range records {
+		groups[record.Principal] = append(groups[record.Principal], record)
+	}
+
+	principals := make([]string, 0, len(groups))
+	for principal := range groups {
+		principals = append(principals, principal)
+	}
+
+	sort.Slice(principals, func(i, j int) bool {
+		if len(groups[principals[i]]) != len(groups[principals[j]]) {
+			return len(groups[principals[i]]) > len(groups[principals[j]])
+		}
+		return principals[i] < principals[j]
+	})
+
+	return principals, groups
+}
+
+// handleGetAuditLogAccessDenials handles the get_audit_log_access_denials tool request
+func handleGetAuditLogAccessDenials(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	timeRangeHours := 24.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	startTime := time.Now().Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+
+	records, truncated, err := fetchAuditLogEntries(ctx, client, projectID,
+		[]string{auditLogActivity, auditLogDataAccess, auditLogPolicy},
+		fmt.Sprintf("protoPayload.status.code=%d", grpcPermissionDenied),
+		startTime, 500)
+	cancelled := partialOnCancel(err, len(records))
+	if err != nil && !cancelled {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying audit logs: %v", err)), nil
+	}
+
+	// Format the results
+	if len(records) == 0 {
+		result := fmt.Sprintf("No PERMISSION_DENIED audit log entries found in project %s in the last %.1f hours.", projectID, timeRangeHours)
+		result += "\n\nNote that denied data reads are only recorded if Data Access audit logs are enabled for the service."
+		return mcp.NewToolResultText(result), nil
+	}
+
+	principals, groups := groupAuditRecordsByPrincipal(records)
+
+	result := fmt.Sprintf("# Access Denials in Project %s\n\n", projectID)
+	result += fmt.Sprintf("Found %d denied requests from %d principals in the last %.1f hours.\n\n", len(records), len(principals), timeRangeHours)
+
+	for _, principal := range principals {
+		denials := groups[principal]
+		result += fmt.Sprintf("## %s (%d denials)\n\n", principal, len(denials))
+
+		// Collapse repeated denials of the same method on the same resource
+		type denialKey struct{ method, resource, permissions string }
+		seen := make(map[denialKey]int)
+		var order []denialKey
+		latest := make(map[denialKey]string)
+		for _, denial := range denials {
+			key := denialKey{denial.Method, denial.Resource, strings.Join(denial.DeniedPermissions, ", ")}
+			if _, ok := seen[key]; !ok {
+				order = append(order, key)
+				latest[key] = denial.Timestamp
+			}
+			seen[key]++
+		}
+
+		for _, key := range order {
+			result += fmt.Sprintf("- **%s** on `%s`", key.method, key.resource)
+			if seen[key] > 1 {
+				result += fmt.Sprintf(" (%d times, last %s)", seen[key], formatTime(latest[key]))
+			} else {
+				result += fmt.Sprintf(" (%s)", formatTime(latest[key]))
+			}
+			result += "\n"
+			if key.permissions != "" {
+				result += fmt.Sprintf("  - Missing permission: `%s`\n", key.permissions)
+			}
+		}
+
+		result += "\n"
+	}
+
+	if truncated {
+		result += "Note: Only the most recent 500 denials were analysed. Narrow the time range to see older entries.\n\n"
+	}
+
+	result += "## Recommended Actions\n\n"
+	result += "1. Grant a role containing the missing permission to the principal, at the narrowest resource that works\n"
+	result += "2. For service accounts, check that the workload is running as the account you expect\n"
+	result += "3. Check for recent IAM policy changes that may have removed a binding\n"
+
+	if cancelled {
+		result += cancelledNote
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// deletionMethodFilter matches audit log methods that delete a resource, such
+// as v1.compute.instances.delete or google.container.v1.ClusterManager.DeleteCluster
+const deletionMethodFilter = `protoPayload.methodName=~"\\.(delete|Delete)[A-Za-z]*$"`
+
+// summariseDeletions keeps one record per deleted resource, the most recent.
+// Long-running deletes log both when they start and when they finish.
+// Records are expected newest first, as fetchAuditLogEntries returns them.
+func summariseDeletions(records []auditRecord) []auditRecord {
+	seen := make(map[string]bool)
+	var deletions []auditRecord
+	for _, record := range records {
+		key := record.Method + "|" + record.Resource
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		deletions = append(deletions, record)
+	}
+	return deletions
+}
+
+// handleGetDeletedResources handles the get_deleted_resources tool request
+func handleGetDeletedResources(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	timeRangeHours := 24.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	resourceType, _ := request.Params.Arguments["resource_type"].(string)
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	startTime := time.Now().Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+
+	// Failed deletes carry a non-zero status, and the resource still exists
+	filter := deletionMethodFilter + " AND (NOT protoPayload.status.code:* OR protoPayload.status.code=0)"
+	if resourceType != "" {
+		filter += fmt.Sprintf(` AND resource.type="%s"`, resourceType)
+	}
+
+	records, truncated, err := fetchAuditLogEntries(ctx, client, projectID,
+		[]string{auditLogActivity}, filter, startTime, 500)
+	cancelled := partialOnCancel(err, len(records))
+	if err != nil && !cancelled {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying audit logs: %v", err)), nil
+	}
+
+	scope := "resources"
+	if resourceType != "" {
+		scope = resourceType + " resources"
+	}
+
+	// Format the results
+	deletions := summariseDeletions(records)
+	if len(deletions) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No deleted %s found in project %s in the last %.1f hours.", scope, projectID, timeRangeHours)), nil
+	}
+
+	result := fmt.Sprintf("# Deleted Resources in Project %s\n\n", projectID)
+	result += fmt.Sprintf("Found %d deleted %s in the last %.1f hours, newest first.\n\n", len(deletions), scope, timeRangeHours)
+
+	result += "| Time | Resource | Type | Method | Deleted By |\n"
+	result += "| ---- | -------- | ---- | ------ | ---------- |\n"
+	for _, deletion := range deletions {
+		result += fmt.Sprintf("| %s | `%s` | %s | %s | %s |\n",
+			formatTime(deletion.Timestamp), deletion.Resource, deletion.ResourceType, deletion.Method, deletion.Principal)
+	}
+
+	if truncated {
+		result += "\nNote: Only the most recent 500 audit entries were analysed. Narrow the time range or filter by resource_type to see older deletions.\n"
+	}
+
+	result += "\n## Recommended Actions\n\n"
+	result += "1. Confirm with the principal whether the deletion was intended, and check for automation (Terraform, CI service accounts) acting on stale state\n"
+	result += "2. Some resources can be restored within a window, e.g. undeleting service accounts or projects, or restoring disks from snapshots\n"
+	result += "3. Use IAM deny policies or resource deletion protection to guard critical resources against repeat deletions\n"
+
+	if cancelled {
+		result += cancelledNote
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,106 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestHandleGetAuditLogAccessDenials(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), fakeLogging(t, func(filter string) string {
+		if !strings.Contains(filter, "protoPayload.status.code=7") {
+			t.Errorf("filter doesn't select PERMISSION_DENIED: %s", filter)
+		}
+		denial := func(timestamp, principal, method, resource, permission string) string {
+			return `{"timestamp": "` + timestamp + `", "protoPayload": {
+				"authenticationInfo": {"principalEmail": "` + principal + `"},
+				"methodName": "` + method + `", "resourceName": "` + resource + `",
+				"status": {"code": 7, "message": "PERMISSION_DENIED"},
+				"authorizationInfo": [{"permission": "` + permission + `", "granted": false}, {"permission": "resourcemanager.projects.get", "granted": true}]
+			}}`
+		}
+		return "[" + strings.Join([]string{
+			denial("2026-10-17T01:03:00Z", "ci@test-project.iam.gserviceaccount.com", "storage.objects.create", "projects/_/buckets/artifacts", "storage.objects.create"),
+			denial("2026-10-17T01:02:00Z", "ci@test-project.iam.gserviceaccount.com", "storage.objects.create", "projects/_/buckets/artifacts", "storage.objects.create"),
+			denial("2026-10-17T01:01:00Z", "dev@example.com", "v1.compute.instances.delete", "projects/test-project/zones/us-central1-a/instances/web-1", "compute.instances.delete"),
+		}, ",") + "]"
+	}))
+
+	text := callTool(t, ctx, handleGetAuditLogAccessDenials, map[string]interface{}{"project_id": "test-project"})
+
+	// Principals with the most denials come first, and repeated denials are
+	// collapsed with only the denied permission reported
+	ci, dev := strings.Index(text, "## ci@test-project.iam.gserviceaccount.com (2 denials)"), strings.Index(text, "## dev@example.com (1 denials)")
+	if ci < 0 || dev < ci {
+		t.Errorf("principals aren't grouped by denial count:\n%s", text)
+	}
+	for _, s := range []string{
+		"Found 3 denied requests from 2 principals",
+		"- **storage.objects.create** on `projects/_/buckets/artifacts` (2 times, last 2026-10-17 01:03:00)\n  - Missing permission: `storage.objects.create`\n",
+		"  - Missing permission: `compute.instances.delete`\n",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if strings.Contains(text, "resourcemanager.projects.get") {
+		t.Errorf("result reports a granted permission as missing:\n%s", text)
+	}
+}
+
+func TestSummariseDeletions(t *testing.T) {
+	records := []auditRecord{
+		{Timestamp: "2026-10-17T01:05:00Z", Method: "google.container.v1.ClusterManager.DeleteCluster", Resource: "projects/p/locations/us-central1/clusters/old"},
+		{Timestamp: "2026-10-17T01:04:00Z", Method: "v1.compute.disks.delete", Resource: "projects/p/zones/us-central1-a/disks/data"},
+		// The start of the long-running cluster delete
+		{Timestamp: "2026-10-17T01:00:00Z", Method: "google.container.v1.ClusterManager.DeleteCluster", Resource: "projects/p/locations/us-central1/clusters/old"},
+		// A different method on the same resource is kept
+		{Timestamp: "2026-10-17T00:59:00Z", Method: "v1.compute.disks.deleteSnapshotSchedule", Resource: "projects/p/zones/us-central1-a/disks/data"},
+	}
+
+	got := summariseDeletions(records)
+	want := []string{"01:05", "01:04", "00:59"}
+	if len(got) != len(want) {
+		t.Fatalf("summariseDeletions kept %d records, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if !strings.Contains(got[i].Timestamp, want[i]) {
+			t.Errorf("deletion %d = %s, want the record from %s", i, got[i].Timestamp, want[i])
+		}
+	}
+}
+
+func TestHandleGetDeletedResources(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), fakeLogging(t, func(filter string) string {
+		for _, s := range []string{deletionMethodFilter, "protoPayload.status.code=0", `resource.type="gce_disk"`} {
+			if !strings.Contains(filter, s) {
+				t.Errorf("filter doesn't contain %s: %s", s, filter)
+			}
+		}
+		deletion := func(timestamp, principal, resource string) string {
+			return `{"timestamp": "` + timestamp + `", "resource": {"type": "gce_disk"}, "protoPayload": {
+				"authenticationInfo": {"principalEmail": "` + principal + `"},
+				"methodName": "v1.compute.disks.delete", "resourceName": "` + resource + `"
+			}}`
+		}
+		return "[" + strings.Join([]string{
+			deletion("2026-10-17T01:05:00Z", "terraform@test-project.iam.gserviceaccount.com", "projects/test-project/zones/us-central1-a/disks/data"),
+			deletion("2026-10-17T01:04:00Z", "terraform@test-project.iam.gserviceaccount.com", "projects/test-project/zones/us-central1-a/disks/data"),
+			deletion("2026-10-17T01:01:00Z", "dev@example.com", "projects/test-project/zones/us-central1-a/disks/scratch"),
+		}, ",") + "]"
+	}))
+
+	text := callTool(t, ctx, handleGetDeletedResources, map[string]interface{}{
+		"project_id":    "test-project",
+		"resource_type": "gce_disk",
+	})
+
+	want := "| 2026-10-17 01:05:00 | `projects/test-project/zones/us-central1-a/disks/data` | gce_disk | v1.compute.disks.delete | terraform@test-project.iam.gserviceaccount.com |\n" +
+		"| 2026-10-17 01:01:00 | `projects/test-project/zones/us-central1-a/disks/scratch` | gce_disk | v1.compute.disks.delete | dev@example.com |\n"
+	if !strings.Contains(text, want) {
+		t.Errorf("result doesn't contain %q:\n%s", want, text)
+	}
+	if !strings.Contains(text, "Found 2 deleted gce_disk resources") {
+		t.Errorf("result doesn't count each deleted resource once:\n%s", text)
+	}
+}
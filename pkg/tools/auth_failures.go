@@ -0,0 +1,240 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxAuthFailureEntries caps the request log entries analysed per call
+const maxAuthFailureEntries = 1000
+
+// registerAuthFailureTools registers tools that find application auth failures
+// in request logs
+func registerAuthFailureTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get recent 401/403 from app logs tool
+	getAuthFailures := mcp.NewTool("get_recent_401_403_from_app_logs",
+		mcp.WithDescription("Finds HTTP 401 and 403 responses in request logs (load balancers, Cloud Run, App Engine and structured application logs), grouped by path and status with counts and recent examples. Application-level auth failures like these often signal an expired or rotated token or key, unlike IAM denials, which are in audit logs."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("service",
+			mcp.Description("Only include requests to this service: a Cloud Run service, App Engine service, load balancer backend service or container name"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range for logs in hours (default: 1)"),
+		),
+	)
+
+	getAuthFailuresHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetRecent401403FromAppLogs(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getAuthFailures, getAuthFailuresHandler)
+
+	return nil
+}
+
+// authFailureExample is a single 401 or 403 request
+type authFailureExample struct {
+	Timestamp string
+	Method    string
+	URL       string
+	RemoteIP  string
+	UserAgent string
+	Resource  string
+}
+
+// authFailureGroup is the 401 or 403 responses for a path
+type authFailureGroup struct {
+	Path   string
+	Status int
+	Count  int
+	// Examples are the most recent requests, newest first
+	Examples []authFailureExample
+}
+
+// maxAuthFailureExamples is the number of example requests kept per group
+const maxAuthFailureExamples = 3
+
+// requestPath returns the path of a request URL without its query string, so
+// requests with different parameters are grouped together
+func requestPath(requestURL string) string {
+	parsed, err := url.Parse(requestURL)
+	if err != nil || parsed.Path == "" {
+		if requestURL == "" {
+			return "(unknown)"
+		}
+		return requestURL
+	}
+	return parsed.Path
+}
+
+// groupAuthFailures groups 401 and 403 request log entries by path and
+// status, most frequent first. Entries are expected newest first, as
+// fetchLogEntries returns them, and other statuses are ignored.
+func groupAuthFailures(entries []logEntry) []authFailureGroup {
+	type groupKey struct {
+		path   string
+		status int
+	}
+	groups := make(map[groupKey]*authFailureGroup)
+	var order []groupKey
+
+	for _, entry := range entries {
+		if entry.HttpRequest == nil {
+			continue
+		}
+		status := entry.HttpRequest.Status
+		if status != 401 && status != 403 {
+			continue
+		}
+
+		key := groupKey{requestPath(entry.HttpRequest.RequestUrl), status}
+		group, ok := groups[key]
+		if !ok {
+			group = &authFailureGroup{Path: key.path, Status: status}
+			groups[key] = group
+			order = append(order, key)
+		}
+
+		group.Count++
+		if len(group.Examples) < maxAuthFailureExamples {
+			resource := entry.Resource.Type
+			if name := entry.Resource.Labels["service_name"]; name != "" {
+				resource += "/" + name
+			} else if name := entry.Resource.Labels["backend_service_name"]; name != "" {
+				resource += "/" + name
+			}
+			group.Examples = append(group.Examples, authFailureExample{
+				Timestamp: entry.Timestamp,
+				Method:    entry.HttpRequest.RequestMethod,
+				URL:       entry.HttpRequest.RequestUrl,
+				RemoteIP:  entry.HttpRequest.RemoteIp,
+				UserAgent: entry.HttpRequest.UserAgent,
+				Resource:  resource,
+			})
+		}
+	}
+
+	result := make([]authFailureGroup, 0, len(order))
+	for _, key := range order {
+		result = append(result, *groups[key])
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Count > result[j].Count
+	})
+
+	return result
+}
+
+// handleGetRecent401403FromAppLogs handles the get_recent_401_403_from_app_logs tool request
+func handleGetRecent401403FromAppLogs(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	service, _ := request.Params.Arguments["service"].(string)
+
+	// Get optional parameters with defaults
+	timeRangeHours := 1.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	startTime := time.Now().Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+
+	serviceFilter := ""
+	if service != "" {
+		serviceFilter = fmt.Sprintf(`resource.labels.service_name=%[1]q OR resource.labels.backend_service_name=%[1]q OR resource.labels.module_id=%[1]q OR resource.labels.container_name=%[1]q`, service)
+	}
+
+	entries, nextPageToken, err := fetchLogEntries(ctx, client, projectID, logQuery{
+		Filter: combineLogFilters(
+			`httpRequest.status=401 OR httpRequest.status=403`,
+			fmt.Sprintf(`timestamp >= "%s"`, startTime.Format(time.RFC3339)),
+			serviceFilter,
+		),
+		PageSize: maxAuthFailureEntries,
+	})
+	cancelled := partialOnCancel(err, len(entries))
+	if err != nil && !cancelled {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying logs: %v", err)), nil
+	}
+
+	groups := groupAuthFailures(entries)
+
+	// Format the results
+	scope := "project " + projectID
+	if service != "" {
+		scope = fmt.Sprintf("service %s in project %s", service, projectID)
+	}
+
+	if len(groups) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No 401 or 403 responses found in request logs for %s in the last %.1f hours.", scope, timeRangeHours)), nil
+	}
+
+	unauthorized, forbidden := 0, 0
+	for _, group := range groups {
+		if group.Status == 401 {
+			unauthorized += group.Count
+		} else {
+			forbidden += group.Count
+		}
+	}
+
+	result := fmt.Sprintf("# 401/403 Responses for %s\n\n", scope)
+	result += fmt.Sprintf("Found %d 401 (unauthenticated) and %d 403 (forbidden) responses across %d paths in the last %.1f hours.\n\n", unauthorized, forbidden, len(groups), timeRangeHours)
+
+	result += "| Status | Path | Count | Last Seen |\n"
+	result += "| ------ | ---- | ----- | --------- |\n"
+	for _, group := range groups {
+		result += fmt.Sprintf("| %d | %s | %d | %s |\n", group.Status, group.Path, group.Count, formatTime(group.Examples[0].Timestamp))
+	}
+
+	shown := groups
+	if len(shown) > 10 {
+		shown = shown[:10]
+	}
+	result += "\n## Recent Examples\n\n"
+	for _, group := range shown {
+		result += fmt.Sprintf("### %d %s\n\n", group.Status, group.Path)
+		for _, example := range group.Examples {
+			result += fmt.Sprintf("- %s %s %s from %s (%s), user agent %q\n",
+				formatTime(example.Timestamp), example.Method, example.URL, valueOrDash(example.RemoteIP), example.Resource, example.UserAgent)
+		}
+		result += "\n"
+	}
+
+	if nextPageToken != "" {
+		result += fmt.Sprintf("Note: Only the most recent %d responses were analysed. Narrow the time range or service to see older entries.\n\n", maxAuthFailureEntries)
+	}
+
+	result += "## Recommended Actions\n\n"
+	result += "1. A sudden rise in 401s across many paths usually means a credential expired or was rotated: check recent secret or key changes and whether clients picked up the new value\n"
+	result += "2. 401s or 403s from a single remote IP or user agent point to one misconfigured client or a scanner rather than an outage\n"
+	result += "3. 403s on specific paths can be authorisation rules or a Cloud Armor policy; check recent policy or deployment changes\n"
+	result += "4. For GCP IAM denials rather than application ones, use get_audit_log_access_denials\n"
+
+	if cancelled {
+		result += cancelledNote
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,76 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// requestLog is a request log entry from a Cloud Run service
+func requestLog(timestamp string, status int, requestURL string) string {
+	return fmt.Sprintf(`{"timestamp": %q, "resource": {"type": "cloud_run_revision", "labels": {"service_name": "api"}},
+		"httpRequest": {"requestMethod": "GET", "requestUrl": %q, "status": %d, "remoteIp": "203.0.113.7", "userAgent": "client/1.0"}}`,
+		timestamp, requestURL, status)
+}
+
+func TestRequestPath(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://api.example.com/v1/orders?page=2", want: "/v1/orders"},
+		{url: "/healthz", want: "/healthz"},
+		{url: "", want: "(unknown)"},
+		{url: "https://api.example.com", want: "https://api.example.com"},
+	}
+
+	for _, tt := range tests {
+		if got := requestPath(tt.url); got != tt.want {
+			t.Errorf("requestPath(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestHandleGetRecent401403FromAppLogs(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), fakeLogging(t, func(filter string) string {
+		for _, s := range []string{"httpRequest.status=401 OR httpRequest.status=403", `resource.labels.service_name="api"`} {
+			if !strings.Contains(filter, s) {
+				t.Errorf("filter doesn't contain %s: %s", s, filter)
+			}
+		}
+		// Newest first, with a 500 the filter would normally exclude
+		return "[" + strings.Join([]string{
+			requestLog("2026-10-17T01:05:00Z", 401, "https://api.example.com/v1/orders?page=2"),
+			requestLog("2026-10-17T01:04:00Z", 403, "https://api.example.com/admin"),
+			requestLog("2026-10-17T01:03:00Z", 500, "https://api.example.com/v1/orders"),
+			requestLog("2026-10-17T01:02:00Z", 401, "https://api.example.com/v1/orders"),
+			requestLog("2026-10-17T01:01:00Z", 403, "https://api.example.com/v1/orders"),
+			requestLog("2026-10-17T01:00:00Z", 401, "https://api.example.com/v1/orders?page=1"),
+		}, ",") + "]"
+	}))
+
+	text := callTool(t, ctx, handleGetRecent401403FromAppLogs, map[string]interface{}{
+		"project_id": "test-project",
+		"service":    "api",
+	})
+
+	// Requests are grouped by path without the query string, and by status
+	for _, s := range []string{
+		"Found 3 401 (unauthenticated) and 2 403 (forbidden) responses across 3 paths in the last 1.0 hours.",
+		"| 401 | /v1/orders | 3 | 2026-10-17 01:05:00 |\n" +
+			"| 403 | /admin | 1 | 2026-10-17 01:04:00 |\n" +
+			"| 403 | /v1/orders | 1 | 2026-10-17 01:01:00 |\n",
+		"### 401 /v1/orders\n\n" +
+			"- 2026-10-17 01:05:00 GET https://api.example.com/v1/orders?page=2 from 203.0.113.7 (cloud_run_revision/api), user agent \"client/1.0\"\n" +
+			"- 2026-10-17 01:02:00 GET https://api.example.com/v1/orders from 203.0.113.7 (cloud_run_revision/api), user agent \"client/1.0\"\n" +
+			"- 2026-10-17 01:00:00 GET https://api.example.com/v1/orders?page=1 from",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if strings.Contains(text, "| 500 |") {
+		t.Errorf("result includes a 500 response:\n%s", text)
+	}
+}
@@ -0,0 +1,182 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"golang.org/x/oauth2"
+)
+
+// googleTokenInfoURL is the endpoint that describes an access token
+const googleTokenInfoURL = "https://oauth2.googleapis.com/tokeninfo"
+
+// registerAuthTools registers tools that report on the server's credentials
+func registerAuthTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get auth status tool
+	getAuthStatus := mcp.NewTool("get_auth_status",
+		mcp.WithDescription("Reports the server's current permission mode (read-only or read-write), the scopes it requests and the scopes its token was actually granted, the credential source in use and the authenticated principal. Use it to explain permission failures, such as a remediation tool failing while the server is read-only."),
+	)
+
+	getAuthStatusHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetAuthStatus(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getAuthStatus, getAuthStatusHandler)
+
+	return nil
+}
+
+// tokenInfo is the subset of a tokeninfo response used by the tools
+type tokenInfo struct {
+	Email     string `json:"email"`
+	Azp       string `json:"azp"`
+	Scope     string `json:"scope"`
+	ExpiresIn string `json:"expires_in"`
+}
+
+// principal returns the identity the token was issued to: its email when
+// the token carries one, or otherwise the authorised party's client ID
+func (t tokenInfo) principal() string {
+	if t.Email != "" {
+		return t.Email
+	}
+	if t.Azp != "" {
+		return "client " + t.Azp
+	}
+	return "unknown"
+}
+
+// fetchTokenInfo asks Google to describe an access token. The token is sent
+// in the request body rather than the URL so it is never recorded with
+// failed request URLs.
+func fetchTokenInfo(ctx context.Context, accessToken string) (*tokenInfo, error) {
+	form := url.Values{"access_token": {accessToken}}
+	req, err := http.NewRequestWithContext(ctx, "POST", googleTokenInfoURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	// The request carries the token itself, so it uses the context's base
+	// client rather than an authenticated one
+	resp, err := doWithRetry(oauth2.NewClient(ctx, nil), markIdempotent(req))
+	if err != nil {
+		return nil, fmt.Errorf("error making request to tokeninfo: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error from tokeninfo: %s", resp.Status)
+	}
+
+	var info tokenInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("error parsing response: %w", err)
+	}
+
+	return &info, nil
+}
+
+// permissionMode names the permission level of a handler
+func permissionMode(readWrite bool) string {
+	if readWrite {
+		return "read-write"
+	}
+	return "read-only"
+}
+
+// missingScopes returns the requested scopes that weren't granted
+func missingScopes(requested, granted []string) []string {
+	var missing []string
+	for _, scope := range requested {
+		if !containsString(granted, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// handleGetAuthStatus handles the get_auth_status tool request
+func handleGetAuthStatus(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	requested := authHandler.Scopes()
+	mode := permissionMode(authHandler.ReadWrite())
+
+	// Look up the token's principal and granted scopes. Failing to get a
+	// token is reported rather than returned, as it is often the answer.
+	var info *tokenInfo
+	var tokenErr error
+	ts, err := authHandler.TokenSource(ctx)
+	if err == nil {
+		token, err := ts.Token()
+		if err == nil {
+			info, tokenErr = fetchTokenInfo(ctx, token.AccessToken)
+		} else {
+			tokenErr = fmt.Errorf("error getting access token: %w", err)
+		}
+	} else {
+		tokenErr = err
+	}
+
+	// Format the results
+	result := "# Auth Status\n\n"
+	result += fmt.Sprintf("- **Mode**: %s\n", mode)
+	result += fmt.Sprintf("- **Credential source**: %s\n", authHandler.CredentialSource())
+	if info != nil {
+		result += fmt.Sprintf("- **Principal**: %s\n", info.principal())
+		if info.ExpiresIn != "" {
+			result += fmt.Sprintf("- **Token expires in**: %s seconds\n", info.ExpiresIn)
+		}
+	} else {
+		result += fmt.Sprintf("- **Principal**: unknown (%v)\n", tokenErr)
+	}
+
+	result += "\n## Requested Scopes\n\n"
+	for _, scope := range requested {
+		result += fmt.Sprintf("- %s\n", scope)
+	}
+
+	var missing []string
+	if info != nil {
+		granted := strings.Fields(info.Scope)
+		if len(granted) > 0 {
+			missing = missingScopes(requested, granted)
+		}
+
+		result += "\n## Granted Scopes\n\n"
+		if len(granted) == 0 {
+			result += "The token didn't report its scopes.\n"
+		}
+		for _, scope := range granted {
+			result += fmt.Sprintf("- %s\n", scope)
+		}
+
+		if len(missing) > 0 {
+			result += "\n**Requested but not granted:**\n\n"
+			for _, scope := range missing {
+				result += fmt.Sprintf("- %s\n", scope)
+			}
+		}
+	}
+
+	result += "\n## Recommended Actions\n\n"
+	if mode == "read-only" {
+		result += "1. The server is read-only, so write operations fail; restart it with -scopes=read-write to allow them\n"
+	} else {
+		result += "1. The server holds read-write scopes, so a failing write is more likely an IAM role missing from the principal than a scope\n"
+	}
+	result += "2. Scopes only cap what a token may do; the principal also needs IAM roles granting the permission, and get_audit_log_access_denials shows which permissions were denied\n"
+	if tokenErr != nil {
+		result += "3. The token couldn't be looked up; check the credential source above is configured and, for an OAuth client, that sign-in completed\n"
+	} else if len(missing) > 0 {
+		result += "3. Some requested scopes weren't granted; re-authenticate, or for a service account check the scopes allowed for its credentials\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,110 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+)
+
+func TestHandleGetAuthStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		scopes  []string
+		status  int
+		granted []string
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "read-only",
+			scopes:  auth.ReadOnlyScopes,
+			status:  http.StatusOK,
+			granted: auth.ReadOnlyScopes,
+			want: []string{
+				"- **Mode**: read-only",
+				"- **Credential source**: OAuth client ID and secret (GOOGLE_CLIENT_ID)",
+				"- **Principal**: sre@example.com",
+				"- **Token expires in**: 3599 seconds",
+				"restart it with -scopes=read-write",
+			},
+			notWant: []string{"Requested but not granted"},
+		},
+		{
+			name:    "read-write missing a scope",
+			scopes:  auth.ReadWriteScopes,
+			status:  http.StatusOK,
+			granted: auth.ReadWriteScopes[1:],
+			want: []string{
+				"- **Mode**: read-write",
+				"**Requested but not granted:**\n\n- " + auth.ReadWriteScopes[0] + "\n",
+				"3. Some requested scopes weren't granted",
+			},
+		},
+		{
+			name:   "token lookup fails",
+			scopes: auth.ReadOnlyScopes,
+			status: http.StatusBadRequest,
+			want: []string{
+				"- **Principal**: unknown (error from tokeninfo: 400 Bad Request)",
+				"3. The token couldn't be looked up",
+			},
+			notWant: []string{"## Granted Scopes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.String() != googleTokenInfoURL {
+					t.Errorf("unexpected request to %s", r.URL)
+				}
+				// The token is only ever sent in the body
+				if got := r.FormValue("access_token"); got != "test-token" || r.URL.Query().Has("access_token") {
+					t.Errorf("token sent as %q in the body of %s", got, r.URL)
+				}
+				writeJSON(w, tt.status, map[string]string{
+					"email":      "sre@example.com",
+					"scope":      strings.Join(tt.granted, " "),
+					"expires_in": "3599",
+				})
+			}))
+
+			result, err := handleGetAuthStatus(ctx, newToolRequest(nil), newTestAuthHandler(t, tt.scopes))
+			if err != nil {
+				t.Fatalf("handleGetAuthStatus returned error: %v", err)
+			}
+			text, _ := resultText(result)
+
+			for _, s := range tt.want {
+				if !strings.Contains(text, s) {
+					t.Errorf("result doesn't contain %q:\n%s", s, text)
+				}
+			}
+			for _, s := range tt.notWant {
+				if strings.Contains(text, s) {
+					t.Errorf("result contains %q:\n%s", s, text)
+				}
+			}
+		})
+	}
+}
+
+func TestTokenInfoPrincipal(t *testing.T) {
+	tests := []struct {
+		info tokenInfo
+		want string
+	}{
+		{info: tokenInfo{Email: "sre@example.com", Azp: "123"}, want: "sre@example.com"},
+		{info: tokenInfo{Azp: "123.apps.googleusercontent.com"}, want: "client 123.apps.googleusercontent.com"},
+		{info: tokenInfo{}, want: "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.info.principal(); got != tt.want {
+			t.Errorf("principal of %+v = %q, want %q", tt.info, got, tt.want)
+		}
+	}
+}
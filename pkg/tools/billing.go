@@ -0,0 +1,710 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// GCP BigQuery API base URL
+	gcpBigQueryBaseURL = "https://bigquery.googleapis.com/bigquery/v2"
+
+	// billingExportMaxLag is how far behind a billing export can normally be;
+	// GCP documents exports as lagging by up to a day or more
+	billingExportMaxLag = 48 * time.Hour
+)
+
+// registerBillingTools registers all billing related tools
+func registerBillingTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get billing export freshness tool
+	getExportFreshness := mcp.NewTool("get_billing_export_freshness",
+		mcp.WithDescription("Checks how recent the data in a BigQuery Cloud Billing export is, flagging exports lagging more than 48 hours, which means the export pipeline is broken and cost analysis is working from stale data"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project that holds the billing export dataset"),
+		),
+		mcp.WithString("dataset",
+			mcp.Description("The billing export dataset (default: OPERABLE_BILLING_EXPORT_DATASET)"),
+		),
+	)
+
+	getExportFreshnessHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetBillingExportFreshness(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getExportFreshness, getExportFreshnessHandler)
+
+	// Register get GKE cost breakdown tool
+	getGKECostBreakdown := mcp.NewTool("get_gke_cost_breakdown",
+		mcp.WithDescription("Reports a GKE cluster's cost by namespace and workload from the detailed BigQuery billing export with GKE cost allocation, highlighting the biggest spenders during a cost incident"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID of the cluster"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithString("billing_project_id",
+			mcp.Description("The project that holds the billing export dataset (default: project_id)"),
+		),
+		mcp.WithString("dataset",
+			mcp.Description("The billing export dataset (default: OPERABLE_BILLING_EXPORT_DATASET)"),
+		),
+		mcp.WithNumber("time_range_days",
+			mcp.Description("Time range for costs in days (default: 7)"),
+		),
+	)
+
+	getGKECostBreakdownHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetGKECostBreakdown(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getGKECostBreakdown, getGKECostBreakdownHandler)
+
+	// Register get billing unattributed costs tool
+	getUnattributedCosts := mcp.NewTool("get_billing_unattributed_costs",
+		mcp.WithDescription("Reports how much spend in the BigQuery billing export lacks required labels (such as team or cost-center), broken down by service and by missing label, to find unattributed spend and rogue resources during a cost incident"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project that holds the billing export dataset"),
+		),
+		mcp.WithString("required_labels",
+			mcp.Required(),
+			mcp.Description("Comma-separated label keys every cost must carry, such as team,cost-center. A label counts if it is on the resource or its project."),
+		),
+		mcp.WithString("dataset",
+			mcp.Description("The billing export dataset (default: OPERABLE_BILLING_EXPORT_DATASET)"),
+		),
+		mcp.WithNumber("time_range_days",
+			mcp.Description("Time range for costs in days (default: 7)"),
+		),
+	)
+
+	getUnattributedCostsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetBillingUnattributedCosts(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getUnattributedCosts, getUnattributedCostsHandler)
+
+	return nil
+}
+
+// bigQueryIdentifierPattern matches dataset names that are safe to embed in SQL
+var bigQueryIdentifierPattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// gcpNamePattern matches project IDs, locations and cluster names that are
+// safe to embed in SQL string literals
+var gcpNamePattern = regexp.MustCompile(`^[a-z0-9.:-]+$`)
+
+// labelKeyPattern matches GCP label keys, which are safe to embed in SQL
+// string literals
+var labelKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// billingDataset returns the billing export dataset from the request, falling
+// back to OPERABLE_BILLING_EXPORT_DATASET
+func billingDataset(request mcp.CallToolRequest) (string, error) {
+	dataset, _ := request.Params.Arguments["dataset"].(string)
+	if dataset == "" {
+		dataset = os.Getenv("OPERABLE_BILLING_EXPORT_DATASET")
+	}
+	if dataset == "" {
+		return "", fmt.Errorf("dataset must be provided or OPERABLE_BILLING_EXPORT_DATASET set")
+	}
+	if !bigQueryIdentifierPattern.MatchString(dataset) {
+		return "", fmt.Errorf("dataset %q must contain only letters, numbers and underscores", dataset)
+	}
+	return dataset, nil
+}
+
+// runBigQuery runs a standard SQL query in the project and returns its rows as
+// maps of column name to value, with NULLs as empty strings. Queries must
+// complete within the request timeout, so they should be small aggregates.
+func runBigQuery(ctx context.Context, client *http.Client, projectID, query string) ([]map[string]string, error) {
+	requestBody, err := json.Marshal(map[string]interface{}{
+		"query":        query,
+		"useLegacySql": false,
+		"timeoutMs":    30000,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling request body: %w", err)
+	}
+
+	apiURL := fmt.Sprintf("%s/projects/%s/queries", gcpBigQueryBaseURL, projectID)
+	// The query may take up to timeoutMs before the API responds
+	ctx = withRequestTimeout(ctx, 45*time.Second)
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Queries are read-only, so the POST is safe to retry
+	req = markIdempotent(req)
+
+	resp, err := doWithRetry(client, req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to BigQuery API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error from BigQuery API: %s", resp.Status)
+	}
+
+	var response struct {
+		JobComplete bool `json:"jobComplete"`
+		Schema      struct {
+			Fields []struct {
+				Name string `json:"name"`
+			} `json:"fields"`
+		} `json:"schema"`
+		Rows []struct {
+			F []struct {
+				V interface{} `json:"v"`
+			} `json:"f"`
+		} `json:"rows"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("error parsing response: %w", err)
+	}
+
+	if !response.JobComplete {
+		return nil, fmt.Errorf("query did not complete within 30 seconds")
+	}
+
+	rows := make([]map[string]string, 0, len(response.Rows))
+	for _, r := range response.Rows {
+		row := make(map[string]string, len(r.F))
+		for i, cell := range r.F {
+			if i >= len(response.Schema.Fields) {
+				break
+			}
+			if v, ok := cell.V.(string); ok {
+				row[response.Schema.Fields[i].Name] = v
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// parseBigQueryTimestamp parses a TIMESTAMP value as returned by the BigQuery
+// REST API, which encodes it as floating point seconds since the epoch
+func parseBigQueryTimestamp(v string) (time.Time, error) {
+	seconds, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q", v)
+	}
+	return time.Unix(0, int64(seconds*float64(time.Second))).UTC(), nil
+}
+
+// billingExportTable is the freshness of one billing export table
+type billingExportTable struct {
+	Table      string
+	LastExport time.Time
+	Lag        time.Duration
+	Stale      bool
+}
+
+// billingExportFreshness evaluates the latest export_time of each export
+// table, listing the stalest first
+func billingExportFreshness(rows []map[string]string, now time.Time) []billingExportTable {
+	var tables []billingExportTable
+	for _, row := range rows {
+		table := billingExportTable{Table: row["table_name"], Stale: true}
+		if last, err := parseBigQueryTimestamp(row["last_export"]); err == nil {
+			table.LastExport = last
+			table.Lag = now.Sub(last)
+			table.Stale = table.Lag > billingExportMaxLag
+		}
+		tables = append(tables, table)
+	}
+
+	sort.Slice(tables, func(i, j int) bool {
+		if tables[i].LastExport.Equal(tables[j].LastExport) {
+			return tables[i].Table < tables[j].Table
+		}
+		return tables[i].LastExport.Before(tables[j].LastExport)
+	})
+
+	return tables
+}
+
+// handleGetBillingExportFreshness handles the get_billing_export_freshness tool request
+func handleGetBillingExportFreshness(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	dataset, err := billingDataset(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	// Export tables are partitioned by day, so only scan recent partitions.
+	// Joining against the table list keeps tables with no recent data.
+	query := fmt.Sprintf("SELECT t.table_name, f.last_export FROM `%[1]s.%[2]s.INFORMATION_SCHEMA.TABLES` t "+
+		"LEFT JOIN (SELECT CONCAT('gcp_billing_export_', _TABLE_SUFFIX) AS table_name, MAX(export_time) AS last_export "+
+		"FROM `%[1]s.%[2]s.gcp_billing_export_*` WHERE DATE(_PARTITIONTIME) >= DATE_SUB(CURRENT_DATE(), INTERVAL 7 DAY) GROUP BY table_name) f "+
+		"USING (table_name) WHERE STARTS_WITH(t.table_name, 'gcp_billing_export_')", projectID, dataset)
+
+	rows, err := runBigQuery(ctx, client, projectID, query)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying billing export: %v", err)), nil
+	}
+
+	if len(rows) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No billing export tables (gcp_billing_export_*) were found in dataset %s.%s. Check that Cloud Billing export to BigQuery is enabled and targets this dataset.", projectID, dataset)), nil
+	}
+
+	tables := billingExportFreshness(rows, time.Now())
+
+	stale := 0
+	for _, table := range tables {
+		if table.Stale {
+			stale++
+		}
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# Billing Export Freshness for %s.%s\n\n", projectID, dataset)
+	if stale > 0 {
+		result += fmt.Sprintf("**%d of %d export tables are more than %.0f hours behind.** Cost data from them is stale and analysis based on it will be incomplete.\n\n", stale, len(tables), billingExportMaxLag.Hours())
+	} else {
+		result += fmt.Sprintf("All %d export tables have data from the last %.0f hours.\n\n", len(tables), billingExportMaxLag.Hours())
+	}
+
+	result += "| Table | Last Export | Lag | Status |\n"
+	result += "| ----- | ----------- | --- | ------ |\n"
+	for _, table := range tables {
+		if table.LastExport.IsZero() {
+			result += fmt.Sprintf("| %s | none in the last 7 days | - | **Stale** |\n", table.Table)
+			continue
+		}
+		status := "OK"
+		if table.Stale {
+			status = "**Stale**"
+		}
+		result += fmt.Sprintf("| %s | %s | %s | %s |\n", table.Table, table.LastExport.Format("2006-01-02 15:04:05"), table.Lag.Round(time.Minute), status)
+	}
+
+	if stale > 0 {
+		result += "\n## Recommended Actions\n\n"
+		result += "1. Check the billing export settings in the Cloud Billing console; exports stop if the dataset is deleted or the export is disabled\n"
+		result += "2. Check that the Cloud Billing service still has permission to write to the dataset\n"
+		result += "3. Exports can't be backfilled beyond the start of the previous month, so fix a broken export promptly\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// gkeCostItem is the cost of a namespace or workload
+type gkeCostItem struct {
+	Namespace    string
+	Workload     string
+	WorkloadType string
+	Cost         float64
+}
+
+// gkeCostBreakdown is a cluster's cost split by namespace and workload
+type gkeCostBreakdown struct {
+	Total      float64
+	Currency   string
+	Allocated  float64
+	Namespaces []gkeCostItem
+	Workloads  []gkeCostItem
+}
+
+// summariseGKECosts aggregates cost rows grouped by namespace and workload.
+// Rows without a namespace are cluster costs that cost allocation couldn't
+// attribute, or all costs when cost allocation isn't enabled.
+func summariseGKECosts(rows []map[string]string) gkeCostBreakdown {
+	var breakdown gkeCostBreakdown
+	byNamespace := make(map[string]float64)
+	for _, row := range rows {
+		cost, err := strconv.ParseFloat(row["cost"], 64)
+		if err != nil {
+			continue
+		}
+		breakdown.Total += cost
+		if breakdown.Currency == "" {
+			breakdown.Currency = row["currency"]
+		}
+
+		namespace := row["namespace"]
+		if namespace == "" {
+			continue
+		}
+		breakdown.Allocated += cost
+		byNamespace[namespace] += cost
+
+		if row["workload"] != "" {
+			breakdown.Workloads = append(breakdown.Workloads, gkeCostItem{
+				Namespace:    namespace,
+				Workload:     row["workload"],
+				WorkloadType: row["workload_type"],
+				Cost:         cost,
+			})
+		}
+	}
+
+	for namespace, cost := range byNamespace {
+		breakdown.Namespaces = append(breakdown.Namespaces, gkeCostItem{Namespace: namespace, Cost: cost})
+	}
+	sort.Slice(breakdown.Namespaces, func(i, j int) bool {
+		return breakdown.Namespaces[i].Cost > breakdown.Namespaces[j].Cost
+	})
+	sort.SliceStable(breakdown.Workloads, func(i, j int) bool {
+		return breakdown.Workloads[i].Cost > breakdown.Workloads[j].Cost
+	})
+
+	return breakdown
+}
+
+// handleGetGKECostBreakdown handles the get_gke_cost_breakdown tool request
+func handleGetGKECostBreakdown(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	billingProjectID, _ := request.Params.Arguments["billing_project_id"].(string)
+	if billingProjectID == "" {
+		billingProjectID = projectID
+	}
+
+	for _, name := range []string{projectID, location, clusterName, billingProjectID} {
+		if !gcpNamePattern.MatchString(name) {
+			return mcp.NewToolResultError(fmt.Sprintf("%q is not a valid project, location or cluster name", name)), nil
+		}
+	}
+
+	dataset, err := billingDataset(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Get optional parameters with defaults
+	timeRangeDays := 7
+	if val, ok := request.Params.Arguments["time_range_days"].(float64); ok && val >= 1 {
+		timeRangeDays = int(val)
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	// Cost allocation has to be enabled on the cluster for namespace labels to
+	// be exported; a lookup failure shouldn't prevent the query
+	costAllocation := true
+	if cluster, err := fetchCluster(ctx, client, projectID, location, clusterName); err == nil {
+		costAllocation = cluster.CostManagementConfig.Enabled
+	}
+
+	// GKE cost allocation labels are only in the detailed (resource level)
+	// export. Credits such as committed use discounts are negative.
+	query := fmt.Sprintf("SELECT "+
+		"(SELECT value FROM UNNEST(labels) WHERE key = 'k8s-namespace') AS namespace, "+
+		"(SELECT value FROM UNNEST(labels) WHERE key = 'k8s-workload-name') AS workload, "+
+		"(SELECT value FROM UNNEST(labels) WHERE key = 'k8s-workload-type') AS workload_type, "+
+		"SUM(cost) + SUM(IFNULL((SELECT SUM(c.amount) FROM UNNEST(credits) c), 0)) AS cost, "+
+		"ANY_VALUE(currency) AS currency "+
+		"FROM `%[1]s.%[2]s.gcp_billing_export_resource_v1_*` "+
+		"WHERE DATE(_PARTITIONTIME) >= DATE_SUB(CURRENT_DATE(), INTERVAL %[3]d DAY) "+
+		"AND usage_start_time >= TIMESTAMP_SUB(CURRENT_TIMESTAMP(), INTERVAL %[3]d DAY) "+
+		"AND project.id = '%[4]s' "+
+		"AND EXISTS(SELECT 1 FROM UNNEST(labels) WHERE key = 'goog-k8s-cluster-name' AND value = '%[5]s') "+
+		"AND EXISTS(SELECT 1 FROM UNNEST(labels) WHERE key = 'goog-k8s-cluster-location' AND value = '%[6]s') "+
+		"GROUP BY namespace, workload, workload_type",
+		billingProjectID, dataset, timeRangeDays, projectID, clusterName, location)
+
+	rows, err := runBigQuery(ctx, client, billingProjectID, query)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying billing export: %v. GKE costs require the detailed usage cost export (gcp_billing_export_resource_v1_*) in %s.%s.", err, billingProjectID, dataset)), nil
+	}
+
+	breakdown := summariseGKECosts(rows)
+	if len(rows) == 0 || breakdown.Total == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No costs found for cluster %s in the billing export %s.%s over the last %d days. Billing data lags by up to a day, so very new clusters may not appear yet.", clusterName, billingProjectID, dataset, timeRangeDays)), nil
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# GKE Cost Breakdown for Cluster %s\n\n", clusterName)
+	result += fmt.Sprintf("Total cost over the last %d days: **%.2f %s**\n\n", timeRangeDays, breakdown.Total, breakdown.Currency)
+
+	if len(breakdown.Namespaces) == 0 {
+		result += "**GKE cost allocation is not enabled** (or has no data yet), so costs can't be broken down by namespace or workload.\n\n"
+		result += "## Recommended Actions\n\n"
+		result += "1. Enable cost allocation on the cluster (`gcloud container clusters update --enable-cost-allocation`); data appears in the export from the following day\n"
+		result += "2. Make sure the detailed usage cost export is enabled, as cost allocation labels are only exported there\n"
+		return mcp.NewToolResultText(result), nil
+	}
+
+	if !costAllocation {
+		result += "Note: Cost allocation is currently disabled on the cluster, so recent costs may not be broken down.\n\n"
+	}
+
+	result += fmt.Sprintf("%.0f%% of the cost is attributed to namespaces; the rest is node capacity not requested by any pod, and cluster-level costs.\n\n", breakdown.Allocated*100/breakdown.Total)
+
+	result += "## By Namespace\n\n"
+	result += "| Namespace | Cost | Share |\n"
+	result += "| --------- | ---- | ----- |\n"
+	for _, item := range breakdown.Namespaces {
+		result += fmt.Sprintf("| %s | %.2f | %.1f%% |\n", item.Namespace, item.Cost, item.Cost*100/breakdown.Total)
+	}
+
+	if len(breakdown.Workloads) > 0 {
+		workloads := breakdown.Workloads
+		if len(workloads) > 20 {
+			workloads = workloads[:20]
+		}
+		result += "\n## Top Workloads\n\n"
+		result += "| Namespace | Workload | Type | Cost | Share |\n"
+		result += "| --------- | -------- | ---- | ---- | ----- |\n"
+		for _, item := range workloads {
+			result += fmt.Sprintf("| %s | %s | %s | %.2f | %.1f%% |\n", item.Namespace, item.Workload, item.WorkloadType, item.Cost, item.Cost*100/breakdown.Total)
+		}
+	}
+
+	top := breakdown.Namespaces[0]
+	result += "\n## Recommended Actions\n\n"
+	result += fmt.Sprintf("1. Start with namespace %s, the biggest spender at %.1f%% of the cluster's cost\n", top.Namespace, top.Cost*100/breakdown.Total)
+	result += "2. Costs are allocated by resource requests, so over-requested workloads cost more than they use; compare requests with actual usage\n"
+	result += "3. Billing data lags by up to a day, so a cost spike in progress may not show yet\n"
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// serviceCost is the total and unattributed cost of a billed service
+type serviceCost struct {
+	Service      string
+	Cost         float64
+	Unattributed float64
+}
+
+// unattributedCosts is spend split by whether it carries the required labels
+type unattributedCosts struct {
+	Total        float64
+	Unattributed float64
+	Currency     string
+	// Services are sorted by unattributed cost, highest first
+	Services []serviceCost
+	// MissingByLabel is the cost lacking each required label key
+	MissingByLabel map[string]float64
+}
+
+// summariseUnattributedCosts aggregates cost rows grouped by service and by
+// which required labels are present. Each row has a has_label_<i> column for
+// the i'th label key, and a cost is unattributed if any of them is false.
+func summariseUnattributedCosts(rows []map[string]string, labelKeys []string) unattributedCosts {
+	summary := unattributedCosts{MissingByLabel: make(map[string]float64)}
+	byService := make(map[string]*serviceCost)
+	for _, row := range rows {
+		cost, err := strconv.ParseFloat(row["cost"], 64)
+		if err != nil {
+			continue
+		}
+		summary.Total += cost
+		if summary.Currency == "" {
+			summary.Currency = row["currency"]
+		}
+
+		service := row["service"]
+		if service == "" {
+			service = "(unknown)"
+		}
+		entry, ok := byService[service]
+		if !ok {
+			entry = &serviceCost{Service: service}
+			byService[service] = entry
+		}
+		entry.Cost += cost
+
+		unattributed := false
+		for i, key := range labelKeys {
+			if row[fmt.Sprintf("has_label_%d", i)] != "true" {
+				summary.MissingByLabel[key] += cost
+				unattributed = true
+			}
+		}
+		if unattributed {
+			summary.Unattributed += cost
+			entry.Unattributed += cost
+		}
+	}
+
+	for _, entry := range byService {
+		summary.Services = append(summary.Services, *entry)
+	}
+	sort.Slice(summary.Services, func(i, j int) bool {
+		if summary.Services[i].Unattributed != summary.Services[j].Unattributed {
+			return summary.Services[i].Unattributed > summary.Services[j].Unattributed
+		}
+		return summary.Services[i].Service < summary.Services[j].Service
+	})
+
+	return summary
+}
+
+// handleGetBillingUnattributedCosts handles the get_billing_unattributed_costs tool request
+func handleGetBillingUnattributedCosts(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+	if !gcpNamePattern.MatchString(projectID) {
+		return mcp.NewToolResultError(fmt.Sprintf("%q is not a valid project ID", projectID)), nil
+	}
+
+	requiredLabels, ok := request.Params.Arguments["required_labels"].(string)
+	if !ok || requiredLabels == "" {
+		return mcp.NewToolResultError("required_labels must be a non-empty string"), nil
+	}
+
+	var labelKeys []string
+	for _, key := range strings.Split(requiredLabels, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" || containsString(labelKeys, key) {
+			continue
+		}
+		if !labelKeyPattern.MatchString(key) {
+			return mcp.NewToolResultError(fmt.Sprintf("%q is not a valid label key", key)), nil
+		}
+		labelKeys = append(labelKeys, key)
+	}
+	if len(labelKeys) == 0 {
+		return mcp.NewToolResultError("required_labels must contain at least one label key"), nil
+	}
+
+	dataset, err := billingDataset(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Get optional parameters with defaults
+	timeRangeDays := 7
+	if val, ok := request.Params.Arguments["time_range_days"].(float64); ok && val >= 1 {
+		timeRangeDays = int(val)
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	// Group by which labels are present rather than returning raw rows, so
+	// the result stays small whatever the export's size
+	var hasLabels []string
+	var groupBy []string
+	for i, key := range labelKeys {
+		column := fmt.Sprintf("has_label_%d", i)
+		hasLabels = append(hasLabels, fmt.Sprintf("(EXISTS(SELECT 1 FROM UNNEST(labels) WHERE key = '%[1]s' AND value != '') "+
+			"OR EXISTS(SELECT 1 FROM UNNEST(project.labels) WHERE key = '%[1]s' AND value != '')) AS %[2]s, ", key, column))
+		groupBy = append(groupBy, column)
+	}
+
+	query := fmt.Sprintf("SELECT service.description AS service, %[3]s"+
+		"SUM(cost) + SUM(IFNULL((SELECT SUM(c.amount) FROM UNNEST(credits) c), 0)) AS cost, "+
+		"ANY_VALUE(currency) AS currency "+
+		"FROM `%[1]s.%[2]s.gcp_billing_export_v1_*` "+
+		"WHERE DATE(_PARTITIONTIME) >= DATE_SUB(CURRENT_DATE(), INTERVAL %[4]d DAY) "+
+		"AND usage_start_time >= TIMESTAMP_SUB(CURRENT_TIMESTAMP(), INTERVAL %[4]d DAY) "+
+		"GROUP BY service, %[5]s",
+		projectID, dataset, strings.Join(hasLabels, ""), timeRangeDays, strings.Join(groupBy, ", "))
+
+	rows, err := runBigQuery(ctx, client, projectID, query)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying billing export: %v. Unattributed costs require the standard usage cost export (gcp_billing_export_v1_*) in %s.%s.", err, projectID, dataset)), nil
+	}
+
+	summary := summariseUnattributedCosts(rows, labelKeys)
+	if len(rows) == 0 || summary.Total == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No costs found in the billing export %s.%s over the last %d days. Billing data lags by up to a day.", projectID, dataset, timeRangeDays)), nil
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# Unattributed Costs in %s.%s\n\n", projectID, dataset)
+	result += fmt.Sprintf("Required labels: %s\n\n", strings.Join(labelKeys, ", "))
+	result += fmt.Sprintf("Over the last %d days, **%.2f of %.2f %s (%.1f%%)** is missing at least one required label.\n\n",
+		timeRangeDays, summary.Unattributed, summary.Total, summary.Currency, summary.Unattributed*100/summary.Total)
+
+	if summary.Unattributed == 0 {
+		result += "All spend carries the required labels.\n"
+		return mcp.NewToolResultText(result), nil
+	}
+
+	result += "## By Missing Label\n\n"
+	result += "| Label | Cost Without It | Share of Total |\n"
+	result += "| ----- | --------------- | -------------- |\n"
+	for _, key := range labelKeys {
+		cost := summary.MissingByLabel[key]
+		result += fmt.Sprintf("| %s | %.2f | %.1f%% |\n", key, cost, cost*100/summary.Total)
+	}
+
+	result += "\n## By Service\n\n"
+	result += "| Service | Unattributed | Total | Unattributed Share |\n"
+	result += "| ------- | ------------ | ----- | ------------------ |\n"
+	shown := 0
+	for _, service := range summary.Services {
+		if service.Unattributed == 0 || shown == 20 {
+			break
+		}
+		share := 0.0
+		if service.Cost != 0 {
+			share = service.Unattributed * 100 / service.Cost
+		}
+		result += fmt.Sprintf("| %s | %.2f | %.2f | %.1f%% |\n", service.Service, service.Unattributed, service.Cost, share)
+		shown++
+	}
+
+	top := summary.Services[0]
+	result += "\n## Recommended Actions\n\n"
+	result += fmt.Sprintf("1. Start with %s, which has the most unattributed spend (%.2f %s)\n", top.Service, top.Unattributed, summary.Currency)
+	result += "2. Find the unlabelled resources in the billing export or Cloud Asset Inventory and label them; labels only apply to costs from when they are added\n"
+	result += "3. Labelling projects covers every resource in them, which is the quickest fix where a project belongs to a single team\n"
+	result += "4. Enforce labels on creation, for example with an org policy custom constraint, so new resources can't be created without them\n"
+
+	return mcp.NewToolResultText(result), nil
+}
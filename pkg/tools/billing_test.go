@@ -0,0 +1,291 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+)
+
+// fakeBigQuery returns a handler for BigQuery jobs.query requests. Each query
+// is answered with the columns and rows that respond returns for it; nil
+// values are NULLs.
+func fakeBigQuery(t *testing.T, respond func(query string) ([]string, [][]interface{})) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host+r.URL.Path != "bigquery.googleapis.com/bigquery/v2/projects/test-project/queries" {
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body struct {
+			Query string `json:"query"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		columns, values := respond(body.Query)
+
+		var fields []map[string]string
+		for _, column := range columns {
+			fields = append(fields, map[string]string{"name": column})
+		}
+		var rows []map[string]interface{}
+		for _, row := range values {
+			var cells []map[string]interface{}
+			for _, v := range row {
+				cells = append(cells, map[string]interface{}{"v": v})
+			}
+			rows = append(rows, map[string]interface{}{"f": cells})
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"jobComplete": true,
+			"schema":      map[string]interface{}{"fields": fields},
+			"rows":        rows,
+		})
+	})
+}
+
+// bigQueryTimestamp encodes t as the BigQuery REST API returns TIMESTAMP
+// values, in floating point seconds since the epoch
+func bigQueryTimestamp(t time.Time) string {
+	return fmt.Sprintf("%.6f", float64(t.UnixMicro())/1e6)
+}
+
+func TestBillingExportFreshness(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	rows := []map[string]string{
+		{"table_name": "gcp_billing_export_v1_fresh", "last_export": bigQueryTimestamp(now.Add(-6 * time.Hour))},
+		{"table_name": "gcp_billing_export_v1_stale", "last_export": bigQueryTimestamp(now.Add(-72 * time.Hour))},
+		{"table_name": "gcp_billing_export_v1_empty"},
+	}
+
+	// Tables without recent data are stalest, so they come first
+	want := []billingExportTable{
+		{Table: "gcp_billing_export_v1_empty", Stale: true},
+		{Table: "gcp_billing_export_v1_stale", LastExport: now.Add(-72 * time.Hour), Lag: 72 * time.Hour, Stale: true},
+		{Table: "gcp_billing_export_v1_fresh", LastExport: now.Add(-6 * time.Hour), Lag: 6 * time.Hour},
+	}
+	got := billingExportFreshness(rows, now)
+	if len(got) != len(want) {
+		t.Fatalf("billingExportFreshness = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("table %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestHandleGetBillingExportFreshness(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), fakeBigQuery(t, func(query string) ([]string, [][]interface{}) {
+		if !strings.Contains(query, "`test-project.billing.gcp_billing_export_*`") {
+			t.Errorf("query doesn't read the dataset's export tables: %s", query)
+		}
+		return []string{"table_name", "last_export"}, [][]interface{}{
+			{"gcp_billing_export_v1_fresh", bigQueryTimestamp(time.Now().Add(-6 * time.Hour))},
+			{"gcp_billing_export_v1_empty", nil},
+		}
+	}))
+
+	text := callTool(t, ctx, handleGetBillingExportFreshness, map[string]interface{}{
+		"project_id": "test-project",
+		"dataset":    "billing",
+	})
+
+	for _, s := range []string{
+		"**1 of 2 export tables are more than 48 hours behind.**",
+		"| gcp_billing_export_v1_empty | none in the last 7 days | - | **Stale** |",
+		"| 6h0m0s | OK |",
+		"## Recommended Actions",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
+
+func TestHandleGetBillingExportFreshnessDataset(t *testing.T) {
+	t.Setenv("OPERABLE_BILLING_EXPORT_DATASET", "")
+
+	for _, dataset := range []string{"", "billing; DROP TABLE x"} {
+		result, err := handleGetBillingExportFreshness(context.Background(), newToolRequest(map[string]interface{}{
+			"project_id": "test-project",
+			"dataset":    dataset,
+		}), newTestAuthHandler(t, auth.ReadOnlyScopes))
+		if err != nil {
+			t.Fatalf("handleGetBillingExportFreshness returned error: %v", err)
+		}
+		if text, _ := resultText(result); !result.IsError || !strings.Contains(text, "dataset") {
+			t.Errorf("dataset %q = %q, want it rejected", dataset, text)
+		}
+	}
+}
+
+// fakeGKECostData returns a handler serving a cluster with cost allocation
+// enabled or not, and answering billing export queries with cost rows of
+// namespace, workload, workload type and cost
+func fakeGKECostData(t *testing.T, costAllocation bool, rows [][]interface{}) http.Handler {
+	bigQuery := fakeBigQuery(t, func(query string) ([]string, [][]interface{}) {
+		for _, s := range []string{"`test-project.billing.gcp_billing_export_resource_v1_*`", "value = 'prod'", "value = 'us-central1'"} {
+			if !strings.Contains(query, s) {
+				t.Errorf("query doesn't contain %s: %s", s, query)
+			}
+		}
+		return []string{"namespace", "workload", "workload_type", "cost", "currency"}, rows
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host == "container.googleapis.com" {
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"name":                 "prod",
+				"costManagementConfig": map[string]bool{"enabled": costAllocation},
+			})
+			return
+		}
+		bigQuery.ServeHTTP(w, r)
+	})
+}
+
+func TestHandleGetGKECostBreakdown(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), fakeGKECostData(t, true, [][]interface{}{
+		{"web", "frontend", "Deployment", "50", "USD"},
+		{"batch", "report", "CronJob", "20", "USD"},
+		{"web", "api", "Deployment", "10", "USD"},
+		{nil, nil, nil, "20", "USD"},
+	}))
+
+	text := callTool(t, ctx, handleGetGKECostBreakdown, map[string]interface{}{
+		"project_id":   "test-project",
+		"location":     "us-central1",
+		"cluster_name": "prod",
+		"dataset":      "billing",
+	})
+
+	// Unallocated cluster costs count towards the total but no namespace
+	for _, s := range []string{
+		"Total cost over the last 7 days: **100.00 USD**",
+		"80% of the cost is attributed to namespaces",
+		"| web | 60.00 | 60.0% |\n| batch | 20.00 | 20.0% |\n",
+		"| web | frontend | Deployment | 50.00 | 50.0% |\n| batch | report | CronJob | 20.00 | 20.0% |\n| web | api | Deployment | 10.00 | 10.0% |\n",
+		"1. Start with namespace web, the biggest spender at 60.0% of the cluster's cost",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if strings.Contains(text, "Cost allocation is currently disabled") {
+		t.Errorf("result says cost allocation is disabled:\n%s", text)
+	}
+}
+
+func TestHandleGetGKECostBreakdownNotEnabled(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), fakeGKECostData(t, false, [][]interface{}{
+		{nil, nil, nil, "42.5", "USD"},
+	}))
+
+	text := callTool(t, ctx, handleGetGKECostBreakdown, map[string]interface{}{
+		"project_id":   "test-project",
+		"location":     "us-central1",
+		"cluster_name": "prod",
+		"dataset":      "billing",
+	})
+
+	for _, s := range []string{
+		"Total cost over the last 7 days: **42.50 USD**",
+		"**GKE cost allocation is not enabled** (or has no data yet)",
+		"1. Enable cost allocation on the cluster",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if strings.Contains(text, "## By Namespace") {
+		t.Errorf("result breaks down costs without cost allocation:\n%s", text)
+	}
+}
+
+func TestSummariseUnattributedCosts(t *testing.T) {
+	row := func(service, team, costCenter, cost string) map[string]string {
+		return map[string]string{"service": service, "has_label_0": team, "has_label_1": costCenter, "cost": cost, "currency": "USD"}
+	}
+	summary := summariseUnattributedCosts([]map[string]string{
+		row("Compute Engine", "true", "true", "100"),
+		row("Compute Engine", "false", "true", "30"),
+		row("", "true", "false", "20"),
+		row("BigQuery", "false", "false", "50"),
+		row("BigQuery", "false", "false", "not a number"),
+	}, []string{"team", "cost-center"})
+
+	if summary.Total != 200 || summary.Unattributed != 100 || summary.Currency != "USD" {
+		t.Errorf("summary = %+v, want 100 of 200 USD unattributed", summary)
+	}
+	if summary.MissingByLabel["team"] != 80 || summary.MissingByLabel["cost-center"] != 70 {
+		t.Errorf("MissingByLabel = %v, want team 80 and cost-center 70", summary.MissingByLabel)
+	}
+
+	// Services are ordered by unattributed cost, ties by name
+	want := []serviceCost{
+		{Service: "BigQuery", Cost: 50, Unattributed: 50},
+		{Service: "Compute Engine", Cost: 130, Unattributed: 30},
+		{Service: "(unknown)", Cost: 20, Unattributed: 20},
+	}
+	if len(summary.Services) != len(want) {
+		t.Fatalf("Services = %+v, want %+v", summary.Services, want)
+	}
+	for i := range want {
+		if summary.Services[i] != want[i] {
+			t.Errorf("service %d = %+v, want %+v", i, summary.Services[i], want[i])
+		}
+	}
+}
+
+func TestHandleGetBillingUnattributedCosts(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), fakeBigQuery(t, func(query string) ([]string, [][]interface{}) {
+		for _, s := range []string{"`test-project.billing.gcp_billing_export_v1_*`", "key = 'team'", "key = 'cost-center'", "GROUP BY service, has_label_0, has_label_1"} {
+			if !strings.Contains(query, s) {
+				t.Errorf("query doesn't contain %s: %s", s, query)
+			}
+		}
+		return []string{"service", "has_label_0", "has_label_1", "cost", "currency"}, [][]interface{}{
+			{"Compute Engine", "true", "true", "150", "USD"},
+			{"Compute Engine", "false", "true", "30", "USD"},
+			{"BigQuery", "false", "false", "20", "USD"},
+		}
+	}))
+
+	text := callTool(t, ctx, handleGetBillingUnattributedCosts, map[string]interface{}{
+		"project_id":      "test-project",
+		"dataset":         "billing",
+		"required_labels": "team, cost-center, team",
+	})
+
+	for _, s := range []string{
+		"Required labels: team, cost-center\n",
+		"**50.00 of 200.00 USD (25.0%)** is missing at least one required label.",
+		"| team | 50.00 | 25.0% |\n| cost-center | 20.00 | 10.0% |\n",
+		"| Compute Engine | 30.00 | 180.00 | 16.7% |\n| BigQuery | 20.00 | 20.00 | 100.0% |\n",
+		"1. Start with Compute Engine, which has the most unattributed spend (30.00 USD)",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
+
+func TestHandleGetBillingUnattributedCostsLabelKeys(t *testing.T) {
+	for _, labels := range []string{" , ", "team,Cost Center", "team'; DROP TABLE x"} {
+		result, err := handleGetBillingUnattributedCosts(context.Background(), newToolRequest(map[string]interface{}{
+			"project_id":      "test-project",
+			"dataset":         "billing",
+			"required_labels": labels,
+		}), newTestAuthHandler(t, auth.ReadOnlyScopes))
+		if err != nil {
+			t.Fatalf("handleGetBillingUnattributedCosts returned error: %v", err)
+		}
+		if text, _ := resultText(result); !result.IsError || !strings.Contains(text, "label key") {
+			t.Errorf("required_labels %q = %q, want them rejected", labels, text)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package tools
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ttlCache is a concurrency-safe in-memory cache whose entries expire after a fixed TTL
+type ttlCache[V any] struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]ttlCacheEntry[V]
+}
+
+// ttlCacheEntry is a cached value and when it was stored
+type ttlCacheEntry[V any] struct {
+	value    V
+	storedAt time.Time
+}
+
+// newTTLCache creates a cache whose entries expire after ttl. A ttl of zero
+// or less disables caching.
+func newTTLCache[V any](ttl time.Duration) *ttlCache[V] {
+	return &ttlCache[V]{
+		ttl:     ttl,
+		entries: make(map[string]ttlCacheEntry[V]),
+	}
+}
+
+// Get returns the cached value for key and its age, if present and not expired
+func (c *ttlCache[V]) Get(key string) (V, time.Duration, bool) {
+	var zero V
+	if c.ttl <= 0 {
+		return zero, 0, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return zero, 0, false
+	}
+
+	age := time.Since(entry.storedAt)
+	if age > c.ttl {
+		delete(c.entries, key)
+		return zero, 0, false
+	}
+
+	return entry.value, age, true
+}
+
+// Set stores value under key
+func (c *ttlCache[V]) Set(key string, value V) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = ttlCacheEntry[V]{value: value, storedAt: time.Now()}
+}
+
+// Invalidate removes key from the cache
+func (c *ttlCache[V]) Invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+// clusterCacheKey returns the cache key for a cluster
+func clusterCacheKey(projectID, location, clusterName string) string {
+	return fmt.Sprintf("%s/%s/%s", projectID, location, clusterName)
+}
+
+// envDuration reads a duration such as "30s" from an environment variable,
+// returning def when it is unset or invalid
+func envDuration(name string, def time.Duration) time.Duration {
+	val := os.Getenv(name)
+	if val == "" {
+		return def
+	}
+
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		return def
+	}
+
+	return d
+}
+
+// envInt reads an integer from an environment variable, returning def when it
+// is unset or invalid
+func envInt(name string, def int) int {
+	val := os.Getenv(name)
+	if val == "" {
+		return def
+	}
+
+	n, err := strconv.Atoi(val)
+	if err != nil {
+		return def
+	}
+
+	return n
+}
+
+// envFloat reads a number from an environment variable, returning def when it
+// is unset or invalid
+func envFloat(name string, def float64) float64 {
+	val := os.Getenv(name)
+	if val == "" {
+		return def
+	}
+
+	f, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return def
+	}
+
+	return f
+}
@@ -0,0 +1,49 @@
+package tools
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTTLCache(t *testing.T) {
+	cache := newTTLCache[string](time.Minute)
+
+	if _, _, ok := cache.Get("a"); ok {
+		t.Error("Get on an empty cache hit")
+	}
+
+	cache.Set("a", "one")
+	if value, _, ok := cache.Get("a"); !ok || value != "one" {
+		t.Errorf("Get = %q, %t, want one, true", value, ok)
+	}
+
+	cache.Invalidate("a")
+	if _, _, ok := cache.Get("a"); ok {
+		t.Error("Get hit after Invalidate")
+	}
+}
+
+func TestTTLCacheExpiry(t *testing.T) {
+	cache := newTTLCache[string](time.Minute)
+	cache.Set("a", "one")
+
+	// Age the entry past the TTL
+	cache.mu.Lock()
+	entry := cache.entries["a"]
+	entry.storedAt = time.Now().Add(-2 * time.Minute)
+	cache.entries["a"] = entry
+	cache.mu.Unlock()
+
+	if _, _, ok := cache.Get("a"); ok {
+		t.Error("Get hit an expired entry")
+	}
+}
+
+func TestTTLCacheDisabled(t *testing.T) {
+	cache := newTTLCache[string](0)
+	cache.Set("a", "one")
+
+	if _, _, ok := cache.Get("a"); ok {
+		t.Error("Get hit with caching disabled")
+	}
+}
@@ -0,0 +1,22 @@
+package tools
+
+import (
+	"context"
+	"errors"
+)
+
+// isCancelled reports whether err was caused by the tool call's context being
+// cancelled or timing out, such as when the client gives up on a slow tool
+func isCancelled(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// partialOnCancel reports whether a fetch that failed with err should return
+// its partial results rather than an error. This is the case when the call was
+// cancelled after some results had already been collected.
+func partialOnCancel(err error, collected int) bool {
+	return err != nil && isCancelled(err) && collected > 0
+}
+
+// cancelledNote is appended to partial results returned after cancellation
+const cancelledNote = "\n\n_The operation was cancelled before it completed, so these results are partial._"
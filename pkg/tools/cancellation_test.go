@@ -0,0 +1,205 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+)
+
+func TestPartialOnCancel(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		collected int
+		want      bool
+	}{
+		{name: "no error", err: nil, collected: 5, want: false},
+		{name: "cancelled with results", err: context.Canceled, collected: 5, want: true},
+		{name: "timed out with results", err: context.DeadlineExceeded, collected: 5, want: true},
+		{name: "wrapped cancellation", err: fmt.Errorf("error making request: %w", context.Canceled), collected: 1, want: true},
+		{name: "cancelled without results", err: context.Canceled, collected: 0, want: false},
+		{name: "other error", err: errors.New("error from Logging API: 500"), collected: 5, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := partialOnCancel(tt.err, tt.collected); got != tt.want {
+			t.Errorf("%s: partialOnCancel = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHandleQueryLogsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The client gives up after the first page arrives
+	pages := 0
+	ctx = withGCPTransport(ctx, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		pages++
+		cancel()
+		body := `{"entries": [{"timestamp": "2026-10-17T01:00:00Z", "severity": "ERROR", "textPayload": "first page"}], "nextPageToken": "page-2"}`
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(body))}, nil
+	}))
+
+	result, err := handleQueryLogs(ctx, newToolRequest(map[string]interface{}{
+		"project_id":  "test-project",
+		"filter":      "severity>=ERROR",
+		"max_results": float64(10),
+	}), newTestAuthHandler(t, auth.ReadOnlyScopes), builtinLogPresets)
+	if err != nil {
+		t.Fatalf("handleQueryLogs returned error: %v", err)
+	}
+
+	text, _ := resultText(result)
+	if result.IsError {
+		t.Fatalf("cancelled query returned an error instead of partial results: %s", text)
+	}
+	if pages != 1 {
+		t.Errorf("fetched %d pages after cancellation, want 1", pages)
+	}
+	if !strings.Contains(text, "first page") || !strings.HasSuffix(text, cancelledNote) {
+		t.Errorf("result isn't the partial first page with the cancelled note:\n%s", text)
+	}
+}
+
+func TestHandleQueryLogsCancelledWithoutResults(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx = withGCPTransport(ctx, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Errorf("unexpected request after cancellation")
+		return nil, context.Canceled
+	}))
+	cancel()
+
+	result, err := handleQueryLogs(ctx, newToolRequest(map[string]interface{}{
+		"project_id": "test-project",
+		"filter":     "severity>=ERROR",
+	}), newTestAuthHandler(t, auth.ReadOnlyScopes), builtinLogPresets)
+	if err != nil {
+		t.Fatalf("handleQueryLogs returned error: %v", err)
+	}
+
+	// With nothing collected there is no partial result to return
+	if text, _ := resultText(result); !result.IsError || !strings.Contains(text, "context canceled") {
+		t.Errorf("cancelled query without results = %q, want a cancellation error", text)
+	}
+}
+
+func TestHandleListActiveIssuesCancelledJSON(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The client gives up after the first page of groups arrives
+	ctx = withGCPTransport(ctx, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		cancel()
+		body := `{"errorGroupStats": [{"group": {"name": "projects/test-project/groups/g1", "groupId": "g1"}, "count": "3"}], "nextPageToken": "page-2"}`
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Header: http.Header{"Content-Type": {"application/json"}}, Body: io.NopCloser(strings.NewReader(body))}, nil
+	}))
+
+	result, err := handleListActiveIssues(ctx, newToolRequest(map[string]interface{}{
+		"project_id":    "test-project",
+		"output_format": "json",
+	}), newTestAuthHandler(t, auth.ReadOnlyScopes))
+	if err != nil {
+		t.Fatalf("handleListActiveIssues returned error: %v", err)
+	}
+
+	text, _ := resultText(result)
+	if result.IsError {
+		t.Fatalf("cancelled call returned an error instead of partial results: %s", text)
+	}
+
+	// The output stays valid JSON, with the cancellation recorded in it
+	var view activeIssuesView
+	if err := json.Unmarshal([]byte(text), &view); err != nil {
+		t.Fatalf("result isn't valid JSON: %v\n%s", err, text)
+	}
+	if !view.Partial || len(view.Issues) != 1 || view.Issues[0].GroupID != "g1" {
+		t.Errorf("result = %+v, want the first page marked partial", view)
+	}
+}
+
+func TestEvictPodsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The first eviction succeeds and then the client gives up
+	kube := &kubeClient{endpoint: "https://evict-cancelled.test", client: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+		cancel()
+		return &http.Response{StatusCode: http.StatusCreated, Status: "201 Created", Body: io.NopCloser(strings.NewReader("{}"))}, nil
+	})}}
+
+	var pods []kubePod
+	for _, name := range []string{"a", "b", "c"} {
+		var pod kubePod
+		pod.Metadata.Name = name
+		pod.Metadata.Namespace = "default"
+		pods = append(pods, pod)
+	}
+
+	start := time.Now()
+	outcome := evictPods(ctx, kube, pods, time.Minute)
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("evictPods took %s after cancellation", elapsed)
+	}
+
+	if !outcome.Cancelled {
+		t.Error("outcome isn't marked cancelled")
+	}
+	if len(outcome.Evicted) != 1 || outcome.Evicted[0].Metadata.Name != "a" {
+		t.Errorf("evicted = %v, want only a", outcome.Evicted)
+	}
+	if len(outcome.Remaining) != 2 {
+		t.Errorf("remaining = %v, want b and c", outcome.Remaining)
+	}
+}
+
+func TestHandleRestartDeploymentCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The client gives up while waiting for the new revision
+	patched := false
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deployment := map[string]interface{}{
+			"metadata": map[string]interface{}{"generation": 2, "annotations": map[string]string{revisionAnnotation: "4"}},
+			"status":   map[string]interface{}{"observedGeneration": 1},
+		}
+		switch {
+		case r.Method == http.MethodPatch:
+			patched = true
+		case patched:
+			cancel()
+		}
+		writeJSON(w, http.StatusOK, deployment)
+	}))
+
+	result, err := handleRestartDeployment(ctx, newToolRequest(map[string]interface{}{
+		"project_id":      "test-project",
+		"location":        "us-central1",
+		"cluster_name":    clusterName,
+		"namespace":       "default",
+		"deployment_name": "web",
+	}), newTestAuthHandler(t, auth.ReadWriteScopes))
+	if err != nil {
+		t.Fatalf("handleRestartDeployment returned error: %v", err)
+	}
+
+	text, _ := resultText(result)
+	if result.IsError {
+		t.Fatalf("cancelled restart returned an error: %s", text)
+	}
+	if !strings.Contains(text, "not yet recorded when the call was cancelled") || !strings.HasSuffix(text, cancelledNote) {
+		t.Errorf("result doesn't report the cancelled wait:\n%s", text)
+	}
+}
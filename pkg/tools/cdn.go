@@ -0,0 +1,224 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerCDNTools registers tools that inspect Cloud CDN
+func registerCDNTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get Cloud CDN cache hit ratio tool
+	getCacheHitRatio := mcp.NewTool("get_cloud_cdn_cache_hit_ratio",
+		mcp.WithDescription("Reports the Cloud CDN cache hit ratio trend for a backend service, flagging a significant drop that sends more traffic to the origin"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("backend_service",
+			mcp.Required(),
+			mcp.Description("The name of the CDN-enabled backend service (or backend bucket)"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range to analyse in hours (default: 6)"),
+		),
+	)
+
+	getCacheHitRatioHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetCloudCDNCacheHitRatio(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getCacheHitRatio, getCacheHitRatioHandler)
+
+	return nil
+}
+
+const (
+	// cdnTrendBuckets is the number of intervals the time range is split into
+	cdnTrendBuckets = 12
+	// cdnRecentBuckets is the number of trailing intervals compared against
+	// the rest of the range
+	cdnRecentBuckets = 3
+	// cdnHitRatioDropThreshold is the fall in hit ratio, as a fraction, that is
+	// flagged as a significant drop
+	cdnHitRatioDropThreshold = 0.2
+)
+
+// cdnBucket is the cache outcome of requests in one interval
+type cdnBucket struct {
+	Time   time.Time
+	Hits   float64
+	Misses float64
+}
+
+// ratio returns the hit ratio of the bucket, and false when it has no
+// cacheable requests
+func (b cdnBucket) ratio() (float64, bool) {
+	total := b.Hits + b.Misses
+	if total == 0 {
+		return 0, false
+	}
+	return b.Hits / total, true
+}
+
+// cdnHitRatioTrend builds time-ordered buckets from request counts grouped by
+// cache_result. Partial hits count as hits; requests with caching disabled are
+// ignored as they were never eligible for the cache.
+func cdnHitRatioTrend(series []timeSeries) []cdnBucket {
+	buckets := make(map[string]*cdnBucket)
+	for _, ts := range series {
+		result := ts.Metric.Labels["cache_result"]
+		for _, point := range ts.Points {
+			bucket, ok := buckets[point.Interval.EndTime]
+			if !ok {
+				t, err := time.Parse(time.RFC3339, point.Interval.EndTime)
+				if err != nil {
+					continue
+				}
+				bucket = &cdnBucket{Time: t}
+				buckets[point.Interval.EndTime] = bucket
+			}
+
+			switch result {
+			case "HIT", "PARTIAL_HIT":
+				bucket.Hits += point.value()
+			case "MISS":
+				bucket.Misses += point.value()
+			}
+		}
+	}
+
+	trend := make([]cdnBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		trend = append(trend, *bucket)
+	}
+	sort.Slice(trend, func(i, j int) bool {
+		return trend[i].Time.Before(trend[j].Time)
+	})
+	return trend
+}
+
+// cdnHitRatioDrop compares the hit ratio of the most recent buckets with the
+// earlier ones, reporting a drop when it fell by at least the threshold
+func cdnHitRatioDrop(trend []cdnBucket) (baseline, recent float64, dropped bool) {
+	if len(trend) <= cdnRecentBuckets {
+		return 0, 0, false
+	}
+
+	split := len(trend) - cdnRecentBuckets
+	var before, after cdnBucket
+	for _, bucket := range trend[:split] {
+		before.Hits += bucket.Hits
+		before.Misses += bucket.Misses
+	}
+	for _, bucket := range trend[split:] {
+		after.Hits += bucket.Hits
+		after.Misses += bucket.Misses
+	}
+
+	baseline, ok := before.ratio()
+	if !ok {
+		return 0, 0, false
+	}
+	recent, ok = after.ratio()
+	if !ok {
+		return baseline, 0, false
+	}
+	return baseline, recent, baseline-recent >= cdnHitRatioDropThreshold
+}
+
+// handleGetCloudCDNCacheHitRatio handles the get_cloud_cdn_cache_hit_ratio tool request
+func handleGetCloudCDNCacheHitRatio(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	backendService, ok := request.Params.Arguments["backend_service"].(string)
+	if !ok || backendService == "" {
+		return mcp.NewToolResultError("backend_service must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	timeRangeHours := 6.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	// Calculate time range, split into equal buckets of at least a minute
+	endTime := time.Now()
+	startTime := endTime.Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+	period := endTime.Sub(startTime) / cdnTrendBuckets
+	if period < time.Minute {
+		period = time.Minute
+	}
+
+	series, err := fetchTimeSeries(ctx, client, projectID, timeSeriesQuery{
+		Filter: fmt.Sprintf(`metric.type="loadbalancing.googleapis.com/https/request_count" AND resource.type="https_lb_rule" AND resource.labels.backend_target_name="%s"`,
+			backendService),
+		StartTime:          startTime,
+		EndTime:            endTime,
+		AlignmentPeriod:    period,
+		PerSeriesAligner:   "ALIGN_DELTA",
+		CrossSeriesReducer: "REDUCE_SUM",
+		GroupByFields:      []string{"metric.labels.cache_result"},
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying request counts: %v", err)), nil
+	}
+
+	trend := cdnHitRatioTrend(series)
+
+	var total cdnBucket
+	for _, bucket := range trend {
+		total.Hits += bucket.Hits
+		total.Misses += bucket.Misses
+	}
+	overall, ok := total.ratio()
+	if !ok {
+		return mcp.NewToolResultText(fmt.Sprintf("No cacheable requests were served through backend %s in the last %.1f hours. Check that Cloud CDN is enabled on the backend and that it is receiving traffic.", backendService, timeRangeHours)), nil
+	}
+
+	baseline, recent, dropped := cdnHitRatioDrop(trend)
+
+	// Format the results
+	result := fmt.Sprintf("# Cloud CDN Cache Hit Ratio for %s\n\n", backendService)
+	result += fmt.Sprintf("Overall hit ratio over the last %.1f hours: **%.1f%%** (%.0f hits, %.0f misses).\n\n", timeRangeHours, overall*100, total.Hits, total.Misses)
+
+	if dropped {
+		result += fmt.Sprintf("**Hit ratio dropped from %.1f%% to %.1f%%** in the most recent %s. Misses are served by the origin, so it is handling noticeably more traffic than before.\n\n",
+			baseline*100, recent*100, period*cdnRecentBuckets)
+	}
+
+	result += "## Trend\n\n"
+	result += "| Interval Ending | Hits | Misses | Hit Ratio |\n"
+	result += "| --------------- | ---- | ------ | --------- |\n"
+	for _, bucket := range trend {
+		ratio := "N/A"
+		if r, ok := bucket.ratio(); ok {
+			ratio = fmt.Sprintf("%.1f%%", r*100)
+		}
+		result += fmt.Sprintf("| %s | %.0f | %.0f | %s |\n", bucket.Time.Format("2006-01-02 15:04:05"), bucket.Hits, bucket.Misses, ratio)
+	}
+
+	if dropped {
+		result += "\n## Recommended Actions\n\n"
+		result += "1. Check for a recent cache invalidation or deployment that changed cache keys, such as new asset URLs or query strings\n"
+		result += "2. Look for responses that became uncacheable, e.g. new Set-Cookie, Cache-Control: private or Vary headers from the origin\n"
+		result += "3. Check the origin's load and latency; if it is overloaded, consider raising TTLs or enabling serve-while-stale\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
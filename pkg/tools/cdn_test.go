@@ -0,0 +1,73 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// cdnSeries returns a request count series for a cache result with a point
+// per hour from 01:00, newest first as the Monitoring API returns them
+func cdnSeries(t *testing.T, cacheResult string, counts ...int) timeSeries {
+	var points []string
+	for i := len(counts) - 1; i >= 0; i-- {
+		points = append(points, fmt.Sprintf(`{"interval": {"endTime": "2026-10-17T%02d:00:00Z"}, "value": {"int64Value": "%d"}}`, i+1, counts[i]))
+	}
+	return decodeJSON[timeSeries](t, `{"metric": {"labels": {"cache_result": "`+cacheResult+`"}}, "points": [`+strings.Join(points, ",")+`]}`)
+}
+
+func TestCDNHitRatioDrop(t *testing.T) {
+	tests := []struct {
+		name        string
+		hits        []int
+		misses      []int
+		wantDropped bool
+	}{
+		{name: "steady", hits: []int{90, 90, 90, 90, 90, 90}, misses: []int{10, 10, 10, 10, 10, 10}},
+		{name: "dropped", hits: []int{90, 90, 90, 50, 50, 50}, misses: []int{10, 10, 10, 50, 50, 50}, wantDropped: true},
+		{name: "small dip", hits: []int{90, 90, 90, 80, 80, 80}, misses: []int{10, 10, 10, 20, 20, 20}},
+		{name: "too few buckets", hits: []int{90, 10}, misses: []int{10, 90}},
+	}
+
+	for _, tt := range tests {
+		trend := cdnHitRatioTrend([]timeSeries{cdnSeries(t, "HIT", tt.hits...), cdnSeries(t, "MISS", tt.misses...)})
+		if _, _, dropped := cdnHitRatioDrop(trend); dropped != tt.wantDropped {
+			t.Errorf("%s: dropped = %t, want %t", tt.name, dropped, tt.wantDropped)
+		}
+	}
+}
+
+func TestHandleGetCloudCDNCacheHitRatio(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if filter := r.URL.Query().Get("filter"); !strings.Contains(filter, `resource.labels.backend_target_name="static-assets"`) {
+			t.Errorf("unexpected filter %s", filter)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"timeSeries": []timeSeries{
+			cdnSeries(t, "HIT", 80, 80, 80, 40, 40, 40),
+			cdnSeries(t, "PARTIAL_HIT", 10, 10, 10, 10, 10, 10),
+			cdnSeries(t, "MISS", 10, 10, 10, 50, 50, 50),
+			// Requests with caching disabled were never eligible for the cache
+			cdnSeries(t, "CACHE_DISABLED", 500, 500, 500, 500, 500, 500),
+		}})
+	}))
+
+	text := callTool(t, ctx, handleGetCloudCDNCacheHitRatio, map[string]interface{}{
+		"project_id":      "test-project",
+		"backend_service": "static-assets",
+	})
+
+	for _, s := range []string{
+		"Overall hit ratio over the last 6.0 hours: **70.0%** (420 hits, 180 misses).",
+		"**Hit ratio dropped from 90.0% to 50.0%** in the most recent 1h30m0s.",
+		"| 2026-10-17 01:00:00 | 90 | 10 | 90.0% |\n| 2026-10-17 02:00:00 | 90 | 10 | 90.0% |",
+		"| 2026-10-17 06:00:00 | 50 | 50 | 50.0% |",
+		"## Recommended Actions",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
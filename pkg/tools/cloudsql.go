@@ -0,0 +1,743 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GCP Cloud SQL Admin API base URL
+const gcpSQLAdminBaseURL = "https://sqladmin.googleapis.com/v1"
+
+// registerCloudSQLTools registers all Cloud SQL related tools
+func registerCloudSQLTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get replication lag tool
+	getReplicationLag := mcp.NewTool("get_cross_region_replication_lag",
+		mcp.WithDescription("Reports the replication lag of each read replica of a Cloud SQL instance"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("instance_id",
+			mcp.Required(),
+			mcp.Description("The Cloud SQL primary instance ID"),
+		),
+		mcp.WithNumber("threshold_seconds",
+			mcp.Description("Replication lag in seconds above which a replica is flagged (default: 60)"),
+		),
+	)
+
+	getReplicationLagHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetReplicationLag(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getReplicationLag, getReplicationLagHandler)
+
+	// Register get active connections tool
+	getActiveConnections := mcp.NewTool("get_active_connections",
+		mcp.WithDescription("Reports current Cloud SQL connections against the instance's max_connections, flagging utilization above 80%"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("instance_id",
+			mcp.Required(),
+			mcp.Description("The Cloud SQL instance ID"),
+		),
+	)
+
+	getActiveConnectionsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetActiveConnections(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getActiveConnections, getActiveConnectionsHandler)
+
+	// Register get slow queries tool
+	getSlowQueries := mcp.NewTool("get_slow_queries",
+		mcp.WithDescription("Lists the slowest and most expensive queries on a Cloud SQL instance from Query Insights, falling back to slow query logs, with normalized query text, average latency and call count"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("instance_id",
+			mcp.Required(),
+			mcp.Description("The Cloud SQL instance ID"),
+		),
+		mcp.WithString("database",
+			mcp.Description("Only include queries against this database (optional)"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range to analyze in hours (default: 1)"),
+		),
+		mcp.WithNumber("max_results",
+			mcp.Description("Maximum number of queries to return (default: 10)"),
+		),
+	)
+
+	getSlowQueriesHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetSlowQueries(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getSlowQueries, getSlowQueriesHandler)
+
+	return nil
+}
+
+// cloudSQLInstance is the subset of a Cloud SQL instance resource used by the tools
+type cloudSQLInstance struct {
+	Name            string   `json:"name"`
+	State           string   `json:"state"`
+	DatabaseVersion string   `json:"databaseVersion"`
+	Region          string   `json:"region"`
+	GceZone         string   `json:"gceZone"`
+	InstanceType    string   `json:"instanceType"`
+	MasterInstance  string   `json:"masterInstanceName"`
+	ReplicaNames    []string `json:"replicaNames"`
+	Settings        struct {
+		Tier          string `json:"tier"`
+		DatabaseFlags []struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"databaseFlags"`
+		InsightsConfig struct {
+			QueryInsightsEnabled bool `json:"queryInsightsEnabled"`
+		} `json:"insightsConfig"`
+	} `json:"settings"`
+}
+
+// databaseFlag returns the value of a database flag set on the instance
+func (i cloudSQLInstance) databaseFlag(name string) (string, bool) {
+	for _, flag := range i.Settings.DatabaseFlags {
+		if flag.Name == name {
+			return flag.Value, true
+		}
+	}
+	return "", false
+}
+
+// isPostgres reports whether the instance runs PostgreSQL
+func (i cloudSQLInstance) isPostgres() bool {
+	return strings.HasPrefix(i.DatabaseVersion, "POSTGRES")
+}
+
+// fetchCloudSQLInstance gets a single Cloud SQL instance from the Admin API
+func fetchCloudSQLInstance(ctx context.Context, client *http.Client, projectID, instanceID string) (*cloudSQLInstance, error) {
+	apiURL := fmt.Sprintf("%s/projects/%s/instances/%s", gcpSQLAdminBaseURL, projectID, instanceID)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := doWithRetry(client, req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to Cloud SQL Admin API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error from Cloud SQL Admin API: %s", resp.Status)
+	}
+
+	var instance cloudSQLInstance
+	if err := json.NewDecoder(resp.Body).Decode(&instance); err != nil {
+		return nil, fmt.Errorf("error parsing response: %w", err)
+	}
+
+	return &instance, nil
+}
+
+// fetchCloudSQLInstances lists the Cloud SQL instances in the project
+func fetchCloudSQLInstances(ctx context.Context, client *http.Client, projectID string) ([]cloudSQLInstance, error) {
+	var instances []cloudSQLInstance
+	pageToken := ""
+	for {
+		apiURL := fmt.Sprintf("%s/projects/%s/instances", gcpSQLAdminBaseURL, projectID)
+		if pageToken != "" {
+			apiURL += "?pageToken=" + url.QueryEscape(pageToken)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+
+		resp, err := doWithRetry(client, req)
+		if err != nil {
+			return nil, fmt.Errorf("error making request to Cloud SQL Admin API: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("error from Cloud SQL Admin API: %s", resp.Status)
+		}
+
+		var response struct {
+			Items         []cloudSQLInstance `json:"items"`
+			NextPageToken string             `json:"nextPageToken"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error parsing response: %w", err)
+		}
+
+		instances = append(instances, response.Items...)
+		pageToken = response.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+
+	return instances, nil
+}
+
+// replicaLag is the most recent replication lag observed for a replica
+type replicaLag struct {
+	Replica    string
+	LagSeconds float64
+	HasData    bool
+}
+
+// latestReplicaLags maps replica_lag series onto the given replicas. Replicas
+// without a series are returned with HasData unset.
+func latestReplicaLags(projectID string, replicas []string, series []timeSeries) []replicaLag {
+	byDatabaseID := make(map[string]float64)
+	for _, ts := range series {
+		if v, ok := ts.latestValue(); ok {
+			byDatabaseID[ts.Resource.Labels["database_id"]] = v
+		}
+	}
+
+	lags := make([]replicaLag, 0, len(replicas))
+	for _, replica := range replicas {
+		lag, ok := byDatabaseID[fmt.Sprintf("%s:%s", projectID, replica)]
+		lags = append(lags, replicaLag{Replica: replica, LagSeconds: lag, HasData: ok})
+	}
+
+	sort.Slice(lags, func(i, j int) bool {
+		return lags[i].LagSeconds > lags[j].LagSeconds
+	})
+
+	return lags
+}
+
+// handleGetReplicationLag handles the get_cross_region_replication_lag tool request
+func handleGetReplicationLag(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	instanceID, ok := request.Params.Arguments["instance_id"].(string)
+	if !ok || instanceID == "" {
+		return mcp.NewToolResultError("instance_id must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	thresholdSeconds := 60.0
+	if val, ok := request.Params.Arguments["threshold_seconds"].(float64); ok && val > 0 {
+		thresholdSeconds = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	instance, err := fetchCloudSQLInstance(ctx, client, projectID, instanceID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting Cloud SQL instance: %v", err)), nil
+	}
+
+	if len(instance.ReplicaNames) == 0 {
+		result := fmt.Sprintf("Cloud SQL instance %s has no read replicas.", instanceID)
+		if instance.MasterInstance != "" {
+			result += fmt.Sprintf(" It is itself a replica of %s; run this tool against the primary instance.", instance.MasterInstance)
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+
+	// Query the replica lag metric for all replicas of the instance
+	databaseIDs := make([]string, len(instance.ReplicaNames))
+	for i, replica := range instance.ReplicaNames {
+		databaseIDs[i] = fmt.Sprintf(`"%s:%s"`, projectID, replica)
+	}
+
+	endTime := time.Now()
+	startTime := endTime.Add(-15 * time.Minute)
+
+	series, err := fetchTimeSeries(ctx, client, projectID, timeSeriesQuery{
+		Filter: fmt.Sprintf(`metric.type="cloudsql.googleapis.com/database/replication/replica_lag" AND resource.type="cloudsql_database" AND resource.labels.database_id=one_of(%s)`,
+			strings.Join(databaseIDs, ",")),
+		StartTime:        startTime,
+		EndTime:          endTime,
+		AlignmentPeriod:  time.Minute,
+		PerSeriesAligner: "ALIGN_MAX",
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying replica lag: %v", err)), nil
+	}
+
+	lags := latestReplicaLags(projectID, instance.ReplicaNames, series)
+
+	// Format the results
+	result := fmt.Sprintf("# Replication Lag for Cloud SQL Instance %s\n\n", instanceID)
+	result += fmt.Sprintf("Primary region: %s\n\n", instance.Region)
+	result += "| Replica | Lag | Status |\n"
+	result += "| ------- | --- | ------ |\n"
+
+	lagging := 0
+	for _, lag := range lags {
+		if !lag.HasData {
+			result += fmt.Sprintf("| %s | N/A | No data in the last 15 minutes |\n", lag.Replica)
+			continue
+		}
+
+		status := "OK"
+		if lag.LagSeconds > thresholdSeconds {
+			status = "**LAGGING**"
+			lagging++
+		}
+		result += fmt.Sprintf("| %s | %.1fs | %s |\n", lag.Replica, lag.LagSeconds, status)
+	}
+
+	if lagging > 0 {
+		result += fmt.Sprintf("\n%d replicas are lagging more than %.0f seconds behind the primary. Reads from them may return stale data.\n", lagging, thresholdSeconds)
+		result += "\n## Recommended Actions\n\n"
+		result += "1. Check the primary for long-running transactions or heavy write bursts\n"
+		result += "2. Check replica CPU and disk I/O utilization for saturation\n"
+		result += "3. Consider routing freshness-sensitive reads to the primary until lag recovers\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// connectionUtilisationThreshold is the fraction of max_connections above which an instance is flagged
+const connectionUtilisationThreshold = 0.8
+
+// connectionSource is the number of connections from a single client application
+type connectionSource struct {
+	Name        string
+	Connections float64
+}
+
+// topConnectionSources sums the latest connection counts per label value,
+// largest first
+func topConnectionSources(series []timeSeries, label string) []connectionSource {
+	totals := make(map[string]float64)
+	for _, ts := range series {
+		if v, ok := ts.latestValue(); ok {
+			name := ts.Metric.Labels[label]
+			if name == "" {
+				name = "(unnamed)"
+			}
+			totals[name] += v
+		}
+	}
+
+	sources := make([]connectionSource, 0, len(totals))
+	for name, connections := range totals {
+		sources = append(sources, connectionSource{Name: name, Connections: connections})
+	}
+
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].Connections > sources[j].Connections
+	})
+
+	return sources
+}
+
+// handleGetActiveConnections handles the get_active_connections tool request
+func handleGetActiveConnections(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	instanceID, ok := request.Params.Arguments["instance_id"].(string)
+	if !ok || instanceID == "" {
+		return mcp.NewToolResultError("instance_id must be a non-empty string"), nil
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	instance, err := fetchCloudSQLInstance(ctx, client, projectID, instanceID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting Cloud SQL instance: %v", err)), nil
+	}
+
+	endTime := time.Now()
+	startTime := endTime.Add(-10 * time.Minute)
+	resourceFilter := fmt.Sprintf(`resource.type="cloudsql_database" AND resource.labels.database_id="%s:%s"`, projectID, instanceID)
+
+	// PostgreSQL reports backends rather than network connections
+	metricType := "cloudsql.googleapis.com/database/network/connections"
+	if instance.isPostgres() {
+		metricType = "cloudsql.googleapis.com/database/postgresql/num_backends"
+	}
+
+	series, err := fetchTimeSeries(ctx, client, projectID, timeSeriesQuery{
+		Filter:             fmt.Sprintf(`metric.type="%s" AND %s`, metricType, resourceFilter),
+		StartTime:          startTime,
+		EndTime:            endTime,
+		AlignmentPeriod:    time.Minute,
+		PerSeriesAligner:   "ALIGN_MAX",
+		CrossSeriesReducer: "REDUCE_SUM",
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying connections: %v", err)), nil
+	}
+
+	current, hasCurrent := 0.0, false
+	if len(series) > 0 {
+		current, hasCurrent = series[0].latestValue()
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# Connections for Cloud SQL Instance %s\n\n", instanceID)
+	result += fmt.Sprintf("- **Database Version**: %s\n", instance.DatabaseVersion)
+	result += fmt.Sprintf("- **Tier**: %s\n", instance.Settings.Tier)
+
+	if !hasCurrent {
+		result += "- **Current Connections**: no data in the last 10 minutes\n"
+		return mcp.NewToolResultText(result), nil
+	}
+	result += fmt.Sprintf("- **Current Connections**: %.0f\n", current)
+
+	maxConnectionsFlag, hasMax := instance.databaseFlag("max_connections")
+	maxConnections, parseErr := strconv.ParseFloat(maxConnectionsFlag, 64)
+	if !hasMax || parseErr != nil || maxConnections <= 0 {
+		result += "- **Max Connections**: not set explicitly, so the engine default for the tier's memory applies\n"
+		result += "\nSet the max_connections database flag to have utilization calculated precisely.\n"
+	} else {
+		utilisation := current / maxConnections
+		result += fmt.Sprintf("- **Max Connections**: %.0f\n", maxConnections)
+		result += fmt.Sprintf("- **Utilization**: %.1f%%\n", utilisation*100)
+
+		if utilisation >= connectionUtilisationThreshold {
+			result += fmt.Sprintf("\n**The instance is using more than %.0f%% of its connection limit.** New connections will be refused with \"too many connections\" once the limit is reached.\n",
+				connectionUtilisationThreshold*100)
+		}
+	}
+
+	// Break down connections by client application where the engine reports it
+	if instance.isPostgres() {
+		byApplication, err := fetchTimeSeries(ctx, client, projectID, timeSeriesQuery{
+			Filter:           fmt.Sprintf(`metric.type="cloudsql.googleapis.com/database/postgresql/num_backends_by_application" AND %s`, resourceFilter),
+			StartTime:        startTime,
+			EndTime:          endTime,
+			AlignmentPeriod:  time.Minute,
+			PerSeriesAligner: "ALIGN_MAX",
+		})
+		if err == nil && len(byApplication) > 0 {
+			result += "\n## Top Connecting Applications\n\n"
+			for i, source := range topConnectionSources(byApplication, "application") {
+				if i >= 5 {
+					break
+				}
+				result += fmt.Sprintf("- **%s**: %.0f connections\n", source.Name, source.Connections)
+			}
+		}
+	}
+
+	result += "\n## Recommended Actions\n\n"
+	result += "1. Check for clients leaking connections or with oversized connection pools\n"
+	result += "2. Put a connection pooler (such as PgBouncer) between high-fan-out clients and the database\n"
+	result += "3. If the load is legitimate, raise max_connections or move to a larger tier\n"
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// slowQuery aggregates executions of a single normalized query
+type slowQuery struct {
+	Query   string
+	Calls   int64
+	TotalMs float64
+	MaxMs   float64
+}
+
+// avgMs returns the average latency per call in milliseconds
+func (q slowQuery) avgMs() float64 {
+	if q.Calls == 0 {
+		return 0
+	}
+	return q.TotalMs / float64(q.Calls)
+}
+
+var (
+	// sqlStringLiteral matches single-quoted SQL string literals
+	sqlStringLiteral = regexp.MustCompile(`'(?:[^']|'')*'`)
+
+	// sqlNumberLiteral matches numeric literals that aren't part of an identifier
+	sqlNumberLiteral = regexp.MustCompile(`\b\d+(?:\.\d+)?\b`)
+
+	// postgresDurationLine matches a PostgreSQL log_min_duration_statement entry
+	postgresDurationLine = regexp.MustCompile(`duration: ([\d.]+) ms\s+(?:statement|(?:execute|parse|bind) [^:]*): (?s)(.*)`)
+
+	// postgresDatabase matches the database in a PostgreSQL log line prefix
+	postgresDatabase = regexp.MustCompile(`\bdb=([^,\s]+)`)
+
+	// mysqlQueryTime matches the timing header of a MySQL slow query log entry
+	mysqlQueryTime = regexp.MustCompile(`# Query_time: ([\d.]+)`)
+
+	// mysqlSchema matches the schema of a MySQL slow query log entry
+	mysqlSchema = regexp.MustCompile(`(?:Schema: |\buse )([^\s;]+)`)
+)
+
+// normaliseQuery replaces literals with placeholders and collapses whitespace
+// so executions of the same query with different arguments group together
+func normaliseQuery(query string) string {
+	query = sqlStringLiteral.ReplaceAllString(query, "?")
+	query = sqlNumberLiteral.ReplaceAllString(query, "?")
+	query = strings.Join(strings.Fields(query), " ")
+	query = strings.TrimSuffix(query, ";")
+	if len(query) > 300 {
+		query = query[:300] + "..."
+	}
+	return query
+}
+
+// sortSlowQueries orders queries by total time spent, most expensive first
+func sortSlowQueries(queries map[string]*slowQuery) []slowQuery {
+	sorted := make([]slowQuery, 0, len(queries))
+	for _, q := range queries {
+		sorted = append(sorted, *q)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].TotalMs > sorted[j].TotalMs
+	})
+	return sorted
+}
+
+// aggregateInsightsQueries sums Query Insights per-query latency
+// distributions, reported in microseconds, into per-query totals
+func aggregateInsightsQueries(series []timeSeries) []slowQuery {
+	queries := map[string]*slowQuery{}
+	for _, ts := range series {
+		text := normaliseQuery(ts.Metric.Labels["querystring"])
+		q, ok := queries[text]
+		if !ok {
+			q = &slowQuery{Query: text}
+			queries[text] = q
+		}
+
+		for _, point := range ts.Points {
+			dist := point.Value.DistributionValue
+			if dist == nil {
+				continue
+			}
+			count, err := strconv.ParseInt(dist.Count, 10, 64)
+			if err != nil || count == 0 {
+				continue
+			}
+			q.Calls += count
+			q.TotalMs += float64(count) * dist.Mean / 1000
+			if dist.Mean/1000 > q.MaxMs {
+				q.MaxMs = dist.Mean / 1000
+			}
+		}
+	}
+
+	for text, q := range queries {
+		if q.Calls == 0 {
+			delete(queries, text)
+		}
+	}
+	return sortSlowQueries(queries)
+}
+
+// parseSlowQueryLogs extracts query durations from PostgreSQL
+// log_min_duration_statement entries and MySQL slow query log entries,
+// optionally limited to a single database
+func parseSlowQueryLogs(entries []logEntry, database string) []slowQuery {
+	queries := map[string]*slowQuery{}
+	for _, entry := range entries {
+		text := entry.TextPayload
+
+		var durationMs float64
+		var query, db string
+		if m := postgresDurationLine.FindStringSubmatch(text); m != nil {
+			durationMs, _ = strconv.ParseFloat(m[1], 64)
+			query = m[2]
+			if dbMatch := postgresDatabase.FindStringSubmatch(text); dbMatch != nil {
+				db = dbMatch[1]
+			}
+		} else if m := mysqlQueryTime.FindStringSubmatch(text); m != nil {
+			seconds, _ := strconv.ParseFloat(m[1], 64)
+			durationMs = seconds * 1000
+			if dbMatch := mysqlSchema.FindStringSubmatch(text); dbMatch != nil {
+				db = dbMatch[1]
+			}
+
+			// The statement follows the comment and session setup lines
+			var lines []string
+			for _, line := range strings.Split(text, "\n") {
+				trimmed := strings.TrimSpace(line)
+				lower := strings.ToLower(trimmed)
+				if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(lower, "set timestamp") || strings.HasPrefix(lower, "use ") {
+					continue
+				}
+				lines = append(lines, trimmed)
+			}
+			query = strings.Join(lines, " ")
+		} else {
+			continue
+		}
+
+		if query == "" || (database != "" && db != "" && db != database) {
+			continue
+		}
+
+		normalised := normaliseQuery(query)
+		q, ok := queries[normalised]
+		if !ok {
+			q = &slowQuery{Query: normalised}
+			queries[normalised] = q
+		}
+		q.Calls++
+		q.TotalMs += durationMs
+		if durationMs > q.MaxMs {
+			q.MaxMs = durationMs
+		}
+	}
+	return sortSlowQueries(queries)
+}
+
+// handleGetSlowQueries handles the get_slow_queries tool request
+func handleGetSlowQueries(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	instanceID, ok := request.Params.Arguments["instance_id"].(string)
+	if !ok || instanceID == "" {
+		return mcp.NewToolResultError("instance_id must be a non-empty string"), nil
+	}
+
+	database, _ := request.Params.Arguments["database"].(string)
+
+	timeRangeHours := 1.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	maxResults := 10
+	if val, ok := request.Params.Arguments["max_results"].(float64); ok && val > 0 {
+		maxResults = int(val)
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	instance, err := fetchCloudSQLInstance(ctx, client, projectID, instanceID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting Cloud SQL instance: %v", err)), nil
+	}
+
+	endTime := time.Now()
+	startTime := endTime.Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+
+	var queries []slowQuery
+	source := ""
+
+	// Query Insights per-query metrics are only published for PostgreSQL
+	insightsEnabled := instance.Settings.InsightsConfig.QueryInsightsEnabled
+	if insightsEnabled && instance.isPostgres() {
+		filter := fmt.Sprintf(`metric.type="cloudsql.googleapis.com/database/postgresql/insights/perquery/latencies" AND resource.type="cloudsql_instance_database" AND resource.labels.resource_id="%s:%s"`,
+			projectID, instanceID)
+		if database != "" {
+			filter += fmt.Sprintf(` AND resource.labels.database="%s"`, database)
+		}
+
+		series, err := fetchTimeSeries(ctx, client, projectID, timeSeriesQuery{
+			Filter:             filter,
+			StartTime:          startTime,
+			EndTime:            endTime,
+			AlignmentPeriod:    endTime.Sub(startTime),
+			PerSeriesAligner:   "ALIGN_DELTA",
+			CrossSeriesReducer: "REDUCE_SUM",
+			GroupByFields:      []string{"metric.label.querystring"},
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error querying Query Insights metrics: %v", err)), nil
+		}
+		queries = aggregateInsightsQueries(series)
+		source = "Query Insights"
+	}
+
+	// Fall back to slow query logs
+	if len(queries) == 0 {
+		filter := fmt.Sprintf(`resource.type="cloudsql_database" AND resource.labels.database_id="%s:%s" AND (textPayload:"duration:" OR logName:"mysql-slow.log") AND timestamp>="%s"`,
+			projectID, instanceID, startTime.UTC().Format(time.RFC3339))
+
+		entries, _, err := fetchLogEntries(ctx, client, projectID, logQuery{Filter: filter, PageSize: 1000})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error querying logs: %v", err)), nil
+		}
+		queries = parseSlowQueryLogs(entries, database)
+		source = "slow query logs"
+	}
+
+	if len(queries) == 0 {
+		result := fmt.Sprintf("No slow queries found for Cloud SQL instance %s in the last %.1f hours.\n\n", instanceID, timeRangeHours)
+		if !insightsEnabled {
+			result += "**Query Insights is not enabled on this instance**, so only slow query logs could be checked. "
+			result += fmt.Sprintf("Enable it with `gcloud sql instances patch %s --insights-config-query-insights-enabled` to record per-query latency.\n\n", instanceID)
+		}
+		if instance.isPostgres() {
+			result += "For slow query logs, set the log_min_duration_statement database flag (in milliseconds) so slow statements are logged.\n"
+		} else {
+			result += "For slow query logs, set the slow_query_log and long_query_time database flags, with log_output set to FILE.\n"
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# Slow Queries for Cloud SQL Instance %s\n\n", instanceID)
+	result += fmt.Sprintf("Top queries by total execution time over the last %.1f hours, from %s", timeRangeHours, source)
+	if database != "" {
+		result += fmt.Sprintf(" (database %s)", database)
+	}
+	result += ".\n\n"
+
+	for i, q := range queries {
+		if i >= maxResults {
+			break
+		}
+		result += fmt.Sprintf("## %d. %.1f ms average, %d calls\n\n", i+1, q.avgMs(), q.Calls)
+		result += fmt.Sprintf("- **Total Time**: %.0f ms\n", q.TotalMs)
+		result += fmt.Sprintf("- **Slowest**: %.1f ms\n\n", q.MaxMs)
+		result += "```sql\n" + q.Query + "\n```\n\n"
+	}
+
+	result += "## Recommended Actions\n\n"
+	result += "1. Run EXPLAIN (ANALYZE) on the top queries to find missing indexes or sequential scans\n"
+	result += "2. Queries with many calls and moderate latency often cost more in total than a single slow query; check for N+1 query patterns\n"
+	result += "3. Correlate the slow period with get_active_connections and CPU metrics to rule out contention\n"
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,217 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHandleGetReplicationLag(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Host + r.URL.Path {
+		case "sqladmin.googleapis.com/v1/projects/test-project/instances/orders":
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"name":         "orders",
+				"region":       "us-central1",
+				"replicaNames": []string{"orders-eu", "orders-asia", "orders-us"},
+			})
+		case "monitoring.googleapis.com/v3/projects/test-project/timeSeries":
+			filter := r.URL.Query().Get("filter")
+			if !strings.Contains(filter, `database_id=one_of("test-project:orders-eu","test-project:orders-asia","test-project:orders-us")`) {
+				t.Errorf("filter doesn't select the replicas: %s", filter)
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"timeSeries": []timeSeries{
+				testSeries(nil, map[string]string{"database_id": "test-project:orders-eu"}, 5),
+				testSeries(nil, map[string]string{"database_id": "test-project:orders-asia"}, 120),
+			}})
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	text := callTool(t, ctx, handleGetReplicationLag, map[string]interface{}{
+		"project_id":  "test-project",
+		"instance_id": "orders",
+	})
+
+	// Replicas are ordered by lag, with those without data last
+	want := "| orders-asia | 120.0s | **LAGGING** |\n" +
+		"| orders-eu | 5.0s | OK |\n" +
+		"| orders-us | N/A | No data in the last 15 minutes |\n"
+	if !strings.Contains(text, want) {
+		t.Errorf("result doesn't contain the lag table %q:\n%s", want, text)
+	}
+	if !strings.Contains(text, "1 replicas are lagging more than 60 seconds") {
+		t.Errorf("result doesn't summarise the lagging replica:\n%s", text)
+	}
+}
+
+func TestHandleGetReplicationLagOfReplica(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"name": "orders-eu", "masterInstanceName": "test-project:orders"})
+	}))
+
+	text := callTool(t, ctx, handleGetReplicationLag, map[string]interface{}{
+		"project_id":  "test-project",
+		"instance_id": "orders-eu",
+	})
+	if !strings.Contains(text, "has no read replicas. It is itself a replica of test-project:orders") {
+		t.Errorf("result doesn't point at the primary:\n%s", text)
+	}
+}
+
+func TestHandleGetActiveConnections(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Host + r.URL.Path {
+		case "sqladmin.googleapis.com/v1/projects/test-project/instances/orders":
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"name":            "orders",
+				"databaseVersion": "POSTGRES_15",
+				"settings": map[string]interface{}{
+					"tier":          "db-custom-2-7680",
+					"databaseFlags": []map[string]string{{"name": "max_connections", "value": "100"}},
+				},
+			})
+		case "monitoring.googleapis.com/v3/projects/test-project/timeSeries":
+			filter := r.URL.Query().Get("filter")
+			var series []timeSeries
+			switch {
+			case strings.Contains(filter, "num_backends_by_application"):
+				series = []timeSeries{
+					testSeries(map[string]string{"application": "api"}, nil, 60),
+					testSeries(map[string]string{"application": "worker"}, nil, 25),
+					testSeries(map[string]string{"application": "api"}, nil, 5),
+				}
+			case strings.Contains(filter, "postgresql/num_backends"):
+				series = []timeSeries{testSeries(nil, nil, 90)}
+			default:
+				t.Errorf("unexpected filter %s", filter)
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"timeSeries": series})
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	text := callTool(t, ctx, handleGetActiveConnections, map[string]interface{}{
+		"project_id":  "test-project",
+		"instance_id": "orders",
+	})
+
+	for _, s := range []string{
+		"- **Current Connections**: 90",
+		"- **Max Connections**: 100",
+		"- **Utilization**: 90.0%",
+		"**The instance is using more than 80% of its connection limit.**",
+		"- **api**: 65 connections\n- **worker**: 25 connections\n",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
+
+func TestNormaliseQuery(t *testing.T) {
+	got := normaliseQuery("SELECT *  FROM orders\n  WHERE id = 42 AND status = 'it''s paid' AND v2 > 1.5;")
+	want := "SELECT * FROM orders WHERE id = ? AND status = ? AND v2 > ?"
+	if got != want {
+		t.Errorf("normaliseQuery = %q, want %q", got, want)
+	}
+}
+
+func TestParseSlowQueryLogs(t *testing.T) {
+	entries := []logEntry{
+		{TextPayload: "2026-10-17 01:00:00 UTC:10.0.0.1(5432):app@db=shop,user=app: LOG:  duration: 1500.5 ms  statement: SELECT * FROM orders WHERE id = 1"},
+		{TextPayload: "2026-10-17 01:00:05 UTC:10.0.0.1(5432):app@db=shop,user=app: LOG:  duration: 500.5 ms  execute <unnamed>: SELECT * FROM orders WHERE id = 2"},
+		{TextPayload: "2026-10-17 01:00:06 UTC:10.0.0.1(5432):app@db=other,user=app: LOG:  duration: 9000 ms  statement: SELECT pg_sleep(9)"},
+		{TextPayload: "# Time: 2026-10-17T01:00:00Z\n# User@Host: app[app] @ [10.0.0.1]\n# Query_time: 0.250000  Lock_time: 0.000100\nuse shop;\nSET timestamp=1792198800;\nUPDATE carts SET total = 10\n  WHERE id = 7;"},
+		{TextPayload: "connection received: host=10.0.0.1"},
+	}
+
+	got := parseSlowQueryLogs(entries, "shop")
+	want := []slowQuery{
+		{Query: "SELECT * FROM orders WHERE id = ?", Calls: 2, TotalMs: 2001, MaxMs: 1500.5},
+		{Query: "UPDATE carts SET total = ? WHERE id = ?", Calls: 1, TotalMs: 250, MaxMs: 250},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parseSlowQueryLogs = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("query %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestHandleGetSlowQueries(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Host + r.URL.Path {
+		case "sqladmin.googleapis.com/v1/projects/test-project/instances/orders":
+			w.Write([]byte(`{"name": "orders", "databaseVersion": "POSTGRES_15", "settings": {"insightsConfig": {"queryInsightsEnabled": true}}}`))
+		case "monitoring.googleapis.com/v3/projects/test-project/timeSeries":
+			if filter := r.URL.Query().Get("filter"); !strings.Contains(filter, "insights/perquery/latencies") || !strings.Contains(filter, `resource.labels.database="shop"`) {
+				t.Errorf("unexpected filter %s", filter)
+			}
+			// Latencies are distributions in microseconds
+			w.Write([]byte(`{"timeSeries": [
+				{"metric": {"labels": {"querystring": "SELECT * FROM orders WHERE id = $1"}}, "points": [{"value": {"distributionValue": {"count": "10", "mean": 50000}}}]},
+				{"metric": {"labels": {"querystring": "SELECT 1"}}, "points": [{"value": {"distributionValue": {"count": "1000", "mean": 1000}}}]},
+				{"metric": {"labels": {"querystring": "SELECT now()"}}, "points": [{"value": {"distributionValue": {"count": "0"}}}]}
+			]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	text := callTool(t, ctx, handleGetSlowQueries, map[string]interface{}{
+		"project_id":  "test-project",
+		"instance_id": "orders",
+		"database":    "shop",
+	})
+
+	// Queries are ranked by total time, so frequent fast queries can outrank
+	// rare slow ones
+	want := "## 1. 1.0 ms average, 1000 calls\n\n- **Total Time**: 1000 ms\n- **Slowest**: 1.0 ms\n\n```sql\nSELECT ?\n```\n\n" +
+		"## 2. 50.0 ms average, 10 calls\n\n- **Total Time**: 500 ms\n- **Slowest**: 50.0 ms\n\n```sql\nSELECT * FROM orders WHERE id = $?\n```\n"
+	if !strings.Contains(text, want) {
+		t.Errorf("result doesn't contain %q:\n%s", want, text)
+	}
+	if !strings.Contains(text, "from Query Insights (database shop).") || strings.Contains(text, "now()") {
+		t.Errorf("result doesn't report only the Query Insights queries with calls:\n%s", text)
+	}
+}
+
+func TestHandleGetSlowQueriesWithoutInsights(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Host + r.URL.Path {
+		case "sqladmin.googleapis.com/v1/projects/test-project/instances/orders":
+			w.Write([]byte(`{"name": "orders", "databaseVersion": "MYSQL_8_0"}`))
+		default:
+			fakeLogging(t, func(filter string) string {
+				if !strings.Contains(filter, `resource.labels.database_id="test-project:orders"`) {
+					t.Errorf("unexpected filter %s", filter)
+				}
+				return `[]`
+			}).ServeHTTP(w, r)
+		}
+	}))
+
+	text := callTool(t, ctx, handleGetSlowQueries, map[string]interface{}{
+		"project_id":  "test-project",
+		"instance_id": "orders",
+	})
+
+	for _, s := range []string{
+		"No slow queries found for Cloud SQL instance orders",
+		"**Query Insights is not enabled on this instance**",
+		"set the slow_query_log and long_query_time database flags",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerClusterEventTools registers tools that read Kubernetes events
+func registerClusterEventTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get cluster events stream tool
+	getEventsStream := mcp.NewTool("get_cluster_events_stream",
+		mcp.WithDescription("Lists Kubernetes events from all namespaces of a cluster within a recent window, newest first with their involved object, for a \"what's going wrong everywhere\" view of evictions, node problems and scheduling failures"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithString("type",
+			mcp.Description("Only return events of this type: Warning or Normal (if not provided, both are returned)"),
+		),
+		mcp.WithString("reason",
+			mcp.Description("Only return events with this reason, such as Evicted, NodeNotReady or FailedScheduling"),
+		),
+		mcp.WithString("window",
+			mcp.Description("How far back to look, as a duration such as 15m or 2h (default: 1h). The API server only keeps events for a limited time, 1h by default."),
+		),
+		mcp.WithNumber("max_results",
+			mcp.Description("Maximum number of events to return (default: 100)"),
+		),
+	)
+
+	getEventsStreamHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetClusterEventsStream(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getEventsStream, getEventsStreamHandler)
+
+	return nil
+}
+
+// kubeEvent is the subset of a core/v1 Event used by the tools
+type kubeEvent struct {
+	Metadata       kubeObjectMeta `json:"metadata"`
+	InvolvedObject struct {
+		Kind      string `json:"kind"`
+		Namespace string `json:"namespace"`
+		Name      string `json:"name"`
+		UID       string `json:"uid"`
+	} `json:"involvedObject"`
+	Type           string `json:"type"`
+	Reason         string `json:"reason"`
+	Message        string `json:"message"`
+	Count          int32  `json:"count"`
+	FirstTimestamp string `json:"firstTimestamp"`
+	LastTimestamp  string `json:"lastTimestamp"`
+	EventTime      string `json:"eventTime"`
+	Source         struct {
+		Component string `json:"component"`
+		Host      string `json:"host"`
+	} `json:"source"`
+	Series *struct {
+		Count            int32  `json:"count"`
+		LastObservedTime string `json:"lastObservedTime"`
+	} `json:"series"`
+}
+
+// lastSeen returns when the event last occurred. Events from newer clients
+// only set eventTime and series, older ones lastTimestamp.
+func (e kubeEvent) lastSeen() time.Time {
+	for _, value := range []string{e.seriesLastObserved(), e.LastTimestamp, e.EventTime, e.FirstTimestamp, e.Metadata.CreationTimestamp} {
+		if value == "" {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// seriesLastObserved returns the last observed time of a repeating event, or
+// an empty string
+func (e kubeEvent) seriesLastObserved() string {
+	if e.Series == nil {
+		return ""
+	}
+	return e.Series.LastObservedTime
+}
+
+// occurrences returns how many times the event occurred
+func (e kubeEvent) occurrences() int32 {
+	if e.Series != nil && e.Series.Count > 0 {
+		return e.Series.Count
+	}
+	if e.Count > 0 {
+		return e.Count
+	}
+	return 1
+}
+
+// eventsFieldSelector builds a field selector that filters events by type
+// and reason on the API server
+func eventsFieldSelector(eventType, reason string) string {
+	var selectors []string
+	if eventType != "" {
+		selectors = append(selectors, "type="+eventType)
+	}
+	if reason != "" {
+		selectors = append(selectors, "reason="+reason)
+	}
+	return strings.Join(selectors, ",")
+}
+
+// recentEvents returns the events last seen at or after since, newest first
+func recentEvents(events []kubeEvent, since time.Time) []kubeEvent {
+	var recent []kubeEvent
+	for _, event := range events {
+		if lastSeen := event.lastSeen(); !lastSeen.IsZero() && !lastSeen.Before(since) {
+			recent = append(recent, event)
+		}
+	}
+
+	sort.SliceStable(recent, func(i, j int) bool {
+		return recent[i].lastSeen().After(recent[j].lastSeen())
+	})
+
+	return recent
+}
+
+// handleGetClusterEventsStream handles the get_cluster_events_stream tool request
+func handleGetClusterEventsStream(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	eventType, _ := request.Params.Arguments["type"].(string)
+	if eventType != "" && eventType != "Warning" && eventType != "Normal" {
+		return mcp.NewToolResultError("type must be Warning or Normal"), nil
+	}
+
+	reason, _ := request.Params.Arguments["reason"].(string)
+
+	// Get optional parameters with defaults
+	window := time.Hour
+	if val, ok := request.Params.Arguments["window"].(string); ok && val != "" {
+		parsed, err := time.ParseDuration(val)
+		if err != nil || parsed <= 0 {
+			return mcp.NewToolResultError("window must be a positive duration, such as 15m or 2h"), nil
+		}
+		window = parsed
+	}
+
+	maxResults := 100
+	if val, ok := request.Params.Arguments["max_results"].(float64); ok && val > 0 {
+		maxResults = int(val)
+	}
+
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error connecting to cluster: %v", err)), nil
+	}
+
+	query := url.Values{}
+	if selector := eventsFieldSelector(eventType, reason); selector != "" {
+		query.Set("fieldSelector", selector)
+	}
+
+	var eventList struct {
+		Items []kubeEvent `json:"items"`
+	}
+	if err := kube.get(ctx, "/api/v1/events", query, &eventList); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing events: %v", err)), nil
+	}
+
+	events := recentEvents(eventList.Items, time.Now().Add(-window))
+
+	// Format the results
+	filters := ""
+	if eventType != "" {
+		filters += " " + eventType
+	}
+	if reason != "" {
+		filters += " " + reason
+	}
+
+	if len(events) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No%s events in the last %s in cluster %s.", filters, window, clusterName)), nil
+	}
+
+	result := fmt.Sprintf("# Cluster Events in %s\n\n", clusterName)
+	result += fmt.Sprintf("Found %d%s events in the last %s across all namespaces", len(events), filters, window)
+	if len(events) > maxResults {
+		result += fmt.Sprintf(", showing the newest %d", maxResults)
+	}
+	result += ".\n\n"
+
+	// Summarise by reason so broad problems stand out before the detail
+	reasonCounts := make(map[string]int)
+	var reasons []string
+	for _, event := range events {
+		key := event.Type + "/" + event.Reason
+		if reasonCounts[key] == 0 {
+			reasons = append(reasons, key)
+		}
+		reasonCounts[key]++
+	}
+	sort.SliceStable(reasons, func(i, j int) bool {
+		return reasonCounts[reasons[i]] > reasonCounts[reasons[j]]
+	})
+
+	result += "## By Reason\n\n"
+	for _, key := range reasons {
+		result += fmt.Sprintf("- **%s**: %d objects\n", key, reasonCounts[key])
+	}
+	result += "\n"
+
+	if len(events) > maxResults {
+		events = events[:maxResults]
+	}
+
+	result += "## Events\n\n"
+	result += "| Last Seen | Type | Reason | Object | Count | Source | Message |\n"
+	result += "| --------- | ---- | ------ | ------ | ----- | ------ | ------- |\n"
+	for _, event := range events {
+		object := event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name
+		if event.InvolvedObject.Namespace != "" {
+			object = event.InvolvedObject.Namespace + "/" + object
+		}
+		message := strings.ReplaceAll(strings.TrimSpace(event.Message), "\n", " ")
+		message = strings.ReplaceAll(message, "|", "\\|")
+		result += fmt.Sprintf("| %s | %s | %s | %s | %d | %s | %s |\n",
+			event.lastSeen().Format(time.RFC3339), event.Type, event.Reason, object, event.occurrences(), valueOrDash(event.Source.Component), message)
+	}
+
+	result += "\n## Recommended Actions\n\n"
+	result += "1. Evicted or node condition events on many objects point to node pressure: check node health with get_gke_node_problem_detector_events\n"
+	result += "2. FailedScheduling events mean pods can't be placed: check taints and affinity with get_node_taints_and_affinity_conflicts\n"
+	result += "3. BackOff or Unhealthy events mean containers are crashing or failing probes: rank them with get_recent_restarts_across_cluster\n"
+	result += "4. Narrow the stream with type and reason to follow a single problem\n"
+
+	return mcp.NewToolResultText(result), nil
+}
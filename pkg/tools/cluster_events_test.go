@@ -0,0 +1,85 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestKubeEventLastSeen(t *testing.T) {
+	tests := []struct {
+		name  string
+		event string
+		want  string
+	}{
+		{
+			name:  "legacy",
+			event: `{"firstTimestamp": "2026-10-17T01:00:00Z", "lastTimestamp": "2026-10-17T01:30:00Z"}`,
+			want:  "2026-10-17T01:30:00Z",
+		},
+		{
+			name:  "events.k8s.io",
+			event: `{"eventTime": "2026-10-17T01:00:00.123456Z"}`,
+			want:  "2026-10-17T01:00:00.123456Z",
+		},
+		{
+			name:  "series",
+			event: `{"eventTime": "2026-10-17T01:00:00Z", "series": {"count": 4, "lastObservedTime": "2026-10-17T01:45:00Z"}}`,
+			want:  "2026-10-17T01:45:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		var event kubeEvent
+		if err := json.Unmarshal([]byte(tt.event), &event); err != nil {
+			t.Fatalf("%s: parsing event: %v", tt.name, err)
+		}
+		if got := event.lastSeen().Format(time.RFC3339Nano); got != tt.want {
+			t.Errorf("%s: lastSeen = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHandleGetClusterEventsStream(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	ago := func(d time.Duration) string { return now.Add(-d).Format(time.RFC3339) }
+
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/events" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("fieldSelector"); got != "type=Warning" {
+			t.Errorf("fieldSelector = %q, want type=Warning", got)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"items": []map[string]interface{}{
+			{"involvedObject": map[string]string{"kind": "Pod", "namespace": "web", "name": "api-1"}, "type": "Warning", "reason": "BackOff",
+				"message": "Back-off restarting failed container", "count": 12, "lastTimestamp": ago(10 * time.Minute), "source": map[string]string{"component": "kubelet"}},
+			{"involvedObject": map[string]string{"kind": "Node", "name": "node-a"}, "type": "Warning", "reason": "NodeNotReady",
+				"message": "Node node-a status is now: NodeNotReady", "lastTimestamp": ago(2 * time.Minute), "source": map[string]string{"component": "node-controller"}},
+			{"involvedObject": map[string]string{"kind": "Pod", "namespace": "web", "name": "api-2"}, "type": "Warning", "reason": "BackOff",
+				"message": "Back-off restarting failed container", "lastTimestamp": ago(30 * time.Minute)},
+			{"involvedObject": map[string]string{"kind": "Pod", "namespace": "batch", "name": "old"}, "type": "Warning", "reason": "Evicted",
+				"message": "outside the window", "lastTimestamp": ago(3 * time.Hour)},
+		}})
+	}))
+
+	text := callClusterTool(t, context.Background(), handleGetClusterEventsStream, clusterName, map[string]interface{}{"type": "Warning"})
+
+	for _, s := range []string{
+		"Found 3 Warning events in the last 1h0m0s across all namespaces.",
+		"- **Warning/BackOff**: 2 objects\n- **Warning/NodeNotReady**: 1 objects\n",
+		"| " + ago(2*time.Minute) + " | Warning | NodeNotReady | Node/node-a | 1 | node-controller | Node node-a status is now: NodeNotReady |\n" +
+			"| " + ago(10*time.Minute) + " | Warning | BackOff | web/Pod/api-1 | 12 | kubelet | Back-off restarting failed container |\n" +
+			"| " + ago(30*time.Minute) + " | Warning | BackOff | web/Pod/api-2 | 1 | - | Back-off restarting failed container |\n",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if strings.Contains(text, "outside the window") {
+		t.Errorf("result includes an event from before the window:\n%s", text)
+	}
+}
@@ -0,0 +1,142 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerClusterOperationTools registers tools that list recent changes to
+// GKE clusters
+func registerClusterOperationTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get recent deployments tool
+	getRecentDeployments := mcp.NewTool("get_recent_deployments",
+		mcp.WithDescription("Lists recent GKE cluster and node pool operations, such as upgrades, node pool resizes and configuration updates, with their type, target, status, start and end times and any error. Use it to see whether a change coincided with an alert."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Description("The GKE location to list operations in (optional, defaults to all locations)"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Only include operations started within this many hours (default: 24)"),
+		),
+	)
+
+	getRecentDeploymentsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetRecentDeployments(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getRecentDeployments, getRecentDeploymentsHandler)
+
+	return nil
+}
+
+// timedOperation is a GKE operation with its parsed start time
+type timedOperation struct {
+	gkeOperation
+	Started time.Time
+}
+
+// operationsSince returns the operations started since the given time,
+// newest first. Operations without a valid start time are dropped.
+func operationsSince(ops []gkeOperation, since time.Time) []timedOperation {
+	var recent []timedOperation
+	for _, op := range ops {
+		started, err := time.Parse(time.RFC3339Nano, op.StartTime)
+		if err != nil || started.Before(since) {
+			continue
+		}
+		recent = append(recent, timedOperation{gkeOperation: op, Started: started})
+	}
+	sort.SliceStable(recent, func(i, j int) bool {
+		return recent[i].Started.After(recent[j].Started)
+	})
+	return recent
+}
+
+// handleGetRecentDeployments handles the get_recent_deployments tool request
+func handleGetRecentDeployments(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	location, _ := request.Params.Arguments["location"].(string)
+
+	timeRangeHours := 24.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	ops, err := fetchContainerOperations(ctx, client, projectID, location)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing operations: %v", err)), nil
+	}
+
+	// The API doesn't filter by time, so operations are filtered here
+	since := time.Now().Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+	recent := operationsSince(ops, since)
+
+	scope := "all locations"
+	if location != "" {
+		scope = location
+	}
+
+	// Format the results
+	if len(recent) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No GKE operations started in %s in the last %.1f hours.", scope, timeRangeHours)), nil
+	}
+
+	result := fmt.Sprintf("# Recent GKE Operations in Project %s\n\n", projectID)
+	result += fmt.Sprintf("Found %d operations started in %s in the last %.1f hours, newest first.\n\n", len(recent), scope, timeRangeHours)
+
+	result += "| Started | Ended | Operation | Target | Location | Status |\n"
+	result += "| ------- | ----- | --------- | ------ | -------- | ------ |\n"
+	var failed []timedOperation
+	for _, op := range recent {
+		ended := "-"
+		if t, err := time.Parse(time.RFC3339Nano, op.EndTime); err == nil {
+			ended = t.UTC().Format(time.RFC3339)
+		}
+		status := op.Status
+		if op.errorMessage() != "" {
+			status = "**Failed**"
+			failed = append(failed, op)
+		}
+		result += fmt.Sprintf("| %s | %s | %s | %s | %s | %s |\n",
+			op.Started.UTC().Format(time.RFC3339), ended, op.OperationType, valueOrDash(computeResourcePath(op.TargetLink)), valueOrDash(op.location()), valueOrDash(status))
+	}
+	result += "\n"
+
+	if len(failed) > 0 {
+		result += "## Errors\n\n"
+		for _, op := range failed {
+			result += fmt.Sprintf("- **%s %s**: %s\n", op.OperationType, valueOrDash(lastPathSegment(op.TargetLink)), op.errorMessage())
+		}
+		result += "\n"
+	}
+
+	result += "## Recommended Actions\n\n"
+	result += "1. Compare operation start and end times with when the alert fired; upgrades and node pool changes recreate nodes and reschedule pods\n"
+	result += "2. Use get_recent_scaling_events or get_cluster_events_stream to see the workload changes that followed an operation\n"
+	if len(failed) > 0 {
+		result += "3. Use get_resource_creation_failures for failed operations across Compute Engine too, such as node instances that couldn't be created\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,580 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// GCP Compute Engine API base URL
+	gcpComputeBaseURL = "https://compute.googleapis.com/compute/v1"
+
+	// recreateLoopWindow and recreateLoopSize define a recreate loop: an
+	// instance autohealed recreateLoopSize or more times within the window
+	recreateLoopWindow = time.Hour
+	recreateLoopSize   = 3
+)
+
+// registerComputeTools registers all Compute Engine related tools
+func registerComputeTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get instance group autohealing status tool
+	getAutohealingStatus := mcp.NewTool("get_instance_group_autohealing_status",
+		mcp.WithDescription("Reports a managed instance group's autohealing policy and recent autohealing recreations, flagging instances stuck in a recreate loop. Works for GKE node pool instance groups, to tell node flapping apart from a one-off repair."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("instance_group",
+			mcp.Required(),
+			mcp.Description("The managed instance group name"),
+		),
+		mcp.WithString("zone",
+			mcp.Description("The zone of a zonal instance group (either zone or region is required)"),
+		),
+		mcp.WithString("region",
+			mcp.Description("The region of a regional instance group (either zone or region is required)"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range for autohealing events in hours (default: 24)"),
+		),
+	)
+
+	getAutohealingStatusHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetInstanceGroupAutohealingStatus(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getAutohealingStatus, getAutohealingStatusHandler)
+
+	// Register get managed instance group errors tool
+	getMIGErrors := mcp.NewTool("get_managed_instance_group_errors",
+		mcp.WithDescription("Reports a managed instance group's current actions and the errors from its instances' last create attempts (e.g. quota exceeded, zone resource exhaustion, missing image), surfacing the exact reason instances can't be created. Explains GKE node pools and autoscaler scale-ups that are stuck."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("instance_group",
+			mcp.Required(),
+			mcp.Description("The managed instance group name"),
+		),
+		mcp.WithString("zone",
+			mcp.Description("The zone of a zonal instance group (either zone or region is required)"),
+		),
+		mcp.WithString("region",
+			mcp.Description("The region of a regional instance group (either zone or region is required)"),
+		),
+	)
+
+	getMIGErrorsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetManagedInstanceGroupErrors(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getMIGErrors, getMIGErrorsHandler)
+
+	if err := registerMaintenanceTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerVMMetricsTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerOperationTools(s, authHandler); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// instanceGroupManager is the subset of a Compute Engine InstanceGroupManager
+// used by the tools
+type instanceGroupManager struct {
+	Name                string `json:"name"`
+	BaseInstanceName    string `json:"baseInstanceName"`
+	TargetSize          int    `json:"targetSize"`
+	AutoHealingPolicies []struct {
+		HealthCheck     string `json:"healthCheck"`
+		InitialDelaySec int    `json:"initialDelaySec"`
+	} `json:"autoHealingPolicies"`
+	CurrentActions struct {
+		None                   int `json:"none"`
+		Creating               int `json:"creating"`
+		CreatingWithoutRetries int `json:"creatingWithoutRetries"`
+		Recreating             int `json:"recreating"`
+		Deleting               int `json:"deleting"`
+		Abandoning             int `json:"abandoning"`
+		Restarting             int `json:"restarting"`
+		Refreshing             int `json:"refreshing"`
+		Verifying              int `json:"verifying"`
+	} `json:"currentActions"`
+	Status struct {
+		IsStable bool `json:"isStable"`
+	} `json:"status"`
+}
+
+// fetchInstanceGroupManager gets a zonal or regional managed instance group
+func fetchInstanceGroupManager(ctx context.Context, client *http.Client, projectID, scope, name string) (*instanceGroupManager, error) {
+	apiURL := fmt.Sprintf("%s/projects/%s/%s/instanceGroupManagers/%s", gcpComputeBaseURL, projectID, scope, name)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := doWithRetry(client, req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to Compute Engine API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error from Compute Engine API: %s", resp.Status)
+	}
+
+	var mig instanceGroupManager
+	if err := json.NewDecoder(resp.Body).Decode(&mig); err != nil {
+		return nil, fmt.Errorf("error parsing response: %w", err)
+	}
+
+	return &mig, nil
+}
+
+// instanceRecreation is an autohealing recreation of an instance
+type instanceRecreation struct {
+	Time     time.Time
+	Instance string
+	Zone     string
+}
+
+// parseInstanceRecreations extracts recreations of the group's instances from
+// autohealing audit log entries, oldest first. A group's instances are named
+// after its base instance name followed by a random suffix.
+func parseInstanceRecreations(entries []logEntry, baseInstanceName string) []instanceRecreation {
+	var recreations []instanceRecreation
+	for _, entry := range entries {
+		resourceName := payloadString(entry.ProtoPayload, "resourceName")
+		instance := resourceName[strings.LastIndex(resourceName, "/")+1:]
+		if !strings.HasPrefix(instance, baseInstanceName+"-") {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, entry.Timestamp)
+		if err != nil {
+			continue
+		}
+
+		recreations = append(recreations, instanceRecreation{
+			Time:     t,
+			Instance: instance,
+			Zone:     entry.Resource.Labels["zone"],
+		})
+	}
+
+	sort.Slice(recreations, func(i, j int) bool {
+		return recreations[i].Time.Before(recreations[j].Time)
+	})
+
+	return recreations
+}
+
+// recreateLoop is an instance recreated repeatedly within recreateLoopWindow
+type recreateLoop struct {
+	Instance string
+	Count    int
+	Start    time.Time
+	End      time.Time
+}
+
+// findRecreateLoops returns the instances recreated recreateLoopSize or more
+// times within recreateLoopWindow, reporting each instance's busiest window
+func findRecreateLoops(recreations []instanceRecreation) []recreateLoop {
+	byInstance := make(map[string][]time.Time)
+	var instances []string
+	for _, r := range recreations {
+		if _, ok := byInstance[r.Instance]; !ok {
+			instances = append(instances, r.Instance)
+		}
+		byInstance[r.Instance] = append(byInstance[r.Instance], r.Time)
+	}
+
+	var loops []recreateLoop
+	for _, instance := range instances {
+		times := byInstance[instance]
+		var best recreateLoop
+		start := 0
+		for end := range times {
+			for times[end].Sub(times[start]) > recreateLoopWindow {
+				start++
+			}
+			if count := end - start + 1; count > best.Count {
+				best = recreateLoop{Instance: instance, Count: count, Start: times[start], End: times[end]}
+			}
+		}
+		if best.Count >= recreateLoopSize {
+			loops = append(loops, best)
+		}
+	}
+
+	sort.SliceStable(loops, func(i, j int) bool {
+		return loops[i].Count > loops[j].Count
+	})
+
+	return loops
+}
+
+// handleGetInstanceGroupAutohealingStatus handles the get_instance_group_autohealing_status tool request
+func handleGetInstanceGroupAutohealingStatus(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	instanceGroup, ok := request.Params.Arguments["instance_group"].(string)
+	if !ok || instanceGroup == "" {
+		return mcp.NewToolResultError("instance_group must be a non-empty string"), nil
+	}
+
+	zone, _ := request.Params.Arguments["zone"].(string)
+	region, _ := request.Params.Arguments["region"].(string)
+	if (zone == "") == (region == "") {
+		return mcp.NewToolResultError("exactly one of zone or region must be provided"), nil
+	}
+
+	scope := "zones/" + zone
+	if region != "" {
+		scope = "regions/" + region
+	}
+
+	// Get optional parameters with defaults
+	timeRangeHours := 24.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	mig, err := fetchInstanceGroupManager(ctx, client, projectID, scope, instanceGroup)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting instance group: %v", err)), nil
+	}
+
+	startTime := time.Now().Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+
+	// Autohealing and GKE node auto-repair both recreate instances through the
+	// repair system event
+	filter := fmt.Sprintf(`resource.type="gce_instance"
+		AND protoPayload.methodName="compute.instances.repair.recreateInstance"
+		AND protoPayload.resourceName:"/instances/%s-"
+		AND timestamp >= "%s"`,
+		mig.BaseInstanceName, startTime.Format(time.RFC3339))
+	if zone != "" {
+		filter += fmt.Sprintf(`
+		AND resource.labels.zone="%s"`, zone)
+	}
+
+	entries, _, err := fetchLogEntries(ctx, client, projectID, logQuery{
+		Filter:   filter,
+		PageSize: 1000,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying logs: %v", err)), nil
+	}
+
+	recreations := parseInstanceRecreations(entries, mig.BaseInstanceName)
+	loops := findRecreateLoops(recreations)
+
+	// Format the results
+	result := fmt.Sprintf("# Autohealing Status for Instance Group %s\n\n", mig.Name)
+
+	result += "## Autohealing Policy\n\n"
+	if len(mig.AutoHealingPolicies) == 0 {
+		result += "Autohealing is not configured; unhealthy instances are only replaced if they stop running.\n\n"
+	} else {
+		for _, policy := range mig.AutoHealingPolicies {
+			healthCheck := policy.HealthCheck[strings.LastIndex(policy.HealthCheck, "/")+1:]
+			result += fmt.Sprintf("- **Health Check**: %s\n", healthCheck)
+			result += fmt.Sprintf("- **Initial Delay**: %ds\n", policy.InitialDelaySec)
+		}
+		result += "\n"
+	}
+
+	result += "## Current State\n\n"
+	result += fmt.Sprintf("- **Target Size**: %d\n", mig.TargetSize)
+	result += fmt.Sprintf("- **Stable**: %t\n", mig.Status.IsStable)
+	result += fmt.Sprintf("- **Instances Being Recreated**: %d\n", mig.CurrentActions.Recreating)
+	result += fmt.Sprintf("- **Instances Verifying**: %d\n\n", mig.CurrentActions.Verifying)
+
+	if len(recreations) == 0 {
+		result += fmt.Sprintf("No autohealing recreations in the last %.1f hours.\n", timeRangeHours)
+		return mcp.NewToolResultText(result), nil
+	}
+
+	result += "## Recent Recreations\n\n"
+	result += fmt.Sprintf("Found %d autohealing recreations in the last %.1f hours.\n\n", len(recreations), timeRangeHours)
+
+	if len(loops) > 0 {
+		result += fmt.Sprintf("**Recreate loop detected**: %d instances were recreated %d or more times within %s:\n\n", len(loops), recreateLoopSize, recreateLoopWindow)
+		for _, loop := range loops {
+			result += fmt.Sprintf("- **%s**: %d recreations between %s and %s\n",
+				loop.Instance, loop.Count, loop.Start.Format("2006-01-02 15:04:05"), loop.End.Format("2006-01-02 15:04:05"))
+		}
+		result += "\n"
+	}
+
+	result += "| Time | Instance | Zone |\n"
+	result += "| ---- | -------- | ---- |\n"
+	for _, r := range recreations {
+		result += fmt.Sprintf("| %s | %s | %s |\n", r.Time.Format("2006-01-02 15:04:05"), r.Instance, r.Zone)
+	}
+
+	result += "\n## Recommended Actions\n\n"
+	if len(loops) > 0 {
+		result += "1. A recreate loop means new instances never pass the health check; check the instance's serial console and startup logs for why the workload fails to come up\n"
+		result += "2. Check the initial delay is long enough for instances to boot and start serving, otherwise healthy instances are recreated before they are ready\n"
+		result += "3. For GKE node pools, check the node's kubelet and container runtime logs; a bad node image or startup script makes every repair fail the same way\n"
+	} else {
+		result += "1. Isolated recreations are usually one-off instance failures; check the serial console logs of the recreated instances if they recur\n"
+		result += "2. Check that the health check reflects actual health, as a flaky health check causes needless recreations\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// managedInstance is the subset of a Compute Engine ManagedInstance used by
+// the tools
+type managedInstance struct {
+	Instance       string `json:"instance"`
+	InstanceStatus string `json:"instanceStatus"`
+	CurrentAction  string `json:"currentAction"`
+	LastAttempt    struct {
+		Errors struct {
+			Errors []struct {
+				Code     string `json:"code"`
+				Message  string `json:"message"`
+				Location string `json:"location"`
+			} `json:"errors"`
+		} `json:"errors"`
+	} `json:"lastAttempt"`
+}
+
+// fetchManagedInstances lists the instances of a zonal or regional managed
+// instance group with their current action and last attempt
+func fetchManagedInstances(ctx context.Context, client *http.Client, projectID, scope, name string) ([]managedInstance, error) {
+	var instances []managedInstance
+	pageToken := ""
+	for {
+		apiURL := fmt.Sprintf("%s/projects/%s/%s/instanceGroupManagers/%s/listManagedInstances?maxResults=500",
+			gcpComputeBaseURL, projectID, scope, name)
+		if pageToken != "" {
+			apiURL += "&pageToken=" + url.QueryEscape(pageToken)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", apiURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+
+		// Listing managed instances is read-only, so the POST is safe to retry
+		req = markIdempotent(req)
+
+		resp, err := doWithRetry(client, req)
+		if err != nil {
+			return nil, fmt.Errorf("error making request to Compute Engine API: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("error from Compute Engine API: %s", resp.Status)
+		}
+
+		var response struct {
+			ManagedInstances []managedInstance `json:"managedInstances"`
+			NextPageToken    string            `json:"nextPageToken"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error parsing response: %w", err)
+		}
+
+		instances = append(instances, response.ManagedInstances...)
+		pageToken = response.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+
+	return instances, nil
+}
+
+// migInstanceError is an error from the last attempt to act on one or more
+// instances of a managed instance group
+type migInstanceError struct {
+	Code      string
+	Message   string
+	Instances []string
+}
+
+// groupManagedInstanceErrors groups the last attempt errors of instances by
+// code and message, most widespread first
+func groupManagedInstanceErrors(instances []managedInstance) []migInstanceError {
+	index := make(map[string]int)
+	var groups []migInstanceError
+	for _, instance := range instances {
+		name := instance.Instance[strings.LastIndex(instance.Instance, "/")+1:]
+		for _, e := range instance.LastAttempt.Errors.Errors {
+			key := e.Code + "\x00" + e.Message
+			i, ok := index[key]
+			if !ok {
+				i = len(groups)
+				index[key] = i
+				groups = append(groups, migInstanceError{Code: e.Code, Message: e.Message})
+			}
+			groups[i].Instances = append(groups[i].Instances, name)
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return len(groups[i].Instances) > len(groups[j].Instances)
+	})
+
+	return groups
+}
+
+// migErrorHint explains what to do about a managed instance creation error
+func migErrorHint(code string) string {
+	switch {
+	case strings.HasPrefix(code, "ZONE_RESOURCE_POOL_EXHAUSTED"):
+		return "The zone has no capacity left for this machine type. Spread the group across more zones, use a different machine type, or reserve capacity."
+	case code == "QUOTA_EXCEEDED":
+		return "A project or regional quota is exhausted. The message names the quota; request an increase or free up resources."
+	case code == "RESOURCE_NOT_FOUND":
+		return "A resource the instance template references, such as an image, disk, network or service account, doesn't exist."
+	case strings.Contains(code, "PERMISSION"):
+		return "The Compute Engine service agent lacks permission to a referenced resource, such as a shared image or subnetwork."
+	case code == "RATE_LIMIT_EXCEEDED" || code == "RESOURCE_OPERATION_RATE_EXCEEDED":
+		return "Too many operations were requested at once. The group retries on its own; this usually clears."
+	case code == "CONDITION_NOT_MET":
+		return "A precondition on a referenced resource wasn't met, often a reservation or placement policy that can't be satisfied."
+	}
+	return ""
+}
+
+// handleGetManagedInstanceGroupErrors handles the get_managed_instance_group_errors tool request
+func handleGetManagedInstanceGroupErrors(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	instanceGroup, ok := request.Params.Arguments["instance_group"].(string)
+	if !ok || instanceGroup == "" {
+		return mcp.NewToolResultError("instance_group must be a non-empty string"), nil
+	}
+
+	zone, _ := request.Params.Arguments["zone"].(string)
+	region, _ := request.Params.Arguments["region"].(string)
+	if (zone == "") == (region == "") {
+		return mcp.NewToolResultError("exactly one of zone or region must be provided"), nil
+	}
+
+	scope := "zones/" + zone
+	if region != "" {
+		scope = "regions/" + region
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	mig, err := fetchInstanceGroupManager(ctx, client, projectID, scope, instanceGroup)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting instance group: %v", err)), nil
+	}
+
+	instances, err := fetchManagedInstances(ctx, client, projectID, scope, instanceGroup)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing managed instances: %v", err)), nil
+	}
+
+	errorGroups := groupManagedInstanceErrors(instances)
+
+	// Format the results
+	result := fmt.Sprintf("# Errors for Managed Instance Group %s\n\n", mig.Name)
+
+	result += "## Current Actions\n\n"
+	result += fmt.Sprintf("- **Target Size**: %d\n", mig.TargetSize)
+	result += fmt.Sprintf("- **Managed Instances**: %d\n", len(instances))
+	result += fmt.Sprintf("- **Stable**: %t\n", mig.Status.IsStable)
+	actions := []struct {
+		name  string
+		count int
+	}{
+		{"Running (no action)", mig.CurrentActions.None},
+		{"Creating", mig.CurrentActions.Creating},
+		{"Creating without retries", mig.CurrentActions.CreatingWithoutRetries},
+		{"Recreating", mig.CurrentActions.Recreating},
+		{"Deleting", mig.CurrentActions.Deleting},
+		{"Abandoning", mig.CurrentActions.Abandoning},
+		{"Restarting", mig.CurrentActions.Restarting},
+		{"Refreshing", mig.CurrentActions.Refreshing},
+		{"Verifying", mig.CurrentActions.Verifying},
+	}
+	for _, action := range actions {
+		if action.count > 0 {
+			result += fmt.Sprintf("- **%s**: %d\n", action.name, action.count)
+		}
+	}
+	result += "\n"
+
+	if len(errorGroups) == 0 {
+		result += "No instance has an error from its last attempt"
+		if mig.CurrentActions.Creating > 0 {
+			result += "; instances still being created may be waiting on capacity without having failed yet"
+		}
+		result += ".\n"
+		return mcp.NewToolResultText(result), nil
+	}
+
+	result += "## Last Attempt Errors\n\n"
+	for i, group := range errorGroups {
+		result += fmt.Sprintf("### %d. %s (%d instances)\n\n", i+1, group.Code, len(group.Instances))
+		result += fmt.Sprintf("%s\n\n", group.Message)
+		if hint := migErrorHint(group.Code); hint != "" {
+			result += fmt.Sprintf("**What it means**: %s\n\n", hint)
+		}
+		shown := group.Instances
+		if len(shown) > 10 {
+			shown = shown[:10]
+		}
+		result += fmt.Sprintf("- **Instances**: %s", strings.Join(shown, ", "))
+		if len(group.Instances) > len(shown) {
+			result += fmt.Sprintf(" and %d more", len(group.Instances)-len(shown))
+		}
+		result += "\n\n"
+	}
+
+	result += "## Recommended Actions\n\n"
+	result += "1. Fix the most widespread error first; the group keeps retrying creation, so instances come up once the cause is resolved\n"
+	result += "2. For GKE node pools, these errors are why the cluster autoscaler's scale-ups don't produce nodes; check get_recent_scaling_events for the scale-ups they affect\n"
+	result += "3. Check the instance template referenced by the group if errors name an image, disk or network\n"
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,180 @@
+package tools
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+)
+
+func TestFetchManagedInstancesRetries(t *testing.T) {
+	attempts := 0
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable", Body: io.NopCloser(strings.NewReader("{}"))}, nil
+		}
+		body := `{"managedInstances": [{"instance": "zones/us-central1-a/instances/web-1", "instanceStatus": "RUNNING"}]}`
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(body))}, nil
+	})}
+
+	instances, err := fetchManagedInstances(context.Background(), client, "test-project", "zones/us-central1-a", "web")
+	if err != nil {
+		t.Fatalf("fetchManagedInstances: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("%d attempts, want 2", attempts)
+	}
+	if len(instances) != 1 || instances[0].InstanceStatus != "RUNNING" {
+		t.Errorf("instances = %+v, want one running instance", instances)
+	}
+}
+
+func TestHandleGetManagedInstanceGroupErrors(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/compute/v1/projects/test-project/zones/us-central1-a/instanceGroupManagers/gke-pool":
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"name":           "gke-pool",
+				"targetSize":     3,
+				"currentActions": map[string]int{"none": 1, "creating": 2},
+			})
+		case "/compute/v1/projects/test-project/zones/us-central1-a/instanceGroupManagers/gke-pool/listManagedInstances":
+			quota := map[string]interface{}{"errors": map[string]interface{}{"errors": []map[string]string{
+				{"code": "QUOTA_EXCEEDED", "message": "Quota 'CPUS' exceeded. Limit: 24.0 in region us-central1."},
+			}}}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"managedInstances": []map[string]interface{}{
+				{"instance": "zones/us-central1-a/instances/gke-pool-a", "instanceStatus": "RUNNING", "currentAction": "NONE"},
+				{"instance": "zones/us-central1-a/instances/gke-pool-b", "currentAction": "CREATING", "lastAttempt": quota},
+				{"instance": "zones/us-central1-a/instances/gke-pool-c", "currentAction": "CREATING", "lastAttempt": quota},
+			}})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	text := callTool(t, ctx, handleGetManagedInstanceGroupErrors, map[string]interface{}{
+		"project_id":     "test-project",
+		"instance_group": "gke-pool",
+		"zone":           "us-central1-a",
+	})
+
+	for _, s := range []string{
+		"- **Creating**: 2",
+		"### 1. QUOTA_EXCEEDED (2 instances)",
+		"Quota 'CPUS' exceeded. Limit: 24.0 in region us-central1.",
+		"**What it means**: A project or regional quota is exhausted.",
+		"- **Instances**: gke-pool-b, gke-pool-c",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if strings.Contains(text, "gke-pool-a") {
+		t.Errorf("result lists the running instance as failing:\n%s", text)
+	}
+}
+
+func TestFindRecreateLoops(t *testing.T) {
+	base := time.Date(2026, 10, 17, 1, 0, 0, 0, time.UTC)
+	at := func(minutes int, instance string) instanceRecreation {
+		return instanceRecreation{Time: base.Add(time.Duration(minutes) * time.Minute), Instance: instance}
+	}
+
+	// web-a is recreated four times in an hour; web-b's recreations are too
+	// spread out and web-c's too few to be a loop
+	recreations := []instanceRecreation{
+		at(0, "web-a"), at(0, "web-b"), at(20, "web-a"), at(30, "web-c"), at(40, "web-a"),
+		at(45, "web-c"), at(50, "web-a"), at(70, "web-b"), at(140, "web-b"), at(200, "web-a"),
+	}
+
+	loops := findRecreateLoops(recreations)
+	want := recreateLoop{Instance: "web-a", Count: 4, Start: base, End: base.Add(50 * time.Minute)}
+	if len(loops) != 1 || loops[0] != want {
+		t.Errorf("findRecreateLoops = %+v, want [%+v]", loops, want)
+	}
+}
+
+func TestHandleGetInstanceGroupAutohealingStatus(t *testing.T) {
+	recreation := func(timestamp, instance string) string {
+		return `{"timestamp": "` + timestamp + `", "resource": {"labels": {"zone": "us-central1-a"}},
+			"protoPayload": {"resourceName": "projects/test-project/zones/us-central1-a/instances/` + instance + `"}}`
+	}
+
+	logs := fakeLogging(t, func(filter string) string {
+		for _, s := range []string{`protoPayload.resourceName:"/instances/web-"`, `resource.labels.zone="us-central1-a"`} {
+			if !strings.Contains(filter, s) {
+				t.Errorf("filter doesn't contain %s: %s", s, filter)
+			}
+		}
+		return "[" + strings.Join([]string{
+			recreation("2026-10-17T01:50:00Z", "web-x1y2"),
+			recreation("2026-10-17T03:00:00Z", "web-z3w4"),
+			recreation("2026-10-17T01:00:00Z", "web-x1y2"),
+			recreation("2026-10-17T01:20:00Z", "web-x1y2"),
+			recreation("2026-10-17T02:00:00Z", "webhook-a1b2"),
+		}, ",") + "]"
+	})
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host != "compute.googleapis.com" {
+			logs.ServeHTTP(w, r)
+			return
+		}
+		if r.URL.Path != "/compute/v1/projects/test-project/zones/us-central1-a/instanceGroupManagers/web" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"name":             "web",
+			"baseInstanceName": "web",
+			"targetSize":       2,
+			"autoHealingPolicies": []map[string]interface{}{
+				{"healthCheck": "projects/test-project/global/healthChecks/web-hc", "initialDelaySec": 30},
+			},
+			"currentActions": map[string]int{"recreating": 1},
+		})
+	}))
+
+	text := callTool(t, ctx, handleGetInstanceGroupAutohealingStatus, map[string]interface{}{
+		"project_id":     "test-project",
+		"instance_group": "web",
+		"zone":           "us-central1-a",
+	})
+
+	// Another group's instances sharing the name prefix aren't counted
+	for _, s := range []string{
+		"- **Health Check**: web-hc\n- **Initial Delay**: 30s\n",
+		"- **Instances Being Recreated**: 1\n",
+		"Found 4 autohealing recreations",
+		"- **web-x1y2**: 3 recreations between 2026-10-17 01:00:00 and 2026-10-17 01:50:00\n",
+		"| 2026-10-17 01:00:00 | web-x1y2 | us-central1-a |\n| 2026-10-17 01:20:00 | web-x1y2 | us-central1-a |\n" +
+			"| 2026-10-17 01:50:00 | web-x1y2 | us-central1-a |\n| 2026-10-17 03:00:00 | web-z3w4 | us-central1-a |\n",
+		"1. A recreate loop means new instances never pass the health check",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if strings.Contains(text, "webhook-a1b2") {
+		t.Errorf("result includes another group's instance:\n%s", text)
+	}
+}
+
+func TestHandleGetInstanceGroupAutohealingStatusScope(t *testing.T) {
+	for _, args := range []map[string]interface{}{
+		{"project_id": "test-project", "instance_group": "web"},
+		{"project_id": "test-project", "instance_group": "web", "zone": "us-central1-a", "region": "us-central1"},
+	} {
+		result, err := handleGetInstanceGroupAutohealingStatus(context.Background(), newToolRequest(args), newTestAuthHandler(t, auth.ReadOnlyScopes))
+		if err != nil {
+			t.Fatalf("handleGetInstanceGroupAutohealingStatus returned error: %v", err)
+		}
+		if text, _ := resultText(result); !result.IsError || !strings.Contains(text, "exactly one of zone or region") {
+			t.Errorf("args %v = %q, want them rejected", args, text)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// configConnectorGroupSuffix is the API group suffix shared by every
+	// Config Connector resource kind
+	configConnectorGroupSuffix = ".cnrm.cloud.google.com"
+
+	// configConnectorOperatorGroup holds the operator's own configuration
+	// objects rather than managed Google Cloud resources
+	configConnectorOperatorGroup = "core.cnrm.cloud.google.com"
+
+	// configConnectorConcurrency caps how many resource kinds are listed at
+	// once. Config Connector installs a couple of hundred kinds.
+	configConnectorConcurrency = 8
+)
+
+// registerConfigConnectorTools registers tools that report Config Connector
+// reconcile failures
+func registerConfigConnectorTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get recent Config Connector errors tool
+	getConfigConnectorErrors := mcp.NewTool("get_recent_config_connector_errors",
+		mcp.WithDescription("Lists Config Connector resources whose reconcile is failing, from their Ready status condition, with the resource kind, name, failure reason and the error Config Connector reported, most recent first. Config Connector keeps retrying failed resources without surfacing errors anywhere else, so the Google Cloud resource silently drifts from its manifest."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("The Kubernetes namespace (optional, defaults to all namespaces)"),
+		),
+	)
+
+	getConfigConnectorErrorsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetRecentConfigConnectorErrors(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getConfigConnectorErrors, getConfigConnectorErrorsHandler)
+
+	return nil
+}
+
+// configConnectorKind is a listable Config Connector resource kind found
+// through API discovery
+type configConnectorKind struct {
+	GroupVersion string
+	Resource     string
+	Kind         string
+}
+
+// kccCondition is a Config Connector status condition
+type kccCondition struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason"`
+	Message            string `json:"message"`
+	LastTransitionTime string `json:"lastTransitionTime"`
+}
+
+// kccResource is the subset of a Config Connector resource used by the tools
+type kccResource struct {
+	Kind     string         `json:"kind"`
+	Metadata kubeObjectMeta `json:"metadata"`
+	Status   struct {
+		Conditions []kccCondition `json:"conditions"`
+	} `json:"status"`
+}
+
+// readyCondition returns the resource's Ready condition, if reported
+func (r kccResource) readyCondition() (kccCondition, bool) {
+	for _, cond := range r.Status.Conditions {
+		if cond.Type == "Ready" {
+			return cond, true
+		}
+	}
+	return kccCondition{}, false
+}
+
+// reconcileFailure returns the Ready condition of a resource whose reconcile
+// failed. Resources not yet reconciled, or still being updated, haven't
+// failed.
+func (r kccResource) reconcileFailure() (kccCondition, bool) {
+	cond, ok := r.readyCondition()
+	if !ok || cond.Status == "True" || cond.Reason == "Updating" {
+		return kccCondition{}, false
+	}
+	return cond, true
+}
+
+// kccFailure is a Config Connector resource whose reconcile failed
+type kccFailure struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Condition kccCondition
+}
+
+// configConnectorFailures returns the resources whose reconcile failed, most
+// recent transition first
+func configConnectorFailures(resources []kccResource) []kccFailure {
+	var failures []kccFailure
+	for _, resource := range resources {
+		cond, failed := resource.reconcileFailure()
+		if !failed {
+			continue
+		}
+		failures = append(failures, kccFailure{
+			Kind:      resource.Kind,
+			Namespace: resource.Metadata.Namespace,
+			Name:      resource.Metadata.Name,
+			Condition: cond,
+		})
+	}
+	sort.SliceStable(failures, func(i, j int) bool {
+		// RFC 3339 timestamps in UTC sort lexically
+		return failures[i].Condition.LastTransitionTime > failures[j].Condition.LastTransitionTime
+	})
+	return failures
+}
+
+// fetchConfigConnectorKinds discovers the Config Connector resource kinds
+// served by the cluster. It returns none when Config Connector isn't
+// installed.
+func fetchConfigConnectorKinds(ctx context.Context, kube *kubeClient) ([]configConnectorKind, error) {
+	var groupList struct {
+		Groups []struct {
+			Name             string `json:"name"`
+			PreferredVersion struct {
+				GroupVersion string `json:"groupVersion"`
+			} `json:"preferredVersion"`
+		} `json:"groups"`
+	}
+	if err := kube.get(ctx, "/apis", nil, &groupList); err != nil {
+		return nil, fmt.Errorf("error listing API groups: %w", err)
+	}
+
+	var kinds []configConnectorKind
+	for _, group := range groupList.Groups {
+		if !strings.HasSuffix(group.Name, configConnectorGroupSuffix) || group.Name == configConnectorOperatorGroup {
+			continue
+		}
+
+		groupVersion := group.PreferredVersion.GroupVersion
+		var resourceList struct {
+			Resources []struct {
+				Name       string   `json:"name"`
+				Kind       string   `json:"kind"`
+				Namespaced bool     `json:"namespaced"`
+				Verbs      []string `json:"verbs"`
+			} `json:"resources"`
+		}
+		if err := kube.get(ctx, "/apis/"+groupVersion, nil, &resourceList); err != nil {
+			return nil, fmt.Errorf("error discovering %s resources: %w", groupVersion, err)
+		}
+
+		for _, resource := range resourceList.Resources {
+			// Skip subresources such as status
+			if strings.Contains(resource.Name, "/") || !resource.Namespaced || !containsString(resource.Verbs, "list") {
+				continue
+			}
+			kinds = append(kinds, configConnectorKind{GroupVersion: groupVersion, Resource: resource.Name, Kind: resource.Kind})
+		}
+	}
+
+	sort.Slice(kinds, func(i, j int) bool {
+		return kinds[i].Kind < kinds[j].Kind
+	})
+	return kinds, nil
+}
+
+// kindError records a resource kind that couldn't be listed
+type kindError struct {
+	Kind  string
+	Error string
+}
+
+// fetchConfigConnectorResources lists the resources of each kind in the
+// namespace, or all namespaces when it's empty, a few kinds at a time
+func fetchConfigConnectorResources(ctx context.Context, kube *kubeClient, kinds []configConnectorKind, namespace string) ([]kccResource, []kindError) {
+	resources := make([][]kccResource, len(kinds))
+	errs := make([]error, len(kinds))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < configConnectorConcurrency && w < len(kinds); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				kind := kinds[i]
+				path := fmt.Sprintf("/apis/%s/%s", kind.GroupVersion, kind.Resource)
+				if namespace != "" {
+					path = fmt.Sprintf("/apis/%s/namespaces/%s/%s", kind.GroupVersion, url.PathEscape(namespace), kind.Resource)
+				}
+
+				var list struct {
+					Items []kccResource `json:"items"`
+				}
+				errs[i] = kube.get(ctx, path, nil, &list)
+
+				// List items omit their kind
+				for j := range list.Items {
+					list.Items[j].Kind = kind.Kind
+				}
+				resources[i] = list.Items
+			}
+		}()
+	}
+	for i := range kinds {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var merged []kccResource
+	var failed []kindError
+	for i, kind := range kinds {
+		if errs[i] != nil {
+			// A kind can disappear if Config Connector is upgraded mid-listing
+			if !isKubeNotFound(errs[i]) {
+				failed = append(failed, kindError{Kind: kind.Kind, Error: errs[i].Error()})
+			}
+			continue
+		}
+		merged = append(merged, resources[i]...)
+	}
+	return merged, failed
+}
+
+// handleGetRecentConfigConnectorErrors handles the get_recent_config_connector_errors tool request
+func handleGetRecentConfigConnectorErrors(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error connecting to cluster: %v", err)), nil
+	}
+
+	kinds, err := fetchConfigConnectorKinds(ctx, kube)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error discovering Config Connector resources: %v", err)), nil
+	}
+	if len(kinds) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("Config Connector isn't installed in cluster %s: the cluster serves no %s resource kinds.", clusterName, strings.TrimPrefix(configConnectorGroupSuffix, "."))), nil
+	}
+
+	resources, kindErrors := fetchConfigConnectorResources(ctx, kube, kinds, namespace)
+	if len(kindErrors) == len(kinds) {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing Config Connector resources: %s", kindErrors[0].Error)), nil
+	}
+	failures := configConnectorFailures(resources)
+
+	scope := "all namespaces"
+	if namespace != "" {
+		scope = "namespace " + namespace
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# Config Connector Errors in Cluster %s\n\n", clusterName)
+	result += fmt.Sprintf("Checked %d resources of %d kinds in %s.\n\n", len(resources), len(kinds), scope)
+
+	if len(kindErrors) > 0 {
+		result += "**Some resource kinds couldn't be listed:**\n\n"
+		for _, kindErr := range kindErrors {
+			result += fmt.Sprintf("- %s: %s\n", kindErr.Kind, kindErr.Error)
+		}
+		result += "\n"
+	}
+
+	if len(failures) == 0 {
+		result += "No Config Connector resources are failing to reconcile.\n"
+		return mcp.NewToolResultText(result), nil
+	}
+
+	result += "| Kind | Resource | Reason | Since |\n"
+	result += "| ---- | -------- | ------ | ----- |\n"
+	now := time.Now()
+	for _, failure := range failures {
+		since := "-"
+		if failure.Condition.LastTransitionTime != "" {
+			since = formatAge(failure.Condition.LastTransitionTime, now) + " ago"
+		}
+		result += fmt.Sprintf("| %s | %s/%s | %s | %s |\n", failure.Kind, failure.Namespace, failure.Name, valueOrDash(failure.Condition.Reason), since)
+	}
+	result += "\n"
+
+	result += "## Reconcile Errors\n\n"
+	for _, failure := range failures {
+		result += fmt.Sprintf("### %s %s/%s\n\n", failure.Kind, failure.Namespace, failure.Name)
+		result += fmt.Sprintf("```\n%s\n```\n\n", valueOrDash(strings.TrimSpace(failure.Condition.Message)))
+	}
+
+	result += "## Recommended Actions\n\n"
+	result += "1. For UpdateFailed, fix the error Config Connector reported, usually a missing IAM permission for its service account, an invalid field or an exhausted quota\n"
+	result += "2. For DependencyNotFound or DependencyNotReady, check the referenced resource exists and is itself Ready; errors often cascade from one failing dependency\n"
+	result += "3. For ManagementConflict, make sure only one Config Connector namespace manages the Google Cloud resource\n"
+	result += "4. Use get_cluster_events_stream to see the UpdateFailed events Config Connector recorded for each attempt\n"
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,80 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestConfigConnectorFailures(t *testing.T) {
+	resources := decodeJSON[[]kccResource](t, `[
+		{"kind": "SQLInstance", "metadata": {"namespace": "infra", "name": "ready"}, "status": {"conditions": [{"type": "Ready", "status": "True", "reason": "UpToDate"}]}},
+		{"kind": "SQLInstance", "metadata": {"namespace": "infra", "name": "updating"}, "status": {"conditions": [{"type": "Ready", "status": "False", "reason": "Updating"}]}},
+		{"kind": "SQLInstance", "metadata": {"namespace": "infra", "name": "new"}},
+		{"kind": "IAMPolicyMember", "metadata": {"namespace": "infra", "name": "older"}, "status": {"conditions": [
+			{"type": "Ready", "status": "False", "reason": "DependencyNotFound", "lastTransitionTime": "2026-10-16T09:00:00Z"}]}},
+		{"kind": "SQLInstance", "metadata": {"namespace": "infra", "name": "newer"}, "status": {"conditions": [
+			{"type": "Ready", "status": "False", "reason": "UpdateFailed", "lastTransitionTime": "2026-10-17T01:00:00Z"}]}}
+	]`)
+
+	// Only failed reconciles are reported, most recent first
+	failures := configConnectorFailures(resources)
+	if len(failures) != 2 || failures[0].Name != "newer" || failures[1].Name != "older" {
+		t.Errorf("configConnectorFailures = %+v, want newer then older", failures)
+	}
+}
+
+func TestHandleGetRecentConfigConnectorErrors(t *testing.T) {
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/apis":
+			w.Write([]byte(`{"groups": [
+				{"name": "apps", "preferredVersion": {"groupVersion": "apps/v1"}},
+				{"name": "core.cnrm.cloud.google.com", "preferredVersion": {"groupVersion": "core.cnrm.cloud.google.com/v1beta1"}},
+				{"name": "sql.cnrm.cloud.google.com", "preferredVersion": {"groupVersion": "sql.cnrm.cloud.google.com/v1beta1"}}
+			]}`))
+		case "/apis/sql.cnrm.cloud.google.com/v1beta1":
+			w.Write([]byte(`{"resources": [
+				{"name": "sqlinstances", "kind": "SQLInstance", "namespaced": true, "verbs": ["get", "list", "watch"]},
+				{"name": "sqlinstances/status", "kind": "SQLInstance", "namespaced": true, "verbs": ["get"]}
+			]}`))
+		case "/apis/sql.cnrm.cloud.google.com/v1beta1/namespaces/infra/sqlinstances":
+			w.Write([]byte(`{"items": [
+				{"metadata": {"namespace": "infra", "name": "orders-db"}, "status": {"conditions": [{"type": "Ready", "status": "False", "reason": "UpdateFailed",
+					"message": "Update call failed: error applying desired state: googleapi: Error 403: The client is not authorized to make this request., notAuthorized",
+					"lastTransitionTime": "2026-10-17T01:00:00Z"}]}},
+				{"metadata": {"namespace": "infra", "name": "users-db"}, "status": {"conditions": [{"type": "Ready", "status": "True", "reason": "UpToDate"}]}}
+			]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"kind": "Status", "reason": "NotFound"})
+		}
+	}))
+
+	text := callClusterTool(t, context.Background(), handleGetRecentConfigConnectorErrors, clusterName, map[string]interface{}{"namespace": "infra"})
+
+	for _, s := range []string{
+		"Checked 2 resources of 1 kinds in namespace infra.",
+		"| SQLInstance | infra/orders-db | UpdateFailed | ",
+		"### SQLInstance infra/orders-db\n\n```\nUpdate call failed: error applying desired state: googleapi: Error 403",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if strings.Contains(text, "users-db") {
+		t.Errorf("result includes a Ready resource:\n%s", text)
+	}
+}
+
+func TestHandleGetRecentConfigConnectorErrorsNotInstalled(t *testing.T) {
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"groups": [{"name": "apps", "preferredVersion": {"groupVersion": "apps/v1"}}]}`))
+	}))
+
+	text := callClusterTool(t, context.Background(), handleGetRecentConfigConnectorErrors, clusterName, nil)
+	if !strings.Contains(text, "Config Connector isn't installed in cluster "+clusterName) {
+		t.Errorf("result doesn't explain Config Connector isn't installed:\n%s", text)
+	}
+}
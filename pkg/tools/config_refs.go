@@ -0,0 +1,374 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerConfigRefTools registers tools that check ConfigMap and Secret references
+func registerConfigRefTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get config map and secret refs tool
+	getConfigRefs := mcp.NewTool("get_config_map_and_secret_refs",
+		mcp.WithDescription("Lists the ConfigMaps and Secrets a pod or deployment references through env, envFrom and volumes, and checks that each referenced object and key exists, explaining CreateContainerConfigError and stuck ContainerCreating pods. Secret values are never read out, only whether keys exist."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The Kubernetes namespace"),
+		),
+		mcp.WithString("pod_name",
+			mcp.Description("The name of the pod to check (either pod_name or deployment_name is required)"),
+		),
+		mcp.WithString("deployment_name",
+			mcp.Description("The name of the deployment to check (either pod_name or deployment_name is required)"),
+		),
+	)
+
+	getConfigRefsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetConfigMapAndSecretRefs(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getConfigRefs, getConfigRefsHandler)
+
+	return nil
+}
+
+// kubeObjectKeyRef is a reference to a key of a ConfigMap or Secret
+type kubeObjectKeyRef struct {
+	Name     string `json:"name"`
+	Key      string `json:"key"`
+	Optional *bool  `json:"optional"`
+}
+
+// kubeVolumeSourceRef is a ConfigMap or Secret volume source, or a projected
+// volume source. Secret volumes name the object in SecretName.
+type kubeVolumeSourceRef struct {
+	Name       string `json:"name"`
+	SecretName string `json:"secretName"`
+	Items      []struct {
+		Key string `json:"key"`
+	} `json:"items"`
+	Optional *bool `json:"optional"`
+}
+
+// kubeContainerRefs is the subset of a container spec that references
+// ConfigMaps and Secrets
+type kubeContainerRefs struct {
+	Name string `json:"name"`
+	Env  []struct {
+		Name      string `json:"name"`
+		ValueFrom *struct {
+			ConfigMapKeyRef *kubeObjectKeyRef `json:"configMapKeyRef"`
+			SecretKeyRef    *kubeObjectKeyRef `json:"secretKeyRef"`
+		} `json:"valueFrom"`
+	} `json:"env"`
+	EnvFrom []struct {
+		ConfigMapRef *kubeObjectKeyRef `json:"configMapRef"`
+		SecretRef    *kubeObjectKeyRef `json:"secretRef"`
+	} `json:"envFrom"`
+}
+
+// kubePodSpecRefs is the subset of a pod spec that references ConfigMaps and
+// Secrets
+type kubePodSpecRefs struct {
+	InitContainers []kubeContainerRefs `json:"initContainers"`
+	Containers     []kubeContainerRefs `json:"containers"`
+	Volumes        []struct {
+		Name      string               `json:"name"`
+		ConfigMap *kubeVolumeSourceRef `json:"configMap"`
+		Secret    *kubeVolumeSourceRef `json:"secret"`
+		Projected *struct {
+			Sources []struct {
+				ConfigMap *kubeVolumeSourceRef `json:"configMap"`
+				Secret    *kubeVolumeSourceRef `json:"secret"`
+			} `json:"sources"`
+		} `json:"projected"`
+	} `json:"volumes"`
+}
+
+// configRef is a single reference from a pod spec to a ConfigMap or Secret,
+// or to one of its keys
+type configRef struct {
+	Kind     string
+	Name     string
+	Key      string
+	Optional bool
+	Source   string
+}
+
+// configRefs enumerates the ConfigMap and Secret references of a pod spec
+func configRefs(spec kubePodSpecRefs) []configRef {
+	var refs []configRef
+	add := func(kind, name, key string, optional *bool, source string) {
+		refs = append(refs, configRef{Kind: kind, Name: name, Key: key, Optional: optional != nil && *optional, Source: source})
+	}
+
+	containers := append(append([]kubeContainerRefs{}, spec.InitContainers...), spec.Containers...)
+	for _, c := range containers {
+		for _, env := range c.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			source := fmt.Sprintf("container %s env %s", c.Name, env.Name)
+			if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil {
+				add("ConfigMap", ref.Name, ref.Key, ref.Optional, source)
+			}
+			if ref := env.ValueFrom.SecretKeyRef; ref != nil {
+				add("Secret", ref.Name, ref.Key, ref.Optional, source)
+			}
+		}
+		for _, envFrom := range c.EnvFrom {
+			source := fmt.Sprintf("container %s envFrom", c.Name)
+			if ref := envFrom.ConfigMapRef; ref != nil {
+				add("ConfigMap", ref.Name, "", ref.Optional, source)
+			}
+			if ref := envFrom.SecretRef; ref != nil {
+				add("Secret", ref.Name, "", ref.Optional, source)
+			}
+		}
+	}
+
+	addVolume := func(kind string, ref *kubeVolumeSourceRef, source string) {
+		name := ref.Name
+		if kind == "Secret" && ref.SecretName != "" {
+			name = ref.SecretName
+		}
+		if len(ref.Items) == 0 {
+			add(kind, name, "", ref.Optional, source)
+			return
+		}
+		for _, item := range ref.Items {
+			add(kind, name, item.Key, ref.Optional, source)
+		}
+	}
+
+	for _, v := range spec.Volumes {
+		source := fmt.Sprintf("volume %s", v.Name)
+		if v.ConfigMap != nil {
+			addVolume("ConfigMap", v.ConfigMap, source)
+		}
+		if v.Secret != nil {
+			addVolume("Secret", v.Secret, source)
+		}
+		if v.Projected != nil {
+			for _, s := range v.Projected.Sources {
+				if s.ConfigMap != nil {
+					addVolume("ConfigMap", s.ConfigMap, source)
+				}
+				if s.Secret != nil {
+					addVolume("Secret", s.Secret, source)
+				}
+			}
+		}
+	}
+
+	return refs
+}
+
+// configObject records whether a referenced object exists and its keys
+type configObject struct {
+	Exists bool
+	Keys   map[string]bool
+	// Values holds the data of a ConfigMap; it is never set for Secrets
+	Values map[string]string
+	Err    error
+}
+
+// fetchConfigObjectKeys looks up a ConfigMap or Secret and returns its keys,
+// and for a ConfigMap its values. Secret values are decoded as raw JSON and
+// discarded.
+func fetchConfigObjectKeys(ctx context.Context, kube *kubeClient, namespace, kind, name string) configObject {
+	resource := "configmaps"
+	if kind == "Secret" {
+		resource = "secrets"
+	}
+
+	var object struct {
+		Data       map[string]json.RawMessage `json:"data"`
+		BinaryData map[string]json.RawMessage `json:"binaryData"`
+	}
+	err := kube.get(ctx, fmt.Sprintf("/api/v1/namespaces/%s/%s/%s", namespace, resource, name), nil, &object)
+	if isKubeNotFound(err) {
+		return configObject{}
+	}
+	if err != nil {
+		return configObject{Err: err}
+	}
+
+	result := configObject{Exists: true, Keys: make(map[string]bool)}
+	for key := range object.Data {
+		result.Keys[key] = true
+	}
+	for key := range object.BinaryData {
+		result.Keys[key] = true
+	}
+
+	if kind == "ConfigMap" {
+		result.Values = make(map[string]string)
+		for key, raw := range object.Data {
+			var value string
+			if json.Unmarshal(raw, &value) == nil {
+				result.Values[key] = value
+			}
+		}
+	}
+
+	return result
+}
+
+// configRefProblem returns why a reference can't be resolved, or an empty
+// string if it can
+func configRefProblem(ref configRef, object configObject) string {
+	switch {
+	case object.Err != nil:
+		return fmt.Sprintf("could not check: %v", object.Err)
+	case !object.Exists:
+		return fmt.Sprintf("%s not found", ref.Kind)
+	case ref.Key != "" && !object.Keys[ref.Key]:
+		return fmt.Sprintf("key %q not found", ref.Key)
+	}
+	return ""
+}
+
+// handleGetConfigMapAndSecretRefs handles the get_config_map_and_secret_refs tool request
+func handleGetConfigMapAndSecretRefs(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	namespace, ok := request.Params.Arguments["namespace"].(string)
+	if !ok || namespace == "" {
+		return mcp.NewToolResultError("namespace must be a non-empty string"), nil
+	}
+
+	podName, _ := request.Params.Arguments["pod_name"].(string)
+	deploymentName, _ := request.Params.Arguments["deployment_name"].(string)
+	if (podName == "") == (deploymentName == "") {
+		return mcp.NewToolResultError("exactly one of pod_name or deployment_name must be provided"), nil
+	}
+
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error connecting to cluster: %v", err)), nil
+	}
+
+	var spec kubePodSpecRefs
+	target := ""
+	if podName != "" {
+		target = fmt.Sprintf("pod %s/%s", namespace, podName)
+		var pod struct {
+			Spec kubePodSpecRefs `json:"spec"`
+		}
+		if err := kube.get(ctx, fmt.Sprintf("/api/v1/namespaces/%s/pods/%s", namespace, podName), nil, &pod); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error getting pod: %v", err)), nil
+		}
+		spec = pod.Spec
+	} else {
+		target = fmt.Sprintf("deployment %s/%s", namespace, deploymentName)
+		var deployment struct {
+			Spec struct {
+				Template struct {
+					Spec kubePodSpecRefs `json:"spec"`
+				} `json:"template"`
+			} `json:"spec"`
+		}
+		if err := kube.get(ctx, fmt.Sprintf("/apis/apps/v1/namespaces/%s/deployments/%s", namespace, deploymentName), nil, &deployment); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error getting deployment: %v", err)), nil
+		}
+		spec = deployment.Spec.Template.Spec
+	}
+
+	refs := configRefs(spec)
+	if len(refs) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("The %s doesn't reference any ConfigMaps or Secrets.", target)), nil
+	}
+
+	// Look up each referenced object once
+	objects := make(map[string]configObject)
+	for _, ref := range refs {
+		key := ref.Kind + "/" + ref.Name
+		if _, ok := objects[key]; !ok {
+			objects[key] = fetchConfigObjectKeys(ctx, kube, namespace, ref.Kind, ref.Name)
+		}
+	}
+
+	sort.SliceStable(refs, func(i, j int) bool {
+		if refs[i].Kind != refs[j].Kind {
+			return refs[i].Kind < refs[j].Kind
+		}
+		return refs[i].Name < refs[j].Name
+	})
+
+	var broken []string
+	rows := ""
+	for _, ref := range refs {
+		status := "OK"
+		if problem := configRefProblem(ref, objects[ref.Kind+"/"+ref.Name]); problem != "" {
+			if ref.Optional {
+				status = problem + " (optional, ignored)"
+			} else {
+				status = "**" + problem + "**"
+				broken = append(broken, fmt.Sprintf("%s %s (%s): %s", ref.Kind, ref.Name, ref.Source, problem))
+			}
+		}
+
+		key := ref.Key
+		if key == "" {
+			key = "(all keys)"
+		}
+		rows += fmt.Sprintf("| %s | %s | %s | %s | %s |\n", ref.Kind, ref.Name, key, ref.Source, status)
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# ConfigMap and Secret References of %s\n\n", target)
+	if len(broken) > 0 {
+		result += fmt.Sprintf("**%d of %d references can't be resolved**, so affected containers fail to start:\n\n", len(broken), len(refs))
+		for _, b := range broken {
+			result += fmt.Sprintf("- %s\n", b)
+		}
+		result += "\n"
+	} else {
+		result += fmt.Sprintf("All %d references resolve to existing objects and keys.\n\n", len(refs))
+	}
+
+	result += "| Kind | Name | Key | Referenced By | Status |\n"
+	result += "| ---- | ---- | --- | ------------- | ------ |\n"
+	result += rows
+
+	if len(broken) > 0 {
+		result += "\n## Recommended Actions\n\n"
+		result += "1. Missing env references cause CreateContainerConfigError; missing volume references leave the pod in ContainerCreating with a FailedMount event\n"
+		result += "2. Create the missing object or key, or fix the name in the workload spec; pods start on their next retry without a restart\n"
+		result += "3. If the reference is genuinely optional, mark it `optional: true` so the pod can start without it\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
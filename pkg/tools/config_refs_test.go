@@ -0,0 +1,91 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestConfigRefs(t *testing.T) {
+	var spec kubePodSpecRefs
+	err := json.Unmarshal([]byte(`{
+		"initContainers": [{"name": "migrate", "envFrom": [{"secretRef": {"name": "db"}}]}],
+		"containers": [{"name": "app", "env": [
+			{"name": "PLAIN", "value": "x"},
+			{"name": "LEVEL", "valueFrom": {"configMapKeyRef": {"name": "app-config", "key": "log_level"}}},
+			{"name": "TOKEN", "valueFrom": {"secretKeyRef": {"name": "api", "key": "token", "optional": true}}}
+		]}],
+		"volumes": [
+			{"name": "certs", "secret": {"secretName": "tls", "items": [{"key": "tls.crt"}, {"key": "tls.key"}]}},
+			{"name": "bundle", "projected": {"sources": [{"configMap": {"name": "ca"}}]}}
+		]
+	}`), &spec)
+	if err != nil {
+		t.Fatalf("parsing pod spec: %v", err)
+	}
+
+	want := []configRef{
+		{Kind: "Secret", Name: "db", Source: "container migrate envFrom"},
+		{Kind: "ConfigMap", Name: "app-config", Key: "log_level", Source: "container app env LEVEL"},
+		{Kind: "Secret", Name: "api", Key: "token", Optional: true, Source: "container app env TOKEN"},
+		{Kind: "Secret", Name: "tls", Key: "tls.crt", Source: "volume certs"},
+		{Kind: "Secret", Name: "tls", Key: "tls.key", Source: "volume certs"},
+		{Kind: "ConfigMap", Name: "ca", Source: "volume bundle"},
+	}
+	got := configRefs(spec)
+	if len(got) != len(want) {
+		t.Fatalf("configRefs = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ref %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestHandleGetConfigMapAndSecretRefs(t *testing.T) {
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/namespaces/web/pods/app-0":
+			w.Write([]byte(`{"spec": {"containers": [{"name": "app",
+				"env": [
+					{"name": "LEVEL", "valueFrom": {"configMapKeyRef": {"name": "app-config", "key": "log_level"}}},
+					{"name": "REGION", "valueFrom": {"configMapKeyRef": {"name": "app-config", "key": "region"}}},
+					{"name": "PASSWORD", "valueFrom": {"secretKeyRef": {"name": "db", "key": "password"}}},
+					{"name": "TOKEN", "valueFrom": {"secretKeyRef": {"name": "api", "key": "token", "optional": true}}}
+				]}]}}`))
+		case "/api/v1/namespaces/web/configmaps/app-config":
+			w.Write([]byte(`{"data": {"log_level": "debug"}}`))
+		case "/api/v1/namespaces/web/secrets/db":
+			w.Write([]byte(`{"data": {"password": "aHVudGVyMg=="}}`))
+		default:
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"kind": "Status", "reason": "NotFound"})
+		}
+	}))
+
+	text := callClusterTool(t, context.Background(), handleGetConfigMapAndSecretRefs, clusterName, map[string]interface{}{
+		"namespace": "web",
+		"pod_name":  "app-0",
+	})
+
+	for _, s := range []string{
+		"**1 of 4 references can't be resolved**",
+		"- ConfigMap app-config (container app env REGION): key \"region\" not found\n",
+		"| ConfigMap | app-config | log_level | container app env LEVEL | OK |\n" +
+			"| ConfigMap | app-config | region | container app env REGION | **key \"region\" not found** |\n" +
+			"| Secret | api | token | container app env TOKEN | Secret not found (optional, ignored) |\n" +
+			"| Secret | db | password | container app env PASSWORD | OK |\n",
+		"## Recommended Actions",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	for _, s := range []string{"aHVudGVyMg==", "hunter2"} {
+		if strings.Contains(text, s) {
+			t.Errorf("result reveals the secret value %q:\n%s", s, text)
+		}
+	}
+}
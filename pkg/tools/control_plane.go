@@ -0,0 +1,332 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerControlPlaneTools registers tools that check control plane and kubelet health
+func registerControlPlaneTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get kubelet and API server health tool
+	getHealth := mcp.NewTool("get_kubelet_and_apiserver_health",
+		mcp.WithDescription("Checks GKE control plane health from API server request error rates and latency, and kubelet health from node Ready conditions, flagging an elevated API server error rate, slow requests or unhealthy kubelets"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range to analyse in hours (default: 1)"),
+		),
+	)
+
+	getHealthHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetKubeletAndAPIServerHealth(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getHealth, getHealthHandler)
+
+	return nil
+}
+
+const (
+	// apiserverErrorRateThreshold is the fraction of API server requests
+	// failing with 5xx that is flagged as elevated
+	apiserverErrorRateThreshold = 0.01
+	// apiserverThrottleRateThreshold is the fraction of requests rejected with
+	// 429 that is flagged as elevated
+	apiserverThrottleRateThreshold = 0.05
+	// apiserverSlowP99 is the p99 request latency flagged as slow
+	apiserverSlowP99 = time.Second
+)
+
+// apiserverRequestRates is the API server request rate split by outcome
+type apiserverRequestRates struct {
+	Total     float64
+	Errors    float64
+	Throttled float64
+}
+
+// errorRate returns the fraction of requests failing with 5xx
+func (r apiserverRequestRates) errorRate() float64 {
+	if r.Total == 0 {
+		return 0
+	}
+	return r.Errors / r.Total
+}
+
+// throttleRate returns the fraction of requests rejected with 429
+func (r apiserverRequestRates) throttleRate() float64 {
+	if r.Total == 0 {
+		return 0
+	}
+	return r.Throttled / r.Total
+}
+
+// summariseAPIServerRequests totals apiserver_request_total series grouped by
+// response code
+func summariseAPIServerRequests(series []timeSeries) apiserverRequestRates {
+	var rates apiserverRequestRates
+	for _, ts := range series {
+		v, ok := ts.latestValue()
+		if !ok {
+			continue
+		}
+		code := ts.Metric.Labels["code"]
+		rates.Total += v
+		switch {
+		case code == "429":
+			rates.Throttled += v
+		case strings.HasPrefix(code, "5"):
+			rates.Errors += v
+		}
+	}
+	return rates
+}
+
+// verbLatency is the p99 API server latency for a request verb
+type verbLatency struct {
+	Verb string
+	P99  time.Duration
+}
+
+// apiserverVerbLatencies returns the p99 latency per verb, slowest first
+func apiserverVerbLatencies(series []timeSeries) []verbLatency {
+	var latencies []verbLatency
+	for _, ts := range series {
+		v, ok := ts.latestValue()
+		if !ok {
+			continue
+		}
+		latencies = append(latencies, verbLatency{
+			Verb: ts.Metric.Labels["verb"],
+			P99:  time.Duration(v * float64(time.Second)),
+		})
+	}
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i].P99 > latencies[j].P99
+	})
+	return latencies
+}
+
+// unhealthyKubelet is a node whose kubelet isn't reporting Ready
+type unhealthyKubelet struct {
+	Node    string
+	Status  string
+	Reason  string
+	Message string
+}
+
+// findUnhealthyKubelets returns nodes whose Ready condition isn't True. An
+// Unknown status means the kubelet has stopped posting status entirely.
+func findUnhealthyKubelets(nodes []kubeNode) []unhealthyKubelet {
+	var unhealthy []unhealthyKubelet
+	for _, node := range nodes {
+		if node.isReady() {
+			continue
+		}
+		kubelet := unhealthyKubelet{Node: node.Metadata.Name, Status: "Missing"}
+		for _, cond := range node.Status.Conditions {
+			if cond.Type == "Ready" {
+				kubelet.Status = cond.Status
+				kubelet.Reason = cond.Reason
+				kubelet.Message = cond.Message
+			}
+		}
+		unhealthy = append(unhealthy, kubelet)
+	}
+	sort.Slice(unhealthy, func(i, j int) bool {
+		return unhealthy[i].Node < unhealthy[j].Node
+	})
+	return unhealthy
+}
+
+// handleGetKubeletAndAPIServerHealth handles the get_kubelet_and_apiserver_health tool request
+func handleGetKubeletAndAPIServerHealth(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	timeRangeHours := 1.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	// Calculate time range
+	endTime := time.Now()
+	startTime := endTime.Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+
+	// GKE exports API server metrics through Managed Service for Prometheus
+	// when control plane metrics are enabled
+	promFilter := fmt.Sprintf(`resource.type="prometheus_target" AND resource.labels.location="%s" AND resource.labels.cluster="%s"`,
+		location, clusterName)
+
+	requests, err := fetchTimeSeries(ctx, client, projectID, timeSeriesQuery{
+		Filter:             `metric.type="prometheus.googleapis.com/apiserver_request_total/counter" AND ` + promFilter,
+		StartTime:          startTime,
+		EndTime:            endTime,
+		AlignmentPeriod:    endTime.Sub(startTime),
+		PerSeriesAligner:   "ALIGN_RATE",
+		CrossSeriesReducer: "REDUCE_SUM",
+		GroupByFields:      []string{"metric.labels.code"},
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying API server requests: %v", err)), nil
+	}
+
+	// WATCH and CONNECT requests are long-running, so their latency isn't meaningful
+	latencySeries, err := fetchTimeSeries(ctx, client, projectID, timeSeriesQuery{
+		Filter:             `metric.type="prometheus.googleapis.com/apiserver_request_duration_seconds/histogram" AND metric.labels.verb!="WATCH" AND metric.labels.verb!="CONNECT" AND ` + promFilter,
+		StartTime:          startTime,
+		EndTime:            endTime,
+		AlignmentPeriod:    endTime.Sub(startTime),
+		PerSeriesAligner:   "ALIGN_DELTA",
+		CrossSeriesReducer: "REDUCE_PERCENTILE_99",
+		GroupByFields:      []string{"metric.labels.verb"},
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying API server latency: %v", err)), nil
+	}
+
+	// Kubelet health comes from the API server itself, which may be the thing
+	// that's struggling, so a failure here is reported rather than returned
+	var kubelets []unhealthyKubelet
+	nodeCount := 0
+	var nodeErr error
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err != nil {
+		nodeErr = err
+	} else {
+		var nodes struct {
+			Items []kubeNode `json:"items"`
+		}
+		if err := kube.get(ctx, "/api/v1/nodes", nil, &nodes); err != nil {
+			nodeErr = err
+		} else {
+			nodeCount = len(nodes.Items)
+			kubelets = findUnhealthyKubelets(nodes.Items)
+		}
+	}
+
+	rates := summariseAPIServerRequests(requests)
+	latencies := apiserverVerbLatencies(latencySeries)
+
+	errorsElevated := rates.errorRate() >= apiserverErrorRateThreshold
+	throttleElevated := rates.throttleRate() >= apiserverThrottleRateThreshold
+	var slowVerbs []verbLatency
+	for _, latency := range latencies {
+		if latency.P99 >= apiserverSlowP99 {
+			slowVerbs = append(slowVerbs, latency)
+		}
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# Control Plane and Kubelet Health for Cluster %s\n\n", clusterName)
+
+	var problems []string
+	if errorsElevated {
+		problems = append(problems, fmt.Sprintf("API server 5xx error rate is elevated at %.2f%%", rates.errorRate()*100))
+	}
+	if throttleElevated {
+		problems = append(problems, fmt.Sprintf("%.2f%% of API server requests are being throttled (429)", rates.throttleRate()*100))
+	}
+	if len(slowVerbs) > 0 {
+		problems = append(problems, fmt.Sprintf("%d request verbs have a p99 latency above %s", len(slowVerbs), apiserverSlowP99))
+	}
+	if len(kubelets) > 0 {
+		problems = append(problems, fmt.Sprintf("%d of %d kubelets are not Ready", len(kubelets), nodeCount))
+	}
+	if nodeErr != nil {
+		problems = append(problems, "the API server could not be reached to check node status")
+	}
+
+	if len(problems) == 0 {
+		result += fmt.Sprintf("No control plane or kubelet problems found in the last %.1f hours.\n\n", timeRangeHours)
+	} else {
+		result += "**Problems found:**\n\n"
+		for _, problem := range problems {
+			result += "- " + problem + "\n"
+		}
+		result += "\n"
+	}
+
+	result += "## API Server Requests\n\n"
+	if rates.Total == 0 {
+		result += "No API server request metrics were found. These require control plane metrics to be enabled on the cluster (`--monitoring=SYSTEM,API_SERVER`).\n\n"
+	} else {
+		result += fmt.Sprintf("- **Request rate**: %.1f/s\n", rates.Total)
+		result += fmt.Sprintf("- **5xx error rate**: %.2f%% (%.2f/s)\n", rates.errorRate()*100, rates.Errors)
+		result += fmt.Sprintf("- **Throttled (429)**: %.2f%% (%.2f/s)\n\n", rates.throttleRate()*100, rates.Throttled)
+	}
+
+	if len(latencies) > 0 {
+		result += "## API Server Latency (p99)\n\n"
+		result += "| Verb | p99 |\n"
+		result += "| ---- | --- |\n"
+		for _, latency := range latencies {
+			p99 := latency.P99.Round(time.Millisecond).String()
+			if latency.P99 >= apiserverSlowP99 {
+				p99 = "**" + p99 + "**"
+			}
+			result += fmt.Sprintf("| %s | %s |\n", latency.Verb, p99)
+		}
+		result += "\n"
+	}
+
+	result += "## Kubelets\n\n"
+	switch {
+	case nodeErr != nil:
+		result += fmt.Sprintf("Node status could not be retrieved: %v\n\n", nodeErr)
+	case len(kubelets) == 0:
+		result += fmt.Sprintf("All %d kubelets are reporting Ready.\n\n", nodeCount)
+	default:
+		result += "| Node | Ready | Reason | Message |\n"
+		result += "| ---- | ----- | ------ | ------- |\n"
+		for _, kubelet := range kubelets {
+			result += fmt.Sprintf("| %s | %s | %s | %s |\n", kubelet.Node, kubelet.Status, kubelet.Reason, kubelet.Message)
+		}
+		result += "\n"
+	}
+
+	if len(problems) > 0 {
+		result += "## Recommended Actions\n\n"
+		result += "1. For API server errors or latency, check for a control plane upgrade or repair in progress, and for clients or controllers flooding the API server\n"
+		result += "2. Throttling usually means a client is exceeding API Priority and Fairness limits; look for runaway controllers or list calls without pagination\n"
+		result += "3. Kubelets with an Unknown status have stopped reporting; check the node's VM health and kubelet logs, or let node auto-repair recreate it\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
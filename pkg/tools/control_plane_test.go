@@ -0,0 +1,81 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHandleGetKubeletAndAPIServerHealth(t *testing.T) {
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items": [
+			{"metadata": {"name": "node-a"}, "status": {"conditions": [{"type": "Ready", "status": "True"}]}},
+			{"metadata": {"name": "node-c"}, "status": {"conditions": []}},
+			{"metadata": {"name": "node-b"}, "status": {"conditions": [{"type": "Ready", "status": "Unknown", "reason": "NodeStatusUnknown", "message": "Kubelet stopped posting node status."}]}}
+		]}`))
+	}))
+
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter := r.URL.Query().Get("filter")
+		if !strings.Contains(filter, `resource.labels.cluster="`+clusterName+`"`) {
+			t.Errorf("filter doesn't select the cluster: %s", filter)
+		}
+		var series []timeSeries
+		switch {
+		case strings.Contains(filter, "apiserver_request_total"):
+			series = []timeSeries{
+				testSeries(map[string]string{"code": "200"}, nil, 90),
+				testSeries(map[string]string{"code": "500"}, nil, 2),
+				testSeries(map[string]string{"code": "429"}, nil, 8),
+			}
+		case strings.Contains(filter, "apiserver_request_duration_seconds"):
+			series = []timeSeries{
+				testSeries(map[string]string{"verb": "GET"}, nil, 0.2),
+				testSeries(map[string]string{"verb": "LIST"}, nil, 2.5),
+			}
+		default:
+			t.Errorf("unexpected filter %s", filter)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"timeSeries": series})
+	}))
+
+	text := callClusterTool(t, ctx, handleGetKubeletAndAPIServerHealth, clusterName, nil)
+
+	for _, s := range []string{
+		"- API server 5xx error rate is elevated at 2.00%\n" +
+			"- 8.00% of API server requests are being throttled (429)\n" +
+			"- 1 request verbs have a p99 latency above 1s\n" +
+			"- 2 of 3 kubelets are not Ready\n",
+		"- **Request rate**: 100.0/s",
+		"| LIST | **2.5s** |\n| GET | 200ms |\n",
+		"| node-b | Unknown | NodeStatusUnknown | Kubelet stopped posting node status. |\n| node-c | Missing |  |  |\n",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
+
+func TestHandleGetKubeletAndAPIServerHealthUnreachable(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	}))
+
+	// Node status comes from the API server, which may be what's failing
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusForbidden, map[string]interface{}{"kind": "Status", "reason": "Forbidden"})
+	}))
+
+	text := callClusterTool(t, ctx, handleGetKubeletAndAPIServerHealth, clusterName, nil)
+
+	for _, s := range []string{
+		"- the API server could not be reached to check node status",
+		"No API server request metrics were found. These require control plane metrics to be enabled",
+		"Node status could not be retrieved: ",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
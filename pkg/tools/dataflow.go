@@ -0,0 +1,457 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GCP Dataflow API base URL
+const gcpDataflowBaseURL = "https://dataflow.googleapis.com/v1b3"
+
+// registerDataflowTools registers all Dataflow related tools
+func registerDataflowTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register list Dataflow jobs tool
+	listDataflowJobs := mcp.NewTool("list_dataflow_jobs",
+		mcp.WithDescription("Lists Dataflow jobs in a region with their state, system lag and data watermark age, flagging FAILED jobs and streaming jobs falling behind"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("region",
+			mcp.Required(),
+			mcp.Description("The Dataflow region (e.g., us-central1)"),
+		),
+		mcp.WithNumber("threshold_seconds",
+			mcp.Description("System lag or watermark age in seconds above which a job is flagged (default: 300)"),
+		),
+		mcp.WithNumber("max_results",
+			mcp.Description("Maximum number of jobs to return (default: 50)"),
+		),
+	)
+
+	listDataflowJobsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleListDataflowJobs(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, listDataflowJobs, listDataflowJobsHandler)
+
+	// Register get Dataflow job status tool
+	getDataflowJobStatus := mcp.NewTool("get_dataflow_job_status",
+		mcp.WithDescription("Reports a Dataflow job's state, system lag, data watermark and recent error messages, flagging a FAILED job or one falling behind"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("region",
+			mcp.Required(),
+			mcp.Description("The Dataflow region (e.g., us-central1)"),
+		),
+		mcp.WithString("job_id",
+			mcp.Required(),
+			mcp.Description("The Dataflow job ID"),
+		),
+		mcp.WithNumber("threshold_seconds",
+			mcp.Description("System lag or watermark age in seconds above which the job is flagged (default: 300)"),
+		),
+	)
+
+	getDataflowJobStatusHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetDataflowJobStatus(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getDataflowJobStatus, getDataflowJobStatusHandler)
+
+	return nil
+}
+
+// dataflowJob is the subset of a Dataflow Job used by the tools
+type dataflowJob struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	Type             string `json:"type"`
+	CurrentState     string `json:"currentState"`
+	CurrentStateTime string `json:"currentStateTime"`
+	CreateTime       string `json:"createTime"`
+	StartTime        string `json:"startTime"`
+	Location         string `json:"location"`
+}
+
+// state returns the job state without its JOB_STATE_ prefix
+func (j dataflowJob) state() string {
+	return strings.TrimPrefix(j.CurrentState, "JOB_STATE_")
+}
+
+// isStreaming reports whether the job is a streaming job
+func (j dataflowJob) isStreaming() bool {
+	return j.Type == "JOB_TYPE_STREAMING"
+}
+
+// dataflowJobLag is the most recent system lag and data watermark age of a
+// job, in seconds
+type dataflowJobLag struct {
+	SystemLag       float64
+	HasSystemLag    bool
+	WatermarkAge    float64
+	HasWatermarkAge bool
+}
+
+// dataflowJobProblems returns why a job needs attention, if anything: it
+// failed, or its system lag or watermark age exceeds threshold seconds
+func dataflowJobProblems(job dataflowJob, lag dataflowJobLag, threshold float64) []string {
+	var problems []string
+	if job.CurrentState == "JOB_STATE_FAILED" {
+		problems = append(problems, "job FAILED")
+	}
+	if lag.HasSystemLag && lag.SystemLag > threshold {
+		problems = append(problems, fmt.Sprintf("system lag %s is above %s", formatLagSeconds(lag.SystemLag), formatLagSeconds(threshold)))
+	}
+	if lag.HasWatermarkAge && lag.WatermarkAge > threshold {
+		problems = append(problems, fmt.Sprintf("data watermark is %s behind", formatLagSeconds(lag.WatermarkAge)))
+	}
+	return problems
+}
+
+// formatLagSeconds formats a lag in seconds as a duration such as 4m30s
+func formatLagSeconds(seconds float64) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
+
+// dataflowRequest sends a GET request to the Dataflow API and decodes the
+// response into out
+func dataflowRequest(ctx context.Context, client *http.Client, apiURL string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := doWithRetry(client, req)
+	if err != nil {
+		return fmt.Errorf("error making request to Dataflow API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error from Dataflow API: %s", resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("error parsing response: %w", err)
+	}
+
+	return nil
+}
+
+// fetchDataflowJobs lists up to maxResults jobs in a region
+func fetchDataflowJobs(ctx context.Context, client *http.Client, projectID, region string, maxResults int) ([]dataflowJob, bool, error) {
+	var jobs []dataflowJob
+	pageToken := ""
+	for {
+		params := url.Values{}
+		params.Set("view", "JOB_VIEW_SUMMARY")
+		params.Set("pageSize", fmt.Sprintf("%d", maxResults-len(jobs)))
+		if pageToken != "" {
+			params.Set("pageToken", pageToken)
+		}
+		apiURL := fmt.Sprintf("%s/projects/%s/locations/%s/jobs?%s", gcpDataflowBaseURL, projectID, region, params.Encode())
+
+		var response struct {
+			Jobs          []dataflowJob `json:"jobs"`
+			NextPageToken string        `json:"nextPageToken"`
+		}
+		if err := dataflowRequest(ctx, client, apiURL, &response); err != nil {
+			return nil, false, err
+		}
+
+		jobs = append(jobs, response.Jobs...)
+		pageToken = response.NextPageToken
+		if pageToken == "" {
+			return jobs, false, nil
+		}
+		if len(jobs) >= maxResults {
+			return jobs[:maxResults], true, nil
+		}
+	}
+}
+
+// dataflowJobMessage is an error or warning message logged for a job
+type dataflowJobMessage struct {
+	Time              string `json:"time"`
+	MessageText       string `json:"messageText"`
+	MessageImportance string `json:"messageImportance"`
+}
+
+// fetchDataflowJobErrors lists the most recent error messages of a job
+func fetchDataflowJobErrors(ctx context.Context, client *http.Client, projectID, region, jobID string, maxResults int) ([]dataflowJobMessage, error) {
+	params := url.Values{}
+	params.Set("minimumImportance", "JOB_MESSAGE_ERROR")
+	params.Set("pageSize", fmt.Sprintf("%d", maxResults))
+	apiURL := fmt.Sprintf("%s/projects/%s/locations/%s/jobs/%s/messages?%s",
+		gcpDataflowBaseURL, projectID, region, url.PathEscape(jobID), params.Encode())
+
+	var response struct {
+		JobMessages []dataflowJobMessage `json:"jobMessages"`
+	}
+	if err := dataflowRequest(ctx, client, apiURL, &response); err != nil {
+		return nil, err
+	}
+
+	return response.JobMessages, nil
+}
+
+// fetchDataflowJobLags gets the latest system lag and data watermark age of
+// the region's jobs from Cloud Monitoring, keyed by job name. Batch jobs don't
+// report these metrics.
+func fetchDataflowJobLags(ctx context.Context, client *http.Client, projectID, region, jobName string) (map[string]dataflowJobLag, error) {
+	endTime := time.Now()
+	startTime := endTime.Add(-15 * time.Minute)
+
+	filter := fmt.Sprintf(`resource.type="dataflow_job" AND resource.labels.region="%s"`, region)
+	if jobName != "" {
+		filter += fmt.Sprintf(` AND resource.labels.job_name="%s"`, jobName)
+	}
+
+	lags := make(map[string]dataflowJobLag)
+	for _, metric := range []string{"system_lag", "data_watermark_age"} {
+		series, err := fetchTimeSeries(ctx, client, projectID, timeSeriesQuery{
+			Filter:           fmt.Sprintf(`metric.type="dataflow.googleapis.com/job/%s" AND %s`, metric, filter),
+			StartTime:        startTime,
+			EndTime:          endTime,
+			AlignmentPeriod:  time.Minute,
+			PerSeriesAligner: "ALIGN_MAX",
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ts := range series {
+			value, ok := ts.latestValue()
+			if !ok {
+				continue
+			}
+			name := ts.Resource.Labels["job_name"]
+			lag := lags[name]
+			if metric == "system_lag" {
+				lag.SystemLag, lag.HasSystemLag = value, true
+			} else {
+				lag.WatermarkAge, lag.HasWatermarkAge = value, true
+			}
+			lags[name] = lag
+		}
+	}
+
+	return lags, nil
+}
+
+// dataflowThreshold returns the threshold_seconds argument, defaulting to 5 minutes
+func dataflowThreshold(request mcp.CallToolRequest) float64 {
+	if val, ok := request.Params.Arguments["threshold_seconds"].(float64); ok && val > 0 {
+		return val
+	}
+	return 300
+}
+
+// handleListDataflowJobs handles the list_dataflow_jobs tool request
+func handleListDataflowJobs(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	region, ok := request.Params.Arguments["region"].(string)
+	if !ok || region == "" {
+		return mcp.NewToolResultError("region must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	threshold := dataflowThreshold(request)
+
+	maxResults := 50
+	if val, ok := request.Params.Arguments["max_results"].(float64); ok && val > 0 {
+		maxResults = int(val)
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	jobs, hasMore, err := fetchDataflowJobs(ctx, client, projectID, region, maxResults)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing Dataflow jobs: %v", err)), nil
+	}
+
+	if len(jobs) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No Dataflow jobs found in project %s region %s.", projectID, region)), nil
+	}
+
+	// Lag is shown when available; the job list is still useful without it
+	lags, lagErr := fetchDataflowJobLags(ctx, client, projectID, region, "")
+
+	// Format the results
+	result := fmt.Sprintf("# Dataflow Jobs in %s (%s)\n\n", projectID, region)
+	result += "| Job | ID | Type | State | System Lag | Watermark Age | Problems |\n"
+	result += "| --- | -- | ---- | ----- | ---------- | ------------- | -------- |\n"
+
+	flagged := 0
+	for _, job := range jobs {
+		lag := lags[job.Name]
+		systemLag, watermarkAge := "-", "-"
+		if lag.HasSystemLag {
+			systemLag = formatLagSeconds(lag.SystemLag)
+		}
+		if lag.HasWatermarkAge {
+			watermarkAge = formatLagSeconds(lag.WatermarkAge)
+		}
+
+		jobType := "batch"
+		if job.isStreaming() {
+			jobType = "streaming"
+		}
+
+		problems := dataflowJobProblems(job, lag, threshold)
+		state := job.state()
+		problemText := "-"
+		if len(problems) > 0 {
+			flagged++
+			state = "**" + state + "**"
+			problemText = strings.Join(problems, "; ")
+		}
+
+		result += fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %s |\n",
+			job.Name, job.ID, jobType, state, systemLag, watermarkAge, problemText)
+	}
+
+	if hasMore {
+		result += fmt.Sprintf("\nShowing the first %d jobs; increase max_results to see more.\n", maxResults)
+	}
+	if lagErr != nil {
+		result += fmt.Sprintf("\nSystem lag and watermark age are unavailable: %v\n", lagErr)
+	}
+
+	if flagged > 0 {
+		result += fmt.Sprintf("\n%d jobs need attention.\n", flagged)
+		result += "\n## Recommended Actions\n\n"
+		result += "1. Use get_dataflow_job_status on flagged jobs to see their error messages\n"
+		result += "2. High system lag on a streaming job means elements wait too long to be processed; check for hot keys, slow external calls or too few workers\n"
+		result += "3. A watermark falling behind while system lag is low usually means a source is delayed or a partition is stuck\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// handleGetDataflowJobStatus handles the get_dataflow_job_status tool request
+func handleGetDataflowJobStatus(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	region, ok := request.Params.Arguments["region"].(string)
+	if !ok || region == "" {
+		return mcp.NewToolResultError("region must be a non-empty string"), nil
+	}
+
+	jobID, ok := request.Params.Arguments["job_id"].(string)
+	if !ok || jobID == "" {
+		return mcp.NewToolResultError("job_id must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	threshold := dataflowThreshold(request)
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	var job dataflowJob
+	apiURL := fmt.Sprintf("%s/projects/%s/locations/%s/jobs/%s?view=JOB_VIEW_SUMMARY",
+		gcpDataflowBaseURL, projectID, region, url.PathEscape(jobID))
+	if err := dataflowRequest(ctx, client, apiURL, &job); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting Dataflow job: %v", err)), nil
+	}
+
+	lags, lagErr := fetchDataflowJobLags(ctx, client, projectID, region, job.Name)
+	lag := lags[job.Name]
+
+	messages, messagesErr := fetchDataflowJobErrors(ctx, client, projectID, region, jobID, 10)
+
+	problems := dataflowJobProblems(job, lag, threshold)
+
+	// Format the results
+	result := fmt.Sprintf("# Dataflow Job %s\n\n", job.Name)
+	result += fmt.Sprintf("- **ID**: %s\n", job.ID)
+	jobType := "batch"
+	if job.isStreaming() {
+		jobType = "streaming"
+	}
+	result += fmt.Sprintf("- **Type**: %s\n", jobType)
+	result += fmt.Sprintf("- **State**: %s (since %s)\n", job.state(), formatTime(job.CurrentStateTime))
+	result += fmt.Sprintf("- **Created**: %s\n", formatTime(job.CreateTime))
+
+	switch {
+	case lagErr != nil:
+		result += fmt.Sprintf("- **System Lag**: unavailable (%v)\n", lagErr)
+	case lag.HasSystemLag || lag.HasWatermarkAge:
+		if lag.HasSystemLag {
+			result += fmt.Sprintf("- **System Lag**: %s\n", formatLagSeconds(lag.SystemLag))
+		}
+		if lag.HasWatermarkAge {
+			result += fmt.Sprintf("- **Data Watermark Age**: %s\n", formatLagSeconds(lag.WatermarkAge))
+		}
+	case job.isStreaming():
+		result += "- **System Lag**: no data in the last 15 minutes\n"
+	}
+	result += "\n"
+
+	if len(problems) > 0 {
+		result += "## Problems\n\n"
+		for _, problem := range problems {
+			result += fmt.Sprintf("- %s\n", problem)
+		}
+		result += "\n"
+	}
+
+	result += "## Recent Errors\n\n"
+	switch {
+	case messagesErr != nil:
+		result += fmt.Sprintf("Could not list job messages: %v\n\n", messagesErr)
+	case len(messages) == 0:
+		result += "No error messages reported for this job.\n\n"
+	default:
+		for _, message := range messages {
+			text := strings.TrimSpace(message.MessageText)
+			if len(text) > 500 {
+				text = text[:500] + "..."
+			}
+			result += fmt.Sprintf("### %s\n\n```\n%s\n```\n\n", formatTime(message.Time), text)
+		}
+	}
+
+	if len(problems) > 0 {
+		result += "## Recommended Actions\n\n"
+		if job.CurrentState == "JOB_STATE_FAILED" {
+			result += "1. The first error message usually holds the root cause; later ones are often consequences of it\n"
+			result += fmt.Sprintf("2. Use query_logs with resource.type=\"dataflow_step\" AND resource.labels.job_id=\"%s\" for worker logs\n", job.ID)
+		} else {
+			result += "1. High system lag means elements wait too long to be processed; check for hot keys, slow external calls or too few workers\n"
+			result += "2. A watermark falling behind while system lag is low usually means a source is delayed or a partition is stuck\n"
+			result += fmt.Sprintf("3. Use query_logs with resource.type=\"dataflow_step\" AND resource.labels.job_id=\"%s\" for worker logs\n", job.ID)
+		}
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,109 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// fakeDataflowLags serves Dataflow lag metrics: the ingest job is 20 minutes
+// behind, the enrich job is keeping up
+func fakeDataflowLags(t *testing.T, w http.ResponseWriter, r *http.Request) {
+	filter := r.URL.Query().Get("filter")
+	if !strings.Contains(filter, `resource.labels.region="us-central1"`) {
+		t.Errorf("filter doesn't select the region: %s", filter)
+	}
+	var series []timeSeries
+	switch {
+	case strings.Contains(filter, "job/system_lag"):
+		series = []timeSeries{
+			testSeries(nil, map[string]string{"job_name": "ingest"}, 1200),
+			testSeries(nil, map[string]string{"job_name": "enrich"}, 5),
+		}
+	case strings.Contains(filter, "job/data_watermark_age"):
+		series = []timeSeries{
+			testSeries(nil, map[string]string{"job_name": "ingest"}, 1500),
+			testSeries(nil, map[string]string{"job_name": "enrich"}, 30),
+		}
+	default:
+		t.Errorf("unexpected filter %s", filter)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"timeSeries": series})
+}
+
+func TestHandleListDataflowJobs(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Host + r.URL.Path {
+		case "dataflow.googleapis.com/v1b3/projects/test-project/locations/us-central1/jobs":
+			w.Write([]byte(`{"jobs": [
+				{"id": "2026-10-17_01", "name": "ingest", "type": "JOB_TYPE_STREAMING", "currentState": "JOB_STATE_RUNNING"},
+				{"id": "2026-10-17_02", "name": "enrich", "type": "JOB_TYPE_STREAMING", "currentState": "JOB_STATE_RUNNING"},
+				{"id": "2026-10-17_03", "name": "backfill", "type": "JOB_TYPE_BATCH", "currentState": "JOB_STATE_FAILED"}
+			]}`))
+		case "monitoring.googleapis.com/v3/projects/test-project/timeSeries":
+			fakeDataflowLags(t, w, r)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	text := callTool(t, ctx, handleListDataflowJobs, map[string]interface{}{
+		"project_id": "test-project",
+		"region":     "us-central1",
+	})
+
+	for _, s := range []string{
+		"| ingest | 2026-10-17_01 | streaming | **RUNNING** | 20m0s | 25m0s | system lag 20m0s is above 5m0s; data watermark is 25m0s behind |\n",
+		"| enrich | 2026-10-17_02 | streaming | RUNNING | 5s | 30s | - |\n",
+		"| backfill | 2026-10-17_03 | batch | **FAILED** | - | - | job FAILED |\n",
+		"2 jobs need attention.",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
+
+func TestHandleGetDataflowJobStatus(t *testing.T) {
+	const job = "dataflow.googleapis.com/v1b3/projects/test-project/locations/us-central1/jobs/2026-10-17_01"
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Host + r.URL.Path {
+		case job:
+			w.Write([]byte(`{"id": "2026-10-17_01", "name": "ingest", "type": "JOB_TYPE_STREAMING", "currentState": "JOB_STATE_RUNNING",
+				"currentStateTime": "2026-10-17T01:00:00Z", "createTime": "2026-10-17T00:55:00Z"}`))
+		case job + "/messages":
+			if got := r.URL.Query().Get("minimumImportance"); got != "JOB_MESSAGE_ERROR" {
+				t.Errorf("minimumImportance = %q, want errors only", got)
+			}
+			w.Write([]byte(`{"jobMessages": [{"time": "2026-10-17T01:30:00Z", "messageText": "Processing stuck in step Write for at least 05m00s"}]}`))
+		case "monitoring.googleapis.com/v3/projects/test-project/timeSeries":
+			if filter := r.URL.Query().Get("filter"); !strings.Contains(filter, `resource.labels.job_name="ingest"`) {
+				t.Errorf("filter doesn't select the job: %s", filter)
+			}
+			fakeDataflowLags(t, w, r)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	text := callTool(t, ctx, handleGetDataflowJobStatus, map[string]interface{}{
+		"project_id": "test-project",
+		"region":     "us-central1",
+		"job_id":     "2026-10-17_01",
+	})
+
+	for _, s := range []string{
+		"- **State**: RUNNING (since 2026-10-17 01:00:00)\n",
+		"- **System Lag**: 20m0s\n- **Data Watermark Age**: 25m0s\n",
+		"## Problems\n\n- system lag 20m0s is above 5m0s\n- data watermark is 25m0s behind\n",
+		"### 2026-10-17 01:30:00\n\n```\nProcessing stuck in step Write for at least 05m00s\n```\n",
+		"1. High system lag means elements wait too long to be processed",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerDescribePodTools registers tools that describe a single pod
+func registerDescribePodTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register describe pod tool
+	describePod := mcp.NewTool("describe_pod",
+		mcp.WithDescription("Describes a pod like kubectl describe: its node, conditions, container statuses with waiting and terminated reasons and exit codes, resource requests and limits, and the events recorded for it, highlighting scheduling failures"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The Kubernetes namespace"),
+		),
+		mcp.WithString("pod_name",
+			mcp.Required(),
+			mcp.Description("The name of the pod"),
+		),
+	)
+
+	describePodHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleDescribePod(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, describePod, describePodHandler)
+
+	return nil
+}
+
+// kubeContainerResources is the subset of a container spec describing its
+// image and resources
+type kubeContainerResources struct {
+	Name      string `json:"name"`
+	Image     string `json:"image"`
+	Resources struct {
+		Requests map[string]string `json:"requests"`
+		Limits   map[string]string `json:"limits"`
+	} `json:"resources"`
+}
+
+// kubePodDescription is the subset of a core/v1 Pod shown by describe_pod
+type kubePodDescription struct {
+	Metadata kubeObjectMeta `json:"metadata"`
+	Spec     struct {
+		NodeName       string                   `json:"nodeName"`
+		InitContainers []kubeContainerResources `json:"initContainers"`
+		Containers     []kubeContainerResources `json:"containers"`
+	} `json:"spec"`
+	Status struct {
+		Phase      string `json:"phase"`
+		Reason     string `json:"reason"`
+		Message    string `json:"message"`
+		QOSClass   string `json:"qosClass"`
+		StartTime  string `json:"startTime"`
+		Conditions []struct {
+			Type    string `json:"type"`
+			Status  string `json:"status"`
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		} `json:"conditions"`
+		InitContainerStatuses []kubeContainerStatus `json:"initContainerStatuses"`
+		ContainerStatuses     []kubeContainerStatus `json:"containerStatuses"`
+	} `json:"status"`
+}
+
+// containerStatus returns the status of the named container, if reported
+func (p kubePodDescription) containerStatus(name string) (kubeContainerStatus, bool) {
+	for _, statuses := range [][]kubeContainerStatus{p.Status.InitContainerStatuses, p.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			if status.Name == name {
+				return status, true
+			}
+		}
+	}
+	return kubeContainerStatus{}, false
+}
+
+// containerStateDescription describes a container's current state
+func containerStateDescription(status kubeContainerStatus) string {
+	switch state := status.State; {
+	case state.Waiting != nil:
+		description := "Waiting: " + valueOrDash(state.Waiting.Reason)
+		if state.Waiting.Message != "" {
+			description += " (" + state.Waiting.Message + ")"
+		}
+		return description
+	case state.Terminated != nil:
+		description := fmt.Sprintf("Terminated: %s, exit code %d", valueOrDash(state.Terminated.Reason), state.Terminated.ExitCode)
+		if message := strings.TrimSpace(state.Terminated.Message); message != "" {
+			description += " (" + message + ")"
+		}
+		return description
+	case state.Running != nil:
+		return "Running since " + state.Running.StartedAt
+	default:
+		return "Unknown"
+	}
+}
+
+// formatResourceList formats resource quantities as name=quantity, sorted by
+// name
+func formatResourceList(resources map[string]string) string {
+	if len(resources) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	quantities := make([]string, 0, len(names))
+	for _, name := range names {
+		quantities = append(quantities, fmt.Sprintf("%s=%s", name, resources[name]))
+	}
+	return strings.Join(quantities, ", ")
+}
+
+// podEvents returns the events recorded for a pod, newest first. Events for
+// an earlier pod with the same name are dropped.
+func podEvents(events []kubeEvent, uid string) []kubeEvent {
+	var matching []kubeEvent
+	for _, event := range events {
+		if event.InvolvedObject.UID == "" || event.InvolvedObject.UID == uid {
+			matching = append(matching, event)
+		}
+	}
+	return recentEvents(matching, time.Time{})
+}
+
+// handleDescribePod handles the describe_pod tool request
+func handleDescribePod(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	namespace, ok := request.Params.Arguments["namespace"].(string)
+	if !ok || namespace == "" {
+		return mcp.NewToolResultError("namespace must be a non-empty string"), nil
+	}
+
+	podName, ok := request.Params.Arguments["pod_name"].(string)
+	if !ok || podName == "" {
+		return mcp.NewToolResultError("pod_name must be a non-empty string"), nil
+	}
+
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error connecting to cluster: %v", err)), nil
+	}
+
+	var pod kubePodDescription
+	if err := kube.get(ctx, fmt.Sprintf("%s/%s", podsPath(namespace), podName), nil, &pod); err != nil {
+		if isKubeNotFound(err) {
+			return mcp.NewToolResultError(fmt.Sprintf("Pod %s not found in namespace %s.", podName, namespace)), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting pod: %v", err)), nil
+	}
+
+	query := url.Values{}
+	query.Set("fieldSelector", "involvedObject.kind=Pod,involvedObject.name="+podName)
+
+	var eventList struct {
+		Items []kubeEvent `json:"items"`
+	}
+	eventsErr := kube.get(ctx, fmt.Sprintf("/api/v1/namespaces/%s/events", url.PathEscape(namespace)), query, &eventList)
+	events := podEvents(eventList.Items, pod.Metadata.UID)
+
+	// Format the results
+	result := fmt.Sprintf("# Pod %s/%s\n\n", namespace, podName)
+	node := pod.Spec.NodeName
+	if node == "" {
+		node = "(not scheduled)"
+	}
+	result += fmt.Sprintf("- **Node**: %s\n", node)
+	result += fmt.Sprintf("- **Phase**: %s\n", pod.Status.Phase)
+	if pod.Status.Reason != "" {
+		result += fmt.Sprintf("- **Reason**: %s\n", pod.Status.Reason)
+	}
+	if pod.Status.Message != "" {
+		result += fmt.Sprintf("- **Message**: %s\n", pod.Status.Message)
+	}
+	result += fmt.Sprintf("- **Created**: %s (%s ago)\n", valueOrDash(pod.Metadata.CreationTimestamp), formatAge(pod.Metadata.CreationTimestamp, time.Now()))
+	if pod.Status.QOSClass != "" {
+		result += fmt.Sprintf("- **QoS Class**: %s\n", pod.Status.QOSClass)
+	}
+	if len(pod.Metadata.OwnerReferences) > 0 {
+		owner := pod.Metadata.OwnerReferences[0]
+		result += fmt.Sprintf("- **Controlled By**: %s/%s\n", owner.Kind, owner.Name)
+	}
+	result += "\n"
+
+	if len(pod.Status.Conditions) > 0 {
+		result += "## Conditions\n\n"
+		result += "| Type | Status | Reason | Message |\n"
+		result += "| ---- | ------ | ------ | ------- |\n"
+		for _, cond := range pod.Status.Conditions {
+			result += fmt.Sprintf("| %s | %s | %s | %s |\n", cond.Type, cond.Status, valueOrDash(cond.Reason), valueOrDash(cond.Message))
+		}
+		result += "\n"
+	}
+
+	result += "## Containers\n\n"
+	containers := append(append([]kubeContainerResources{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for i, c := range containers {
+		heading := "Container"
+		if i < len(pod.Spec.InitContainers) {
+			heading = "Init Container"
+		}
+		result += fmt.Sprintf("### %s %s\n\n", heading, c.Name)
+		result += fmt.Sprintf("- **Image**: %s\n", c.Image)
+
+		if status, ok := pod.containerStatus(c.Name); ok {
+			result += fmt.Sprintf("- **State**: %s\n", containerStateDescription(status))
+			result += fmt.Sprintf("- **Ready**: %t\n", status.Ready)
+			result += fmt.Sprintf("- **Restart Count**: %d\n", status.RestartCount)
+			if last := status.LastState.Terminated; last != nil {
+				result += fmt.Sprintf("- **Last Termination**: %s, exit code %d at %s\n", valueOrDash(last.Reason), last.ExitCode, valueOrDash(last.FinishedAt))
+				if message := strings.TrimSpace(last.Message); message != "" {
+					result += fmt.Sprintf("- **Last Termination Message**: %s\n", message)
+				}
+			}
+		} else {
+			result += "- **State**: not yet reported\n"
+		}
+
+		result += fmt.Sprintf("- **Requests**: %s\n", formatResourceList(c.Resources.Requests))
+		result += fmt.Sprintf("- **Limits**: %s\n", formatResourceList(c.Resources.Limits))
+		result += "\n"
+	}
+
+	var unschedulable []kubeEvent
+	for _, event := range events {
+		if event.Reason == "FailedScheduling" {
+			unschedulable = append(unschedulable, event)
+		}
+	}
+	if len(unschedulable) > 0 {
+		result += "## Scheduling Failures\n\n"
+		for _, event := range unschedulable {
+			result += fmt.Sprintf("- %s (x%d): %s\n", event.lastSeen().Format(time.RFC3339), event.occurrences(), event.Message)
+		}
+		result += "\nUse get_node_taints_and_affinity_conflicts to see which nodes the pod can't run on and why.\n\n"
+	}
+
+	result += "## Events\n\n"
+	switch {
+	case eventsErr != nil:
+		result += fmt.Sprintf("Error listing events: %v\n", eventsErr)
+	case len(events) == 0:
+		result += "No events recorded. Events expire after an hour by default, so older ones are no longer available.\n"
+	default:
+		result += "| Last Seen | Type | Reason | Count | Source | Message |\n"
+		result += "| --------- | ---- | ------ | ----- | ------ | ------- |\n"
+		for _, event := range events {
+			message := strings.ReplaceAll(event.Message, "\n", " ")
+			message = strings.ReplaceAll(message, "|", "\\|")
+			result += fmt.Sprintf("| %s | %s | %s | %d | %s | %s |\n",
+				event.lastSeen().Format(time.RFC3339), event.Type, event.Reason, event.occurrences(), valueOrDash(event.Source.Component), message)
+		}
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
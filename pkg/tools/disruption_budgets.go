@@ -0,0 +1,201 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerDisruptionBudgetTools registers tools that inspect PodDisruptionBudgets
+func registerDisruptionBudgetTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get pod disruption budget status tool
+	getPDBStatus := mcp.NewTool("get_pod_disruption_budget_status",
+		mcp.WithDescription("Lists PodDisruptionBudgets with their minAvailable/maxUnavailable, healthy vs desired pods and allowed disruptions, flagging budgets that currently block evictions and so stall node drains and upgrades"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("The Kubernetes namespace (if not provided, all namespaces are searched)"),
+		),
+	)
+
+	getPDBStatusHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetPodDisruptionBudgetStatus(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getPDBStatus, getPDBStatusHandler)
+
+	return nil
+}
+
+// pdbStatus summarises a PodDisruptionBudget
+type pdbStatus struct {
+	Namespace          string
+	Name               string
+	Budget             string
+	CurrentHealthy     int32
+	DesiredHealthy     int32
+	ExpectedPods       int32
+	DisruptionsAllowed int32
+}
+
+// blocking reports whether the budget currently prevents any eviction. A
+// budget that matches no pods never blocks anything.
+func (p pdbStatus) blocking() bool {
+	return p.DisruptionsAllowed == 0 && p.ExpectedPods > 0
+}
+
+// reason explains why a blocking budget allows no disruptions
+func (p pdbStatus) reason() string {
+	if p.CurrentHealthy < p.DesiredHealthy {
+		return fmt.Sprintf("only %d of the %d required pods are healthy, so evicting any would breach the budget", p.CurrentHealthy, p.DesiredHealthy)
+	}
+	return "the budget requires every matching pod to stay up, so no pod can be evicted at the current replica count"
+}
+
+// pdbBudget describes a budget's minAvailable or maxUnavailable setting
+func pdbBudget(pdb kubePodDisruptionBudget) string {
+	if pdb.Spec.MinAvailable != nil {
+		return fmt.Sprintf("minAvailable: %v", pdb.Spec.MinAvailable)
+	}
+	if pdb.Spec.MaxUnavailable != nil {
+		return fmt.Sprintf("maxUnavailable: %v", pdb.Spec.MaxUnavailable)
+	}
+	return "none"
+}
+
+// evaluateDisruptionBudgets summarises budgets, listing blocking ones first
+func evaluateDisruptionBudgets(pdbs []kubePodDisruptionBudget) []pdbStatus {
+	statuses := make([]pdbStatus, 0, len(pdbs))
+	for _, pdb := range pdbs {
+		statuses = append(statuses, pdbStatus{
+			Namespace:          pdb.Metadata.Namespace,
+			Name:               pdb.Metadata.Name,
+			Budget:             pdbBudget(pdb),
+			CurrentHealthy:     pdb.Status.CurrentHealthy,
+			DesiredHealthy:     pdb.Status.DesiredHealthy,
+			ExpectedPods:       pdb.Status.ExpectedPods,
+			DisruptionsAllowed: pdb.Status.DisruptionsAllowed,
+		})
+	}
+
+	sort.SliceStable(statuses, func(i, j int) bool {
+		if statuses[i].blocking() != statuses[j].blocking() {
+			return statuses[i].blocking()
+		}
+		if statuses[i].Namespace != statuses[j].Namespace {
+			return statuses[i].Namespace < statuses[j].Namespace
+		}
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	return statuses
+}
+
+// pdbsPath returns the API path for listing PodDisruptionBudgets, across all
+// namespaces when namespace is empty
+func pdbsPath(namespace string) string {
+	if namespace == "" {
+		return "/apis/policy/v1/poddisruptionbudgets"
+	}
+	return fmt.Sprintf("/apis/policy/v1/namespaces/%s/poddisruptionbudgets", namespace)
+}
+
+// handleGetPodDisruptionBudgetStatus handles the get_pod_disruption_budget_status tool request
+func handleGetPodDisruptionBudgetStatus(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error connecting to cluster: %v", err)), nil
+	}
+
+	var pdbs struct {
+		Items []kubePodDisruptionBudget `json:"items"`
+	}
+	if err := kube.get(ctx, pdbsPath(namespace), nil, &pdbs); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing PodDisruptionBudgets: %v", err)), nil
+	}
+
+	scope := "all namespaces"
+	if namespace != "" {
+		scope = fmt.Sprintf("namespace %s", namespace)
+	}
+
+	if len(pdbs.Items) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No PodDisruptionBudgets found in %s of cluster %s.", scope, clusterName)), nil
+	}
+
+	statuses := evaluateDisruptionBudgets(pdbs.Items)
+
+	blocking := 0
+	for _, status := range statuses {
+		if status.blocking() {
+			blocking++
+		}
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# PodDisruptionBudgets in Cluster %s\n\n", clusterName)
+	result += fmt.Sprintf("Found %d PodDisruptionBudgets in %s, %d currently blocking evictions.\n\n", len(statuses), scope, blocking)
+
+	if blocking > 0 {
+		result += "## Blocking Evictions\n\n"
+		for _, status := range statuses {
+			if status.blocking() {
+				result += fmt.Sprintf("- **%s/%s** (%s): %s\n", status.Namespace, status.Name, status.Budget, status.reason())
+			}
+		}
+		result += "\n"
+	}
+
+	result += "## All PodDisruptionBudgets\n\n"
+	result += "| Namespace | Name | Budget | Healthy | Expected Pods | Disruptions Allowed |\n"
+	result += "| --------- | ---- | ------ | ------- | ------------- | ------------------- |\n"
+	for _, status := range statuses {
+		allowed := fmt.Sprintf("%d", status.DisruptionsAllowed)
+		if status.blocking() {
+			allowed = "**0 (blocking)**"
+		}
+		result += fmt.Sprintf("| %s | %s | %s | %d/%d | %d | %s |\n",
+			status.Namespace, status.Name, status.Budget, status.CurrentHealthy, status.DesiredHealthy, status.ExpectedPods, allowed)
+	}
+
+	if blocking > 0 {
+		result += "\n## Recommended Actions\n\n"
+		result += "1. Node drains and GKE upgrades wait on blocking budgets; GKE gives up after an hour and evicts anyway, so stalled upgrades often trace back here\n"
+		result += "2. Where pods are unhealthy, fix them first so the budget has headroom again\n"
+		result += "3. Where the budget requires every pod to stay up, scale the workload up or relax minAvailable/maxUnavailable so at least one disruption is allowed\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
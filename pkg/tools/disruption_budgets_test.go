@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHandleGetPodDisruptionBudgetStatus(t *testing.T) {
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/policy/v1/poddisruptionbudgets" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"items": [
+			{"metadata": {"namespace": "web", "name": "frontend"}, "spec": {"maxUnavailable": "25%"},
+			 "status": {"currentHealthy": 4, "desiredHealthy": 3, "expectedPods": 4, "disruptionsAllowed": 1}},
+			{"metadata": {"namespace": "db", "name": "postgres"}, "spec": {"minAvailable": 3},
+			 "status": {"currentHealthy": 3, "desiredHealthy": 3, "expectedPods": 3, "disruptionsAllowed": 0}},
+			{"metadata": {"namespace": "web", "name": "api"}, "spec": {"minAvailable": 2},
+			 "status": {"currentHealthy": 1, "desiredHealthy": 2, "expectedPods": 3, "disruptionsAllowed": 0}},
+			{"metadata": {"namespace": "jobs", "name": "unused"}, "spec": {"minAvailable": 1},
+			 "status": {"expectedPods": 0, "disruptionsAllowed": 0}}
+		]}`))
+	}))
+
+	text := callClusterTool(t, context.Background(), handleGetPodDisruptionBudgetStatus, clusterName, nil)
+
+	for _, s := range []string{
+		"Found 4 PodDisruptionBudgets in all namespaces, 2 currently blocking evictions.",
+		// Blocking budgets explain whether pods are unhealthy or the budget is too tight
+		"- **db/postgres** (minAvailable: 3): the budget requires every matching pod to stay up",
+		"- **web/api** (minAvailable: 2): only 1 of the 2 required pods are healthy",
+		"| db | postgres | minAvailable: 3 | 3/3 | 3 | **0 (blocking)** |\n" +
+			"| web | api | minAvailable: 2 | 1/2 | 3 | **0 (blocking)** |\n" +
+			"| jobs | unused | minAvailable: 1 | 0/0 | 0 | 0 |\n" +
+			"| web | frontend | maxUnavailable: 25% | 4/3 | 4 | 1 |\n",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
+
+func TestHandleGetPodDisruptionBudgetStatusNamespace(t *testing.T) {
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/policy/v1/namespaces/web/poddisruptionbudgets" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"items": []}`))
+	}))
+
+	text := callClusterTool(t, context.Background(), handleGetPodDisruptionBudgetStatus, clusterName, map[string]interface{}{"namespace": "web"})
+	if !strings.Contains(text, "No PodDisruptionBudgets found in namespace web") {
+		t.Errorf("result doesn't report no budgets:\n%s", text)
+	}
+}
@@ -0,0 +1,232 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// clusterDNSLabelSelector matches the cluster DNS pods, which keep the
+	// kube-dns label whether they run kube-dns or CoreDNS
+	clusterDNSLabelSelector = "k8s-app=kube-dns"
+
+	// dnsFailureRateThreshold is the fraction of DNS responses failing with
+	// SERVFAIL or REFUSED that is flagged as elevated
+	dnsFailureRateThreshold = 0.01
+)
+
+// registerDNSTools registers tools that diagnose cluster DNS
+func registerDNSTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get DNS resolution check tool
+	getDNSCheck := mcp.NewTool("get_dns_resolution_check",
+		mcp.WithDescription("Checks cluster DNS from the cluster's perspective without exec access: reports the health of the kube-dns or CoreDNS pods and the DNS response codes they returned over a window, flagging an elevated SERVFAIL or REFUSED rate cluster-wide. Response codes come from CoreDNS metrics collected by Managed Service for Prometheus."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range to analyse in hours (default: 1)"),
+		),
+	)
+
+	getDNSCheckHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetDNSResolutionCheck(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getDNSCheck, getDNSCheckHandler)
+
+	return nil
+}
+
+// dnsResponseBreakdown is the number of DNS responses by response code
+type dnsResponseBreakdown struct {
+	Codes map[string]float64
+	Total float64
+}
+
+// newDNSResponseBreakdown totals coredns_dns_responses_total series grouped
+// by rcode
+func newDNSResponseBreakdown(series []timeSeries) dnsResponseBreakdown {
+	breakdown := dnsResponseBreakdown{Codes: sumSeriesByLabel(series, "rcode")}
+	for _, count := range breakdown.Codes {
+		breakdown.Total += count
+	}
+	return breakdown
+}
+
+// rate returns the fraction of responses with the given code
+func (b dnsResponseBreakdown) rate(code string) float64 {
+	if b.Total == 0 {
+		return 0
+	}
+	return b.Codes[code] / b.Total
+}
+
+// failureRate returns the fraction of responses that failed on the server
+// side. NXDOMAIN isn't a failure: with the default ndots:5, most lookups of
+// external names try the search domains first and get NXDOMAIN.
+func (b dnsResponseBreakdown) failureRate() float64 {
+	return b.rate("SERVFAIL") + b.rate("REFUSED")
+}
+
+// elevated reports whether the failure rate is at or above the threshold
+func (b dnsResponseBreakdown) elevated() bool {
+	return b.Total > 0 && b.failureRate() >= dnsFailureRateThreshold
+}
+
+// handleGetDNSResolutionCheck handles the get_dns_resolution_check tool request
+func handleGetDNSResolutionCheck(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	timeRangeHours := 1.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	// Pod health and metrics come from different APIs, so a failure of
+	// either is reported rather than returned
+	var pods []kubePod
+	kube, podsErr := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if podsErr == nil {
+		query := url.Values{}
+		query.Set("labelSelector", clusterDNSLabelSelector)
+		var podList struct {
+			Items []kubePod `json:"items"`
+		}
+		podsErr = kube.get(ctx, podsPath("kube-system"), query, &podList)
+		pods = podList.Items
+	}
+
+	endTime := time.Now()
+	startTime := endTime.Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+	series, metricsErr := fetchTimeSeries(ctx, client, projectID, timeSeriesQuery{
+		Filter: fmt.Sprintf(`metric.type="prometheus.googleapis.com/coredns_dns_responses_total/counter" AND resource.type="prometheus_target" AND resource.labels.location="%s" AND resource.labels.cluster="%s"`,
+			location, clusterName),
+		StartTime:          startTime,
+		EndTime:            endTime,
+		AlignmentPeriod:    endTime.Sub(startTime),
+		PerSeriesAligner:   "ALIGN_DELTA",
+		CrossSeriesReducer: "REDUCE_SUM",
+		GroupByFields:      []string{"metric.labels.rcode"},
+	})
+	responses := newDNSResponseBreakdown(series)
+
+	var unhealthy []kubePod
+	for _, pod := range pods {
+		if !podHealthy(pod) {
+			unhealthy = append(unhealthy, pod)
+		}
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# DNS Resolution Check for Cluster %s\n\n", clusterName)
+
+	var problems []string
+	if podsErr == nil && len(pods) == 0 {
+		problems = append(problems, "no cluster DNS pods were found in kube-system")
+	}
+	if len(pods) > 0 && len(unhealthy) == len(pods) {
+		problems = append(problems, "no cluster DNS pod is healthy, so lookups from pods fail")
+	} else if len(unhealthy) > 0 {
+		problems = append(problems, fmt.Sprintf("%d of %d cluster DNS pods are unhealthy", len(unhealthy), len(pods)))
+	}
+	if responses.elevated() {
+		problems = append(problems, fmt.Sprintf("%.2f%% of DNS responses failed with SERVFAIL or REFUSED", responses.failureRate()*100))
+	}
+
+	if len(problems) == 0 {
+		result += "No cluster DNS problems found.\n\n"
+	} else {
+		result += "**Problems found:**\n\n"
+		for _, problem := range problems {
+			result += "- " + problem + "\n"
+		}
+		result += "\n"
+	}
+
+	result += "## DNS Pods\n\n"
+	switch {
+	case podsErr != nil:
+		result += fmt.Sprintf("Error listing DNS pods: %v\n\n", podsErr)
+	case len(pods) == 0:
+		result += fmt.Sprintf("No pods in kube-system match %s. The cluster may use Cloud DNS for GKE, which answers lookups outside the cluster.\n\n", clusterDNSLabelSelector)
+	default:
+		sort.Slice(pods, func(i, j int) bool {
+			return pods[i].Metadata.Name < pods[j].Metadata.Name
+		})
+		result += "| Pod | Node | Phase | Ready | Restarts | Problem |\n"
+		result += "| --- | ---- | ----- | ----- | -------- | ------- |\n"
+		for _, pod := range pods {
+			result += fmt.Sprintf("| %s | %s | %s | %t | %d | %s |\n",
+				pod.Metadata.Name, valueOrDash(pod.Spec.NodeName), pod.Status.Phase, pod.isReady(), totalRestarts(pod), valueOrDash(podProblem(pod)))
+		}
+		result += "\n"
+	}
+
+	result += fmt.Sprintf("## DNS Responses (last %.1f hours)\n\n", timeRangeHours)
+	switch {
+	case metricsErr != nil:
+		result += fmt.Sprintf("Error querying CoreDNS metrics: %v\n\n", metricsErr)
+	case responses.Total == 0:
+		result += "No CoreDNS response metrics were found. GKE's default kube-dns doesn't export them; they need CoreDNS scraped by Managed Service for Prometheus.\n\n"
+	default:
+		codes := make([]string, 0, len(responses.Codes))
+		for code := range responses.Codes {
+			codes = append(codes, code)
+		}
+		sort.Slice(codes, func(i, j int) bool {
+			return responses.Codes[codes[i]] > responses.Codes[codes[j]]
+		})
+		result += "| Response Code | Responses | Share |\n"
+		result += "| ------------- | --------- | ----- |\n"
+		for _, code := range codes {
+			result += fmt.Sprintf("| %s | %.0f | %.2f%% |\n", valueOrDash(code), responses.Codes[code], responses.rate(code)*100)
+		}
+		result += "\nNXDOMAIN is expected for many lookups, as pods try each search domain before the name itself.\n\n"
+	}
+
+	if len(problems) > 0 {
+		result += "## Recommended Actions\n\n"
+		result += "1. For unhealthy DNS pods, use describe_pod and get_pod_logs on them to see why they aren't ready or keep restarting\n"
+		result += "2. For SERVFAIL, check the upstream resolvers and stub domains in the kube-dns or CoreDNS ConfigMap, and that nodes can reach the metadata server resolver at 169.254.169.254\n"
+		result += "3. If DNS pods are overloaded, scale them up through the kube-dns-autoscaler ConfigMap or enable NodeLocal DNSCache\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
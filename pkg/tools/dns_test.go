@@ -0,0 +1,80 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDNSResponseBreakdown(t *testing.T) {
+	rcode := func(code string, value float64) timeSeries {
+		return testSeries(map[string]string{"rcode": code}, nil, value)
+	}
+
+	tests := []struct {
+		name     string
+		series   []timeSeries
+		rate     float64
+		elevated bool
+	}{
+		{name: "no responses"},
+		{name: "healthy", series: []timeSeries{rcode("NOERROR", 991), rcode("SERVFAIL", 5), rcode("REFUSED", 4)}, rate: 0.009},
+		// NXDOMAIN from search domain expansion isn't a failure
+		{name: "search domains", series: []timeSeries{rcode("NOERROR", 200), rcode("NXDOMAIN", 800)}, rate: 0},
+		{name: "servfail", series: []timeSeries{rcode("NOERROR", 900), rcode("SERVFAIL", 80), rcode("REFUSED", 20)}, rate: 0.1, elevated: true},
+	}
+
+	for _, tt := range tests {
+		breakdown := newDNSResponseBreakdown(tt.series)
+		if got := breakdown.failureRate(); got < tt.rate-1e-9 || got > tt.rate+1e-9 {
+			t.Errorf("%s: failureRate = %v, want %v", tt.name, got, tt.rate)
+		}
+		if got := breakdown.elevated(); got != tt.elevated {
+			t.Errorf("%s: elevated = %v, want %v", tt.name, got, tt.elevated)
+		}
+	}
+}
+
+func TestHandleGetDNSResolutionCheck(t *testing.T) {
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces/kube-system/pods" || r.URL.Query().Get("labelSelector") != clusterDNSLabelSelector {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"items": [
+			{"metadata": {"name": "kube-dns-b"}, "spec": {"nodeName": "node-2"},
+			 "status": {"phase": "Running", "conditions": [{"type": "Ready", "status": "False"}],
+			  "containerStatuses": [{"name": "kubedns", "ready": false, "restartCount": 7, "state": {"waiting": {"reason": "CrashLoopBackOff"}}}]}},
+			{"metadata": {"name": "kube-dns-a"}, "spec": {"nodeName": "node-1"},
+			 "status": {"phase": "Running", "conditions": [{"type": "Ready", "status": "True"}],
+			  "containerStatuses": [{"name": "kubedns", "ready": true, "state": {"running": {}}}]}}
+		]}`))
+	}))
+
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter := r.URL.Query().Get("filter")
+		if !strings.Contains(filter, "coredns_dns_responses_total") || !strings.Contains(filter, `resource.labels.cluster="`+clusterName+`"`) {
+			t.Errorf("unexpected filter %s", filter)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"timeSeries": []timeSeries{
+			testSeries(map[string]string{"rcode": "NOERROR"}, nil, 700),
+			testSeries(map[string]string{"rcode": "NXDOMAIN"}, nil, 200),
+			testSeries(map[string]string{"rcode": "SERVFAIL"}, nil, 100),
+		}})
+	}))
+
+	text := callClusterTool(t, ctx, handleGetDNSResolutionCheck, clusterName, nil)
+
+	for _, s := range []string{
+		"- 1 of 2 cluster DNS pods are unhealthy\n" +
+			"- 10.00% of DNS responses failed with SERVFAIL or REFUSED\n",
+		"| kube-dns-a | node-1 | Running | true | 0 | Running |\n" +
+			"| kube-dns-b | node-2 | Running | false | 7 | CrashLoopBackOff (container kubedns) |\n",
+		"| NOERROR | 700 | 70.00% |\n| NXDOMAIN | 200 | 20.00% |\n| SERVFAIL | 100 | 10.00% |\n",
+		"## Recommended Actions",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerEndpointTools registers tools that inspect Service endpoints
+func registerEndpointTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get stale endpoints tool
+	getStaleEndpoints := mcp.NewTool("get_stale_endpoints",
+		mcp.WithDescription("Cross-references each Service's Endpoints in a namespace against the live pods, flagging endpoint addresses whose backing pod no longer exists, has been replaced, or is not Ready, which points to an endpoints controller problem routing traffic to dead pods"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The Kubernetes namespace"),
+		),
+	)
+
+	getStaleEndpointsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetStaleEndpoints(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getStaleEndpoints, getStaleEndpointsHandler)
+
+	return nil
+}
+
+// staleEndpoint is an endpoint address that doesn't match a live, ready pod
+type staleEndpoint struct {
+	Service string
+	IP      string
+	Pod     string
+	Ready   bool
+	Problem string
+}
+
+// findStaleEndpoints checks every pod-backed endpoint address against the live
+// pods. Addresses without a pod target, such as those of selector-less
+// Services, are managed by hand and skipped.
+func findStaleEndpoints(endpoints []kubeEndpoints, pods []kubePod) []staleEndpoint {
+	podsByName := make(map[string]kubePod, len(pods))
+	for _, pod := range pods {
+		podsByName[pod.Metadata.Namespace+"/"+pod.Metadata.Name] = pod
+	}
+
+	var stale []staleEndpoint
+	check := func(service string, address kubeEndpointAddress, ready bool) {
+		if address.TargetRef == nil || address.TargetRef.Kind != "Pod" {
+			return
+		}
+
+		name := address.TargetRef.Namespace + "/" + address.TargetRef.Name
+		problem := ""
+		pod, ok := podsByName[name]
+		switch {
+		case !ok:
+			problem = "pod no longer exists"
+		case address.TargetRef.UID != "" && pod.Metadata.UID != address.TargetRef.UID:
+			problem = "pod was replaced by a new pod with the same name"
+		case ready && !pod.isReady():
+			problem = "listed as ready but the pod is not Ready"
+		}
+		if problem == "" {
+			return
+		}
+
+		stale = append(stale, staleEndpoint{
+			Service: service,
+			IP:      address.IP,
+			Pod:     address.TargetRef.Name,
+			Ready:   ready,
+			Problem: problem,
+		})
+	}
+
+	for _, ep := range endpoints {
+		for _, subset := range ep.Subsets {
+			for _, address := range subset.Addresses {
+				check(ep.Metadata.Name, address, true)
+			}
+			for _, address := range subset.NotReadyAddresses {
+				check(ep.Metadata.Name, address, false)
+			}
+		}
+	}
+
+	sort.SliceStable(stale, func(i, j int) bool {
+		return stale[i].Service < stale[j].Service
+	})
+
+	return stale
+}
+
+// handleGetStaleEndpoints handles the get_stale_endpoints tool request
+func handleGetStaleEndpoints(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	namespace, ok := request.Params.Arguments["namespace"].(string)
+	if !ok || namespace == "" {
+		return mcp.NewToolResultError("namespace must be a non-empty string"), nil
+	}
+
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error connecting to cluster: %v", err)), nil
+	}
+
+	var endpoints struct {
+		Items []kubeEndpoints `json:"items"`
+	}
+	if err := kube.get(ctx, fmt.Sprintf("/api/v1/namespaces/%s/endpoints", namespace), nil, &endpoints); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing endpoints: %v", err)), nil
+	}
+
+	// List pods after endpoints, so a pod created in between isn't reported missing
+	var pods struct {
+		Items []kubePod `json:"items"`
+	}
+	if err := kube.get(ctx, podsPath(namespace), nil, &pods); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing pods: %v", err)), nil
+	}
+
+	if len(endpoints.Items) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No Endpoints found in namespace %s of cluster %s.", namespace, clusterName)), nil
+	}
+
+	stale := findStaleEndpoints(endpoints.Items, pods.Items)
+	if len(stale) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("All pod-backed endpoint addresses of the %d Endpoints in namespace %s reference live pods in the expected state.", len(endpoints.Items), namespace)), nil
+	}
+
+	services := make(map[string]bool)
+	routable := 0
+	for _, endpoint := range stale {
+		services[endpoint.Service] = true
+		if endpoint.Ready {
+			routable++
+		}
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# Stale Endpoints in Namespace %s\n\n", namespace)
+	result += fmt.Sprintf("Found %d stale endpoint addresses across %d Services in cluster %s; %d are in the ready set and still receive traffic.\n\n", len(stale), len(services), clusterName, routable)
+
+	result += "| Service | Address | Pod | Ready Set | Problem |\n"
+	result += "| ------- | ------- | --- | --------- | ------- |\n"
+	for _, endpoint := range stale {
+		readySet := "no"
+		if endpoint.Ready {
+			readySet = "**yes**"
+		}
+		result += fmt.Sprintf("| %s | %s | %s | %s | %s |\n", endpoint.Service, endpoint.IP, endpoint.Pod, readySet, endpoint.Problem)
+	}
+
+	result += "\n## Recommended Actions\n\n"
+	result += "1. Endpoints normally update within seconds of a pod change; re-run the check to rule out a pod that was just replaced\n"
+	result += "2. If addresses stay stale, check the kube-controller-manager (endpoints and EndpointSlice controllers) with get_kubelet_and_apiserver_health and the control plane logs\n"
+	result += "3. Stale ready addresses send traffic to dead pods; expect connection errors or timeouts for the affected Services until they are cleared\n"
+
+	return mcp.NewToolResultText(result), nil
+}
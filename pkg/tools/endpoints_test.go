@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHandleGetStaleEndpoints(t *testing.T) {
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/namespaces/web/endpoints":
+			w.Write([]byte(`{"items": [
+				{"metadata": {"name": "frontend"}, "subsets": [{
+					"addresses": [
+						{"ip": "10.0.0.1", "targetRef": {"kind": "Pod", "namespace": "web", "name": "frontend-a", "uid": "uid-a"}},
+						{"ip": "10.0.0.2", "targetRef": {"kind": "Pod", "namespace": "web", "name": "frontend-b", "uid": "uid-b"}},
+						{"ip": "10.0.0.3", "targetRef": {"kind": "Pod", "namespace": "web", "name": "frontend-c", "uid": "uid-c-old"}}
+					],
+					"notReadyAddresses": [
+						{"ip": "10.0.0.4", "targetRef": {"kind": "Pod", "namespace": "web", "name": "frontend-d", "uid": "uid-d"}}
+					]
+				}]},
+				{"metadata": {"name": "api"}, "subsets": [{
+					"addresses": [
+						{"ip": "10.0.1.1", "targetRef": {"kind": "Pod", "namespace": "web", "name": "api-gone", "uid": "uid-gone"}}
+					]
+				}]},
+				{"metadata": {"name": "external-db"}, "subsets": [{"addresses": [{"ip": "192.168.0.10"}]}]}
+			]}`))
+		case "/api/v1/namespaces/web/pods":
+			w.Write([]byte(`{"items": [
+				{"metadata": {"namespace": "web", "name": "frontend-a", "uid": "uid-a"}, "status": {"conditions": [{"type": "Ready", "status": "True"}]}},
+				{"metadata": {"namespace": "web", "name": "frontend-b", "uid": "uid-b"}, "status": {"conditions": [{"type": "Ready", "status": "False"}]}},
+				{"metadata": {"namespace": "web", "name": "frontend-c", "uid": "uid-c-new"}, "status": {"conditions": [{"type": "Ready", "status": "True"}]}},
+				{"metadata": {"namespace": "web", "name": "frontend-d", "uid": "uid-d"}, "status": {"conditions": [{"type": "Ready", "status": "False"}]}}
+			]}`))
+		default:
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"kind": "Status", "reason": "NotFound"})
+		}
+	}))
+
+	text := callClusterTool(t, context.Background(), handleGetStaleEndpoints, clusterName, map[string]interface{}{"namespace": "web"})
+
+	// Healthy, not-ready-but-unlisted and selector-less addresses aren't stale
+	want := "| api | 10.0.1.1 | api-gone | **yes** | pod no longer exists |\n" +
+		"| frontend | 10.0.0.2 | frontend-b | **yes** | listed as ready but the pod is not Ready |\n" +
+		"| frontend | 10.0.0.3 | frontend-c | **yes** | pod was replaced by a new pod with the same name |\n"
+	if !strings.Contains(text, want) {
+		t.Errorf("result doesn't contain %q:\n%s", want, text)
+	}
+	for _, s := range []string{"frontend-a", "frontend-d", "192.168.0.10"} {
+		if strings.Contains(text, s) {
+			t.Errorf("result reports %s as stale:\n%s", s, text)
+		}
+	}
+	if !strings.Contains(text, "Found 3 stale endpoint addresses across 2 Services") {
+		t.Errorf("result doesn't summarise the stale addresses:\n%s", text)
+	}
+}
@@ -0,0 +1,330 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerEnvVarTools registers tools that show workload environment variables
+func registerEnvVarTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get deployment env vars tool
+	getDeploymentEnvVars := mcp.NewTool("get_deployment_env_vars",
+		mcp.WithDescription("Shows the effective environment variables of each container in a deployment, resolving env and envFrom in the order Kubernetes applies them. Literal and ConfigMap values are shown; values from Secrets are redacted to the Secret and key they come from. References to missing ConfigMaps, Secrets or keys are flagged."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The Kubernetes namespace"),
+		),
+		mcp.WithString("deployment_name",
+			mcp.Required(),
+			mcp.Description("The name of the deployment"),
+		),
+	)
+
+	getDeploymentEnvVarsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetDeploymentEnvVars(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getDeploymentEnvVars, getDeploymentEnvVarsHandler)
+
+	return nil
+}
+
+// kubeEnvVar is a container environment variable
+type kubeEnvVar struct {
+	Name      string `json:"name"`
+	Value     string `json:"value"`
+	ValueFrom *struct {
+		ConfigMapKeyRef *kubeObjectKeyRef `json:"configMapKeyRef"`
+		SecretKeyRef    *kubeObjectKeyRef `json:"secretKeyRef"`
+		FieldRef        *struct {
+			FieldPath string `json:"fieldPath"`
+		} `json:"fieldRef"`
+		ResourceFieldRef *struct {
+			ContainerName string `json:"containerName"`
+			Resource      string `json:"resource"`
+		} `json:"resourceFieldRef"`
+	} `json:"valueFrom"`
+}
+
+// kubeContainerEnv is the subset of a container spec that sets its
+// environment
+type kubeContainerEnv struct {
+	Name    string       `json:"name"`
+	Env     []kubeEnvVar `json:"env"`
+	EnvFrom []struct {
+		Prefix       string            `json:"prefix"`
+		ConfigMapRef *kubeObjectKeyRef `json:"configMapRef"`
+		SecretRef    *kubeObjectKeyRef `json:"secretRef"`
+	} `json:"envFrom"`
+}
+
+// secretEnvValue is shown in place of a value read from a Secret
+func secretEnvValue(name, key string) string {
+	return fmt.Sprintf("[from secret %s/key %s]", name, key)
+}
+
+// envVarValue is the effective value of an environment variable
+type envVarValue struct {
+	Name string
+	// Value is redacted for Secrets and describes the source of values only
+	// known at runtime
+	Value  string
+	Source string
+	// Problem is why the value can't be resolved, if it can't
+	Problem string
+}
+
+// containerEnv resolves a container's effective environment from the
+// referenced objects, keyed by kind/name. Kubernetes applies envFrom sources
+// in order, then env, with later definitions of a name replacing earlier
+// ones. Unresolvable envFrom sources are returned as problems, as they have
+// no variable to attach to.
+func containerEnv(c kubeContainerEnv, objects map[string]configObject) ([]envVarValue, []string) {
+	var vars []envVarValue
+	index := make(map[string]int)
+	set := func(v envVarValue) {
+		if i, ok := index[v.Name]; ok {
+			vars[i] = v
+			return
+		}
+		index[v.Name] = len(vars)
+		vars = append(vars, v)
+	}
+
+	var problems []string
+	for _, envFrom := range c.EnvFrom {
+		kind, ref := "ConfigMap", envFrom.ConfigMapRef
+		if ref == nil {
+			kind, ref = "Secret", envFrom.SecretRef
+		}
+		if ref == nil {
+			continue
+		}
+
+		object := objects[kind+"/"+ref.Name]
+		if problem := configRefProblem(configRef{Kind: kind, Name: ref.Name}, object); problem != "" {
+			if ref.Optional == nil || !*ref.Optional {
+				problems = append(problems, fmt.Sprintf("envFrom %s %s: %s", kind, ref.Name, problem))
+			}
+			continue
+		}
+
+		keys := make([]string, 0, len(object.Keys))
+		for key := range object.Keys {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		source := fmt.Sprintf("envFrom %s %s", kind, ref.Name)
+		for _, key := range keys {
+			v := envVarValue{Name: envFrom.Prefix + key, Source: source}
+			if kind == "Secret" {
+				v.Value = secretEnvValue(ref.Name, key)
+			} else if value, ok := object.Values[key]; ok {
+				v.Value = value
+			} else {
+				// Binary data isn't exposed as environment variables
+				continue
+			}
+			set(v)
+		}
+	}
+
+	for _, env := range c.Env {
+		v := envVarValue{Name: env.Name, Value: env.Value, Source: "literal"}
+		if from := env.ValueFrom; from != nil {
+			switch {
+			case from.SecretKeyRef != nil:
+				ref := from.SecretKeyRef
+				v.Value = secretEnvValue(ref.Name, ref.Key)
+				v.Source = "Secret " + ref.Name
+				v.Problem = configRefProblem(configRef{Kind: "Secret", Name: ref.Name, Key: ref.Key}, objects["Secret/"+ref.Name])
+				if v.Problem != "" && ref.Optional != nil && *ref.Optional {
+					continue
+				}
+			case from.ConfigMapKeyRef != nil:
+				ref := from.ConfigMapKeyRef
+				v.Source = "ConfigMap " + ref.Name
+				v.Problem = configRefProblem(configRef{Kind: "ConfigMap", Name: ref.Name, Key: ref.Key}, objects["ConfigMap/"+ref.Name])
+				if v.Problem != "" && ref.Optional != nil && *ref.Optional {
+					continue
+				}
+				v.Value = objects["ConfigMap/"+ref.Name].Values[ref.Key]
+			case from.FieldRef != nil:
+				v.Value = fmt.Sprintf("[field %s]", from.FieldRef.FieldPath)
+				v.Source = "pod field"
+			case from.ResourceFieldRef != nil:
+				resource := from.ResourceFieldRef.Resource
+				if from.ResourceFieldRef.ContainerName != "" {
+					resource = from.ResourceFieldRef.ContainerName + " " + resource
+				}
+				v.Value = fmt.Sprintf("[resource %s]", resource)
+				v.Source = "container resource"
+			}
+		}
+		set(v)
+	}
+
+	return vars, problems
+}
+
+// envRefObjects returns the kind/name of each ConfigMap and Secret the
+// containers' environments reference
+func envRefObjects(containers []kubeContainerEnv) []configRef {
+	seen := make(map[string]bool)
+	var refs []configRef
+	add := func(kind string, ref *kubeObjectKeyRef) {
+		if ref == nil || seen[kind+"/"+ref.Name] {
+			return
+		}
+		seen[kind+"/"+ref.Name] = true
+		refs = append(refs, configRef{Kind: kind, Name: ref.Name})
+	}
+
+	for _, c := range containers {
+		for _, envFrom := range c.EnvFrom {
+			add("ConfigMap", envFrom.ConfigMapRef)
+			add("Secret", envFrom.SecretRef)
+		}
+		for _, env := range c.Env {
+			if env.ValueFrom != nil {
+				add("ConfigMap", env.ValueFrom.ConfigMapKeyRef)
+				add("Secret", env.ValueFrom.SecretKeyRef)
+			}
+		}
+	}
+
+	return refs
+}
+
+// handleGetDeploymentEnvVars handles the get_deployment_env_vars tool request
+func handleGetDeploymentEnvVars(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	namespace, ok := request.Params.Arguments["namespace"].(string)
+	if !ok || namespace == "" {
+		return mcp.NewToolResultError("namespace must be a non-empty string"), nil
+	}
+
+	deploymentName, ok := request.Params.Arguments["deployment_name"].(string)
+	if !ok || deploymentName == "" {
+		return mcp.NewToolResultError("deployment_name must be a non-empty string"), nil
+	}
+
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error connecting to cluster: %v", err)), nil
+	}
+
+	var deployment struct {
+		Spec struct {
+			Template struct {
+				Spec struct {
+					InitContainers []kubeContainerEnv `json:"initContainers"`
+					Containers     []kubeContainerEnv `json:"containers"`
+				} `json:"spec"`
+			} `json:"template"`
+		} `json:"spec"`
+	}
+	if err := kube.get(ctx, fmt.Sprintf("/apis/apps/v1/namespaces/%s/deployments/%s", namespace, deploymentName), nil, &deployment); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting deployment: %v", err)), nil
+	}
+
+	podSpec := deployment.Spec.Template.Spec
+	containers := append(append([]kubeContainerEnv{}, podSpec.InitContainers...), podSpec.Containers...)
+
+	// Look up each referenced object once
+	objects := make(map[string]configObject)
+	for _, ref := range envRefObjects(containers) {
+		objects[ref.Kind+"/"+ref.Name] = fetchConfigObjectKeys(ctx, kube, namespace, ref.Kind, ref.Name)
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# Environment Variables of Deployment %s/%s\n\n", namespace, deploymentName)
+	result += "Values from Secrets are redacted to the Secret and key they come from.\n\n"
+
+	var broken []string
+	for i, c := range containers {
+		vars, problems := containerEnv(c, objects)
+
+		heading := "Container"
+		if i < len(podSpec.InitContainers) {
+			heading = "Init Container"
+		}
+		result += fmt.Sprintf("## %s %s\n\n", heading, c.Name)
+
+		for _, problem := range problems {
+			broken = append(broken, fmt.Sprintf("container %s %s", c.Name, problem))
+			result += fmt.Sprintf("- **%s**\n", problem)
+		}
+		if len(problems) > 0 {
+			result += "\n"
+		}
+
+		if len(vars) == 0 {
+			result += "No environment variables are set.\n\n"
+			continue
+		}
+
+		result += "| Name | Value | Source | Status |\n"
+		result += "| ---- | ----- | ------ | ------ |\n"
+		for _, v := range vars {
+			status := "OK"
+			if v.Problem != "" {
+				status = "**" + v.Problem + "**"
+				broken = append(broken, fmt.Sprintf("container %s env %s (%s): %s", c.Name, v.Name, v.Source, v.Problem))
+			}
+			value := strings.ReplaceAll(v.Value, "\n", "\\n")
+			value = strings.ReplaceAll(value, "|", "\\|")
+			result += fmt.Sprintf("| %s | %s | %s | %s |\n", v.Name, value, v.Source, status)
+		}
+		result += "\n"
+	}
+
+	if len(broken) > 0 {
+		result += fmt.Sprintf("**%d references can't be resolved**, so affected containers fail with CreateContainerConfigError:\n\n", len(broken))
+		for _, b := range broken {
+			result += fmt.Sprintf("- %s\n", b)
+		}
+		result += "\n"
+
+		result += "## Recommended Actions\n\n"
+		result += "1. Create the missing ConfigMap, Secret or key, or fix the name in the deployment; pods start on their next retry without a restart\n"
+		result += "2. If the reference is genuinely optional, mark it `optional: true` so the pod can start without it\n"
+		result += "3. Use get_config_map_and_secret_refs to also check references from volumes\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,75 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHandleGetDeploymentEnvVars(t *testing.T) {
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/apis/apps/v1/namespaces/default/deployments/web":
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"spec": map[string]interface{}{"template": map[string]interface{}{"spec": map[string]interface{}{
+					"containers": []map[string]interface{}{{
+						"name": "web",
+						"envFrom": []map[string]interface{}{
+							{"prefix": "CFG_", "configMapRef": map[string]string{"name": "app-config"}},
+							{"secretRef": map[string]string{"name": "db-creds"}},
+						},
+						"env": []map[string]interface{}{
+							{"name": "PORT", "value": "8080"},
+							{"name": "CFG_REGION", "value": "override"},
+							{"name": "LOG_LEVEL", "valueFrom": map[string]interface{}{"configMapKeyRef": map[string]string{"name": "app-config", "key": "LOG_LEVEL"}}},
+							{"name": "DB_PASSWORD", "valueFrom": map[string]interface{}{"secretKeyRef": map[string]string{"name": "db-creds", "key": "password"}}},
+							{"name": "MISSING", "valueFrom": map[string]interface{}{"configMapKeyRef": map[string]string{"name": "app-config", "key": "nope"}}},
+						},
+					}},
+				}}},
+			})
+		case "/api/v1/namespaces/default/configmaps/app-config":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]string{"LOG_LEVEL": "debug", "REGION": "australia-southeast1"}})
+		case "/api/v1/namespaces/default/secrets/db-creds":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]string{"password": "aHVudGVyMg=="}})
+		default:
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"kind": "Status", "reason": "NotFound"})
+		}
+	}))
+
+	text := callClusterTool(t, context.Background(), handleGetDeploymentEnvVars, clusterName, map[string]interface{}{
+		"namespace":       "default",
+		"deployment_name": "web",
+	})
+
+	for _, row := range []string{
+		"| CFG_LOG_LEVEL | debug | envFrom ConfigMap app-config | OK |",
+		"| CFG_REGION | override | literal | OK |",
+		"| password | [from secret db-creds/key password] | envFrom Secret db-creds | OK |",
+		"| PORT | 8080 | literal | OK |",
+		"| LOG_LEVEL | debug | ConfigMap app-config | OK |",
+		"| DB_PASSWORD | [from secret db-creds/key password] | Secret db-creds | OK |",
+		`| MISSING |  | ConfigMap app-config | **key "nope" not found** |`,
+	} {
+		if !strings.Contains(text, row) {
+			t.Errorf("result doesn't contain %q:\n%s", row, text)
+		}
+	}
+
+	// Secret values never appear, encoded or decoded
+	for _, secret := range []string{"aHVudGVyMg==", "hunter2"} {
+		if strings.Contains(text, secret) {
+			t.Errorf("result contains the secret value %q:\n%s", secret, text)
+		}
+	}
+
+	// env replaces a variable set by envFrom rather than listing it twice
+	if strings.Count(text, "| CFG_REGION |") != 1 || strings.Contains(text, "australia-southeast1") {
+		t.Errorf("CFG_REGION from envFrom wasn't replaced by env:\n%s", text)
+	}
+
+	if !strings.Contains(text, "**1 references can't be resolved**") {
+		t.Errorf("result doesn't flag the missing key:\n%s", text)
+	}
+}
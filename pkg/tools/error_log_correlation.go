@@ -0,0 +1,226 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// correlationEvents is how many of an error group's most recent events
+	// logs are correlated with
+	correlationEvents = 10
+
+	// correlationMargin is how far either side of each error event logs are
+	// fetched from
+	correlationMargin = time.Minute
+
+	// correlationMaxEntries caps how many log entries are returned
+	correlationMaxEntries = 200
+)
+
+// registerErrorLogCorrelationTools registers tools that correlate error
+// groups with logs
+func registerErrorLogCorrelationTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get error group logs tool
+	getErrorGroupLogs := mcp.NewTool("get_error_group_logs",
+		mcp.WithDescription(fmt.Sprintf("Finds the logs surrounding an error group's most recent events: fetches the event times and affected services from Error Reporting, then returns Cloud Logging entries from those services within %s of each event, to show what the service and its infrastructure were doing when the error occurred", correlationMargin)),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("error_group_id",
+			mcp.Required(),
+			mcp.Description("The ID of the error group"),
+		),
+	)
+
+	getErrorGroupLogsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetErrorGroupLogs(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getErrorGroupLogs, getErrorGroupLogsHandler)
+
+	return nil
+}
+
+// timeWindow is a closed time range
+type timeWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+// correlationWindows returns windows reaching margin either side of each
+// event time, oldest first, merging windows that overlap
+func correlationWindows(times []time.Time, margin time.Duration) []timeWindow {
+	sorted := append([]time.Time{}, times...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Before(sorted[j])
+	})
+
+	var windows []timeWindow
+	for _, t := range sorted {
+		start, end := t.Add(-margin), t.Add(margin)
+		if n := len(windows); n > 0 && !start.After(windows[n-1].End) {
+			windows[n-1].End = end
+			continue
+		}
+		windows = append(windows, timeWindow{Start: start, End: end})
+	}
+	return windows
+}
+
+// serviceLogFilter matches log entries from a service as Error Reporting
+// names it: a Cloud Run service, GKE container, App Engine service, Cloud
+// Function, or any entry reporting it as its service context
+func serviceLogFilter(service string) string {
+	return fmt.Sprintf(`resource.labels.service_name="%[1]s" OR resource.labels.container_name="%[1]s" OR resource.labels.module_id="%[1]s" OR resource.labels.function_name="%[1]s" OR jsonPayload.serviceContext.service="%[1]s"`, service)
+}
+
+// correlationFilter builds a Cloud Logging filter for entries from any of the
+// services within any of the windows
+func correlationFilter(services []string, windows []timeWindow) string {
+	serviceFilters := make([]string, 0, len(services))
+	for _, service := range services {
+		serviceFilters = append(serviceFilters, serviceLogFilter(service))
+	}
+
+	windowFilters := make([]string, 0, len(windows))
+	for _, w := range windows {
+		windowFilters = append(windowFilters, fmt.Sprintf(`(timestamp >= "%s" AND timestamp <= "%s")`,
+			w.Start.UTC().Format(time.RFC3339Nano), w.End.UTC().Format(time.RFC3339Nano)))
+	}
+
+	return fmt.Sprintf("(%s)\nAND (%s)", strings.Join(serviceFilters, " OR "), strings.Join(windowFilters, " OR "))
+}
+
+// handleGetErrorGroupLogs handles the get_error_group_logs tool request
+func handleGetErrorGroupLogs(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	errorGroupID, ok := request.Params.Arguments["error_group_id"].(string)
+	if !ok || errorGroupID == "" {
+		return mcp.NewToolResultError("error_group_id must be a non-empty string"), nil
+	}
+
+	events, err := fetchErrorEvents(ctx, authHandler, projectID, errorGroupID, correlationEvents)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting error events: %v", err)), nil
+	}
+
+	var eventTimes []time.Time
+	var services []string
+	for _, event := range events {
+		if event.EventTime == nil {
+			continue
+		}
+		eventTimes = append(eventTimes, event.EventTime.AsTime())
+		if event.ServiceContext != nil && event.ServiceContext.Service != "" && !containsString(services, event.ServiceContext.Service) {
+			services = append(services, event.ServiceContext.Service)
+		}
+	}
+	if len(eventTimes) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("Error group %s has no recent events to correlate logs with.", errorGroupID)), nil
+	}
+	if len(services) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("The recent events of error group %s don't name the service that reported them, so there are no logs to correlate. Use get_logs_context with an event time instead.", errorGroupID)), nil
+	}
+	sort.Strings(services)
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	windows := correlationWindows(eventTimes, correlationMargin)
+	entries, nextPageToken, err := fetchLogEntries(ctx, client, projectID, logQuery{
+		Filter:   correlationFilter(services, windows),
+		OrderBy:  "timestamp asc",
+		PageSize: correlationMaxEntries,
+	})
+	cancelled := false
+	if err != nil {
+		if !partialOnCancel(err, len(entries)) {
+			return mcp.NewToolResultError(fmt.Sprintf("Error querying logs: %v", err)), nil
+		}
+		cancelled = true
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# Logs Around Error Group %s\n\n", errorGroupID)
+	result += fmt.Sprintf("- **Services**: %s\n", strings.Join(services, ", "))
+	result += fmt.Sprintf("- **Events Correlated**: %d most recent\n", len(eventTimes))
+	result += fmt.Sprintf("- **Windows**: %s either side of each event\n\n", correlationMargin)
+
+	if len(entries) == 0 {
+		result += "No log entries from these services were found around the error events.\n"
+		if cancelled {
+			result += cancelledNote
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+
+	if nextPageToken != "" {
+		result += fmt.Sprintf("Only the first %d entries are shown; use query_logs with a narrower filter to see the rest.\n\n", correlationMaxEntries)
+	}
+
+	// Show each window with the events in it marked in time order
+	entryIndex := 0
+	for i, w := range windows {
+		result += fmt.Sprintf("## Window %d: %s to %s\n\n", i+1, w.Start.UTC().Format(time.RFC3339), w.End.UTC().Format(time.RFC3339))
+
+		var markers []time.Time
+		for _, t := range eventTimes {
+			if !t.Before(w.Start) && !t.After(w.End) {
+				markers = append(markers, t)
+			}
+		}
+		sort.Slice(markers, func(a, b int) bool {
+			return markers[a].Before(markers[b])
+		})
+
+		result += "```\n"
+		for ; entryIndex < len(entries); entryIndex++ {
+			entry := entries[entryIndex]
+			t, err := time.Parse(time.RFC3339Nano, entry.Timestamp)
+			if err == nil && t.After(w.End) {
+				break
+			}
+			for len(markers) > 0 && err == nil && markers[0].Before(t) {
+				result += fmt.Sprintf("--> %s (error event)\n", markers[0].UTC().Format(time.RFC3339Nano))
+				markers = markers[1:]
+			}
+			severity := entry.Severity
+			if severity == "" {
+				severity = "DEFAULT"
+			}
+			result += fmt.Sprintf("    %s %-8s %s\n", entry.Timestamp, severity, logEntryMessage(entry))
+		}
+		for _, marker := range markers {
+			result += fmt.Sprintf("--> %s (error event)\n", marker.UTC().Format(time.RFC3339Nano))
+		}
+		result += "```\n\n"
+	}
+
+	result += "## Recommended Actions\n\n"
+	result += "1. Look for warnings, restarts, timeouts or dependency errors just before each error event; they often point to the cause\n"
+	result += "2. Use get_issue_details for the error group's stack traces and request details\n"
+	result += "3. Use get_logs_context around a single event to see all logs at that moment, not just the affected services\n"
+
+	if cancelled {
+		result += cancelledNote
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
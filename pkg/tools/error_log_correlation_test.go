@@ -0,0 +1,84 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCorrelationWindows(t *testing.T) {
+	at := func(s string) time.Time {
+		t, _ := time.Parse(time.RFC3339, "2026-10-17T"+s+"Z")
+		return t
+	}
+
+	// Events are unordered, and the two close together share a window
+	windows := correlationWindows([]time.Time{at("01:10:00"), at("01:00:00"), at("01:01:30")}, time.Minute)
+
+	want := []timeWindow{
+		{Start: at("00:59:00"), End: at("01:02:30")},
+		{Start: at("01:09:00"), End: at("01:11:00")},
+	}
+	if !reflect.DeepEqual(windows, want) {
+		t.Errorf("correlationWindows = %+v, want %+v", windows, want)
+	}
+}
+
+func TestHandleGetErrorGroupLogs(t *testing.T) {
+	logs := fakeLogging(t, func(filter string) string {
+		// The query windows bracket each event by a minute either side
+		for _, s := range []string{
+			`resource.labels.service_name="checkout"`,
+			`(timestamp >= "2026-10-17T00:59:00Z" AND timestamp <= "2026-10-17T01:01:30Z") OR (timestamp >= "2026-10-17T01:09:00Z" AND timestamp <= "2026-10-17T01:11:00Z")`,
+		} {
+			if !strings.Contains(filter, s) {
+				t.Errorf("filter doesn't contain %s: %s", s, filter)
+			}
+		}
+		return `[
+			{"timestamp": "2026-10-17T00:59:50Z", "severity": "WARNING", "textPayload": "connection pool exhausted"},
+			{"timestamp": "2026-10-17T01:00:10Z", "severity": "INFO", "textPayload": "retrying payment"},
+			{"timestamp": "2026-10-17T01:10:20Z", "severity": "ERROR", "textPayload": "payment provider timeout"}
+		]`
+	})
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host != "clouderrorreporting.googleapis.com" {
+			logs.ServeHTTP(w, r)
+			return
+		}
+		if got := r.URL.Query().Get("groupId"); got != "g1" {
+			t.Errorf("groupId = %q, want g1", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"errorEvents": [
+			{"eventTime": "2026-10-17T01:10:00Z", "serviceContext": {"service": "checkout", "version": "v7"}, "message": "Timeout"},
+			{"eventTime": "2026-10-17T01:00:30Z", "serviceContext": {"service": "checkout", "version": "v7"}, "message": "Timeout"},
+			{"eventTime": "2026-10-17T01:00:00Z", "serviceContext": {"service": "checkout", "version": "v7"}, "message": "Timeout"}
+		]}`))
+	}))
+
+	text := callTool(t, ctx, handleGetErrorGroupLogs, map[string]interface{}{
+		"project_id":     "test-project",
+		"error_group_id": "g1",
+	})
+
+	// Event markers are interleaved with the entries at their times
+	for _, s := range []string{
+		"- **Services**: checkout\n- **Events Correlated**: 3 most recent\n",
+		"## Window 1: 2026-10-17T00:59:00Z to 2026-10-17T01:01:30Z\n\n```\n" +
+			"    2026-10-17T00:59:50Z WARNING  connection pool exhausted\n" +
+			"--> 2026-10-17T01:00:00Z (error event)\n" +
+			"    2026-10-17T01:00:10Z INFO     retrying payment\n" +
+			"--> 2026-10-17T01:00:30Z (error event)\n```\n",
+		"## Window 2: 2026-10-17T01:09:00Z to 2026-10-17T01:11:00Z\n\n```\n" +
+			"--> 2026-10-17T01:10:00Z (error event)\n" +
+			"    2026-10-17T01:10:20Z ERROR    payment provider timeout\n```\n",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
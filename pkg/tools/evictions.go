@@ -0,0 +1,246 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerEvictionTools registers tools that find pods evicted by kubelets
+func registerEvictionTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get recent evictions tool
+	getRecentEvictions := mcp.NewTool("get_recent_evictions",
+		mcp.WithDescription("Finds pods evicted by kubelets under node pressure (memory, disk, ephemeral storage or PIDs) within a recent window, grouped by node and reason with the evicted pods. Evicted pods are replaced and disappear from view, so node-pressure incidents are easy to miss from pod status alone."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Only include pods in this namespace (if not provided, all namespaces are checked)"),
+		),
+		mcp.WithString("window",
+			mcp.Description("How far back to look, as a duration such as 15m or 2h (default: 1h). The API server only keeps events for a limited time, 1h by default."),
+		),
+	)
+
+	getRecentEvictionsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetRecentEvictions(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getRecentEvictions, getRecentEvictionsHandler)
+
+	return nil
+}
+
+// evictionReason classifies the message of an Evicted event by the resource
+// the node was short of. Kubelets report "The node was low on resource:
+// memory." for threshold evictions, "The node had condition: [DiskPressure]."
+// for pods rejected under a condition, and a container or pod limit for
+// ephemeral storage overuse.
+func evictionReason(message string) string {
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "ephemeral-storage"), strings.Contains(lower, "ephemeral local storage"):
+		return "Ephemeral storage"
+	case strings.Contains(lower, "low on resource: memory"), strings.Contains(lower, "[memorypressure]"):
+		return "MemoryPressure"
+	case strings.Contains(lower, "low on resource: nodefs"), strings.Contains(lower, "low on resource: imagefs"), strings.Contains(lower, "[diskpressure]"):
+		return "DiskPressure"
+	case strings.Contains(lower, "low on resource: pids"), strings.Contains(lower, "[pidpressure]"):
+		return "PIDPressure"
+	default:
+		return "Other"
+	}
+}
+
+// evictedPod is a pod evicted by a kubelet
+type evictedPod struct {
+	Namespace string
+	Name      string
+	Time      time.Time
+	Message   string
+}
+
+// evictionGroup is the pods evicted from a node for the same reason
+type evictionGroup struct {
+	Node   string
+	Reason string
+	// Pods are newest first
+	Pods []evictedPod
+}
+
+// groupEvictions groups Evicted pod events by node and reason, most
+// evictions first. Events are expected newest first, as recentEvents returns
+// them.
+func groupEvictions(events []kubeEvent) []evictionGroup {
+	type groupKey struct {
+		node   string
+		reason string
+	}
+	groups := make(map[groupKey]*evictionGroup)
+	var order []groupKey
+
+	for _, event := range events {
+		if event.Reason != "Evicted" || event.InvolvedObject.Kind != "Pod" {
+			continue
+		}
+
+		// The kubelet reporting the eviction names its node as the source host
+		node := event.Source.Host
+		if node == "" {
+			node = "(unknown)"
+		}
+
+		key := groupKey{node, evictionReason(event.Message)}
+		group, ok := groups[key]
+		if !ok {
+			group = &evictionGroup{Node: key.node, Reason: key.reason}
+			groups[key] = group
+			order = append(order, key)
+		}
+		group.Pods = append(group.Pods, evictedPod{
+			Namespace: event.InvolvedObject.Namespace,
+			Name:      event.InvolvedObject.Name,
+			Time:      event.lastSeen(),
+			Message:   strings.TrimSpace(event.Message),
+		})
+	}
+
+	result := make([]evictionGroup, 0, len(order))
+	for _, key := range order {
+		result = append(result, *groups[key])
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return len(result[i].Pods) > len(result[j].Pods)
+	})
+
+	return result
+}
+
+// handleGetRecentEvictions handles the get_recent_evictions tool request
+func handleGetRecentEvictions(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+
+	// Get optional parameters with defaults
+	window := time.Hour
+	if val, ok := request.Params.Arguments["window"].(string); ok && val != "" {
+		parsed, err := time.ParseDuration(val)
+		if err != nil || parsed <= 0 {
+			return mcp.NewToolResultError("window must be a positive duration, such as 15m or 2h"), nil
+		}
+		window = parsed
+	}
+
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error connecting to cluster: %v", err)), nil
+	}
+
+	path := "/api/v1/events"
+	if namespace != "" {
+		path = fmt.Sprintf("/api/v1/namespaces/%s/events", url.PathEscape(namespace))
+	}
+	query := url.Values{}
+	query.Set("fieldSelector", eventsFieldSelector("", "Evicted")+",involvedObject.kind=Pod")
+
+	var eventList struct {
+		Items []kubeEvent `json:"items"`
+	}
+	if err := kube.get(ctx, path, query, &eventList); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing events: %v", err)), nil
+	}
+
+	groups := groupEvictions(recentEvents(eventList.Items, time.Now().Add(-window)))
+
+	// Format the results
+	scope := "cluster " + clusterName
+	if namespace != "" {
+		scope = fmt.Sprintf("namespace %s of cluster %s", namespace, clusterName)
+	}
+
+	if len(groups) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No pods were evicted in %s in the last %s.", scope, window)), nil
+	}
+
+	total := 0
+	nodes := make(map[string]bool)
+	reasonCounts := make(map[string]int)
+	for _, group := range groups {
+		total += len(group.Pods)
+		nodes[group.Node] = true
+		reasonCounts[group.Reason] += len(group.Pods)
+	}
+
+	result := fmt.Sprintf("# Recent Evictions in %s\n\n", scope)
+	result += fmt.Sprintf("%d pods were evicted from %d nodes in the last %s.\n\n", total, len(nodes), window)
+
+	reasons := make([]string, 0, len(reasonCounts))
+	for reason := range reasonCounts {
+		reasons = append(reasons, reason)
+	}
+	sort.SliceStable(reasons, func(i, j int) bool {
+		if reasonCounts[reasons[i]] != reasonCounts[reasons[j]] {
+			return reasonCounts[reasons[i]] > reasonCounts[reasons[j]]
+		}
+		return reasons[i] < reasons[j]
+	})
+
+	result += "## By Reason\n\n"
+	for _, reason := range reasons {
+		result += fmt.Sprintf("- **%s**: %d pods\n", reason, reasonCounts[reason])
+	}
+	result += "\n"
+
+	result += "## By Node\n\n"
+	for _, group := range groups {
+		result += fmt.Sprintf("### %s: %s (%d pods)\n\n", group.Node, group.Reason, len(group.Pods))
+		result += "| Time | Pod | Message |\n"
+		result += "| ---- | --- | ------- |\n"
+		for _, pod := range group.Pods {
+			message := strings.ReplaceAll(pod.Message, "\n", " ")
+			message = strings.ReplaceAll(message, "|", "\\|")
+			result += fmt.Sprintf("| %s | %s/%s | %s |\n", pod.Time.Format(time.RFC3339), pod.Namespace, pod.Name, message)
+		}
+		result += "\n"
+	}
+
+	result += "## Recommended Actions\n\n"
+	result += "1. MemoryPressure evictions mean pods use more memory than they request; raise memory requests to match usage so the scheduler doesn't overcommit the node\n"
+	result += "2. DiskPressure and ephemeral storage evictions usually come from logs, caches or emptyDir volumes filling the node's disk; set ephemeral-storage requests and limits, or use larger boot disks\n"
+	result += "3. Evictions concentrated on one node point to a noisy neighbour or an unhealthy node; check it with get_gke_node_problem_detector_events\n"
+	result += "4. Events expire after about an hour; query_logs with the gke-events preset finds older evictions\n"
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,81 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEvictionReason(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{message: "The node was low on resource: memory. Threshold quantity: 100Mi, available: 51200Ki.", want: "MemoryPressure"},
+		{message: "The node had condition: [DiskPressure].", want: "DiskPressure"},
+		{message: "The node was low on resource: ephemeral-storage. Container app was using 12Gi, request is 0.", want: "Ephemeral storage"},
+		{message: "Pod ephemeral local storage usage exceeds the total limit of containers 1Gi.", want: "Ephemeral storage"},
+		{message: "The node was low on resource: pids.", want: "PIDPressure"},
+		{message: "Preempted by a higher priority pod", want: "Other"},
+	}
+
+	for _, tt := range tests {
+		if got := evictionReason(tt.message); got != tt.want {
+			t.Errorf("evictionReason(%q) = %s, want %s", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestHandleGetRecentEvictions(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	ago := func(d time.Duration) string { return now.Add(-d).Format(time.RFC3339) }
+	eviction := func(namespace, pod, node, message string, lastSeen time.Duration) map[string]interface{} {
+		return map[string]interface{}{
+			"involvedObject": map[string]string{"kind": "Pod", "namespace": namespace, "name": pod},
+			"type":           "Warning",
+			"reason":         "Evicted",
+			"message":        message,
+			"lastTimestamp":  ago(lastSeen),
+			"source":         map[string]string{"component": "kubelet", "host": node},
+		}
+	}
+
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces/web/events" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("fieldSelector"); got != "reason=Evicted,involvedObject.kind=Pod" {
+			t.Errorf("fieldSelector = %q, want Evicted pod events", got)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"items": []map[string]interface{}{
+			eviction("web", "api-1", "node-a", "The node was low on resource: memory. ", 20*time.Minute),
+			eviction("web", "api-2", "node-a", "The node was low on resource: memory. ", 5*time.Minute),
+			eviction("web", "cache-1", "node-b", "The node was low on resource: ephemeral-storage. ", 10*time.Minute),
+			eviction("web", "old", "node-c", "The node had condition: [DiskPressure].", 3*time.Hour),
+		}})
+	}))
+
+	text := callClusterTool(t, context.Background(), handleGetRecentEvictions, clusterName, map[string]interface{}{
+		"namespace": "web",
+	})
+
+	// Grouped by node and reason, with the largest group first and each
+	// group's pods newest first
+	for _, s := range []string{
+		"3 pods were evicted from 2 nodes in the last 1h0m0s.",
+		"- **MemoryPressure**: 2 pods\n- **Ephemeral storage**: 1 pods\n",
+		"### node-a: MemoryPressure (2 pods)\n\n| Time | Pod | Message |\n| ---- | --- | ------- |\n" +
+			"| " + ago(5*time.Minute) + " | web/api-2 | The node was low on resource: memory. |\n" +
+			"| " + ago(20*time.Minute) + " | web/api-1 | The node was low on resource: memory. |\n",
+		"### node-b: Ephemeral storage (1 pods)",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if strings.Contains(text, "node-c") {
+		t.Errorf("result includes an eviction from before the window:\n%s", text)
+	}
+}
@@ -0,0 +1,209 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GCP Firestore API base URL
+const gcpFirestoreBaseURL = "https://firestore.googleapis.com/v1"
+
+// registerFirestoreTools registers all Firestore related tools
+func registerFirestoreTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get Firestore index status tool
+	getIndexStatus := mcp.NewTool("get_firestore_index_status",
+		mcp.WithDescription("Lists Firestore composite indexes and their state, flagging indexes still building or needing repair"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("database_id",
+			mcp.Description("The Firestore database ID (default: (default))"),
+		),
+	)
+
+	getIndexStatusHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetFirestoreIndexStatus(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getIndexStatus, getIndexStatusHandler)
+
+	return nil
+}
+
+// firestoreIndex is a Firestore composite index
+type firestoreIndex struct {
+	Name       string `json:"name"`
+	QueryScope string `json:"queryScope"`
+	State      string `json:"state"`
+	Fields     []struct {
+		FieldPath   string `json:"fieldPath"`
+		Order       string `json:"order"`
+		ArrayConfig string `json:"arrayConfig"`
+	} `json:"fields"`
+}
+
+// collectionGroup returns the collection group the index belongs to
+func (i firestoreIndex) collectionGroup() string {
+	parts := strings.Split(i.Name, "/")
+	for j := 0; j < len(parts)-1; j++ {
+		if parts[j] == "collectionGroups" {
+			return parts[j+1]
+		}
+	}
+	return ""
+}
+
+// fieldsString formats the index fields as "field ASC, other DESC"
+func (i firestoreIndex) fieldsString() string {
+	fields := make([]string, 0, len(i.Fields))
+	for _, f := range i.Fields {
+		mode := f.Order
+		if mode == "" {
+			mode = f.ArrayConfig
+		}
+		if f.FieldPath == "__name__" {
+			continue
+		}
+		fields = append(fields, fmt.Sprintf("%s %s", f.FieldPath, mode))
+	}
+	return strings.Join(fields, ", ")
+}
+
+// indexStateRank orders index states so problems are listed first
+var indexStateRank = map[string]int{
+	"NEEDS_REPAIR": 0,
+	"CREATING":     1,
+	"READY":        2,
+}
+
+// sortIndexesByState orders indexes needing attention first, then by collection group
+func sortIndexesByState(indexes []firestoreIndex) {
+	rank := func(state string) int {
+		if r, ok := indexStateRank[state]; ok {
+			return r
+		}
+		return 1
+	}
+
+	sort.SliceStable(indexes, func(i, j int) bool {
+		if rank(indexes[i].State) != rank(indexes[j].State) {
+			return rank(indexes[i].State) < rank(indexes[j].State)
+		}
+		return indexes[i].collectionGroup() < indexes[j].collectionGroup()
+	})
+}
+
+// handleGetFirestoreIndexStatus handles the get_firestore_index_status tool request
+func handleGetFirestoreIndexStatus(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	databaseID := "(default)"
+	if val, ok := request.Params.Arguments["database_id"].(string); ok && val != "" {
+		databaseID = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	// List indexes across all collection groups, following pagination
+	var indexes []firestoreIndex
+	pageToken := ""
+	for {
+		apiURL := fmt.Sprintf("%s/projects/%s/databases/%s/collectionGroups/-/indexes",
+			gcpFirestoreBaseURL, projectID, url.PathEscape(databaseID))
+		if pageToken != "" {
+			apiURL += "?pageToken=" + url.QueryEscape(pageToken)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error creating request: %v", err)), nil
+		}
+
+		resp, err := doWithRetry(client, req)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error making request to Firestore API: %v", err)), nil
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return mcp.NewToolResultError(fmt.Sprintf("Error from Firestore API: %s", resp.Status)), nil
+		}
+
+		var response struct {
+			Indexes       []firestoreIndex `json:"indexes"`
+			NextPageToken string           `json:"nextPageToken"`
+		}
+
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error parsing response: %v", err)), nil
+		}
+
+		indexes = append(indexes, response.Indexes...)
+
+		if response.NextPageToken == "" {
+			break
+		}
+		pageToken = response.NextPageToken
+	}
+
+	// Format the results
+	if len(indexes) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No composite indexes found in Firestore database %s. Queries that filter or order on multiple fields will fail with FAILED_PRECONDITION until an index is created.", databaseID)), nil
+	}
+
+	sortIndexesByState(indexes)
+
+	creating, needsRepair := 0, 0
+	for _, index := range indexes {
+		switch index.State {
+		case "CREATING":
+			creating++
+		case "NEEDS_REPAIR":
+			needsRepair++
+		}
+	}
+
+	result := fmt.Sprintf("# Firestore Indexes in Database %s\n\n", databaseID)
+	result += fmt.Sprintf("Found %d composite indexes (%d building, %d needing repair).\n\n", len(indexes), creating, needsRepair)
+	result += "| Collection Group | Fields | Scope | State |\n"
+	result += "| ---------------- | ------ | ----- | ----- |\n"
+	for _, index := range indexes {
+		state := index.State
+		if state != "READY" {
+			state = "**" + state + "**"
+		}
+		result += fmt.Sprintf("| %s | %s | %s | %s |\n", index.collectionGroup(), index.fieldsString(), index.QueryScope, state)
+	}
+
+	if creating > 0 || needsRepair > 0 {
+		result += "\n## Recommended Actions\n\n"
+		if creating > 0 {
+			result += "- Queries needing an index that is still CREATING fail with FAILED_PRECONDITION until the build completes. Large collections can take hours.\n"
+		}
+		if needsRepair > 0 {
+			result += "- Indexes in NEEDS_REPAIR failed to build, often because of a document exceeding index entry limits. Delete and recreate the index after fixing the offending documents.\n"
+		}
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
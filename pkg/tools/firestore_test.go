@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHandleGetFirestoreIndexStatus(t *testing.T) {
+	index := func(collection, state string, fields ...map[string]string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":       "projects/test-project/databases/(default)/collectionGroups/" + collection + "/indexes/" + collection + "-idx",
+			"queryScope": "COLLECTION",
+			"state":      state,
+			"fields":     fields,
+		}
+	}
+
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/databases/(default)/collectionGroups/-/indexes" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+
+		// The indexes come back over two pages
+		if r.URL.Query().Get("pageToken") == "" {
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"indexes": []map[string]interface{}{
+					index("users", "READY", map[string]string{"fieldPath": "email", "order": "ASCENDING"}, map[string]string{"fieldPath": "__name__", "order": "ASCENDING"}),
+					index("orders", "CREATING", map[string]string{"fieldPath": "status", "order": "ASCENDING"}, map[string]string{"fieldPath": "created", "order": "DESCENDING"}),
+				},
+				"nextPageToken": "page-2",
+			})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"indexes": []map[string]interface{}{
+			index("carts", "NEEDS_REPAIR", map[string]string{"fieldPath": "tags", "arrayConfig": "CONTAINS"}),
+		}})
+	}))
+
+	text := callTool(t, ctx, handleGetFirestoreIndexStatus, map[string]interface{}{"project_id": "test-project"})
+
+	// Indexes needing repair come first, then those building
+	want := "| carts | tags CONTAINS | COLLECTION | **NEEDS_REPAIR** |\n" +
+		"| orders | status ASCENDING, created DESCENDING | COLLECTION | **CREATING** |\n" +
+		"| users | email ASCENDING | COLLECTION | READY |\n"
+	if !strings.Contains(text, want) {
+		t.Errorf("result doesn't contain the index table %q:\n%s", want, text)
+	}
+	if !strings.Contains(text, "Found 3 composite indexes (1 building, 1 needing repair).") {
+		t.Errorf("result doesn't count the indexes by state:\n%s", text)
+	}
+}
@@ -0,0 +1,383 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GCP GKE Hub API base URL
+const gcpGKEHubBaseURL = "https://gkehub.googleapis.com/v1"
+
+// errHubNotFound is returned when a GKE Hub resource doesn't exist
+var errHubNotFound = errors.New("not found")
+
+// registerFleetTools registers all fleet related tools
+func registerFleetTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get GKE fleet membership status tool
+	getMembershipStatus := mcp.NewTool("get_gke_fleet_membership_status",
+		mcp.WithDescription("Reports a cluster's fleet (GKE Hub) membership state and, when Config Management is enabled, its Config Sync status and reconcile errors"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Description("The location of the cluster (required with cluster_name)"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Description("The name of the cluster (either cluster_name or membership is required)"),
+		),
+		mcp.WithString("membership",
+			mcp.Description("The fleet membership ID or full resource name, instead of looking it up from the cluster"),
+		),
+	)
+
+	getMembershipStatusHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetGKEFleetMembershipStatus(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getMembershipStatus, getMembershipStatusHandler)
+
+	return nil
+}
+
+// hubMembership is a fleet membership returned by the GKE Hub API
+type hubMembership struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	UpdateTime  string `json:"updateTime"`
+	State       struct {
+		Code string `json:"code"`
+	} `json:"state"`
+	Endpoint struct {
+		GkeCluster struct {
+			ResourceLink   string `json:"resourceLink"`
+			ClusterMissing bool   `json:"clusterMissing"`
+		} `json:"gkeCluster"`
+	} `json:"endpoint"`
+}
+
+// project returns the fleet host project from the membership name
+func (m hubMembership) project() string {
+	parts := strings.Split(m.Name, "/")
+	if len(parts) > 1 && parts[0] == "projects" {
+		return parts[1]
+	}
+	return ""
+}
+
+// configSyncError is a reconcile error reported by Config Sync
+type configSyncError struct {
+	Code           string `json:"code"`
+	ErrorMessage   string `json:"errorMessage"`
+	ErrorResources []struct {
+		SourcePath        string `json:"sourcePath"`
+		ResourceName      string `json:"resourceName"`
+		ResourceNamespace string `json:"resourceNamespace"`
+		ResourceGvk       struct {
+			Kind string `json:"kind"`
+		} `json:"resourceGvk"`
+	} `json:"errorResources"`
+}
+
+// configManagementState is the per-membership state of the Config Management fleet feature
+type configManagementState struct {
+	State struct {
+		Code        string `json:"code"`
+		Description string `json:"description"`
+	} `json:"state"`
+	Configmanagement struct {
+		MembershipSpec struct {
+			Version string `json:"version"`
+		} `json:"membershipSpec"`
+		ConfigSyncState struct {
+			State     string `json:"state"`
+			SyncState struct {
+				Code         string            `json:"code"`
+				LastSyncTime string            `json:"lastSyncTime"`
+				SourceToken  string            `json:"sourceToken"`
+				SyncToken    string            `json:"syncToken"`
+				Errors       []configSyncError `json:"errors"`
+			} `json:"syncState"`
+			Errors []struct {
+				ErrorMessage string `json:"errorMessage"`
+			} `json:"errors"`
+		} `json:"configSyncState"`
+	} `json:"configmanagement"`
+}
+
+// configSyncProblems lists the reasons a membership's Config Sync state
+// needs attention. An empty result means the membership is in sync.
+func configSyncProblems(state configManagementState) []string {
+	var problems []string
+
+	switch state.State.Code {
+	case "ERROR", "WARNING":
+		problem := fmt.Sprintf("Feature state is %s", state.State.Code)
+		if state.State.Description != "" {
+			problem += ": " + state.State.Description
+		}
+		problems = append(problems, problem)
+	}
+
+	syncState := state.Configmanagement.ConfigSyncState.SyncState
+	if syncState.Code != "" && syncState.Code != "SYNCED" {
+		problems = append(problems, fmt.Sprintf("Sync state is %s", syncState.Code))
+	}
+	if syncState.SourceToken != "" && syncState.SyncToken != "" && syncState.SourceToken != syncState.SyncToken {
+		problems = append(problems, fmt.Sprintf("Cluster is OUT_OF_SYNC: synced commit %s differs from source commit %s",
+			shortToken(syncState.SyncToken), shortToken(syncState.SourceToken)))
+	}
+
+	for _, syncErr := range syncState.Errors {
+		problem := syncErr.ErrorMessage
+		if syncErr.Code != "" {
+			problem = fmt.Sprintf("KNV%s: %s", syncErr.Code, problem)
+		}
+		for _, res := range syncErr.ErrorResources {
+			problem += fmt.Sprintf(" (%s %s", res.ResourceGvk.Kind, res.ResourceName)
+			if res.ResourceNamespace != "" {
+				problem += " in " + res.ResourceNamespace
+			}
+			if res.SourcePath != "" {
+				problem += ", " + res.SourcePath
+			}
+			problem += ")"
+		}
+		problems = append(problems, problem)
+	}
+
+	for _, installErr := range state.Configmanagement.ConfigSyncState.Errors {
+		problems = append(problems, installErr.ErrorMessage)
+	}
+
+	return problems
+}
+
+// shortToken shortens a commit hash for display
+func shortToken(token string) string {
+	if len(token) > 8 {
+		return token[:8]
+	}
+	return token
+}
+
+// getHub sends a GET request to the GKE Hub API
+func getHub(ctx context.Context, client *http.Client, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", gcpGKEHubBaseURL+"/"+path, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := doWithRetry(client, req)
+	if err != nil {
+		return fmt.Errorf("error making request to GKE Hub API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errHubNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error from GKE Hub API: %s", resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("error parsing response: %w", err)
+	}
+	return nil
+}
+
+// findMembership finds the membership in a project matching the predicate
+func findMembership(ctx context.Context, client *http.Client, projectID string, match func(hubMembership) bool) (*hubMembership, error) {
+	pageToken := ""
+	for {
+		path := fmt.Sprintf("projects/%s/locations/-/memberships", projectID)
+		if pageToken != "" {
+			path += "?pageToken=" + url.QueryEscape(pageToken)
+		}
+
+		var response struct {
+			Resources     []hubMembership `json:"resources"`
+			NextPageToken string          `json:"nextPageToken"`
+		}
+		if err := getHub(ctx, client, path, &response); err != nil {
+			return nil, err
+		}
+
+		for i := range response.Resources {
+			if match(response.Resources[i]) {
+				return &response.Resources[i], nil
+			}
+		}
+
+		if response.NextPageToken == "" {
+			return nil, nil
+		}
+		pageToken = response.NextPageToken
+	}
+}
+
+// lookupMembership resolves the fleet membership for a membership name or ID,
+// or for a cluster. It returns nil when the cluster isn't registered to a fleet.
+func lookupMembership(ctx context.Context, client *http.Client, projectID, location, clusterName, membership string) (*hubMembership, error) {
+	if strings.HasPrefix(membership, "projects/") {
+		var m hubMembership
+		if err := getHub(ctx, client, membership, &m); err != nil {
+			if errors.Is(err, errHubNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return &m, nil
+	}
+
+	if membership != "" {
+		return findMembership(ctx, client, projectID, func(m hubMembership) bool {
+			return strings.HasSuffix(m.Name, "/memberships/"+membership)
+		})
+	}
+
+	cluster, err := fetchCluster(ctx, client, projectID, location, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	if cluster.Fleet.Membership != "" {
+		name := strings.TrimPrefix(cluster.Fleet.Membership, "//gkehub.googleapis.com/")
+		return lookupMembership(ctx, client, projectID, location, clusterName, name)
+	}
+
+	// Clusters registered before fleet fields existed are matched by resource link
+	resourceLink := fmt.Sprintf("/projects/%s/locations/%s/clusters/%s", projectID, location, clusterName)
+	return findMembership(ctx, client, projectID, func(m hubMembership) bool {
+		return strings.HasSuffix(m.Endpoint.GkeCluster.ResourceLink, resourceLink)
+	})
+}
+
+// handleGetGKEFleetMembershipStatus handles the get_gke_fleet_membership_status tool request
+func handleGetGKEFleetMembershipStatus(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, _ := request.Params.Arguments["location"].(string)
+	clusterName, _ := request.Params.Arguments["cluster_name"].(string)
+	membership, _ := request.Params.Arguments["membership"].(string)
+
+	if membership == "" && (clusterName == "" || location == "") {
+		return mcp.NewToolResultError("either membership, or cluster_name and location, must be provided"), nil
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	m, err := lookupMembership(ctx, client, projectID, location, clusterName, membership)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error looking up fleet membership: %v", err)), nil
+	}
+
+	if m == nil {
+		target := membership
+		if target == "" {
+			target = "Cluster " + clusterName
+		} else {
+			target = "Membership " + target
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("%s is not registered to a fleet in project %s, so fleet features such as Config Management don't apply.", target, projectID)), nil
+	}
+
+	var problems []string
+
+	// Format the results
+	result := fmt.Sprintf("# Fleet Membership %s\n\n", m.Name)
+	result += fmt.Sprintf("- **State**: %s\n", m.State.Code)
+	if m.Endpoint.GkeCluster.ResourceLink != "" {
+		result += fmt.Sprintf("- **Cluster**: %s\n", m.Endpoint.GkeCluster.ResourceLink)
+	}
+	if m.UpdateTime != "" {
+		result += fmt.Sprintf("- **Last Updated**: %s\n", m.UpdateTime)
+	}
+	if m.State.Code != "READY" {
+		problems = append(problems, fmt.Sprintf("Membership state is %s", m.State.Code))
+	}
+	if m.Endpoint.GkeCluster.ClusterMissing {
+		problems = append(problems, "The registered GKE cluster no longer exists")
+	}
+	result += "\n"
+
+	// Config Management is a fleet-wide feature with per-membership state
+	var feature struct {
+		MembershipStates map[string]configManagementState `json:"membershipStates"`
+	}
+	featurePath := fmt.Sprintf("projects/%s/locations/global/features/configmanagement", m.project())
+	err = getHub(ctx, client, featurePath, &feature)
+
+	result += "## Config Management\n\n"
+	switch {
+	case errors.Is(err, errHubNotFound):
+		result += "Config Management is not enabled for this fleet.\n\n"
+	case err != nil:
+		result += fmt.Sprintf("Could not read Config Management status: %v\n\n", err)
+	default:
+		// State keys use the project number, so match on the location and membership ID
+		suffix := m.Name
+		if i := strings.Index(m.Name, "/locations/"); i >= 0 {
+			suffix = m.Name[i:]
+		}
+		var state *configManagementState
+		for key, s := range feature.MembershipStates {
+			if strings.HasSuffix(key, suffix) {
+				s := s
+				state = &s
+				break
+			}
+		}
+
+		if state == nil {
+			result += "Config Management is enabled for the fleet but not configured for this membership.\n\n"
+			break
+		}
+
+		syncState := state.Configmanagement.ConfigSyncState.SyncState
+		result += fmt.Sprintf("- **Version**: %s\n", state.Configmanagement.MembershipSpec.Version)
+		result += fmt.Sprintf("- **Config Sync**: %s\n", state.Configmanagement.ConfigSyncState.State)
+		result += fmt.Sprintf("- **Sync State**: %s\n", syncState.Code)
+		if syncState.LastSyncTime != "" {
+			result += fmt.Sprintf("- **Last Sync**: %s\n", syncState.LastSyncTime)
+		}
+		result += "\n"
+
+		problems = append(problems, configSyncProblems(*state)...)
+	}
+
+	if len(problems) == 0 {
+		result += "No fleet or Config Sync problems found.\n"
+		return mcp.NewToolResultText(result), nil
+	}
+
+	result += "## Problems\n\n"
+	for _, problem := range problems {
+		result += fmt.Sprintf("- **%s**\n", problem)
+	}
+
+	result += "\n## Recommended Actions\n\n"
+	result += "1. Reconcile errors name the offending resource and source path; fix the config in the source repository and let Config Sync retry\n"
+	result += "2. Check the Config Sync reconciler pods in the config-management-system namespace for crash loops or auth errors to the source repository\n"
+	result += "3. While the cluster is out of sync, manual changes to managed resources may be reverted or drift further\n"
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,106 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestConfigSyncProblems(t *testing.T) {
+	tests := []struct {
+		name  string
+		state string
+		want  []string
+	}{
+		{
+			name:  "synced",
+			state: `{"state": {"code": "OK"}, "configmanagement": {"configSyncState": {"syncState": {"code": "SYNCED", "sourceToken": "abc", "syncToken": "abc"}}}}`,
+		},
+		{
+			name:  "out of sync",
+			state: `{"configmanagement": {"configSyncState": {"syncState": {"code": "PENDING", "sourceToken": "0123456789abcdef", "syncToken": "fedcba9876543210"}}}}`,
+			want: []string{
+				"Sync state is PENDING",
+				"Cluster is OUT_OF_SYNC: synced commit fedcba98 differs from source commit 01234567",
+			},
+		},
+		{
+			name: "reconcile and install errors",
+			state: `{"state": {"code": "ERROR", "description": "Config Sync is failing"}, "configmanagement": {"configSyncState": {
+				"syncState": {"code": "ERROR", "errors": [{"code": "1021", "errorMessage": "unknown kind", "errorResources": [
+					{"sourcePath": "apps/web.yaml", "resourceName": "web", "resourceNamespace": "prod", "resourceGvk": {"kind": "Widget"}}
+				]}]},
+				"errors": [{"errorMessage": "reconciler is crash looping"}]
+			}}}`,
+			want: []string{
+				"Feature state is ERROR: Config Sync is failing",
+				"Sync state is ERROR",
+				"KNV1021: unknown kind (Widget web in prod, apps/web.yaml)",
+				"reconciler is crash looping",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		got := configSyncProblems(decodeJSON[configManagementState](t, tt.state))
+		if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("%s: configSyncProblems = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHandleGetGKEFleetMembershipStatus(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Host + r.URL.Path {
+		case "gkehub.googleapis.com/v1/projects/test-project/locations/-/memberships":
+			w.Write([]byte(`{"resources": [
+				{"name": "projects/test-project/locations/global/memberships/staging", "state": {"code": "READY"}},
+				{"name": "projects/test-project/locations/global/memberships/prod", "state": {"code": "READY"},
+				 "endpoint": {"gkeCluster": {"resourceLink": "//container.googleapis.com/projects/test-project/locations/us-central1/clusters/prod"}}}
+			]}`))
+		case "gkehub.googleapis.com/v1/projects/test-project/locations/global/features/configmanagement":
+			// State keys use the project number rather than the project ID
+			w.Write([]byte(`{"membershipStates": {
+				"projects/123456/locations/global/memberships/prod": {"configmanagement": {
+					"membershipSpec": {"version": "1.19.0"},
+					"configSyncState": {"state": "INSTALLED", "syncState": {"code": "SYNCED", "sourceToken": "0123456789", "syncToken": "9876543210"}}
+				}}
+			}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	text := callTool(t, ctx, handleGetGKEFleetMembershipStatus, map[string]interface{}{
+		"project_id": "test-project",
+		"membership": "prod",
+	})
+
+	for _, s := range []string{
+		"# Fleet Membership projects/test-project/locations/global/memberships/prod",
+		"- **Cluster**: //container.googleapis.com/projects/test-project/locations/us-central1/clusters/prod",
+		"- **Version**: 1.19.0",
+		"- **Cluster is OUT_OF_SYNC: synced commit 98765432 differs from source commit 01234567**",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
+
+func TestHandleGetGKEFleetMembershipStatusNotRegistered(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+
+	text := callTool(t, ctx, handleGetGKEFleetMembershipStatus, map[string]interface{}{
+		"project_id": "test-project",
+		"membership": "projects/test-project/locations/global/memberships/gone",
+	})
+
+	if !strings.Contains(text, "Membership projects/test-project/locations/global/memberships/gone is not registered to a fleet") {
+		t.Errorf("missing membership wasn't reported as unregistered:\n%s", text)
+	}
+}
@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -18,17 +19,18 @@ import (
 func registerGCPIssuesTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
 	// Register list active issues tool
 	listActiveIssues := mcp.NewTool("list_active_issues",
-		mcp.WithDescription("Lists active issues from GCP Error Reporting"),
+		mcp.WithDescription("Lists active issues from GCP Error Reporting. JSON output is an object holding the issue summaries, with partial set if the call was cancelled."),
 		mcp.WithString("project_id",
 			mcp.Required(),
 			mcp.Description("The Google Cloud project ID"),
 		),
 		mcp.WithNumber("time_range_hours",
-			mcp.Description("Time range for issues in hours (default: 24)"),
+			mcp.Description("Time range for issues in hours (default: 24). Error Reporting only supports 1, 6, 24, 168 and 720 hours, so other values are rounded to the nearest of these."),
 		),
 		mcp.WithNumber("max_results",
 			mcp.Description("Maximum number of results to return (default: 10)"),
 		),
+		withOutputFormat(),
 	)
 
 	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -58,38 +60,57 @@ func registerGCPIssuesTools(s *server.MCPServer, authHandler *auth.OAuthHandler)
 	// Register the tool using the safe wrapper
 	AddToolSafe(s, getIssueDetails, detailsHandler)
 
+	if err := registerErrorLogCorrelationTools(s, authHandler); err != nil {
+		return err
+	}
+
 	return nil
 }
 
-// handleListActiveIssues handles the list_active_issues tool request
-func handleListActiveIssues(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
-	// Extract parameters
-	projectID, ok := request.Params.Arguments["project_id"].(string)
-	if !ok || projectID == "" {
-		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
-	}
+// errorReportingPeriod is a time range supported by Error Reporting
+type errorReportingPeriod struct {
+	Period errorreportingpb.QueryTimeRange_Period
+	Hours  float64
+	Label  string
+}
 
-	// Unused but kept for future use
-	// timeRangeHours := 24.0
-	// if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
-	// 	timeRangeHours = val
-	// }
+// errorReportingPeriods are the time ranges Error Reporting can query,
+// shortest first
+var errorReportingPeriods = []errorReportingPeriod{
+	{errorreportingpb.QueryTimeRange_PERIOD_1_HOUR, 1, "1 hour"},
+	{errorreportingpb.QueryTimeRange_PERIOD_6_HOURS, 6, "6 hours"},
+	{errorreportingpb.QueryTimeRange_PERIOD_1_DAY, 24, "1 day"},
+	{errorreportingpb.QueryTimeRange_PERIOD_1_WEEK, 7 * 24, "7 days"},
+	{errorreportingpb.QueryTimeRange_PERIOD_30_DAYS, 30 * 24, "30 days"},
+}
 
-	maxResults := int32(10)
-	if val, ok := request.Params.Arguments["max_results"].(float64); ok && val > 0 {
-		maxResults = int32(val)
+// nearestErrorReportingPeriod returns the supported time range closest to
+// hours, preferring the longer range on a tie so no errors are missed
+func nearestErrorReportingPeriod(hours float64) errorReportingPeriod {
+	nearest := errorReportingPeriods[0]
+	for _, period := range errorReportingPeriods[1:] {
+		if math.Abs(period.Hours-hours) <= math.Abs(nearest.Hours-hours) {
+			nearest = period
+		}
 	}
+	return nearest
+}
 
+// fetchErrorGroupStats lists the error groups with occurrences in the period,
+// requesting pageSize groups at a time. If iteration fails part way, the
+// groups read so far are returned with the error.
+func fetchErrorGroupStats(ctx context.Context, authHandler *auth.OAuthHandler, projectID string, period errorreportingpb.QueryTimeRange_Period, pageSize int32) ([]*errorreportingpb.ErrorGroupStats, error) {
 	// Get client options
 	opts, err := authHandler.GetClientOptions(ctx)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error getting client options: %v", err)), nil
+		return nil, fmt.Errorf("error getting client options: %w", err)
 	}
 
-	// Create error reporting client
-	client, err := errorreporting.NewErrorStatsClient(ctx, opts...)
+	// Create error reporting client. The REST client is used because OAuth
+	// sign-in passes an HTTP client, which the gRPC client rejects.
+	client, err := errorreporting.NewErrorStatsRESTClient(ctx, opts...)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error creating Error Reporting client: %v", err)), nil
+		return nil, fmt.Errorf("error creating Error Reporting client: %w", err)
 	}
 	defer client.Close()
 
@@ -97,9 +118,9 @@ func handleListActiveIssues(ctx context.Context, request mcp.CallToolRequest, au
 	req := &errorreportingpb.ListGroupStatsRequest{
 		ProjectName: fmt.Sprintf("projects/%s", projectID),
 		TimeRange: &errorreportingpb.QueryTimeRange{
-			Period: errorreportingpb.QueryTimeRange_PERIOD_1_DAY,
+			Period: period,
 		},
-		PageSize: maxResults,
+		PageSize: pageSize,
 		// The GCP SDK uses different enum names than the raw API
 		// Here we're ordering by count (most frequent first)
 		Alignment: errorreportingpb.TimedCountAlignment_ALIGNMENT_EQUAL_ROUNDED,
@@ -108,47 +129,96 @@ func handleListActiveIssues(ctx context.Context, request mcp.CallToolRequest, au
 	// Call the API
 	groupStatsIterator := client.ListGroupStats(ctx, req)
 
-	// Process results
 	var errorGroupStats []*errorreportingpb.ErrorGroupStats
 	for {
+		// The REST client doesn't attach the context to its HTTP requests, so
+		// paging only stops on cancellation if it's checked here
+		if err := ctx.Err(); err != nil {
+			return errorGroupStats, fmt.Errorf("error iterating through error groups: %w", err)
+		}
 		stat, err := groupStatsIterator.Next()
 		if err == iterator.Done {
 			break
 		}
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Error iterating through error groups: %v", err)), nil
+			return errorGroupStats, fmt.Errorf("error iterating through error groups: %w", err)
 		}
 		errorGroupStats = append(errorGroupStats, stat)
 	}
 
+	return errorGroupStats, nil
+}
+
+// handleListActiveIssues handles the list_active_issues tool request
+func handleListActiveIssues(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	timeRangeHours := 24.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok {
+		if val <= 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("time_range_hours must be positive, got %g", val)), nil
+		}
+		timeRangeHours = val
+	}
+	period := nearestErrorReportingPeriod(timeRangeHours)
+
+	maxResults := int32(10)
+	if val, ok := request.Params.Arguments["max_results"].(float64); ok && val > 0 {
+		maxResults = int32(val)
+	}
+
+	format, err := outputFormat(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	errorGroupStats, err := fetchErrorGroupStats(ctx, authHandler, projectID, period.Period, maxResults)
+	cancelled := false
+	if err != nil {
+		if !partialOnCancel(err, len(errorGroupStats)) {
+			return mcp.NewToolResultError(fmt.Sprintf("Error listing error groups: %v", err)), nil
+		}
+		cancelled = true
+	}
+
+	issues := summariseErrorGroups(errorGroupStats)
+
+	// JSON output must stay parseable, so a cancelled call marks the issues as
+	// partial instead of appending the cancellation note
+	if format == outputJSON {
+		return jsonResult(activeIssuesView{Issues: issues, Partial: cancelled})
+	}
+
 	// Format the results
 	var result string
-	if len(errorGroupStats) == 0 {
-		result = "No active issues found in the specified time range."
+	if period.Hours != timeRangeHours {
+		result = fmt.Sprintf("Note: Error Reporting doesn't support a %g hour time range, so the nearest supported range of %s was used.\n\n", timeRangeHours, period.Label)
+	}
+	if len(issues) == 0 {
+		result += fmt.Sprintf("No active issues found in the last %s.", period.Label)
 	} else {
-		result = fmt.Sprintf("Found %d active issues in project %s:\n\n", len(errorGroupStats), projectID)
-
-		for i, stat := range errorGroupStats {
-			// Extract the group ID from the name (e.g., "projects/my-project/groups/some-group-id")
-			groupIDParts := strings.Split(stat.Group.Name, "/")
-			groupID := groupIDParts[len(groupIDParts)-1]
+		result += fmt.Sprintf("Found %d active issues in project %s in the last %s:\n\n", len(issues), projectID, period.Label)
 
-			result += fmt.Sprintf("%d. Error Group: %s\n", i+1, groupID)
-			result += fmt.Sprintf("   Count: %d occurrences\n", stat.Count)
+		for i, issue := range issues {
+			result += fmt.Sprintf("%d. Error Group: %s\n", i+1, issue.GroupID)
+			result += fmt.Sprintf("   Count: %d occurrences\n", issue.Count)
 
-			if stat.FirstSeenTime != nil {
-				firstSeen := stat.FirstSeenTime.AsTime()
-				result += fmt.Sprintf("   First seen: %s\n", firstSeen.Format(time.RFC3339))
+			if issue.FirstSeen != nil {
+				result += fmt.Sprintf("   First seen: %s\n", issue.FirstSeen.Format(time.RFC3339))
 			}
 
-			if stat.LastSeenTime != nil {
-				lastSeen := stat.LastSeenTime.AsTime()
-				result += fmt.Sprintf("   Last seen: %s\n", lastSeen.Format(time.RFC3339))
+			if issue.LastSeen != nil {
+				result += fmt.Sprintf("   Last seen: %s\n", issue.LastSeen.Format(time.RFC3339))
 			}
 
-			if len(stat.AffectedServices) > 0 {
+			if len(issue.AffectedServices) > 0 {
 				result += "   Affected services:\n"
-				for _, svc := range stat.AffectedServices {
+				for _, svc := range issue.AffectedServices {
 					result += fmt.Sprintf("     - %s (version: %s)\n", svc.Service, svc.Version)
 				}
 			}
@@ -159,55 +229,126 @@ func handleListActiveIssues(ctx context.Context, request mcp.CallToolRequest, au
 		result += "To get more details about a specific error group, use the get_issue_details tool."
 	}
 
+	if cancelled {
+		result += cancelledNote
+	}
+
 	return mcp.NewToolResultText(result), nil
 }
 
-// handleGetIssueDetails handles the get_issue_details tool request
-func handleGetIssueDetails(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
-	// Extract parameters
-	projectID, ok := request.Params.Arguments["project_id"].(string)
-	if !ok || projectID == "" {
-		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
-	}
+// issueService is a service affected by an error group
+type issueService struct {
+	Service string `json:"service"`
+	Version string `json:"version"`
+}
 
-	errorGroupID, ok := request.Params.Arguments["error_group_id"].(string)
-	if !ok || errorGroupID == "" {
-		return mcp.NewToolResultError("error_group_id must be a non-empty string"), nil
+// issueSummary is the structured form of an active error group
+type issueSummary struct {
+	GroupID          string         `json:"groupId"`
+	Count            int64          `json:"count"`
+	FirstSeen        *time.Time     `json:"firstSeen"`
+	LastSeen         *time.Time     `json:"lastSeen"`
+	AffectedServices []issueService `json:"affectedServices"`
+}
+
+// activeIssuesView is the JSON output of list_active_issues
+type activeIssuesView struct {
+	Issues []issueSummary `json:"issues"`
+	// Partial is set when the call was cancelled before every group was listed
+	Partial bool `json:"partial,omitempty"`
+}
+
+// summariseErrorGroups converts Error Reporting group stats into issue summaries
+func summariseErrorGroups(stats []*errorreportingpb.ErrorGroupStats) []issueSummary {
+	issues := make([]issueSummary, 0, len(stats))
+	for _, stat := range stats {
+		// Extract the group ID from the name (e.g., "projects/my-project/groups/some-group-id")
+		groupIDParts := strings.Split(stat.GetGroup().GetName(), "/")
+
+		issue := issueSummary{
+			GroupID:          groupIDParts[len(groupIDParts)-1],
+			Count:            stat.Count,
+			AffectedServices: []issueService{},
+		}
+
+		if stat.FirstSeenTime != nil {
+			firstSeen := stat.FirstSeenTime.AsTime()
+			issue.FirstSeen = &firstSeen
+		}
+
+		if stat.LastSeenTime != nil {
+			lastSeen := stat.LastSeenTime.AsTime()
+			issue.LastSeen = &lastSeen
+		}
+
+		for _, svc := range stat.AffectedServices {
+			issue.AffectedServices = append(issue.AffectedServices, issueService{Service: svc.Service, Version: svc.Version})
+		}
+
+		issues = append(issues, issue)
 	}
+	return issues
+}
 
+// fetchErrorEvents lists up to maxEvents of an error group's most recent
+// events, newest first
+func fetchErrorEvents(ctx context.Context, authHandler *auth.OAuthHandler, projectID, errorGroupID string, maxEvents int) ([]*errorreportingpb.ErrorEvent, error) {
 	// Get client options
 	opts, err := authHandler.GetClientOptions(ctx)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error getting client options: %v", err)), nil
+		return nil, fmt.Errorf("error getting client options: %w", err)
 	}
 
 	// Create error reporting client
-	errClient, err := errorreporting.NewErrorStatsClient(ctx, opts...)
+	client, err := errorreporting.NewErrorStatsRESTClient(ctx, opts...)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error creating Error Reporting client: %v", err)), nil
+		return nil, fmt.Errorf("error creating Error Reporting client: %w", err)
 	}
-	defer errClient.Close()
+	defer client.Close()
 
-	// Get errors in the group
-	errorsRequest := &errorreportingpb.ListEventsRequest{
+	req := &errorreportingpb.ListEventsRequest{
 		ProjectName: fmt.Sprintf("projects/%s", projectID),
 		GroupId:     errorGroupID,
-		PageSize:    10,
+		PageSize:    int32(maxEvents),
 	}
 
-	eventsIterator := errClient.ListEvents(ctx, errorsRequest)
+	eventsIterator := client.ListEvents(ctx, req)
 
-	// Process results
-	var errorEvents []*errorreportingpb.ErrorEvent
-	for {
+	var events []*errorreportingpb.ErrorEvent
+	for len(events) < maxEvents {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("error iterating through error events: %w", err)
+		}
 		event, err := eventsIterator.Next()
 		if err == iterator.Done {
 			break
 		}
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Error iterating through error events: %v", err)), nil
+			return nil, fmt.Errorf("error iterating through error events: %w", err)
 		}
-		errorEvents = append(errorEvents, event)
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// handleGetIssueDetails handles the get_issue_details tool request
+func handleGetIssueDetails(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	errorGroupID, ok := request.Params.Arguments["error_group_id"].(string)
+	if !ok || errorGroupID == "" {
+		return mcp.NewToolResultError("error_group_id must be a non-empty string"), nil
+	}
+
+	// Get errors in the group
+	errorEvents, err := fetchErrorEvents(ctx, authHandler, projectID, errorGroupID, 10)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting error events: %v", err)), nil
 	}
 
 	// Format the results
@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// errorReportingTransport serves body for every Error Reporting request
+func errorReportingTransport(t *testing.T, body string) http.RoundTripper {
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Host != "clouderrorreporting.googleapis.com" {
+			t.Errorf("unexpected request to %s", req.URL)
+		}
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Header: http.Header{"Content-Type": {"application/json"}}, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+}
+
+func TestHandleListActiveIssuesOutputFormat(t *testing.T) {
+	// The tool uses the shared output_format parameter
+	useEmptyToolRegistry(t)
+	if err := registerGCPIssuesTools(server.NewMCPServer("test", "0.0.0"), newTestAuthHandler(t, auth.ReadOnlyScopes)); err != nil {
+		t.Fatalf("registerGCPIssuesTools: %v", err)
+	}
+	tool, _ := registeredTool("list_active_issues")
+	want := mcp.NewTool("shared", withOutputFormat()).InputSchema.Properties["output_format"]
+	if got := tool.InputSchema.Properties["output_format"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("output_format = %v, want the shared parameter %v", got, want)
+	}
+
+	tests := []struct {
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{format: "", want: "Found 1 active issues"},
+		{format: "markdown", want: "Found 1 active issues"},
+		{format: "json", want: `"groupId": "g1"`},
+		{format: "yaml", want: `output_format must be "markdown" or "json", got "yaml"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		ctx := withGCPTransport(context.Background(), errorReportingTransport(t, `{"errorGroupStats": [{"group": {"name": "projects/test-project/groups/g1"}, "count": "3"}]}`))
+		args := map[string]interface{}{"project_id": "test-project"}
+		if tt.format != "" {
+			args["output_format"] = tt.format
+		}
+
+		result, err := handleListActiveIssues(ctx, newToolRequest(args), newTestAuthHandler(t, auth.ReadOnlyScopes))
+		if err != nil {
+			t.Fatalf("handleListActiveIssues returned error: %v", err)
+		}
+		text, _ := resultText(result)
+		if result.IsError != tt.wantErr || !strings.Contains(text, tt.want) {
+			t.Errorf("output_format %q = %q (error %t), want %q", tt.format, text, result.IsError, tt.want)
+		}
+	}
+}
+
+func TestHandleListActiveIssuesJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "issues",
+			body: `{"errorGroupStats": [{
+				"group": {"name": "projects/test-project/groups/g1"}, "count": "42",
+				"firstSeenTime": "2026-10-16T01:00:00Z", "lastSeenTime": "2026-10-17T01:00:00Z",
+				"affectedServices": [{"service": "checkout", "version": "v7"}]
+			}]}`,
+			want: `{"issues": [{"groupId": "g1", "count": 42, "firstSeen": "2026-10-16T01:00:00Z", "lastSeen": "2026-10-17T01:00:00Z",
+				"affectedServices": [{"service": "checkout", "version": "v7"}]}]}`,
+		},
+		{
+			// No issues is an empty list rather than null
+			name: "no issues",
+			body: `{}`,
+			want: `{"issues": []}`,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := withGCPTransport(context.Background(), errorReportingTransport(t, tt.body))
+		text := callTool(t, ctx, handleListActiveIssues, map[string]interface{}{
+			"project_id":    "test-project",
+			"output_format": "json",
+		})
+
+		got := decodeJSON[map[string]interface{}](t, text)
+		want := decodeJSON[map[string]interface{}](t, tt.want)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: result = %s, want %s", tt.name, text, tt.want)
+		}
+	}
+}
@@ -0,0 +1,504 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// GCP Org Policy API base URL
+	gcpOrgPolicyBaseURL = "https://orgpolicy.googleapis.com/v2"
+
+	// GCP Resource Manager API base URLs; getAncestry is only in v1
+	gcpResourceManagerV1BaseURL = "https://cloudresourcemanager.googleapis.com/v1"
+	gcpResourceManagerBaseURL   = "https://cloudresourcemanager.googleapis.com/v3"
+)
+
+// watchedConstraints are org policy constraints that commonly block everyday
+// operations, with a description of what they prevent when enforced
+var watchedConstraints = map[string]string{
+	"compute.vmExternalIpAccess":                   "VMs, including GKE nodes, can't be given external IP addresses",
+	"gcp.resourceLocations":                        "Resources can only be created in the allowed locations",
+	"gcp.restrictServiceUsage":                     "Only allowed services can be used in the project",
+	"gcp.restrictNonCmekServices":                  "Listed services require customer-managed encryption keys",
+	"compute.restrictVpcPeering":                   "VPC peering is limited to allowed networks",
+	"compute.restrictLoadBalancerCreationForTypes": "Only allowed load balancer types can be created",
+	"compute.vmCanIpForward":                       "VMs can't enable IP forwarding",
+	"compute.requireShieldedVm":                    "VMs must use Shielded VM images",
+	"compute.skipDefaultNetworkCreation":           "New projects are created without a default network",
+	"sql.restrictPublicIp":                         "Cloud SQL instances can't have a public IP",
+	"storage.publicAccessPrevention":               "Cloud Storage buckets can't be made public",
+	"iam.disableServiceAccountKeyCreation":         "Service account keys can't be created",
+	"iam.allowedPolicyMemberDomains":               "IAM bindings are limited to members from allowed domains",
+}
+
+// orgPolicy is an effective org policy returned by the Org Policy API
+type orgPolicy struct {
+	Name string `json:"name"`
+	Spec struct {
+		Rules []struct {
+			Values *struct {
+				AllowedValues []string `json:"allowedValues"`
+				DeniedValues  []string `json:"deniedValues"`
+			} `json:"values"`
+			AllowAll  bool `json:"allowAll"`
+			DenyAll   bool `json:"denyAll"`
+			Enforce   bool `json:"enforce"`
+			Condition *struct {
+				Expression string `json:"expression"`
+				Title      string `json:"title"`
+			} `json:"condition"`
+		} `json:"rules"`
+	} `json:"spec"`
+}
+
+// constraint returns the constraint name, e.g. "compute.vmExternalIpAccess"
+func (p orgPolicy) constraint() string {
+	return p.Name[strings.LastIndex(p.Name, "/")+1:]
+}
+
+// policyEffect summarises what an effective policy allows
+type policyEffect struct {
+	Restricted  bool
+	Conditional bool
+	Summary     string
+}
+
+// evaluatePolicy summarises the rules of an effective policy, treating any
+// rule that denies, enforces or limits values as restrictive
+func evaluatePolicy(p orgPolicy) policyEffect {
+	if len(p.Spec.Rules) == 0 {
+		return policyEffect{Summary: "Not set (default behaviour)"}
+	}
+
+	var effect policyEffect
+	var parts []string
+	for _, rule := range p.Spec.Rules {
+		if rule.Condition != nil {
+			effect.Conditional = true
+		}
+
+		switch {
+		case rule.DenyAll:
+			effect.Restricted = true
+			parts = append(parts, "deny all")
+		case rule.AllowAll:
+			parts = append(parts, "allow all")
+		case rule.Enforce:
+			effect.Restricted = true
+			parts = append(parts, "enforced")
+		case rule.Values != nil && len(rule.Values.AllowedValues) > 0:
+			effect.Restricted = true
+			parts = append(parts, "allowed: "+strings.Join(rule.Values.AllowedValues, ", "))
+		case rule.Values != nil && len(rule.Values.DeniedValues) > 0:
+			effect.Restricted = true
+			parts = append(parts, "denied: "+strings.Join(rule.Values.DeniedValues, ", "))
+		default:
+			parts = append(parts, "not enforced")
+		}
+	}
+
+	effect.Summary = strings.Join(parts, "; ")
+	if effect.Conditional {
+		effect.Summary += " (conditional)"
+	}
+	return effect
+}
+
+// errOrgPolicyPermission is returned when the caller can't read org policies
+var errOrgPolicyPermission = errors.New("permission denied reading org policies")
+
+// fetchEffectivePolicy gets the effective policy for a constraint on a project
+func fetchEffectivePolicy(ctx context.Context, client *http.Client, projectID, constraint string) (orgPolicy, error) {
+	var policy orgPolicy
+
+	apiURL := fmt.Sprintf("%s/projects/%s/policies/%s:getEffectivePolicy",
+		gcpOrgPolicyBaseURL, projectID, url.PathEscape(constraint))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return policy, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := doWithRetry(client, req)
+	if err != nil {
+		return policy, fmt.Errorf("error making request to Org Policy API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusForbidden:
+		return policy, errOrgPolicyPermission
+	default:
+		return policy, fmt.Errorf("error from Org Policy API: %s", resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&policy); err != nil {
+		return policy, fmt.Errorf("error parsing response: %w", err)
+	}
+	return policy, nil
+}
+
+// fetchProjectPolicyConstraints lists the constraints with a policy set
+// directly on the project
+func fetchProjectPolicyConstraints(ctx context.Context, client *http.Client, projectID string) ([]string, error) {
+	var constraints []string
+	pageToken := ""
+	for {
+		apiURL := fmt.Sprintf("%s/projects/%s/policies", gcpOrgPolicyBaseURL, projectID)
+		if pageToken != "" {
+			apiURL += "?pageToken=" + url.QueryEscape(pageToken)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+
+		resp, err := doWithRetry(client, req)
+		if err != nil {
+			return nil, fmt.Errorf("error making request to Org Policy API: %w", err)
+		}
+
+		if resp.StatusCode == http.StatusForbidden {
+			resp.Body.Close()
+			return nil, errOrgPolicyPermission
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("error from Org Policy API: %s", resp.Status)
+		}
+
+		var response struct {
+			Policies      []orgPolicy `json:"policies"`
+			NextPageToken string      `json:"nextPageToken"`
+		}
+
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error parsing response: %w", err)
+		}
+
+		for _, policy := range response.Policies {
+			constraints = append(constraints, policy.constraint())
+		}
+
+		if response.NextPageToken == "" {
+			break
+		}
+		pageToken = response.NextPageToken
+	}
+	return constraints, nil
+}
+
+// registerGovernanceTools registers all governance and policy related tools
+func registerGovernanceTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get organization policy violations tool
+	getPolicyViolations := mcp.NewTool("get_organization_policy_violations",
+		mcp.WithDescription("Lists effective org policies on a project and flags constraints likely to block common operations, explaining \"why can't I create X here\" failures"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+	)
+
+	getPolicyViolationsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetOrganizationPolicyViolations(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getPolicyViolations, getPolicyViolationsHandler)
+
+	// Register get resource hierarchy tool
+	getResourceHierarchy := mcp.NewTool("get_resource_hierarchy",
+		mcp.WithDescription("Reports where a project sits in the resource hierarchy (project, folders, organization), to understand which IAM policies and org policies it inherits"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+	)
+
+	getResourceHierarchyHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetResourceHierarchy(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getResourceHierarchy, getResourceHierarchyHandler)
+
+	return nil
+}
+
+// handleGetOrganizationPolicyViolations handles the get_organization_policy_violations tool request
+func handleGetOrganizationPolicyViolations(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	permissionError := mcp.NewToolResultError(fmt.Sprintf("Permission denied reading org policies for project %s. "+
+		"Grant the Organization Policy Viewer role (roles/orgpolicy.policyViewer) on the project or its organization, "+
+		"and make sure the Org Policy API (orgpolicy.googleapis.com) is enabled.", projectID))
+
+	// Check the watched constraints plus anything set directly on the project
+	constraints := make(map[string]bool, len(watchedConstraints))
+	for constraint := range watchedConstraints {
+		constraints[constraint] = true
+	}
+
+	projectConstraints, err := fetchProjectPolicyConstraints(ctx, client, projectID)
+	if errors.Is(err, errOrgPolicyPermission) {
+		return permissionError, nil
+	}
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing org policies: %v", err)), nil
+	}
+	for _, constraint := range projectConstraints {
+		constraints[constraint] = true
+	}
+
+	names := make([]string, 0, len(constraints))
+	for constraint := range constraints {
+		names = append(names, constraint)
+	}
+	sort.Strings(names)
+
+	type policyRow struct {
+		Constraint string
+		Effect     policyEffect
+	}
+	var restricted, unrestricted []policyRow
+	for _, constraint := range names {
+		policy, err := fetchEffectivePolicy(ctx, client, projectID, "constraints/"+constraint)
+		if errors.Is(err, errOrgPolicyPermission) {
+			return permissionError, nil
+		}
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error getting effective policy for %s: %v", constraint, err)), nil
+		}
+
+		row := policyRow{Constraint: constraint, Effect: evaluatePolicy(policy)}
+		if row.Effect.Restricted {
+			restricted = append(restricted, row)
+		} else {
+			unrestricted = append(unrestricted, row)
+		}
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# Effective Org Policies for Project %s\n\n", projectID)
+	result += fmt.Sprintf("Checked %d constraints (%d set directly on the project). %d restrict operations.\n\n",
+		len(names), len(projectConstraints), len(restricted))
+
+	if len(restricted) > 0 {
+		result += "## Restrictive Policies\n\n"
+		result += "| Constraint | Effect | Impact |\n"
+		result += "| ---------- | ------ | ------ |\n"
+		for _, row := range restricted {
+			impact := watchedConstraints[row.Constraint]
+			if impact == "" {
+				impact = "Custom or less common constraint, check its description"
+			}
+			result += fmt.Sprintf("| **%s** | %s | %s |\n", row.Constraint, row.Effect.Summary, impact)
+		}
+		result += "\n"
+	}
+
+	if len(unrestricted) > 0 {
+		result += "## Unrestricted Policies\n\n"
+		for _, row := range unrestricted {
+			result += fmt.Sprintf("- %s: %s\n", row.Constraint, row.Effect.Summary)
+		}
+		result += "\n"
+	}
+
+	result += "## Recommended Actions\n\n"
+	result += "1. If a create call failed with a constraint violation, match the constraint in the error against the table above\n"
+	result += "2. Policy exceptions must be made by an org policy administrator, usually with a project-level override or a tag-based condition\n"
+	result += "3. VPC Service Controls perimeters aren't org policies; check get_audit_log_access_denials for VPC_SERVICE_CONTROLS violations\n"
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// resourceAncestor is a resource in a project's ancestry, as returned by the
+// Resource Manager getAncestry method
+type resourceAncestor struct {
+	ResourceID struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+	} `json:"resourceId"`
+}
+
+// resourceName returns the Resource Manager v3 name of the ancestor, such as
+// folders/123
+func (a resourceAncestor) resourceName() string {
+	switch a.ResourceID.Type {
+	case "organization":
+		return "organizations/" + a.ResourceID.ID
+	case "folder":
+		return "folders/" + a.ResourceID.ID
+	default:
+		return "projects/" + a.ResourceID.ID
+	}
+}
+
+// fetchProjectAncestry gets a project's ancestors, starting with the project
+// itself and ending with its organization, if it has one
+func fetchProjectAncestry(ctx context.Context, client *http.Client, projectID string) ([]resourceAncestor, error) {
+	apiURL := fmt.Sprintf("%s/projects/%s:getAncestry", gcpResourceManagerV1BaseURL, projectID)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, strings.NewReader("{}"))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// getAncestry is read-only, so the POST is safe to retry
+	req = markIdempotent(req)
+
+	resp, err := doWithRetry(client, req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to Resource Manager API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error from Resource Manager API: %s", resp.Status)
+	}
+
+	var response struct {
+		Ancestor []resourceAncestor `json:"ancestor"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("error parsing response: %w", err)
+	}
+
+	return response.Ancestor, nil
+}
+
+// fetchResourceDisplayName gets the display name of a folder or organization
+func fetchResourceDisplayName(ctx context.Context, client *http.Client, name string) (string, error) {
+	apiURL := fmt.Sprintf("%s/%s", gcpResourceManagerBaseURL, name)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := doWithRetry(client, req)
+	if err != nil {
+		return "", fmt.Errorf("error making request to Resource Manager API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error from Resource Manager API: %s", resp.Status)
+	}
+
+	var resource struct {
+		DisplayName string `json:"displayName"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&resource); err != nil {
+		return "", fmt.Errorf("error parsing response: %w", err)
+	}
+
+	return resource.DisplayName, nil
+}
+
+// formatHierarchyPath renders a project's ancestry from its organization down
+// to the project, using display names where known
+func formatHierarchyPath(ancestors []resourceAncestor, displayNames map[string]string) string {
+	var parts []string
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		ancestor := ancestors[i]
+		part := fmt.Sprintf("%s %s", ancestor.ResourceID.Type, ancestor.ResourceID.ID)
+		if name := displayNames[ancestor.resourceName()]; name != "" {
+			part = fmt.Sprintf("%s %q (%s)", ancestor.ResourceID.Type, name, ancestor.ResourceID.ID)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " → ")
+}
+
+// handleGetResourceHierarchy handles the get_resource_hierarchy tool request
+func handleGetResourceHierarchy(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	ancestors, err := fetchProjectAncestry(ctx, client, projectID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting project ancestry: %v", err)), nil
+	}
+	if len(ancestors) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("no ancestry returned for project %s", projectID)), nil
+	}
+
+	// Display names need folder and organization read access, which the
+	// caller may not have; IDs are shown instead
+	displayNames := make(map[string]string)
+	unnamed := 0
+	for _, ancestor := range ancestors {
+		if ancestor.ResourceID.Type == "project" {
+			continue
+		}
+		name, err := fetchResourceDisplayName(ctx, client, ancestor.resourceName())
+		if err != nil || name == "" {
+			unnamed++
+			continue
+		}
+		displayNames[ancestor.resourceName()] = name
+	}
+
+	hasOrganization := ancestors[len(ancestors)-1].ResourceID.Type == "organization"
+
+	// Format the results
+	result := fmt.Sprintf("# Resource Hierarchy for Project %s\n\n", projectID)
+	result += formatHierarchyPath(ancestors, displayNames) + "\n\n"
+
+	result += "| Level | Type | ID | Name |\n"
+	result += "| ----- | ---- | -- | ---- |\n"
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		ancestor := ancestors[i]
+		result += fmt.Sprintf("| %d | %s | %s | %s |\n", len(ancestors)-i, ancestor.ResourceID.Type, ancestor.ResourceID.ID, valueOrDash(displayNames[ancestor.resourceName()]))
+	}
+	result += "\n"
+
+	if !hasOrganization {
+		result += "The project has no organization, so it inherits no IAM or org policies from above.\n\n"
+	}
+	if unnamed > 0 {
+		result += fmt.Sprintf("Note: The names of %d folders or organizations couldn't be read; this needs the Folder Viewer or Organization Viewer role.\n\n", unnamed)
+	}
+
+	result += "## Recommended Actions\n\n"
+	result += "1. IAM bindings on every level above the project are inherited, so check each folder and the organization when access is unexpectedly granted or denied\n"
+	result += "2. Org policies are also inherited unless overridden; use get_organization_policy_violations for the project's effective policies\n"
+	result += "3. Moving a project between folders changes what it inherits; if the path looks unexpected, check the Admin Activity audit logs for MoveProject\n"
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,167 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+)
+
+func TestEvaluatePolicy(t *testing.T) {
+	tests := []struct {
+		name           string
+		policy         string
+		wantRestricted bool
+		wantSummary    string
+	}{
+		{name: "no rules", policy: `{}`, wantSummary: "Not set (default behaviour)"},
+		{name: "enforced", policy: `{"spec": {"rules": [{"enforce": true}]}}`, wantRestricted: true, wantSummary: "enforced"},
+		{name: "deny all", policy: `{"spec": {"rules": [{"denyAll": true}]}}`, wantRestricted: true, wantSummary: "deny all"},
+		{name: "allow all", policy: `{"spec": {"rules": [{"allowAll": true}]}}`, wantSummary: "allow all"},
+		{name: "allowed values", policy: `{"spec": {"rules": [{"values": {"allowedValues": ["in:us-locations", "in:eu-locations"]}}]}}`, wantRestricted: true, wantSummary: "allowed: in:us-locations, in:eu-locations"},
+		{name: "denied values", policy: `{"spec": {"rules": [{"values": {"deniedValues": ["EXTERNAL"]}}]}}`, wantRestricted: true, wantSummary: "denied: EXTERNAL"},
+		{name: "not enforced", policy: `{"spec": {"rules": [{"enforce": false}]}}`, wantSummary: "not enforced"},
+		{
+			name:           "conditional",
+			policy:         `{"spec": {"rules": [{"enforce": true, "condition": {"expression": "resource.matchTag('env', 'prod')"}}, {"enforce": false}]}}`,
+			wantRestricted: true,
+			wantSummary:    "enforced; not enforced (conditional)",
+		},
+	}
+
+	for _, tt := range tests {
+		effect := evaluatePolicy(decodeJSON[orgPolicy](t, tt.policy))
+		if effect.Restricted != tt.wantRestricted || effect.Summary != tt.wantSummary {
+			t.Errorf("%s: evaluatePolicy = %+v, want restricted %v with summary %q", tt.name, effect, tt.wantRestricted, tt.wantSummary)
+		}
+	}
+}
+
+// fakeOrgPolicyAPI returns a handler for the Org Policy API that sets
+// policies on the project, and leaves every other constraint unset
+func fakeOrgPolicyAPI(t *testing.T, policies map[string]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/v2/projects/test-project/policies")
+		switch {
+		case path == "":
+			var listed []string
+			for constraint, policy := range policies {
+				listed = append(listed, `{"name": "projects/test-project/policies/`+constraint+`", "spec": `+policy+`}`)
+			}
+			w.Write([]byte(`{"policies": [` + strings.Join(listed, ",") + `]}`))
+		case strings.HasSuffix(path, ":getEffectivePolicy"):
+			constraint := strings.TrimSuffix(strings.TrimPrefix(path, "/constraints/"), ":getEffectivePolicy")
+			spec, ok := policies[constraint]
+			if !ok {
+				spec = "{}"
+			}
+			w.Write([]byte(`{"name": "projects/test-project/policies/` + constraint + `", "spec": ` + spec + `}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestHandleGetOrganizationPolicyViolations(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), fakeOrgPolicyAPI(t, map[string]string{
+		"compute.vmExternalIpAccess": `{"rules": [{"denyAll": true}]}`,
+		"custom.requireLabels":       `{"rules": [{"enforce": true}]}`,
+		"compute.vmCanIpForward":     `{"rules": [{"allowAll": true}]}`,
+	}))
+
+	text := callTool(t, ctx, handleGetOrganizationPolicyViolations, map[string]interface{}{"project_id": "test-project"})
+
+	for _, s := range []string{
+		// Every watched constraint is checked, plus the custom one set on the project
+		"Checked 14 constraints (3 set directly on the project). 2 restrict operations.",
+		"| **compute.vmExternalIpAccess** | deny all | VMs, including GKE nodes, can't be given external IP addresses |",
+		"| **custom.requireLabels** | enforced | Custom or less common constraint, check its description |",
+		"- compute.vmCanIpForward: allow all",
+		"- sql.restrictPublicIp: Not set (default behaviour)",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
+
+func TestHandleGetOrganizationPolicyViolationsPermissionDenied(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+
+	result, err := handleGetOrganizationPolicyViolations(ctx, newToolRequest(map[string]interface{}{
+		"project_id": "test-project",
+	}), newTestAuthHandler(t, auth.ReadOnlyScopes))
+	if err != nil {
+		t.Fatalf("handleGetOrganizationPolicyViolations returned error: %v", err)
+	}
+
+	text, _ := resultText(result)
+	if !result.IsError || !strings.Contains(text, "roles/orgpolicy.policyViewer") {
+		t.Errorf("permission error doesn't explain the missing role: %s", text)
+	}
+}
+
+// testAncestry is a getAncestry response for a project in two nested folders
+const testAncestry = `{"ancestor": [
+	{"resourceId": {"type": "project", "id": "test-project"}},
+	{"resourceId": {"type": "folder", "id": "222"}},
+	{"resourceId": {"type": "folder", "id": "111"}},
+	{"resourceId": {"type": "organization", "id": "999"}}
+]}`
+
+func TestFormatHierarchyPath(t *testing.T) {
+	response := decodeJSON[struct {
+		Ancestor []resourceAncestor `json:"ancestor"`
+	}](t, testAncestry)
+
+	got := formatHierarchyPath(response.Ancestor, map[string]string{
+		"organizations/999": "example.com",
+		"folders/111":       "Production",
+	})
+	want := `organization "example.com" (999) → folder "Production" (111) → folder 222 → project test-project`
+	if got != want {
+		t.Errorf("formatHierarchyPath = %s, want %s", got, want)
+	}
+}
+
+func TestHandleGetResourceHierarchy(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/projects/test-project:getAncestry":
+			w.Write([]byte(testAncestry))
+		case "/v3/organizations/999":
+			w.Write([]byte(`{"name": "organizations/999", "displayName": "example.com"}`))
+		case "/v3/folders/111":
+			w.Write([]byte(`{"name": "folders/111", "displayName": "Production"}`))
+		case "/v3/folders/222":
+			// The caller can't read this folder
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	text := callTool(t, ctx, handleGetResourceHierarchy, map[string]interface{}{"project_id": "test-project"})
+
+	for _, s := range []string{
+		`organization "example.com" (999) → folder "Production" (111) → folder 222 → project test-project` + "\n",
+		"| 1 | organization | 999 | example.com |\n" +
+			"| 2 | folder | 111 | Production |\n" +
+			"| 3 | folder | 222 | - |\n" +
+			"| 4 | project | test-project | - |\n",
+		"Note: The names of 1 folders or organizations couldn't be read",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if strings.Contains(text, "has no organization") {
+		t.Errorf("result says the project has no organization:\n%s", text)
+	}
+}
@@ -0,0 +1,265 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"cloud.google.com/go/errorreporting/apiv1beta1/errorreportingpb"
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerHealthSummaryTools registers the project-wide triage tool
+func registerHealthSummaryTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register project health summary tool
+	projectHealthSummary := mcp.NewTool("project_health_summary",
+		mcp.WithDescription("Single-call incident triage for a project: concurrently checks open Monitoring incidents, error groups active in the last hour, GKE clusters not RUNNING and Cloud SQL instances not RUNNABLE, returning a red/yellow/green status per category and overall"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+	)
+
+	projectHealthSummaryHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleProjectHealthSummary(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, projectHealthSummary, projectHealthSummaryHandler)
+
+	return nil
+}
+
+// healthStatus is the traffic light status of a health category
+type healthStatus int
+
+// Statuses are ordered by severity, so the worst of several is the maximum
+const (
+	healthGreen healthStatus = iota
+	healthUnknown
+	healthYellow
+	healthRed
+)
+
+func (s healthStatus) String() string {
+	switch s {
+	case healthGreen:
+		return "GREEN"
+	case healthYellow:
+		return "YELLOW"
+	case healthRed:
+		return "RED"
+	}
+	return "UNKNOWN"
+}
+
+// healthCategory is the result of one project health check
+type healthCategory struct {
+	Name     string
+	Status   healthStatus
+	Problems int
+	Details  []string
+	Err      error
+}
+
+// maxHealthDetails caps the problems listed per category
+const maxHealthDetails = 5
+
+// addProblem records a problem, raising the category to at least status
+func (c *healthCategory) addProblem(status healthStatus, detail string) {
+	c.Problems++
+	if status > c.Status {
+		c.Status = status
+	}
+	if len(c.Details) < maxHealthDetails {
+		c.Details = append(c.Details, detail)
+	}
+}
+
+// incidentHealth evaluates open Monitoring incidents. Warning-only incidents
+// are yellow; anything more severe is red.
+func incidentHealth(incidents []monitoringIncident) healthCategory {
+	category := healthCategory{Name: "Monitoring incidents"}
+	for _, incident := range incidents {
+		if incident.State != "OPEN" {
+			continue
+		}
+		status := healthRed
+		if incident.Severity == "WARNING" {
+			status = healthYellow
+		}
+		category.addProblem(status, fmt.Sprintf("%s on %s since %s", incident.Severity, incident.ResourceDisplayName, formatTime(incident.StartTime)))
+	}
+	return category
+}
+
+// errorGroupHealth evaluates error groups with recent occurrences. Errors are
+// common in healthy services, so they only raise the category to yellow.
+func errorGroupHealth(stats []*errorreportingpb.ErrorGroupStats) healthCategory {
+	category := healthCategory{Name: "Error groups (last hour)"}
+	for _, stat := range stats {
+		message := stat.GetGroup().GetGroupId()
+		if stat.Representative != nil && stat.Representative.Message != "" {
+			message = stat.Representative.Message
+			if len(message) > 80 {
+				message = message[:80] + "..."
+			}
+		}
+		category.addProblem(healthYellow, fmt.Sprintf("%d occurrences: %s", stat.Count, message))
+	}
+	return category
+}
+
+// clusterHealth evaluates GKE clusters. Clusters in ERROR or DEGRADED are red;
+// those provisioning, reconciling or stopping are yellow.
+func clusterHealth(clusters []gkeClusterSummary) healthCategory {
+	category := healthCategory{Name: "GKE clusters"}
+	for _, cluster := range clusters {
+		switch cluster.Status {
+		case "RUNNING":
+		case "ERROR", "DEGRADED":
+			category.addProblem(healthRed, fmt.Sprintf("%s (%s) is %s", cluster.Name, cluster.Location, cluster.Status))
+		default:
+			category.addProblem(healthYellow, fmt.Sprintf("%s (%s) is %s", cluster.Name, cluster.Location, cluster.Status))
+		}
+	}
+	return category
+}
+
+// cloudSQLHealth evaluates Cloud SQL instances. FAILED and SUSPENDED instances
+// are red; other states, such as MAINTENANCE, are yellow.
+func cloudSQLHealth(instances []cloudSQLInstance) healthCategory {
+	category := healthCategory{Name: "Cloud SQL instances"}
+	for _, instance := range instances {
+		switch instance.State {
+		case "RUNNABLE":
+		case "FAILED", "SUSPENDED":
+			category.addProblem(healthRed, fmt.Sprintf("%s is %s", instance.Name, instance.State))
+		default:
+			category.addProblem(healthYellow, fmt.Sprintf("%s is %s", instance.Name, instance.State))
+		}
+	}
+	return category
+}
+
+// unknownHealth is a category whose check failed
+func unknownHealth(name string, err error) healthCategory {
+	return healthCategory{Name: name, Status: healthUnknown, Err: err}
+}
+
+// overallHealth returns the worst status across categories. A category that
+// couldn't be checked makes an otherwise green project yellow.
+func overallHealth(categories []healthCategory) healthStatus {
+	overall := healthGreen
+	for _, category := range categories {
+		status := category.Status
+		if status == healthUnknown {
+			status = healthYellow
+		}
+		if status > overall {
+			overall = status
+		}
+	}
+	return overall
+}
+
+// handleProjectHealthSummary handles the project_health_summary tool request
+func handleProjectHealthSummary(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	// Run every check concurrently; a failed check only affects its own category
+	checks := []func() healthCategory{
+		func() healthCategory {
+			incidents, err := fetchIncidents(ctx, client, projectID)
+			if err != nil {
+				return unknownHealth("Monitoring incidents", err)
+			}
+			return incidentHealth(incidents)
+		},
+		func() healthCategory {
+			stats, err := fetchErrorGroupStats(ctx, authHandler, projectID, errorreportingpb.QueryTimeRange_PERIOD_1_HOUR, 100)
+			if err != nil {
+				return unknownHealth("Error groups (last hour)", err)
+			}
+			return errorGroupHealth(stats)
+		},
+		func() healthCategory {
+			clusters, err := fetchClusters(ctx, client, projectID, "")
+			if err != nil {
+				return unknownHealth("GKE clusters", err)
+			}
+			return clusterHealth(clusters)
+		},
+		func() healthCategory {
+			instances, err := fetchCloudSQLInstances(ctx, client, projectID)
+			if err != nil {
+				return unknownHealth("Cloud SQL instances", err)
+			}
+			return cloudSQLHealth(instances)
+		},
+	}
+
+	categories := make([]healthCategory, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check func() healthCategory) {
+			defer wg.Done()
+			categories[i] = check()
+		}(i, check)
+	}
+	wg.Wait()
+
+	overall := overallHealth(categories)
+
+	// Format the results
+	result := fmt.Sprintf("# Project Health Summary for %s\n\n", projectID)
+	result += fmt.Sprintf("**Overall: %s**\n\n", overall)
+
+	result += "| Category | Status | Problems |\n"
+	result += "| -------- | ------ | -------- |\n"
+	for _, category := range categories {
+		problems := fmt.Sprintf("%d", category.Problems)
+		if category.Err != nil {
+			problems = "-"
+		}
+		result += fmt.Sprintf("| %s | %s | %s |\n", category.Name, category.Status, problems)
+	}
+
+	for _, category := range categories {
+		if category.Err == nil && len(category.Details) == 0 {
+			continue
+		}
+		result += fmt.Sprintf("\n## %s\n\n", category.Name)
+		if category.Err != nil {
+			result += fmt.Sprintf("Could not be checked: %v\n", category.Err)
+			continue
+		}
+		for _, detail := range category.Details {
+			result += fmt.Sprintf("- %s\n", detail)
+		}
+		if category.Problems > len(category.Details) {
+			result += fmt.Sprintf("- ...and %d more\n", category.Problems-len(category.Details))
+		}
+	}
+
+	if overall != healthGreen {
+		result += "\n## Recommended Actions\n\n"
+		result += "1. Start with RED categories: list_alerts for incidents, get_cluster_info for clusters and the Cloud SQL tools for instances\n"
+		result += "2. Use list_active_issues to see the error groups behind a YELLOW error category\n"
+		result += "3. UNKNOWN categories could not be checked, usually because the API is disabled or permission is missing; check them manually if relevant\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
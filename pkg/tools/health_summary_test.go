@@ -0,0 +1,75 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestOverallHealth(t *testing.T) {
+	tests := []struct {
+		statuses []healthStatus
+		want     healthStatus
+	}{
+		{statuses: []healthStatus{healthGreen, healthGreen}, want: healthGreen},
+		{statuses: []healthStatus{healthGreen, healthUnknown}, want: healthYellow},
+		{statuses: []healthStatus{healthUnknown, healthRed, healthYellow}, want: healthRed},
+	}
+
+	for _, tt := range tests {
+		var categories []healthCategory
+		for _, status := range tt.statuses {
+			categories = append(categories, healthCategory{Status: status})
+		}
+		if got := overallHealth(categories); got != tt.want {
+			t.Errorf("overallHealth(%v) = %s, want %s", tt.statuses, got, tt.want)
+		}
+	}
+}
+
+func TestHandleProjectHealthSummary(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Host + r.URL.Path {
+		case "monitoring.googleapis.com/v3/projects/test-project/incidents":
+			w.Write([]byte(`{"incidents": [
+				{"state": "OPEN", "severity": "WARNING", "resourceDisplayName": "web", "startTime": "2026-10-17T01:00:00Z"},
+				{"state": "CLOSED", "severity": "CRITICAL", "resourceDisplayName": "db", "startTime": "2026-10-16T01:00:00Z"}
+			]}`))
+		case "container.googleapis.com/v1/projects/test-project/locations/-/clusters":
+			w.Write([]byte(`{"clusters": [
+				{"name": "prod", "location": "us-central1", "status": "RUNNING"},
+				{"name": "staging", "location": "us-east1", "status": "ERROR"}
+			]}`))
+		case "sqladmin.googleapis.com/v1/projects/test-project/instances":
+			w.Write([]byte(`{"items": [{"name": "orders", "state": "RUNNABLE"}]}`))
+		default:
+			// Error Reporting is disabled in the project
+			writeJSON(w, http.StatusForbidden, map[string]interface{}{"error": map[string]interface{}{
+				"code": 403, "message": "Error Reporting API has not been used in project test-project", "status": "PERMISSION_DENIED",
+			}})
+		}
+	}))
+
+	text := callTool(t, ctx, handleProjectHealthSummary, map[string]interface{}{"project_id": "test-project"})
+
+	// A check that fails is reported as unknown without hiding the others
+	for _, s := range []string{
+		"**Overall: RED**",
+		"| Monitoring incidents | YELLOW | 1 |\n" +
+			"| Error groups (last hour) | UNKNOWN | - |\n" +
+			"| GKE clusters | RED | 1 |\n" +
+			"| Cloud SQL instances | GREEN | 0 |\n",
+		"## Monitoring incidents\n\n- WARNING on web since 2026-10-17 01:00:00\n",
+		"## Error groups (last hour)\n\nCould not be checked: ",
+		"## GKE clusters\n\n- staging (us-east1) is ERROR\n",
+		"## Recommended Actions",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if strings.Contains(text, "## Cloud SQL instances") {
+		t.Errorf("result details a healthy category:\n%s", text)
+	}
+}
@@ -0,0 +1,190 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"golang.org/x/oauth2"
+)
+
+// roundTripFunc is an http.RoundTripper implemented by a function
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newTestAuthHandler returns an OAuth handler holding scopes that uses a
+// cached, unexpired token, so no authorization flow or token refresh is
+// attempted
+func newTestAuthHandler(t *testing.T, scopes []string) *auth.OAuthHandler {
+	t.Helper()
+
+	cacheFile := filepath.Join(t.TempDir(), "token.json")
+	data, err := json.Marshal(map[string]interface{}{
+		"scopes": scopes,
+		"token": &oauth2.Token{
+			AccessToken: "test-token",
+			TokenType:   "Bearer",
+			Expiry:      time.Now().Add(time.Hour),
+		},
+	})
+	if err != nil {
+		t.Fatalf("encoding token cache: %v", err)
+	}
+	if err := os.WriteFile(cacheFile, data, 0o600); err != nil {
+		t.Fatalf("writing token cache: %v", err)
+	}
+
+	t.Setenv("GOOGLE_CLIENT_ID", "test-client")
+	t.Setenv("GOOGLE_CLIENT_SECRET", "test-secret")
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+	t.Setenv("GOOGLE_TOKEN_CACHE", cacheFile)
+
+	authHandler, err := auth.NewOAuthHandlerWithScopes(scopes)
+	if err != nil {
+		t.Fatalf("creating auth handler: %v", err)
+	}
+	return authHandler
+}
+
+// withGCPTransport returns a context whose authenticated clients send Google
+// API requests to transport instead of the network
+func withGCPTransport(ctx context.Context, transport http.RoundTripper) context.Context {
+	return context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: transport})
+}
+
+// withFakeGCP returns a context whose authenticated clients send Google API
+// requests to handler instead of the network
+func withFakeGCP(ctx context.Context, handler http.Handler) context.Context {
+	return withGCPTransport(ctx, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Result(), nil
+	}))
+}
+
+// fakeLogging returns a handler for Logging API entries:list requests. Each
+// is answered with the JSON array of entries that respond returns for the
+// request's filter.
+func fakeLogging(t *testing.T, respond func(filter string) string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host+r.URL.Path != "logging.googleapis.com/v2/entries:list" {
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body struct {
+			Filter string `json:"filter"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"entries": ` + respond(body.Filter) + `}`))
+	})
+}
+
+// callTool calls a tool handler with a read-only auth handler and returns the
+// text of its result, failing the test if the tool returns an error
+func callTool(t *testing.T, ctx context.Context, handler func(context.Context, mcp.CallToolRequest, *auth.OAuthHandler) (*mcp.CallToolResult, error), args map[string]interface{}) string {
+	t.Helper()
+
+	result, err := handler(ctx, newToolRequest(args), newTestAuthHandler(t, auth.ReadOnlyScopes))
+	if err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	text, _ := resultText(result)
+	if result.IsError {
+		t.Fatalf("handler returned tool error: %s", text)
+	}
+	return text
+}
+
+// newFakeCluster starts a TLS server standing in for a GKE cluster's API
+// server and caches cluster metadata pointing at it, so tools connect to it
+// without calling the Container API. It returns the cluster's name; the
+// cluster is in project test-project and location us-central1.
+func newFakeCluster(t *testing.T, handler http.Handler) string {
+	t.Helper()
+
+	srv := httptest.NewTLSServer(handler)
+	t.Cleanup(srv.Close)
+
+	name := strings.ToLower(strings.ReplaceAll(t.Name(), "/", "-"))
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	var cluster gkeCluster
+	cluster.Name = name
+	cluster.Location = "us-central1"
+	cluster.Endpoint = strings.TrimPrefix(srv.URL, "https://")
+	cluster.MasterAuth.ClusterCaCertificate = base64.StdEncoding.EncodeToString(caPEM)
+
+	cacheKey := clusterCacheKey("test-project", "us-central1", name)
+	clusterCache.Set(cacheKey, cluster)
+	t.Cleanup(func() { clusterCache.Invalidate(cacheKey) })
+
+	return name
+}
+
+// newToolRequest returns a tool call request with the given arguments
+func newToolRequest(args map[string]interface{}) mcp.CallToolRequest {
+	var request mcp.CallToolRequest
+	request.Params.Arguments = args
+	return request
+}
+
+// decodeJSON decodes a JSON test fixture into a T
+func decodeJSON[T any](t *testing.T, data string) T {
+	t.Helper()
+
+	var v T
+	if err := json.Unmarshal([]byte(data), &v); err != nil {
+		t.Fatalf("decoding fixture: %v", err)
+	}
+	return v
+}
+
+// writeJSON writes v as a JSON response with the given status
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// useEmptyToolRegistry starts the test with no registered tools, and restores
+// the registry when it ends
+func useEmptyToolRegistry(t *testing.T) {
+	toolRegistry.Lock()
+	saved := toolRegistry.tools
+	toolRegistry.tools = make(map[string]mcp.Tool)
+	toolRegistry.Unlock()
+
+	t.Cleanup(func() {
+		toolRegistry.Lock()
+		toolRegistry.tools = saved
+		toolRegistry.Unlock()
+	})
+}
+
+// resultText returns the text of a single-text tool result
+func resultText(result *mcp.CallToolResult) (string, bool) {
+	if len(result.Content) != 1 {
+		return "", false
+	}
+	text, ok := result.Content[0].(mcp.TextContent)
+	if !ok {
+		return "", false
+	}
+	return text.Text, true
+}
@@ -0,0 +1,587 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// maxRetryAttempts is the total number of attempts made for a retryable request
+	maxRetryAttempts = 3
+
+	// retryBaseDelay is the backoff before the first retry, doubled on each attempt
+	retryBaseDelay = 500 * time.Millisecond
+)
+
+// requestTimeout bounds each attempt at a request, including reading the
+// response body, so a hung endpoint can't block a tool call forever
+var requestTimeout = envDuration("OPERABLE_HTTP_TIMEOUT", 30*time.Second)
+
+// requestTimeoutKey is the context key used to override requestTimeout
+type requestTimeoutKey struct{}
+
+// withRequestTimeout overrides the per-attempt timeout for requests made with
+// ctx, for calls that are expected to take longer than the default
+func withRequestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, requestTimeoutKey{}, timeout)
+}
+
+// timeoutFor returns the per-attempt timeout for a request
+func timeoutFor(req *http.Request) time.Duration {
+	if timeout, ok := req.Context().Value(requestTimeoutKey{}).(time.Duration); ok {
+		return timeout
+	}
+	return requestTimeout
+}
+
+// cancelOnClose releases a request's timeout once its response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close implements io.Closer
+func (b cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// requestTimeoutError is returned when an attempt gets no response within
+// the per-attempt timeout
+type requestTimeoutError struct {
+	host    string
+	timeout time.Duration
+}
+
+func (e *requestTimeoutError) Error() string {
+	return fmt.Sprintf("no response from %s within %s", e.host, e.timeout)
+}
+
+// sendOnce sends a single attempt at a request under the per-attempt timeout.
+// A timeout is reported as its own error rather than a context error, so it
+// isn't mistaken for the tool call being cancelled.
+func sendOnce(client *http.Client, req *http.Request) (*http.Response, error) {
+	timeout := timeoutFor(req)
+	if timeout <= 0 {
+		return client.Do(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		if ctx.Err() == context.DeadlineExceeded && req.Context().Err() == nil {
+			return nil, &requestTimeoutError{host: req.URL.Host, timeout: timeout}
+		}
+		return nil, err
+	}
+
+	resp.Body = cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// idempotentKey is the context key used to mark a request as safe to retry
+type idempotentKey struct{}
+
+// markIdempotent marks a request as safe to retry. GET requests are always
+// retried, so this is only needed for read-only endpoints that use POST, such
+// as the Logging entries:list and Monitoring timeSeries:query calls.
+func markIdempotent(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), idempotentKey{}, true))
+}
+
+// isIdempotent reports whether a request can be sent more than once without
+// side effects
+func isIdempotent(req *http.Request) bool {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return true
+	}
+	marked, _ := req.Context().Value(idempotentKey{}).(bool)
+	return marked
+}
+
+// expectedStatusKey is the context key used to mark a status code as an
+// expected answer rather than a failure
+type expectedStatusKey struct{}
+
+// withExpectedStatus marks a status code as an expected answer to requests
+// made with ctx, such as the 429 the eviction API returns when a
+// PodDisruptionBudget blocks an eviction. The API answered normally, so such
+// responses don't count against its circuit breaker or in request outcomes.
+func withExpectedStatus(ctx context.Context, code int) context.Context {
+	return context.WithValue(ctx, expectedStatusKey{}, code)
+}
+
+// isExpectedStatus reports whether a response has the status its request was
+// marked as expecting
+func isExpectedStatus(req *http.Request, resp *http.Response) bool {
+	if resp == nil {
+		return false
+	}
+	code, ok := req.Context().Value(expectedStatusKey{}).(int)
+	return ok && resp.StatusCode == code
+}
+
+// isRetryableStatus reports whether a response status indicates a transient failure
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryBudgetBurst is how many seconds' worth of retries the budget can
+// accumulate, allowing short bursts above the sustained rate
+const retryBudgetBurst = 10
+
+// tokenBucket is a rate limiter that refills at rate tokens per second up to
+// capacity
+type tokenBucket struct {
+	rate     float64
+	capacity float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket returns a full token bucket
+func newTokenBucket(rate, capacity float64) *tokenBucket {
+	return &tokenBucket{rate: rate, capacity: capacity, tokens: capacity}
+}
+
+// take removes a token if one is available, reporting whether it did
+func (b *tokenBucket) take(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// retryBudget caps retries across all concurrent calls, so that during a broad
+// outage retries don't multiply the load on already struggling APIs. First
+// attempts are never limited. It is nil when the budget is disabled.
+var retryBudget = newRetryBudget(envFloat("OPERABLE_RETRY_BUDGET_PER_SECOND", 5))
+
+// newRetryBudget returns a retry budget allowing retriesPerSecond retries,
+// or nil if retriesPerSecond is zero or negative
+func newRetryBudget(retriesPerSecond float64) *tokenBucket {
+	if retriesPerSecond <= 0 {
+		return nil
+	}
+	capacity := retriesPerSecond * retryBudgetBurst
+	if capacity < 1 {
+		capacity = 1
+	}
+	return newTokenBucket(retriesPerSecond, capacity)
+}
+
+// circuitState is the state of a circuit breaker
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker fails fast for an upstream API after repeated failures, so
+// that an outage doesn't make every tool call wait for the full timeout.
+// After threshold consecutive failures it opens for the cooldown, then lets a
+// single probe request through to check for recovery.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    circuitState
+	failures int
+	openedAt time.Time
+}
+
+// allow reports whether a request may be sent, moving an open breaker to
+// half-open once the cooldown has passed
+func (b *circuitBreaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if now.Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = circuitHalfOpen
+		return true
+	case circuitHalfOpen:
+		// A probe is already in flight
+		return false
+	}
+	return true
+}
+
+// record updates the breaker with the outcome of a request
+func (b *circuitBreaker) record(success bool, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if success {
+		b.state = circuitClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == circuitHalfOpen || b.failures >= b.threshold {
+		b.state = circuitOpen
+		b.openedAt = now
+	}
+}
+
+// abort releases a probe that ended without an answer, such as when the
+// caller's context was cancelled, so the next request can probe instead
+func (b *circuitBreaker) abort() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == circuitHalfOpen {
+		b.state = circuitOpen
+	}
+}
+
+var (
+	// circuitThreshold is the number of consecutive failures that opens a breaker
+	circuitThreshold = envInt("OPERABLE_CIRCUIT_BREAKER_THRESHOLD", 5)
+
+	// circuitCooldown is how long a breaker stays open before probing
+	circuitCooldown = envDuration("OPERABLE_CIRCUIT_BREAKER_COOLDOWN", 30*time.Second)
+
+	circuitBreakersMu sync.Mutex
+	circuitBreakers   = map[string]*circuitBreaker{}
+)
+
+// breakerFor returns the circuit breaker for the upstream API a request is
+// sent to, keyed by scheme and host. It returns nil when breakers are disabled.
+func breakerFor(req *http.Request) *circuitBreaker {
+	if circuitThreshold <= 0 {
+		return nil
+	}
+
+	key := req.URL.Scheme + "://" + req.URL.Host
+
+	circuitBreakersMu.Lock()
+	defer circuitBreakersMu.Unlock()
+
+	b, ok := circuitBreakers[key]
+	if !ok {
+		b = &circuitBreaker{threshold: circuitThreshold, cooldown: circuitCooldown}
+		circuitBreakers[key] = b
+	}
+	return b
+}
+
+// isUpstreamFailure reports whether a request outcome counts against the
+// upstream API's circuit breaker. Client errors such as 404 or 403 mean the
+// API is up.
+func isUpstreamFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+}
+
+// doWithRetry sends the request, retrying transient failures with exponential
+// backoff and jitter. Only idempotent requests are retried; mutating requests
+// are sent exactly once so a side effect is never executed twice. Retries draw
+// from the shared retry budget, and once it is spent failures are returned
+// without retrying. Requests to an upstream API whose circuit breaker is open
+// fail immediately. A response with the status the request was marked as
+// expecting is treated as a success.
+func doWithRetry(client *http.Client, req *http.Request) (*http.Response, error) {
+	breaker := breakerFor(req)
+	if breaker == nil {
+		resp, err := sendWithRetry(client, req)
+		if !isExpectedStatus(req, resp) {
+			recordRequestID(req, resp)
+			recordOutcome(req, resp, err)
+		}
+		return resp, err
+	}
+
+	if !breaker.allow(time.Now()) {
+		addOutcome(req.URL.Host, outcomeCircuitOpen)
+		return nil, fmt.Errorf("upstream API %s appears unavailable (circuit open), not sending request", req.URL.Host)
+	}
+
+	resp, err := sendWithRetry(client, req)
+	if isExpectedStatus(req, resp) {
+		breaker.record(true, time.Now())
+		return resp, err
+	}
+	recordRequestID(req, resp)
+	recordOutcome(req, resp, err)
+	if err != nil && req.Context().Err() != nil {
+		// A cancelled request says nothing about the API's health
+		breaker.abort()
+		return resp, err
+	}
+	breaker.record(!isUpstreamFailure(resp, err), time.Now())
+	return resp, err
+}
+
+// sendWithRetry implements the retry loop for doWithRetry. When every attempt
+// fails, the number of attempts is added to the error, or to the status of
+// the last response, so callers' messages show the request was retried.
+func sendWithRetry(client *http.Client, req *http.Request) (*http.Response, error) {
+	if !isIdempotent(req) {
+		return sendOnce(client, req)
+	}
+
+	ctx := req.Context()
+	var resp *http.Response
+	var err error
+
+	for attempt := 1; ; attempt++ {
+		resp, err = sendOnce(client, req)
+		if err == nil && !isRetryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+		if attempt >= maxRetryAttempts || ctx.Err() != nil {
+			return withAttempts(resp, err, attempt)
+		}
+
+		// Rewind the body for the next attempt, giving up if that isn't possible
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return withAttempts(resp, err, attempt)
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return withAttempts(resp, err, attempt)
+			}
+			req.Body = body
+		}
+
+		// Fail fast rather than add to the load when retries are already high
+		if retryBudget != nil && !retryBudget.take(time.Now()) {
+			return withAttempts(resp, err, attempt)
+		}
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		delay := retryBaseDelay << (attempt - 1)
+		delay += time.Duration(rand.Int63n(int64(delay) / 2))
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("request cancelled while waiting to retry after %d attempts: %w", attempt, ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+// withAttempts notes the number of attempts made on a failed request's error
+// or response status. A single attempt is left as is.
+func withAttempts(resp *http.Response, err error, attempts int) (*http.Response, error) {
+	if attempts <= 1 {
+		return resp, err
+	}
+	if err != nil {
+		return resp, fmt.Errorf("%w (after %d attempts)", err, attempts)
+	}
+	resp.Status = fmt.Sprintf("%s (after %d attempts)", resp.Status, attempts)
+	return resp, nil
+}
+
+// requestIDHeaders are the response headers Google APIs use to identify a
+// request, in order of preference
+var requestIDHeaders = []string{"X-Goog-Request-Id", "X-GUploader-UploadID"}
+
+// maxRecordedRequestIDs is the number of failed requests remembered for support escalation
+const maxRecordedRequestIDs = 50
+
+// failedRequest is a failed upstream call recorded for support escalation
+type failedRequest struct {
+	Time      time.Time
+	Method    string
+	URL       string
+	Status    string
+	RequestID string
+}
+
+var (
+	failedRequestsMu sync.Mutex
+	failedRequests   []failedRequest
+	failedRequestsAt int
+)
+
+// recordRequestID captures the request ID of a failed response. The ID is
+// appended to resp.Status, which callers already include in their error
+// messages, and kept in a ring buffer for the last_gcp_request_ids tool.
+func recordRequestID(req *http.Request, resp *http.Response) {
+	if resp == nil || resp.StatusCode < 400 {
+		return
+	}
+
+	requestID := ""
+	for _, header := range requestIDHeaders {
+		if requestID = resp.Header.Get(header); requestID != "" {
+			break
+		}
+	}
+	if requestID == "" {
+		return
+	}
+
+	record := failedRequest{
+		Time:      time.Now(),
+		Method:    req.Method,
+		URL:       req.URL.Scheme + "://" + req.URL.Host + req.URL.Path,
+		Status:    resp.Status,
+		RequestID: requestID,
+	}
+
+	resp.Status = fmt.Sprintf("%s (request ID: %s)", resp.Status, requestID)
+
+	failedRequestsMu.Lock()
+	defer failedRequestsMu.Unlock()
+
+	if len(failedRequests) < maxRecordedRequestIDs {
+		failedRequests = append(failedRequests, record)
+		return
+	}
+	failedRequests[failedRequestsAt] = record
+	failedRequestsAt = (failedRequestsAt + 1) % maxRecordedRequestIDs
+}
+
+// recentFailedRequests returns the recorded failed requests, newest first
+func recentFailedRequests() []failedRequest {
+	failedRequestsMu.Lock()
+	defer failedRequestsMu.Unlock()
+
+	records := make([]failedRequest, 0, len(failedRequests))
+	for i := len(failedRequests) - 1; i >= 0; i-- {
+		records = append(records, failedRequests[(failedRequestsAt+i)%len(failedRequests)])
+	}
+	return records
+}
+
+// maxRecordedOutcomes is the number of request outcomes remembered for the
+// get_api_error_rate_by_gcp_service tool
+const maxRecordedOutcomes = 5000
+
+// Error classes of failed request outcomes
+const (
+	outcomeRateLimited  = "429 rate limited"
+	outcomeServerError  = "5xx server error"
+	outcomePermission   = "401/403 permission denied"
+	outcomeNotFound     = "404 not found"
+	outcomeClientError  = "other 4xx client error"
+	outcomeTimeout      = "timeout"
+	outcomeNetworkError = "network error"
+	outcomeCircuitOpen  = "circuit open"
+)
+
+// requestOutcome is the outcome of a call to an upstream API, after any
+// retries
+type requestOutcome struct {
+	Time time.Time
+	Host string
+	// Class is empty for a success, or the kind of failure
+	Class string
+}
+
+var (
+	requestOutcomesMu sync.Mutex
+	requestOutcomes   []requestOutcome
+	requestOutcomesAt int
+)
+
+// outcomeClass classifies the outcome of a request, returning an empty
+// string for a success
+func outcomeClass(resp *http.Response, err error) string {
+	if err != nil {
+		var timeoutErr *requestTimeoutError
+		if errors.As(err, &timeoutErr) {
+			return outcomeTimeout
+		}
+		return outcomeNetworkError
+	}
+
+	switch code := resp.StatusCode; {
+	case code < 400:
+		return ""
+	case code == http.StatusTooManyRequests:
+		return outcomeRateLimited
+	case code == http.StatusUnauthorized, code == http.StatusForbidden:
+		return outcomePermission
+	case code == http.StatusNotFound:
+		return outcomeNotFound
+	case code >= 500:
+		return outcomeServerError
+	default:
+		return outcomeClientError
+	}
+}
+
+// recordOutcome records the outcome of a request for the
+// get_api_error_rate_by_gcp_service tool. Requests cancelled by the caller
+// say nothing about the API, so they aren't recorded.
+func recordOutcome(req *http.Request, resp *http.Response, err error) {
+	if err != nil && req.Context().Err() != nil {
+		return
+	}
+	addOutcome(req.URL.Host, outcomeClass(resp, err))
+}
+
+// addOutcome appends an outcome to the ring buffer
+func addOutcome(host, class string) {
+	outcome := requestOutcome{Time: time.Now(), Host: host, Class: class}
+
+	requestOutcomesMu.Lock()
+	defer requestOutcomesMu.Unlock()
+
+	if len(requestOutcomes) < maxRecordedOutcomes {
+		requestOutcomes = append(requestOutcomes, outcome)
+		return
+	}
+	requestOutcomes[requestOutcomesAt] = outcome
+	requestOutcomesAt = (requestOutcomesAt + 1) % maxRecordedOutcomes
+}
+
+// recentOutcomes returns the recorded outcomes at or after since, oldest
+// first
+func recentOutcomes(since time.Time) []requestOutcome {
+	requestOutcomesMu.Lock()
+	defer requestOutcomesMu.Unlock()
+
+	outcomes := make([]requestOutcome, 0, len(requestOutcomes))
+	for i := 0; i < len(requestOutcomes); i++ {
+		outcome := requestOutcomes[(requestOutcomesAt+i)%len(requestOutcomes)]
+		if !outcome.Time.Before(since) {
+			outcomes = append(outcomes, outcome)
+		}
+	}
+	return outcomes
+}
@@ -0,0 +1,363 @@
+package tools
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flakyServer is a test server that fails the first failures requests with
+// status, then succeeds. It records the body of every request.
+type flakyServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	failures int
+	status   int
+	bodies   []string
+}
+
+// newFlakyServer starts a flakyServer
+func newFlakyServer(t *testing.T, failures, status int) *flakyServer {
+	s := &flakyServer{failures: failures, status: status}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.bodies = append(s.bodies, string(body))
+		if len(s.bodies) <= s.failures {
+			w.WriteHeader(s.status)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// requests returns the number of requests the server received
+func (s *flakyServer) requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.bodies)
+}
+
+func TestDoWithRetryIdempotency(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		idempotent   bool
+		wantRequests int
+		wantStatus   int
+	}{
+		{name: "GET is retried", method: "GET", wantRequests: 2, wantStatus: http.StatusOK},
+		{name: "query POST is retried", method: "POST", idempotent: true, wantRequests: 2, wantStatus: http.StatusOK},
+		{name: "mutating POST isn't retried", method: "POST", wantRequests: 1, wantStatus: http.StatusServiceUnavailable},
+		{name: "PATCH isn't retried", method: "PATCH", wantRequests: 1, wantStatus: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newFlakyServer(t, 1, http.StatusServiceUnavailable)
+
+			var body io.Reader
+			if tt.method != "GET" {
+				body = strings.NewReader(`{"query": "x"}`)
+			}
+			req, err := http.NewRequest(tt.method, srv.URL, body)
+			if err != nil {
+				t.Fatalf("creating request: %v", err)
+			}
+			if tt.idempotent {
+				req = markIdempotent(req)
+			}
+
+			resp, err := doWithRetry(srv.Client(), req)
+			if err != nil {
+				t.Fatalf("doWithRetry: %v", err)
+			}
+			resp.Body.Close()
+
+			if got := srv.requests(); got != tt.wantRequests {
+				t.Errorf("server received %d requests, want %d", got, tt.wantRequests)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			// A retried request must resend its body
+			for i, got := range srv.bodies {
+				if body != nil && got != `{"query": "x"}` {
+					t.Errorf("attempt %d sent body %q", i+1, got)
+				}
+			}
+		})
+	}
+}
+
+func TestIsIdempotent(t *testing.T) {
+	tests := []struct {
+		method string
+		marked bool
+		want   bool
+	}{
+		{method: "GET", want: true},
+		{method: "HEAD", want: true},
+		{method: "POST", want: false},
+		{method: "POST", marked: true, want: true},
+		{method: "PUT", want: false},
+		{method: "DELETE", want: false},
+	}
+
+	for _, tt := range tests {
+		req, _ := http.NewRequest(tt.method, "https://example.com", nil)
+		if tt.marked {
+			req = markIdempotent(req)
+		}
+		if got := isIdempotent(req); got != tt.want {
+			t.Errorf("isIdempotent(%s, marked=%t) = %t, want %t", tt.method, tt.marked, got, tt.want)
+		}
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	b := &circuitBreaker{threshold: 3, cooldown: 30 * time.Second}
+
+	// Failures below the threshold leave it closed, and a success resets
+	// the count
+	b.record(false, now)
+	b.record(false, now)
+	b.record(true, now)
+	b.record(false, now)
+	b.record(false, now)
+	if !b.allow(now) {
+		t.Fatal("breaker opened below the threshold")
+	}
+
+	b.record(false, now)
+	if b.allow(now.Add(29 * time.Second)) {
+		t.Error("breaker allowed a request while open")
+	}
+
+	// After the cooldown a single probe is let through
+	probeAt := now.Add(31 * time.Second)
+	if !b.allow(probeAt) {
+		t.Fatal("breaker didn't allow a probe after the cooldown")
+	}
+	if b.allow(probeAt) {
+		t.Error("breaker allowed a second request while the probe is in flight")
+	}
+
+	// A failed probe reopens it for another cooldown
+	b.record(false, probeAt)
+	if b.allow(probeAt.Add(time.Second)) {
+		t.Error("breaker allowed a request after a failed probe")
+	}
+
+	// A successful probe closes it
+	if !b.allow(probeAt.Add(31 * time.Second)) {
+		t.Fatal("breaker didn't allow a second probe")
+	}
+	b.record(true, probeAt.Add(31*time.Second))
+	if !b.allow(probeAt.Add(31 * time.Second)) {
+		t.Error("breaker didn't close after a successful probe")
+	}
+}
+
+func TestCircuitBreakerAbortedProbe(t *testing.T) {
+	now := time.Now()
+	b := &circuitBreaker{threshold: 1, cooldown: time.Second}
+
+	b.record(false, now)
+	if !b.allow(now.Add(2 * time.Second)) {
+		t.Fatal("breaker didn't allow a probe after the cooldown")
+	}
+
+	// A cancelled probe lets the next request probe instead
+	b.abort()
+	if !b.allow(now.Add(2 * time.Second)) {
+		t.Error("breaker didn't allow a probe after the previous one was aborted")
+	}
+}
+
+func TestDoWithRetryCircuitOpen(t *testing.T) {
+	if circuitThreshold <= 0 {
+		t.Skip("circuit breakers are disabled")
+	}
+
+	// 500 isn't retried, so each call is a single failed attempt
+	srv := newFlakyServer(t, 100, http.StatusInternalServerError)
+
+	for i := 0; i < circuitThreshold; i++ {
+		req, _ := http.NewRequest("GET", srv.URL, nil)
+		resp, err := doWithRetry(srv.Client(), req)
+		if err != nil {
+			t.Fatalf("call %d: %v", i+1, err)
+		}
+		resp.Body.Close()
+	}
+	if got := srv.requests(); got != circuitThreshold {
+		t.Fatalf("server received %d requests, want %d", got, circuitThreshold)
+	}
+
+	// The breaker is now open, so calls fail without reaching the server
+	req, _ := http.NewRequest("GET", srv.URL, nil)
+	_, err := doWithRetry(srv.Client(), req)
+	if err == nil || !strings.Contains(err.Error(), "circuit open") {
+		t.Errorf("error = %v, want a circuit open error", err)
+	}
+	if got := srv.requests(); got != circuitThreshold {
+		t.Errorf("server received %d requests with the circuit open, want %d", got, circuitThreshold)
+	}
+}
+
+func TestIsUpstreamFailure(t *testing.T) {
+	tests := []struct {
+		status int
+		want   bool
+	}{
+		{status: http.StatusOK, want: false},
+		{status: http.StatusNotFound, want: false},
+		{status: http.StatusForbidden, want: false},
+		{status: http.StatusTooManyRequests, want: true},
+		{status: http.StatusInternalServerError, want: true},
+		{status: http.StatusServiceUnavailable, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := isUpstreamFailure(&http.Response{StatusCode: tt.status}, nil); got != tt.want {
+			t.Errorf("isUpstreamFailure(%d) = %t, want %t", tt.status, got, tt.want)
+		}
+	}
+	if !isUpstreamFailure(nil, io.ErrUnexpectedEOF) {
+		t.Error("a network error isn't an upstream failure")
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	b := newTokenBucket(1, 2)
+
+	if !b.take(now) || !b.take(now) {
+		t.Fatal("full bucket refused a token")
+	}
+	if b.take(now) {
+		t.Error("empty bucket gave a token")
+	}
+
+	// One token refills per second, up to the capacity
+	if !b.take(now.Add(time.Second)) {
+		t.Error("bucket didn't refill after a second")
+	}
+	if !b.take(now.Add(time.Hour)) || !b.take(now.Add(time.Hour)) || b.take(now.Add(time.Hour)) {
+		t.Error("bucket didn't refill to exactly its capacity")
+	}
+}
+
+func TestNewRetryBudget(t *testing.T) {
+	if newRetryBudget(0) != nil || newRetryBudget(-1) != nil {
+		t.Error("a zero or negative rate didn't disable the budget")
+	}
+	if b := newRetryBudget(0.01); b == nil || b.capacity != 1 {
+		t.Errorf("a low rate's capacity isn't raised to 1: %+v", b)
+	}
+}
+
+func TestDoWithRetryBudgetExhausted(t *testing.T) {
+	// Allow a single retry, refilling too slowly to matter
+	saved := retryBudget
+	retryBudget = newTokenBucket(0.0001, 1)
+	t.Cleanup(func() { retryBudget = saved })
+
+	srv := newFlakyServer(t, 100, http.StatusServiceUnavailable)
+
+	// The first call spends the budget on its retry
+	req, _ := http.NewRequest("GET", srv.URL, nil)
+	resp, err := doWithRetry(srv.Client(), req)
+	if err != nil {
+		t.Fatalf("doWithRetry: %v", err)
+	}
+	resp.Body.Close()
+	if got := srv.requests(); got != 2 {
+		t.Fatalf("server received %d requests, want 2", got)
+	}
+
+	// Once it is spent, the next call fails without retrying
+	req, _ = http.NewRequest("GET", srv.URL, nil)
+	resp, err = doWithRetry(srv.Client(), req)
+	if err != nil {
+		t.Fatalf("doWithRetry: %v", err)
+	}
+	resp.Body.Close()
+	if got := srv.requests(); got != 3 {
+		t.Errorf("server received %d requests, want 3", got)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", resp.StatusCode)
+	}
+}
+
+func TestRequestIDInError(t *testing.T) {
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		header := http.Header{}
+		header.Set("X-Goog-Request-Id", "req-1708")
+		return &http.Response{
+			StatusCode: http.StatusForbidden,
+			Status:     "403 Forbidden",
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader("{}")),
+		}, nil
+	})}
+
+	_, err := fetchNodePools(context.Background(), client, "test-project", "us-central1", "request-id-cluster")
+	if err == nil || !strings.Contains(err.Error(), "(request ID: req-1708)") {
+		t.Errorf("error = %v, want it to include the request ID", err)
+	}
+}
+
+func TestRecordRequestIDSkipsSuccesses(t *testing.T) {
+	req, _ := http.NewRequest("GET", "https://example.com/ok", nil)
+	header := http.Header{}
+	header.Set("X-Goog-Request-Id", "req-ok")
+	resp := &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Header: header}
+
+	recordRequestID(req, resp)
+
+	if resp.Status != "200 OK" {
+		t.Errorf("status of a success changed to %q", resp.Status)
+	}
+	for _, record := range recentFailedRequests() {
+		if record.RequestID == "req-ok" {
+			t.Error("a successful request was recorded")
+		}
+	}
+}
+
+func TestDoWithRetryRecordsOutcome(t *testing.T) {
+	start := time.Now()
+	srv := newFlakyServer(t, 1, http.StatusServiceUnavailable)
+
+	req, _ := http.NewRequest("GET", srv.URL, nil)
+	resp, err := doWithRetry(srv.Client(), req)
+	if err != nil {
+		t.Fatalf("doWithRetry: %v", err)
+	}
+	resp.Body.Close()
+
+	// A call is recorded once, with its outcome after retries
+	var recorded []requestOutcome
+	for _, outcome := range recentOutcomes(start) {
+		if outcome.Host == req.URL.Host {
+			recorded = append(recorded, outcome)
+		}
+	}
+	if len(recorded) != 1 || recorded[0].Class != "" {
+		t.Errorf("recorded outcomes = %+v, want one success", recorded)
+	}
+}
@@ -0,0 +1,149 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// setIAMPolicyMethodFilter matches audit log methods that replace a
+// resource's IAM policy, such as SetIamPolicy, the IAM service's
+// SetIAMPolicy, and Cloud Storage's storage.setIamPermissions
+const setIAMPolicyMethodFilter = `protoPayload.methodName=~"(?i)(setIamPolicy|setIamPermissions)$"`
+
+// registerIAMPolicyChangeTools registers tools that report IAM policy changes
+func registerIAMPolicyChangeTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get IAM policy changes tool
+	getIAMPolicyChanges := mcp.NewTool("get_iam_policy_changes",
+		mcp.WithDescription("Lists IAM policy changes in a project from Admin Activity audit logs, showing the resource, who made the change and when, and which role bindings were added or removed. Explains \"suddenly can't access\" incidents caused by a removed binding."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range for audit logs in hours (default: 24)"),
+		),
+	)
+
+	getIAMPolicyChangesHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetIAMPolicyChanges(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getIAMPolicyChanges, getIAMPolicyChangesHandler)
+
+	return nil
+}
+
+// bindingDelta is a member added to or removed from a role
+type bindingDelta struct {
+	// Action is ADD or REMOVE
+	Action    string
+	Role      string
+	Member    string
+	Condition string
+}
+
+// policyBindingDeltas returns the binding changes recorded in a SetIamPolicy
+// audit record's policy delta. Services that don't record a delta return
+// none.
+func policyBindingDeltas(record auditRecord) []bindingDelta {
+	var deltas []bindingDelta
+	for _, delta := range payloadObjects(record.ServiceData, "policyDelta", "bindingDeltas") {
+		deltas = append(deltas, bindingDelta{
+			Action:    payloadString(delta, "action"),
+			Role:      payloadString(delta, "role"),
+			Member:    payloadString(delta, "member"),
+			Condition: payloadString(delta, "condition", "title"),
+		})
+	}
+	return deltas
+}
+
+// handleGetIAMPolicyChanges handles the get_iam_policy_changes tool request
+func handleGetIAMPolicyChanges(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	timeRangeHours := 24.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	startTime := time.Now().Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+
+	// Failed calls carry a non-zero status and didn't change the policy
+	filter := setIAMPolicyMethodFilter + " AND (NOT protoPayload.status.code:* OR protoPayload.status.code=0)"
+
+	records, truncated, err := fetchAuditLogEntries(ctx, client, projectID,
+		[]string{auditLogActivity}, filter, startTime, 500)
+	cancelled := partialOnCancel(err, len(records))
+	if err != nil && !cancelled {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying audit logs: %v", err)), nil
+	}
+
+	// Format the results
+	if len(records) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No IAM policy changes found in project %s in the last %.1f hours.", projectID, timeRangeHours)), nil
+	}
+
+	result := fmt.Sprintf("# IAM Policy Changes in Project %s\n\n", projectID)
+	result += fmt.Sprintf("Found %d IAM policy changes in the last %.1f hours, newest first.\n\n", len(records), timeRangeHours)
+
+	removals := 0
+	for _, record := range records {
+		result += fmt.Sprintf("## %s on `%s`\n\n", formatTime(record.Timestamp), record.Resource)
+		result += fmt.Sprintf("- **Changed By**: %s\n", record.Principal)
+		result += fmt.Sprintf("- **Method**: %s\n", record.Method)
+
+		deltas := policyBindingDeltas(record)
+		if len(deltas) == 0 {
+			result += "- **Bindings**: the service didn't record which bindings changed; compare with the current policy\n\n"
+			continue
+		}
+		for _, delta := range deltas {
+			line := fmt.Sprintf("- Added `%s` to `%s`", delta.Member, delta.Role)
+			if delta.Action == "REMOVE" {
+				line = fmt.Sprintf("- **Removed** `%s` from `%s`", delta.Member, delta.Role)
+				removals++
+			}
+			if delta.Condition != "" {
+				line += fmt.Sprintf(" (condition: %s)", delta.Condition)
+			}
+			result += line + "\n"
+		}
+		result += "\n"
+	}
+
+	if truncated {
+		result += "Note: Only the most recent 500 changes were analysed. Narrow the time range to see older changes.\n\n"
+	}
+
+	result += "## Recommended Actions\n\n"
+	if removals > 0 {
+		result += "1. Check whether a removed binding belonged to a principal that is now failing; restore it if the removal wasn't intended\n"
+	} else {
+		result += "1. Check whether a change lines up with when access started failing\n"
+	}
+	result += "2. Use get_audit_log_access_denials to see which principals are being denied and the permission they're missing\n"
+	result += "3. If the change was made by automation such as Terraform, fix its configuration too, or it will undo a manual fix\n"
+
+	if cancelled {
+		result += cancelledNote
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// testSetIAMPolicyEntry is a SetIamPolicy audit entry whose policy delta
+// removes one member and adds another under a condition
+const testSetIAMPolicyEntry = `{"timestamp": "2026-10-17T01:05:00Z", "protoPayload": {
+	"authenticationInfo": {"principalEmail": "admin@example.com"},
+	"methodName": "SetIamPolicy",
+	"resourceName": "projects/test-project",
+	"serviceData": {"policyDelta": {"bindingDeltas": [
+		{"action": "REMOVE", "role": "roles/storage.objectViewer", "member": "serviceAccount:app@test-project.iam.gserviceaccount.com"},
+		{"action": "ADD", "role": "roles/storage.objectAdmin", "member": "user:dev@example.com", "condition": {"title": "expires-2026-11"}}
+	]}}
+}}`
+
+func TestPolicyBindingDeltas(t *testing.T) {
+	record := parseAuditEntry(decodeJSON[logEntry](t, testSetIAMPolicyEntry))
+
+	want := []bindingDelta{
+		{Action: "REMOVE", Role: "roles/storage.objectViewer", Member: "serviceAccount:app@test-project.iam.gserviceaccount.com"},
+		{Action: "ADD", Role: "roles/storage.objectAdmin", Member: "user:dev@example.com", Condition: "expires-2026-11"},
+	}
+	got := policyBindingDeltas(record)
+	if len(got) != len(want) {
+		t.Fatalf("policyBindingDeltas = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("delta %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	// Services that don't record a delta
+	if got := policyBindingDeltas(auditRecord{}); len(got) != 0 {
+		t.Errorf("policyBindingDeltas without service data = %+v, want none", got)
+	}
+}
+
+func TestHandleGetIAMPolicyChanges(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), fakeLogging(t, func(filter string) string {
+		for _, s := range []string{setIAMPolicyMethodFilter, `log_id("` + auditLogActivity + `")`} {
+			if !strings.Contains(filter, s) {
+				t.Errorf("filter doesn't contain %s: %s", s, filter)
+			}
+		}
+		return "[" + testSetIAMPolicyEntry + "]"
+	}))
+
+	text := callTool(t, ctx, handleGetIAMPolicyChanges, map[string]interface{}{
+		"project_id": "test-project",
+	})
+
+	for _, s := range []string{
+		"## 2026-10-17 01:05:00 on `projects/test-project`\n",
+		"- **Changed By**: admin@example.com\n",
+		"- **Removed** `serviceAccount:app@test-project.iam.gserviceaccount.com` from `roles/storage.objectViewer`\n" +
+			"- Added `user:dev@example.com` to `roles/storage.objectAdmin` (condition: expires-2026-11)\n",
+		"1. Check whether a removed binding belonged to a principal that is now failing",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
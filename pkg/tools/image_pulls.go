@@ -0,0 +1,273 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerImagePullTools registers tools that diagnose image pull failures
+func registerImagePullTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get recent image pulls tool
+	getRecentImagePulls := mcp.NewTool("get_recent_image_pulls",
+		mcp.WithDescription("Lists image pull failures (ErrImagePull/ImagePullBackOff) from Kubernetes events, grouped by image with the failure reason (unauthorized, not found, rate limited, network) and the affected pods"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("The Kubernetes namespace (if not provided, all namespaces are searched)"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range for events in hours (default: 6)"),
+		),
+	)
+
+	getRecentImagePullsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetRecentImagePulls(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getRecentImagePulls, getRecentImagePullsHandler)
+
+	return nil
+}
+
+// imageRefPattern extracts the image reference from kubelet pull event messages
+var imageRefPattern = regexp.MustCompile(`image "([^"]+)"`)
+
+// imagePullReasons classifies pull failure messages, checked in order
+var imagePullReasons = []struct {
+	Reason   string
+	Patterns []string
+	Advice   string
+}{
+	{"rate limited", []string{"toomanyrequests", "429 too many requests", "rate limit"},
+		"The registry is rate limiting pulls; authenticate to the registry, mirror the image to Artifact Registry or use a pull-through cache"},
+	{"unauthorized", []string{"unauthorized", "403 forbidden", "401", "denied", "permission", "authentication required"},
+		"The node or imagePullSecret lacks access; grant the node service account roles/artifactregistry.reader or fix the pull secret"},
+	{"not found", []string{"not found", "manifest unknown", "name unknown"},
+		"The image or tag doesn't exist; check the tag was pushed and the reference is spelled correctly"},
+	{"network", []string{"i/o timeout", "dial tcp", "connection refused", "no such host", "tls handshake timeout", "context deadline exceeded"},
+		"Nodes can't reach the registry; check Private Google Access, Cloud NAT and firewall egress rules"},
+}
+
+// classifyImagePullFailure returns the failure reason for a pull event message
+func classifyImagePullFailure(message string) string {
+	lower := strings.ToLower(message)
+	for _, r := range imagePullReasons {
+		for _, pattern := range r.Patterns {
+			if strings.Contains(lower, pattern) {
+				return r.Reason
+			}
+		}
+	}
+	return "ErrImagePull"
+}
+
+// imagePullFailure is a group of pull failures for one image and reason
+type imagePullFailure struct {
+	Image         string
+	Reason        string
+	Count         int
+	Pods          []string
+	LastSeen      string
+	SampleMessage string
+}
+
+// groupImagePullFailures groups failed pull events by image and reason, most
+// frequent first. Back-off events only reference the image, so they are
+// counted but don't set the sample message.
+func groupImagePullFailures(entries []logEntry) []imagePullFailure {
+	groups := make(map[string]*imagePullFailure)
+	var order []string
+	for _, entry := range entries {
+		message := payloadString(entry.JsonPayload, "message")
+		match := imageRefPattern.FindStringSubmatch(message)
+		if match == nil {
+			continue
+		}
+		image := match[1]
+
+		reason := classifyImagePullFailure(message)
+		backOff := strings.HasPrefix(message, "Back-off pulling image")
+		if backOff {
+			reason = ""
+		}
+
+		pod := payloadString(entry.JsonPayload, "involvedObject", "namespace") + "/" + payloadString(entry.JsonPayload, "involvedObject", "name")
+
+		key := image + "|" + reason
+		group, ok := groups[key]
+		if !ok {
+			group = &imagePullFailure{Image: image, Reason: reason}
+			groups[key] = group
+			order = append(order, key)
+		}
+
+		group.Count++
+		if !containsString(group.Pods, pod) {
+			group.Pods = append(group.Pods, pod)
+		}
+		if entry.Timestamp > group.LastSeen {
+			group.LastSeen = entry.Timestamp
+			if !backOff {
+				group.SampleMessage = message
+			}
+		}
+	}
+
+	// Fold back-offs into the image's actual failure where one was seen
+	actual := make(map[string]*imagePullFailure)
+	for _, key := range order {
+		if group := groups[key]; group.Reason != "" && actual[group.Image] == nil {
+			actual[group.Image] = group
+		}
+	}
+	for _, key := range order {
+		group := groups[key]
+		if group.Reason != "" {
+			continue
+		}
+		target, ok := actual[group.Image]
+		if !ok {
+			group.Reason = "ImagePullBackOff"
+			continue
+		}
+		target.Count += group.Count
+		for _, pod := range group.Pods {
+			if !containsString(target.Pods, pod) {
+				target.Pods = append(target.Pods, pod)
+			}
+		}
+		delete(groups, key)
+	}
+
+	var failures []imagePullFailure
+	for _, key := range order {
+		if group, ok := groups[key]; ok {
+			failures = append(failures, *group)
+		}
+	}
+
+	sort.SliceStable(failures, func(i, j int) bool {
+		return failures[i].Count > failures[j].Count
+	})
+
+	return failures
+}
+
+// handleGetRecentImagePulls handles the get_recent_image_pulls tool request
+func handleGetRecentImagePulls(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+
+	// Get optional parameters with defaults
+	timeRangeHours := 6.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	startTime := time.Now().Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+
+	// The kubelet reports pull failures as pod events
+	filter := fmt.Sprintf(`resource.type="k8s_pod"
+		AND resource.labels.project_id="%s"
+		AND resource.labels.location="%s"
+		AND resource.labels.cluster_name="%s"
+		AND log_id("events")
+		AND jsonPayload.reason=("Failed" OR "BackOff")
+		AND jsonPayload.message=~"(Failed to pull|Back-off pulling) image"
+		AND timestamp >= "%s"`,
+		projectID, location, clusterName, startTime.Format(time.RFC3339))
+	if namespace != "" {
+		filter += fmt.Sprintf(`
+		AND resource.labels.namespace_name="%s"`, namespace)
+	}
+
+	entries, _, err := fetchLogEntries(ctx, client, projectID, logQuery{
+		Filter:   filter,
+		PageSize: 1000,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying logs: %v", err)), nil
+	}
+
+	scope := "all namespaces"
+	if namespace != "" {
+		scope = fmt.Sprintf("namespace %s", namespace)
+	}
+
+	failures := groupImagePullFailures(entries)
+	if len(failures) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No image pull failures found in %s of cluster %s in the last %.1f hours.", scope, clusterName, timeRangeHours)), nil
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# Image Pull Failures in Cluster %s\n\n", clusterName)
+	result += fmt.Sprintf("Found pull failures for %d image/reason combinations in %s over the last %.1f hours.\n\n", len(failures), scope, timeRangeHours)
+
+	reasons := make(map[string]bool)
+	for _, failure := range failures {
+		reasons[failure.Reason] = true
+
+		result += fmt.Sprintf("## %s\n\n", failure.Image)
+		result += fmt.Sprintf("- **Reason**: %s\n", failure.Reason)
+		result += fmt.Sprintf("- **Failed Pulls**: %d\n", failure.Count)
+		result += fmt.Sprintf("- **Last Seen**: %s\n", formatTime(failure.LastSeen))
+		result += fmt.Sprintf("- **Affected Pods**: %s\n", strings.Join(failure.Pods, ", "))
+		if failure.SampleMessage != "" {
+			result += "- **Message**:\n```\n" + failure.SampleMessage + "\n```\n"
+		}
+		result += "\n"
+	}
+
+	result += "## Recommended Actions\n\n"
+	step := 1
+	for _, r := range imagePullReasons {
+		if reasons[r.Reason] {
+			result += fmt.Sprintf("%d. **%s**: %s\n", step, r.Reason, r.Advice)
+			step++
+		}
+	}
+	if reasons["ErrImagePull"] || reasons["ImagePullBackOff"] {
+		result += fmt.Sprintf("%d. For other failures, run describe on an affected pod to see the full pull error from the kubelet\n", step)
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
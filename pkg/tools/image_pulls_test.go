@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestClassifyImagePullFailure(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{message: `Failed to pull image "nginx:latest": toomanyrequests: You have reached your pull rate limit`, want: "rate limited"},
+		{message: `Failed to pull image "us-docker.pkg.dev/p/r/app:v1": failed to authorize: 403 Forbidden`, want: "unauthorized"},
+		{message: `Failed to pull image "app:v9": rpc error: code = NotFound desc = manifest unknown`, want: "not found"},
+		{message: `Failed to pull image "gcr.io/p/app:v1": dial tcp 74.125.0.1:443: i/o timeout`, want: "network"},
+		{message: `Failed to pull image "app:v1": something else went wrong`, want: "ErrImagePull"},
+	}
+
+	for _, tt := range tests {
+		if got := classifyImagePullFailure(tt.message); got != tt.want {
+			t.Errorf("classifyImagePullFailure(%q) = %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestHandleGetRecentImagePulls(t *testing.T) {
+	event := func(timestamp, pod, message string) string {
+		return `{"timestamp": "` + timestamp + `", "jsonPayload": {"message": "` + strings.ReplaceAll(message, `"`, `\"`) + `",
+			"involvedObject": {"namespace": "web", "name": "` + pod + `"}}}`
+	}
+
+	ctx := withFakeGCP(context.Background(), fakeLogging(t, func(filter string) string {
+		if !strings.Contains(filter, `resource.labels.namespace_name="web"`) {
+			t.Errorf("filter doesn't select the namespace: %s", filter)
+		}
+		return "[" + strings.Join([]string{
+			event("2026-10-17T01:05:00Z", "app-1", `Back-off pulling image "app:v9"`),
+			event("2026-10-17T01:04:00Z", "app-2", `Failed to pull image "app:v9": manifest unknown`),
+			event("2026-10-17T01:03:00Z", "app-1", `Failed to pull image "app:v9": manifest unknown`),
+			event("2026-10-17T01:02:00Z", "app-1", `Back-off pulling image "app:v9"`),
+			event("2026-10-17T01:01:00Z", "cache-0", `Back-off pulling image "redis:7"`),
+		}, ",") + "]"
+	}))
+
+	text := callClusterTool(t, ctx, handleGetRecentImagePulls, "prod", map[string]interface{}{"namespace": "web"})
+
+	// Back-offs fold into the image's actual failure, or stand alone when
+	// none was seen
+	for _, s := range []string{
+		"Found pull failures for 2 image/reason combinations in namespace web",
+		"## app:v9\n\n- **Reason**: not found\n- **Failed Pulls**: 4\n- **Last Seen**: 2026-10-17 01:04:00\n- **Affected Pods**: web/app-2, web/app-1\n" +
+			"- **Message**:\n```\nFailed to pull image \"app:v9\": manifest unknown\n```\n",
+		"## redis:7\n\n- **Reason**: ImagePullBackOff\n- **Failed Pulls**: 1\n",
+		"1. **not found**: The image or tag doesn't exist",
+		"2. For other failures, run describe on an affected pod",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
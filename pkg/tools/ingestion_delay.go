@@ -0,0 +1,254 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerIngestionDelayTools registers tools that check metric freshness
+func registerIngestionDelayTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get metrics ingestion delay tool
+	getIngestionDelay := mcp.NewTool("get_metrics_ingestion_delay",
+		mcp.WithDescription("Compares the latest data points of a metric type against now and its expected cadence, to tell a delayed metric (every series late) apart from sources that actually stopped reporting (only some series late). Helps explain late or missing metric-based alerts."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("metric_type",
+			mcp.Required(),
+			mcp.Description("The metric type (e.g., kubernetes.io/container/cpu/core_usage_time)"),
+		),
+		mcp.WithNumber("lookback_hours",
+			mcp.Description("How far back to look for data points in hours; series with no point in this window aren't seen (default: 1)"),
+		),
+	)
+
+	getIngestionDelayHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetMetricsIngestionDelay(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getIngestionDelay, getIngestionDelayHandler)
+
+	return nil
+}
+
+// defaultSamplePeriod is assumed when a metric descriptor doesn't state one
+const defaultSamplePeriod = time.Minute
+
+// minIngestionDelayThreshold is the smallest delay that is ever flagged, so
+// normal jitter on fast metrics isn't reported
+const minIngestionDelayThreshold = 3 * time.Minute
+
+// ingestionDelayThreshold returns how old a metric's latest point can be
+// before it is flagged: twice the sample period plus the documented ingest
+// delay, and at least minIngestionDelayThreshold
+func ingestionDelayThreshold(descriptor metricDescriptor) time.Duration {
+	samplePeriod, err := time.ParseDuration(descriptor.Metadata.SamplePeriod)
+	if err != nil || samplePeriod <= 0 {
+		samplePeriod = defaultSamplePeriod
+	}
+	ingestDelay, err := time.ParseDuration(descriptor.Metadata.IngestDelay)
+	if err != nil || ingestDelay < 0 {
+		ingestDelay = 0
+	}
+
+	threshold := 2*samplePeriod + ingestDelay
+	if threshold < minIngestionDelayThreshold {
+		threshold = minIngestionDelayThreshold
+	}
+	return threshold
+}
+
+// seriesDelay is how long ago a time series last reported
+type seriesDelay struct {
+	Series string
+	Latest time.Time
+	Delay  time.Duration
+}
+
+// ingestionDelayReport summarises the freshness of a metric's time series
+type ingestionDelayReport struct {
+	Series int
+	// Freshest is the newest point across all series; its delay is the best
+	// case ingestion delay
+	Freshest      time.Time
+	FreshestDelay time.Duration
+	// Stale are the series whose latest point is older than the threshold,
+	// oldest first
+	Stale []seriesDelay
+}
+
+// allDelayed reports whether even the freshest series is late, which points
+// at delayed ingestion (or everything stopping at once) rather than
+// individual sources
+func (r ingestionDelayReport) allDelayed(threshold time.Duration) bool {
+	return r.Series > 0 && r.FreshestDelay > threshold
+}
+
+// seriesName describes a time series by its resource and metric labels
+func seriesName(ts timeSeries) string {
+	var labels []string
+	for key, value := range ts.Resource.Labels {
+		if key == "project_id" {
+			continue
+		}
+		labels = append(labels, key+"="+value)
+	}
+	for key, value := range ts.Metric.Labels {
+		labels = append(labels, key+"="+value)
+	}
+	sort.Strings(labels)
+	return fmt.Sprintf("%s{%s}", ts.Resource.Type, strings.Join(labels, ","))
+}
+
+// summariseIngestionDelay measures how long ago each series last reported
+func summariseIngestionDelay(series []timeSeries, now time.Time, threshold time.Duration) ingestionDelayReport {
+	var report ingestionDelayReport
+	for _, ts := range series {
+		if len(ts.Points) == 0 {
+			continue
+		}
+		// Points are returned newest first
+		latest, err := time.Parse(time.RFC3339Nano, ts.Points[0].Interval.EndTime)
+		if err != nil {
+			continue
+		}
+
+		report.Series++
+		if latest.After(report.Freshest) {
+			report.Freshest = latest
+		}
+
+		if delay := now.Sub(latest); delay > threshold {
+			report.Stale = append(report.Stale, seriesDelay{Series: seriesName(ts), Latest: latest, Delay: delay})
+		}
+	}
+
+	if report.Series > 0 {
+		report.FreshestDelay = now.Sub(report.Freshest)
+	}
+
+	sort.SliceStable(report.Stale, func(i, j int) bool {
+		return report.Stale[i].Delay > report.Stale[j].Delay
+	})
+
+	return report
+}
+
+// handleGetMetricsIngestionDelay handles the get_metrics_ingestion_delay tool request
+func handleGetMetricsIngestionDelay(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	metricType, ok := request.Params.Arguments["metric_type"].(string)
+	if !ok || metricType == "" {
+		return mcp.NewToolResultError("metric_type must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	lookbackHours := 1.0
+	if val, ok := request.Params.Arguments["lookback_hours"].(float64); ok && val > 0 {
+		lookbackHours = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	descriptors, _, err := fetchMetricDescriptors(ctx, client, projectID, fmt.Sprintf("metric.type = %q", metricType), 1)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting metric descriptor: %v", err)), nil
+	}
+	if len(descriptors) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("metric type %s was not found in project %s; use list_metric_descriptors to find it", metricType, projectID)), nil
+	}
+	descriptor := descriptors[0]
+	threshold := ingestionDelayThreshold(descriptor)
+
+	// Raw points keep their real timestamps, unlike aligned ones
+	now := time.Now()
+	series, err := fetchTimeSeries(ctx, client, projectID, timeSeriesQuery{
+		Filter:    fmt.Sprintf("metric.type = %q", metricType),
+		StartTime: now.Add(-time.Duration(lookbackHours * float64(time.Hour))),
+		EndTime:   now,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying metric: %v", err)), nil
+	}
+
+	report := summariseIngestionDelay(series, now, threshold)
+
+	// Format the results
+	result := fmt.Sprintf("# Ingestion Delay for %s\n\n", metricType)
+	samplePeriod := descriptor.Metadata.SamplePeriod
+	if samplePeriod == "" {
+		samplePeriod = "not documented, assuming " + defaultSamplePeriod.String()
+	}
+	ingestDelay := descriptor.Metadata.IngestDelay
+	if ingestDelay == "" {
+		ingestDelay = "not documented"
+	}
+	result += fmt.Sprintf("- **Sample Period**: %s\n", samplePeriod)
+	result += fmt.Sprintf("- **Documented Ingest Delay**: %s\n", ingestDelay)
+	result += fmt.Sprintf("- **Flagged After**: %s\n", threshold)
+
+	if report.Series == 0 {
+		result += fmt.Sprintf("\n**No data points in the last %.1f hours.** Either every source stopped reporting or the metric is delayed by more than the lookback; increase lookback_hours to find when it last reported.\n", lookbackHours)
+		return mcp.NewToolResultText(result), nil
+	}
+
+	result += fmt.Sprintf("- **Series Seen**: %d\n", report.Series)
+	result += fmt.Sprintf("- **Freshest Point**: %s (%s ago)\n\n", report.Freshest.Format(time.RFC3339), report.FreshestDelay.Round(time.Second))
+
+	switch {
+	case report.allDelayed(threshold):
+		result += "**Verdict: the whole metric is delayed.** Even the freshest series is behind its expected cadence, which points to an ingestion delay (or every source stopping at once). Alerts on this metric will fire late or not at all.\n\n"
+	case len(report.Stale) > 0:
+		result += fmt.Sprintf("**Verdict: ingestion is on time, but %d of %d series stopped reporting.** The metric itself is fresh, so these sources stopped sending data rather than being delayed.\n\n", len(report.Stale), report.Series)
+	default:
+		result += "**Verdict: on time.** Every series reported within its expected cadence.\n\n"
+	}
+
+	if len(report.Stale) > 0 && !report.allDelayed(threshold) {
+		shown := report.Stale
+		if len(shown) > 20 {
+			shown = shown[:20]
+		}
+		result += "| Series | Last Point | Delay |\n"
+		result += "| ------ | ---------- | ----- |\n"
+		for _, stale := range shown {
+			result += fmt.Sprintf("| %s | %s | %s |\n", stale.Series, stale.Latest.Format(time.RFC3339), stale.Delay.Round(time.Second))
+		}
+		if len(report.Stale) > len(shown) {
+			result += fmt.Sprintf("\n...and %d more stale series.\n", len(report.Stale)-len(shown))
+		}
+		result += "\n"
+	}
+
+	if report.allDelayed(threshold) || len(report.Stale) > 0 {
+		result += "## Recommended Actions\n\n"
+		if report.allDelayed(threshold) {
+			result += "1. Check the Google Cloud status dashboard for Cloud Monitoring ingestion incidents\n"
+			result += "2. For agent or custom metrics, check the writer is healthy and not being throttled (look for quota errors in its logs)\n"
+			result += "3. Until ingestion recovers, don't trust the absence of alerts on this metric; check the underlying service directly\n"
+		} else {
+			result += "1. Check the stale resources are still running; a stopped series usually means the resource was deleted, scaled in or crashed\n"
+			result += "2. If the resources are running, check the agent or exporter that writes the metric on them\n"
+			result += "3. Alerts that use metric absence conditions should fire for these series; check they exist if this was unexpected\n"
+		}
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIngestionDelayThreshold(t *testing.T) {
+	tests := []struct {
+		samplePeriod, ingestDelay string
+		want                      time.Duration
+	}{
+		{samplePeriod: "60s", ingestDelay: "180s", want: 5 * time.Minute},
+		{samplePeriod: "300s", want: 10 * time.Minute},
+		// Fast or undocumented metrics are flagged after the minimum
+		{samplePeriod: "10s", want: minIngestionDelayThreshold},
+		{want: minIngestionDelayThreshold},
+	}
+
+	for _, tt := range tests {
+		var descriptor metricDescriptor
+		descriptor.Metadata.SamplePeriod = tt.samplePeriod
+		descriptor.Metadata.IngestDelay = tt.ingestDelay
+		if got := ingestionDelayThreshold(descriptor); got != tt.want {
+			t.Errorf("ingestionDelayThreshold(%q, %q) = %s, want %s", tt.samplePeriod, tt.ingestDelay, got, tt.want)
+		}
+	}
+}
+
+// pointAt is a time series for resource instance whose latest point ends at t
+func pointAt(instance string, t time.Time) timeSeries {
+	ts := testSeries(nil, map[string]string{"instance_id": instance, "project_id": "test-project"}, 1)
+	ts.Resource.Type = "gce_instance"
+	ts.Points[0].Interval.EndTime = t.Format(time.RFC3339)
+	return ts
+}
+
+func TestSummariseIngestionDelay(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	report := summariseIngestionDelay([]timeSeries{
+		pointAt("1", now.Add(-time.Minute)),
+		pointAt("2", now.Add(-20*time.Minute)),
+		pointAt("3", now.Add(-45*time.Minute)),
+	}, now, 5*time.Minute)
+
+	if report.Series != 3 || report.FreshestDelay != time.Minute {
+		t.Errorf("report = %+v, want 3 series with the freshest a minute old", report)
+	}
+	if report.allDelayed(5 * time.Minute) {
+		t.Error("report is all delayed, but one series is fresh")
+	}
+	if len(report.Stale) != 2 || report.Stale[0].Series != "gce_instance{instance_id=3}" || report.Stale[1].Delay != 20*time.Minute {
+		t.Errorf("stale series = %+v, want instances 3 then 2", report.Stale)
+	}
+}
+
+func TestHandleGetMetricsIngestionDelay(t *testing.T) {
+	const metricType = "custom.googleapis.com/queue/depth"
+	latest := time.Now().Add(-40 * time.Minute).UTC()
+
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if filter := r.URL.Query().Get("filter"); filter != `metric.type = "`+metricType+`"` {
+			t.Errorf("filter = %q, want the metric type", filter)
+		}
+		switch r.URL.Path {
+		case "/v3/projects/test-project/metricDescriptors":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"metricDescriptors": []map[string]interface{}{
+				{"type": metricType, "metadata": map[string]string{"samplePeriod": "60s"}},
+			}})
+		case "/v3/projects/test-project/timeSeries":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"timeSeries": []timeSeries{
+				pointAt("1", latest),
+				pointAt("2", latest.Add(-10*time.Minute)),
+			}})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	text := callTool(t, ctx, handleGetMetricsIngestionDelay, map[string]interface{}{
+		"project_id":  "test-project",
+		"metric_type": metricType,
+	})
+
+	// Every series is behind, so the metric is delayed rather than individual
+	// sources having stopped
+	for _, s := range []string{
+		"- **Sample Period**: 60s\n- **Documented Ingest Delay**: not documented\n- **Flagged After**: 3m0s\n",
+		"- **Series Seen**: 2\n",
+		"- **Freshest Point**: " + latest.Format(time.RFC3339) + " (40m",
+		"**Verdict: the whole metric is delayed.**",
+		"1. Check the Google Cloud status dashboard",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if strings.Contains(text, "| Series |") {
+		t.Errorf("result lists stale series for a delayed metric:\n%s", text)
+	}
+}
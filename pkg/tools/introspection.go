@@ -0,0 +1,246 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerIntrospectionTools registers tools that report on the server itself
+func registerIntrospectionTools(s *server.MCPServer) error {
+	// Register last GCP request IDs tool
+	lastRequestIDs := mcp.NewTool("last_gcp_request_ids",
+		mcp.WithDescription("Lists the request IDs of recent failed GCP API calls, for quoting when escalating to Google Support"),
+	)
+
+	lastRequestIDsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleLastGCPRequestIDs(ctx, request)
+	}
+
+	AddToolSafe(s, lastRequestIDs, lastRequestIDsHandler)
+
+	// Register describe tool tool
+	describeTool := mcp.NewTool("describe_tool",
+		mcp.WithDescription("Returns the description and full parameter schema of a single tool, including required parameters, types, enums and defaults, to help construct a valid call"),
+		mcp.WithString("tool_name",
+			mcp.Required(),
+			mcp.Description("The name of the tool to describe"),
+		),
+	)
+
+	describeToolHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleDescribeTool(ctx, request)
+	}
+
+	AddToolSafe(s, describeTool, describeToolHandler)
+
+	// Register get API error rate by GCP service tool
+	getAPIErrorRate := mcp.NewTool("get_api_error_rate_by_gcp_service",
+		mcp.WithDescription("Reports the error rate of this server's own recent calls to each upstream API (such as monitoring.googleapis.com or a cluster's API server), with success and error counts and the dominant error class. Shows whether a particular API is failing or throttling, such as Monitoring calls failing while Logging is fine."),
+		mcp.WithString("window",
+			mcp.Description("How far back to look, as a duration such as 15m or 2h (default: 1h). Only calls since the server started are recorded."),
+		),
+	)
+
+	getAPIErrorRateHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetAPIErrorRateByGCPService(ctx, request)
+	}
+
+	AddToolSafe(s, getAPIErrorRate, getAPIErrorRateHandler)
+
+	return nil
+}
+
+// hostOutcomes summarises the outcomes of calls to one upstream API host
+type hostOutcomes struct {
+	Host      string
+	Succeeded int
+	Failed    int
+	// Errors counts failures by class
+	Errors map[string]int
+}
+
+// errorRate returns the fraction of calls that failed
+func (h hostOutcomes) errorRate() float64 {
+	if h.Succeeded+h.Failed == 0 {
+		return 0
+	}
+	return float64(h.Failed) / float64(h.Succeeded+h.Failed)
+}
+
+// dominantError returns the most frequent error class and its count, or an
+// empty string if no calls failed. Ties go to the alphabetically first class
+// so the result is stable.
+func (h hostOutcomes) dominantError() (string, int) {
+	dominant, count := "", 0
+	for class, n := range h.Errors {
+		if n > count || (n == count && class < dominant) {
+			dominant, count = class, n
+		}
+	}
+	return dominant, count
+}
+
+// summariseOutcomes groups request outcomes by host, highest error rate
+// first
+func summariseOutcomes(outcomes []requestOutcome) []hostOutcomes {
+	byHost := make(map[string]*hostOutcomes)
+	for _, outcome := range outcomes {
+		summary, ok := byHost[outcome.Host]
+		if !ok {
+			summary = &hostOutcomes{Host: outcome.Host, Errors: make(map[string]int)}
+			byHost[outcome.Host] = summary
+		}
+		if outcome.Class == "" {
+			summary.Succeeded++
+		} else {
+			summary.Failed++
+			summary.Errors[outcome.Class]++
+		}
+	}
+
+	summaries := make([]hostOutcomes, 0, len(byHost))
+	for _, summary := range byHost {
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].errorRate() != summaries[j].errorRate() {
+			return summaries[i].errorRate() > summaries[j].errorRate()
+		}
+		return summaries[i].Host < summaries[j].Host
+	})
+
+	return summaries
+}
+
+// handleLastGCPRequestIDs handles the last_gcp_request_ids tool request
+func handleLastGCPRequestIDs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	records := recentFailedRequests()
+	if len(records) == 0 {
+		return mcp.NewToolResultText("No failed GCP API calls with a request ID have been recorded since the server started."), nil
+	}
+
+	// Format the results
+	result := "# Recent Failed GCP API Calls\n\n"
+	result += fmt.Sprintf("The last %d failed calls that returned a request ID, newest first:\n\n", len(records))
+	result += "| Time | Request ID | Status | Call |\n"
+	result += "| ---- | ---------- | ------ | ---- |\n"
+	for _, record := range records {
+		result += fmt.Sprintf("| %s | `%s` | %s | %s %s |\n",
+			record.Time.UTC().Format(time.RFC3339), record.RequestID, record.Status, record.Method, record.URL)
+	}
+	result += "\nInclude the request ID, time and call when opening a Google Support case.\n"
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// handleDescribeTool handles the describe_tool tool request
+func handleDescribeTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	toolName, ok := request.Params.Arguments["tool_name"].(string)
+	if !ok || toolName == "" {
+		return mcp.NewToolResultError("tool_name must be a non-empty string"), nil
+	}
+
+	tool, ok := registeredTool(toolName)
+	if !ok {
+		names := registeredToolNames()
+		sort.Strings(names)
+		return mcp.NewToolResultError(fmt.Sprintf("NOT_FOUND: no tool named %q. Available tools: %s", toolName, strings.Join(names, ", "))), nil
+	}
+
+	schema, err := json.MarshalIndent(tool.InputSchema, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error encoding schema: %v", err)), nil
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# Tool: %s\n\n", tool.Name)
+	result += tool.Description + "\n\n"
+
+	if len(tool.InputSchema.Properties) == 0 {
+		result += "This tool takes no parameters.\n"
+		return mcp.NewToolResultText(result), nil
+	}
+
+	params := make([]string, 0, len(tool.InputSchema.Properties))
+	for name := range tool.InputSchema.Properties {
+		params = append(params, name)
+	}
+	sort.Strings(params)
+
+	result += "## Parameters\n\n"
+	result += "| Name | Type | Required | Allowed Values | Default | Description |\n"
+	result += "| ---- | ---- | -------- | -------------- | ------- | ----------- |\n"
+	for _, name := range params {
+		prop, _ := tool.InputSchema.Properties[name].(map[string]interface{})
+
+		enum := ""
+		if values, ok := prop["enum"].([]string); ok {
+			enum = strings.Join(values, ", ")
+		}
+		def := ""
+		if val, ok := prop["default"]; ok {
+			def = fmt.Sprintf("%v", val)
+		}
+
+		result += fmt.Sprintf("| %s | %v | %t | %s | %s | %v |\n",
+			name, prop["type"], containsString(tool.InputSchema.Required, name), enum, def, prop["description"])
+	}
+
+	result += "\n## Input Schema\n\n```json\n" + string(schema) + "\n```\n"
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// handleGetAPIErrorRateByGCPService handles the get_api_error_rate_by_gcp_service tool request
+func handleGetAPIErrorRateByGCPService(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Get optional parameters with defaults
+	window := time.Hour
+	if val, ok := request.Params.Arguments["window"].(string); ok && val != "" {
+		parsed, err := time.ParseDuration(val)
+		if err != nil || parsed <= 0 {
+			return mcp.NewToolResultError("window must be a positive duration, such as 15m or 2h"), nil
+		}
+		window = parsed
+	}
+
+	outcomes := recentOutcomes(time.Now().Add(-window))
+	if len(outcomes) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No upstream API calls have been recorded in the last %s.", window)), nil
+	}
+
+	summaries := summariseOutcomes(outcomes)
+
+	// Format the results
+	result := "# API Error Rate by Service\n\n"
+	result += fmt.Sprintf("%d calls to %d upstream APIs in the last %s, after retries:\n\n", len(outcomes), len(summaries), window)
+	result += "| Host | Calls | Succeeded | Failed | Error Rate | Dominant Error |\n"
+	result += "| ---- | ----- | --------- | ------ | ---------- | -------------- |\n"
+	for _, summary := range summaries {
+		dominant := "-"
+		if class, count := summary.dominantError(); class != "" {
+			dominant = fmt.Sprintf("%s (%d)", class, count)
+		}
+		result += fmt.Sprintf("| %s | %d | %d | %d | %.1f%% | %s |\n",
+			summary.Host, summary.Succeeded+summary.Failed, summary.Succeeded, summary.Failed, summary.errorRate()*100, dominant)
+	}
+
+	if len(outcomes) >= maxRecordedOutcomes {
+		result += fmt.Sprintf("\nNote: Only the most recent %d calls are kept, so the window may be shorter than requested.\n", maxRecordedOutcomes)
+	}
+
+	result += "\n## Recommended Actions\n\n"
+	result += "1. 5xx errors, timeouts and network errors concentrated on one API point to that API being degraded; check the Google Cloud status dashboard for it\n"
+	result += "2. 429s mean quota or rate limits are being hit; fewer or narrower tool calls, or a quota increase, will help\n"
+	result += "3. 401/403s are missing permissions rather than an outage; check them with get_auth_status\n"
+	result += "4. Quote request IDs from last_gcp_request_ids when escalating failed calls to Google Support\n"
+
+	return mcp.NewToolResultText(result), nil
+}
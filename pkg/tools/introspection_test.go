@@ -0,0 +1,154 @@
+package tools
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func TestHandleLastGCPRequestIDs(t *testing.T) {
+	req, _ := http.NewRequest("GET", "https://container.googleapis.com/v1/projects/test-project/locations/-/clusters", nil)
+	header := http.Header{}
+	header.Set("X-Goog-Request-Id", "req-last-ids")
+	recordRequestID(req, &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Status:     "503 Service Unavailable",
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader("")),
+	})
+
+	result, err := handleLastGCPRequestIDs(context.Background(), newToolRequest(nil))
+	if err != nil {
+		t.Fatalf("handleLastGCPRequestIDs returned error: %v", err)
+	}
+	text, _ := resultText(result)
+	if !strings.Contains(text, "`req-last-ids` | 503 Service Unavailable | GET https://container.googleapis.com/v1/projects/test-project/locations/-/clusters") {
+		t.Errorf("result doesn't list the failed call:\n%s", text)
+	}
+}
+
+func TestHandleDescribeTool(t *testing.T) {
+	useEmptyToolRegistry(t)
+
+	s := server.NewMCPServer("test", "0.0.0")
+	if err := registerIntrospectionTools(s); err != nil {
+		t.Fatalf("registering tools: %v", err)
+	}
+	AddToolSafe(s, mcp.NewTool("sample_tool",
+		mcp.WithDescription("A tool for testing describe_tool"),
+		mcp.WithString("project_id", mcp.Required(), mcp.Description("The project")),
+		mcp.WithString("output_format", mcp.Enum("markdown", "json"), mcp.Description("The format")),
+		mcp.WithNumber("max_results", mcp.DefaultNumber(50), mcp.Description("The limit")),
+	), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(""), nil
+	})
+
+	result, err := handleDescribeTool(context.Background(), newToolRequest(map[string]interface{}{"tool_name": "sample_tool"}))
+	if err != nil {
+		t.Fatalf("handleDescribeTool returned error: %v", err)
+	}
+	text, _ := resultText(result)
+	if result.IsError {
+		t.Fatalf("handleDescribeTool returned tool error: %s", text)
+	}
+	for _, s := range []string{
+		"# Tool: sample_tool",
+		"A tool for testing describe_tool",
+		"| max_results | number | false |  | 50 | The limit |",
+		"| output_format | string | false | markdown, json |  | The format |",
+		"| project_id | string | true |  |  | The project |",
+		`"required": [`,
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("description doesn't contain %q:\n%s", s, text)
+		}
+	}
+
+	// Parameters are listed alphabetically
+	if strings.Index(text, "| max_results") > strings.Index(text, "| project_id") {
+		t.Errorf("parameters aren't sorted:\n%s", text)
+	}
+
+	// An unknown tool is NOT_FOUND, listing the registered tools
+	result, err = handleDescribeTool(context.Background(), newToolRequest(map[string]interface{}{"tool_name": "sample"}))
+	if err != nil {
+		t.Fatalf("handleDescribeTool returned error: %v", err)
+	}
+	text, _ = resultText(result)
+	if !result.IsError || !strings.HasPrefix(text, `NOT_FOUND: no tool named "sample"`) {
+		t.Errorf("unknown tool didn't return NOT_FOUND: %s", text)
+	}
+	if !strings.Contains(text, "describe_tool, get_api_error_rate_by_gcp_service, last_gcp_request_ids, sample_tool") {
+		t.Errorf("NOT_FOUND doesn't list the registered tools: %s", text)
+	}
+}
+
+func TestSummariseOutcomes(t *testing.T) {
+	outcomes := []requestOutcome{
+		{Host: "logging.googleapis.com"},
+		{Host: "monitoring.googleapis.com", Class: outcomeServerError},
+		{Host: "logging.googleapis.com"},
+		{Host: "monitoring.googleapis.com", Class: outcomeRateLimited},
+		{Host: "monitoring.googleapis.com", Class: outcomeServerError},
+		{Host: "monitoring.googleapis.com"},
+		{Host: "container.googleapis.com", Class: outcomePermission},
+		{Host: "container.googleapis.com"},
+	}
+
+	summaries := summariseOutcomes(outcomes)
+
+	// Hosts are ordered by error rate, highest first
+	var hosts []string
+	for _, summary := range summaries {
+		hosts = append(hosts, summary.Host)
+	}
+	if got := strings.Join(hosts, ", "); got != "monitoring.googleapis.com, container.googleapis.com, logging.googleapis.com" {
+		t.Fatalf("hosts = %s", got)
+	}
+
+	monitoring := summaries[0]
+	if monitoring.Succeeded != 1 || monitoring.Failed != 3 || monitoring.errorRate() != 0.75 {
+		t.Errorf("monitoring summary = %+v", monitoring)
+	}
+	if class, count := monitoring.dominantError(); class != outcomeServerError || count != 2 {
+		t.Errorf("monitoring dominant error = %s (%d), want %s (2)", class, count, outcomeServerError)
+	}
+
+	logging := summaries[2]
+	if logging.Succeeded != 2 || logging.Failed != 0 || logging.errorRate() != 0 {
+		t.Errorf("logging summary = %+v", logging)
+	}
+	if class, _ := logging.dominantError(); class != "" {
+		t.Errorf("logging dominant error = %q, want none", class)
+	}
+}
+
+func TestHandleGetAPIErrorRateByGCPService(t *testing.T) {
+	addOutcome("error-rate-a.googleapis.com", "")
+	addOutcome("error-rate-a.googleapis.com", outcomeTimeout)
+	addOutcome("error-rate-b.googleapis.com", "")
+
+	result, err := handleGetAPIErrorRateByGCPService(context.Background(), newToolRequest(map[string]interface{}{"window": "1m"}))
+	if err != nil {
+		t.Fatalf("handleGetAPIErrorRateByGCPService returned error: %v", err)
+	}
+	text, _ := resultText(result)
+	for _, row := range []string{
+		"| error-rate-a.googleapis.com | 2 | 1 | 1 | 50.0% | timeout (1) |",
+		"| error-rate-b.googleapis.com | 1 | 1 | 0 | 0.0% | - |",
+	} {
+		if !strings.Contains(text, row) {
+			t.Errorf("result doesn't contain %q:\n%s", row, text)
+		}
+	}
+
+	result, _ = handleGetAPIErrorRateByGCPService(context.Background(), newToolRequest(map[string]interface{}{"window": "-1h"}))
+	if text, _ := resultText(result); !result.IsError || !strings.Contains(text, "positive duration") {
+		t.Errorf("negative window = %q, want an error", text)
+	}
+}
@@ -0,0 +1,425 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"golang.org/x/oauth2"
+)
+
+// kubeClient is a minimal client for the Kubernetes API server of a GKE cluster
+type kubeClient struct {
+	endpoint string
+	client   *http.Client
+}
+
+// privateEndpointOnly reports whether a cluster's API server only has a
+// private IP endpoint, which isn't reachable from outside its VPC
+func (c gkeCluster) privateEndpointOnly() bool {
+	if c.PrivateClusterConfig.EnablePrivateEndpoint {
+		return true
+	}
+	ip := c.ControlPlaneEndpointsConfig.IPEndpointsConfig
+	return ip != nil && ip.EnablePublicEndpoint != nil && !*ip.EnablePublicEndpoint
+}
+
+// clusterAPIEndpoint returns the host to reach a cluster's API server at, and
+// whether it serves the cluster's own CA certificate. Clusters with only a
+// private IP endpoint are reached through their DNS-based endpoint when it
+// allows external traffic, which serves a publicly trusted certificate.
+func clusterAPIEndpoint(cluster gkeCluster) (string, bool, error) {
+	if cluster.privateEndpointOnly() {
+		dns := cluster.ControlPlaneEndpointsConfig.DNSEndpointConfig
+		if dns.Endpoint != "" && dns.AllowExternalTraffic {
+			return dns.Endpoint, false, nil
+		}
+		return "", false, fmt.Errorf("cluster %s only has a private API server endpoint, which isn't reachable directly; enable external traffic on its DNS-based endpoint to reach it", cluster.Name)
+	}
+
+	if cluster.Endpoint == "" {
+		return "", false, fmt.Errorf("cluster %s has no API server endpoint", cluster.Name)
+	}
+	return cluster.Endpoint, true, nil
+}
+
+// newKubeClientForCluster looks up the cluster endpoint and CA certificate via
+// the Container API and returns a client that authenticates to the Kubernetes
+// API server with the OAuth access token
+func newKubeClientForCluster(ctx context.Context, authHandler *auth.OAuthHandler, projectID, location, clusterName string) (*kubeClient, error) {
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting authenticated client: %w", err)
+	}
+
+	cluster, err := cachedCluster(ctx, client, projectID, location, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	host, useClusterCA, err := clusterAPIEndpoint(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	// A nil pool trusts the system roots
+	var pool *x509.CertPool
+	if useClusterCA {
+		caCert, err := base64.StdEncoding.DecodeString(cluster.MasterAuth.ClusterCaCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding cluster CA certificate: %w", err)
+		}
+
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("cluster %s returned an invalid CA certificate", clusterName)
+		}
+	}
+
+	ts, err := authHandler.TokenSource(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting token source: %w", err)
+	}
+
+	return &kubeClient{
+		endpoint: "https://" + host,
+		client: &http.Client{
+			Transport: &oauth2.Transport{
+				Source: ts,
+				Base: &http.Transport{
+					TLSClientConfig: &tls.Config{RootCAs: pool},
+				},
+			},
+		},
+	}, nil
+}
+
+// kubeAPIError is returned when the Kubernetes API responds with a non-success status
+type kubeAPIError struct {
+	StatusCode int
+	Status     string
+	Message    string
+}
+
+func (e *kubeAPIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("error from Kubernetes API: %s: %s", e.Status, e.Message)
+	}
+	return fmt.Sprintf("error from Kubernetes API: %s", e.Status)
+}
+
+// isKubeNotFound reports whether err is a Kubernetes API 404
+func isKubeNotFound(err error) bool {
+	var apiErr *kubeAPIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// get fetches a Kubernetes API path and decodes the JSON response into out
+func (k *kubeClient) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	return k.do(ctx, "GET", path, query, "", nil, out)
+}
+
+// do sends a request to the Kubernetes API. A non-nil body is encoded as JSON
+// and sent with the given content type. The response is decoded as JSON into
+// out, unless out is a *string in which case the raw body is stored.
+func (k *kubeClient) do(ctx context.Context, method, path string, query url.Values, contentType string, body interface{}, out interface{}) error {
+	apiURL := k.endpoint + path
+	if len(query) > 0 {
+		apiURL += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		bodyJSON, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error marshaling request body: %w", err)
+		}
+		reqBody = bytes.NewReader(bodyJSON)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, apiURL, reqBody)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := doWithRetry(k.client, req)
+	if err != nil {
+		return fmt.Errorf("error making request to Kubernetes API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var status struct {
+			Message string `json:"message"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&status)
+		return &kubeAPIError{StatusCode: resp.StatusCode, Status: resp.Status, Message: status.Message}
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	// Plain text endpoints such as pod logs are read as-is
+	if text, ok := out.(*string); ok {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("error reading response: %w", err)
+		}
+		*text = string(body)
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("error parsing response: %w", err)
+	}
+
+	return nil
+}
+
+// kubeObjectMeta is the subset of Kubernetes object metadata used by the tools
+type kubeObjectMeta struct {
+	Name              string            `json:"name"`
+	Namespace         string            `json:"namespace"`
+	UID               string            `json:"uid"`
+	Labels            map[string]string `json:"labels"`
+	Annotations       map[string]string `json:"annotations"`
+	CreationTimestamp string            `json:"creationTimestamp"`
+	DeletionTimestamp string            `json:"deletionTimestamp"`
+	Finalizers        []string          `json:"finalizers"`
+	OwnerReferences   []struct {
+		Kind string `json:"kind"`
+		Name string `json:"name"`
+	} `json:"ownerReferences"`
+}
+
+// kubePod is the subset of a core/v1 Pod used by the tools
+type kubePod struct {
+	Metadata kubeObjectMeta `json:"metadata"`
+	Spec     struct {
+		NodeName                      string `json:"nodeName"`
+		TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds"`
+		Volumes                       []struct {
+			Name                  string `json:"name"`
+			PersistentVolumeClaim *struct {
+				ClaimName string `json:"claimName"`
+			} `json:"persistentVolumeClaim"`
+		} `json:"volumes"`
+	} `json:"spec"`
+	Status struct {
+		Phase      string `json:"phase"`
+		Reason     string `json:"reason"`
+		Message    string `json:"message"`
+		Conditions []struct {
+			Type   string `json:"type"`
+			Status string `json:"status"`
+		} `json:"conditions"`
+		ContainerStatuses []kubeContainerStatus `json:"containerStatuses"`
+	} `json:"status"`
+}
+
+// kubeContainerStatus is the subset of a core/v1 ContainerStatus used by the tools
+type kubeContainerStatus struct {
+	Name         string `json:"name"`
+	Image        string `json:"image"`
+	Ready        bool   `json:"ready"`
+	RestartCount int32  `json:"restartCount"`
+	State        struct {
+		Running *struct {
+			StartedAt string `json:"startedAt"`
+		} `json:"running"`
+		Waiting *struct {
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		} `json:"waiting"`
+		Terminated *struct {
+			Reason   string `json:"reason"`
+			Message  string `json:"message"`
+			ExitCode int32  `json:"exitCode"`
+		} `json:"terminated"`
+	} `json:"state"`
+	LastState struct {
+		Terminated *struct {
+			Reason     string `json:"reason"`
+			Message    string `json:"message"`
+			ExitCode   int32  `json:"exitCode"`
+			FinishedAt string `json:"finishedAt"`
+		} `json:"terminated"`
+	} `json:"lastState"`
+}
+
+// kubeNode is the subset of a core/v1 Node used by the tools
+type kubeNode struct {
+	Metadata kubeObjectMeta `json:"metadata"`
+	Status   struct {
+		Conditions []struct {
+			Type    string `json:"type"`
+			Status  string `json:"status"`
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		} `json:"conditions"`
+	} `json:"status"`
+}
+
+// kubeDeployment is the subset of an apps/v1 Deployment used by the tools
+type kubeDeployment struct {
+	Metadata kubeObjectMeta `json:"metadata"`
+	Spec     struct {
+		Replicas *int32 `json:"replicas"`
+	} `json:"spec"`
+	Status struct {
+		Replicas            int32 `json:"replicas"`
+		ReadyReplicas       int32 `json:"readyReplicas"`
+		AvailableReplicas   int32 `json:"availableReplicas"`
+		UpdatedReplicas     int32 `json:"updatedReplicas"`
+		UnavailableReplicas int32 `json:"unavailableReplicas"`
+	} `json:"status"`
+}
+
+// desiredReplicas returns the requested replica count, which defaults to 1
+func (d kubeDeployment) desiredReplicas() int32 {
+	if d.Spec.Replicas == nil {
+		return 1
+	}
+	return *d.Spec.Replicas
+}
+
+// kubeDaemonSet is the subset of an apps/v1 DaemonSet used by the tools
+type kubeDaemonSet struct {
+	Metadata kubeObjectMeta `json:"metadata"`
+	Status   struct {
+		DesiredNumberScheduled int32 `json:"desiredNumberScheduled"`
+		CurrentNumberScheduled int32 `json:"currentNumberScheduled"`
+		NumberReady            int32 `json:"numberReady"`
+		NumberAvailable        int32 `json:"numberAvailable"`
+		NumberUnavailable      int32 `json:"numberUnavailable"`
+		NumberMisscheduled     int32 `json:"numberMisscheduled"`
+	} `json:"status"`
+}
+
+// kubeEndpointAddress is an address in a core/v1 Endpoints subset
+type kubeEndpointAddress struct {
+	IP        string `json:"ip"`
+	TargetRef *struct {
+		Kind      string `json:"kind"`
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+		UID       string `json:"uid"`
+	} `json:"targetRef"`
+}
+
+// kubeEndpoints is the subset of a core/v1 Endpoints used by the tools
+type kubeEndpoints struct {
+	Metadata kubeObjectMeta `json:"metadata"`
+	Subsets  []struct {
+		Addresses         []kubeEndpointAddress `json:"addresses"`
+		NotReadyAddresses []kubeEndpointAddress `json:"notReadyAddresses"`
+	} `json:"subsets"`
+}
+
+// kubePodDisruptionBudget is the subset of a policy/v1 PodDisruptionBudget
+// used by the tools
+type kubePodDisruptionBudget struct {
+	Metadata kubeObjectMeta `json:"metadata"`
+	Spec     struct {
+		// MinAvailable and MaxUnavailable are either a count or a percentage
+		MinAvailable   interface{} `json:"minAvailable"`
+		MaxUnavailable interface{} `json:"maxUnavailable"`
+		Selector       *struct {
+			MatchLabels map[string]string `json:"matchLabels"`
+		} `json:"selector"`
+	} `json:"spec"`
+	Status struct {
+		CurrentHealthy     int32 `json:"currentHealthy"`
+		DesiredHealthy     int32 `json:"desiredHealthy"`
+		ExpectedPods       int32 `json:"expectedPods"`
+		DisruptionsAllowed int32 `json:"disruptionsAllowed"`
+	} `json:"status"`
+}
+
+// isReady reports whether the node's Ready condition is True
+func (n kubeNode) isReady() bool {
+	for _, cond := range n.Status.Conditions {
+		if cond.Type == "Ready" {
+			return cond.Status == "True"
+		}
+	}
+	return false
+}
+
+// isReady reports whether the pod's Ready condition is True
+func (p kubePod) isReady() bool {
+	for _, cond := range p.Status.Conditions {
+		if cond.Type == "Ready" {
+			return cond.Status == "True"
+		}
+	}
+	return false
+}
+
+// ownerKind returns the kind of the pod's first owner, or an empty string
+func (p kubePod) ownerKind() string {
+	if len(p.Metadata.OwnerReferences) == 0 {
+		return ""
+	}
+	return p.Metadata.OwnerReferences[0].Kind
+}
+
+// podsPath returns the API path for listing pods, across all namespaces when
+// namespace is empty
+func podsPath(namespace string) string {
+	if namespace == "" {
+		return "/api/v1/pods"
+	}
+	return fmt.Sprintf("/api/v1/namespaces/%s/pods", namespace)
+}
+
+// quantitySuffixes maps Kubernetes resource quantity suffixes to multipliers
+var quantitySuffixes = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"Ki", 1 << 10}, {"Mi", 1 << 20}, {"Gi", 1 << 30}, {"Ti", 1 << 40}, {"Pi", 1 << 50}, {"Ei", 1 << 60},
+	{"n", 1e-9}, {"u", 1e-6}, {"m", 1e-3},
+	{"k", 1e3}, {"M", 1e6}, {"G", 1e9}, {"T", 1e12}, {"P", 1e15}, {"E", 1e18},
+}
+
+// parseQuantity converts a Kubernetes resource quantity such as "500m" or
+// "2Gi" to a plain number
+func parseQuantity(quantity string) (float64, error) {
+	quantity = strings.TrimSpace(quantity)
+	if quantity == "" {
+		return 0, fmt.Errorf("empty quantity")
+	}
+
+	multiplier := 1.0
+	for _, s := range quantitySuffixes {
+		if strings.HasSuffix(quantity, s.suffix) {
+			quantity = strings.TrimSuffix(quantity, s.suffix)
+			multiplier = s.multiplier
+			break
+		}
+	}
+
+	value, err := strconv.ParseFloat(quantity, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quantity %q: %w", quantity, err)
+	}
+
+	return value * multiplier, nil
+}
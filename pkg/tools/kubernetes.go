@@ -5,6 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/ivanvanderbyl/operable/pkg/auth"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -26,6 +31,13 @@ func registerKubernetesTools(s *server.MCPServer, authHandler *auth.OAuthHandler
 		mcp.WithString("location",
 			mcp.Description("The location to list clusters from (optional, if not provided, all locations will be queried)"),
 		),
+		mcp.WithString("locations",
+			mcp.Description("Comma-separated locations to list clusters from concurrently (e.g., \"us-central1,europe-west1\"). A location that can't be listed is reported alongside the clusters found in the others, instead of failing the call. Can't be combined with location."),
+		),
+		mcp.WithString("label_selector",
+			mcp.Description("Only include clusters whose resource labels match all of these comma-separated key=value pairs (e.g., \"team=payments,env=prod\")"),
+		),
+		withOutputFormat(),
 	)
 
 	listClustersHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -49,6 +61,12 @@ func registerKubernetesTools(s *server.MCPServer, authHandler *auth.OAuthHandler
 			mcp.Required(),
 			mcp.Description("The name of the cluster"),
 		),
+		mcp.WithBoolean("upgrade_readiness",
+			mcp.Description("Also check what could block an upgrade (active maintenance exclusions, node pools without surge and blocking PodDisruptionBudgets) and report a go/no-go (default: false)"),
+		),
+		mcp.WithBoolean("force_refresh",
+			mcp.Description("Bypass the short-lived cluster cache and fetch fresh data (default: false)"),
+		),
 	)
 
 	getClusterInfoHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -72,6 +90,9 @@ func registerKubernetesTools(s *server.MCPServer, authHandler *auth.OAuthHandler
 			mcp.Required(),
 			mcp.Description("The name of the cluster"),
 		),
+		mcp.WithBoolean("refresh",
+			mcp.Description("Bypass the short-lived node pool cache and fetch fresh data (default: false)"),
+		),
 	)
 
 	listNodePoolsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -80,128 +101,340 @@ func registerKubernetesTools(s *server.MCPServer, authHandler *auth.OAuthHandler
 
 	AddToolSafe(s, listNodePools, listNodePoolsHandler)
 
+	// Register list pods tool
+	listPods := mcp.NewTool("list_pods",
+		mcp.WithDescription("Lists pods in a GKE cluster with their phase, restart count, node and age, unhealthy pods first. For pods that aren't running or have failing containers, shows the reason (such as CrashLoopBackOff) and the last container termination message to triage crashloops."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The location of the cluster"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The name of the cluster"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("The Kubernetes namespace (default: all namespaces)"),
+		),
+		mcp.WithString("label_selector",
+			mcp.Description("A Kubernetes label selector to filter pods, such as app=web,tier!=cache (optional)"),
+		),
+	)
+
+	listPodsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleListPods(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, listPods, listPodsHandler)
+
+	// Register list versions in channel tool
+	listVersionsInChannel := mcp.NewTool("list_versions_in_channel",
+		mcp.WithDescription("Lists the default and valid GKE versions per release channel, to help plan upgrades"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The location to get the server config for"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Description("A cluster to compare against the available versions (optional)"),
+		),
+	)
+
+	listVersionsInChannelHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleListVersionsInChannel(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, listVersionsInChannel, listVersionsInChannelHandler)
+
+	// Register tools backed by the cluster's Kubernetes API
+	if err := registerPodTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerDescribePodTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerNamespaceTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerNodeTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerNodeInfoTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerFleetTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerSecurityBulletinTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerAddonTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerDisruptionBudgetTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerControlPlaneTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerImagePullTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerEndpointTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerConfigRefTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerEnvVarTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerScalingTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerSchedulingTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerRestartTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerPodSecurityTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerAdmissionWebhookTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerClusterEventTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerEvictionTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerScaleDownTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerWorkloadMetadataTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerConfigConnectorTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerDNSTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerClusterOperationTools(s, authHandler); err != nil {
+		return err
+	}
+
 	return nil
 }
 
-// handleListClusters handles the list_clusters tool request
-func handleListClusters(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
-	// Extract parameters
-	projectID, ok := request.Params.Arguments["project_id"].(string)
-	if !ok || projectID == "" {
-		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
-	}
+// gkeClusterSummary is the subset of a GKE cluster shown by list_clusters
+type gkeClusterSummary struct {
+	Name             string            `json:"name"`
+	Description      string            `json:"description"`
+	Location         string            `json:"location"`
+	Status           string            `json:"status"`
+	NodeCount        int               `json:"currentNodeCount"`
+	MasterVersion    string            `json:"currentMasterVersion"`
+	NodeVersion      string            `json:"currentNodeVersion"`
+	Network          string            `json:"network"`
+	Subnetwork       string            `json:"subnetwork"`
+	ClusterIpv4Cidr  string            `json:"clusterIpv4Cidr"`
+	ServicesIpv4Cidr string            `json:"servicesIpv4Cidr"`
+	Endpoint         string            `json:"endpoint"`
+	CreateTime       string            `json:"createTime"`
+	ResourceLabels   map[string]string `json:"resourceLabels"`
+}
 
-	location, _ := request.Params.Arguments["location"].(string)
+// listClustersView is the data rendered by the list_clusters template
+type listClustersView struct {
+	ProjectID      string              `json:"projectId"`
+	Location       string              `json:"location,omitempty"`
+	Locations      []string            `json:"locations,omitempty"`
+	LabelSelector  string              `json:"labelSelector,omitempty"`
+	Clusters       []gkeClusterSummary `json:"clusters"`
+	LocationErrors []locationError     `json:"locationErrors,omitempty"`
+}
 
-	// Get HTTP client with authentication
-	client, err := authHandler.GetClient(ctx)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+// locationError is a location list_clusters couldn't list
+type locationError struct {
+	Location string `json:"location"`
+	Error    string `json:"error"`
+}
+
+// listClustersConcurrency bounds how many locations list_clusters queries at
+// once
+const listClustersConcurrency = 8
+
+// parseLabelSelector parses comma-separated key=value pairs into the labels
+// they require
+func parseLabelSelector(selector string) (map[string]string, error) {
+	required := make(map[string]string)
+	for _, pair := range strings.Split(selector, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid label selector %q: expected key=value pairs", pair)
+		}
+		required[key] = strings.TrimSpace(value)
+	}
+	return required, nil
+}
+
+// matchesLabels reports whether labels contain every required key and value
+func matchesLabels(labels, required map[string]string) bool {
+	for key, value := range required {
+		if actual, ok := labels[key]; !ok || actual != value {
+			return false
+		}
 	}
+	return true
+}
 
-	// Construct URL for the Container API
-	var apiURL string
+// fetchClusters lists the clusters in a location, or in all locations when
+// location is empty
+func fetchClusters(ctx context.Context, client *http.Client, projectID, location string) ([]gkeClusterSummary, error) {
 	if location == "" {
-		apiURL = fmt.Sprintf("%s/projects/%s/locations/-/clusters", gcpContainerBaseURL, projectID)
-	} else {
-		apiURL = fmt.Sprintf("%s/projects/%s/locations/%s/clusters", gcpContainerBaseURL, projectID, location)
+		location = "-"
 	}
+	apiURL := fmt.Sprintf("%s/projects/%s/locations/%s/clusters", gcpContainerBaseURL, projectID, location)
 
-	// Make the API request
 	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error creating request: %v", err)), nil
+		return nil, fmt.Errorf("error creating request: %w", err)
 	}
 
-	resp, err := client.Do(req)
+	resp, err := doWithRetry(client, req)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error making request to Container API: %v", err)), nil
+		return nil, fmt.Errorf("error making request to Container API: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return mcp.NewToolResultError(fmt.Sprintf("Error from Container API: %s", resp.Status)), nil
+		return nil, fmt.Errorf("error from Container API: %s", resp.Status)
 	}
 
-	// Parse the response
 	var response struct {
-		Clusters []struct {
-			Name             string `json:"name"`
-			Description      string `json:"description"`
-			Location         string `json:"location"`
-			Status           string `json:"status"`
-			NodeCount        int    `json:"currentNodeCount"`
-			MasterVersion    string `json:"currentMasterVersion"`
-			NodeVersion      string `json:"currentNodeVersion"`
-			Network          string `json:"network"`
-			Subnetwork       string `json:"subnetwork"`
-			ClusterIpv4Cidr  string `json:"clusterIpv4Cidr"`
-			ServicesIpv4Cidr string `json:"servicesIpv4Cidr"`
-			Endpoint         string `json:"endpoint"`
-			CreateTime       string `json:"createTime"`
-		} `json:"clusters"`
+		Clusters []gkeClusterSummary `json:"clusters"`
 	}
-
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error parsing response: %v", err)), nil
+		return nil, fmt.Errorf("error parsing response: %w", err)
 	}
 
-	// Format the results
-	var result string
-	if len(response.Clusters) == 0 {
-		result = fmt.Sprintf("No GKE clusters found in project %s", projectID)
-		if location != "" {
-			result += fmt.Sprintf(" in location %s", location)
-		}
-		result += "."
-	} else {
-		result = fmt.Sprintf("Found %d GKE clusters in project %s", len(response.Clusters), projectID)
-		if location != "" {
-			result += fmt.Sprintf(" in location %s", location)
-		}
-		result += ":\n\n"
-
-		for i, cluster := range response.Clusters {
-			result += fmt.Sprintf("### %d. Cluster: %s\n", i+1, cluster.Name)
-			result += fmt.Sprintf("- **Location**: %s\n", cluster.Location)
-			result += fmt.Sprintf("- **Status**: %s\n", cluster.Status)
-			result += fmt.Sprintf("- **Node Count**: %d\n", cluster.NodeCount)
-			result += fmt.Sprintf("- **Kubernetes Version**: %s (master) / %s (nodes)\n",
-				cluster.MasterVersion, cluster.NodeVersion)
-			result += fmt.Sprintf("- **Endpoint**: %s\n", cluster.Endpoint)
-			result += fmt.Sprintf("- **Network**: %s\n", cluster.Network)
-			result += fmt.Sprintf("- **Subnetwork**: %s\n", cluster.Subnetwork)
-			result += fmt.Sprintf("- **Pod CIDR**: %s\n", cluster.ClusterIpv4Cidr)
-			result += fmt.Sprintf("- **Service CIDR**: %s\n", cluster.ServicesIpv4Cidr)
-			result += fmt.Sprintf("- **Created**: %s\n", cluster.CreateTime)
-
-			if cluster.Description != "" {
-				result += fmt.Sprintf("- **Description**: %s\n", cluster.Description)
-			}
+	return response.Clusters, nil
+}
 
-			result += "\n"
+// fetchClustersInLocations lists the clusters in each location concurrently,
+// with at most listClustersConcurrency requests in flight. Clusters are
+// returned in the order of their locations, and a location that fails is
+// returned as an error without affecting the others.
+func fetchClustersInLocations(ctx context.Context, client *http.Client, projectID string, locations []string) ([]gkeClusterSummary, []locationError) {
+	clusters := make([][]gkeClusterSummary, len(locations))
+	errs := make([]error, len(locations))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < listClustersConcurrency && w < len(locations); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				clusters[i], errs[i] = fetchClusters(ctx, client, projectID, locations[i])
+			}
+		}()
+	}
+	for i := range locations {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var merged []gkeClusterSummary
+	var failed []locationError
+	for i, location := range locations {
+		if errs[i] != nil {
+			failed = append(failed, locationError{Location: location, Error: errs[i].Error()})
+			continue
 		}
+		merged = append(merged, clusters[i]...)
 	}
+	return merged, failed
+}
 
-	return mcp.NewToolResultText(result), nil
+// parseLocations parses a comma-separated list of locations, dropping blanks
+// and duplicates
+func parseLocations(s string) []string {
+	var locations []string
+	for _, location := range strings.Split(s, ",") {
+		location = strings.TrimSpace(location)
+		if location != "" && !containsString(locations, location) {
+			locations = append(locations, location)
+		}
+	}
+	return locations
 }
 
-// handleGetClusterInfo handles the get_cluster_info tool request
-func handleGetClusterInfo(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+// handleListClusters handles the list_clusters tool request
+func handleListClusters(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
 	// Extract parameters
 	projectID, ok := request.Params.Arguments["project_id"].(string)
 	if !ok || projectID == "" {
 		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
 	}
 
-	location, ok := request.Params.Arguments["location"].(string)
-	if !ok || location == "" {
-		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	location, _ := request.Params.Arguments["location"].(string)
+
+	locationsArg, _ := request.Params.Arguments["locations"].(string)
+	locations := parseLocations(locationsArg)
+	if location != "" && len(locations) > 0 {
+		return mcp.NewToolResultError("location and locations can't both be provided"), nil
 	}
 
-	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
-	if !ok || clusterName == "" {
-		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	labelSelector, _ := request.Params.Arguments["label_selector"].(string)
+	requiredLabels, err := parseLabelSelector(labelSelector)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	format, err := outputFormat(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	// Get HTTP client with authentication
@@ -210,84 +443,105 @@ func handleGetClusterInfo(ctx context.Context, request mcp.CallToolRequest, auth
 		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
 	}
 
-	// Construct URL for the Container API
-	apiURL := fmt.Sprintf("%s/projects/%s/locations/%s/clusters/%s",
-		gcpContainerBaseURL, projectID, location, clusterName)
+	var allClusters []gkeClusterSummary
+	var locationErrors []locationError
+	if len(locations) > 0 {
+		allClusters, locationErrors = fetchClustersInLocations(ctx, client, projectID, locations)
+		if len(locationErrors) == len(locations) {
+			return mcp.NewToolResultError(fmt.Sprintf("Error listing clusters in every location, first error: %s: %s", locationErrors[0].Location, locationErrors[0].Error)), nil
+		}
+	} else {
+		allClusters, err = fetchClusters(ctx, client, projectID, location)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error listing clusters: %v", err)), nil
+		}
+	}
 
-	// Make the API request
-	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error creating request: %v", err)), nil
+	// The Container API can't filter by label, so filter the clusters here
+	clusters := allClusters
+	if len(requiredLabels) > 0 {
+		clusters = nil
+		for _, cluster := range allClusters {
+			if matchesLabels(cluster.ResourceLabels, requiredLabels) {
+				clusters = append(clusters, cluster)
+			}
+		}
 	}
 
-	resp, err := client.Do(req)
+	// Format the results
+	view := listClustersView{
+		ProjectID:      projectID,
+		Location:       location,
+		Locations:      locations,
+		LabelSelector:  labelSelector,
+		Clusters:       clusters,
+		LocationErrors: locationErrors,
+	}
+	if format == outputJSON {
+		return jsonResult(view)
+	}
+
+	result, err := renderOutput("list_clusters", view)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error making request to Container API: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Error formatting clusters: %v", err)), nil
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return mcp.NewToolResultError(fmt.Sprintf("Error from Container API: %s", resp.Status)), nil
+	return mcp.NewToolResultText(result), nil
+}
+
+// handleGetClusterInfo handles the get_cluster_info tool request
+func handleGetClusterInfo(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
 	}
 
-	// Parse the response
-	var cluster struct {
-		Name              string `json:"name"`
-		Description       string `json:"description"`
-		Location          string `json:"location"`
-		Status            string `json:"status"`
-		NodeCount         int    `json:"currentNodeCount"`
-		MasterVersion     string `json:"currentMasterVersion"`
-		NodeVersion       string `json:"currentNodeVersion"`
-		Network           string `json:"network"`
-		Subnetwork        string `json:"subnetwork"`
-		ClusterIpv4Cidr   string `json:"clusterIpv4Cidr"`
-		ServicesIpv4Cidr  string `json:"servicesIpv4Cidr"`
-		Endpoint          string `json:"endpoint"`
-		CreateTime        string `json:"createTime"`
-		MaintenancePolicy struct {
-			Window struct {
-				DailyMaintenanceWindow struct {
-					StartTime string `json:"startTime"`
-					Duration  string `json:"duration"`
-				} `json:"dailyMaintenanceWindow"`
-			} `json:"maintenanceWindow"`
-		} `json:"maintenancePolicy"`
-		NetworkConfig struct {
-			Network    string `json:"network"`
-			Subnetwork string `json:"subnetwork"`
-		} `json:"networkConfig"`
-		AddonsConfig struct {
-			HttpLoadBalancing struct {
-				Disabled bool `json:"disabled"`
-			} `json:"httpLoadBalancing"`
-			HorizontalPodAutoscaling struct {
-				Disabled bool `json:"disabled"`
-			} `json:"horizontalPodAutoscaling"`
-			KubernetesDashboard struct {
-				Disabled bool `json:"disabled"`
-			} `json:"kubernetesDashboard"`
-			NetworkPolicyConfig struct {
-				Disabled bool `json:"disabled"`
-			} `json:"networkPolicyConfig"`
-		} `json:"addonsConfig"`
-		Locations      []string          `json:"locations"`
-		ResourceLabels map[string]string `json:"resourceLabels"`
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&cluster); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error parsing response: %v", err)), nil
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	includeUpgradeReadiness, _ := request.Params.Arguments["upgrade_readiness"].(bool)
+	forceRefresh, _ := request.Params.Arguments["force_refresh"].(bool)
+
+	// Serve from the cluster cache unless a refresh was requested
+	cacheKey := clusterCacheKey(projectID, location, clusterName)
+	cluster, cacheAge, cached := clusterCache.Get(cacheKey)
+	if forceRefresh || !cached {
+		// Get HTTP client with authentication
+		client, err := authHandler.GetClient(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+		}
+
+		cluster, err = fetchCluster(ctx, client, projectID, location, clusterName)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error getting cluster: %v", err)), nil
+		}
+
+		clusterCache.Set(cacheKey, cluster)
+		cached = false
 	}
 
 	// Format the results
 	result := fmt.Sprintf("# GKE Cluster: %s\n\n", cluster.Name)
 
+	if cached {
+		result += fmt.Sprintf("_(cached, fetched %s ago; use force_refresh to fetch fresh data)_\n\n", cacheAge.Round(time.Second))
+	}
+
 	result += "## Basic Information\n\n"
 	result += fmt.Sprintf("- **Location**: %s\n", cluster.Location)
 	result += fmt.Sprintf("- **Status**: %s\n", cluster.Status)
-	result += fmt.Sprintf("- **Node Count**: %d\n", cluster.NodeCount)
+	result += fmt.Sprintf("- **Node Count**: %d\n", cluster.CurrentNodeCount)
 	result += fmt.Sprintf("- **Kubernetes Version**: %s (master) / %s (nodes)\n",
-		cluster.MasterVersion, cluster.NodeVersion)
+		cluster.CurrentMasterVersion, cluster.CurrentNodeVersion)
 	result += fmt.Sprintf("- **Endpoint**: %s\n", cluster.Endpoint)
 	result += fmt.Sprintf("- **Created**: %s\n", cluster.CreateTime)
 
@@ -333,33 +587,183 @@ func handleGetClusterInfo(ctx context.Context, request mcp.CallToolRequest, auth
 			cluster.MaintenancePolicy.Window.DailyMaintenanceWindow.Duration)
 	}
 
+	if includeUpgradeReadiness {
+		result += formatUpgradeReadiness(checkUpgradeReadiness(ctx, authHandler, projectID, location, clusterName,
+			cluster.MaintenancePolicy.Window.MaintenanceExclusions))
+	}
+
 	return mcp.NewToolResultText(result), nil
 }
 
-// handleListNodePools handles the list_node_pools tool request
-func handleListNodePools(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
-	// Extract parameters
-	projectID, ok := request.Params.Arguments["project_id"].(string)
-	if !ok || projectID == "" {
-		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+// gkeCluster holds the cluster fields used by the tools
+type gkeCluster struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Location    string `json:"location"`
+	Status      string `json:"status"`
+	Endpoint    string `json:"endpoint"`
+	CreateTime  string `json:"createTime"`
+	MasterAuth  struct {
+		ClusterCaCertificate string `json:"clusterCaCertificate"`
+	} `json:"masterAuth"`
+	CurrentMasterVersion string `json:"currentMasterVersion"`
+	CurrentNodeVersion   string `json:"currentNodeVersion"`
+	CurrentNodeCount     int    `json:"currentNodeCount"`
+	Network              string `json:"network"`
+	Subnetwork           string `json:"subnetwork"`
+	ClusterIpv4Cidr      string `json:"clusterIpv4Cidr"`
+	ServicesIpv4Cidr     string `json:"servicesIpv4Cidr"`
+	MaintenancePolicy    struct {
+		Window struct {
+			DailyMaintenanceWindow struct {
+				StartTime string `json:"startTime"`
+				Duration  string `json:"duration"`
+			} `json:"dailyMaintenanceWindow"`
+			MaintenanceExclusions map[string]gkeTimeWindow `json:"maintenanceExclusions"`
+		} `json:"window"`
+	} `json:"maintenancePolicy"`
+	AddonsConfig struct {
+		HttpLoadBalancing struct {
+			Disabled bool `json:"disabled"`
+		} `json:"httpLoadBalancing"`
+		HorizontalPodAutoscaling struct {
+			Disabled bool `json:"disabled"`
+		} `json:"horizontalPodAutoscaling"`
+		KubernetesDashboard struct {
+			Disabled bool `json:"disabled"`
+		} `json:"kubernetesDashboard"`
+		NetworkPolicyConfig struct {
+			Disabled bool `json:"disabled"`
+		} `json:"networkPolicyConfig"`
+	} `json:"addonsConfig"`
+	Locations      []string          `json:"locations"`
+	ResourceLabels map[string]string `json:"resourceLabels"`
+	ReleaseChannel struct {
+		Channel string `json:"channel"`
+	} `json:"releaseChannel"`
+	Fleet struct {
+		Project       string `json:"project"`
+		Membership    string `json:"membership"`
+		PreRegistered bool   `json:"preRegistered"`
+	} `json:"fleet"`
+	CostManagementConfig struct {
+		Enabled bool `json:"enabled"`
+	} `json:"costManagementConfig"`
+	PrivateClusterConfig struct {
+		EnablePrivateEndpoint bool `json:"enablePrivateEndpoint"`
+	} `json:"privateClusterConfig"`
+	WorkloadIdentityConfig struct {
+		WorkloadPool string `json:"workloadPool"`
+	} `json:"workloadIdentityConfig"`
+	Autopilot struct {
+		Enabled bool `json:"enabled"`
+	} `json:"autopilot"`
+	ControlPlaneEndpointsConfig struct {
+		DNSEndpointConfig struct {
+			Endpoint             string `json:"endpoint"`
+			AllowExternalTraffic bool   `json:"allowExternalTraffic"`
+		} `json:"dnsEndpointConfig"`
+		IPEndpointsConfig *struct {
+			EnablePublicEndpoint *bool `json:"enablePublicEndpoint"`
+		} `json:"ipEndpointsConfig"`
+	} `json:"controlPlaneEndpointsConfig"`
+}
+
+// fetchCluster gets a cluster from the Container API
+func fetchCluster(ctx context.Context, client *http.Client, projectID, location, clusterName string) (gkeCluster, error) {
+	var cluster gkeCluster
+
+	apiURL := fmt.Sprintf("%s/projects/%s/locations/%s/clusters/%s",
+		gcpContainerBaseURL, projectID, location, clusterName)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return cluster, fmt.Errorf("error creating request: %w", err)
 	}
 
-	location, ok := request.Params.Arguments["location"].(string)
-	if !ok || location == "" {
-		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	resp, err := doWithRetry(client, req)
+	if err != nil {
+		return cluster, fmt.Errorf("error making request to Container API: %w", err)
 	}
+	defer resp.Body.Close()
 
-	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
-	if !ok || clusterName == "" {
-		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	if resp.StatusCode != http.StatusOK {
+		return cluster, fmt.Errorf("error from Container API: %s", resp.Status)
 	}
 
-	// Get HTTP client with authentication
-	client, err := authHandler.GetClient(ctx)
+	if err := json.NewDecoder(resp.Body).Decode(&cluster); err != nil {
+		return cluster, fmt.Errorf("error parsing response: %w", err)
+	}
+
+	return cluster, nil
+}
+
+// clusterCache holds recently fetched cluster metadata, such as the API server
+// endpoint and CA certificate needed to build Kubernetes API clients
+var clusterCache = newTTLCache[gkeCluster](envDuration("OPERABLE_CLUSTER_CACHE_TTL", 60*time.Second))
+
+// cachedCluster gets a cluster from the cluster cache, fetching and caching it
+// from the Container API on a miss
+func cachedCluster(ctx context.Context, client *http.Client, projectID, location, clusterName string) (gkeCluster, error) {
+	cacheKey := clusterCacheKey(projectID, location, clusterName)
+	if cluster, _, ok := clusterCache.Get(cacheKey); ok {
+		return cluster, nil
+	}
+
+	cluster, err := fetchCluster(ctx, client, projectID, location, clusterName)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+		return cluster, err
 	}
+	clusterCache.Set(cacheKey, cluster)
+	return cluster, nil
+}
+
+// gkeNodePool is a node pool returned by the Container API
+type gkeNodePool struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Config struct {
+		MachineType    string            `json:"machineType"`
+		DiskSizeGb     int               `json:"diskSizeGb"`
+		OauthScopes    []string          `json:"oauthScopes"`
+		ServiceAccount string            `json:"serviceAccount"`
+		Preemptible    bool              `json:"preemptible"`
+		Spot           bool              `json:"spot"`
+		Labels         map[string]string `json:"labels"`
+		Metadata       map[string]string `json:"metadata"`
+		// WorkloadMetadataConfig is unset when the pool doesn't configure
+		// how its pods reach the metadata server
+		WorkloadMetadataConfig *struct {
+			Mode string `json:"mode"`
+		} `json:"workloadMetadataConfig"`
+	} `json:"config"`
+	InitialNodeCount  int      `json:"initialNodeCount"`
+	Locations         []string `json:"locations"`
+	InstanceGroupUrls []string `json:"instanceGroupUrls"`
+	Version           string   `json:"version"`
+	Autoscaling       struct {
+		Enabled      bool `json:"enabled"`
+		MinNodeCount int  `json:"minNodeCount"`
+		MaxNodeCount int  `json:"maxNodeCount"`
+	} `json:"autoscaling"`
+	Management struct {
+		AutoUpgrade bool `json:"autoUpgrade"`
+		AutoRepair  bool `json:"autoRepair"`
+	} `json:"management"`
+	UpgradeSettings *struct {
+		Strategy       string `json:"strategy"`
+		MaxSurge       int    `json:"maxSurge"`
+		MaxUnavailable int    `json:"maxUnavailable"`
+	} `json:"upgradeSettings"`
+}
 
+// nodePoolCache holds recent node pool listings keyed by cluster. No tool
+// changes node pools, so entries only go stale through changes made outside
+// the server, which the short TTL and the refresh parameter cover.
+var nodePoolCache = newTTLCache[[]gkeNodePool](envDuration("OPERABLE_NODE_POOL_CACHE_TTL", 30*time.Second))
+
+// fetchNodePools lists the node pools of a cluster from the Container API
+func fetchNodePools(ctx context.Context, client *http.Client, projectID, location, clusterName string) ([]gkeNodePool, error) {
 	// Construct URL for the Container API
 	apiURL := fmt.Sprintf("%s/projects/%s/locations/%s/clusters/%s/nodePools",
 		gcpContainerBaseURL, projectID, location, clusterName)
@@ -367,60 +771,82 @@ func handleListNodePools(ctx context.Context, request mcp.CallToolRequest, authH
 	// Make the API request
 	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error creating request: %v", err)), nil
+		return nil, fmt.Errorf("error creating request: %w", err)
 	}
 
-	resp, err := client.Do(req)
+	resp, err := doWithRetry(client, req)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error making request to Container API: %v", err)), nil
+		return nil, fmt.Errorf("error making request to Container API: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return mcp.NewToolResultError(fmt.Sprintf("Error from Container API: %s", resp.Status)), nil
+		return nil, fmt.Errorf("error from Container API: %s", resp.Status)
 	}
 
 	// Parse the response
 	var response struct {
-		NodePools []struct {
-			Name   string `json:"name"`
-			Status string `json:"status"`
-			Config struct {
-				MachineType    string            `json:"machineType"`
-				DiskSizeGb     int               `json:"diskSizeGb"`
-				OauthScopes    []string          `json:"oauthScopes"`
-				ServiceAccount string            `json:"serviceAccount"`
-				Preemptible    bool              `json:"preemptible"`
-				Labels         map[string]string `json:"labels"`
-			} `json:"config"`
-			InitialNodeCount  int      `json:"initialNodeCount"`
-			Locations         []string `json:"locations"`
-			InstanceGroupUrls []string `json:"instanceGroupUrls"`
-			Version           string   `json:"version"`
-			Autoscaling       struct {
-				Enabled      bool `json:"enabled"`
-				MinNodeCount int  `json:"minNodeCount"`
-				MaxNodeCount int  `json:"maxNodeCount"`
-			} `json:"autoscaling"`
-			Management struct {
-				AutoUpgrade bool `json:"autoUpgrade"`
-				AutoRepair  bool `json:"autoRepair"`
-			} `json:"management"`
-		} `json:"nodePools"`
+		NodePools []gkeNodePool `json:"nodePools"`
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error parsing response: %v", err)), nil
+		return nil, fmt.Errorf("error parsing response: %w", err)
+	}
+
+	return response.NodePools, nil
+}
+
+// handleListNodePools handles the list_node_pools tool request
+func handleListNodePools(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	refresh, _ := request.Params.Arguments["refresh"].(bool)
+
+	// Serve from the cache unless a refresh was requested
+	cacheKey := clusterCacheKey(projectID, location, clusterName)
+	nodePools, cacheAge, cached := nodePoolCache.Get(cacheKey)
+	if refresh || !cached {
+		// Get HTTP client with authentication
+		client, err := authHandler.GetClient(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+		}
+
+		nodePools, err = fetchNodePools(ctx, client, projectID, location, clusterName)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error listing node pools: %v", err)), nil
+		}
+
+		nodePoolCache.Set(cacheKey, nodePools)
+		cached = false
 	}
 
 	// Format the results
 	var result string
-	if len(response.NodePools) == 0 {
+	if len(nodePools) == 0 {
 		result = fmt.Sprintf("No node pools found in cluster %s in location %s.", clusterName, location)
 	} else {
 		result = fmt.Sprintf("# Node Pools in Cluster %s\n\n", clusterName)
 
-		for i, pool := range response.NodePools {
+		if cached {
+			result += fmt.Sprintf("_(cached %s ago, use refresh to fetch fresh data)_\n\n", cacheAge.Round(time.Second))
+		}
+
+		for i, pool := range nodePools {
 			result += fmt.Sprintf("## %d. Node Pool: %s\n\n", i+1, pool.Name)
 			result += fmt.Sprintf("- **Status**: %s\n", pool.Status)
 			result += fmt.Sprintf("- **Version**: %s\n", pool.Version)
@@ -473,6 +899,394 @@ func handleListNodePools(ctx context.Context, request mcp.CallToolRequest, authH
 	return mcp.NewToolResultText(result), nil
 }
 
+// podHealthy reports whether a pod is healthy: succeeded, or running with
+// every container ready and none waiting to restart
+func podHealthy(pod kubePod) bool {
+	switch pod.Status.Phase {
+	case "Succeeded":
+		return true
+	case "Running":
+		for _, status := range pod.Status.ContainerStatuses {
+			if !status.Ready || status.State.Waiting != nil {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// totalRestarts returns the total restart count of a pod's containers
+func totalRestarts(pod kubePod) int32 {
+	var restarts int32
+	for _, status := range pod.Status.ContainerStatuses {
+		restarts += status.RestartCount
+	}
+	return restarts
+}
+
+// podProblem explains why a pod isn't healthy. A container's waiting or
+// terminated reason, such as CrashLoopBackOff, is more specific than the
+// pod's own reason, so it takes precedence.
+func podProblem(pod kubePod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if waiting := status.State.Waiting; waiting != nil && waiting.Reason != "" {
+			return fmt.Sprintf("%s (container %s)", waiting.Reason, status.Name)
+		}
+		if terminated := status.State.Terminated; terminated != nil && terminated.Reason != "" && terminated.ExitCode != 0 {
+			return fmt.Sprintf("%s, exit code %d (container %s)", terminated.Reason, terminated.ExitCode, status.Name)
+		}
+	}
+	if pod.Status.Reason != "" {
+		return pod.Status.Reason
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if !status.Ready {
+			return fmt.Sprintf("Container %s not ready", status.Name)
+		}
+	}
+	return pod.Status.Phase
+}
+
+// lastTerminationMessage returns the message the first of a pod's containers
+// to have restarted left when it last terminated. Containers that didn't write
+// a termination message are reported by reason and exit code.
+func lastTerminationMessage(pod kubePod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		terminated := status.LastState.Terminated
+		if terminated == nil {
+			continue
+		}
+		message := strings.TrimSpace(terminated.Message)
+		if message == "" {
+			message = fmt.Sprintf("%s, exit code %d", terminated.Reason, terminated.ExitCode)
+		}
+		return fmt.Sprintf("container %s: %s", status.Name, message)
+	}
+	return ""
+}
+
+// formatAge formats how long ago a timestamp was, like kubectl does
+func formatAge(timestamp string, now time.Time) string {
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return "-"
+	}
+	age := now.Sub(t)
+	switch {
+	case age < time.Minute:
+		return fmt.Sprintf("%ds", int(age.Seconds()))
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	case age < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(age.Hours()/24))
+	}
+}
+
+// handleListPods handles the list_pods tool request
+func handleListPods(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+	labelSelector, _ := request.Params.Arguments["label_selector"].(string)
+
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error connecting to cluster: %v", err)), nil
+	}
+
+	query := url.Values{}
+	if labelSelector != "" {
+		query.Set("labelSelector", labelSelector)
+	}
+
+	var podList struct {
+		Items []kubePod `json:"items"`
+	}
+	if err := kube.get(ctx, podsPath(namespace), query, &podList); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing pods: %v", err)), nil
+	}
+
+	// Unhealthy pods first, then by namespace and name
+	pods := podList.Items
+	sort.SliceStable(pods, func(i, j int) bool {
+		if hi, hj := podHealthy(pods[i]), podHealthy(pods[j]); hi != hj {
+			return !hi
+		}
+		if pods[i].Metadata.Namespace != pods[j].Metadata.Namespace {
+			return pods[i].Metadata.Namespace < pods[j].Metadata.Namespace
+		}
+		return pods[i].Metadata.Name < pods[j].Metadata.Name
+	})
+
+	// Format the results
+	scope := "all namespaces"
+	if namespace != "" {
+		scope = "namespace " + namespace
+	}
+	if labelSelector != "" {
+		scope += fmt.Sprintf(" matching %s", labelSelector)
+	}
+
+	if len(pods) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No pods found in %s of cluster %s.", scope, clusterName)), nil
+	}
+
+	var unhealthy []kubePod
+	for _, pod := range pods {
+		if !podHealthy(pod) {
+			unhealthy = append(unhealthy, pod)
+		}
+	}
+
+	result := fmt.Sprintf("# Pods in Cluster %s\n\n", clusterName)
+	result += fmt.Sprintf("Found %d pods in %s, %d of them unhealthy.\n\n", len(pods), scope, len(unhealthy))
+
+	now := time.Now()
+	result += "| Namespace | Name | Phase | Restarts | Node | Age |\n"
+	result += "| --------- | ---- | ----- | -------- | ---- | --- |\n"
+	for _, pod := range pods {
+		phase := pod.Status.Phase
+		if !podHealthy(pod) {
+			phase = fmt.Sprintf("**%s**", phase)
+		}
+		result += fmt.Sprintf("| %s | %s | %s | %d | %s | %s |\n",
+			pod.Metadata.Namespace, pod.Metadata.Name, phase, totalRestarts(pod),
+			valueOrDash(pod.Spec.NodeName), formatAge(pod.Metadata.CreationTimestamp, now))
+	}
+	result += "\n"
+
+	if len(unhealthy) > 0 {
+		result += "## Unhealthy Pods\n\n"
+		for _, pod := range unhealthy {
+			result += fmt.Sprintf("### %s/%s\n\n", pod.Metadata.Namespace, pod.Metadata.Name)
+			result += fmt.Sprintf("- **Reason**: %s\n", podProblem(pod))
+			if message := strings.TrimSpace(pod.Status.Message); message != "" {
+				result += fmt.Sprintf("- **Message**: %s\n", message)
+			}
+			if message := lastTerminationMessage(pod); message != "" {
+				result += fmt.Sprintf("- **Last Termination**: %s\n", message)
+			}
+			result += "\n"
+		}
+
+		result += "## Recommended Actions\n\n"
+		result += "1. For CrashLoopBackOff, the last termination message and exit code show why the container exited; query its logs with query_logs for the full output\n"
+		result += "2. For image pull errors, use get_recent_image_pulls to find the cause\n"
+		result += "3. For CreateContainerConfigError, use get_config_map_and_secret_refs to find missing ConfigMaps and Secrets\n"
+		result += "4. Pending pods usually lack capacity or match no node; check get_cluster_events_stream for FailedScheduling events\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// gkeServerConfig is the response of the Container API serverConfig endpoint
+type gkeServerConfig struct {
+	DefaultClusterVersion string   `json:"defaultClusterVersion"`
+	ValidMasterVersions   []string `json:"validMasterVersions"`
+	ValidNodeVersions     []string `json:"validNodeVersions"`
+	Channels              []struct {
+		Channel        string   `json:"channel"`
+		DefaultVersion string   `json:"defaultVersion"`
+		ValidVersions  []string `json:"validVersions"`
+	} `json:"channels"`
+}
+
+// channelVersions is the versions available in a single release channel
+type channelVersions struct {
+	Channel        string
+	DefaultVersion string
+	ValidVersions  []string
+}
+
+// releaseChannelOrder is the order channels are reported in, fastest first
+var releaseChannelOrder = []string{"RAPID", "REGULAR", "STABLE", "EXTENDED"}
+
+// groupVersionsByChannel returns the versions of each release channel in
+// RAPID, REGULAR, STABLE order, followed by any other channels
+func groupVersionsByChannel(config gkeServerConfig) []channelVersions {
+	byChannel := make(map[string]channelVersions)
+	for _, ch := range config.Channels {
+		byChannel[ch.Channel] = channelVersions{
+			Channel:        ch.Channel,
+			DefaultVersion: ch.DefaultVersion,
+			ValidVersions:  ch.ValidVersions,
+		}
+	}
+
+	var channels []channelVersions
+	for _, name := range releaseChannelOrder {
+		if ch, ok := byChannel[name]; ok {
+			channels = append(channels, ch)
+			delete(byChannel, name)
+		}
+	}
+
+	var others []string
+	for name := range byChannel {
+		others = append(others, name)
+	}
+	sort.Strings(others)
+	for _, name := range others {
+		channels = append(channels, byChannel[name])
+	}
+
+	return channels
+}
+
+// containsString reports whether values contains s
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// handleListVersionsInChannel handles the list_versions_in_channel tool request
+func handleListVersionsInChannel(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, _ := request.Params.Arguments["cluster_name"].(string)
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	// Construct URL for the Container API
+	apiURL := fmt.Sprintf("%s/projects/%s/locations/%s/serverConfig", gcpContainerBaseURL, projectID, location)
+
+	// Make the API request
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error creating request: %v", err)), nil
+	}
+
+	resp, err := doWithRetry(client, req)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error making request to Container API: %v", err)), nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return mcp.NewToolResultError(fmt.Sprintf("Error from Container API: %s", resp.Status)), nil
+	}
+
+	var config gkeServerConfig
+	if err := json.NewDecoder(resp.Body).Decode(&config); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error parsing response: %v", err)), nil
+	}
+
+	// Look up the cluster to compare against, if requested
+	var cluster struct {
+		CurrentMasterVersion string `json:"currentMasterVersion"`
+		CurrentNodeVersion   string `json:"currentNodeVersion"`
+		ReleaseChannel       struct {
+			Channel string `json:"channel"`
+		} `json:"releaseChannel"`
+	}
+	if clusterName != "" {
+		clusterURL := fmt.Sprintf("%s/projects/%s/locations/%s/clusters/%s", gcpContainerBaseURL, projectID, location, clusterName)
+
+		clusterReq, err := http.NewRequestWithContext(ctx, "GET", clusterURL, nil)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error creating request: %v", err)), nil
+		}
+
+		clusterResp, err := doWithRetry(client, clusterReq)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error making request to Container API: %v", err)), nil
+		}
+		defer clusterResp.Body.Close()
+
+		if clusterResp.StatusCode != http.StatusOK {
+			return mcp.NewToolResultError(fmt.Sprintf("Error from Container API for cluster %s: %s", clusterName, clusterResp.Status)), nil
+		}
+
+		if err := json.NewDecoder(clusterResp.Body).Decode(&cluster); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error parsing cluster response: %v", err)), nil
+		}
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# GKE Versions in %s\n\n", location)
+	result += fmt.Sprintf("- **Default Cluster Version**: %s\n", config.DefaultClusterVersion)
+
+	if clusterName != "" {
+		channel := cluster.ReleaseChannel.Channel
+		if channel == "" || channel == "UNSPECIFIED" {
+			channel = "none (static version)"
+		}
+		result += fmt.Sprintf("- **Cluster %s**: %s (master) / %s (nodes), channel %s\n",
+			clusterName, cluster.CurrentMasterVersion, cluster.CurrentNodeVersion, channel)
+	}
+
+	for _, ch := range groupVersionsByChannel(config) {
+		result += fmt.Sprintf("\n## %s Channel\n\n", ch.Channel)
+		result += fmt.Sprintf("- **Default Version**: %s\n", ch.DefaultVersion)
+
+		if clusterName != "" && ch.Channel == cluster.ReleaseChannel.Channel {
+			switch {
+			case cluster.CurrentMasterVersion == ch.DefaultVersion:
+				result += "- The cluster is on this channel's default version\n"
+			case containsString(ch.ValidVersions, cluster.CurrentMasterVersion):
+				result += fmt.Sprintf("- The cluster is on a valid version for this channel, but not the default (%s)\n", ch.DefaultVersion)
+			default:
+				result += "- **The cluster's version is no longer offered in this channel and will be auto-upgraded**\n"
+			}
+		}
+
+		if len(ch.ValidVersions) > 0 {
+			result += "- **Valid Versions**:\n"
+			for _, v := range ch.ValidVersions {
+				marker := ""
+				if clusterName != "" && v == cluster.CurrentMasterVersion {
+					marker = " (current)"
+				}
+				result += fmt.Sprintf("  - %s%s\n", v, marker)
+			}
+		}
+	}
+
+	if clusterName != "" && cluster.CurrentMasterVersion != "" {
+		result += "\n## Static Versions\n\n"
+		result += fmt.Sprintf("- **Master version valid**: %t\n", containsString(config.ValidMasterVersions, cluster.CurrentMasterVersion))
+		result += fmt.Sprintf("- **Node version valid**: %t\n", containsString(config.ValidNodeVersions, cluster.CurrentNodeVersion))
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
+
 // boolToEnabledString converts a boolean to "Enabled" or "Disabled"
 func boolToEnabledString(b bool) string {
 	if b {
@@ -0,0 +1,331 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// fakeContainerAPI is a Container API stand-in that serves a fixed response
+// for one path and counts the requests for it
+type fakeContainerAPI struct {
+	t        *testing.T
+	path     string
+	response interface{}
+	requests int
+}
+
+// RoundTrip implements http.RoundTripper
+func (f *fakeContainerAPI) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path != f.path {
+		f.t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		return &http.Response{StatusCode: http.StatusNotFound, Status: "404 Not Found", Body: io.NopCloser(strings.NewReader("{}"))}, nil
+	}
+	f.requests++
+	body, _ := json.Marshal(f.response)
+	return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(string(body)))}, nil
+}
+
+// callClusterTool calls a tool handler for a cluster in test-project
+func callClusterTool(t *testing.T, ctx context.Context, handler func(context.Context, mcp.CallToolRequest, *auth.OAuthHandler) (*mcp.CallToolResult, error), clusterName string, args map[string]interface{}) string {
+	t.Helper()
+
+	request := map[string]interface{}{
+		"project_id":   "test-project",
+		"location":     "us-central1",
+		"cluster_name": clusterName,
+	}
+	for k, v := range args {
+		request[k] = v
+	}
+
+	result, err := handler(ctx, newToolRequest(request), newTestAuthHandler(t, auth.ReadOnlyScopes))
+	if err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	text, _ := resultText(result)
+	if result.IsError {
+		t.Fatalf("handler returned tool error: %s", text)
+	}
+	return text
+}
+
+func TestHandleGetClusterInfoUsesClusterCache(t *testing.T) {
+	const clusterName = "info-cluster"
+	cacheKey := clusterCacheKey("test-project", "us-central1", clusterName)
+	t.Cleanup(func() { clusterCache.Invalidate(cacheKey) })
+
+	api := &fakeContainerAPI{
+		t:        t,
+		path:     "/v1/projects/test-project/locations/us-central1/clusters/" + clusterName,
+		response: map[string]interface{}{"name": clusterName, "status": "RUNNING", "currentMasterVersion": "1.31.1"},
+	}
+	ctx := withGCPTransport(context.Background(), api)
+
+	// A cluster cached by warmup or another tool is served without a request
+	var warmed gkeCluster
+	warmed.Name = clusterName
+	warmed.Status = "PROVISIONING"
+	clusterCache.Set(cacheKey, warmed)
+
+	text := callClusterTool(t, ctx, handleGetClusterInfo, clusterName, nil)
+	if api.requests != 0 {
+		t.Errorf("cached cluster fetched %d times, want 0", api.requests)
+	}
+	if !strings.Contains(text, "**Status**: PROVISIONING") || !strings.Contains(text, "cached, fetched") {
+		t.Errorf("result isn't from the cache:\n%s", text)
+	}
+
+	// force_refresh fetches the cluster and updates the shared cache
+	text = callClusterTool(t, ctx, handleGetClusterInfo, clusterName, map[string]interface{}{"force_refresh": true})
+	if api.requests != 1 {
+		t.Errorf("force_refresh fetched %d times, want 1", api.requests)
+	}
+	if !strings.Contains(text, "**Status**: RUNNING") || strings.Contains(text, "cached, fetched") {
+		t.Errorf("force_refresh result isn't fresh:\n%s", text)
+	}
+	if cluster, _, ok := clusterCache.Get(cacheKey); !ok || cluster.Status != "RUNNING" {
+		t.Errorf("cluster cache holds %+v after force_refresh, want the fresh cluster", cluster)
+	}
+}
+
+func TestHandleListNodePoolsCache(t *testing.T) {
+	const clusterName = "pool-cluster"
+	t.Cleanup(func() { nodePoolCache.Invalidate(clusterCacheKey("test-project", "us-central1", clusterName)) })
+
+	api := &fakeContainerAPI{
+		t:        t,
+		path:     "/v1/projects/test-project/locations/us-central1/clusters/" + clusterName + "/nodePools",
+		response: map[string]interface{}{"nodePools": []map[string]interface{}{{"name": "default-pool", "status": "RUNNING"}}},
+	}
+	ctx := withGCPTransport(context.Background(), api)
+
+	text := callClusterTool(t, ctx, handleListNodePools, clusterName, nil)
+	if api.requests != 1 || strings.Contains(text, "cached") {
+		t.Errorf("first call made %d requests, want 1 uncached:\n%s", api.requests, text)
+	}
+
+	// A repeated call is a cache hit
+	text = callClusterTool(t, ctx, handleListNodePools, clusterName, nil)
+	if api.requests != 1 || !strings.Contains(text, "cached") {
+		t.Errorf("second call made %d requests, want a cache hit:\n%s", api.requests, text)
+	}
+
+	// A pool added outside the server is only seen with refresh
+	api.response = map[string]interface{}{"nodePools": []map[string]interface{}{
+		{"name": "default-pool", "status": "RUNNING"},
+		{"name": "spot-pool", "status": "PROVISIONING"},
+	}}
+	text = callClusterTool(t, ctx, handleListNodePools, clusterName, nil)
+	if strings.Contains(text, "spot-pool") {
+		t.Errorf("cached listing shows the new pool before a refresh:\n%s", text)
+	}
+
+	text = callClusterTool(t, ctx, handleListNodePools, clusterName, map[string]interface{}{"refresh": true})
+	if api.requests != 2 || !strings.Contains(text, "spot-pool") || strings.Contains(text, "cached") {
+		t.Errorf("refresh made %d requests, want a fresh listing:\n%s", api.requests, text)
+	}
+
+	// The refreshed listing replaces the cached one
+	text = callClusterTool(t, ctx, handleListNodePools, clusterName, nil)
+	if api.requests != 2 || !strings.Contains(text, "spot-pool") {
+		t.Errorf("call after refresh made %d requests, want the refreshed listing from the cache:\n%s", api.requests, text)
+	}
+}
+
+func TestParseLabelSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     map[string]string
+		wantErr  bool
+	}{
+		{selector: "", want: map[string]string{}},
+		{selector: "env=prod", want: map[string]string{"env": "prod"}},
+		{selector: " env = prod , team=payments,", want: map[string]string{"env": "prod", "team": "payments"}},
+		{selector: "env=", want: map[string]string{"env": ""}},
+		{selector: "env", wantErr: true},
+		{selector: "=prod", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseLabelSelector(tt.selector)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseLabelSelector(%q) error = %v, want error %t", tt.selector, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseLabelSelector(%q) = %v, want %v", tt.selector, got, tt.want)
+		}
+	}
+}
+
+func TestHandleListClustersLabelSelector(t *testing.T) {
+	api := &fakeContainerAPI{
+		t:    t,
+		path: "/v1/projects/test-project/locations/-/clusters",
+		response: map[string]interface{}{"clusters": []map[string]interface{}{
+			{"name": "prod-payments", "resourceLabels": map[string]string{"env": "prod", "team": "payments"}},
+			{"name": "prod-search", "resourceLabels": map[string]string{"env": "prod", "team": "search"}},
+			{"name": "staging-payments", "resourceLabels": map[string]string{"env": "staging", "team": "payments"}},
+			{"name": "unlabelled"},
+		}},
+	}
+	ctx := withGCPTransport(context.Background(), api)
+	authHandler := newTestAuthHandler(t, auth.ReadOnlyScopes)
+
+	tests := []struct {
+		selector string
+		want     []string
+	}{
+		{selector: "", want: []string{"prod-payments", "prod-search", "staging-payments", "unlabelled"}},
+		{selector: "env=prod", want: []string{"prod-payments", "prod-search"}},
+		{selector: "env=prod,team=payments", want: []string{"prod-payments"}},
+		{selector: "team=payments", want: []string{"prod-payments", "staging-payments"}},
+		{selector: "env=dev", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			result, err := handleListClusters(ctx, newToolRequest(map[string]interface{}{
+				"project_id":     "test-project",
+				"label_selector": tt.selector,
+				"output_format":  "json",
+			}), authHandler)
+			if err != nil {
+				t.Fatalf("handleListClusters returned error: %v", err)
+			}
+			text, _ := resultText(result)
+			if result.IsError {
+				t.Fatalf("handleListClusters returned tool error: %s", text)
+			}
+
+			var view listClustersView
+			if err := json.Unmarshal([]byte(text), &view); err != nil {
+				t.Fatalf("parsing output: %v", err)
+			}
+			var names []string
+			for _, cluster := range view.Clusters {
+				names = append(names, cluster.Name)
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("clusters = %v, want %v", names, tt.want)
+			}
+		})
+	}
+
+	// An invalid selector is refused before listing
+	requests := api.requests
+	result, _ := handleListClusters(ctx, newToolRequest(map[string]interface{}{
+		"project_id":     "test-project",
+		"label_selector": "env",
+	}), authHandler)
+	if text, _ := resultText(result); !result.IsError || !strings.Contains(text, "invalid label selector") {
+		t.Errorf("invalid selector = %q, want an error", text)
+	}
+	if api.requests != requests {
+		t.Error("invalid selector listed clusters")
+	}
+}
+
+func TestHandleGetClusterInfoCachedMarker(t *testing.T) {
+	if os.Getenv("OPERABLE_CLUSTER_CACHE_TTL") == "" && clusterCache.ttl != 60*time.Second {
+		t.Errorf("default cluster cache TTL = %s, want 60s", clusterCache.ttl)
+	}
+
+	// The cluster cached for the fake API server stands in for one cached
+	// by an earlier tool call
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected Kubernetes API request %s %s", r.Method, r.URL.Path)
+	}))
+	warmed, _, _ := clusterCache.Get(clusterCacheKey("test-project", "us-central1", clusterName))
+
+	api := &fakeContainerAPI{
+		t:    t,
+		path: "/v1/projects/test-project/locations/us-central1/clusters/" + clusterName,
+		response: map[string]interface{}{
+			"name":     clusterName,
+			"location": "us-central1",
+			"status":   "RECONCILING",
+			"endpoint": warmed.Endpoint,
+		},
+	}
+	ctx := withGCPTransport(context.Background(), api)
+	marker := regexp.MustCompile(`_\(cached, fetched \d+s ago; use force_refresh to fetch fresh data\)_`)
+
+	text := callClusterTool(t, ctx, handleGetClusterInfo, clusterName, nil)
+	if api.requests != 0 || !marker.MatchString(text) {
+		t.Errorf("cached call made %d requests, want 0 and the cached marker:\n%s", api.requests, text)
+	}
+
+	text = callClusterTool(t, ctx, handleGetClusterInfo, clusterName, map[string]interface{}{"force_refresh": true})
+	if api.requests != 1 || marker.MatchString(text) || !strings.Contains(text, "**Status**: RECONCILING") {
+		t.Errorf("force_refresh made %d requests, want 1 and fresh data without the marker:\n%s", api.requests, text)
+	}
+
+	// The refreshed cluster is cached for later calls
+	text = callClusterTool(t, ctx, handleGetClusterInfo, clusterName, nil)
+	if api.requests != 1 || !marker.MatchString(text) || !strings.Contains(text, "**Status**: RECONCILING") {
+		t.Errorf("call after force_refresh made %d requests, want the refreshed cluster from the cache:\n%s", api.requests, text)
+	}
+}
+
+func TestHandleListVersionsInChannel(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/projects/test-project/locations/us-central1/serverConfig":
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"defaultClusterVersion": "1.30.5-gke.100",
+				"validMasterVersions":   []string{"1.31.1-gke.200", "1.30.5-gke.100"},
+				"validNodeVersions":     []string{"1.31.1-gke.200", "1.30.5-gke.100", "1.29.8-gke.300"},
+				"channels": []map[string]interface{}{
+					{"channel": "STABLE", "defaultVersion": "1.29.8-gke.300", "validVersions": []string{"1.29.8-gke.300"}},
+					{"channel": "RAPID", "defaultVersion": "1.31.1-gke.200", "validVersions": []string{"1.31.1-gke.200"}},
+					{"channel": "REGULAR", "defaultVersion": "1.30.5-gke.100", "validVersions": []string{"1.30.5-gke.100", "1.30.4-gke.50"}},
+				},
+			})
+		case "/v1/projects/test-project/locations/us-central1/clusters/web":
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"currentMasterVersion": "1.30.4-gke.50",
+				"currentNodeVersion":   "1.30.4-gke.50",
+				"releaseChannel":       map[string]string{"channel": "REGULAR"},
+			})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	text := callTool(t, ctx, handleListVersionsInChannel, map[string]interface{}{
+		"project_id":   "test-project",
+		"location":     "us-central1",
+		"cluster_name": "web",
+	})
+
+	// Channels are listed fastest first
+	rapid, regular, stable := strings.Index(text, "## RAPID Channel"), strings.Index(text, "## REGULAR Channel"), strings.Index(text, "## STABLE Channel")
+	if rapid < 0 || regular < rapid || stable < regular {
+		t.Errorf("channels aren't ordered RAPID, REGULAR, STABLE:\n%s", text)
+	}
+
+	for _, s := range []string{
+		"- **Default Cluster Version**: 1.30.5-gke.100",
+		"- **Cluster web**: 1.30.4-gke.50 (master) / 1.30.4-gke.50 (nodes), channel REGULAR",
+		"- The cluster is on a valid version for this channel, but not the default (1.30.5-gke.100)",
+		"  - 1.30.4-gke.50 (current)",
+		"- **Master version valid**: false",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
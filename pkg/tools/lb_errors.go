@@ -0,0 +1,247 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerLoadBalancerErrorTools registers tools that attribute load balancer
+// errors
+func registerLoadBalancerErrorTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get load balancer 5xx breakdown tool
+	getLB5xxBreakdown := mcp.NewTool("get_load_balancer_5xx_breakdown",
+		mcp.WithDescription("Breaks down an external HTTP(S) load balancer's responses by response code class over a time window, and attributes 5xx responses to the backends or to the load balancer itself by comparing responses sent to clients with responses received from backends. Tells a backend incident apart from a load balancer, health check or configuration problem."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("url_map_name",
+			mcp.Description("The name of the load balancer's URL map (either url_map_name or forwarding_rule_name is required)"),
+		),
+		mcp.WithString("forwarding_rule_name",
+			mcp.Description("The name of the load balancer's forwarding rule (either url_map_name or forwarding_rule_name is required)"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range to analyse in hours (default: 1)"),
+		),
+	)
+
+	getLB5xxBreakdownHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetLoadBalancer5xxBreakdown(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getLB5xxBreakdown, getLB5xxBreakdownHandler)
+
+	return nil
+}
+
+// sumSeriesByLabel totals the points of each series by the value of a metric
+// label
+func sumSeriesByLabel(series []timeSeries, label string) map[string]float64 {
+	totals := make(map[string]float64)
+	for _, ts := range series {
+		for _, point := range ts.Points {
+			totals[ts.Metric.Labels[label]] += point.value()
+		}
+	}
+	return totals
+}
+
+// lb5xxBreakdown attributes a load balancer's 5xx responses. Responses sent
+// to clients that weren't 5xx responses from backends were generated by the
+// load balancer, for example when no backend was healthy or a backend
+// connection failed.
+type lb5xxBreakdown struct {
+	// Classes are the responses sent to clients by response code class
+	Classes    map[string]float64
+	Total      float64
+	Total5xx   float64
+	Backend5xx float64
+	LB5xx      float64
+}
+
+// newLB5xxBreakdown builds the breakdown from responses sent to clients and
+// responses received from backends, both by response_code_class
+func newLB5xxBreakdown(frontend, backend map[string]float64) lb5xxBreakdown {
+	b := lb5xxBreakdown{Classes: frontend}
+	for _, count := range frontend {
+		b.Total += count
+	}
+	b.Total5xx = frontend["500"]
+	b.Backend5xx = backend["500"]
+
+	// Retries and requests still in flight at the window edges can make
+	// backend 5xx exceed client 5xx slightly
+	if b.Backend5xx > b.Total5xx {
+		b.Backend5xx = b.Total5xx
+	}
+	b.LB5xx = b.Total5xx - b.Backend5xx
+
+	return b
+}
+
+// rate returns count as a fraction of all requests
+func (b lb5xxBreakdown) rate(count float64) float64 {
+	if b.Total == 0 {
+		return 0
+	}
+	return count / b.Total
+}
+
+// attribution summarises where most 5xx responses came from
+func (b lb5xxBreakdown) attribution() string {
+	switch {
+	case b.Total5xx == 0:
+		return "No 5xx responses were served."
+	case b.Backend5xx >= 0.8*b.Total5xx:
+		return "Most 5xx responses came from the backends, so the problem is in the application serving the requests."
+	case b.LB5xx >= 0.8*b.Total5xx:
+		return "Most 5xx responses were generated by the load balancer, so backends were unreachable, unhealthy or timing out rather than returning errors."
+	default:
+		return "5xx responses came from both the backends and the load balancer."
+	}
+}
+
+// lbResponseClassName names a response_code_class label value
+func lbResponseClassName(class string) string {
+	if class == "0" {
+		return "No response"
+	}
+	if len(class) == 3 {
+		return class[:1] + "xx"
+	}
+	return class
+}
+
+// handleGetLoadBalancer5xxBreakdown handles the get_load_balancer_5xx_breakdown tool request
+func handleGetLoadBalancer5xxBreakdown(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	urlMapName, _ := request.Params.Arguments["url_map_name"].(string)
+	forwardingRuleName, _ := request.Params.Arguments["forwarding_rule_name"].(string)
+	if (urlMapName == "") == (forwardingRuleName == "") {
+		return mcp.NewToolResultError("exactly one of url_map_name or forwarding_rule_name must be provided"), nil
+	}
+
+	// Get optional parameters with defaults
+	timeRangeHours := 1.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	target := "URL map " + urlMapName
+	resourceFilter := fmt.Sprintf(`resource.type="https_lb_rule" AND resource.labels.url_map_name="%s"`, urlMapName)
+	if forwardingRuleName != "" {
+		target = "forwarding rule " + forwardingRuleName
+		resourceFilter = fmt.Sprintf(`resource.type="https_lb_rule" AND resource.labels.forwarding_rule_name="%s"`, forwardingRuleName)
+	}
+
+	// Each series is reduced to a single total over the window
+	endTime := time.Now()
+	startTime := endTime.Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+	query := func(metricType, groupBy, extraFilter string) ([]timeSeries, error) {
+		filter := fmt.Sprintf(`metric.type="%s" AND %s`, metricType, resourceFilter)
+		if extraFilter != "" {
+			filter += " AND " + extraFilter
+		}
+		return fetchTimeSeries(ctx, client, projectID, timeSeriesQuery{
+			Filter:             filter,
+			StartTime:          startTime,
+			EndTime:            endTime,
+			AlignmentPeriod:    endTime.Sub(startTime),
+			PerSeriesAligner:   "ALIGN_DELTA",
+			CrossSeriesReducer: "REDUCE_SUM",
+			GroupByFields:      []string{groupBy},
+		})
+	}
+
+	frontend, err := query("loadbalancing.googleapis.com/https/request_count", "metric.labels.response_code_class", "")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying request counts: %v", err)), nil
+	}
+
+	backend, err := query("loadbalancing.googleapis.com/https/backend_request_count", "metric.labels.response_code_class", "")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying backend request counts: %v", err)), nil
+	}
+
+	codes, err := query("loadbalancing.googleapis.com/https/request_count", "metric.labels.response_code", "metric.labels.response_code_class=500")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying 5xx response codes: %v", err)), nil
+	}
+
+	breakdown := newLB5xxBreakdown(
+		sumSeriesByLabel(frontend, "response_code_class"),
+		sumSeriesByLabel(backend, "response_code_class"),
+	)
+	if breakdown.Total == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No requests were served through %s in the last %.1f hours. Check the name, and that it belongs to an external Application Load Balancer.", target, timeRangeHours)), nil
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# Load Balancer 5xx Breakdown for %s\n\n", target)
+	result += fmt.Sprintf("%.0f requests in the last %.1f hours, %.0f (%.2f%%) of them 5xx.\n\n", breakdown.Total, timeRangeHours, breakdown.Total5xx, breakdown.rate(breakdown.Total5xx)*100)
+	result += breakdown.attribution() + "\n\n"
+
+	result += "## 5xx Attribution\n\n"
+	result += "| Source | 5xx Responses | Share of Requests |\n"
+	result += "| ------ | ------------- | ----------------- |\n"
+	result += fmt.Sprintf("| Backends | %.0f | %.2f%% |\n", breakdown.Backend5xx, breakdown.rate(breakdown.Backend5xx)*100)
+	result += fmt.Sprintf("| Load balancer | %.0f | %.2f%% |\n", breakdown.LB5xx, breakdown.rate(breakdown.LB5xx)*100)
+	result += "\n"
+
+	classes := make([]string, 0, len(breakdown.Classes))
+	for class := range breakdown.Classes {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+
+	result += "## Responses by Class\n\n"
+	result += "| Class | Requests | Share |\n"
+	result += "| ----- | -------- | ----- |\n"
+	for _, class := range classes {
+		count := breakdown.Classes[class]
+		result += fmt.Sprintf("| %s | %.0f | %.2f%% |\n", lbResponseClassName(class), count, breakdown.rate(count)*100)
+	}
+	result += "\n"
+
+	codeCounts := sumSeriesByLabel(codes, "response_code")
+	if len(codeCounts) > 0 {
+		codeNames := make([]string, 0, len(codeCounts))
+		for code := range codeCounts {
+			codeNames = append(codeNames, code)
+		}
+		sort.SliceStable(codeNames, func(i, j int) bool {
+			return codeCounts[codeNames[i]] > codeCounts[codeNames[j]]
+		})
+
+		result += "## 5xx Response Codes\n\n"
+		for _, code := range codeNames {
+			result += fmt.Sprintf("- **%s**: %.0f\n", code, codeCounts[code])
+		}
+		result += "\n"
+	}
+
+	result += "## Recommended Actions\n\n"
+	result += "1. Backend 5xx responses are application errors; check the backend's logs with query_logs, or list_active_issues for new error groups\n"
+	result += "2. Load balancer 502s and 503s usually mean no healthy backend or a failed backend connection; check backend health and, for GKE, get_cluster_network_endpoint_groups\n"
+	result += "3. Load balancer logs record the exact cause of each generated error in jsonPayload.statusDetails; query them with query_logs and resource.type=\"http_load_balancer\"\n"
+
+	return mcp.NewToolResultText(result), nil
+}
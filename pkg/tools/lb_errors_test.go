@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNewLB5xxBreakdown(t *testing.T) {
+	tests := []struct {
+		name            string
+		frontend        map[string]float64
+		backend         map[string]float64
+		wantBackend     float64
+		wantLB          float64
+		wantAttribution string
+	}{
+		{
+			name:            "backend errors",
+			frontend:        map[string]float64{"200": 900, "500": 100},
+			backend:         map[string]float64{"200": 900, "500": 95},
+			wantBackend:     95,
+			wantLB:          5,
+			wantAttribution: "Most 5xx responses came from the backends",
+		},
+		{
+			name:            "no healthy backends",
+			frontend:        map[string]float64{"200": 500, "500": 500},
+			backend:         map[string]float64{"200": 500},
+			wantLB:          500,
+			wantAttribution: "Most 5xx responses were generated by the load balancer",
+		},
+		{
+			// Backend 5xx are capped at what clients were sent
+			name:            "retried",
+			frontend:        map[string]float64{"500": 10},
+			backend:         map[string]float64{"500": 12},
+			wantBackend:     10,
+			wantAttribution: "Most 5xx responses came from the backends",
+		},
+	}
+
+	for _, tt := range tests {
+		b := newLB5xxBreakdown(tt.frontend, tt.backend)
+		if b.Backend5xx != tt.wantBackend || b.LB5xx != tt.wantLB {
+			t.Errorf("%s: backend 5xx = %.0f, load balancer 5xx = %.0f, want %.0f and %.0f", tt.name, b.Backend5xx, b.LB5xx, tt.wantBackend, tt.wantLB)
+		}
+		if !strings.HasPrefix(b.attribution(), tt.wantAttribution) {
+			t.Errorf("%s: attribution = %q, want %q", tt.name, b.attribution(), tt.wantAttribution)
+		}
+	}
+}
+
+func TestHandleGetLoadBalancer5xxBreakdown(t *testing.T) {
+	class := func(class string, count float64) timeSeries {
+		return testSeries(map[string]string{"response_code_class": class}, nil, count)
+	}
+
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/projects/test-project/timeSeries" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		filter := r.URL.Query().Get("filter")
+		if !strings.Contains(filter, `resource.labels.url_map_name="web-map"`) {
+			t.Errorf("filter doesn't match the URL map: %s", filter)
+		}
+
+		var series []timeSeries
+		switch {
+		case strings.Contains(filter, "response_code_class=500"):
+			series = []timeSeries{
+				testSeries(map[string]string{"response_code": "502"}, nil, 150),
+				testSeries(map[string]string{"response_code": "503"}, nil, 40),
+				testSeries(map[string]string{"response_code": "500"}, nil, 10),
+			}
+		case strings.Contains(filter, "backend_request_count"):
+			series = []timeSeries{class("200", 7600), class("400", 200), class("500", 10)}
+		default:
+			series = []timeSeries{class("200", 7600), class("400", 200), class("500", 200)}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"timeSeries": series})
+	}))
+
+	text := callTool(t, ctx, handleGetLoadBalancer5xxBreakdown, map[string]interface{}{
+		"project_id":   "test-project",
+		"url_map_name": "web-map",
+	})
+
+	for _, s := range []string{
+		"8000 requests in the last 1.0 hours, 200 (2.50%) of them 5xx.\n\n" +
+			"Most 5xx responses were generated by the load balancer",
+		"| Backends | 10 | 0.12% |\n| Load balancer | 190 | 2.38% |\n",
+		"| 2xx | 7600 | 95.00% |\n| 4xx | 200 | 2.50% |\n| 5xx | 200 | 2.50% |\n",
+		"- **502**: 150\n- **503**: 40\n- **500**: 10\n",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxLogFrequencyEntries caps the entries counted per call
+	maxLogFrequencyEntries = 20000
+
+	// maxLogFrequencyBuckets caps the buckets a window is split into
+	maxLogFrequencyBuckets = 360
+
+	// logSpikeFactor is how many times the median bucket count a bucket must
+	// reach to be a spike, or fall below to be a drop
+	logSpikeFactor = 3
+
+	// minLogSpikeEntries is the smallest count that can be a spike, and the
+	// smallest median a drop is detected from, so sparse logs aren't flagged
+	minLogSpikeEntries = 10
+)
+
+// registerLogFrequencyTools registers tools that count logs over time
+func registerLogFrequencyTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get logs frequency over time tool
+	getLogsFrequency := mcp.NewTool("get_logs_frequency_over_time",
+		mcp.WithDescription("Counts the log entries matching a filter per time bucket (e.g. per minute) and flags a spike or drop in volume, without returning the entries themselves. A sudden change in log volume is often the first sign of an incident, or of a service that stopped."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("filter",
+			mcp.Required(),
+			mcp.Description("The filter expression for the logs to count, e.g. severity>=ERROR"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range to count over in hours (default: 1)"),
+		),
+		mcp.WithNumber("bucket_minutes",
+			mcp.Description("Bucket size in minutes (default: 1)"),
+		),
+	)
+
+	getLogsFrequencyHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetLogsFrequencyOverTime(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getLogsFrequency, getLogsFrequencyHandler)
+
+	return nil
+}
+
+// logBucket is the number of log entries in a time bucket
+type logBucket struct {
+	Start time.Time
+	Count int
+	// Complete is false for buckets older than the oldest entry fetched when
+	// the entry cap was reached, whose counts are too low
+	Complete bool
+}
+
+// bucketLogCounts counts timestamps into consecutive buckets of size from
+// start to end. When truncated, buckets starting before coveredFrom are
+// marked incomplete.
+func bucketLogCounts(timestamps []time.Time, start, end time.Time, size time.Duration, coveredFrom time.Time) []logBucket {
+	var buckets []logBucket
+	for bucketStart := start; bucketStart.Before(end); bucketStart = bucketStart.Add(size) {
+		buckets = append(buckets, logBucket{Start: bucketStart, Complete: !bucketStart.Before(coveredFrom)})
+	}
+
+	for _, ts := range timestamps {
+		if ts.Before(start) || !ts.Before(end) {
+			continue
+		}
+		buckets[int(ts.Sub(start)/size)].Count++
+	}
+
+	return buckets
+}
+
+// logVolumeChange describes a spike or drop in log volume
+type logVolumeChange struct {
+	Median float64
+	// Spike is the bucket with the most entries, if it is a spike
+	Spike *logBucket
+	// DropFrom is the first of the trailing buckets whose counts all fell to
+	// a logSpikeFactor'th of the median or less, if volume dropped
+	DropFrom *logBucket
+}
+
+// detectLogVolumeChange compares complete buckets against their median. A
+// spike is the busiest bucket at logSpikeFactor times the median or more; a
+// drop is the most recent buckets all falling to a logSpikeFactor'th of it.
+func detectLogVolumeChange(buckets []logBucket) logVolumeChange {
+	var complete []logBucket
+	for _, bucket := range buckets {
+		if bucket.Complete {
+			complete = append(complete, bucket)
+		}
+	}
+	if len(complete) == 0 {
+		return logVolumeChange{}
+	}
+
+	counts := make([]int, len(complete))
+	for i, bucket := range complete {
+		counts[i] = bucket.Count
+	}
+	sort.Ints(counts)
+	var median float64
+	if n := len(counts); n%2 == 1 {
+		median = float64(counts[n/2])
+	} else {
+		median = float64(counts[n/2-1]+counts[n/2]) / 2
+	}
+
+	change := logVolumeChange{Median: median}
+
+	busiest := complete[0]
+	for _, bucket := range complete[1:] {
+		if bucket.Count > busiest.Count {
+			busiest = bucket
+		}
+	}
+	if busiest.Count >= minLogSpikeEntries && float64(busiest.Count) >= logSpikeFactor*max(median, 1) {
+		change.Spike = &busiest
+	}
+
+	if median >= minLogSpikeEntries {
+		for i := len(complete) - 1; i >= 0; i-- {
+			if float64(complete[i].Count)*logSpikeFactor > median {
+				break
+			}
+			dropFrom := complete[i]
+			change.DropFrom = &dropFrom
+		}
+	}
+
+	return change
+}
+
+// handleGetLogsFrequencyOverTime handles the get_logs_frequency_over_time tool request
+func handleGetLogsFrequencyOverTime(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	filter, ok := request.Params.Arguments["filter"].(string)
+	if !ok || filter == "" {
+		return mcp.NewToolResultError("filter must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	timeRangeHours := 1.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	bucketMinutes := 1.0
+	if val, ok := request.Params.Arguments["bucket_minutes"].(float64); ok && val > 0 {
+		bucketMinutes = val
+	}
+
+	window := time.Duration(timeRangeHours * float64(time.Hour))
+	bucketSize := time.Duration(bucketMinutes * float64(time.Minute))
+	if bucketSize < time.Second || bucketSize > window {
+		return mcp.NewToolResultError("bucket_minutes must be at least one second and no longer than the time range"), nil
+	}
+	if window/bucketSize > maxLogFrequencyBuckets {
+		return mcp.NewToolResultError(fmt.Sprintf("the time range would be split into more than %d buckets; use a larger bucket_minutes", maxLogFrequencyBuckets)), nil
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	// Align buckets to the end of the window so they are all full
+	end := time.Now().Truncate(bucketSize)
+	start := end.Add(-time.Duration(window/bucketSize) * bucketSize)
+
+	// Only timestamps are needed, so fetch nothing else
+	entries, nextPageToken, err := fetchLogEntries(ctx, client, projectID, logQuery{
+		Filter: combineLogFilters(
+			filter,
+			fmt.Sprintf(`timestamp >= "%s" AND timestamp < "%s"`, start.Format(time.RFC3339), end.Format(time.RFC3339)),
+		),
+		PageSize: maxLogFrequencyEntries,
+		Fields:   "entries(timestamp),nextPageToken",
+	})
+	cancelled := partialOnCancel(err, len(entries))
+	if err != nil && !cancelled {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying logs: %v", err)), nil
+	}
+
+	timestamps := make([]time.Time, 0, len(entries))
+	for _, entry := range entries {
+		if ts, err := time.Parse(time.RFC3339Nano, entry.Timestamp); err == nil {
+			timestamps = append(timestamps, ts)
+		}
+	}
+
+	// Entries are newest first, so when more are available the oldest
+	// buckets are only partly counted
+	truncated := nextPageToken != "" || cancelled
+	var coveredFrom time.Time
+	if truncated && len(timestamps) > 0 {
+		oldest := timestamps[len(timestamps)-1]
+		coveredFrom = start.Add((oldest.Sub(start)/bucketSize + 1) * bucketSize)
+	}
+
+	buckets := bucketLogCounts(timestamps, start, end, bucketSize, coveredFrom)
+	change := detectLogVolumeChange(buckets)
+
+	// Format the results
+	result := "# Log Frequency Over Time\n\n"
+	result += fmt.Sprintf("Filter: `%s`\n\n", filter)
+	result += fmt.Sprintf("Counted %d entries from %s to %s in %s buckets", len(timestamps), start.Format(time.RFC3339), end.Format(time.RFC3339), bucketSize)
+	if !coveredFrom.IsZero() {
+		result += fmt.Sprintf(" (stopped at %d entries, so buckets before %s are incomplete and marked ?)", len(timestamps), coveredFrom.Format(time.RFC3339))
+	}
+	result += fmt.Sprintf(". The median is %.1f entries per bucket.\n\n", change.Median)
+
+	switch {
+	case change.Spike != nil && change.DropFrom != nil:
+		result += fmt.Sprintf("**Spike and drop detected.** %d entries at %s (%.1fx the median), and volume has been below a third of the median since %s.\n\n",
+			change.Spike.Count, change.Spike.Start.Format(time.RFC3339), float64(change.Spike.Count)/max(change.Median, 1), change.DropFrom.Start.Format(time.RFC3339))
+	case change.Spike != nil:
+		result += fmt.Sprintf("**Spike detected.** %d entries at %s, %.1fx the median.\n\n",
+			change.Spike.Count, change.Spike.Start.Format(time.RFC3339), float64(change.Spike.Count)/max(change.Median, 1))
+	case change.DropFrom != nil:
+		result += fmt.Sprintf("**Drop detected.** Volume has been below a third of the median since %s.\n\n", change.DropFrom.Start.Format(time.RFC3339))
+	default:
+		result += "No spike or drop detected.\n\n"
+	}
+
+	peak := 0
+	for _, bucket := range buckets {
+		peak = max(peak, bucket.Count)
+	}
+
+	result += "| Bucket Start | Count | |\n"
+	result += "| ------------ | ----- | - |\n"
+	for _, bucket := range buckets {
+		count := fmt.Sprintf("%d", bucket.Count)
+		if !bucket.Complete {
+			count += "?"
+		}
+		bar := ""
+		if peak > 0 {
+			bar = strings.Repeat("█", bucket.Count*30/peak)
+		}
+		if change.Spike != nil && bucket.Start.Equal(change.Spike.Start) {
+			bar += " ← spike"
+		}
+		if change.DropFrom != nil && bucket.Start.Equal(change.DropFrom.Start) {
+			bar += " ← drop"
+		}
+		result += fmt.Sprintf("| %s | %s | %s |\n", bucket.Start.Format("2006-01-02 15:04:05"), count, bar)
+	}
+
+	result += "\n## Recommended Actions\n\n"
+	if change.Spike != nil {
+		result += fmt.Sprintf("1. See what was logged during the spike with get_logs_context, using the same filter and timestamp %s\n", change.Spike.Start.Format(time.RFC3339))
+	} else {
+		result += "1. Use query_logs with the same filter to read the entries behind any change\n"
+	}
+	result += "2. A drop can mean the service stopped or its logging broke rather than that things improved; check get_dropped_logs_indicator and whether the service is running\n"
+	result += "3. The most recent bucket can be low because of ingestion delay, so re-run shortly to confirm a drop\n"
+
+	if cancelled {
+		result += cancelledNote
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
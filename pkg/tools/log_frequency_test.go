@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDetectLogVolumeChange(t *testing.T) {
+	start := time.Date(2026, 10, 17, 1, 0, 0, 0, time.UTC)
+	end := start.Add(10 * time.Minute)
+
+	// Two entries a minute, with 40 clustered into 01:04
+	var timestamps []time.Time
+	for minute := 0; minute < 10; minute++ {
+		timestamps = append(timestamps, start.Add(time.Duration(minute)*time.Minute), start.Add(time.Duration(minute)*time.Minute+30*time.Second))
+	}
+	for i := 0; i < 40; i++ {
+		timestamps = append(timestamps, start.Add(4*time.Minute+time.Duration(i)*time.Second))
+	}
+
+	buckets := bucketLogCounts(timestamps, start, end, time.Minute, time.Time{})
+	if len(buckets) != 10 || buckets[4].Count != 42 || buckets[5].Count != 2 {
+		t.Fatalf("buckets = %+v, want 10 with 42 entries at 01:04", buckets)
+	}
+
+	change := detectLogVolumeChange(buckets)
+	if change.Median != 2 || change.Spike == nil || !change.Spike.Start.Equal(start.Add(4*time.Minute)) {
+		t.Errorf("change = %+v, want a spike at 01:04 over a median of 2", change)
+	}
+	if change.DropFrom != nil {
+		t.Errorf("drop detected from %s, want none", change.DropFrom.Start)
+	}
+
+	// Incomplete buckets are left out of the median
+	buckets = bucketLogCounts(timestamps, start, end, time.Minute, start.Add(5*time.Minute))
+	if buckets[4].Complete || !buckets[5].Complete {
+		t.Errorf("buckets = %+v, want those before 01:05 incomplete", buckets)
+	}
+	if change := detectLogVolumeChange(buckets); change.Spike != nil {
+		t.Errorf("spike detected at %s in an incomplete bucket", change.Spike.Start)
+	}
+}
+
+func TestHandleGetLogsFrequencyOverTime(t *testing.T) {
+	// Every entry falls in the minute ten minutes ago
+	clustered := time.Now().Truncate(time.Minute).Add(-10 * time.Minute).UTC()
+
+	ctx := withFakeGCP(context.Background(), fakeLogging(t, func(filter string) string {
+		for _, s := range []string{"severity>=ERROR", `timestamp >= "`} {
+			if !strings.Contains(filter, s) {
+				t.Errorf("filter doesn't contain %s: %s", s, filter)
+			}
+		}
+		var entries []string
+		for i := 0; i < 25; i++ {
+			entries = append(entries, `{"timestamp": "`+clustered.Add(time.Duration(59-i)*time.Second).Format(time.RFC3339)+`"}`)
+		}
+		return "[" + strings.Join(entries, ",") + "]"
+	}))
+
+	text := callTool(t, ctx, handleGetLogsFrequencyOverTime, map[string]interface{}{
+		"project_id":       "test-project",
+		"filter":           "severity>=ERROR",
+		"time_range_hours": 0.5,
+	})
+
+	for _, s := range []string{
+		"Counted 25 entries from ",
+		" in 1m0s buckets. The median is 0.0 entries per bucket.",
+		"**Spike detected.** 25 entries at ",
+		" | 25 | " + strings.Repeat("█", 30) + " ← spike |\n",
+		"1. See what was logged during the spike with get_logs_context",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if n := strings.Count(text, " | 0 |  |\n"); n != 29 {
+		t.Errorf("%d empty buckets, want 29:\n%s", n, text)
+	}
+}
@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// builtinLogPresets are the named filters available to query_logs without any configuration
+var builtinLogPresets = map[string]string{
+	"errors":         `severity>=ERROR`,
+	"warnings":       `severity>=WARNING`,
+	"gke-container":  `resource.type="k8s_container"`,
+	"gke-node":       `resource.type="k8s_node"`,
+	"gke-events":     `log_id("events")`,
+	"audit-activity": `log_id("cloudaudit.googleapis.com/activity")`,
+	"http-5xx":       `httpRequest.status>=500`,
+}
+
+// loadLogPresets returns the built-in log filter presets merged with any
+// presets defined in the JSON file named by OPERABLE_LOG_PRESETS_FILE. The
+// file maps preset names to filter expressions, and may override built-ins.
+func loadLogPresets() (map[string]string, error) {
+	presets := make(map[string]string, len(builtinLogPresets))
+	for name, filter := range builtinLogPresets {
+		presets[name] = filter
+	}
+
+	path := os.Getenv("OPERABLE_LOG_PRESETS_FILE")
+	if path == "" {
+		return presets, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading log presets file: %w", err)
+	}
+
+	var custom map[string]string
+	if err := json.Unmarshal(data, &custom); err != nil {
+		return nil, fmt.Errorf("error parsing log presets file %s: %w", path, err)
+	}
+
+	for name, filter := range custom {
+		if strings.TrimSpace(filter) == "" {
+			return nil, fmt.Errorf("log preset %q in %s has an empty filter", name, path)
+		}
+		presets[name] = filter
+	}
+
+	return presets, nil
+}
+
+// presetNames returns the preset names in alphabetical order
+func presetNames(presets map[string]string) []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// combineLogFilters joins the non-empty filters with AND, parenthesising each
+// so that OR expressions inside a preset keep their meaning
+func combineLogFilters(filters ...string) string {
+	var parts []string
+	for _, filter := range filters {
+		if filter = strings.TrimSpace(filter); filter != "" {
+			parts = append(parts, "("+filter+")")
+		}
+	}
+
+	return strings.Join(parts, " AND ")
+}
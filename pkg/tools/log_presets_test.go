@@ -0,0 +1,135 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+)
+
+func TestCombineLogFilters(t *testing.T) {
+	tests := []struct {
+		filters []string
+		want    string
+	}{
+		{filters: []string{`severity>=ERROR`}, want: `(severity>=ERROR)`},
+		{filters: []string{`severity>=ERROR`, ""}, want: `(severity>=ERROR)`},
+		{filters: []string{`severity>=ERROR`, `resource.type="gce_instance"`}, want: `(severity>=ERROR) AND (resource.type="gce_instance")`},
+		{filters: []string{`log_id("a") OR log_id("b")`, `severity>=ERROR`}, want: `(log_id("a") OR log_id("b")) AND (severity>=ERROR)`},
+		{filters: []string{" ", ""}, want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := combineLogFilters(tt.filters...); got != tt.want {
+			t.Errorf("combineLogFilters(%q) = %q, want %q", tt.filters, got, tt.want)
+		}
+	}
+}
+
+func TestLoadLogPresets(t *testing.T) {
+	writeFile := func(t *testing.T, contents string) string {
+		path := filepath.Join(t.TempDir(), "presets.json")
+		if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+			t.Fatalf("writing presets: %v", err)
+		}
+		return path
+	}
+
+	t.Run("built-in", func(t *testing.T) {
+		t.Setenv("OPERABLE_LOG_PRESETS_FILE", "")
+		presets, err := loadLogPresets()
+		if err != nil {
+			t.Fatalf("loadLogPresets: %v", err)
+		}
+		if presets["errors"] != `severity>=ERROR` {
+			t.Errorf("errors preset = %q", presets["errors"])
+		}
+	})
+
+	t.Run("custom and override", func(t *testing.T) {
+		t.Setenv("OPERABLE_LOG_PRESETS_FILE", writeFile(t, `{"payments": "resource.labels.namespace_name=\"payments\"", "errors": "severity>=CRITICAL"}`))
+		presets, err := loadLogPresets()
+		if err != nil {
+			t.Fatalf("loadLogPresets: %v", err)
+		}
+		if presets["payments"] != `resource.labels.namespace_name="payments"` {
+			t.Errorf("payments preset = %q", presets["payments"])
+		}
+		if presets["errors"] != `severity>=CRITICAL` {
+			t.Errorf("errors preset wasn't overridden: %q", presets["errors"])
+		}
+		if presets["warnings"] != builtinLogPresets["warnings"] {
+			t.Errorf("warnings preset = %q, want the built-in", presets["warnings"])
+		}
+	})
+
+	t.Run("empty filter", func(t *testing.T) {
+		t.Setenv("OPERABLE_LOG_PRESETS_FILE", writeFile(t, `{"blank": " "}`))
+		if _, err := loadLogPresets(); err == nil {
+			t.Error("loadLogPresets accepted an empty filter")
+		}
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		t.Setenv("OPERABLE_LOG_PRESETS_FILE", writeFile(t, `{`))
+		if _, err := loadLogPresets(); err == nil {
+			t.Error("loadLogPresets accepted invalid JSON")
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Setenv("OPERABLE_LOG_PRESETS_FILE", filepath.Join(t.TempDir(), "missing.json"))
+		if _, err := loadLogPresets(); err == nil {
+			t.Error("loadLogPresets accepted a missing file")
+		}
+	})
+}
+
+func TestHandleQueryLogsPreset(t *testing.T) {
+	var filters []string
+	ctx := withGCPTransport(context.Background(), roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var body struct {
+			Filter string `json:"filter"`
+		}
+		json.NewDecoder(req.Body).Decode(&body)
+		filters = append(filters, body.Filter)
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(`{"entries": []}`))}, nil
+	}))
+	authHandler := newTestAuthHandler(t, auth.ReadOnlyScopes)
+
+	result, err := handleQueryLogs(ctx, newToolRequest(map[string]interface{}{
+		"project_id": "test-project",
+		"preset":     "errors",
+		"filter":     `resource.type="gce_instance"`,
+	}), authHandler, builtinLogPresets)
+	if err != nil {
+		t.Fatalf("handleQueryLogs returned error: %v", err)
+	}
+	if text, _ := resultText(result); result.IsError {
+		t.Fatalf("handleQueryLogs returned tool error: %s", text)
+	}
+	if len(filters) != 1 || !strings.HasPrefix(filters[0], `(severity>=ERROR) AND (resource.type="gce_instance") AND timestamp >= `) {
+		t.Errorf("logs queried with filters %q, want the preset ANDed with the filter", filters)
+	}
+
+	// An unknown preset is refused without querying
+	result, err = handleQueryLogs(ctx, newToolRequest(map[string]interface{}{
+		"project_id": "test-project",
+		"preset":     "nope",
+	}), authHandler, builtinLogPresets)
+	if err != nil {
+		t.Fatalf("handleQueryLogs returned error: %v", err)
+	}
+	if text, _ := resultText(result); !result.IsError || !strings.Contains(text, "available presets are") {
+		t.Errorf("unknown preset didn't return an error: %s", text)
+	}
+	if len(filters) != 1 {
+		t.Errorf("unknown preset sent a query")
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,6 +21,12 @@ const gcpLoggingBaseURL = "https://logging.googleapis.com/v2"
 
 // registerLoggingTools registers all logging related tools
 func registerLoggingTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Load the named filter presets
+	presets, err := loadLogPresets()
+	if err != nil {
+		return err
+	}
+
 	// Register query logs tool
 	queryLogs := mcp.NewTool("query_logs",
 		mcp.WithDescription("Queries logs from GCP Cloud Logging"),
@@ -26,8 +35,11 @@ func registerLoggingTools(s *server.MCPServer, authHandler *auth.OAuthHandler) e
 			mcp.Description("The Google Cloud project ID"),
 		),
 		mcp.WithString("filter",
-			mcp.Required(),
-			mcp.Description("The filter expression for the logs query"),
+			mcp.Description("The filter expression for the logs query (required unless a preset is given)"),
+		),
+		mcp.WithString("preset",
+			mcp.Description("A named filter preset, combined with filter using AND"),
+			mcp.Enum(presetNames(presets)...),
 		),
 		mcp.WithNumber("time_range_hours",
 			mcp.Description("Time range for logs in hours (default: 1)"),
@@ -35,10 +47,11 @@ func registerLoggingTools(s *server.MCPServer, authHandler *auth.OAuthHandler) e
 		mcp.WithNumber("max_results",
 			mcp.Description("Maximum number of results to return (default: 50)"),
 		),
+		withOutputFormat(),
 	)
 
 	queryHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		return handleQueryLogs(ctx, request, authHandler)
+		return handleQueryLogs(ctx, request, authHandler, presets)
 	}
 
 	AddToolSafe(s, queryLogs, queryHandler)
@@ -75,6 +88,11 @@ func registerLoggingTools(s *server.MCPServer, authHandler *auth.OAuthHandler) e
 		mcp.WithNumber("max_results",
 			mcp.Description("Maximum number of results to return (default: 100)"),
 		),
+		mcp.WithString("source",
+			mcp.Description("Where to read logs from: \"logging\" (Cloud Logging, default) or \"live\" (the pod log subresource on the cluster API, for the freshest logs). "+
+				"Live logs only cover the container's current instance and can't look back past a restart."),
+			mcp.Enum("logging", "live"),
+		),
 	)
 
 	podLogsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -83,20 +101,116 @@ func registerLoggingTools(s *server.MCPServer, authHandler *auth.OAuthHandler) e
 
 	AddToolSafe(s, getPodLogs, podLogsHandler)
 
+	// Register get dropped logs indicator tool
+	getDroppedLogs := mcp.NewTool("get_dropped_logs_indicator",
+		mcp.WithDescription("Checks whether Cloud Logging dropped logs or had ingestion, export, or logs-based metric errors, which would make log data incomplete"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range to check in hours (default: 24)"),
+		),
+	)
+
+	droppedLogsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetDroppedLogsIndicator(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getDroppedLogs, droppedLogsHandler)
+
+	// Register list monitored resource descriptors tool
+	listResourceDescriptors := mcp.NewTool("list_monitored_resource_descriptors",
+		mcp.WithDescription("Lists monitored resource types with their label keys and descriptions, to help build resource.type and resource.labels filters for query_logs"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID, used for quota attribution"),
+		),
+		mcp.WithString("filter",
+			mcp.Description("Only include resource types starting with this prefix (e.g., k8s_ or gce_)"),
+		),
+	)
+
+	listResourceDescriptorsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleListMonitoredResourceDescriptors(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, listResourceDescriptors, listResourceDescriptorsHandler)
+
+	// Register get logs context tool
+	getLogsContext := mcp.NewTool("get_logs_context",
+		mcp.WithDescription("Fetches the log entries immediately before and after a target timestamp, in chronological order, to show the surrounding narrative of an interesting log line"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("filter",
+			mcp.Required(),
+			mcp.Description("The filter selecting the logs to show, usually the resource that wrote the target entry (e.g., resource.type=\"k8s_container\" AND resource.labels.pod_name=\"api-123\")"),
+		),
+		mcp.WithString("timestamp",
+			mcp.Required(),
+			mcp.Description("The timestamp of the target entry, in RFC 3339 format"),
+		),
+		mcp.WithString("insert_id",
+			mcp.Description("The insertId of the target entry, to pick it out from entries with the same timestamp"),
+		),
+		mcp.WithNumber("context_lines",
+			mcp.Description("Number of entries to show before and after the target (default: 10, maximum: 100)"),
+		),
+	)
+
+	logsContextHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetLogsContext(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getLogsContext, logsContextHandler)
+
+	// Register tools backed by Cloud Audit Logs
+	if err := registerAuditTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerAuthFailureTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerLogFrequencyTools(s, authHandler); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// queryLogsView is the data rendered by the query_logs template
+type queryLogsView struct {
+	Entries []logEntry `json:"entries"`
+	HasMore bool       `json:"hasMore"`
+	// Partial is set when the query was cancelled before it completed
+	Partial bool `json:"partial,omitempty"`
+}
+
 // handleQueryLogs handles the query_logs tool request
-func handleQueryLogs(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+func handleQueryLogs(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler, presets map[string]string) (*mcp.CallToolResult, error) {
 	// Extract parameters
 	projectID, ok := request.Params.Arguments["project_id"].(string)
 	if !ok || projectID == "" {
 		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
 	}
 
-	filter, ok := request.Params.Arguments["filter"].(string)
-	if !ok || filter == "" {
-		return mcp.NewToolResultError("filter must be a non-empty string"), nil
+	filter, _ := request.Params.Arguments["filter"].(string)
+
+	// Expand the preset, if any, and combine it with the explicit filter
+	if preset, _ := request.Params.Arguments["preset"].(string); preset != "" {
+		presetFilter, ok := presets[preset]
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("unknown preset %q, available presets are: %s", preset, strings.Join(presetNames(presets), ", "))), nil
+		}
+		filter = combineLogFilters(presetFilter, filter)
+	}
+
+	if filter == "" {
+		return mcp.NewToolResultError("filter must be a non-empty string when no preset is given"), nil
 	}
 
 	// Get optional parameters with defaults
@@ -110,6 +224,11 @@ func handleQueryLogs(ctx context.Context, request mcp.CallToolRequest, authHandl
 		maxResults = val
 	}
 
+	format, err := outputFormat(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
 	// Get HTTP client with authentication
 	client, err := authHandler.GetClient(ctx)
 	if err != nil {
@@ -128,108 +247,32 @@ func handleQueryLogs(ctx context.Context, request mcp.CallToolRequest, authHandl
 			endTime.Format(time.RFC3339))
 	}
 
-	// Construct the request body
-	requestBody := map[string]interface{}{
-		"resourceNames": []string{fmt.Sprintf("projects/%s", projectID)},
-		"filter":        filter,
-		"orderBy":       "timestamp desc",
-		"pageSize":      int(maxResults),
+	entries, nextPageToken, err := fetchLogEntries(ctx, client, projectID, logQuery{
+		Filter:   filter,
+		PageSize: int(maxResults),
+	})
+	cancelled := partialOnCancel(err, len(entries))
+	if err != nil && !cancelled {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying logs: %v", err)), nil
 	}
 
-	requestBodyJSON, err := json.Marshal(requestBody)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling request body: %v", err)), nil
+	// Format the results
+	view := queryLogsView{
+		Entries: entries,
+		HasMore: nextPageToken != "",
+		Partial: cancelled,
 	}
-
-	// Construct URL for the Logging API
-	apiURL := fmt.Sprintf("%s/entries:list", gcpLoggingBaseURL)
-
-	// Make the API request
-	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, strings.NewReader(string(requestBodyJSON)))
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error creating request: %v", err)), nil
+	if format == outputJSON {
+		return jsonResult(view)
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := client.Do(req)
+	result, err := renderOutput("query_logs", view)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error making request to Logging API: %v", err)), nil
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return mcp.NewToolResultError(fmt.Sprintf("Error from Logging API: %s", resp.Status)), nil
-	}
-
-	// Parse the response
-	var response struct {
-		Entries []struct {
-			LogName  string `json:"logName"`
-			Resource struct {
-				Type   string            `json:"type"`
-				Labels map[string]string `json:"labels"`
-			} `json:"resource"`
-			Timestamp   string                 `json:"timestamp"`
-			Severity    string                 `json:"severity"`
-			TextPayload string                 `json:"textPayload"`
-			JsonPayload map[string]interface{} `json:"jsonPayload"`
-			Labels      map[string]string      `json:"labels"`
-		} `json:"entries"`
-		NextPageToken string `json:"nextPageToken"`
+		return mcp.NewToolResultError(fmt.Sprintf("Error formatting logs: %v", err)), nil
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error parsing response: %v", err)), nil
-	}
-
-	// Format the results
-	var result string
-	if len(response.Entries) == 0 {
-		result = "No logs found matching the filter criteria."
-	} else {
-		result = fmt.Sprintf("Found %d log entries matching the filter criteria:\n\n", len(response.Entries))
-
-		for i, entry := range response.Entries {
-			result += fmt.Sprintf("### Log Entry %d\n", i+1)
-			result += fmt.Sprintf("- **Timestamp**: %s\n", entry.Timestamp)
-			result += fmt.Sprintf("- **Severity**: %s\n", entry.Severity)
-			result += fmt.Sprintf("- **Log Name**: %s\n", entry.LogName)
-			result += fmt.Sprintf("- **Resource Type**: %s\n", entry.Resource.Type)
-
-			if len(entry.Resource.Labels) > 0 {
-				result += "- **Resource Labels**:\n"
-				for k, v := range entry.Resource.Labels {
-					result += fmt.Sprintf("  - %s: %s\n", k, v)
-				}
-			}
-
-			if len(entry.Labels) > 0 {
-				result += "- **Labels**:\n"
-				for k, v := range entry.Labels {
-					result += fmt.Sprintf("  - %s: %s\n", k, v)
-				}
-			}
-
-			result += "- **Payload**:\n"
-			if entry.TextPayload != "" {
-				result += "```\n" + entry.TextPayload + "\n```\n"
-			} else if entry.JsonPayload != nil {
-				jsonBytes, err := json.MarshalIndent(entry.JsonPayload, "", "  ")
-				if err != nil {
-					result += "Error formatting JSON payload\n"
-				} else {
-					result += "```json\n" + string(jsonBytes) + "\n```\n"
-				}
-			} else {
-				result += "No payload\n"
-			}
-
-			result += "\n"
-		}
-
-		if response.NextPageToken != "" {
-			result += "Note: There are more log entries available. Refine your filter or increase max_results to see more.\n"
-		}
+	if cancelled {
+		result += cancelledNote
 	}
 
 	return mcp.NewToolResultText(result), nil
@@ -277,6 +320,19 @@ func handleGetPodLogs(ctx context.Context, request mcp.CallToolRequest, authHand
 		maxResults = val
 	}
 
+	source := "logging"
+	if val, ok := request.Params.Arguments["source"].(string); ok && val != "" {
+		source = val
+	}
+
+	switch source {
+	case "logging":
+	case "live":
+		return handleGetLivePodLogs(ctx, authHandler, projectID, location, clusterName, namespace, podName, containerName, timeRangeHours, int(maxResults))
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("source must be \"logging\" or \"live\", got %q", source)), nil
+	}
+
 	// Get HTTP client with authentication
 	client, err := authHandler.GetClient(ctx)
 	if err != nil {
@@ -306,65 +362,23 @@ func handleGetPodLogs(ctx context.Context, request mcp.CallToolRequest, authHand
 		startTime.Format(time.RFC3339),
 		endTime.Format(time.RFC3339))
 
-	// Construct the request body
-	requestBody := map[string]interface{}{
-		"resourceNames": []string{fmt.Sprintf("projects/%s", projectID)},
-		"filter":        filter,
-		"orderBy":       "timestamp desc",
-		"pageSize":      int(maxResults),
-	}
-
-	requestBodyJSON, err := json.Marshal(requestBody)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error marshaling request body: %v", err)), nil
-	}
-
-	// Construct URL for the Logging API
-	apiURL := fmt.Sprintf("%s/entries:list", gcpLoggingBaseURL)
-
-	// Make the API request
-	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, strings.NewReader(string(requestBodyJSON)))
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error creating request: %v", err)), nil
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error making request to Logging API: %v", err)), nil
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return mcp.NewToolResultError(fmt.Sprintf("Error from Logging API: %s", resp.Status)), nil
-	}
-
-	// Parse the response
-	var response struct {
-		Entries []struct {
-			Timestamp   string                 `json:"timestamp"`
-			Severity    string                 `json:"severity"`
-			TextPayload string                 `json:"textPayload"`
-			JsonPayload map[string]interface{} `json:"jsonPayload"`
-			Resource    struct {
-				Labels map[string]string `json:"labels"`
-			} `json:"resource"`
-		} `json:"entries"`
-		NextPageToken string `json:"nextPageToken"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error parsing response: %v", err)), nil
+	entries, nextPageToken, err := fetchLogEntries(ctx, client, projectID, logQuery{
+		Filter:   filter,
+		PageSize: int(maxResults),
+	})
+	cancelled := partialOnCancel(err, len(entries))
+	if err != nil && !cancelled {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying logs: %v", err)), nil
 	}
 
 	// Format the results
 	var result string
-	if len(response.Entries) == 0 {
+	if len(entries) == 0 {
 		result = fmt.Sprintf("No logs found for pod %s in namespace %s.", podName, namespace)
 	} else {
 		// Get container name from the first entry if not provided
-		if containerName == "" && len(response.Entries) > 0 {
-			containerName = response.Entries[0].Resource.Labels["container_name"]
+		if containerName == "" && len(entries) > 0 {
+			containerName = entries[0].Resource.Labels["container_name"]
 		}
 
 		result = fmt.Sprintf("## Logs for pod %s", podName)
@@ -373,11 +387,11 @@ func handleGetPodLogs(ctx context.Context, request mcp.CallToolRequest, authHand
 		}
 		result += fmt.Sprintf(" in namespace %s\n\n", namespace)
 
-		result += fmt.Sprintf("Found %d log entries in the last %.1f hours:\n\n", len(response.Entries), timeRangeHours)
+		result += fmt.Sprintf("Found %d log entries in the last %.1f hours:\n\n", len(entries), timeRangeHours)
 
 		result += "```\n"
-		for i := len(response.Entries) - 1; i >= 0; i-- { // Reverse to show oldest first
-			entry := response.Entries[i]
+		for i := len(entries) - 1; i >= 0; i-- { // Reverse to show oldest first
+			entry := entries[i]
 
 			// Format timestamp
 			t, err := time.Parse(time.RFC3339, entry.Timestamp)
@@ -415,10 +429,620 @@ func handleGetPodLogs(ctx context.Context, request mcp.CallToolRequest, authHand
 		}
 		result += "```\n\n"
 
-		if response.NextPageToken != "" {
+		if nextPageToken != "" {
 			result += "Note: There are more log entries available. Increase time_range_hours or max_results to see more.\n"
 		}
 	}
 
+	if cancelled {
+		result += cancelledNote
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// podLogRequest builds the path and query for the pod log subresource
+func podLogRequest(namespace, podName, containerName string, sinceSeconds int64, tailLines int) (string, url.Values) {
+	path := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/log", namespace, podName)
+
+	query := url.Values{}
+	if containerName != "" {
+		query.Set("container", containerName)
+	}
+	if sinceSeconds > 0 {
+		query.Set("sinceSeconds", strconv.FormatInt(sinceSeconds, 10))
+	}
+	if tailLines > 0 {
+		query.Set("tailLines", strconv.Itoa(tailLines))
+	}
+	query.Set("timestamps", "true")
+
+	return path, query
+}
+
+// handleGetLivePodLogs reads pod logs directly from the cluster API, which is
+// real-time but limited to the container's current instance
+func handleGetLivePodLogs(ctx context.Context, authHandler *auth.OAuthHandler, projectID, location, clusterName, namespace, podName, containerName string, timeRangeHours float64, maxResults int) (*mcp.CallToolResult, error) {
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error connecting to cluster: %v", err)), nil
+	}
+
+	path, query := podLogRequest(namespace, podName, containerName, int64(timeRangeHours*3600), maxResults)
+
+	var logs string
+	if err := kube.get(ctx, path, query, &logs); err != nil {
+		if isKubeNotFound(err) {
+			return mcp.NewToolResultError(fmt.Sprintf("Pod %s not found in namespace %s.", podName, namespace)), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Error reading live pod logs: %v", err)), nil
+	}
+
+	result := fmt.Sprintf("## Live logs for pod %s", podName)
+	if containerName != "" {
+		result += fmt.Sprintf(", container %s", containerName)
+	}
+	result += fmt.Sprintf(" in namespace %s\n\n", namespace)
+
+	logs = strings.TrimRight(logs, "\n")
+	if logs == "" {
+		result += fmt.Sprintf("No log lines in the last %.1f hours of the container's current instance.\n", timeRangeHours)
+		return mcp.NewToolResultText(result), nil
+	}
+
+	result += fmt.Sprintf("Last %d lines from the last %.1f hours, read directly from the cluster:\n\n", strings.Count(logs, "\n")+1, timeRangeHours)
+	result += "```\n" + logs + "\n```\n\n"
+	result += "Note: Live logs only cover the container's current instance. Use source \"logging\" to see logs from before a restart.\n"
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// logEntry is a single entry returned by the Logging entries:list API
+type logEntry struct {
+	InsertID string `json:"insertId"`
+	LogName  string `json:"logName"`
+	Resource struct {
+		Type   string            `json:"type"`
+		Labels map[string]string `json:"labels"`
+	} `json:"resource"`
+	Timestamp    string                 `json:"timestamp"`
+	Severity     string                 `json:"severity"`
+	TextPayload  string                 `json:"textPayload"`
+	JsonPayload  map[string]interface{} `json:"jsonPayload"`
+	ProtoPayload map[string]interface{} `json:"protoPayload"`
+	Labels       map[string]string      `json:"labels"`
+	HttpRequest  *struct {
+		RequestMethod string `json:"requestMethod"`
+		RequestUrl    string `json:"requestUrl"`
+		Status        int    `json:"status"`
+		Latency       string `json:"latency"`
+		UserAgent     string `json:"userAgent"`
+		RemoteIp      string `json:"remoteIp"`
+	} `json:"httpRequest"`
+}
+
+// logQuery describes an entries:list request
+type logQuery struct {
+	Filter string
+	// OrderBy defaults to newest first
+	OrderBy string
+	// PageSize is the maximum number of entries to return
+	PageSize int
+	// Fields, if set, is a partial response field mask such as
+	// "entries(timestamp),nextPageToken", to fetch less of each entry
+	Fields string
+}
+
+// maxLogPageSize is the most entries the Logging API returns in one page
+const maxLogPageSize = 1000
+
+// fetchLogEntries lists entries matching the query from the project's logs,
+// following pagination until PageSize entries have been collected. The
+// returned page token is non-empty when more entries are available.
+// If a page fails, the entries collected so far are returned with the error,
+// so callers can show partial results when the call is cancelled.
+func fetchLogEntries(ctx context.Context, client *http.Client, projectID string, query logQuery) ([]logEntry, string, error) {
+	orderBy := query.OrderBy
+	if orderBy == "" {
+		orderBy = "timestamp desc"
+	}
+
+	var entries []logEntry
+	pageToken := ""
+	for {
+		// Stop between pages once the caller has lost interest
+		if err := ctx.Err(); err != nil {
+			return entries, pageToken, err
+		}
+
+		// Construct the request body
+		requestBody := map[string]interface{}{
+			"resourceNames": []string{fmt.Sprintf("projects/%s", projectID)},
+			"filter":        query.Filter,
+			"orderBy":       orderBy,
+			"pageSize":      min(query.PageSize-len(entries), maxLogPageSize),
+		}
+		if pageToken != "" {
+			requestBody["pageToken"] = pageToken
+		}
+
+		requestBodyJSON, err := json.Marshal(requestBody)
+		if err != nil {
+			return nil, "", fmt.Errorf("error marshaling request body: %w", err)
+		}
+
+		// Construct URL for the Logging API
+		apiURL := fmt.Sprintf("%s/entries:list", gcpLoggingBaseURL)
+		if query.Fields != "" {
+			apiURL += "?fields=" + url.QueryEscape(query.Fields)
+		}
+
+		// Make the API request
+		req, err := http.NewRequestWithContext(ctx, "POST", apiURL, strings.NewReader(string(requestBodyJSON)))
+		if err != nil {
+			return nil, "", fmt.Errorf("error creating request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		// Querying is read-only, so the POST is safe to retry
+		req = markIdempotent(req)
+
+		resp, err := doWithRetry(client, req)
+		if err != nil {
+			return entries, pageToken, fmt.Errorf("error making request to Logging API: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, "", fmt.Errorf("error from Logging API: %s", resp.Status)
+		}
+
+		// Parse the response
+		var response struct {
+			Entries       []logEntry `json:"entries"`
+			NextPageToken string     `json:"nextPageToken"`
+		}
+
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, "", fmt.Errorf("error parsing response: %w", err)
+		}
+
+		entries = append(entries, response.Entries...)
+		pageToken = response.NextPageToken
+
+		if pageToken == "" || len(entries) >= query.PageSize {
+			break
+		}
+	}
+
+	return entries, pageToken, nil
+}
+
+// payloadString returns the string at the given path in a JSON or proto
+// payload, or an empty string if it isn't present
+func payloadString(payload map[string]interface{}, path ...string) string {
+	var current interface{} = payload
+	for _, key := range path {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		current = m[key]
+	}
+
+	switch v := current.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// payloadObjects returns the objects in the array at path in a log entry
+// payload, skipping any elements that aren't objects
+func payloadObjects(payload map[string]interface{}, path ...string) []map[string]interface{} {
+	var current interface{} = payload
+	for _, key := range path {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = m[key]
+	}
+
+	items, _ := current.([]interface{})
+	objects := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		if object, ok := item.(map[string]interface{}); ok {
+			objects = append(objects, object)
+		}
+	}
+	return objects
+}
+
+// loggingHealthMetrics are the Monitoring metrics that indicate log data was lost or incomplete
+var loggingHealthMetrics = []struct {
+	MetricType  string
+	Description string
+}{
+	{"logging.googleapis.com/dropped_log_entry_count", "Log entries dropped before ingestion (e.g. quota or size limits)"},
+	{"logging.googleapis.com/exports/error_count", "Log entries that failed to export through a sink"},
+	{"logging.googleapis.com/logs_based_metric_error_count", "Log entries that logs-based metrics failed to process"},
+}
+
+// sumSeriesPoints totals every point across the given series
+func sumSeriesPoints(series []timeSeries) float64 {
+	total := 0.0
+	for _, ts := range series {
+		for _, p := range ts.Points {
+			total += p.value()
+		}
+	}
+	return total
+}
+
+// handleGetDroppedLogsIndicator handles the get_dropped_logs_indicator tool request
+func handleGetDroppedLogsIndicator(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	timeRangeHours := 24.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	endTime := time.Now()
+	startTime := endTime.Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+
+	result := fmt.Sprintf("# Log Ingestion Health for Project %s\n\n", projectID)
+	result += fmt.Sprintf("Checked the last %.1f hours.\n\n", timeRangeHours)
+	result += "| Signal | Count | Description |\n"
+	result += "| ------ | ----- | ----------- |\n"
+
+	problems := 0
+	for _, metric := range loggingHealthMetrics {
+		series, err := fetchTimeSeries(ctx, client, projectID, timeSeriesQuery{
+			Filter:             fmt.Sprintf(`metric.type="%s"`, metric.MetricType),
+			StartTime:          startTime,
+			EndTime:            endTime,
+			AlignmentPeriod:    time.Hour,
+			PerSeriesAligner:   "ALIGN_SUM",
+			CrossSeriesReducer: "REDUCE_SUM",
+		})
+		if err != nil {
+			result += fmt.Sprintf("| %s | Error: %v | %s |\n", metric.MetricType, err, metric.Description)
+			continue
+		}
+
+		count := sumSeriesPoints(series)
+		countStr := fmt.Sprintf("%.0f", count)
+		if count > 0 {
+			countStr = "**" + countStr + "**"
+			problems++
+		}
+		result += fmt.Sprintf("| %s | %s | %s |\n", metric.MetricType, countStr, metric.Description)
+	}
+
+	if problems == 0 {
+		result += "\nNo dropped entries or ingestion errors were recorded. The logs for this period should be complete.\n"
+		return mcp.NewToolResultText(result), nil
+	}
+
+	result += "\n**Log ingestion problems were recorded in this period, so the log data may be incomplete.** Absence of a log line is not evidence that the event didn't happen.\n"
+	result += "\n## Recommended Actions\n\n"
+	result += "1. Check the Logging quotas page for write-rate or entry-size limits being hit\n"
+	result += "2. For export errors, check the sink destination's permissions and that it still exists\n"
+	result += "3. Corroborate findings with metrics, which are ingested separately from logs\n"
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// monitoredResourceDescriptor describes a resource type that log entries can be attached to
+type monitoredResourceDescriptor struct {
+	Type        string `json:"type"`
+	DisplayName string `json:"displayName"`
+	Description string `json:"description"`
+	Labels      []struct {
+		Key         string `json:"key"`
+		Description string `json:"description"`
+	} `json:"labels"`
+}
+
+// filterResourceDescriptors returns the descriptors whose type starts with prefix, sorted by type
+func filterResourceDescriptors(descriptors []monitoredResourceDescriptor, prefix string) []monitoredResourceDescriptor {
+	var matched []monitoredResourceDescriptor
+	for _, d := range descriptors {
+		if strings.HasPrefix(d.Type, prefix) {
+			matched = append(matched, d)
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].Type < matched[j].Type
+	})
+	return matched
+}
+
+// fetchMonitoredResourceDescriptors lists all resource types known to Cloud Logging
+func fetchMonitoredResourceDescriptors(ctx context.Context, client *http.Client, projectID string) ([]monitoredResourceDescriptor, error) {
+	var descriptors []monitoredResourceDescriptor
+	pageToken := ""
+	for {
+		apiURL := gcpLoggingBaseURL + "/monitoredResourceDescriptors"
+		if pageToken != "" {
+			apiURL += "?pageToken=" + url.QueryEscape(pageToken)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+		// Descriptors aren't project scoped, so attribute quota to the project explicitly
+		req.Header.Set("X-Goog-User-Project", projectID)
+
+		resp, err := doWithRetry(client, req)
+		if err != nil {
+			return nil, fmt.Errorf("error making request to Logging API: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("error from Logging API: %s", resp.Status)
+		}
+
+		var response struct {
+			ResourceDescriptors []monitoredResourceDescriptor `json:"resourceDescriptors"`
+			NextPageToken       string                        `json:"nextPageToken"`
+		}
+
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error parsing response: %w", err)
+		}
+
+		descriptors = append(descriptors, response.ResourceDescriptors...)
+
+		if response.NextPageToken == "" {
+			break
+		}
+		pageToken = response.NextPageToken
+	}
+
+	return descriptors, nil
+}
+
+// handleListMonitoredResourceDescriptors handles the list_monitored_resource_descriptors tool request
+func handleListMonitoredResourceDescriptors(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	prefix, _ := request.Params.Arguments["filter"].(string)
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	descriptors, err := fetchMonitoredResourceDescriptors(ctx, client, projectID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing resource descriptors: %v", err)), nil
+	}
+
+	descriptors = filterResourceDescriptors(descriptors, prefix)
+	if len(descriptors) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No resource types found starting with %q.", prefix)), nil
+	}
+
+	// Format the results
+	result := "# Monitored Resource Types\n\n"
+	result += fmt.Sprintf("Found %d resource types. Filter logs with `resource.type=\"TYPE\"` and `resource.labels.KEY=\"VALUE\"`.\n\n", len(descriptors))
+
+	for _, d := range descriptors {
+		result += fmt.Sprintf("## %s\n\n", d.Type)
+		if d.Description != "" {
+			result += d.Description + "\n\n"
+		}
+		for _, label := range d.Labels {
+			result += fmt.Sprintf("- `%s`: %s\n", label.Key, label.Description)
+		}
+		result += "\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// logContextSpan bounds how far from the target get_logs_context searches, so
+// sparse logs don't scan the whole retention period
+const logContextSpan = time.Hour
+
+// logEntryMessage returns a one-line summary of an entry's payload
+func logEntryMessage(entry logEntry) string {
+	message := entry.TextPayload
+	if message == "" {
+		message = payloadString(entry.JsonPayload, "message")
+	}
+	if message == "" && entry.JsonPayload != nil {
+		if data, err := json.Marshal(entry.JsonPayload); err == nil {
+			message = string(data)
+		}
+	}
+	if message == "" {
+		message = payloadString(entry.ProtoPayload, "methodName")
+	}
+	return strings.Join(strings.Fields(message), " ")
+}
+
+// logContextWindow merges the entries at or before the target (newest first)
+// with those after it (oldest first) into chronological order, keeping n on
+// each side. The target is the entry with insertID, or without one the first
+// entry at exactly target. It returns the index of the target, or -1 if it
+// wasn't found.
+func logContextWindow(before, after []logEntry, target time.Time, insertID string, n int) ([]logEntry, int) {
+	targetIndex := -1
+	for i, entry := range before {
+		if insertID != "" {
+			if entry.InsertID == insertID {
+				targetIndex = i
+				break
+			}
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339Nano, entry.Timestamp); err == nil && t.Equal(target) {
+			targetIndex = i
+			break
+		}
+	}
+
+	var targetEntry *logEntry
+	var earlier []logEntry
+	for i, entry := range before {
+		if i == targetIndex {
+			targetEntry = &before[i]
+			continue
+		}
+		earlier = append(earlier, entry)
+	}
+	if len(earlier) > n {
+		earlier = earlier[:n]
+	}
+	if len(after) > n {
+		after = after[:n]
+	}
+
+	window := make([]logEntry, 0, len(earlier)+len(after)+1)
+	for i := len(earlier) - 1; i >= 0; i-- {
+		window = append(window, earlier[i])
+	}
+	targetIndex = -1
+	if targetEntry != nil {
+		targetIndex = len(window)
+		window = append(window, *targetEntry)
+	}
+	window = append(window, after...)
+
+	return window, targetIndex
+}
+
+// handleGetLogsContext handles the get_logs_context tool request
+func handleGetLogsContext(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	filter, ok := request.Params.Arguments["filter"].(string)
+	if !ok || filter == "" {
+		return mcp.NewToolResultError("filter must be a non-empty string"), nil
+	}
+
+	timestamp, ok := request.Params.Arguments["timestamp"].(string)
+	if !ok || timestamp == "" {
+		return mcp.NewToolResultError("timestamp must be a non-empty string"), nil
+	}
+	target, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return mcp.NewToolResultError("timestamp must be in RFC 3339 format, such as 2024-05-01T12:34:56.789Z"), nil
+	}
+
+	insertID, _ := request.Params.Arguments["insert_id"].(string)
+
+	// Get optional parameters with defaults
+	contextLines := 10
+	if val, ok := request.Params.Arguments["context_lines"].(float64); ok && val > 0 {
+		contextLines = int(val)
+	}
+	if contextLines > 100 {
+		contextLines = 100
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	// Walk backwards from the target, including it and anything sharing its
+	// timestamp, then forwards from just after it
+	targetTime := target.UTC().Format(time.RFC3339Nano)
+	before, _, err := fetchLogEntries(ctx, client, projectID, logQuery{
+		Filter: fmt.Sprintf(`(%s) AND timestamp <= "%s" AND timestamp >= "%s"`,
+			filter, targetTime, target.Add(-logContextSpan).UTC().Format(time.RFC3339Nano)),
+		OrderBy:  "timestamp desc",
+		PageSize: contextLines * 2,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying logs before the target: %v", err)), nil
+	}
+
+	after, _, err := fetchLogEntries(ctx, client, projectID, logQuery{
+		Filter: fmt.Sprintf(`(%s) AND timestamp > "%s" AND timestamp <= "%s"`,
+			filter, targetTime, target.Add(logContextSpan).UTC().Format(time.RFC3339Nano)),
+		OrderBy:  "timestamp asc",
+		PageSize: contextLines,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying logs after the target: %v", err)), nil
+	}
+
+	window, targetIndex := logContextWindow(before, after, target, insertID, contextLines)
+
+	// Format the results
+	if len(window) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No logs matching the filter within %s of %s.", logContextSpan, targetTime)), nil
+	}
+
+	result := fmt.Sprintf("# Logs Around %s\n\n", targetTime)
+	if targetIndex < 0 {
+		if insertID != "" {
+			result += fmt.Sprintf("The entry with insertId %s wasn't found; the target time is marked between the surrounding entries.\n\n", insertID)
+		} else {
+			result += "No entry has exactly the target timestamp; the target time is marked between the surrounding entries.\n\n"
+		}
+	}
+
+	result += "```\n"
+	markerShown := false
+	for i, entry := range window {
+		if targetIndex < 0 && !markerShown {
+			if t, err := time.Parse(time.RFC3339Nano, entry.Timestamp); err == nil && t.After(target) {
+				result += fmt.Sprintf("--> %s (target time)\n", targetTime)
+				markerShown = true
+			}
+		}
+		prefix := "   "
+		if i == targetIndex {
+			prefix = ">>>"
+		}
+		severity := entry.Severity
+		if severity == "" {
+			severity = "DEFAULT"
+		}
+		result += fmt.Sprintf("%s %s %-8s %s\n", prefix, entry.Timestamp, severity, logEntryMessage(entry))
+	}
+	if targetIndex < 0 && !markerShown {
+		result += fmt.Sprintf("--> %s (target time)\n", targetTime)
+	}
+	result += "```\n"
+
 	return mcp.NewToolResultText(result), nil
 }
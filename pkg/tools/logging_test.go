@@ -0,0 +1,229 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+)
+
+func TestHandleGetDroppedLogsIndicator(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter := r.URL.Query().Get("filter")
+		switch {
+		case strings.Contains(filter, "dropped_log_entry_count"):
+			// Every point of every series counts towards the total
+			hourly := testSeries(nil, nil, 40)
+			hourly.Points = append(hourly.Points, testSeries(nil, nil, 2).Points...)
+			writeJSON(w, http.StatusOK, map[string]interface{}{"timeSeries": []timeSeries{hourly, testSeries(nil, nil, 3)}})
+		case strings.Contains(filter, "exports/error_count"):
+			writeJSON(w, http.StatusForbidden, map[string]interface{}{})
+		default:
+			writeJSON(w, http.StatusOK, map[string]interface{}{})
+		}
+	}))
+
+	text := callTool(t, ctx, handleGetDroppedLogsIndicator, map[string]interface{}{"project_id": "test-project"})
+
+	for _, s := range []string{
+		"| logging.googleapis.com/dropped_log_entry_count | **45** |",
+		"| logging.googleapis.com/exports/error_count | Error: error from Monitoring API: 403 Forbidden |",
+		"| logging.googleapis.com/logs_based_metric_error_count | 0 |",
+		"**Log ingestion problems were recorded in this period, so the log data may be incomplete.**",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
+
+func TestHandleGetDroppedLogsIndicatorComplete(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	}))
+
+	text := callTool(t, ctx, handleGetDroppedLogsIndicator, map[string]interface{}{"project_id": "test-project"})
+	if !strings.Contains(text, "The logs for this period should be complete.") {
+		t.Errorf("result doesn't report complete logs:\n%s", text)
+	}
+}
+
+func TestPodLogRequest(t *testing.T) {
+	path, query := podLogRequest("default", "web-0", "app", 7200, 50)
+	if path != "/api/v1/namespaces/default/pods/web-0/log" {
+		t.Errorf("path = %s", path)
+	}
+	if got, want := query.Encode(), "container=app&sinceSeconds=7200&tailLines=50&timestamps=true"; got != want {
+		t.Errorf("query = %s, want %s", got, want)
+	}
+
+	// Unset options are left to the API server's defaults
+	if _, query := podLogRequest("default", "web-0", "", 0, 0); query.Encode() != "timestamps=true" {
+		t.Errorf("query without options = %s, want timestamps=true", query.Encode())
+	}
+}
+
+func TestHandleGetPodLogsLive(t *testing.T) {
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces/default/pods/web-0/log" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		if got, want := r.URL.RawQuery, "container=app&sinceSeconds=1800&tailLines=20&timestamps=true"; got != want {
+			t.Errorf("query = %s, want %s", got, want)
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("2026-10-17T01:00:00Z starting\n2026-10-17T01:00:01Z listening on :8080\n"))
+	}))
+
+	text := callClusterTool(t, context.Background(), handleGetPodLogs, clusterName, map[string]interface{}{
+		"namespace":        "default",
+		"pod_name":         "web-0",
+		"container_name":   "app",
+		"time_range_hours": 0.5,
+		"max_results":      float64(20),
+		"source":           "live",
+	})
+
+	for _, s := range []string{
+		"## Live logs for pod web-0, container app in namespace default",
+		"Last 2 lines from the last 0.5 hours",
+		"```\n2026-10-17T01:00:00Z starting\n2026-10-17T01:00:01Z listening on :8080\n```",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
+
+func TestHandleGetPodLogsRejectsUnknownSource(t *testing.T) {
+	result, err := handleGetPodLogs(context.Background(), newToolRequest(map[string]interface{}{
+		"project_id":   "test-project",
+		"location":     "us-central1",
+		"cluster_name": "test",
+		"namespace":    "default",
+		"pod_name":     "web-0",
+		"source":       "console",
+	}), newTestAuthHandler(t, auth.ReadOnlyScopes))
+	if err != nil {
+		t.Fatalf("handleGetPodLogs returned error: %v", err)
+	}
+	text, _ := resultText(result)
+	if !result.IsError || !strings.Contains(text, `source must be "logging" or "live", got "console"`) {
+		t.Errorf("unknown source wasn't rejected: %s", text)
+	}
+}
+
+func TestHandleListMonitoredResourceDescriptors(t *testing.T) {
+	pages := map[string]string{
+		"": `{"resourceDescriptors": [
+			{"type": "k8s_pod", "description": "A Kubernetes pod instance.", "labels": [{"key": "namespace_name", "description": "The namespace"}, {"key": "pod_name", "description": "The pod"}]},
+			{"type": "gce_instance", "description": "A VM instance."}
+		], "nextPageToken": "page-2"}`,
+		"page-2": `{"resourceDescriptors": [
+			{"type": "k8s_container", "labels": [{"key": "container_name", "description": "The container"}]}
+		]}`,
+	}
+
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/monitoredResourceDescriptors" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		// Descriptors aren't project scoped, so quota is attributed explicitly
+		if got := r.Header.Get("X-Goog-User-Project"); got != "test-project" {
+			t.Errorf("X-Goog-User-Project = %q, want test-project", got)
+		}
+		w.Write([]byte(pages[r.URL.Query().Get("pageToken")]))
+	}))
+
+	text := callTool(t, ctx, handleListMonitoredResourceDescriptors, map[string]interface{}{
+		"project_id": "test-project",
+		"filter":     "k8s_",
+	})
+
+	// Matching types from every page are listed in type order
+	want := "Found 2 resource types. Filter logs with `resource.type=\"TYPE\"` and `resource.labels.KEY=\"VALUE\"`.\n\n" +
+		"## k8s_container\n\n- `container_name`: The container\n\n" +
+		"## k8s_pod\n\nA Kubernetes pod instance.\n\n- `namespace_name`: The namespace\n- `pod_name`: The pod\n"
+	if !strings.Contains(text, want) {
+		t.Errorf("result doesn't contain %q:\n%s", want, text)
+	}
+	if strings.Contains(text, "gce_instance") {
+		t.Errorf("result includes a type outside the filter:\n%s", text)
+	}
+}
+
+func TestHandleGetLogsContext(t *testing.T) {
+	entry := func(timestamp, insertID, severity, message string) string {
+		return `{"timestamp": "` + timestamp + `", "insertId": "` + insertID + `", "severity": "` + severity + `", "textPayload": "` + message + `"}`
+	}
+
+	ctx := withFakeGCP(context.Background(), fakeLogging(t, func(filter string) string {
+		if !strings.HasPrefix(filter, `(resource.labels.pod_name="api-0") AND `) {
+			t.Errorf("filter doesn't select the logs: %s", filter)
+		}
+		switch {
+		case strings.Contains(filter, `timestamp <= "2026-10-17T01:00:00Z" AND timestamp >= "2026-10-17T00:00:00Z"`):
+			// Newest first, including entries sharing the target's timestamp
+			return "[" + strings.Join([]string{
+				entry("2026-10-17T01:00:00Z", "b", "INFO", "retrying"),
+				entry("2026-10-17T01:00:00Z", "a", "ERROR", "connection refused"),
+				entry("2026-10-17T00:59:58Z", "c", "INFO", "request started"),
+				entry("2026-10-17T00:59:50Z", "d", "INFO", "too early"),
+			}, ",") + "]"
+		case strings.Contains(filter, `timestamp > "2026-10-17T01:00:00Z" AND timestamp <= "2026-10-17T02:00:00Z"`):
+			return "[" + strings.Join([]string{
+				entry("2026-10-17T01:00:01Z", "e", "", "reconnected"),
+				entry("2026-10-17T01:00:05Z", "f", "INFO", "request finished"),
+				entry("2026-10-17T01:00:09Z", "g", "INFO", "too late"),
+			}, ",") + "]"
+		}
+		t.Errorf("unexpected filter %s", filter)
+		return "[]"
+	}))
+
+	text := callTool(t, ctx, handleGetLogsContext, map[string]interface{}{
+		"project_id":    "test-project",
+		"filter":        `resource.labels.pod_name="api-0"`,
+		"timestamp":     "2026-10-17T01:00:00Z",
+		"insert_id":     "a",
+		"context_lines": float64(2),
+	})
+
+	// The target is bracketed by context_lines entries on each side
+	want := "```\n" +
+		"    2026-10-17T00:59:58Z INFO     request started\n" +
+		"    2026-10-17T01:00:00Z INFO     retrying\n" +
+		">>> 2026-10-17T01:00:00Z ERROR    connection refused\n" +
+		"    2026-10-17T01:00:01Z DEFAULT  reconnected\n" +
+		"    2026-10-17T01:00:05Z INFO     request finished\n" +
+		"```\n"
+	if !strings.Contains(text, want) {
+		t.Errorf("result doesn't contain %q:\n%s", want, text)
+	}
+}
+
+func TestHandleGetLogsContextMissingTarget(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), fakeLogging(t, func(filter string) string {
+		if strings.Contains(filter, `timestamp > "`) {
+			return `[{"timestamp": "2026-10-17T01:00:05Z", "textPayload": "after"}]`
+		}
+		return `[{"timestamp": "2026-10-17T01:00:00Z", "textPayload": "before"}]`
+	}))
+
+	text := callTool(t, ctx, handleGetLogsContext, map[string]interface{}{
+		"project_id": "test-project",
+		"filter":     `resource.type="k8s_container"`,
+		"timestamp":  "2026-10-17T01:00:03Z",
+	})
+
+	for _, s := range []string{
+		"No entry has exactly the target timestamp",
+		"before\n--> 2026-10-17T01:00:03Z (target time)\n    2026-10-17T01:00:05Z DEFAULT  after\n",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maintenanceMethodFilter matches the System Event audit log entries Compute
+// Engine writes when host maintenance or a host error affects an instance
+const maintenanceMethodFilter = `protoPayload.methodName=("compute.instances.migrateOnHostMaintenance" OR "compute.instances.terminateOnHostMaintenance" OR "compute.instances.hostError")`
+
+// registerMaintenanceTools registers tools that report platform maintenance
+func registerMaintenanceTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get active maintenance events tool
+	getMaintenanceEvents := mcp.NewTool("get_active_maintenance_events",
+		mcp.WithDescription("Reports Compute Engine host maintenance affecting a project: instances (including GKE nodes) with upcoming or ongoing scheduled maintenance and its window, and recent live migrations, maintenance terminations and host errors from System Event audit logs. Helps rule platform maintenance in or out as the cause of an incident."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("zone",
+			mcp.Description("Only check instances in this zone (if not provided, all zones are checked)"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range for past maintenance events in hours (default: 24)"),
+		),
+	)
+
+	getMaintenanceEventsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetActiveMaintenanceEvents(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getMaintenanceEvents, getMaintenanceEventsHandler)
+
+	return nil
+}
+
+// computeInstance is the subset of a Compute Engine Instance used by the tools
+type computeInstance struct {
+	Name       string `json:"name"`
+	Zone       string `json:"zone"`
+	Status     string `json:"status"`
+	Scheduling struct {
+		OnHostMaintenance string `json:"onHostMaintenance"`
+	} `json:"scheduling"`
+	ResourceStatus struct {
+		UpcomingMaintenance *struct {
+			Type              string `json:"type"`
+			MaintenanceStatus string `json:"maintenanceStatus"`
+			CanReschedule     bool   `json:"canReschedule"`
+			WindowStartTime   string `json:"windowStartTime"`
+			WindowEndTime     string `json:"windowEndTime"`
+		} `json:"upcomingMaintenance"`
+	} `json:"resourceStatus"`
+}
+
+// fetchComputeInstances lists a project's instances across all zones,
+// following pagination. A non-empty zone limits the results to that zone.
+func fetchComputeInstances(ctx context.Context, client *http.Client, projectID, zone string) ([]computeInstance, error) {
+	var instances []computeInstance
+	pageToken := ""
+	for {
+		apiURL := fmt.Sprintf("%s/projects/%s/aggregated/instances?maxResults=500", gcpComputeBaseURL, projectID)
+		if pageToken != "" {
+			apiURL += "&pageToken=" + url.QueryEscape(pageToken)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+
+		resp, err := doWithRetry(client, req)
+		if err != nil {
+			return nil, fmt.Errorf("error making request to Compute Engine API: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("error from Compute Engine API: %s", resp.Status)
+		}
+
+		var response struct {
+			Items map[string]struct {
+				Instances []computeInstance `json:"instances"`
+			} `json:"items"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error parsing response: %w", err)
+		}
+
+		for scope, scoped := range response.Items {
+			if zone != "" && scope != "zones/"+zone {
+				continue
+			}
+			instances = append(instances, scoped.Instances...)
+		}
+
+		pageToken = response.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+
+	return instances, nil
+}
+
+// scheduledMaintenance is an instance with upcoming or ongoing maintenance
+type scheduledMaintenance struct {
+	Instance          string
+	Zone              string
+	Type              string
+	Status            string
+	CanReschedule     bool
+	OnHostMaintenance string
+	WindowStart       time.Time
+	WindowEnd         time.Time
+}
+
+// scheduledMaintenances returns the instances with maintenance scheduled,
+// ongoing first and then by window start
+func scheduledMaintenances(instances []computeInstance) []scheduledMaintenance {
+	var scheduled []scheduledMaintenance
+	for _, instance := range instances {
+		upcoming := instance.ResourceStatus.UpcomingMaintenance
+		if upcoming == nil {
+			continue
+		}
+		maintenance := scheduledMaintenance{
+			Instance:          instance.Name,
+			Zone:              lastPathSegment(instance.Zone),
+			Type:              upcoming.Type,
+			Status:            upcoming.MaintenanceStatus,
+			CanReschedule:     upcoming.CanReschedule,
+			OnHostMaintenance: instance.Scheduling.OnHostMaintenance,
+		}
+		maintenance.WindowStart, _ = time.Parse(time.RFC3339, upcoming.WindowStartTime)
+		maintenance.WindowEnd, _ = time.Parse(time.RFC3339, upcoming.WindowEndTime)
+		scheduled = append(scheduled, maintenance)
+	}
+
+	sort.SliceStable(scheduled, func(i, j int) bool {
+		iOngoing, jOngoing := scheduled[i].Status == "ONGOING", scheduled[j].Status == "ONGOING"
+		if iOngoing != jOngoing {
+			return iOngoing
+		}
+		return scheduled[i].WindowStart.Before(scheduled[j].WindowStart)
+	})
+
+	return scheduled
+}
+
+// maintenanceEvent is a past host maintenance event or host error
+type maintenanceEvent struct {
+	Timestamp string
+	Instance  string
+	Zone      string
+	Kind      string
+}
+
+// maintenanceEventKinds describes each System Event method matched by
+// maintenanceMethodFilter
+var maintenanceEventKinds = map[string]string{
+	"compute.instances.migrateOnHostMaintenance":   "Live migrated for host maintenance",
+	"compute.instances.terminateOnHostMaintenance": "Terminated for host maintenance",
+	"compute.instances.hostError":                  "Host error (instance restarted)",
+}
+
+// parseMaintenanceEvents converts System Event audit records into maintenance
+// events, ignoring other methods
+func parseMaintenanceEvents(records []auditRecord) []maintenanceEvent {
+	var events []maintenanceEvent
+	for _, record := range records {
+		kind, ok := maintenanceEventKinds[record.Method]
+		if !ok {
+			continue
+		}
+		// Resource names look like projects/p/zones/z/instances/name
+		zone := ""
+		if _, rest, found := strings.Cut(record.Resource, "/zones/"); found {
+			zone, _, _ = strings.Cut(rest, "/")
+		}
+		events = append(events, maintenanceEvent{
+			Timestamp: record.Timestamp,
+			Instance:  lastPathSegment(record.Resource),
+			Zone:      zone,
+			Kind:      kind,
+		})
+	}
+	return events
+}
+
+// handleGetActiveMaintenanceEvents handles the get_active_maintenance_events tool request
+func handleGetActiveMaintenanceEvents(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	zone, _ := request.Params.Arguments["zone"].(string)
+
+	// Get optional parameters with defaults
+	timeRangeHours := 24.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	instances, err := fetchComputeInstances(ctx, client, projectID, zone)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing instances: %v", err)), nil
+	}
+	scheduled := scheduledMaintenances(instances)
+
+	filter := maintenanceMethodFilter
+	if zone != "" {
+		filter += fmt.Sprintf(` AND resource.labels.zone=%q`, zone)
+	}
+	startTime := time.Now().Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+	records, truncated, err := fetchAuditLogEntries(ctx, client, projectID, []string{auditLogSystemEvent}, filter, startTime, 500)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying system event logs: %v", err)), nil
+	}
+	events := parseMaintenanceEvents(records)
+
+	// Format the results
+	scope := "project " + projectID
+	if zone != "" {
+		scope = fmt.Sprintf("zone %s of project %s", zone, projectID)
+	}
+
+	if len(scheduled) == 0 && len(events) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No scheduled host maintenance on the %d instances in %s, and no maintenance events or host errors in the last %.1f hours.", len(instances), scope, timeRangeHours)), nil
+	}
+
+	result := fmt.Sprintf("# Host Maintenance in %s\n\n", scope)
+	result += fmt.Sprintf("%d of %d instances have maintenance scheduled; %d maintenance events or host errors in the last %.1f hours.\n\n", len(scheduled), len(instances), len(events), timeRangeHours)
+
+	if len(scheduled) > 0 {
+		result += "## Scheduled Maintenance\n\n"
+		result += "| Instance | Zone | Type | Status | Window | On Host Maintenance | Can Reschedule |\n"
+		result += "| -------- | ---- | ---- | ------ | ------ | ------------------- | -------------- |\n"
+		for _, m := range scheduled {
+			window := "-"
+			if !m.WindowStart.IsZero() {
+				window = m.WindowStart.Format(time.RFC3339)
+				if !m.WindowEnd.IsZero() {
+					window += " to " + m.WindowEnd.Format(time.RFC3339)
+				}
+			}
+			status := m.Status
+			if status == "ONGOING" {
+				status = "**ONGOING**"
+			}
+			result += fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %t |\n",
+				m.Instance, m.Zone, valueOrDash(m.Type), valueOrDash(status), window, valueOrDash(m.OnHostMaintenance), m.CanReschedule)
+		}
+		result += "\n"
+	}
+
+	if len(events) > 0 {
+		result += "## Recent Maintenance Events\n\n"
+		result += "| Time | Instance | Zone | Event |\n"
+		result += "| ---- | -------- | ---- | ----- |\n"
+		for _, event := range events {
+			result += fmt.Sprintf("| %s | %s | %s | %s |\n", formatTime(event.Timestamp), event.Instance, valueOrDash(event.Zone), event.Kind)
+		}
+		result += "\n"
+		if truncated {
+			result += "Note: Only the most recent 500 events were analysed. Narrow the time range or zone to see older events.\n\n"
+		}
+	}
+
+	result += "## Recommended Actions\n\n"
+	result += "1. Compare the event times and maintenance windows with the start of the incident; a match on the affected instances points at platform maintenance\n"
+	result += "2. Instances set to TERMINATE on host maintenance (such as those with GPUs) are stopped and restarted, so their workloads are interrupted; live migrated instances usually only see a brief pause\n"
+	result += "3. Where maintenance can be rescheduled, trigger it at a quieter time with `gcloud compute instances perform-maintenance`\n"
+	result += "4. Host errors are unplanned hardware failures; if they repeat, check the Google Cloud status dashboard for the zone\n"
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,88 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestScheduledMaintenances(t *testing.T) {
+	instances := decodeJSON[[]computeInstance](t, `[
+		{"name": "idle", "zone": "zones/us-central1-a"},
+		{"name": "later", "zone": "zones/us-central1-a", "resourceStatus": {"upcomingMaintenance": {"maintenanceStatus": "PENDING", "windowStartTime": "2026-10-18T04:00:00Z"}}},
+		{"name": "sooner", "zone": "zones/us-central1-b", "resourceStatus": {"upcomingMaintenance": {"maintenanceStatus": "PENDING", "windowStartTime": "2026-10-17T22:00:00Z"}}},
+		{"name": "now", "zone": "zones/us-central1-c", "resourceStatus": {"upcomingMaintenance": {"maintenanceStatus": "ONGOING", "windowStartTime": "2026-10-18T06:00:00Z"}}}
+	]`)
+
+	// Ongoing maintenance is listed first, then by window start
+	var got []string
+	for _, m := range scheduledMaintenances(instances) {
+		got = append(got, m.Instance)
+	}
+	if strings.Join(got, ",") != "now,sooner,later" {
+		t.Errorf("scheduledMaintenances = %v, want now, sooner, later", got)
+	}
+}
+
+// maintenanceLog is a System Event audit log entry for an instance
+func maintenanceLog(timestamp, method, instance string) string {
+	return `{"timestamp": "` + timestamp + `", "logName": "projects/test-project/logs/cloudaudit.googleapis.com%2Fsystem_event",
+		"protoPayload": {"methodName": "` + method + `", "resourceName": "projects/test-project/zones/us-central1-a/instances/` + instance + `"}}`
+}
+
+func TestHandleGetActiveMaintenanceEvents(t *testing.T) {
+	logs := fakeLogging(t, func(filter string) string {
+		for _, s := range []string{maintenanceMethodFilter, `resource.labels.zone="us-central1-a"`} {
+			if !strings.Contains(filter, s) {
+				t.Errorf("filter doesn't contain %s: %s", s, filter)
+			}
+		}
+		return "[" + strings.Join([]string{
+			maintenanceLog("2026-10-17T03:10:00Z", "compute.instances.hostError", "gke-pool-b"),
+			maintenanceLog("2026-10-17T02:00:00Z", "compute.instances.migrateOnHostMaintenance", "gke-pool-a"),
+			maintenanceLog("2026-10-17T01:00:00Z", "v1.compute.instances.stop", "gke-pool-a"),
+		}, ",") + "]"
+	})
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host != "compute.googleapis.com" {
+			logs.ServeHTTP(w, r)
+			return
+		}
+		if r.URL.Path != "/compute/v1/projects/test-project/aggregated/instances" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"items": {
+			"zones/us-central1-a": {"instances": [
+				{"name": "gpu-1", "zone": "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a",
+				 "scheduling": {"onHostMaintenance": "TERMINATE"},
+				 "resourceStatus": {"upcomingMaintenance": {"type": "SCHEDULED", "maintenanceStatus": "PENDING", "canReschedule": true,
+					"windowStartTime": "2026-10-18T04:00:00Z", "windowEndTime": "2026-10-18T08:00:00Z"}}},
+				{"name": "gke-pool-a", "zone": "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a"}
+			]},
+			"zones/us-central1-b": {"instances": [
+				{"name": "other-zone", "zone": "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-b",
+				 "resourceStatus": {"upcomingMaintenance": {"maintenanceStatus": "ONGOING"}}}
+			]}
+		}}`))
+	}))
+
+	text := callTool(t, ctx, handleGetActiveMaintenanceEvents, map[string]interface{}{
+		"project_id": "test-project",
+		"zone":       "us-central1-a",
+	})
+
+	for _, s := range []string{
+		"1 of 2 instances have maintenance scheduled; 2 maintenance events or host errors in the last 24.0 hours.",
+		"| gpu-1 | us-central1-a | SCHEDULED | PENDING | 2026-10-18T04:00:00Z to 2026-10-18T08:00:00Z | TERMINATE | true |\n",
+		"| 2026-10-17 03:10:00 | gke-pool-b | us-central1-a | Host error (instance restarted) |\n" +
+			"| 2026-10-17 02:00:00 | gke-pool-a | us-central1-a | Live migrated for host maintenance |\n",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if strings.Contains(text, "other-zone") {
+		t.Errorf("result includes an instance outside the zone:\n%s", text)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"sort"
 	"strings"
 	"time"
 
@@ -38,6 +40,7 @@ func registerMonitoringTools(s *server.MCPServer, authHandler *auth.OAuthHandler
 		mcp.WithNumber("alignment_period_seconds",
 			mcp.Description("Alignment period in seconds (default: 300)"),
 		),
+		withOutputFormat(),
 	)
 
 	queryMetricsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -64,6 +67,80 @@ func registerMonitoringTools(s *server.MCPServer, authHandler *auth.OAuthHandler
 
 	AddToolSafe(s, listAlerts, listAlertsHandler)
 
+	// Register get throttled containers tool
+	getThrottledContainers := mcp.NewTool("get_throttled_containers",
+		mcp.WithDescription("Finds containers in a GKE namespace experiencing significant CPU throttling"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The Kubernetes namespace"),
+		),
+		mcp.WithString("workload",
+			mcp.Description("Only include pods belonging to this workload (matched by pod name prefix)"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range for metrics in hours (default: 1)"),
+		),
+		mcp.WithNumber("threshold",
+			mcp.Description("Throttled periods ratio above which a container is reported, between 0 and 1 (default: 0.25)"),
+		),
+	)
+
+	getThrottledContainersHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetThrottledContainers(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getThrottledContainers, getThrottledContainersHandler)
+
+	// Register list metric descriptors tool
+	listMetricDescriptors := mcp.NewTool("list_metric_descriptors",
+		mcp.WithDescription("Lists available metric types with their kind, value type, unit and label keys, to discover what query_metrics can query"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("filter",
+			mcp.Description("A metric type prefix (e.g., kubernetes.io/container/) or a full Monitoring filter expression"),
+		),
+		mcp.WithNumber("max_results",
+			mcp.Description("Maximum number of descriptors to return (default: 50)"),
+		),
+	)
+
+	listMetricDescriptorsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleListMetricDescriptors(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, listMetricDescriptors, listMetricDescriptorsHandler)
+
+	// Register tools for services observed through Monitoring
+	if err := registerCDNTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerMonitoringGroupTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerIngestionDelayTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerUptimeLatencyTools(s, authHandler); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -94,6 +171,11 @@ func handleQueryMetrics(ctx context.Context, request mcp.CallToolRequest, authHa
 		alignmentPeriodSeconds = val
 	}
 
+	format, err := outputFormat(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
 	// Get HTTP client with authentication
 	client, err := authHandler.GetClient(ctx)
 	if err != nil {
@@ -140,7 +222,10 @@ func handleQueryMetrics(ctx context.Context, request mcp.CallToolRequest, authHa
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := client.Do(req)
+	// Querying is read-only, so the POST is safe to retry
+	req = markIdempotent(req)
+
+	resp, err := doWithRetry(client, req)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Error making request to Monitoring API: %v", err)), nil
 	}
@@ -192,6 +277,10 @@ func handleQueryMetrics(ctx context.Context, request mcp.CallToolRequest, authHa
 	}
 
 	// Format the results
+	if format == outputJSON {
+		return jsonResult(response)
+	}
+
 	var result string
 	if len(response.TimeSeriesData) == 0 {
 		result = fmt.Sprintf("No metrics data found for metric type %s in the specified time range.", metricType)
@@ -273,6 +362,50 @@ func handleQueryMetrics(ctx context.Context, request mcp.CallToolRequest, authHa
 	return mcp.NewToolResultText(result), nil
 }
 
+// monitoringIncident is an alerting incident from the Monitoring API
+type monitoringIncident struct {
+	Name                string `json:"name"`
+	ResourceName        string `json:"resourceName"`
+	PolicyName          string `json:"policyName"`
+	ConditionName       string `json:"conditionName"`
+	StartTime           string `json:"startTime"`
+	EndTime             string `json:"endTime"`
+	State               string `json:"state"`
+	Summary             string `json:"summary"`
+	Documentation       string `json:"documentation"`
+	Severity            string `json:"severity"`
+	ResourceDisplayName string `json:"resourceDisplayName"`
+}
+
+// fetchIncidents lists the alerting incidents in the project
+func fetchIncidents(ctx context.Context, client *http.Client, projectID string) ([]monitoringIncident, error) {
+	apiURL := fmt.Sprintf("%s/projects/%s/incidents", gcpMonitoringBaseURL, projectID)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := doWithRetry(client, req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to Monitoring API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error from Monitoring API: %s", resp.Status)
+	}
+
+	var response struct {
+		Incidents []monitoringIncident `json:"incidents"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("error parsing response: %w", err)
+	}
+
+	return response.Incidents, nil
+}
+
 // handleListAlerts handles the list_alerts tool request
 func handleListAlerts(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
 	// Extract parameters
@@ -302,7 +435,7 @@ func handleListAlerts(ctx context.Context, request mcp.CallToolRequest, authHand
 		return mcp.NewToolResultError(fmt.Sprintf("Error creating request: %v", err)), nil
 	}
 
-	resp, err := client.Do(req)
+	resp, err := doWithRetry(client, req)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Error making request to Monitoring API: %v", err)), nil
 	}
@@ -334,42 +467,9 @@ func handleListAlerts(ctx context.Context, request mcp.CallToolRequest, authHand
 	}
 
 	// Get active incidents
-	incidentsURL := fmt.Sprintf("%s/projects/%s/incidents", gcpMonitoringBaseURL, projectID)
-
-	incidentsReq, err := http.NewRequestWithContext(ctx, "GET", incidentsURL, nil)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error creating incidents request: %v", err)), nil
-	}
-
-	incidentsResp, err := client.Do(incidentsReq)
+	incidents, err := fetchIncidents(ctx, client, projectID)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error making request to Monitoring API for incidents: %v", err)), nil
-	}
-	defer incidentsResp.Body.Close()
-
-	if incidentsResp.StatusCode != http.StatusOK {
-		return mcp.NewToolResultError(fmt.Sprintf("Error from Monitoring API for incidents: %s", incidentsResp.Status)), nil
-	}
-
-	// Parse the incidents response
-	var incidentsResponse struct {
-		Incidents []struct {
-			Name                string `json:"name"`
-			ResourceName        string `json:"resourceName"`
-			PolicyName          string `json:"policyName"`
-			ConditionName       string `json:"conditionName"`
-			StartTime           string `json:"startTime"`
-			EndTime             string `json:"endTime"`
-			State               string `json:"state"`
-			Summary             string `json:"summary"`
-			Documentation       string `json:"documentation"`
-			Severity            string `json:"severity"`
-			ResourceDisplayName string `json:"resourceDisplayName"`
-		} `json:"incidents"`
-	}
-
-	if err := json.NewDecoder(incidentsResp.Body).Decode(&incidentsResponse); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error parsing incidents response: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting incidents: %v", err)), nil
 	}
 
 	// Create a map of policy names to policies for quick lookup
@@ -400,7 +500,7 @@ func handleListAlerts(ctx context.Context, request mcp.CallToolRequest, authHand
 	// Format the results
 	var result string
 	activeIncidents := 0
-	for _, incident := range incidentsResponse.Incidents {
+	for _, incident := range incidents {
 		if incident.State == "OPEN" {
 			activeIncidents++
 		}
@@ -412,7 +512,7 @@ func handleListAlerts(ctx context.Context, request mcp.CallToolRequest, authHand
 		result = fmt.Sprintf("# Active Alerts in Project %s\n\n", projectID)
 		result += fmt.Sprintf("Found %d active alerts:\n\n", activeIncidents)
 
-		for i, incident := range incidentsResponse.Incidents {
+		for i, incident := range incidents {
 			if incident.State != "OPEN" {
 				continue
 			}
@@ -471,3 +571,495 @@ func formatTime(timeStr string) string {
 	}
 	return t.Format("2006-01-02 15:04:05")
 }
+
+// timeSeries is a single series returned by the Monitoring timeSeries.list API
+type timeSeries struct {
+	Metric struct {
+		Type   string            `json:"type"`
+		Labels map[string]string `json:"labels"`
+	} `json:"metric"`
+	Resource struct {
+		Type   string            `json:"type"`
+		Labels map[string]string `json:"labels"`
+	} `json:"resource"`
+	MetricKind string            `json:"metricKind"`
+	ValueType  string            `json:"valueType"`
+	Points     []timeSeriesPoint `json:"points"`
+}
+
+// timeSeriesPoint is a single data point in a time series
+type timeSeriesPoint struct {
+	Interval struct {
+		StartTime string `json:"startTime"`
+		EndTime   string `json:"endTime"`
+	} `json:"interval"`
+	Value struct {
+		DoubleValue       *float64 `json:"doubleValue"`
+		Int64Value        string   `json:"int64Value"`
+		BoolValue         *bool    `json:"boolValue"`
+		DistributionValue *struct {
+			Count string  `json:"count"`
+			Mean  float64 `json:"mean"`
+		} `json:"distributionValue"`
+	} `json:"value"`
+}
+
+// value returns the numeric value of the point, using the mean for distributions
+func (p timeSeriesPoint) value() float64 {
+	switch {
+	case p.Value.DoubleValue != nil:
+		return *p.Value.DoubleValue
+	case p.Value.Int64Value != "":
+		var v float64
+		fmt.Sscanf(p.Value.Int64Value, "%g", &v)
+		return v
+	case p.Value.DistributionValue != nil:
+		return p.Value.DistributionValue.Mean
+	case p.Value.BoolValue != nil && *p.Value.BoolValue:
+		return 1
+	}
+	return 0
+}
+
+// latestValue returns the most recent point value of a series. The Monitoring
+// API returns points newest first.
+func (ts timeSeries) latestValue() (float64, bool) {
+	if len(ts.Points) == 0 {
+		return 0, false
+	}
+	return ts.Points[0].value(), true
+}
+
+// timeSeriesQuery describes a timeSeries.list request
+type timeSeriesQuery struct {
+	Filter             string
+	StartTime          time.Time
+	EndTime            time.Time
+	AlignmentPeriod    time.Duration
+	PerSeriesAligner   string
+	CrossSeriesReducer string
+	GroupByFields      []string
+}
+
+// fetchTimeSeries lists all time series matching the query, following
+// pagination. If a page fails, the series collected so far are returned with
+// the error, so callers can show partial results when the call is cancelled.
+func fetchTimeSeries(ctx context.Context, client *http.Client, projectID string, query timeSeriesQuery) ([]timeSeries, error) {
+	params := url.Values{}
+	params.Set("filter", query.Filter)
+	params.Set("interval.startTime", query.StartTime.UTC().Format(time.RFC3339))
+	params.Set("interval.endTime", query.EndTime.UTC().Format(time.RFC3339))
+	if query.AlignmentPeriod > 0 {
+		params.Set("aggregation.alignmentPeriod", fmt.Sprintf("%.0fs", query.AlignmentPeriod.Seconds()))
+	}
+	if query.PerSeriesAligner != "" {
+		params.Set("aggregation.perSeriesAligner", query.PerSeriesAligner)
+	}
+	if query.CrossSeriesReducer != "" {
+		params.Set("aggregation.crossSeriesReducer", query.CrossSeriesReducer)
+	}
+	for _, field := range query.GroupByFields {
+		params.Add("aggregation.groupByFields", field)
+	}
+
+	var series []timeSeries
+	for {
+		// Stop between pages once the caller has lost interest
+		if err := ctx.Err(); err != nil {
+			return series, err
+		}
+
+		apiURL := fmt.Sprintf("%s/projects/%s/timeSeries?%s", gcpMonitoringBaseURL, projectID, params.Encode())
+
+		req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+
+		resp, err := doWithRetry(client, req)
+		if err != nil {
+			return series, fmt.Errorf("error making request to Monitoring API: %w", err)
+		}
+
+		var response struct {
+			TimeSeries    []timeSeries `json:"timeSeries"`
+			NextPageToken string       `json:"nextPageToken"`
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("error from Monitoring API: %s", resp.Status)
+		}
+
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error parsing response: %w", err)
+		}
+
+		series = append(series, response.TimeSeries...)
+
+		if response.NextPageToken == "" {
+			break
+		}
+		params.Set("pageToken", response.NextPageToken)
+	}
+
+	return series, nil
+}
+
+// throttledContainer summarises CPU throttling for a single container
+type throttledContainer struct {
+	Pod              string
+	Container        string
+	ThrottledRatio   float64
+	LimitUtilisation float64
+	HasLimitMetric   bool
+}
+
+// findThrottledContainers joins throttled and total CFS period rates by
+// pod/container and returns the containers whose throttled ratio exceeds the
+// threshold, most throttled first
+func findThrottledContainers(throttled, periods, limitUtilisation []timeSeries, workload string, threshold float64) []throttledContainer {
+	key := func(pod, container string) string { return pod + "/" + container }
+
+	totalPeriods := make(map[string]float64)
+	for _, ts := range periods {
+		if v, ok := ts.latestValue(); ok {
+			totalPeriods[key(ts.Metric.Labels["pod"], ts.Metric.Labels["container"])] = v
+		}
+	}
+
+	limits := make(map[string]float64)
+	for _, ts := range limitUtilisation {
+		if v, ok := ts.latestValue(); ok {
+			limits[key(ts.Resource.Labels["pod_name"], ts.Resource.Labels["container_name"])] = v
+		}
+	}
+
+	var containers []throttledContainer
+	for _, ts := range throttled {
+		pod := ts.Metric.Labels["pod"]
+		container := ts.Metric.Labels["container"]
+		if workload != "" && !strings.HasPrefix(pod, workload) {
+			continue
+		}
+
+		throttledRate, ok := ts.latestValue()
+		if !ok {
+			continue
+		}
+		total := totalPeriods[key(pod, container)]
+		if total <= 0 {
+			continue
+		}
+
+		ratio := throttledRate / total
+		if ratio <= threshold {
+			continue
+		}
+
+		limit, hasLimit := limits[key(pod, container)]
+		containers = append(containers, throttledContainer{
+			Pod:              pod,
+			Container:        container,
+			ThrottledRatio:   ratio,
+			LimitUtilisation: limit,
+			HasLimitMetric:   hasLimit,
+		})
+	}
+
+	sort.Slice(containers, func(i, j int) bool {
+		return containers[i].ThrottledRatio > containers[j].ThrottledRatio
+	})
+
+	return containers
+}
+
+// handleGetThrottledContainers handles the get_throttled_containers tool request
+func handleGetThrottledContainers(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	namespace, ok := request.Params.Arguments["namespace"].(string)
+	if !ok || namespace == "" {
+		return mcp.NewToolResultError("namespace must be a non-empty string"), nil
+	}
+
+	// Get optional parameters
+	workload, _ := request.Params.Arguments["workload"].(string)
+
+	// Get optional parameters with defaults
+	timeRangeHours := 1.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	threshold := 0.25
+	if val, ok := request.Params.Arguments["threshold"].(float64); ok {
+		if val <= 0 || val > 1 {
+			return mcp.NewToolResultError(fmt.Sprintf("threshold is a ratio of CPU periods and must be between 0 and 1, got %g", val)), nil
+		}
+		threshold = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	// Calculate time range
+	endTime := time.Now()
+	startTime := endTime.Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+
+	// cAdvisor CFS counters are exported through Managed Service for Prometheus
+	promFilter := fmt.Sprintf(`resource.type="prometheus_target" AND resource.labels.location="%s" AND resource.labels.cluster="%s" AND resource.labels.namespace="%s"`,
+		location, clusterName, namespace)
+	promQuery := timeSeriesQuery{
+		StartTime:          startTime,
+		EndTime:            endTime,
+		AlignmentPeriod:    endTime.Sub(startTime),
+		PerSeriesAligner:   "ALIGN_RATE",
+		CrossSeriesReducer: "REDUCE_SUM",
+		GroupByFields:      []string{"metric.labels.pod", "metric.labels.container"},
+	}
+
+	throttledQuery := promQuery
+	throttledQuery.Filter = `metric.type="prometheus.googleapis.com/container_cpu_cfs_throttled_periods_total/counter" AND ` + promFilter
+	throttled, err := fetchTimeSeries(ctx, client, projectID, throttledQuery)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying throttled periods: %v", err)), nil
+	}
+
+	periodsQuery := promQuery
+	periodsQuery.Filter = `metric.type="prometheus.googleapis.com/container_cpu_cfs_periods_total/counter" AND ` + promFilter
+	periods, err := fetchTimeSeries(ctx, client, projectID, periodsQuery)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying CFS periods: %v", err)), nil
+	}
+
+	limitUtilisation, err := fetchTimeSeries(ctx, client, projectID, timeSeriesQuery{
+		Filter: fmt.Sprintf(`metric.type="kubernetes.io/container/cpu/limit_utilization" AND resource.type="k8s_container" AND resource.labels.location="%s" AND resource.labels.cluster_name="%s" AND resource.labels.namespace_name="%s"`,
+			location, clusterName, namespace),
+		StartTime:        startTime,
+		EndTime:          endTime,
+		AlignmentPeriod:  endTime.Sub(startTime),
+		PerSeriesAligner: "ALIGN_MEAN",
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying CPU limit utilization: %v", err)), nil
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# CPU Throttling in Namespace %s (cluster %s)\n\n", namespace, clusterName)
+
+	if len(throttled) == 0 || len(periods) == 0 {
+		result += "No CFS throttling metrics were found. These come from cAdvisor via Managed Service for Prometheus, which must be enabled on the cluster.\n\n"
+
+		result += "## Containers Near Their CPU Limit\n\n"
+		found := false
+		for _, ts := range limitUtilisation {
+			pod := ts.Resource.Labels["pod_name"]
+			if workload != "" && !strings.HasPrefix(pod, workload) {
+				continue
+			}
+			if v, ok := ts.latestValue(); ok && v >= 0.9 {
+				result += fmt.Sprintf("- **%s/%s**: %.1f%% of CPU limit\n", pod, ts.Resource.Labels["container_name"], v*100)
+				found = true
+			}
+		}
+		if !found {
+			result += "No containers are averaging above 90% of their CPU limit.\n"
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+
+	containers := findThrottledContainers(throttled, periods, limitUtilisation, workload, threshold)
+	if len(containers) == 0 {
+		result += fmt.Sprintf("No containers were throttled in more than %.0f%% of CPU periods over the last %.1f hours.\n", threshold*100, timeRangeHours)
+		return mcp.NewToolResultText(result), nil
+	}
+
+	result += fmt.Sprintf("Found %d containers throttled in more than %.0f%% of CPU periods over the last %.1f hours:\n\n", len(containers), threshold*100, timeRangeHours)
+	result += "| Pod | Container | Throttled Periods | CPU Limit Utilization |\n"
+	result += "| --- | --------- | ----------------- | --------------------- |\n"
+	for _, c := range containers {
+		limit := "N/A"
+		if c.HasLimitMetric {
+			limit = fmt.Sprintf("%.1f%%", c.LimitUtilisation*100)
+		}
+		result += fmt.Sprintf("| %s | %s | %.1f%% | %s |\n", c.Pod, c.Container, c.ThrottledRatio*100, limit)
+	}
+
+	result += "\n## Recommended Actions\n\n"
+	result += "1. Throttling with low average utilization means bursts are hitting the CPU limit; consider raising or removing the limit\n"
+	result += "2. Check whether request latency for these workloads correlates with the throttled periods\n"
+	result += "3. Review thread pool or GOMAXPROCS settings that may exceed the container's CPU limit\n"
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// metricDescriptor describes a metric type available in Cloud Monitoring
+type metricDescriptor struct {
+	Type        string `json:"type"`
+	MetricKind  string `json:"metricKind"`
+	ValueType   string `json:"valueType"`
+	Unit        string `json:"unit"`
+	Description string `json:"description"`
+	DisplayName string `json:"displayName"`
+	Labels      []struct {
+		Key         string `json:"key"`
+		Description string `json:"description"`
+	} `json:"labels"`
+	MonitoredResourceTypes []string `json:"monitoredResourceTypes"`
+	Metadata               struct {
+		// SamplePeriod and IngestDelay are durations such as "60s"
+		SamplePeriod string `json:"samplePeriod"`
+		IngestDelay  string `json:"ingestDelay"`
+	} `json:"metadata"`
+}
+
+// labelKeys returns the metric label keys usable in filters as metric.labels.KEY
+func (d metricDescriptor) labelKeys() []string {
+	keys := make([]string, 0, len(d.Labels))
+	for _, label := range d.Labels {
+		keys = append(keys, label.Key)
+	}
+	return keys
+}
+
+// descriptorFilter turns a type prefix into a Monitoring filter on field,
+// passing through anything that already looks like a filter expression
+func descriptorFilter(field, filter string) string {
+	if filter == "" || strings.ContainsAny(filter, "=:(") {
+		return filter
+	}
+	return fmt.Sprintf("%s = starts_with(%q)", field, filter)
+}
+
+// fetchMetricDescriptors lists metric descriptors matching filter, up to maxResults
+func fetchMetricDescriptors(ctx context.Context, client *http.Client, projectID, filter string, maxResults int) ([]metricDescriptor, bool, error) {
+	params := url.Values{}
+	if filter != "" {
+		params.Set("filter", filter)
+	}
+
+	var descriptors []metricDescriptor
+	for {
+		apiURL := fmt.Sprintf("%s/projects/%s/metricDescriptors?%s", gcpMonitoringBaseURL, projectID, params.Encode())
+
+		req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+		if err != nil {
+			return nil, false, fmt.Errorf("error creating request: %w", err)
+		}
+
+		resp, err := doWithRetry(client, req)
+		if err != nil {
+			return nil, false, fmt.Errorf("error making request to Monitoring API: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, false, fmt.Errorf("error from Monitoring API: %s", resp.Status)
+		}
+
+		var response struct {
+			MetricDescriptors []metricDescriptor `json:"metricDescriptors"`
+			NextPageToken     string             `json:"nextPageToken"`
+		}
+
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, false, fmt.Errorf("error parsing response: %w", err)
+		}
+
+		descriptors = append(descriptors, response.MetricDescriptors...)
+		if len(descriptors) >= maxResults {
+			return descriptors[:maxResults], len(descriptors) > maxResults || response.NextPageToken != "", nil
+		}
+
+		if response.NextPageToken == "" {
+			break
+		}
+		params.Set("pageToken", response.NextPageToken)
+	}
+
+	return descriptors, false, nil
+}
+
+// handleListMetricDescriptors handles the list_metric_descriptors tool request
+func handleListMetricDescriptors(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	filter, _ := request.Params.Arguments["filter"].(string)
+
+	maxResults := 50
+	if val, ok := request.Params.Arguments["max_results"].(float64); ok && val > 0 {
+		maxResults = int(val)
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	descriptors, truncated, err := fetchMetricDescriptors(ctx, client, projectID, descriptorFilter("metric.type", filter), maxResults)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing metric descriptors: %v", err)), nil
+	}
+
+	if len(descriptors) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No metric descriptors found matching %q.", filter)), nil
+	}
+
+	// Format the results
+	result := "# Metric Descriptors\n\n"
+	if filter != "" {
+		result += fmt.Sprintf("Matching: `%s`\n\n", filter)
+	}
+	if truncated {
+		result += fmt.Sprintf("Showing the first %d descriptors. Narrow the filter to see more.\n\n", len(descriptors))
+	}
+
+	for _, d := range descriptors {
+		result += fmt.Sprintf("## %s\n\n", d.Type)
+		if d.Description != "" {
+			result += d.Description + "\n\n"
+		}
+		result += fmt.Sprintf("- **Kind**: %s\n", d.MetricKind)
+		result += fmt.Sprintf("- **Value Type**: %s\n", d.ValueType)
+		if d.Unit != "" {
+			result += fmt.Sprintf("- **Unit**: %s\n", d.Unit)
+		}
+		if keys := d.labelKeys(); len(keys) > 0 {
+			result += fmt.Sprintf("- **Labels** (filter as `metric.labels.KEY`): %s\n", strings.Join(keys, ", "))
+		}
+		if len(d.MonitoredResourceTypes) > 0 {
+			result += fmt.Sprintf("- **Resource Types**: %s\n", strings.Join(d.MonitoredResourceTypes, ", "))
+		}
+		result += "\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
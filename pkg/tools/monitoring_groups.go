@@ -0,0 +1,318 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// monitoringGroupMemberLimit caps how many members of a group are listed
+const monitoringGroupMemberLimit = 200
+
+// registerMonitoringGroupTools registers tools for Cloud Monitoring groups
+func registerMonitoringGroupTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get monitoring group members tool
+	getGroupMembers := mcp.NewTool("get_monitoring_group_members",
+		mcp.WithDescription("Lists Cloud Monitoring groups as a hierarchy with their filters, and the current member resources of a given group, to scope an incident to the resources a team owns"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("group_id",
+			mcp.Description("The ID of a group to list the members of (if not provided, only the group hierarchy is listed)"),
+		),
+	)
+
+	getGroupMembersHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetMonitoringGroupMembers(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getGroupMembers, getGroupMembersHandler)
+
+	return nil
+}
+
+// monitoringGroup is a Cloud Monitoring group
+type monitoringGroup struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	ParentName  string `json:"parentName"`
+	Filter      string `json:"filter"`
+	IsCluster   bool   `json:"isCluster"`
+}
+
+// id returns the group ID, the last segment of its name
+func (g monitoringGroup) id() string {
+	return g.Name[strings.LastIndex(g.Name, "/")+1:]
+}
+
+// monitoredResource is a member of a Cloud Monitoring group
+type monitoredResource struct {
+	Type   string            `json:"type"`
+	Labels map[string]string `json:"labels"`
+}
+
+// fetchMonitoringGroups lists all groups in the project
+func fetchMonitoringGroups(ctx context.Context, client *http.Client, projectID string) ([]monitoringGroup, error) {
+	var groups []monitoringGroup
+	pageToken := ""
+	for {
+		apiURL := fmt.Sprintf("%s/projects/%s/groups", gcpMonitoringBaseURL, projectID)
+		if pageToken != "" {
+			apiURL += "?pageToken=" + url.QueryEscape(pageToken)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+
+		resp, err := doWithRetry(client, req)
+		if err != nil {
+			return nil, fmt.Errorf("error making request to Monitoring API: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("error from Monitoring API: %s", resp.Status)
+		}
+
+		var response struct {
+			Group         []monitoringGroup `json:"group"`
+			NextPageToken string            `json:"nextPageToken"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error parsing response: %w", err)
+		}
+
+		groups = append(groups, response.Group...)
+		pageToken = response.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+
+	return groups, nil
+}
+
+// fetchMonitoringGroupMembers lists up to limit members of a group, returning
+// the total number of members
+func fetchMonitoringGroupMembers(ctx context.Context, client *http.Client, groupName string, limit int) ([]monitoredResource, int, error) {
+	var members []monitoredResource
+	total := 0
+	pageToken := ""
+	for {
+		params := url.Values{}
+		params.Set("pageSize", fmt.Sprintf("%d", limit-len(members)))
+		if pageToken != "" {
+			params.Set("pageToken", pageToken)
+		}
+		apiURL := fmt.Sprintf("%s/%s/members?%s", gcpMonitoringBaseURL, groupName, params.Encode())
+
+		req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error creating request: %w", err)
+		}
+
+		resp, err := doWithRetry(client, req)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error making request to Monitoring API: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, 0, fmt.Errorf("error from Monitoring API: %s", resp.Status)
+		}
+
+		var response struct {
+			Members       []monitoredResource `json:"members"`
+			NextPageToken string              `json:"nextPageToken"`
+			TotalSize     int                 `json:"totalSize"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, 0, fmt.Errorf("error parsing response: %w", err)
+		}
+
+		members = append(members, response.Members...)
+		total = response.TotalSize
+		pageToken = response.NextPageToken
+		if pageToken == "" || len(members) >= limit {
+			break
+		}
+	}
+
+	if total < len(members) {
+		total = len(members)
+	}
+	return members, total, nil
+}
+
+// formatGroupHierarchy renders groups as a nested list under their parents.
+// Groups whose parent isn't in the list are treated as top-level.
+func formatGroupHierarchy(groups []monitoringGroup) string {
+	known := make(map[string]bool)
+	for _, group := range groups {
+		known[group.Name] = true
+	}
+
+	children := make(map[string][]monitoringGroup)
+	for _, group := range groups {
+		parent := group.ParentName
+		if !known[parent] {
+			parent = ""
+		}
+		children[parent] = append(children[parent], group)
+	}
+	for _, siblings := range children {
+		sort.Slice(siblings, func(i, j int) bool {
+			return siblings[i].DisplayName < siblings[j].DisplayName
+		})
+	}
+
+	var result string
+	var walk func(parent string, depth int)
+	walk = func(parent string, depth int) {
+		for _, group := range children[parent] {
+			result += fmt.Sprintf("%s- **%s** (`%s`)", strings.Repeat("  ", depth), group.DisplayName, group.id())
+			if group.IsCluster {
+				result += " [cluster]"
+			}
+			if group.Filter != "" {
+				result += fmt.Sprintf(": `%s`", group.Filter)
+			}
+			result += "\n"
+			walk(group.Name, depth+1)
+		}
+	}
+	walk("", 0)
+
+	return result
+}
+
+// groupAncestry returns the display names of a group's ancestors and the group
+// itself, outermost first
+func groupAncestry(groups []monitoringGroup, group monitoringGroup) []string {
+	byName := make(map[string]monitoringGroup)
+	for _, g := range groups {
+		byName[g.Name] = g
+	}
+
+	path := []string{group.DisplayName}
+	seen := map[string]bool{group.Name: true}
+	for parent, ok := byName[group.ParentName]; ok && !seen[parent.Name]; parent, ok = byName[parent.ParentName] {
+		seen[parent.Name] = true
+		path = append([]string{parent.DisplayName}, path...)
+	}
+	return path
+}
+
+// handleGetMonitoringGroupMembers handles the get_monitoring_group_members tool request
+func handleGetMonitoringGroupMembers(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	groupID, _ := request.Params.Arguments["group_id"].(string)
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	groups, err := fetchMonitoringGroups(ctx, client, projectID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing groups: %v", err)), nil
+	}
+
+	if len(groups) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No Monitoring groups found in project %s.", projectID)), nil
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# Monitoring Groups in Project %s\n\n", projectID)
+
+	if groupID == "" {
+		result += fmt.Sprintf("Found %d groups. Nested groups are shown under their parent; a group's members must also match its parent's filter.\n\n", len(groups))
+		result += formatGroupHierarchy(groups)
+		result += "\nPass a group_id to list the group's current members.\n"
+		return mcp.NewToolResultText(result), nil
+	}
+
+	var group monitoringGroup
+	found := false
+	for _, g := range groups {
+		if g.id() == groupID {
+			group = g
+			found = true
+			break
+		}
+	}
+	if !found {
+		return mcp.NewToolResultError(fmt.Sprintf("Group %s not found in project %s", groupID, projectID)), nil
+	}
+
+	members, total, err := fetchMonitoringGroupMembers(ctx, client, group.Name, monitoringGroupMemberLimit)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing group members: %v", err)), nil
+	}
+
+	result += fmt.Sprintf("## Group: %s\n\n", group.DisplayName)
+	result += fmt.Sprintf("- **ID**: %s\n", group.id())
+	result += fmt.Sprintf("- **Hierarchy**: %s\n", strings.Join(groupAncestry(groups, group), " > "))
+	result += fmt.Sprintf("- **Filter**: `%s`\n", group.Filter)
+	result += fmt.Sprintf("- **Members**: %d\n\n", total)
+
+	if len(members) == 0 {
+		result += "The group currently has no members. Check that its filter, and its parents' filters, match existing resources.\n"
+	} else {
+		// Group members by resource type for readability
+		byType := make(map[string][]monitoredResource)
+		var types []string
+		for _, member := range members {
+			if _, ok := byType[member.Type]; !ok {
+				types = append(types, member.Type)
+			}
+			byType[member.Type] = append(byType[member.Type], member)
+		}
+		sort.Strings(types)
+
+		for _, resourceType := range types {
+			result += fmt.Sprintf("### %s (%d)\n\n", resourceType, len(byType[resourceType]))
+			for _, member := range byType[resourceType] {
+				keys := make([]string, 0, len(member.Labels))
+				for key := range member.Labels {
+					keys = append(keys, key)
+				}
+				sort.Strings(keys)
+
+				labels := make([]string, len(keys))
+				for i, key := range keys {
+					labels[i] = fmt.Sprintf("%s=%s", key, member.Labels[key])
+				}
+				result += fmt.Sprintf("- %s\n", strings.Join(labels, ", "))
+			}
+			result += "\n"
+		}
+
+		if total > len(members) {
+			result += fmt.Sprintf("Note: Only the first %d of %d members are shown.\n", len(members), total)
+		}
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
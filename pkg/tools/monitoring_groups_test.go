@@ -0,0 +1,68 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// testMonitoringGroups is a hierarchy of groups: Production contains
+// Frontend, which contains Checkout. Orphan's parent no longer exists.
+const testMonitoringGroups = `{"group": [
+	{"name": "projects/test-project/groups/checkout", "displayName": "Checkout", "parentName": "projects/test-project/groups/frontend", "filter": "resource.metadata.name=starts_with(\"checkout\")"},
+	{"name": "projects/test-project/groups/prod", "displayName": "Production", "filter": "resource.metadata.tag.env=\"prod\"", "isCluster": true},
+	{"name": "projects/test-project/groups/orphan", "displayName": "Orphan", "parentName": "projects/test-project/groups/deleted"},
+	{"name": "projects/test-project/groups/frontend", "displayName": "Frontend", "parentName": "projects/test-project/groups/prod", "filter": "resource.metadata.tag.tier=\"web\""}
+]}`
+
+func TestHandleGetMonitoringGroupMembersHierarchy(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testMonitoringGroups))
+	}))
+
+	text := callTool(t, ctx, handleGetMonitoringGroupMembers, map[string]interface{}{"project_id": "test-project"})
+
+	want := "- **Orphan** (`orphan`)\n" +
+		"- **Production** (`prod`) [cluster]: `resource.metadata.tag.env=\"prod\"`\n" +
+		"  - **Frontend** (`frontend`): `resource.metadata.tag.tier=\"web\"`\n" +
+		"    - **Checkout** (`checkout`): `resource.metadata.name=starts_with(\"checkout\")`\n"
+	if !strings.Contains(text, want) {
+		t.Errorf("result doesn't contain the hierarchy %q:\n%s", want, text)
+	}
+}
+
+func TestHandleGetMonitoringGroupMembers(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/projects/test-project/groups":
+			w.Write([]byte(testMonitoringGroups))
+		case "/v3/projects/test-project/groups/checkout/members":
+			w.Write([]byte(`{"totalSize": 250, "members": [
+				{"type": "gce_instance", "labels": {"zone": "us-central1-a", "instance_id": "123"}},
+				{"type": "k8s_container", "labels": {"container_name": "checkout", "namespace_name": "web"}},
+				{"type": "gce_instance", "labels": {"zone": "us-central1-b", "instance_id": "456"}}
+			]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	text := callTool(t, ctx, handleGetMonitoringGroupMembers, map[string]interface{}{
+		"project_id": "test-project",
+		"group_id":   "checkout",
+	})
+
+	for _, s := range []string{
+		"- **Hierarchy**: Production > Frontend > Checkout\n",
+		"- **Members**: 250\n",
+		"### gce_instance (2)\n\n- instance_id=123, zone=us-central1-a\n- instance_id=456, zone=us-central1-b\n",
+		"### k8s_container (1)\n\n- container_name=checkout, namespace_name=web\n",
+		"Note: Only the first 3 of 250 members are shown.",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
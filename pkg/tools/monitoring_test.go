@@ -0,0 +1,149 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+)
+
+// testSeries returns a time series with a single point
+func testSeries(metricLabels, resourceLabels map[string]string, value float64) timeSeries {
+	var ts timeSeries
+	ts.Metric.Labels = metricLabels
+	ts.Resource.Labels = resourceLabels
+	var point timeSeriesPoint
+	point.Value.DoubleValue = &value
+	ts.Points = []timeSeriesPoint{point}
+	return ts
+}
+
+func TestFindThrottledContainers(t *testing.T) {
+	container := func(pod string) map[string]string {
+		return map[string]string{"pod": pod, "container": "app"}
+	}
+
+	throttled := []timeSeries{
+		testSeries(container("web-1"), nil, 30),
+		testSeries(container("web-2"), nil, 10),
+		testSeries(container("web-3"), nil, 90),
+		testSeries(container("web-4"), nil, 50),
+		testSeries(container("worker-1"), nil, 80),
+	}
+	// web-4 has no periods series, so its ratio is unknown
+	periods := []timeSeries{
+		testSeries(container("web-1"), nil, 100),
+		testSeries(container("web-2"), nil, 100),
+		testSeries(container("web-3"), nil, 100),
+		testSeries(container("worker-1"), nil, 100),
+	}
+	limitUtilisation := []timeSeries{
+		testSeries(nil, map[string]string{"pod_name": "web-1", "container_name": "app"}, 0.4),
+	}
+
+	got := findThrottledContainers(throttled, periods, limitUtilisation, "web", 0.25)
+
+	// web-2 is under the threshold and worker-1 isn't part of the workload
+	want := []throttledContainer{
+		{Pod: "web-3", Container: "app", ThrottledRatio: 0.9},
+		{Pod: "web-1", Container: "app", ThrottledRatio: 0.3, LimitUtilisation: 0.4, HasLimitMetric: true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("findThrottledContainers = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("container %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestHandleGetThrottledContainersThreshold(t *testing.T) {
+	for _, threshold := range []float64{1.5, 25, 0, -0.1} {
+		result, err := handleGetThrottledContainers(context.Background(), newToolRequest(map[string]interface{}{
+			"project_id":   "test-project",
+			"location":     "us-central1",
+			"cluster_name": "web",
+			"namespace":    "default",
+			"threshold":    threshold,
+		}), newTestAuthHandler(t, auth.ReadOnlyScopes))
+		if err != nil {
+			t.Fatalf("handleGetThrottledContainers returned error: %v", err)
+		}
+
+		text, _ := resultText(result)
+		if !result.IsError || !strings.Contains(text, "must be between 0 and 1") {
+			t.Errorf("threshold %g = %q, want it rejected", threshold, text)
+		}
+	}
+}
+
+func TestDescriptorFilter(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   string
+	}{
+		{filter: "", want: ""},
+		{filter: "kubernetes.io/container/", want: `metric.type = starts_with("kubernetes.io/container/")`},
+		{filter: `metric.type = "kubernetes.io/container/restart_count"`, want: `metric.type = "kubernetes.io/container/restart_count"`},
+	}
+
+	for _, tt := range tests {
+		if got := descriptorFilter("metric.type", tt.filter); got != tt.want {
+			t.Errorf("descriptorFilter(%q) = %q, want %q", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestHandleListMetricDescriptors(t *testing.T) {
+	pages := map[string]string{
+		"": `{"metricDescriptors": [
+			{"type": "kubernetes.io/container/restart_count", "metricKind": "CUMULATIVE", "valueType": "INT64", "unit": "1",
+			 "description": "Number of times the container has restarted.",
+			 "labels": [{"key": "container_name"}, {"key": "pod_name"}], "monitoredResourceTypes": ["k8s_container"]}
+		], "nextPageToken": "page-2"}`,
+		"page-2": `{"metricDescriptors": [
+			{"type": "kubernetes.io/container/uptime", "metricKind": "GAUGE", "valueType": "DOUBLE", "unit": "s"},
+			{"type": "kubernetes.io/container/cpu/limit_cores", "metricKind": "GAUGE", "valueType": "DOUBLE"}
+		]}`,
+	}
+
+	var requests int
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/v3/projects/test-project/metricDescriptors" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		if got, want := r.URL.Query().Get("filter"), `metric.type = starts_with("kubernetes.io/container/")`; got != want {
+			t.Errorf("filter = %s, want %s", got, want)
+		}
+		w.Write([]byte(pages[r.URL.Query().Get("pageToken")]))
+	}))
+
+	text := callTool(t, ctx, handleListMetricDescriptors, map[string]interface{}{
+		"project_id":  "test-project",
+		"filter":      "kubernetes.io/container/",
+		"max_results": float64(2),
+	})
+
+	for _, s := range []string{
+		"Showing the first 2 descriptors. Narrow the filter to see more.",
+		"## kubernetes.io/container/restart_count\n\nNumber of times the container has restarted.",
+		"- **Kind**: CUMULATIVE\n- **Value Type**: INT64\n- **Unit**: 1\n",
+		"- **Labels** (filter as `metric.labels.KEY`): container_name, pod_name",
+		"- **Resource Types**: k8s_container",
+		"## kubernetes.io/container/uptime",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if strings.Contains(text, "limit_cores") {
+		t.Errorf("result has more than max_results descriptors:\n%s", text)
+	}
+	if requests != 2 {
+		t.Errorf("made %d requests, want both pages", requests)
+	}
+}
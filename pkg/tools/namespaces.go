@@ -0,0 +1,177 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerNamespaceTools registers tools that inspect namespace-level policy objects
+func registerNamespaceTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get effective resource quotas tool
+	getResourceQuotas := mcp.NewTool("get_effective_resource_quotas",
+		mcp.WithDescription("Reports ResourceQuota hard limits vs usage in a namespace, flagging resources at or near their quota"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The Kubernetes namespace"),
+		),
+	)
+
+	getResourceQuotasHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetResourceQuotas(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getResourceQuotas, getResourceQuotasHandler)
+
+	return nil
+}
+
+// quotaNearThreshold is the usage ratio at which a quota is reported as nearly exhausted
+const quotaNearThreshold = 0.9
+
+// kubeResourceQuota is the subset of a core/v1 ResourceQuota used by the tools
+type kubeResourceQuota struct {
+	Metadata kubeObjectMeta `json:"metadata"`
+	Status   struct {
+		Hard map[string]string `json:"hard"`
+		Used map[string]string `json:"used"`
+	} `json:"status"`
+}
+
+// quotaUsage is the usage of a single resource within a quota
+type quotaUsage struct {
+	Resource string
+	Hard     string
+	Used     string
+	Ratio    float64
+	Status   string
+}
+
+// summariseQuota compares used against hard for each resource in the quota,
+// returning the resources sorted by how close they are to their limit
+func summariseQuota(quota kubeResourceQuota) []quotaUsage {
+	var usages []quotaUsage
+	for resource, hard := range quota.Status.Hard {
+		used := quota.Status.Used[resource]
+		if used == "" {
+			used = "0"
+		}
+
+		usage := quotaUsage{Resource: resource, Hard: hard, Used: used, Status: "OK"}
+
+		hardValue, hardErr := parseQuantity(hard)
+		usedValue, usedErr := parseQuantity(used)
+		switch {
+		case hardErr != nil || usedErr != nil:
+			usage.Status = "Unknown"
+		case hardValue == 0:
+			usage.Ratio = 1
+			usage.Status = "EXHAUSTED"
+		default:
+			usage.Ratio = usedValue / hardValue
+			if usage.Ratio >= 1 {
+				usage.Status = "EXHAUSTED"
+			} else if usage.Ratio >= quotaNearThreshold {
+				usage.Status = "NEAR LIMIT"
+			}
+		}
+
+		usages = append(usages, usage)
+	}
+
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].Ratio != usages[j].Ratio {
+			return usages[i].Ratio > usages[j].Ratio
+		}
+		return usages[i].Resource < usages[j].Resource
+	})
+
+	return usages
+}
+
+// handleGetResourceQuotas handles the get_effective_resource_quotas tool request
+func handleGetResourceQuotas(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	namespace, ok := request.Params.Arguments["namespace"].(string)
+	if !ok || namespace == "" {
+		return mcp.NewToolResultError("namespace must be a non-empty string"), nil
+	}
+
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error connecting to cluster: %v", err)), nil
+	}
+
+	var quotaList struct {
+		Items []kubeResourceQuota `json:"items"`
+	}
+	if err := kube.get(ctx, fmt.Sprintf("/api/v1/namespaces/%s/resourcequotas", namespace), nil, &quotaList); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing resource quotas: %v", err)), nil
+	}
+
+	// Format the results
+	if len(quotaList.Items) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No ResourceQuotas are defined in namespace %s, so quotas can't be blocking pod creation.", namespace)), nil
+	}
+
+	result := fmt.Sprintf("# Resource Quotas in Namespace %s\n\n", namespace)
+
+	blocked := 0
+	for _, quota := range quotaList.Items {
+		result += fmt.Sprintf("## %s\n\n", quota.Metadata.Name)
+		result += "| Resource | Used | Hard | Usage | Status |\n"
+		result += "| -------- | ---- | ---- | ----- | ------ |\n"
+
+		for _, usage := range summariseQuota(quota) {
+			status := usage.Status
+			if status == "EXHAUSTED" || status == "NEAR LIMIT" {
+				status = "**" + status + "**"
+				blocked++
+			}
+			result += fmt.Sprintf("| %s | %s | %s | %.0f%% | %s |\n",
+				usage.Resource, usage.Used, usage.Hard, usage.Ratio*100, status)
+		}
+
+		result += "\n"
+	}
+
+	if blocked > 0 {
+		result += "## Recommended Actions\n\n"
+		result += "1. Pods requesting an exhausted resource are rejected with an \"exceeded quota\" error; check the ReplicaSet or Job events\n"
+		result += "2. Remove completed or unused pods, PVCs, or services to free quota\n"
+		result += "3. If the workload legitimately needs more, raise the ResourceQuota hard limits\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSummariseQuota(t *testing.T) {
+	var quota kubeResourceQuota
+	quota.Status.Hard = map[string]string{
+		"requests.cpu":    "4",
+		"requests.memory": "8Gi",
+		"pods":            "10",
+		"services":        "0",
+		"count/jobs":      "bogus",
+	}
+	quota.Status.Used = map[string]string{
+		"requests.cpu":    "3800m",
+		"requests.memory": "2Gi",
+		"pods":            "10",
+		"count/jobs":      "1",
+	}
+
+	var got []string
+	for _, usage := range summariseQuota(quota) {
+		got = append(got, usage.Resource+" "+usage.Used+"/"+usage.Hard+" "+usage.Status)
+	}
+
+	// Ordered by usage ratio; a zero hard limit is exhausted and an
+	// unparseable quantity is unknown
+	want := []string{
+		"pods 10/10 EXHAUSTED",
+		"services 0/0 EXHAUSTED",
+		"requests.cpu 3800m/4 NEAR LIMIT",
+		"requests.memory 2Gi/8Gi OK",
+		"count/jobs 1/bogus Unknown",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("summariseQuota =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestHandleGetResourceQuotas(t *testing.T) {
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces/team-a/resourcequotas" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"items": []map[string]interface{}{{
+			"metadata": map[string]string{"name": "compute"},
+			"status": map[string]interface{}{
+				"hard": map[string]string{"pods": "10"},
+				"used": map[string]string{"pods": "10"},
+			},
+		}}})
+	}))
+
+	text := callClusterTool(t, context.Background(), handleGetResourceQuotas, clusterName, map[string]interface{}{
+		"namespace": "team-a",
+	})
+
+	for _, s := range []string{"## compute", "| pods | 10 | 10 | 100% | **EXHAUSTED** |", "exceeded quota"} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
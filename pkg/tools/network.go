@@ -0,0 +1,405 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// GCP Certificate Manager API base URL
+	gcpCertificateManagerBaseURL = "https://certificatemanager.googleapis.com/v1"
+
+	// certificateExpiryWarning is how close to expiry a self-managed
+	// certificate is flagged
+	certificateExpiryWarning = 30 * 24 * time.Hour
+)
+
+// registerNetworkTools registers all load balancing and network related tools
+func registerNetworkTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get certificate map status tool
+	getCertificateMapStatus := mcp.NewTool("get_certificate_map_status",
+		mcp.WithDescription("Lists Certificate Manager certificates and certificate maps, reporting each certificate's state, managed domains, DNS or load balancer authorization status and provisioning issues, and flagging FAILED or pending certificates and map entries"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Description("The Certificate Manager location (default: global)"),
+		),
+	)
+
+	getCertificateMapStatusHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetCertificateMapStatus(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getCertificateMapStatus, getCertificateMapStatusHandler)
+
+	if err := registerNEGTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerLoadBalancerErrorTools(s, authHandler); err != nil {
+		return err
+	}
+
+	if err := registerSSLPolicyTools(s, authHandler); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// managedCertificate is the subset of a Certificate Manager Certificate used
+// by the tools
+type managedCertificate struct {
+	Name        string    `json:"name"`
+	SanDnsnames []string  `json:"sanDnsnames"`
+	ExpireTime  string    `json:"expireTime"`
+	SelfManaged *struct{} `json:"selfManaged"`
+	Managed     *struct {
+		Domains           []string `json:"domains"`
+		DnsAuthorizations []string `json:"dnsAuthorizations"`
+		State             string   `json:"state"`
+		ProvisioningIssue *struct {
+			Reason  string `json:"reason"`
+			Details string `json:"details"`
+		} `json:"provisioningIssue"`
+		AuthorizationAttemptInfo []struct {
+			Domain        string `json:"domain"`
+			State         string `json:"state"`
+			FailureReason string `json:"failureReason"`
+			Details       string `json:"details"`
+		} `json:"authorizationAttemptInfo"`
+	} `json:"managed"`
+}
+
+// certificateMap is the subset of a Certificate Manager CertificateMap used
+// by the tools
+type certificateMap struct {
+	Name        string `json:"name"`
+	GclbTargets []struct {
+		TargetHttpsProxy string `json:"targetHttpsProxy"`
+		TargetSslProxy   string `json:"targetSslProxy"`
+	} `json:"gclbTargets"`
+}
+
+// certificateMapEntry is the subset of a Certificate Manager
+// CertificateMapEntry used by the tools
+type certificateMapEntry struct {
+	Name         string   `json:"name"`
+	Hostname     string   `json:"hostname"`
+	Matcher      string   `json:"matcher"`
+	Certificates []string `json:"certificates"`
+	State        string   `json:"state"`
+}
+
+// lastPathSegment returns the final segment of a resource name
+func lastPathSegment(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+// fetchCertificateManagerList lists resources under a Certificate Manager
+// parent, following pagination. itemsKey is the response field holding them.
+func fetchCertificateManagerList[T any](ctx context.Context, client *http.Client, parent, collection, itemsKey string) ([]T, error) {
+	var items []T
+	pageToken := ""
+	for {
+		apiURL := fmt.Sprintf("%s/%s/%s", gcpCertificateManagerBaseURL, parent, collection)
+		if pageToken != "" {
+			apiURL += "?pageToken=" + url.QueryEscape(pageToken)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+
+		resp, err := doWithRetry(client, req)
+		if err != nil {
+			return nil, fmt.Errorf("error making request to Certificate Manager API: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("error from Certificate Manager API: %s", resp.Status)
+		}
+
+		var response map[string]json.RawMessage
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error parsing response: %w", err)
+		}
+
+		if raw, ok := response[itemsKey]; ok {
+			var page []T
+			if err := json.Unmarshal(raw, &page); err != nil {
+				return nil, fmt.Errorf("error parsing response: %w", err)
+			}
+			items = append(items, page...)
+		}
+
+		pageToken = ""
+		if raw, ok := response["nextPageToken"]; ok {
+			_ = json.Unmarshal(raw, &pageToken)
+		}
+		if pageToken == "" {
+			break
+		}
+	}
+
+	return items, nil
+}
+
+// certificateState returns the state of a certificate. Self-managed
+// certificates have no provisioning state, so they are ACTIVE until they expire.
+func certificateState(cert managedCertificate, now time.Time) string {
+	if cert.Managed != nil {
+		return cert.Managed.State
+	}
+	if expiry, err := time.Parse(time.RFC3339, cert.ExpireTime); err == nil && now.After(expiry) {
+		return "EXPIRED"
+	}
+	return "ACTIVE"
+}
+
+// certificateProblems returns what is wrong with a certificate, if anything:
+// failed or stalled provisioning, failed domain authorizations and imminent
+// expiry
+func certificateProblems(cert managedCertificate, now time.Time) []string {
+	var problems []string
+
+	if managed := cert.Managed; managed != nil {
+		switch managed.State {
+		case "ACTIVE":
+		case "FAILED":
+			problems = append(problems, "provisioning FAILED")
+		default:
+			problems = append(problems, fmt.Sprintf("still %s, not yet serving", managed.State))
+		}
+
+		if issue := managed.ProvisioningIssue; issue != nil && issue.Reason != "" {
+			problem := "provisioning issue " + issue.Reason
+			if issue.Details != "" {
+				problem += ": " + issue.Details
+			}
+			problems = append(problems, problem)
+		}
+
+		for _, attempt := range managed.AuthorizationAttemptInfo {
+			if attempt.State != "FAILED" {
+				continue
+			}
+			problem := fmt.Sprintf("authorization FAILED for %s", attempt.Domain)
+			if attempt.FailureReason != "" {
+				problem += " (" + attempt.FailureReason + ")"
+			}
+			if attempt.Details != "" {
+				problem += ": " + attempt.Details
+			}
+			problems = append(problems, problem)
+		}
+	}
+
+	if expiry, err := time.Parse(time.RFC3339, cert.ExpireTime); err == nil {
+		if now.After(expiry) {
+			problems = append(problems, fmt.Sprintf("expired on %s", expiry.Format("2006-01-02")))
+		} else if cert.SelfManaged != nil && expiry.Sub(now) < certificateExpiryWarning {
+			// Managed certificates renew themselves, so only self-managed ones are flagged
+			problems = append(problems, fmt.Sprintf("expires on %s and must be replaced manually", expiry.Format("2006-01-02")))
+		}
+	}
+
+	return problems
+}
+
+// certificateAuthorization summarises how a managed certificate's domains are
+// authorized and the state of each attempt
+func certificateAuthorization(cert managedCertificate) string {
+	if cert.Managed == nil {
+		return "self-managed"
+	}
+
+	method := "load balancer"
+	if len(cert.Managed.DnsAuthorizations) > 0 {
+		method = "DNS"
+	}
+
+	var states []string
+	for _, attempt := range cert.Managed.AuthorizationAttemptInfo {
+		states = append(states, fmt.Sprintf("%s %s", attempt.Domain, attempt.State))
+	}
+	if len(states) == 0 {
+		return method
+	}
+	return fmt.Sprintf("%s (%s)", method, strings.Join(states, ", "))
+}
+
+// handleGetCertificateMapStatus handles the get_certificate_map_status tool request
+func handleGetCertificateMapStatus(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	location := "global"
+	if val, ok := request.Params.Arguments["location"].(string); ok && val != "" {
+		location = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	parent := fmt.Sprintf("projects/%s/locations/%s", projectID, location)
+
+	certs, err := fetchCertificateManagerList[managedCertificate](ctx, client, parent, "certificates", "certificates")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing certificates: %v", err)), nil
+	}
+
+	maps, err := fetchCertificateManagerList[certificateMap](ctx, client, parent, "certificateMaps", "certificateMaps")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing certificate maps: %v", err)), nil
+	}
+
+	mapEntries := make(map[string][]certificateMapEntry)
+	for _, certMap := range maps {
+		entries, err := fetchCertificateManagerList[certificateMapEntry](ctx, client, certMap.Name, "certificateMapEntries", "certificateMapEntries")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error listing entries of certificate map %s: %v", lastPathSegment(certMap.Name), err)), nil
+		}
+		mapEntries[certMap.Name] = entries
+	}
+
+	// Format the results
+	if len(certs) == 0 && len(maps) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No Certificate Manager certificates or certificate maps found in project %s (location %s). Load balancers may be using classic Compute Engine SSL certificates instead.", projectID, location)), nil
+	}
+
+	now := time.Now()
+	problems := make(map[string][]string)
+	flagged := 0
+	for _, cert := range certs {
+		if p := certificateProblems(cert, now); len(p) > 0 {
+			problems[cert.Name] = p
+			flagged++
+		}
+	}
+
+	// List certificates with problems first
+	sort.SliceStable(certs, func(i, j int) bool {
+		return len(problems[certs[i].Name]) > 0 && len(problems[certs[j].Name]) == 0
+	})
+
+	result := fmt.Sprintf("# Certificate Manager Status for %s (%s)\n\n", projectID, location)
+	result += fmt.Sprintf("Found %d certificates (%d with problems) and %d certificate maps.\n\n", len(certs), flagged, len(maps))
+
+	if len(certs) > 0 {
+		result += "## Certificates\n\n"
+		result += "| Certificate | State | Domains | Authorization | Expires |\n"
+		result += "| ----------- | ----- | ------- | ------------- | ------- |\n"
+		for _, cert := range certs {
+			state := certificateState(cert, now)
+			if len(problems[cert.Name]) > 0 {
+				state = "**" + state + "**"
+			}
+			domains := cert.SanDnsnames
+			if cert.Managed != nil && len(cert.Managed.Domains) > 0 {
+				domains = cert.Managed.Domains
+			}
+			expires := "-"
+			if t, err := time.Parse(time.RFC3339, cert.ExpireTime); err == nil {
+				expires = t.Format("2006-01-02")
+			}
+			result += fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
+				lastPathSegment(cert.Name), state, strings.Join(domains, ", "), certificateAuthorization(cert), expires)
+		}
+		result += "\n"
+
+		if flagged > 0 {
+			result += "### Problems\n\n"
+			for _, cert := range certs {
+				for _, problem := range problems[cert.Name] {
+					result += fmt.Sprintf("- **%s**: %s\n", lastPathSegment(cert.Name), problem)
+				}
+			}
+			result += "\n"
+		}
+	}
+
+	pendingEntries := 0
+	if len(maps) > 0 {
+		result += "## Certificate Maps\n\n"
+		for _, certMap := range maps {
+			result += fmt.Sprintf("### %s\n\n", lastPathSegment(certMap.Name))
+
+			var targets []string
+			for _, target := range certMap.GclbTargets {
+				switch {
+				case target.TargetHttpsProxy != "":
+					targets = append(targets, lastPathSegment(target.TargetHttpsProxy))
+				case target.TargetSslProxy != "":
+					targets = append(targets, lastPathSegment(target.TargetSslProxy))
+				}
+			}
+			if len(targets) == 0 {
+				result += "- **Attached To**: no load balancer\n"
+			} else {
+				result += fmt.Sprintf("- **Attached To**: %s\n", strings.Join(targets, ", "))
+			}
+
+			entries := mapEntries[certMap.Name]
+			if len(entries) == 0 {
+				result += "- **Entries**: none, so the map serves no certificates\n\n"
+				continue
+			}
+
+			for _, entry := range entries {
+				host := entry.Hostname
+				if host == "" {
+					host = entry.Matcher
+				}
+				var certNames []string
+				for _, cert := range entry.Certificates {
+					name := lastPathSegment(cert)
+					if len(problems[cert]) > 0 {
+						name += " (has problems)"
+					}
+					certNames = append(certNames, name)
+				}
+				state := entry.State
+				if state != "ACTIVE" {
+					state = "**" + state + "**"
+					pendingEntries++
+				}
+				result += fmt.Sprintf("- %s → %s: %s\n", host, strings.Join(certNames, ", "), state)
+			}
+			result += "\n"
+		}
+	}
+
+	if flagged > 0 || pendingEntries > 0 {
+		result += "## Recommended Actions\n\n"
+		result += "1. For failed DNS authorizations, check the CNAME record from the DNS authorization exists in the domain's zone\n"
+		result += "2. For failed load balancer authorizations, check the domain's A/AAAA records point at the load balancer's IP and that no CAA record excludes pki.goog\n"
+		result += "3. Certificates stay PROVISIONING until every domain is authorized; map entries stay PENDING until their certificates are ACTIVE\n"
+		result += "4. Replace expiring self-managed certificates before they expire, as they aren't renewed automatically\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,447 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// negStatusAnnotation is set by the GKE NEG controller on services with
+// container-native load balancing, naming their NEGs and zones
+const negStatusAnnotation = "cloud.google.com/neg-status"
+
+// registerNEGTools registers tools that check network endpoint groups
+func registerNEGTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get cluster network endpoint groups tool
+	getNEGs := mcp.NewTool("get_cluster_network_endpoint_groups",
+		mcp.WithDescription("Lists the zonal network endpoint groups (NEGs) that connect container-native load balancers to pods, with each NEG's endpoint count and the health of its endpoints, flagging NEGs with no healthy endpoints, which cause 502s"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The zone or region to check, or the GKE cluster location when cluster_name is set"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Description("The GKE cluster name; only NEGs of the cluster's services are checked (if not provided, all NEGs in the location are checked)"),
+		),
+		mcp.WithString("service",
+			mcp.Description("Only check NEGs of this Kubernetes service, as name or namespace/name"),
+		),
+	)
+
+	getNEGsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetClusterNetworkEndpointGroups(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getNEGs, getNEGsHandler)
+
+	return nil
+}
+
+// negRef identifies a zonal NEG and the Kubernetes service port it backs
+type negRef struct {
+	Name string
+	Zone string
+	// Service is "namespace/name" of the Kubernetes service, if known
+	Service string
+	Port    string
+}
+
+// networkEndpointGroup is the subset of a Compute Engine NetworkEndpointGroup
+// used by the tools
+type networkEndpointGroup struct {
+	Name        string `json:"name"`
+	Zone        string `json:"zone"`
+	Description string `json:"description"`
+}
+
+// negDescription is the JSON description the GKE NEG controller sets on the
+// NEGs it creates
+type negDescription struct {
+	Namespace   string `json:"namespace"`
+	ServiceName string `json:"service-name"`
+	Port        string `json:"port"`
+}
+
+// negEndpoint is a network endpoint with its health as seen by each backend
+// service using the NEG
+type negEndpoint struct {
+	NetworkEndpoint struct {
+		IPAddress string `json:"ipAddress"`
+		Port      int    `json:"port"`
+	} `json:"networkEndpoint"`
+	Healths []struct {
+		HealthState string `json:"healthState"`
+	} `json:"healths"`
+}
+
+// negStatus is the assessed health of a NEG's endpoints
+type negStatus struct {
+	Ref       negRef
+	Endpoints int
+	Healthy   int
+	// Unhealthy lists endpoints no backend service reports as healthy, with
+	// their health states
+	Unhealthy []string
+	// Unchecked is the number of endpoints with no health information, because
+	// the NEG isn't used by a backend service
+	Unchecked int
+}
+
+// noHealthyEndpoints reports whether the load balancer has nowhere to send
+// traffic for the NEG. NEGs whose endpoints all lack health information
+// aren't used by a load balancer, so aren't flagged unless they are empty.
+func (n negStatus) noHealthyEndpoints() bool {
+	return n.Endpoints == 0 || (n.Healthy == 0 && n.Unchecked < n.Endpoints)
+}
+
+// assessNEG summarises the health of a NEG's endpoints. An endpoint is healthy
+// if any backend service reports it HEALTHY.
+func assessNEG(ref negRef, endpoints []negEndpoint) negStatus {
+	status := negStatus{Ref: ref, Endpoints: len(endpoints)}
+	for _, endpoint := range endpoints {
+		if len(endpoint.Healths) == 0 {
+			status.Unchecked++
+			continue
+		}
+
+		healthy := false
+		var states []string
+		for _, health := range endpoint.Healths {
+			if health.HealthState == "HEALTHY" {
+				healthy = true
+				break
+			}
+			if !containsString(states, health.HealthState) {
+				states = append(states, health.HealthState)
+			}
+		}
+		if healthy {
+			status.Healthy++
+			continue
+		}
+
+		address := fmt.Sprintf("%s:%d", endpoint.NetworkEndpoint.IPAddress, endpoint.NetworkEndpoint.Port)
+		status.Unhealthy = append(status.Unhealthy, fmt.Sprintf("%s (%s)", address, strings.Join(states, ", ")))
+	}
+	return status
+}
+
+// parseNEGStatusAnnotation returns the NEGs named in a service's neg-status
+// annotation, one per zone and port
+func parseNEGStatusAnnotation(service, annotation string) ([]negRef, error) {
+	var status struct {
+		NetworkEndpointGroups map[string]string `json:"network_endpoint_groups"`
+		Zones                 []string          `json:"zones"`
+	}
+	if err := json.Unmarshal([]byte(annotation), &status); err != nil {
+		return nil, fmt.Errorf("error parsing %s annotation of %s: %w", negStatusAnnotation, service, err)
+	}
+
+	var refs []negRef
+	for port, name := range status.NetworkEndpointGroups {
+		for _, zone := range status.Zones {
+			refs = append(refs, negRef{Name: name, Zone: zone, Service: service, Port: port})
+		}
+	}
+	return refs, nil
+}
+
+// matchesService reports whether "namespace/name" matches a service filter
+// given as a name or namespace/name
+func matchesService(service, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	if strings.Contains(filter, "/") {
+		return service == filter
+	}
+	return lastPathSegment(service) == filter
+}
+
+// inLocation reports whether a zone is, or is in, the location
+func inLocation(zone, location string) bool {
+	return zone == location || strings.HasPrefix(zone, location+"-")
+}
+
+// fetchNetworkEndpointGroups lists the zonal NEGs of a project in a zone or
+// region, following pagination
+func fetchNetworkEndpointGroups(ctx context.Context, client *http.Client, projectID, location string) ([]networkEndpointGroup, error) {
+	var negs []networkEndpointGroup
+	pageToken := ""
+	for {
+		apiURL := fmt.Sprintf("%s/projects/%s/aggregated/networkEndpointGroups?maxResults=500", gcpComputeBaseURL, projectID)
+		if pageToken != "" {
+			apiURL += "&pageToken=" + url.QueryEscape(pageToken)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+
+		resp, err := doWithRetry(client, req)
+		if err != nil {
+			return nil, fmt.Errorf("error making request to Compute Engine API: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("error from Compute Engine API: %s", resp.Status)
+		}
+
+		var response struct {
+			Items map[string]struct {
+				NetworkEndpointGroups []networkEndpointGroup `json:"networkEndpointGroups"`
+			} `json:"items"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error parsing response: %w", err)
+		}
+
+		for scope, scoped := range response.Items {
+			zone, ok := strings.CutPrefix(scope, "zones/")
+			if !ok || !inLocation(zone, location) {
+				continue
+			}
+			negs = append(negs, scoped.NetworkEndpointGroups...)
+		}
+
+		pageToken = response.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+
+	return negs, nil
+}
+
+// fetchNEGEndpoints lists the endpoints of a zonal NEG with their health
+func fetchNEGEndpoints(ctx context.Context, client *http.Client, projectID, zone, name string) ([]negEndpoint, error) {
+	var endpoints []negEndpoint
+	pageToken := ""
+	for {
+		apiURL := fmt.Sprintf("%s/projects/%s/zones/%s/networkEndpointGroups/%s/listNetworkEndpoints?maxResults=500",
+			gcpComputeBaseURL, projectID, zone, name)
+		if pageToken != "" {
+			apiURL += "&pageToken=" + url.QueryEscape(pageToken)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewReader([]byte(`{"healthStatus":"SHOW"}`)))
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		// Listing endpoints is read-only, so the POST is safe to retry
+		req = markIdempotent(req)
+
+		resp, err := doWithRetry(client, req)
+		if err != nil {
+			return nil, fmt.Errorf("error making request to Compute Engine API: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("error from Compute Engine API: %s", resp.Status)
+		}
+
+		var response struct {
+			Items         []negEndpoint `json:"items"`
+			NextPageToken string        `json:"nextPageToken"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error parsing response: %w", err)
+		}
+
+		endpoints = append(endpoints, response.Items...)
+		pageToken = response.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+
+	return endpoints, nil
+}
+
+// clusterNEGRefs returns the NEGs of a cluster's services from their
+// neg-status annotations
+func clusterNEGRefs(ctx context.Context, authHandler *auth.OAuthHandler, projectID, location, clusterName, serviceFilter string) ([]negRef, error) {
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to cluster: %w", err)
+	}
+
+	var serviceList struct {
+		Items []struct {
+			Metadata kubeObjectMeta `json:"metadata"`
+		} `json:"items"`
+	}
+	if err := kube.get(ctx, "/api/v1/services", nil, &serviceList); err != nil {
+		return nil, fmt.Errorf("error listing services: %w", err)
+	}
+
+	var refs []negRef
+	for _, service := range serviceList.Items {
+		name := service.Metadata.Namespace + "/" + service.Metadata.Name
+		annotation := service.Metadata.Annotations[negStatusAnnotation]
+		if annotation == "" || !matchesService(name, serviceFilter) {
+			continue
+		}
+		serviceRefs, err := parseNEGStatusAnnotation(name, annotation)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, serviceRefs...)
+	}
+
+	return refs, nil
+}
+
+// handleGetClusterNetworkEndpointGroups handles the get_cluster_network_endpoint_groups tool request
+func handleGetClusterNetworkEndpointGroups(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, _ := request.Params.Arguments["cluster_name"].(string)
+	serviceFilter, _ := request.Params.Arguments["service"].(string)
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	// With a cluster, its services' annotations name their NEGs exactly.
+	// Otherwise NEGs are found by location, and the GKE NEG controller's
+	// description says which service they belong to.
+	var refs []negRef
+	if clusterName != "" {
+		refs, err = clusterNEGRefs(ctx, authHandler, projectID, location, clusterName, serviceFilter)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	} else {
+		negs, err := fetchNetworkEndpointGroups(ctx, client, projectID, location)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error listing network endpoint groups: %v", err)), nil
+		}
+		for _, neg := range negs {
+			ref := negRef{Name: neg.Name, Zone: lastPathSegment(neg.Zone)}
+			var description negDescription
+			if json.Unmarshal([]byte(neg.Description), &description) == nil && description.ServiceName != "" {
+				ref.Service = description.Namespace + "/" + description.ServiceName
+				ref.Port = description.Port
+			}
+			if serviceFilter != "" && (ref.Service == "" || !matchesService(ref.Service, serviceFilter)) {
+				continue
+			}
+			refs = append(refs, ref)
+		}
+	}
+
+	scope := "location " + location
+	if clusterName != "" {
+		scope = "cluster " + clusterName
+	}
+
+	if len(refs) == 0 {
+		result := fmt.Sprintf("No network endpoint groups found in %s of project %s", scope, projectID)
+		if serviceFilter != "" {
+			result += fmt.Sprintf(" for service %s", serviceFilter)
+		}
+		result += ". Services only get NEGs with container-native load balancing, through the cloud.google.com/neg annotation or an Ingress on a VPC-native cluster."
+		return mcp.NewToolResultText(result), nil
+	}
+
+	var statuses []negStatus
+	for _, ref := range refs {
+		endpoints, err := fetchNEGEndpoints(ctx, client, projectID, ref.Zone, ref.Name)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error listing endpoints of NEG %s in %s: %v", ref.Name, ref.Zone, err)), nil
+		}
+		statuses = append(statuses, assessNEG(ref, endpoints))
+	}
+
+	// List NEGs with no healthy endpoints first
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return statuses[i].noHealthyEndpoints() && !statuses[j].noHealthyEndpoints()
+	})
+
+	flagged := 0
+	for _, status := range statuses {
+		if status.noHealthyEndpoints() {
+			flagged++
+		}
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# Network Endpoint Groups in %s\n\n", scope)
+	result += fmt.Sprintf("Found %d NEGs, %d with no healthy endpoints.\n\n", len(statuses), flagged)
+
+	result += "| NEG | Zone | Service | Port | Endpoints | Healthy | Unhealthy | No Health Info |\n"
+	result += "| --- | ---- | ------- | ---- | --------- | ------- | --------- | -------------- |\n"
+	for _, status := range statuses {
+		name := status.Ref.Name
+		if status.noHealthyEndpoints() {
+			name = "**" + name + "**"
+		}
+		result += fmt.Sprintf("| %s | %s | %s | %s | %d | %d | %d | %d |\n",
+			name, status.Ref.Zone, valueOrDash(status.Ref.Service), valueOrDash(status.Ref.Port),
+			status.Endpoints, status.Healthy, len(status.Unhealthy), status.Unchecked)
+	}
+
+	var withUnhealthy []negStatus
+	for _, status := range statuses {
+		if len(status.Unhealthy) > 0 {
+			withUnhealthy = append(withUnhealthy, status)
+		}
+	}
+	if len(withUnhealthy) > 0 {
+		result += "\n## Unhealthy Endpoints\n\n"
+		for _, status := range withUnhealthy {
+			unhealthy := status.Unhealthy
+			if len(unhealthy) > 10 {
+				unhealthy = unhealthy[:10]
+			}
+			result += fmt.Sprintf("- **%s** (%s): %s", status.Ref.Name, status.Ref.Zone, strings.Join(unhealthy, ", "))
+			if len(status.Unhealthy) > len(unhealthy) {
+				result += fmt.Sprintf(" and %d more", len(status.Unhealthy)-len(unhealthy))
+			}
+			result += "\n"
+		}
+	}
+
+	if flagged > 0 || len(withUnhealthy) > 0 {
+		result += "\n## Recommended Actions\n\n"
+		result += "1. A NEG with no healthy endpoints makes the load balancer return 502s for its zone; check the service's endpoints and pod readiness with get_stale_endpoints\n"
+		result += "2. If the pods are Ready but the load balancer health check fails, compare the health check's path and port with the pods' readiness probe, and check firewall rules allow the health check ranges 35.191.0.0/16 and 130.211.0.0/22\n"
+		result += "3. A NEG with no endpoints at all usually means the service selects no pods, or the NEG controller hasn't synced them yet\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
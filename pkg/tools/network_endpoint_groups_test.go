@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// negEndpointsJSON lists NEG endpoints at 10.0.0.n:8080 with the given
+// health states; an empty state is an endpoint with no health information
+func negEndpointsJSON(states ...string) string {
+	var items []string
+	for i, state := range states {
+		item := fmt.Sprintf(`{"networkEndpoint": {"ipAddress": "10.0.0.%d", "port": 8080}`, i+1)
+		if state != "" {
+			item += `, "healths": [{"healthState": "` + state + `"}]`
+		}
+		items = append(items, item+"}")
+	}
+	return `{"items": [` + strings.Join(items, ",") + `]}`
+}
+
+func TestAssessNEG(t *testing.T) {
+	tests := []struct {
+		name      string
+		states    []string
+		unhealthy int
+		flagged   bool
+	}{
+		{name: "healthy", states: []string{"HEALTHY", "UNHEALTHY"}, unhealthy: 1},
+		{name: "no healthy endpoints", states: []string{"UNHEALTHY", "DRAINING"}, unhealthy: 2, flagged: true},
+		{name: "empty", flagged: true},
+		// Not used by a backend service, so health isn't known
+		{name: "unchecked", states: []string{"", ""}},
+	}
+
+	for _, tt := range tests {
+		response := decodeJSON[struct {
+			Items []negEndpoint `json:"items"`
+		}](t, negEndpointsJSON(tt.states...))
+
+		status := assessNEG(negRef{Name: "neg"}, response.Items)
+		if len(status.Unhealthy) != tt.unhealthy {
+			t.Errorf("%s: unhealthy = %q, want %d", tt.name, status.Unhealthy, tt.unhealthy)
+		}
+		if got := status.noHealthyEndpoints(); got != tt.flagged {
+			t.Errorf("%s: noHealthyEndpoints = %v, want %v", tt.name, got, tt.flagged)
+		}
+	}
+}
+
+func TestHandleGetClusterNetworkEndpointGroups(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const prefix = "compute.googleapis.com/compute/v1/projects/test-project/"
+		switch r.URL.Host + r.URL.Path {
+		case prefix + "aggregated/networkEndpointGroups":
+			w.Write([]byte(`{"items": {
+				"zones/us-central1-a": {"networkEndpointGroups": [
+					{"name": "k8s1-web-api-8080", "zone": "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a",
+					 "description": "{\"cluster-uid\":\"abc\",\"namespace\":\"web\",\"service-name\":\"api\",\"port\":\"8080\"}"}
+				]},
+				"zones/us-central1-b": {"networkEndpointGroups": [
+					{"name": "k8s1-web-api-8080", "zone": "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-b",
+					 "description": "{\"cluster-uid\":\"abc\",\"namespace\":\"web\",\"service-name\":\"api\",\"port\":\"8080\"}"}
+				]},
+				"zones/europe-west1-b": {"networkEndpointGroups": [
+					{"name": "elsewhere", "zone": "https://www.googleapis.com/compute/v1/projects/test-project/zones/europe-west1-b"}
+				]}
+			}}`))
+		case prefix + "zones/us-central1-a/networkEndpointGroups/k8s1-web-api-8080/listNetworkEndpoints":
+			w.Write([]byte(negEndpointsJSON("HEALTHY", "HEALTHY")))
+		case prefix + "zones/us-central1-b/networkEndpointGroups/k8s1-web-api-8080/listNetworkEndpoints":
+			// Every pod in the zone is failing the load balancer health check
+			w.Write([]byte(negEndpointsJSON("UNHEALTHY", "UNHEALTHY")))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	text := callTool(t, ctx, handleGetClusterNetworkEndpointGroups, map[string]interface{}{
+		"project_id": "test-project",
+		"location":   "us-central1",
+		"service":    "web/api",
+	})
+
+	// The NEG with no healthy endpoints is listed first
+	for _, s := range []string{
+		"Found 2 NEGs, 1 with no healthy endpoints.",
+		"| **k8s1-web-api-8080** | us-central1-b | web/api | 8080 | 2 | 0 | 2 | 0 |\n" +
+			"| k8s1-web-api-8080 | us-central1-a | web/api | 8080 | 2 | 2 | 0 | 0 |\n",
+		"- **k8s1-web-api-8080** (us-central1-b): 10.0.0.1:8080 (UNHEALTHY), 10.0.0.2:8080 (UNHEALTHY)\n",
+		"## Recommended Actions",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if strings.Contains(text, "elsewhere") {
+		t.Errorf("result includes a NEG outside the location:\n%s", text)
+	}
+}
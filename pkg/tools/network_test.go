@@ -0,0 +1,107 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCertificateProblems(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		cert string
+		want []string
+	}{
+		{
+			name: "active managed",
+			cert: `{"managed": {"state": "ACTIVE"}, "expireTime": "2026-10-20T00:00:00Z"}`,
+		},
+		{
+			name: "failed authorization",
+			cert: `{"managed": {"state": "PROVISIONING",
+				"provisioningIssue": {"reason": "AUTHORIZATION_ISSUE", "details": "could not authorize all domains"},
+				"authorizationAttemptInfo": [
+					{"domain": "a.example.com", "state": "AUTHORIZED"},
+					{"domain": "b.example.com", "state": "FAILED", "failureReason": "CONFIG", "details": "no A record"}
+				]}}`,
+			want: []string{
+				"still PROVISIONING, not yet serving",
+				"provisioning issue AUTHORIZATION_ISSUE: could not authorize all domains",
+				"authorization FAILED for b.example.com (CONFIG): no A record",
+			},
+		},
+		{
+			name: "expiring self-managed",
+			cert: `{"selfManaged": {}, "expireTime": "2026-11-01T00:00:00Z"}`,
+			want: []string{"expires on 2026-11-01 and must be replaced manually"},
+		},
+		{
+			name: "expired self-managed",
+			cert: `{"selfManaged": {}, "expireTime": "2026-10-01T00:00:00Z"}`,
+			want: []string{"expired on 2026-10-01"},
+		},
+	}
+
+	for _, tt := range tests {
+		var cert managedCertificate
+		if err := json.Unmarshal([]byte(tt.cert), &cert); err != nil {
+			t.Fatalf("%s: parsing certificate: %v", tt.name, err)
+		}
+		if got := certificateProblems(cert, now); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: certificateProblems = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHandleGetCertificateMapStatus(t *testing.T) {
+	const parent = "/v1/projects/test-project/locations/global"
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case parent + "/certificates":
+			w.Write([]byte(`{"certificates": [
+				{"name": "projects/test-project/locations/global/certificates/www", "managed": {
+					"domains": ["www.example.com"], "state": "ACTIVE",
+					"authorizationAttemptInfo": [{"domain": "www.example.com", "state": "AUTHORIZED"}]
+				}, "expireTime": "2099-01-01T00:00:00Z"},
+				{"name": "projects/test-project/locations/global/certificates/api", "managed": {
+					"domains": ["api.example.com"], "dnsAuthorizations": ["projects/test-project/locations/global/dnsAuthorizations/api"],
+					"state": "FAILED",
+					"authorizationAttemptInfo": [{"domain": "api.example.com", "state": "FAILED", "failureReason": "CONFIG", "details": "CNAME record not found"}]
+				}}
+			]}`))
+		case parent + "/certificateMaps":
+			w.Write([]byte(`{"certificateMaps": [{"name": "projects/test-project/locations/global/certificateMaps/prod",
+				"gclbTargets": [{"targetHttpsProxy": "projects/test-project/global/targetHttpsProxies/prod-proxy"}]}]}`))
+		case parent + "/certificateMaps/prod/certificateMapEntries":
+			w.Write([]byte(`{"certificateMapEntries": [
+				{"hostname": "www.example.com", "certificates": ["projects/test-project/locations/global/certificates/www"], "state": "ACTIVE"},
+				{"hostname": "api.example.com", "certificates": ["projects/test-project/locations/global/certificates/api"], "state": "PENDING"}
+			]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	text := callTool(t, ctx, handleGetCertificateMapStatus, map[string]interface{}{"project_id": "test-project"})
+
+	// Certificates with problems are listed first
+	for _, s := range []string{
+		"Found 2 certificates (1 with problems) and 1 certificate maps.",
+		"| api | **FAILED** | api.example.com | DNS (api.example.com FAILED) | - |\n" +
+			"| www | ACTIVE | www.example.com | load balancer (www.example.com AUTHORIZED) | 2099-01-01 |\n",
+		"- **api**: provisioning FAILED\n- **api**: authorization FAILED for api.example.com (CONFIG): CNAME record not found\n",
+		"- **Attached To**: prod-proxy\n",
+		"- www.example.com → www: ACTIVE\n- api.example.com → api (has problems): **PENDING**\n",
+		"1. For failed DNS authorizations, check the CNAME record",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
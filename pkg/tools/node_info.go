@@ -0,0 +1,304 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// nodePressureConditions are the node conditions that mean the kubelet is
+// short of a resource and may evict pods, in the order they're shown
+var nodePressureConditions = []string{"MemoryPressure", "DiskPressure", "PIDPressure"}
+
+// registerNodeInfoTools registers tools that report node status and capacity
+func registerNodeInfoTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get node info tool
+	getNodeInfo := mcp.NewTool("get_node_info",
+		mcp.WithDescription("Lists a cluster's nodes with their Ready and pressure conditions, allocatable and capacity CPU and memory, kubelet version and number of running pods, flagging NotReady nodes and nodes under memory, disk or PID pressure first. Set node_name to drill into one node, including its taints and labels."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithString("node_name",
+			mcp.Description("The name of a node to show in detail (optional)"),
+		),
+	)
+
+	getNodeInfoHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetNodeInfo(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getNodeInfo, getNodeInfoHandler)
+
+	return nil
+}
+
+// kubeNodeCondition is a core/v1 NodeCondition
+type kubeNodeCondition struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason"`
+	Message            string `json:"message"`
+	LastTransitionTime string `json:"lastTransitionTime"`
+}
+
+// kubeNodeDetail is the subset of a core/v1 Node shown by get_node_info
+type kubeNodeDetail struct {
+	Metadata kubeObjectMeta `json:"metadata"`
+	Spec     struct {
+		Unschedulable bool        `json:"unschedulable"`
+		Taints        []kubeTaint `json:"taints"`
+	} `json:"spec"`
+	Status struct {
+		Conditions  []kubeNodeCondition `json:"conditions"`
+		Capacity    map[string]string   `json:"capacity"`
+		Allocatable map[string]string   `json:"allocatable"`
+		NodeInfo    struct {
+			KubeletVersion string `json:"kubeletVersion"`
+		} `json:"nodeInfo"`
+	} `json:"status"`
+}
+
+// condition returns the node's condition of the given type, if reported
+func (n kubeNodeDetail) condition(conditionType string) (kubeNodeCondition, bool) {
+	for _, cond := range n.Status.Conditions {
+		if cond.Type == conditionType {
+			return cond, true
+		}
+	}
+	return kubeNodeCondition{}, false
+}
+
+// problems describes why the node needs attention: a Ready condition that
+// isn't True, or an active pressure condition
+func (n kubeNodeDetail) problems() []string {
+	var problems []string
+	if ready, ok := n.condition("Ready"); !ok {
+		problems = append(problems, "NotReady: no Ready condition reported")
+	} else if ready.Status != "True" {
+		problem := "NotReady"
+		if ready.Status == "Unknown" {
+			problem += " (kubelet stopped reporting)"
+		}
+		if ready.Reason != "" {
+			problem += ": " + ready.Reason
+		}
+		problems = append(problems, problem)
+	}
+	for _, conditionType := range nodePressureConditions {
+		if cond, ok := n.condition(conditionType); ok && cond.Status == "True" {
+			problems = append(problems, conditionType)
+		}
+	}
+	return problems
+}
+
+// statusSummary summarises the node's status like kubectl get nodes
+func (n kubeNodeDetail) statusSummary() string {
+	status := "Ready"
+	if ready, ok := n.condition("Ready"); !ok || ready.Status != "True" {
+		status = "NotReady"
+	}
+	for _, conditionType := range nodePressureConditions {
+		if cond, ok := n.condition(conditionType); ok && cond.Status == "True" {
+			status += "," + conditionType
+		}
+	}
+	if n.Spec.Unschedulable {
+		status += ",SchedulingDisabled"
+	}
+	return status
+}
+
+// allocatableOfCapacity formats a resource as allocatable/capacity
+func (n kubeNodeDetail) allocatableOfCapacity(resource string) string {
+	return fmt.Sprintf("%s/%s", valueOrDash(n.Status.Allocatable[resource]), valueOrDash(n.Status.Capacity[resource]))
+}
+
+// podsPerNode counts the pods scheduled on each node that haven't finished
+func podsPerNode(pods []kubePod) map[string]int {
+	counts := make(map[string]int)
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || pod.Status.Phase == "Succeeded" || pod.Status.Phase == "Failed" {
+			continue
+		}
+		counts[pod.Spec.NodeName]++
+	}
+	return counts
+}
+
+// handleGetNodeInfo handles the get_node_info tool request
+func handleGetNodeInfo(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	nodeName, _ := request.Params.Arguments["node_name"].(string)
+
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error connecting to cluster: %v", err)), nil
+	}
+
+	var nodes []kubeNodeDetail
+	podQuery := url.Values{}
+	if nodeName != "" {
+		var node kubeNodeDetail
+		if err := kube.get(ctx, "/api/v1/nodes/"+url.PathEscape(nodeName), nil, &node); err != nil {
+			if isKubeNotFound(err) {
+				return mcp.NewToolResultError(fmt.Sprintf("Node %s not found in cluster %s.", nodeName, clusterName)), nil
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("Error getting node: %v", err)), nil
+		}
+		nodes = append(nodes, node)
+		podQuery.Set("fieldSelector", "spec.nodeName="+nodeName)
+	} else {
+		var nodeList struct {
+			Items []kubeNodeDetail `json:"items"`
+		}
+		if err := kube.get(ctx, "/api/v1/nodes", nil, &nodeList); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error listing nodes: %v", err)), nil
+		}
+		nodes = nodeList.Items
+	}
+	if len(nodes) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("Cluster %s has no nodes.", clusterName)), nil
+	}
+
+	// Pod counts are supplementary, so a failure is reported rather than
+	// returned
+	var podList struct {
+		Items []kubePod `json:"items"`
+	}
+	podsErr := kube.get(ctx, podsPath(""), podQuery, &podList)
+	podCounts := podsPerNode(podList.Items)
+	podCount := func(node kubeNodeDetail) string {
+		if podsErr != nil {
+			return "?"
+		}
+		return fmt.Sprintf("%d/%s", podCounts[node.Metadata.Name], valueOrDash(node.Status.Allocatable["pods"]))
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Metadata.Name < nodes[j].Metadata.Name
+	})
+
+	// Format the results
+	now := time.Now()
+	result := fmt.Sprintf("# Nodes in Cluster %s\n\n", clusterName)
+	if nodeName != "" {
+		result = fmt.Sprintf("# Node %s\n\n", nodeName)
+	}
+
+	var flagged []kubeNodeDetail
+	for _, node := range nodes {
+		if len(node.problems()) > 0 {
+			flagged = append(flagged, node)
+		}
+	}
+	if len(flagged) > 0 {
+		result += "## Nodes Needing Attention\n\n"
+		for _, node := range flagged {
+			result += fmt.Sprintf("- **%s**: %s\n", node.Metadata.Name, strings.Join(node.problems(), ", "))
+		}
+		result += "\n"
+	} else if nodeName == "" {
+		result += fmt.Sprintf("All %d nodes are Ready with no pressure conditions.\n\n", len(nodes))
+	}
+
+	if podsErr != nil {
+		result += fmt.Sprintf("Error listing pods, so pod counts are unavailable: %v\n\n", podsErr)
+	}
+
+	if nodeName == "" {
+		result += "| Node | Status | CPU (allocatable/capacity) | Memory (allocatable/capacity) | Pods | Kubelet | Age |\n"
+		result += "| ---- | ------ | -------------------------- | ----------------------------- | ---- | ------- | --- |\n"
+		for _, node := range nodes {
+			result += fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %s |\n",
+				node.Metadata.Name, node.statusSummary(), node.allocatableOfCapacity("cpu"), node.allocatableOfCapacity("memory"),
+				podCount(node), valueOrDash(node.Status.NodeInfo.KubeletVersion), formatAge(node.Metadata.CreationTimestamp, now))
+		}
+		result += "\nUse node_name to see a node's conditions, taints and labels.\n"
+	} else {
+		node := nodes[0]
+		result += fmt.Sprintf("- **Status**: %s\n", node.statusSummary())
+		result += fmt.Sprintf("- **Kubelet Version**: %s\n", valueOrDash(node.Status.NodeInfo.KubeletVersion))
+		result += fmt.Sprintf("- **Pods**: %s\n", podCount(node))
+		result += fmt.Sprintf("- **Created**: %s (%s ago)\n\n", valueOrDash(node.Metadata.CreationTimestamp), formatAge(node.Metadata.CreationTimestamp, now))
+
+		result += "## Conditions\n\n"
+		result += "| Type | Status | Reason | Last Transition | Message |\n"
+		result += "| ---- | ------ | ------ | --------------- | ------- |\n"
+		for _, cond := range node.Status.Conditions {
+			result += fmt.Sprintf("| %s | %s | %s | %s | %s |\n", cond.Type, cond.Status, valueOrDash(cond.Reason), valueOrDash(cond.LastTransitionTime), valueOrDash(cond.Message))
+		}
+		result += "\n"
+
+		result += "## Resources\n\n"
+		result += "| Resource | Allocatable | Capacity |\n"
+		result += "| -------- | ----------- | -------- |\n"
+		for _, resource := range []string{"cpu", "memory", "ephemeral-storage", "pods"} {
+			result += fmt.Sprintf("| %s | %s | %s |\n", resource, valueOrDash(node.Status.Allocatable[resource]), valueOrDash(node.Status.Capacity[resource]))
+		}
+		result += "\n"
+
+		result += "## Taints\n\n"
+		if len(node.Spec.Taints) == 0 {
+			result += "None\n"
+		}
+		for _, taint := range node.Spec.Taints {
+			result += fmt.Sprintf("- %s\n", taint)
+		}
+		result += "\n"
+
+		result += "## Labels\n\n"
+		keys := make([]string, 0, len(node.Metadata.Labels))
+		for key := range node.Metadata.Labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			result += fmt.Sprintf("- %s=%s\n", key, node.Metadata.Labels[key])
+		}
+		if len(keys) == 0 {
+			result += "None\n"
+		}
+	}
+
+	if len(flagged) > 0 {
+		result += "\n## Recommended Actions\n\n"
+		result += "1. Use get_gke_node_problem_detector_events to check for kernel, runtime or filesystem problems on NotReady nodes\n"
+		result += "2. For pressure conditions, use get_recent_evictions to see which pods the kubelet evicted from pressured nodes\n"
+		result += "3. If a node doesn't recover, use drain_node to move its workloads so GKE auto-repair or the autoscaler can replace it\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
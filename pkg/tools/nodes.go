@@ -0,0 +1,776 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerNodeTools registers tools that inspect cluster nodes
+func registerNodeTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get node problem detector events tool
+	getNPDEvents := mcp.NewTool("get_gke_node_problem_detector_events",
+		mcp.WithDescription("Lists Node Problem Detector events (kernel deadlocks, read-only filesystems, kubelet restarts) across a GKE cluster, grouped by node and problem"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range for events in hours (default: 24)"),
+		),
+	)
+
+	getNPDEventsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetNodeProblemDetectorEvents(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getNPDEvents, getNPDEventsHandler)
+
+	// Register get recent preemptions tool
+	getRecentPreemptions := mcp.NewTool("get_recent_preemptions",
+		mcp.WithDescription("Lists Spot and preemptible VM preemptions of a GKE cluster's nodes, with the node pool each belonged to, flagging bursts of preemptions that explain sudden capacity loss"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range for preemptions in hours (default: 24)"),
+		),
+	)
+
+	getRecentPreemptionsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetRecentPreemptions(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getRecentPreemptions, getRecentPreemptionsHandler)
+
+	// Draining changes the cluster, so it is a remediation tool
+	if !remediationEnabled() {
+		return nil
+	}
+
+	// Register drain node tool
+	drainNode := mcp.NewTool("drain_node",
+		mcp.WithDescription("Cordons a node, then evicts its pods through the eviction API so PodDisruptionBudgets are honoured, like kubectl drain. DaemonSet and static pods are left in place, and pods without a controller are only evicted with force. Evictions blocked by a PodDisruptionBudget are retried until the timeout, then reported. "+
+			"WARNING: this disrupts every workload on the node. Requires the server to run with read-write scopes."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithString("node_name",
+			mcp.Required(),
+			mcp.Description("The name of the node to drain"),
+		),
+		mcp.WithNumber("timeout_seconds",
+			mcp.Description("How long to keep retrying evictions blocked by PodDisruptionBudgets, in seconds (default: 120)"),
+		),
+		mcp.WithBoolean("force",
+			mcp.Description("Evict pods without a controller, which aren't recreated anywhere once evicted (default: false). Without it the drain stops after cordoning if the node has such pods."),
+		),
+		mcp.WithBoolean("confirm",
+			mcp.Required(),
+			mcp.Description("Must be true to confirm the drain"),
+		),
+	)
+
+	drainNodeHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleDrainNode(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, drainNode, drainNodeHandler)
+
+	return nil
+}
+
+// criticalNodeProblems are Node Problem Detector reasons that usually mean the
+// node needs to be repaired or replaced
+var criticalNodeProblems = map[string]bool{
+	"KernelDeadlock":            true,
+	"ReadonlyFilesystem":        true,
+	"FilesystemIsReadOnly":      true,
+	"FrequentKubeletRestart":    true,
+	"FrequentContainerdRestart": true,
+	"FrequentDockerRestart":     true,
+	"CorruptDockerOverlay2":     true,
+}
+
+// nodeProblem is a group of Node Problem Detector events with the same node and reason
+type nodeProblem struct {
+	Node        string
+	Reason      string
+	Count       int
+	LastSeen    string
+	LastMessage string
+	Critical    bool
+}
+
+// groupNodeProblems groups NPD events by node and reason. Critical problems
+// come first, then the most frequent.
+func groupNodeProblems(entries []logEntry) []nodeProblem {
+	groups := make(map[string]*nodeProblem)
+	for _, entry := range entries {
+		node := entry.Resource.Labels["node_name"]
+		if node == "" {
+			node = payloadString(entry.JsonPayload, "involvedObject", "name")
+		}
+		reason := payloadString(entry.JsonPayload, "reason")
+		if reason == "" {
+			reason = "Unknown"
+		}
+
+		key := node + "/" + reason
+		group, ok := groups[key]
+		if !ok {
+			group = &nodeProblem{Node: node, Reason: reason, Critical: criticalNodeProblems[reason]}
+			groups[key] = group
+		}
+
+		group.Count++
+		if entry.Timestamp > group.LastSeen {
+			group.LastSeen = entry.Timestamp
+			group.LastMessage = payloadString(entry.JsonPayload, "message")
+		}
+	}
+
+	problems := make([]nodeProblem, 0, len(groups))
+	for _, group := range groups {
+		problems = append(problems, *group)
+	}
+
+	sort.Slice(problems, func(i, j int) bool {
+		if problems[i].Critical != problems[j].Critical {
+			return problems[i].Critical
+		}
+		if problems[i].Count != problems[j].Count {
+			return problems[i].Count > problems[j].Count
+		}
+		return problems[i].Node+problems[i].Reason < problems[j].Node+problems[j].Reason
+	})
+
+	return problems
+}
+
+// handleGetNodeProblemDetectorEvents handles the get_gke_node_problem_detector_events tool request
+func handleGetNodeProblemDetectorEvents(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	timeRangeHours := 24.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	startTime := time.Now().Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+
+	// NPD reports problems as node events from its monitors
+	filter := fmt.Sprintf(`resource.type="k8s_node"
+		AND resource.labels.project_id="%s"
+		AND resource.labels.location="%s"
+		AND resource.labels.cluster_name="%s"
+		AND log_id("events")
+		AND jsonPayload.source.component=~"(kernel|systemd|docker|containerd|custom-plugin|abrt)-monitor|node-problem-detector"
+		AND timestamp >= "%s"`,
+		projectID, location, clusterName, startTime.Format(time.RFC3339))
+
+	entries, _, err := fetchLogEntries(ctx, client, projectID, logQuery{
+		Filter:   filter,
+		PageSize: 1000,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying logs: %v", err)), nil
+	}
+
+	// Format the results
+	if len(entries) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No Node Problem Detector events found in cluster %s in the last %.1f hours.", clusterName, timeRangeHours)), nil
+	}
+
+	problems := groupNodeProblems(entries)
+
+	result := fmt.Sprintf("# Node Problem Detector Events in Cluster %s\n\n", clusterName)
+	result += fmt.Sprintf("Found %d events across %d node/problem combinations in the last %.1f hours.\n\n", len(entries), len(problems), timeRangeHours)
+
+	critical := 0
+	for _, problem := range problems {
+		if problem.Critical {
+			critical++
+		}
+	}
+	if critical > 0 {
+		result += fmt.Sprintf("**%d critical node problems detected.** These usually require the node to be repaired or recreated.\n\n", critical)
+	}
+
+	result += "| Node | Problem | Count | Last Seen |\n"
+	result += "| ---- | ------- | ----- | --------- |\n"
+	for _, problem := range problems {
+		reason := problem.Reason
+		if problem.Critical {
+			reason = "**" + reason + "**"
+		}
+		result += fmt.Sprintf("| %s | %s | %d | %s |\n", problem.Node, reason, problem.Count, formatTime(problem.LastSeen))
+	}
+
+	result += "\n## Latest Messages\n\n"
+	for _, problem := range problems {
+		if problem.LastMessage == "" {
+			continue
+		}
+		result += fmt.Sprintf("- **%s** on %s: %s\n", problem.Reason, problem.Node, strings.TrimSpace(problem.LastMessage))
+	}
+
+	if critical > 0 {
+		result += "\n## Recommended Actions\n\n"
+		result += "1. Cordon and drain the affected nodes so workloads move to healthy nodes\n"
+		result += "2. Check whether node auto-repair is enabled on the node pool; it should recreate persistently unhealthy nodes\n"
+		result += "3. If many nodes are affected, check for a recent node image or kernel change\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// preemptionBurstWindow and preemptionBurstSize define a burst: at least
+// preemptionBurstSize preemptions within preemptionBurstWindow
+const (
+	preemptionBurstWindow = 10 * time.Minute
+	preemptionBurstSize   = 3
+)
+
+// preemption is a single preempted node
+type preemption struct {
+	Time     time.Time
+	Node     string
+	Zone     string
+	NodePool string
+}
+
+// preemptionBurst is a run of preemptions close together in time
+type preemptionBurst struct {
+	Start time.Time
+	End   time.Time
+	Count int
+}
+
+// nodePoolInstancePrefixes maps the instance name prefix of each node pool's
+// managed instance groups to the pool name. GKE names instances after their
+// group, e.g. gke-prod-spot-1a2b3c4d-grp creates gke-prod-spot-1a2b3c4d-x7k2.
+func nodePoolInstancePrefixes(pools []gkeNodePool) map[string]string {
+	prefixes := make(map[string]string)
+	for _, pool := range pools {
+		for _, groupURL := range pool.InstanceGroupUrls {
+			group := groupURL[strings.LastIndex(groupURL, "/")+1:]
+			prefixes[strings.TrimSuffix(group, "-grp")+"-"] = pool.Name
+		}
+	}
+	return prefixes
+}
+
+// parsePreemptions extracts preemptions of the cluster's nodes from
+// compute.instances.preempted audit log entries, oldest first. Instances that
+// don't belong to one of the node pools are skipped.
+func parsePreemptions(entries []logEntry, prefixes map[string]string) []preemption {
+	var preemptions []preemption
+	for _, entry := range entries {
+		resourceName := payloadString(entry.ProtoPayload, "resourceName")
+		node := resourceName[strings.LastIndex(resourceName, "/")+1:]
+		if node == "" {
+			continue
+		}
+
+		pool := ""
+		for prefix, name := range prefixes {
+			if strings.HasPrefix(node, prefix) {
+				pool = name
+				break
+			}
+		}
+		if pool == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, entry.Timestamp)
+		if err != nil {
+			continue
+		}
+
+		preemptions = append(preemptions, preemption{
+			Time:     t,
+			Node:     node,
+			Zone:     entry.Resource.Labels["zone"],
+			NodePool: pool,
+		})
+	}
+
+	sort.Slice(preemptions, func(i, j int) bool {
+		return preemptions[i].Time.Before(preemptions[j].Time)
+	})
+
+	return preemptions
+}
+
+// findPreemptionBursts groups time-ordered preemptions that follow each other
+// within the burst window, returning groups of at least the burst size
+func findPreemptionBursts(preemptions []preemption) []preemptionBurst {
+	var bursts []preemptionBurst
+	for i := 0; i < len(preemptions); {
+		j := i + 1
+		for j < len(preemptions) && preemptions[j].Time.Sub(preemptions[j-1].Time) <= preemptionBurstWindow {
+			j++
+		}
+		if j-i >= preemptionBurstSize {
+			bursts = append(bursts, preemptionBurst{Start: preemptions[i].Time, End: preemptions[j-1].Time, Count: j - i})
+		}
+		i = j
+	}
+	return bursts
+}
+
+// handleGetRecentPreemptions handles the get_recent_preemptions tool request
+func handleGetRecentPreemptions(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	timeRangeHours := 24.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	pools, err := fetchNodePools(ctx, client, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing node pools: %v", err)), nil
+	}
+
+	startTime := time.Now().Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+
+	// Compute Engine records preemptions as system event audit logs
+	filter := fmt.Sprintf(`resource.type="gce_instance"
+		AND protoPayload.methodName="compute.instances.preempted"
+		AND timestamp >= "%s"`,
+		startTime.Format(time.RFC3339))
+
+	entries, _, err := fetchLogEntries(ctx, client, projectID, logQuery{
+		Filter:   filter,
+		PageSize: 1000,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying logs: %v", err)), nil
+	}
+
+	preemptions := parsePreemptions(entries, nodePoolInstancePrefixes(pools))
+
+	var spotPools []string
+	for _, pool := range pools {
+		if pool.Config.Spot || pool.Config.Preemptible {
+			spotPools = append(spotPools, pool.Name)
+		}
+	}
+
+	if len(preemptions) == 0 {
+		result := fmt.Sprintf("No node preemptions found in cluster %s in the last %.1f hours.", clusterName, timeRangeHours)
+		if len(spotPools) == 0 {
+			result += " The cluster has no Spot or preemptible node pools."
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+
+	bursts := findPreemptionBursts(preemptions)
+
+	perPool := make(map[string]int)
+	for _, p := range preemptions {
+		perPool[p.NodePool]++
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# Recent Preemptions in Cluster %s\n\n", clusterName)
+	result += fmt.Sprintf("Found %d node preemptions in the last %.1f hours.\n\n", len(preemptions), timeRangeHours)
+
+	if len(bursts) > 0 {
+		result += "## Preemption Bursts\n\n"
+		result += fmt.Sprintf("Runs of %d or more preemptions less than %s apart, which cause sudden capacity loss:\n\n", preemptionBurstSize, preemptionBurstWindow)
+		for _, burst := range bursts {
+			result += fmt.Sprintf("- **%d nodes** preempted between %s and %s\n",
+				burst.Count, burst.Start.Format("2006-01-02 15:04:05"), burst.End.Format("2006-01-02 15:04:05"))
+		}
+		result += "\n"
+	}
+
+	result += "## By Node Pool\n\n"
+	poolNames := make([]string, 0, len(perPool))
+	for name := range perPool {
+		poolNames = append(poolNames, name)
+	}
+	sort.Slice(poolNames, func(i, j int) bool {
+		return perPool[poolNames[i]] > perPool[poolNames[j]]
+	})
+	for _, name := range poolNames {
+		result += fmt.Sprintf("- **%s**: %d preemptions\n", name, perPool[name])
+	}
+
+	result += "\n## Preemptions\n\n"
+	result += "| Time | Node | Zone | Node Pool |\n"
+	result += "| ---- | ---- | ---- | --------- |\n"
+	for _, p := range preemptions {
+		result += fmt.Sprintf("| %s | %s | %s | %s |\n", p.Time.Format("2006-01-02 15:04:05"), p.Node, p.Zone, p.NodePool)
+	}
+
+	result += "\n## Recommended Actions\n\n"
+	result += "1. Pods on preempted nodes are rescheduled once replacement nodes join; check for Pending pods if capacity hasn't recovered\n"
+	result += "2. Bursts usually mean Compute Engine reclaimed capacity in a zone; spreading Spot pools across zones and machine types reduces their impact\n"
+	result += "3. Keep critical workloads on a standard node pool, or add one as a fallback, so preemptions can't take them down entirely\n"
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// drainRetryInterval is how long to wait before retrying evictions blocked by
+// a PodDisruptionBudget, as kubectl drain does
+const drainRetryInterval = 5 * time.Second
+
+// mirrorPodAnnotation marks the API server's copy of a static pod, which the
+// kubelet recreates if it is evicted
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+// drainablePods splits the pods on a node into those a drain evicts and those
+// it leaves in place: DaemonSet pods, which would be recreated on the node
+// straight away, and static pods, which the API server can't evict
+func drainablePods(pods []kubePod) (evict, skipped []kubePod) {
+	for _, pod := range pods {
+		if pod.ownerKind() == "DaemonSet" {
+			skipped = append(skipped, pod)
+			continue
+		}
+		if _, ok := pod.Metadata.Annotations[mirrorPodAnnotation]; ok {
+			skipped = append(skipped, pod)
+			continue
+		}
+		evict = append(evict, pod)
+	}
+	return evict, skipped
+}
+
+// unmanagedPods returns the pods without a controller, which nothing
+// recreates once they are evicted
+func unmanagedPods(pods []kubePod) []kubePod {
+	var unmanaged []kubePod
+	for _, pod := range pods {
+		if pod.ownerKind() == "" {
+			unmanaged = append(unmanaged, pod)
+		}
+	}
+	return unmanaged
+}
+
+// isEvictionBlocked reports whether an eviction was refused because it would
+// violate a PodDisruptionBudget, which the API server signals with a 429
+func isEvictionBlocked(err error) bool {
+	var apiErr *kubeAPIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests
+}
+
+// evictPod asks the API server to evict a pod, which honours its
+// PodDisruptionBudgets and grace period
+func evictPod(ctx context.Context, kube *kubeClient, pod kubePod) error {
+	eviction := map[string]interface{}{
+		"apiVersion": "policy/v1",
+		"kind":       "Eviction",
+		"metadata": map[string]interface{}{
+			"name":      pod.Metadata.Name,
+			"namespace": pod.Metadata.Namespace,
+		},
+	}
+	path := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/eviction", pod.Metadata.Namespace, pod.Metadata.Name)
+
+	// A PodDisruptionBudget refusal means the API server is working, so it
+	// mustn't trip the circuit breaker for the cluster
+	ctx = withExpectedStatus(ctx, http.StatusTooManyRequests)
+	return kube.do(ctx, "POST", path, nil, "application/json", eviction, nil)
+}
+
+// evictionOutcome is the result of evicting a node's pods, keyed by
+// namespace/name where a reason is recorded
+type evictionOutcome struct {
+	Evicted []kubePod
+	// Remaining are the pods still not evicted when the timeout ran out
+	Remaining []kubePod
+	// Blocked holds the last PodDisruptionBudget refusal of remaining pods
+	Blocked map[string]string
+	// Failed holds pods whose eviction failed for another reason
+	Failed map[string]string
+	// Cancelled is set when the tool call was cancelled before the timeout
+	Cancelled bool
+}
+
+// evictPods evicts pods until every one is gone, or only pods blocked by a
+// PodDisruptionBudget remain when the timeout runs out. Blocked evictions
+// are retried every drainRetryInterval.
+func evictPods(ctx context.Context, kube *kubeClient, pods []kubePod, timeout time.Duration) evictionOutcome {
+	parent := ctx
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	outcome := evictionOutcome{
+		Blocked: make(map[string]string),
+		Failed:  make(map[string]string),
+	}
+	pending := pods
+	for len(pending) > 0 {
+		var retry []kubePod
+		for _, pod := range pending {
+			key := pod.Metadata.Namespace + "/" + pod.Metadata.Name
+			err := evictPod(ctx, kube, pod)
+			switch {
+			case err == nil, isKubeNotFound(err):
+				outcome.Evicted = append(outcome.Evicted, pod)
+				delete(outcome.Blocked, key)
+			case isEvictionBlocked(err):
+				retry = append(retry, pod)
+				outcome.Blocked[key] = err.Error()
+			case ctx.Err() != nil:
+				retry = append(retry, pod)
+			default:
+				outcome.Failed[key] = err.Error()
+				delete(outcome.Blocked, key)
+			}
+		}
+		pending = retry
+		if len(pending) == 0 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(drainRetryInterval):
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	outcome.Remaining = pending
+	outcome.Cancelled = parent.Err() != nil
+
+	return outcome
+}
+
+// handleDrainNode handles the drain_node tool request
+func handleDrainNode(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	nodeName, ok := request.Params.Arguments["node_name"].(string)
+	if !ok || nodeName == "" {
+		return mcp.NewToolResultError("node_name must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	timeout := 120 * time.Second
+	if val, ok := request.Params.Arguments["timeout_seconds"].(float64); ok && val > 0 {
+		timeout = time.Duration(val * float64(time.Second))
+	}
+
+	force, _ := request.Params.Arguments["force"].(bool)
+
+	// Refuse to touch the cluster at all without an explicit confirmation
+	if confirm, _ := request.Params.Arguments["confirm"].(bool); !confirm {
+		return mcp.NewToolResultError(fmt.Sprintf("Draining node %s evicts every pod on it except DaemonSet and static pods. Set confirm to true to proceed.", nodeName)), nil
+	}
+
+	// Draining is a mutating operation
+	if result := requireReadWrite(authHandler, "drain_node"); result != nil {
+		return result, nil
+	}
+
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error connecting to cluster: %v", err)), nil
+	}
+
+	// Cordon first so evicted pods can't be scheduled back onto the node
+	nodePath := "/api/v1/nodes/" + nodeName
+	cordon := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"unschedulable": true,
+		},
+	}
+	if err := kube.do(ctx, "PATCH", nodePath, nil, "application/merge-patch+json", cordon, nil); err != nil {
+		if isKubeNotFound(err) {
+			return mcp.NewToolResultError(fmt.Sprintf("Node %s not found in cluster %s.", nodeName, clusterName)), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Error cordoning node: %v", err)), nil
+	}
+
+	query := url.Values{}
+	query.Set("fieldSelector", "spec.nodeName="+nodeName)
+	var podList struct {
+		Items []kubePod `json:"items"`
+	}
+	if err := kube.get(ctx, podsPath(""), query, &podList); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Node %s was cordoned, but listing its pods failed: %v", nodeName, err)), nil
+	}
+
+	toEvict, skipped := drainablePods(podList.Items)
+
+	// Like kubectl drain, pods that would be lost for good are only evicted
+	// when forced
+	unmanaged := unmanagedPods(toEvict)
+	if len(unmanaged) > 0 && !force {
+		message := fmt.Sprintf("Node %s was cordoned, but no pods were evicted because %d pods have no controller and would be lost:\n\n", nodeName, len(unmanaged))
+		for _, pod := range unmanaged {
+			message += fmt.Sprintf("- %s/%s\n", pod.Metadata.Namespace, pod.Metadata.Name)
+		}
+		message += "\nSet force to true to evict them anyway, or uncordon the node with `kubectl uncordon`."
+		return mcp.NewToolResultError(message), nil
+	}
+
+	outcome := evictPods(ctx, kube, toEvict, timeout)
+
+	// Format the results
+	result := fmt.Sprintf("# Drained Node %s\n\n", nodeName)
+	result += "- Cordoned the node so no new pods are scheduled on it\n"
+	result += fmt.Sprintf("- Requested eviction of %d of %d pods; %d DaemonSet or static pods were left in place\n\n", len(outcome.Evicted), len(toEvict), len(skipped))
+
+	if len(outcome.Evicted) > 0 {
+		result += "## Evicted\n\n"
+		for _, pod := range outcome.Evicted {
+			result += fmt.Sprintf("- %s/%s\n", pod.Metadata.Namespace, pod.Metadata.Name)
+		}
+		result += "\n"
+	}
+
+	if len(outcome.Remaining) > 0 {
+		heading, notAttempted := fmt.Sprintf("Not Evicted After %s", timeout), "timed out before eviction was attempted"
+		if outcome.Cancelled {
+			heading, notAttempted = "Not Evicted Before the Call Was Cancelled", "cancelled before eviction was attempted"
+		}
+		result += fmt.Sprintf("## %s\n\n", heading)
+		for _, pod := range outcome.Remaining {
+			key := pod.Metadata.Namespace + "/" + pod.Metadata.Name
+			if reason, ok := outcome.Blocked[key]; ok {
+				result += fmt.Sprintf("- %s: blocked by a PodDisruptionBudget (%s)\n", key, reason)
+			} else {
+				result += fmt.Sprintf("- %s: %s\n", key, notAttempted)
+			}
+		}
+		result += "\n"
+	}
+
+	if len(unmanaged) > 0 {
+		result += "## Not Recreated\n\n"
+		result += "These pods have no controller, so they aren't recreated once evicted:\n\n"
+		for _, pod := range unmanaged {
+			result += fmt.Sprintf("- %s/%s\n", pod.Metadata.Namespace, pod.Metadata.Name)
+		}
+		result += "\n"
+	}
+
+	if len(outcome.Failed) > 0 {
+		result += "## Failed\n\n"
+		keys := make([]string, 0, len(outcome.Failed))
+		for key := range outcome.Failed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			result += fmt.Sprintf("- %s: %s\n", key, outcome.Failed[key])
+		}
+		result += "\n"
+	}
+
+	result += "## Recommended Actions\n\n"
+	result += "1. Evicted pods shut down gracefully and are recreated elsewhere by their controllers; check for Pending pods if the cluster is short of capacity\n"
+	if len(outcome.Remaining) > 0 {
+		result += "2. Pods blocked by a PodDisruptionBudget are waiting on other replicas to become healthy; check them with get_pod_disruption_budget_status, then re-run the drain\n"
+	} else {
+		result += "2. Repair or delete the node, then uncordon it with `kubectl uncordon` if it is to be reused\n"
+	}
+	result += "3. emptyDir data on the node is lost\n"
+
+	if outcome.Cancelled {
+		result += cancelledNote
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
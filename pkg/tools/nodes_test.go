@@ -0,0 +1,370 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+)
+
+// testPod returns a pod in the default namespace, owned by a controller of
+// the given kind unless it is empty
+func testPod(name, ownerKind string, annotations map[string]string) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"name":        name,
+		"namespace":   "default",
+		"annotations": annotations,
+	}
+	if ownerKind != "" {
+		metadata["ownerReferences"] = []map[string]interface{}{{"kind": ownerKind, "name": name + "-owner"}}
+	}
+	return map[string]interface{}{
+		"metadata": metadata,
+		"spec":     map[string]interface{}{"nodeName": "node-1"},
+	}
+}
+
+func TestDrainablePods(t *testing.T) {
+	var pods []kubePod
+	data, _ := json.Marshal([]map[string]interface{}{
+		testPod("web", "ReplicaSet", nil),
+		testPod("bare", "", nil),
+		testPod("fluentbit", "DaemonSet", nil),
+		testPod("kube-proxy", "Node", map[string]string{mirrorPodAnnotation: "abc"}),
+	})
+	if err := json.Unmarshal(data, &pods); err != nil {
+		t.Fatalf("decoding pods: %v", err)
+	}
+
+	evict, skipped := drainablePods(pods)
+
+	var evictNames, skippedNames []string
+	for _, pod := range evict {
+		evictNames = append(evictNames, pod.Metadata.Name)
+	}
+	for _, pod := range skipped {
+		skippedNames = append(skippedNames, pod.Metadata.Name)
+	}
+	if got, want := strings.Join(evictNames, ","), "web,bare"; got != want {
+		t.Errorf("evicted pods = %s, want %s", got, want)
+	}
+	if got, want := strings.Join(skippedNames, ","), "fluentbit,kube-proxy"; got != want {
+		t.Errorf("skipped pods = %s, want %s", got, want)
+	}
+}
+
+func TestHandleDrainNode(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	cordoned := false
+
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		switch {
+		case r.Method == "PATCH" && r.URL.Path == "/api/v1/nodes/node-1":
+			var patch struct {
+				Spec struct {
+					Unschedulable bool `json:"unschedulable"`
+				} `json:"spec"`
+			}
+			json.NewDecoder(r.Body).Decode(&patch)
+			cordoned = patch.Spec.Unschedulable
+			writeJSON(w, http.StatusOK, map[string]interface{}{})
+		case r.Method == "GET" && r.URL.Path == "/api/v1/pods":
+			if got := r.URL.Query().Get("fieldSelector"); got != "spec.nodeName=node-1" {
+				t.Errorf("pods listed with fieldSelector %q", got)
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"items": []map[string]interface{}{
+				testPod("web", "ReplicaSet", nil),
+				testPod("fluentbit", "DaemonSet", nil),
+				testPod("kube-proxy", "Node", map[string]string{mirrorPodAnnotation: "abc"}),
+			}})
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/eviction"):
+			if !cordoned {
+				t.Errorf("%s sent before the node was cordoned", r.URL.Path)
+			}
+			writeJSON(w, http.StatusCreated, map[string]interface{}{})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	authHandler := newTestAuthHandler(t, auth.ReadWriteScopes)
+	result, err := handleDrainNode(context.Background(), newToolRequest(map[string]interface{}{
+		"project_id":   "test-project",
+		"location":     "us-central1",
+		"cluster_name": clusterName,
+		"node_name":    "node-1",
+		"confirm":      true,
+	}), authHandler)
+	if err != nil {
+		t.Fatalf("handleDrainNode returned error: %v", err)
+	}
+	text, _ := resultText(result)
+	if result.IsError {
+		t.Fatalf("handleDrainNode returned tool error: %s", text)
+	}
+
+	want := []string{
+		"PATCH /api/v1/nodes/node-1",
+		"GET /api/v1/pods",
+		"POST /api/v1/namespaces/default/pods/web/eviction",
+	}
+	if got := strings.Join(requests, "\n"); got != strings.Join(want, "\n") {
+		t.Errorf("requests:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+	if !strings.Contains(text, "Requested eviction of 1 of 1 pods; 2 DaemonSet or static pods were left in place") {
+		t.Errorf("result doesn't report skipped pods:\n%s", text)
+	}
+}
+
+func TestHandleDrainNodeUnmanagedPods(t *testing.T) {
+	tests := []struct {
+		name          string
+		force         bool
+		wantError     bool
+		wantEvictions []string
+	}{
+		{name: "refused without force", force: false, wantError: true},
+		{name: "evicted with force", force: true, wantEvictions: []string{"web", "bare"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var evictions []string
+
+			clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				switch {
+				case r.Method == "PATCH" && r.URL.Path == "/api/v1/nodes/node-1":
+					writeJSON(w, http.StatusOK, map[string]interface{}{})
+				case r.Method == "GET" && r.URL.Path == "/api/v1/pods":
+					writeJSON(w, http.StatusOK, map[string]interface{}{"items": []map[string]interface{}{
+						testPod("web", "ReplicaSet", nil),
+						testPod("bare", "", nil),
+					}})
+				case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/eviction"):
+					evictions = append(evictions, strings.Split(r.URL.Path, "/")[6])
+					writeJSON(w, http.StatusCreated, map[string]interface{}{})
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+
+			result, err := handleDrainNode(context.Background(), newToolRequest(map[string]interface{}{
+				"project_id":   "test-project",
+				"location":     "us-central1",
+				"cluster_name": clusterName,
+				"node_name":    "node-1",
+				"force":        tt.force,
+				"confirm":      true,
+			}), newTestAuthHandler(t, auth.ReadWriteScopes))
+			if err != nil {
+				t.Fatalf("handleDrainNode returned error: %v", err)
+			}
+			text, _ := resultText(result)
+
+			if result.IsError != tt.wantError {
+				t.Fatalf("handleDrainNode error = %t, want %t: %s", result.IsError, tt.wantError, text)
+			}
+			if got, want := strings.Join(evictions, ","), strings.Join(tt.wantEvictions, ","); got != want {
+				t.Errorf("evicted %q, want %q", got, want)
+			}
+
+			// Either way the pods that would be lost are named
+			lost := text
+			if tt.force {
+				_, lost, _ = strings.Cut(text, "## Not Recreated")
+				lost, _, _ = strings.Cut(lost, "## Recommended Actions")
+			}
+			if !strings.Contains(lost, "- default/bare\n") || strings.Contains(lost, "default/web") {
+				t.Errorf("result doesn't list only the pod without a controller:\n%s", text)
+			}
+		})
+	}
+}
+
+func TestHandleDrainNodeRequiresConfirm(t *testing.T) {
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+
+	authHandler := newTestAuthHandler(t, auth.ReadWriteScopes)
+	result, err := handleDrainNode(context.Background(), newToolRequest(map[string]interface{}{
+		"project_id":   "test-project",
+		"location":     "us-central1",
+		"cluster_name": clusterName,
+		"node_name":    "node-1",
+	}), authHandler)
+	if err != nil {
+		t.Fatalf("handleDrainNode returned error: %v", err)
+	}
+	if !result.IsError {
+		t.Errorf("handleDrainNode without confirm succeeded: %s", result.Content)
+	}
+}
+
+func TestEvictPodsBlockedByDisruptionBudget(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusTooManyRequests, map[string]interface{}{
+			"message": "Cannot evict pod as it would violate the pod's disruption budget.",
+		})
+	}))
+	defer srv.Close()
+
+	var pods []kubePod
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		var pod kubePod
+		pod.Metadata.Name = name
+		pod.Metadata.Namespace = "default"
+		pods = append(pods, pod)
+	}
+
+	start := time.Now()
+	kube := &kubeClient{endpoint: srv.URL, client: srv.Client()}
+	outcome := evictPods(context.Background(), kube, pods, 100*time.Millisecond)
+
+	if len(outcome.Remaining) != len(pods) {
+		t.Errorf("%d pods remaining, want %d", len(outcome.Remaining), len(pods))
+	}
+	if len(outcome.Blocked) != len(pods) {
+		t.Errorf("%d pods blocked, want %d", len(outcome.Blocked), len(pods))
+	}
+	if len(outcome.Failed) != 0 {
+		t.Errorf("blocked evictions reported as failed: %v", outcome.Failed)
+	}
+
+	// More blocked evictions than the breaker threshold mustn't open it
+	req, _ := http.NewRequest("GET", srv.URL, nil)
+	if breaker := breakerFor(req); breaker != nil && !breaker.allow(time.Now()) {
+		t.Error("blocked evictions opened the circuit breaker")
+	}
+	for _, outcome := range recentOutcomes(start) {
+		if outcome.Host == req.URL.Host {
+			t.Errorf("blocked eviction recorded as outcome %q", outcome.Class)
+		}
+	}
+}
+
+func TestGroupNodeProblems(t *testing.T) {
+	entries := decodeJSON[[]logEntry](t, `[
+		{"timestamp": "2026-10-17T01:00:00Z", "resource": {"labels": {"node_name": "node-1"}}, "jsonPayload": {"reason": "OOMKilling", "message": "first oom"}},
+		{"timestamp": "2026-10-17T01:05:00Z", "resource": {"labels": {"node_name": "node-1"}}, "jsonPayload": {"reason": "OOMKilling", "message": "latest oom"}},
+		{"timestamp": "2026-10-17T01:02:00Z", "resource": {"labels": {"node_name": "node-1"}}, "jsonPayload": {"reason": "OOMKilling", "message": "middle oom"}},
+		{"timestamp": "2026-10-17T01:01:00Z", "jsonPayload": {"involvedObject": {"name": "node-2"}, "reason": "KernelDeadlock", "message": "task blocked"}},
+		{"timestamp": "2026-10-17T01:03:00Z", "resource": {"labels": {"node_name": "node-2"}}, "jsonPayload": {"reason": "OOMKilling"}},
+		{"timestamp": "2026-10-17T01:04:00Z", "resource": {"labels": {"node_name": "node-3"}}, "jsonPayload": {"message": "no reason"}}
+	]`)
+
+	got := groupNodeProblems(entries)
+
+	// Critical problems first, then by count; the node comes from the event's
+	// object when the resource doesn't name it
+	want := []nodeProblem{
+		{Node: "node-2", Reason: "KernelDeadlock", Count: 1, LastSeen: "2026-10-17T01:01:00Z", LastMessage: "task blocked", Critical: true},
+		{Node: "node-1", Reason: "OOMKilling", Count: 3, LastSeen: "2026-10-17T01:05:00Z", LastMessage: "latest oom"},
+		{Node: "node-2", Reason: "OOMKilling", Count: 1, LastSeen: "2026-10-17T01:03:00Z"},
+		{Node: "node-3", Reason: "Unknown", Count: 1, LastSeen: "2026-10-17T01:04:00Z", LastMessage: "no reason"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("groupNodeProblems = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("problem %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestFindPreemptionBursts(t *testing.T) {
+	start := time.Date(2026, 10, 17, 1, 0, 0, 0, time.UTC)
+	var preemptions []preemption
+	for _, minutes := range []int{0, 4, 12, 40, 45, 120, 121, 122, 123} {
+		preemptions = append(preemptions, preemption{Time: start.Add(time.Duration(minutes) * time.Minute)})
+	}
+
+	// Gaps of up to the burst window chain together; two close preemptions
+	// aren't a burst
+	want := []preemptionBurst{
+		{Start: start, End: start.Add(12 * time.Minute), Count: 3},
+		{Start: start.Add(120 * time.Minute), End: start.Add(123 * time.Minute), Count: 4},
+	}
+	got := findPreemptionBursts(preemptions)
+	if len(got) != len(want) {
+		t.Fatalf("findPreemptionBursts = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("burst %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestHandleGetRecentPreemptions(t *testing.T) {
+	preempted := func(timestamp, instance string) string {
+		return `{"timestamp": "` + timestamp + `", "resource": {"labels": {"zone": "us-central1-a"}},
+			"protoPayload": {"methodName": "compute.instances.preempted", "resourceName": "projects/test-project/zones/us-central1-a/instances/` + instance + `"}}`
+	}
+	entries := fakeLogging(t, func(filter string) string {
+		if !strings.Contains(filter, `protoPayload.methodName="compute.instances.preempted"`) {
+			t.Errorf("unexpected filter %s", filter)
+		}
+		// Newest first, as the Logging API returns them
+		return "[" + strings.Join([]string{
+			preempted("2026-10-17T03:00:00Z", "gke-prod-default-9f8e7d6c-p0q1"),
+			preempted("2026-10-17T01:12:00Z", "gke-prod-spot-1a2b3c4d-c3d4"),
+			preempted("2026-10-17T01:05:00Z", "unrelated-vm"),
+			preempted("2026-10-17T01:04:00Z", "gke-prod-spot-1a2b3c4d-b2c3"),
+			preempted("2026-10-17T01:00:00Z", "gke-prod-spot-1a2b3c4d-a1b2"),
+		}, ",") + "]"
+	})
+
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host == "container.googleapis.com" {
+			w.Write([]byte(`{"nodePools": [
+				{"name": "spot", "config": {"spot": true}, "instanceGroupUrls": ["https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a/instanceGroupManagers/gke-prod-spot-1a2b3c4d-grp"]},
+				{"name": "default", "instanceGroupUrls": ["https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a/instanceGroupManagers/gke-prod-default-9f8e7d6c-grp"]}
+			]}`))
+			return
+		}
+		entries.ServeHTTP(w, r)
+	}))
+
+	text := callTool(t, ctx, handleGetRecentPreemptions, map[string]interface{}{
+		"project_id":   "test-project",
+		"location":     "us-central1",
+		"cluster_name": "prod",
+	})
+
+	for _, s := range []string{
+		// The VM outside the cluster's node pools isn't counted
+		"Found 4 node preemptions in the last 24.0 hours.",
+		"- **3 nodes** preempted between 2026-10-17 01:00:00 and 2026-10-17 01:12:00",
+		"- **spot**: 3 preemptions\n- **default**: 1 preemptions\n",
+		"| 2026-10-17 01:00:00 | gke-prod-spot-1a2b3c4d-a1b2 | us-central1-a | spot |\n" +
+			"| 2026-10-17 01:04:00 | gke-prod-spot-1a2b3c4d-b2c3 | us-central1-a | spot |\n" +
+			"| 2026-10-17 01:12:00 | gke-prod-spot-1a2b3c4d-c3d4 | us-central1-a | spot |\n" +
+			"| 2026-10-17 03:00:00 | gke-prod-default-9f8e7d6c-p0q1 | us-central1-a | default |\n",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if strings.Contains(text, "unrelated-vm") {
+		t.Errorf("result includes a VM outside the cluster:\n%s", text)
+	}
+}
@@ -0,0 +1,301 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerOperationTools registers tools that inspect Compute Engine and GKE
+// operations
+func registerOperationTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get resource creation failures tool
+	getCreationFailures := mcp.NewTool("get_resource_creation_failures",
+		mcp.WithDescription("Lists recent Compute Engine and GKE operations that failed, such as instance, disk, cluster or node pool creation, with the operation type, target resource and the error it carries (e.g. quota exceeded, zone resource exhaustion, invalid configuration). Gives \"my resource won't create\" incidents a concrete reason."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Description("A zone or region to limit operations to (optional, defaults to all locations)"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range to look back in hours (default: 24)"),
+		),
+	)
+
+	getCreationFailuresHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetResourceCreationFailures(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getCreationFailures, getCreationFailuresHandler)
+
+	return nil
+}
+
+// computeOperation is the subset of a Compute Engine operation used by the
+// tools
+type computeOperation struct {
+	Name          string `json:"name"`
+	OperationType string `json:"operationType"`
+	TargetLink    string `json:"targetLink"`
+	Status        string `json:"status"`
+	User          string `json:"user"`
+	InsertTime    string `json:"insertTime"`
+	EndTime       string `json:"endTime"`
+	Zone          string `json:"zone"`
+	Region        string `json:"region"`
+	Error         *struct {
+		Errors []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	} `json:"error"`
+}
+
+// errorMessage returns the operation's error, or an empty string if it
+// didn't fail
+func (o computeOperation) errorMessage() string {
+	if o.Error == nil || len(o.Error.Errors) == 0 {
+		return ""
+	}
+	messages := make([]string, 0, len(o.Error.Errors))
+	for _, e := range o.Error.Errors {
+		if e.Code != "" {
+			messages = append(messages, fmt.Sprintf("%s: %s", e.Code, e.Message))
+		} else {
+			messages = append(messages, e.Message)
+		}
+	}
+	return strings.Join(messages, "; ")
+}
+
+// gkeOperation is the subset of a GKE (Container API) operation used by the
+// tools
+type gkeOperation struct {
+	Name          string `json:"name"`
+	OperationType string `json:"operationType"`
+	Status        string `json:"status"`
+	StatusMessage string `json:"statusMessage"`
+	TargetLink    string `json:"targetLink"`
+	Location      string `json:"location"`
+	Zone          string `json:"zone"`
+	StartTime     string `json:"startTime"`
+	EndTime       string `json:"endTime"`
+	Error         *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// errorMessage returns the operation's error, or an empty string if it
+// didn't fail. Older operations only report errors in statusMessage.
+func (o gkeOperation) errorMessage() string {
+	if o.Error != nil && o.Error.Message != "" {
+		return o.Error.Message
+	}
+	return o.StatusMessage
+}
+
+// location returns the operation's location, which older operations report
+// as a zone
+func (o gkeOperation) location() string {
+	if o.Location != "" {
+		return o.Location
+	}
+	return o.Zone
+}
+
+// fetchContainerOperations lists the GKE operations in a location, or in all
+// locations when it's empty
+func fetchContainerOperations(ctx context.Context, client *http.Client, projectID, location string) ([]gkeOperation, error) {
+	if location == "" {
+		location = "-"
+	}
+	apiURL := fmt.Sprintf("%s/projects/%s/locations/%s/operations", gcpContainerBaseURL, projectID, location)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := doWithRetry(client, req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to Container API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error from Container API: %s", resp.Status)
+	}
+
+	var response struct {
+		Operations []gkeOperation `json:"operations"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("error parsing response: %w", err)
+	}
+
+	return response.Operations, nil
+}
+
+// computeOperationsPath returns the Compute Engine operations collection for
+// a zone, a region, or every scope when location is empty. Zones are told
+// apart from regions by their extra suffix, as in us-central1-a.
+func computeOperationsPath(projectID, location string) (path, itemsKey string) {
+	switch {
+	case location == "":
+		return fmt.Sprintf("projects/%s/aggregated/operations", projectID), "operations"
+	case strings.Count(location, "-") >= 2:
+		return fmt.Sprintf("projects/%s/zones/%s/operations", projectID, location), "items"
+	default:
+		return fmt.Sprintf("projects/%s/regions/%s/operations", projectID, location), "items"
+	}
+}
+
+// operationFailure is a failed Compute Engine or GKE operation
+type operationFailure struct {
+	Service       string
+	OperationType string
+	Target        string
+	Location      string
+	User          string
+	Time          time.Time
+	Error         string
+}
+
+// operationFailures returns the operations that failed since the given time,
+// newest first. Operations are matched on when they started.
+func operationFailures(computeOps []computeOperation, gkeOps []gkeOperation, since time.Time) []operationFailure {
+	var failures []operationFailure
+	for _, op := range computeOps {
+		message := op.errorMessage()
+		if message == "" {
+			continue
+		}
+		started, err := time.Parse(time.RFC3339Nano, op.InsertTime)
+		if err != nil || started.Before(since) {
+			continue
+		}
+		location := op.Zone
+		if location == "" {
+			location = op.Region
+		}
+		failures = append(failures, operationFailure{
+			Service:       "Compute Engine",
+			OperationType: op.OperationType,
+			Target:        computeResourcePath(op.TargetLink),
+			Location:      scopeName(location),
+			User:          op.User,
+			Time:          started,
+			Error:         message,
+		})
+	}
+	for _, op := range operationsSince(gkeOps, since) {
+		message := op.errorMessage()
+		if message == "" {
+			continue
+		}
+		failures = append(failures, operationFailure{
+			Service:       "GKE",
+			OperationType: op.OperationType,
+			Target:        computeResourcePath(op.TargetLink),
+			Location:      op.location(),
+			Time:          op.Started,
+			Error:         message,
+		})
+	}
+
+	sort.SliceStable(failures, func(i, j int) bool {
+		return failures[i].Time.After(failures[j].Time)
+	})
+	return failures
+}
+
+// handleGetResourceCreationFailures handles the get_resource_creation_failures tool request
+func handleGetResourceCreationFailures(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	location, _ := request.Params.Arguments["location"].(string)
+
+	timeRangeHours := 24.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	// Either API can be disabled in the project, so each failure is reported
+	// rather than returned
+	path, itemsKey := computeOperationsPath(projectID, location)
+	computeOps, computeErr := fetchComputeList[computeOperation](ctx, client, path, itemsKey)
+	gkeOps, gkeErr := fetchContainerOperations(ctx, client, projectID, location)
+	if computeErr != nil && gkeErr != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing operations: Compute Engine: %v; GKE: %v", computeErr, gkeErr)), nil
+	}
+
+	since := time.Now().Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+	failures := operationFailures(computeOps, gkeOps, since)
+
+	scope := "all locations"
+	if location != "" {
+		scope = location
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# Failed Operations in Project %s\n\n", projectID)
+	result += fmt.Sprintf("Operations started in the last %.1f hours in %s.\n\n", timeRangeHours, scope)
+	if computeErr != nil {
+		result += fmt.Sprintf("Error listing Compute Engine operations: %v\n\n", computeErr)
+	}
+	if gkeErr != nil {
+		result += fmt.Sprintf("Error listing GKE operations: %v\n\n", gkeErr)
+	}
+
+	if len(failures) == 0 {
+		result += "No failed operations were found.\n"
+		return mcp.NewToolResultText(result), nil
+	}
+
+	result += "| Time | Service | Operation | Target | Location |\n"
+	result += "| ---- | ------- | --------- | ------ | -------- |\n"
+	for _, failure := range failures {
+		result += fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
+			failure.Time.UTC().Format(time.RFC3339), failure.Service, failure.OperationType, valueOrDash(failure.Target), valueOrDash(failure.Location))
+	}
+	result += "\n"
+
+	result += "## Errors\n\n"
+	for _, failure := range failures {
+		result += fmt.Sprintf("### %s %s\n\n", failure.OperationType, valueOrDash(lastPathSegment(failure.Target)))
+		if failure.User != "" {
+			result += fmt.Sprintf("- **Requested By**: %s\n", failure.User)
+		}
+		result += fmt.Sprintf("- **Error**: %s\n\n", failure.Error)
+	}
+
+	result += "## Recommended Actions\n\n"
+	result += "1. For quota errors, request a quota increase or free up resources in the region; quotas are per region and per resource type\n"
+	result += "2. For ZONE_RESOURCE_POOL_EXHAUSTED or stockout errors, retry in another zone or with a different machine type\n"
+	result += "3. For invalid configuration or permission errors, fix the request, using get_organization_policy_violations when an org policy constraint is named\n"
+	result += "4. For failed node pool operations, use get_managed_instance_group_errors on the node pool's instance group for the per-instance reasons\n"
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,106 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestComputeOperationsPath(t *testing.T) {
+	tests := []struct {
+		location string
+		wantPath string
+		wantKey  string
+	}{
+		{location: "", wantPath: "projects/p/aggregated/operations", wantKey: "operations"},
+		{location: "us-central1", wantPath: "projects/p/regions/us-central1/operations", wantKey: "items"},
+		{location: "us-central1-a", wantPath: "projects/p/zones/us-central1-a/operations", wantKey: "items"},
+	}
+
+	for _, tt := range tests {
+		path, key := computeOperationsPath("p", tt.location)
+		if path != tt.wantPath || key != tt.wantKey {
+			t.Errorf("computeOperationsPath(%q) = %q, %q, want %q, %q", tt.location, path, key, tt.wantPath, tt.wantKey)
+		}
+	}
+}
+
+func TestOperationFailures(t *testing.T) {
+	now := time.Now()
+	at := func(ago time.Duration) string { return now.Add(-ago).UTC().Format(time.RFC3339) }
+
+	computeOps := decodeJSON[[]computeOperation](t, `[
+		{"operationType": "insert", "targetLink": "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/ok",
+		 "zone": "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a", "insertTime": "`+at(time.Hour)+`"},
+		{"operationType": "insert", "targetLink": "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/big",
+		 "zone": "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a", "insertTime": "`+at(2*time.Hour)+`",
+		 "error": {"errors": [{"code": "QUOTA_EXCEEDED", "message": "Quota 'CPUS' exceeded."}]}},
+		{"operationType": "insert", "targetLink": "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/old",
+		 "insertTime": "`+at(48*time.Hour)+`", "error": {"errors": [{"message": "too old"}]}}
+	]`)
+	gkeOps := decodeJSON[[]gkeOperation](t, `[
+		{"operationType": "CREATE_NODE_POOL", "targetLink": "https://container.googleapis.com/v1/projects/p/locations/us-central1/clusters/c/nodePools/gpu",
+		 "location": "us-central1", "startTime": "`+at(30*time.Minute)+`", "statusMessage": "ZONE_RESOURCE_POOL_EXHAUSTED"}
+	]`)
+
+	failures := operationFailures(computeOps, gkeOps, now.Add(-24*time.Hour))
+	if len(failures) != 2 {
+		t.Fatalf("got %d failures, want 2: %+v", len(failures), failures)
+	}
+
+	// Newest first
+	if got := failures[0]; got.Service != "GKE" || got.Location != "us-central1" || got.Error != "ZONE_RESOURCE_POOL_EXHAUSTED" ||
+		got.Target != "projects/p/locations/us-central1/clusters/c/nodePools/gpu" {
+		t.Errorf("failures[0] = %+v", got)
+	}
+	if got := failures[1]; got.Service != "Compute Engine" || got.Location != "us-central1-a" || got.Error != "QUOTA_EXCEEDED: Quota 'CPUS' exceeded." ||
+		got.Target != "projects/p/zones/us-central1-a/instances/big" {
+		t.Errorf("failures[1] = %+v", got)
+	}
+}
+
+func TestHandleGetResourceCreationFailures(t *testing.T) {
+	started := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Host + r.URL.Path {
+		case "compute.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a/operations":
+			w.Write([]byte(`{"items": [
+				{"operationType": "insert", "status": "DONE", "user": "dev@example.com", "insertTime": "` + started + `",
+				 "targetLink": "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a/instances/web-1",
+				 "zone": "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a",
+				 "error": {"errors": [{"code": "ZONE_RESOURCE_POOL_EXHAUSTED", "message": "The zone does not have enough resources available."}]}},
+				{"operationType": "insert", "status": "DONE", "insertTime": "` + started + `",
+				 "targetLink": "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a/disks/healthy-disk",
+				 "zone": "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a"}
+			]}`))
+		case "container.googleapis.com/v1/projects/test-project/locations/us-central1-a/operations":
+			// The Container API isn't enabled in the project
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	text := callTool(t, ctx, handleGetResourceCreationFailures, map[string]interface{}{
+		"project_id": "test-project",
+		"location":   "us-central1-a",
+	})
+
+	for _, s := range []string{
+		"Error listing GKE operations: error from Container API: 403 Forbidden",
+		"| Compute Engine | insert | projects/test-project/zones/us-central1-a/instances/web-1 | us-central1-a |",
+		"### insert web-1",
+		"- **Requested By**: dev@example.com\n",
+		"- **Error**: ZONE_RESOURCE_POOL_EXHAUSTED: The zone does not have enough resources available.\n",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if strings.Contains(text, "healthy-disk") {
+		t.Errorf("result includes an operation that succeeded:\n%s", text)
+	}
+}
@@ -0,0 +1,339 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerPodSecurityTools registers tools that explain Pod Security Admission
+func registerPodSecurityTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get effective PSP or PSA status tool
+	getPodSecurityStatus := mcp.NewTool("get_effective_psp_or_psa_status",
+		mcp.WithDescription("Reports each namespace's Pod Security Admission levels (enforce, warn, audit) and explains which Pod Security Standard checks rejected pods violated, from controllers' FailedCreate events. Explains pods that silently fail to be created."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("The Kubernetes namespace (if not provided, all namespaces are reported)"),
+		),
+		mcp.WithString("pod",
+			mcp.Description("Only explain rejections of this pod, or of pods created by this workload (e.g. a ReplicaSet or Deployment name prefix)"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range for rejection events in hours (default: 6)"),
+		),
+	)
+
+	getPodSecurityStatusHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetEffectivePodSecurityStatus(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getPodSecurityStatus, getPodSecurityStatusHandler)
+
+	return nil
+}
+
+// podSecurityLabelPrefix prefixes the namespace labels that configure Pod
+// Security Admission
+const podSecurityLabelPrefix = "pod-security.kubernetes.io/"
+
+// podSecurityLevels are the Pod Security Admission levels of a namespace. An
+// empty level means the label is unset, so the cluster default applies.
+type podSecurityLevels struct {
+	Enforce        string
+	EnforceVersion string
+	Warn           string
+	Audit          string
+}
+
+// podSecurityLabels reads the Pod Security Admission levels from namespace labels
+func podSecurityLabels(labels map[string]string) podSecurityLevels {
+	return podSecurityLevels{
+		Enforce:        labels[podSecurityLabelPrefix+"enforce"],
+		EnforceVersion: labels[podSecurityLabelPrefix+"enforce-version"],
+		Warn:           labels[podSecurityLabelPrefix+"warn"],
+		Audit:          labels[podSecurityLabelPrefix+"audit"],
+	}
+}
+
+// podSecurityPattern matches the admission error in a FailedCreate event,
+// capturing the pod name, the profile and the list of violations
+var podSecurityPattern = regexp.MustCompile(`pods "([^"]*)" is forbidden: violates PodSecurity "([^"]+)": (.+)$`)
+
+// splitPodSecurityViolations splits the comma-separated violations of an
+// admission error, ignoring commas inside the parenthesised details
+func splitPodSecurityViolations(s string) []string {
+	var violations []string
+	depth, inQuote, start := 0, false, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			inQuote = !inQuote
+		case '(':
+			if !inQuote {
+				depth++
+			}
+		case ')':
+			if !inQuote && depth > 0 {
+				depth--
+			}
+		case ',':
+			if !inQuote && depth == 0 {
+				if v := strings.TrimSpace(s[start:i]); v != "" {
+					violations = append(violations, v)
+				}
+				start = i + 1
+			}
+		}
+	}
+	if v := strings.TrimSpace(s[start:]); v != "" {
+		violations = append(violations, v)
+	}
+	return violations
+}
+
+// podSecurityRejection is a group of identical admission rejections of pods
+// created by one controller
+type podSecurityRejection struct {
+	Namespace  string
+	Owner      string
+	Pod        string
+	Profile    string
+	Violations []string
+	Count      int
+	LastSeen   string
+}
+
+// matchesPodFilter reports whether a rejection concerns the pod the caller
+// asked about. The pod may be named directly, or by its controller's name
+// as a prefix, and a rejected pod name is matched to its controller likewise.
+func (r podSecurityRejection) matchesPodFilter(pod string) bool {
+	if pod == "" {
+		return true
+	}
+	ownerName := r.Owner[strings.LastIndex(r.Owner, "/")+1:]
+	return strings.HasPrefix(r.Pod, pod) || strings.HasPrefix(pod, ownerName) || strings.HasPrefix(ownerName, pod)
+}
+
+// groupPodSecurityRejections extracts Pod Security rejections from
+// FailedCreate events, grouped by controller and violations, most recent first
+func groupPodSecurityRejections(entries []logEntry, pod string) []podSecurityRejection {
+	groups := make(map[string]*podSecurityRejection)
+	var order []string
+	for _, entry := range entries {
+		match := podSecurityPattern.FindStringSubmatch(payloadString(entry.JsonPayload, "message"))
+		if match == nil {
+			continue
+		}
+
+		rejection := podSecurityRejection{
+			Namespace:  payloadString(entry.JsonPayload, "involvedObject", "namespace"),
+			Owner:      payloadString(entry.JsonPayload, "involvedObject", "kind") + "/" + payloadString(entry.JsonPayload, "involvedObject", "name"),
+			Pod:        match[1],
+			Profile:    match[2],
+			Violations: splitPodSecurityViolations(match[3]),
+		}
+		if !rejection.matchesPodFilter(pod) {
+			continue
+		}
+
+		key := rejection.Namespace + "|" + rejection.Owner + "|" + match[2] + "|" + match[3]
+		group, ok := groups[key]
+		if !ok {
+			group = &rejection
+			groups[key] = group
+			order = append(order, key)
+		}
+		group.Count++
+		if entry.Timestamp > group.LastSeen {
+			group.LastSeen = entry.Timestamp
+			group.Pod = rejection.Pod
+		}
+	}
+
+	rejections := make([]podSecurityRejection, 0, len(order))
+	for _, key := range order {
+		rejections = append(rejections, *groups[key])
+	}
+
+	sort.SliceStable(rejections, func(i, j int) bool {
+		return rejections[i].LastSeen > rejections[j].LastSeen
+	})
+
+	return rejections
+}
+
+// handleGetEffectivePodSecurityStatus handles the get_effective_psp_or_psa_status tool request
+func handleGetEffectivePodSecurityStatus(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+	pod, _ := request.Params.Arguments["pod"].(string)
+
+	// Get optional parameters with defaults
+	timeRangeHours := 6.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error connecting to cluster: %v", err)), nil
+	}
+
+	var namespaces []struct {
+		Metadata kubeObjectMeta `json:"metadata"`
+	}
+	if namespace != "" {
+		var ns struct {
+			Metadata kubeObjectMeta `json:"metadata"`
+		}
+		if err := kube.get(ctx, "/api/v1/namespaces/"+namespace, nil, &ns); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error getting namespace: %v", err)), nil
+		}
+		namespaces = append(namespaces, ns)
+	} else {
+		var nsList struct {
+			Items []struct {
+				Metadata kubeObjectMeta `json:"metadata"`
+			} `json:"items"`
+		}
+		if err := kube.get(ctx, "/api/v1/namespaces", nil, &nsList); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error listing namespaces: %v", err)), nil
+		}
+		namespaces = nsList.Items
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	startTime := time.Now().Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+
+	// Rejected pods never exist, so the only trace is the controller's
+	// FailedCreate event
+	filter := fmt.Sprintf(`resource.labels.project_id="%s"
+		AND resource.labels.location="%s"
+		AND resource.labels.cluster_name="%s"
+		AND log_id("events")
+		AND jsonPayload.reason="FailedCreate"
+		AND jsonPayload.message:"violates PodSecurity"
+		AND timestamp >= "%s"`,
+		projectID, location, clusterName, startTime.Format(time.RFC3339))
+	if namespace != "" {
+		filter += fmt.Sprintf(`
+		AND jsonPayload.involvedObject.namespace="%s"`, namespace)
+	}
+
+	entries, _, err := fetchLogEntries(ctx, client, projectID, logQuery{
+		Filter:   filter,
+		PageSize: 500,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying logs: %v", err)), nil
+	}
+
+	rejections := groupPodSecurityRejections(entries, pod)
+
+	// Format the results
+	result := fmt.Sprintf("# Pod Security Admission Status for Cluster %s\n\n", clusterName)
+
+	result += "## Namespace Levels\n\n"
+	result += "Namespaces without labels use the cluster default, which is privileged (no restrictions) on GKE Standard.\n\n"
+	result += "| Namespace | Enforce | Warn | Audit |\n"
+	result += "| --------- | ------- | ---- | ----- |\n"
+	labelled := 0
+	for _, ns := range namespaces {
+		levels := podSecurityLabels(ns.Metadata.Labels)
+		if levels == (podSecurityLevels{}) {
+			if namespace == "" {
+				continue
+			}
+		} else {
+			labelled++
+		}
+
+		enforce := valueOrDash(levels.Enforce)
+		if levels.EnforceVersion != "" && levels.Enforce != "" {
+			enforce += ":" + levels.EnforceVersion
+		}
+		if levels.Enforce == "restricted" || levels.Enforce == "baseline" {
+			enforce = "**" + enforce + "**"
+		}
+		result += fmt.Sprintf("| %s | %s | %s | %s |\n", ns.Metadata.Name, enforce, valueOrDash(levels.Warn), valueOrDash(levels.Audit))
+	}
+	if namespace == "" {
+		result += fmt.Sprintf("\n%d of %d namespaces set Pod Security levels; the others are omitted.\n", labelled, len(namespaces))
+	}
+	result += "\n"
+
+	result += "## Rejected Pods\n\n"
+	if len(rejections) == 0 {
+		result += fmt.Sprintf("No pods were rejected by Pod Security Admission in the last %.1f hours.", timeRangeHours)
+		if pod != "" {
+			result += " If the pod was created directly rather than by a controller, the rejection was only returned to the client that created it."
+		}
+		result += "\n"
+		return mcp.NewToolResultText(result), nil
+	}
+
+	for _, rejection := range rejections {
+		result += fmt.Sprintf("### %s/%s\n\n", rejection.Namespace, rejection.Owner)
+		result += fmt.Sprintf("- **Profile**: %s\n", rejection.Profile)
+		result += fmt.Sprintf("- **Last Rejected Pod**: %s\n", rejection.Pod)
+		result += fmt.Sprintf("- **Rejections**: %d (last at %s)\n", rejection.Count, formatTime(rejection.LastSeen))
+		result += "- **Violations**:\n"
+		for _, violation := range rejection.Violations {
+			result += fmt.Sprintf("  - %s\n", violation)
+		}
+		result += "\n"
+	}
+
+	result += "## Recommended Actions\n\n"
+	result += "1. Fix the pod template so each listed check passes, for example by setting runAsNonRoot, dropping ALL capabilities, disallowing privilege escalation and setting a RuntimeDefault seccomp profile\n"
+	result += "2. If the workload legitimately needs the privilege, move it to a namespace with a less restrictive enforce level rather than relaxing a shared namespace\n"
+	result += "3. Set the warn label to the target level before tightening enforce, so violations show up as warnings first\n"
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// valueOrDash returns value, or "-" when it is empty
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
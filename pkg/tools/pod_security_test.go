@@ -0,0 +1,74 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitPodSecurityViolations(t *testing.T) {
+	s := `allowPrivilegeEscalation != false (container "app" must set securityContext.allowPrivilegeEscalation=false), ` +
+		`unrestricted capabilities (containers "app", "sidecar" must set securityContext.capabilities.drop=["ALL"]), ` +
+		`runAsNonRoot != true (pod or container "app" must set securityContext.runAsNonRoot=true)`
+
+	want := []string{
+		`allowPrivilegeEscalation != false (container "app" must set securityContext.allowPrivilegeEscalation=false)`,
+		`unrestricted capabilities (containers "app", "sidecar" must set securityContext.capabilities.drop=["ALL"])`,
+		`runAsNonRoot != true (pod or container "app" must set securityContext.runAsNonRoot=true)`,
+	}
+	if got := splitPodSecurityViolations(s); !reflect.DeepEqual(got, want) {
+		t.Errorf("splitPodSecurityViolations = %q, want %q", got, want)
+	}
+}
+
+func TestHandleGetEffectivePodSecurityStatus(t *testing.T) {
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces/payments" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"metadata": {"name": "payments", "labels": {
+			"pod-security.kubernetes.io/enforce": "restricted",
+			"pod-security.kubernetes.io/enforce-version": "v1.29",
+			"pod-security.kubernetes.io/warn": "restricted"
+		}}}`))
+	}))
+
+	event := func(timestamp, pod string) string {
+		return `{"timestamp": "` + timestamp + `", "jsonPayload": {
+			"involvedObject": {"kind": "ReplicaSet", "namespace": "payments", "name": "api-7f9c"},
+			"message": "Error creating: pods \"` + pod + `\" is forbidden: violates PodSecurity \"restricted:v1.29\": ` +
+			`privileged (container \"app\" must not set securityContext.privileged=true), ` +
+			`runAsNonRoot != true (pod or container \"app\" must set securityContext.runAsNonRoot=true)"}}`
+	}
+	ctx := withFakeGCP(context.Background(), fakeLogging(t, func(filter string) string {
+		for _, s := range []string{`jsonPayload.message:"violates PodSecurity"`, `jsonPayload.involvedObject.namespace="payments"`} {
+			if !strings.Contains(filter, s) {
+				t.Errorf("filter doesn't contain %s: %s", s, filter)
+			}
+		}
+		return "[" + event("2026-10-17T01:05:00Z", "api-7f9c-b2") + "," + event("2026-10-17T01:00:00Z", "api-7f9c-a1") + "]"
+	}))
+
+	text := callClusterTool(t, ctx, handleGetEffectivePodSecurityStatus, clusterName, map[string]interface{}{
+		"namespace": "payments",
+		"pod":       "api-7f9c",
+	})
+
+	for _, s := range []string{
+		"| payments | **restricted:v1.29** | restricted | - |\n",
+		"### payments/ReplicaSet/api-7f9c\n\n" +
+			"- **Profile**: restricted:v1.29\n" +
+			"- **Last Rejected Pod**: api-7f9c-b2\n" +
+			"- **Rejections**: 2 (last at 2026-10-17 01:05:00)\n" +
+			"- **Violations**:\n" +
+			"  - privileged (container \"app\" must not set securityContext.privileged=true)\n" +
+			"  - runAsNonRoot != true (pod or container \"app\" must set securityContext.runAsNonRoot=true)\n",
+		"## Recommended Actions",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
@@ -0,0 +1,335 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerPodTools registers tools that inspect pods via the Kubernetes API
+func registerPodTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get terminating pods tool
+	getTerminatingPods := mcp.NewTool("get_terminating_pods",
+		mcp.WithDescription("Lists pods stuck in Terminating past their grace period, with their remaining finalizers and node"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("The Kubernetes namespace (if not provided, all namespaces are searched)"),
+		),
+	)
+
+	getTerminatingPodsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetTerminatingPods(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getTerminatingPods, getTerminatingPodsHandler)
+
+	// Force deletion changes the cluster, so it is a remediation tool
+	if !remediationEnabled() {
+		return nil
+	}
+
+	// Register force delete pod tool
+	forceDeletePod := mcp.NewTool("force_delete_pod",
+		mcp.WithDescription("Force deletes a pod with a zero grace period, optionally removing its finalizers first. "+
+			"WARNING: this skips graceful shutdown and can cause data loss or split-brain for stateful pods. Requires the server to run with read-write scopes."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The Kubernetes namespace"),
+		),
+		mcp.WithString("pod_name",
+			mcp.Required(),
+			mcp.Description("The name of the pod"),
+		),
+		mcp.WithBoolean("remove_finalizers",
+			mcp.Description("Remove the pod's finalizers before deleting it (default: false)"),
+		),
+		mcp.WithBoolean("confirm",
+			mcp.Required(),
+			mcp.Description("Must be true to confirm the force deletion"),
+		),
+	)
+
+	forceDeletePodHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleForceDeletePod(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, forceDeletePod, forceDeletePodHandler)
+
+	return nil
+}
+
+// terminatingPod describes a pod that has outlived its deletion deadline
+type terminatingPod struct {
+	Namespace         string
+	Name              string
+	Node              string
+	NodeNotReady      bool
+	DeletionTimestamp time.Time
+	Overdue           time.Duration
+	Finalizers        []string
+}
+
+// findStuckTerminatingPods returns pods whose deletion timestamp, which already
+// includes the grace period, has passed. Pods are flagged when their node is
+// NotReady, since the kubelet can't confirm the containers have stopped.
+func findStuckTerminatingPods(pods []kubePod, nodes []kubeNode, now time.Time) []terminatingPod {
+	notReady := make(map[string]bool)
+	for _, node := range nodes {
+		if !node.isReady() {
+			notReady[node.Metadata.Name] = true
+		}
+	}
+
+	var stuck []terminatingPod
+	for _, pod := range pods {
+		if pod.Metadata.DeletionTimestamp == "" {
+			continue
+		}
+
+		deletionTime, err := time.Parse(time.RFC3339, pod.Metadata.DeletionTimestamp)
+		if err != nil || !now.After(deletionTime) {
+			continue
+		}
+
+		stuck = append(stuck, terminatingPod{
+			Namespace:         pod.Metadata.Namespace,
+			Name:              pod.Metadata.Name,
+			Node:              pod.Spec.NodeName,
+			NodeNotReady:      pod.Spec.NodeName != "" && notReady[pod.Spec.NodeName],
+			DeletionTimestamp: deletionTime,
+			Overdue:           now.Sub(deletionTime),
+			Finalizers:        pod.Metadata.Finalizers,
+		})
+	}
+
+	sort.Slice(stuck, func(i, j int) bool {
+		return stuck[i].Overdue > stuck[j].Overdue
+	})
+
+	return stuck
+}
+
+// handleGetTerminatingPods handles the get_terminating_pods tool request
+func handleGetTerminatingPods(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error connecting to cluster: %v", err)), nil
+	}
+
+	var podList struct {
+		Items []kubePod `json:"items"`
+	}
+	if err := kube.get(ctx, podsPath(namespace), nil, &podList); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing pods: %v", err)), nil
+	}
+
+	var nodeList struct {
+		Items []kubeNode `json:"items"`
+	}
+	if err := kube.get(ctx, "/api/v1/nodes", nil, &nodeList); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing nodes: %v", err)), nil
+	}
+
+	stuck := findStuckTerminatingPods(podList.Items, nodeList.Items, time.Now())
+
+	// Format the results
+	scope := "all namespaces"
+	if namespace != "" {
+		scope = "namespace " + namespace
+	}
+
+	if len(stuck) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No pods are stuck Terminating in %s of cluster %s.", scope, clusterName)), nil
+	}
+
+	result := fmt.Sprintf("# Pods Stuck Terminating in Cluster %s\n\n", clusterName)
+	result += fmt.Sprintf("Found %d pods past their grace period in %s:\n\n", len(stuck), scope)
+
+	nodesNotReady := 0
+	for i, pod := range stuck {
+		result += fmt.Sprintf("## %d. %s/%s\n\n", i+1, pod.Namespace, pod.Name)
+		result += fmt.Sprintf("- **Deletion Timestamp**: %s (%s overdue)\n",
+			pod.DeletionTimestamp.Format(time.RFC3339), pod.Overdue.Round(time.Second))
+
+		node := pod.Node
+		if node == "" {
+			node = "(not scheduled)"
+		}
+		if pod.NodeNotReady {
+			node += " **(NotReady)**"
+			nodesNotReady++
+		}
+		result += fmt.Sprintf("- **Node**: %s\n", node)
+
+		if len(pod.Finalizers) > 0 {
+			result += fmt.Sprintf("- **Finalizers**: %s\n", strings.Join(pod.Finalizers, ", "))
+		} else {
+			result += "- **Finalizers**: none\n"
+		}
+
+		result += "\n"
+	}
+
+	result += "## Potential Causes\n\n"
+	if nodesNotReady > 0 {
+		result += fmt.Sprintf("- %d of these pods are on NotReady nodes. The kubelet can't confirm the containers stopped, so deletion won't complete until the node recovers or is removed.\n", nodesNotReady)
+	}
+	result += "- Pods with finalizers wait for the owning controller to remove them. Check that the controller is running and healthy.\n"
+	result += "- Force deletion should be a last resort, particularly for StatefulSet pods, where it can lead to two pods sharing the same identity.\n"
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// handleForceDeletePod handles the force_delete_pod tool request
+func handleForceDeletePod(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	namespace, ok := request.Params.Arguments["namespace"].(string)
+	if !ok || namespace == "" {
+		return mcp.NewToolResultError("namespace must be a non-empty string"), nil
+	}
+
+	podName, ok := request.Params.Arguments["pod_name"].(string)
+	if !ok || podName == "" {
+		return mcp.NewToolResultError("pod_name must be a non-empty string"), nil
+	}
+
+	removeFinalizers, _ := request.Params.Arguments["remove_finalizers"].(bool)
+
+	// Refuse to touch the cluster at all without an explicit confirmation
+	if confirm, _ := request.Params.Arguments["confirm"].(bool); !confirm {
+		return mcp.NewToolResultError(fmt.Sprintf("Force deleting pod %s/%s skips graceful shutdown and can cause data loss. Set confirm to true to proceed.", namespace, podName)), nil
+	}
+
+	// Force deletion is a mutating operation
+	if result := requireReadWrite(authHandler, "force_delete_pod"); result != nil {
+		return result, nil
+	}
+
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error connecting to cluster: %v", err)), nil
+	}
+
+	podPath := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s", namespace, podName)
+
+	var pod kubePod
+	if err := kube.get(ctx, podPath, nil, &pod); err != nil {
+		if isKubeNotFound(err) {
+			return mcp.NewToolResultError(fmt.Sprintf("Pod %s not found in namespace %s.", podName, namespace)), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting pod: %v", err)), nil
+	}
+
+	result := fmt.Sprintf("# Force Deleted Pod %s/%s\n\n", namespace, podName)
+
+	if removeFinalizers && len(pod.Metadata.Finalizers) > 0 {
+		patch := map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"finalizers": nil,
+			},
+		}
+		if err := kube.do(ctx, "PATCH", podPath, nil, "application/merge-patch+json", patch, nil); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error removing finalizers: %v", err)), nil
+		}
+		result += fmt.Sprintf("- Removed finalizers: %s\n", strings.Join(pod.Metadata.Finalizers, ", "))
+	}
+
+	query := url.Values{}
+	query.Set("gracePeriodSeconds", "0")
+	if err := kube.do(ctx, "DELETE", podPath, query, "", nil, nil); err != nil {
+		if isKubeNotFound(err) {
+			result += "- The pod was already gone by the time the delete was sent.\n"
+			return mcp.NewToolResultText(result), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Error deleting pod: %v", err)), nil
+	}
+	result += "- Deleted with a grace period of 0 seconds\n"
+
+	if !removeFinalizers && len(pod.Metadata.Finalizers) > 0 {
+		result += fmt.Sprintf("\nThe pod still has finalizers (%s) and will remain until they are removed. Re-run with remove_finalizers to clear them.\n",
+			strings.Join(pod.Metadata.Finalizers, ", "))
+	}
+
+	// Warn loudly about the risks for stateful pods
+	var claims []string
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil {
+			claims = append(claims, volume.PersistentVolumeClaim.ClaimName)
+		}
+	}
+	if pod.ownerKind() == "StatefulSet" || len(claims) > 0 {
+		result += "\n## WARNING: Stateful Pod\n\n"
+		if pod.ownerKind() == "StatefulSet" {
+			result += "This pod belongs to a StatefulSet. If its containers are still running on an unreachable node, the replacement pod may run alongside it with the same identity.\n"
+		}
+		if len(claims) > 0 {
+			result += fmt.Sprintf("This pod mounted persistent volume claims (%s). Verify the volumes are detached and the data is consistent before relying on the replacement pod.\n",
+				strings.Join(claims, ", "))
+		}
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,147 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+)
+
+// forceDeleteRequest returns force_delete_pod arguments for pod web-0
+func forceDeleteRequest(clusterName string, confirm bool) map[string]interface{} {
+	return map[string]interface{}{
+		"project_id":        "test-project",
+		"location":          "us-central1",
+		"cluster_name":      clusterName,
+		"namespace":         "default",
+		"pod_name":          "web-0",
+		"remove_finalizers": true,
+		"confirm":           confirm,
+	}
+}
+
+func TestHandleForceDeletePodRequiresConfirm(t *testing.T) {
+	requests := 0
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	authHandler := newTestAuthHandler(t, auth.ReadWriteScopes)
+	result, err := handleForceDeletePod(context.Background(), newToolRequest(forceDeleteRequest(clusterName, false)), authHandler)
+	if err != nil {
+		t.Fatalf("handleForceDeletePod returned error: %v", err)
+	}
+
+	text, _ := resultText(result)
+	if !result.IsError || !strings.Contains(text, "Set confirm to true") {
+		t.Errorf("handleForceDeletePod without confirm didn't refuse: %s", text)
+	}
+	if requests != 0 {
+		t.Errorf("%d requests sent without confirm, want none", requests)
+	}
+}
+
+func TestHandleForceDeletePod(t *testing.T) {
+	var requests []string
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery)
+
+		switch r.Method {
+		case "GET":
+			pod := testPod("web-0", "StatefulSet", nil)
+			pod["metadata"].(map[string]interface{})["finalizers"] = []string{"example.com/cleanup"}
+			writeJSON(w, http.StatusOK, pod)
+		case "PATCH", "DELETE":
+			writeJSON(w, http.StatusOK, map[string]interface{}{})
+		}
+	}))
+
+	authHandler := newTestAuthHandler(t, auth.ReadWriteScopes)
+	result, err := handleForceDeletePod(context.Background(), newToolRequest(forceDeleteRequest(clusterName, true)), authHandler)
+	if err != nil {
+		t.Fatalf("handleForceDeletePod returned error: %v", err)
+	}
+	text, _ := resultText(result)
+	if result.IsError {
+		t.Fatalf("handleForceDeletePod returned tool error: %s", text)
+	}
+
+	want := []string{
+		"GET /api/v1/namespaces/default/pods/web-0?",
+		"PATCH /api/v1/namespaces/default/pods/web-0?",
+		"DELETE /api/v1/namespaces/default/pods/web-0?gracePeriodSeconds=0",
+	}
+	if got := strings.Join(requests, "\n"); got != strings.Join(want, "\n") {
+		t.Errorf("requests:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+	for _, s := range []string{"Removed finalizers: example.com/cleanup", "WARNING: Stateful Pod"} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
+
+// terminatingTestPod returns a pod on node-1 deleted at deletionTimestamp,
+// which already includes its grace period
+func terminatingTestPod(name, deletionTimestamp string, finalizers ...string) map[string]interface{} {
+	metadata := map[string]interface{}{"name": name, "namespace": "default", "finalizers": finalizers}
+	if deletionTimestamp != "" {
+		metadata["deletionTimestamp"] = deletionTimestamp
+	}
+	return map[string]interface{}{
+		"metadata": metadata,
+		"spec":     map[string]interface{}{"nodeName": "node-1", "terminationGracePeriodSeconds": 30},
+	}
+}
+
+func TestHandleGetTerminatingPods(t *testing.T) {
+	now := time.Now().UTC()
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/namespaces/default/pods":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"items": []map[string]interface{}{
+				terminatingTestPod("not-deleted", ""),
+				terminatingTestPod("within-grace", now.Add(20*time.Second).Format(time.RFC3339)),
+				terminatingTestPod("stuck-finalizer", now.Add(-10*time.Minute).Format(time.RFC3339), "example.com/cleanup"),
+				terminatingTestPod("stuck", now.Add(-time.Hour).Format(time.RFC3339)),
+			}})
+		case "/api/v1/nodes":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"items": []map[string]interface{}{{
+				"metadata": map[string]string{"name": "node-1"},
+				"status":   map[string]interface{}{"conditions": []map[string]string{{"type": "Ready", "status": "Unknown"}}},
+			}}})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	text := callClusterTool(t, context.Background(), handleGetTerminatingPods, clusterName, map[string]interface{}{
+		"namespace": "default",
+	})
+
+	// Only pods past their deletion timestamp are reported, most overdue first
+	if !strings.Contains(text, "Found 2 pods past their grace period in namespace default") {
+		t.Errorf("result doesn't count the stuck pods:\n%s", text)
+	}
+	if strings.Contains(text, "not-deleted") || strings.Contains(text, "within-grace") {
+		t.Errorf("result reports pods that aren't stuck:\n%s", text)
+	}
+	stuck, finalizer := strings.Index(text, "## 1. default/stuck\n"), strings.Index(text, "## 2. default/stuck-finalizer\n")
+	if stuck < 0 || finalizer < stuck {
+		t.Errorf("stuck pods aren't ordered by how overdue they are:\n%s", text)
+	}
+	for _, s := range []string{
+		"- **Finalizers**: example.com/cleanup",
+		"- **Node**: node-1 **(NotReady)**",
+		"2 of these pods are on NotReady nodes",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
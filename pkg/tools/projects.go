@@ -0,0 +1,148 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerProjectTools registers tools that discover Google Cloud projects
+func registerProjectTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register list projects tool
+	listProjects := mcp.NewTool("list_projects",
+		mcp.WithDescription("Lists the Google Cloud projects the authenticated account can access, with their project ID, name, number and lifecycle state. Use it to find the project_id other tools need."),
+		mcp.WithString("filter",
+			mcp.Description("A Resource Manager filter expression, such as name:payments* or labels.env:prod (optional)"),
+		),
+		mcp.WithNumber("max_results",
+			mcp.Description("Maximum number of projects to return (default: 100)"),
+		),
+	)
+
+	listProjectsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleListProjects(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, listProjects, listProjectsHandler)
+
+	return nil
+}
+
+// gcpProject is a project returned by the Resource Manager API
+type gcpProject struct {
+	ProjectID      string `json:"projectId"`
+	Name           string `json:"name"`
+	ProjectNumber  string `json:"projectNumber"`
+	LifecycleState string `json:"lifecycleState"`
+}
+
+// fetchProjects lists up to maxResults projects matching the filter,
+// following pagination. It also reports whether more projects matched.
+func fetchProjects(ctx context.Context, client *http.Client, filter string, maxResults int) ([]gcpProject, bool, error) {
+	var projects []gcpProject
+	pageToken := ""
+	for {
+		params := url.Values{}
+		params.Set("pageSize", strconv.Itoa(maxResults-len(projects)))
+		if filter != "" {
+			params.Set("filter", filter)
+		}
+		if pageToken != "" {
+			params.Set("pageToken", pageToken)
+		}
+		apiURL := fmt.Sprintf("%s/projects?%s", gcpResourceManagerV1BaseURL, params.Encode())
+
+		req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+		if err != nil {
+			return nil, false, fmt.Errorf("error creating request: %w", err)
+		}
+
+		resp, err := doWithRetry(client, req)
+		if err != nil {
+			return nil, false, fmt.Errorf("error making request to Resource Manager API: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, false, fmt.Errorf("error from Resource Manager API: %s", resp.Status)
+		}
+
+		var response struct {
+			Projects      []gcpProject `json:"projects"`
+			NextPageToken string       `json:"nextPageToken"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, false, fmt.Errorf("error parsing response: %w", err)
+		}
+
+		projects = append(projects, response.Projects...)
+		pageToken = response.NextPageToken
+		if len(projects) >= maxResults {
+			return projects[:maxResults], pageToken != "" || len(projects) > maxResults, nil
+		}
+		if pageToken == "" {
+			return projects, false, nil
+		}
+	}
+}
+
+// handleListProjects handles the list_projects tool request
+func handleListProjects(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Get optional parameters with defaults
+	filter, _ := request.Params.Arguments["filter"].(string)
+
+	maxResults := 100
+	if val, ok := request.Params.Arguments["max_results"].(float64); ok && val > 0 {
+		maxResults = int(val)
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	projects, more, err := fetchProjects(ctx, client, filter, maxResults)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing projects: %v", err)), nil
+	}
+
+	// Format the results
+	if len(projects) == 0 {
+		if filter != "" {
+			return mcp.NewToolResultText(fmt.Sprintf("No accessible projects match the filter %q.", filter)), nil
+		}
+		return mcp.NewToolResultText("The authenticated account can't access any projects."), nil
+	}
+
+	result := "# Projects\n\n"
+	result += fmt.Sprintf("Found %d accessible projects", len(projects))
+	if filter != "" {
+		result += fmt.Sprintf(" matching %q", filter)
+	}
+	result += ".\n\n"
+	if more {
+		result += fmt.Sprintf("More projects are available; raise max_results above %d or narrow the filter to see them.\n\n", maxResults)
+	}
+
+	result += "| Project ID | Name | Number | State |\n"
+	result += "| ---------- | ---- | ------ | ----- |\n"
+	for _, project := range projects {
+		state := project.LifecycleState
+		if state != "ACTIVE" {
+			state = fmt.Sprintf("**%s**", valueOrDash(state))
+		}
+		result += fmt.Sprintf("| %s | %s | %s | %s |\n", project.ProjectID, valueOrDash(project.Name), valueOrDash(project.ProjectNumber), state)
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
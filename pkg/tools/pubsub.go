@@ -0,0 +1,269 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// GCP Pub/Sub API base URL
+	gcpPubSubBaseURL = "https://pubsub.googleapis.com/v1"
+
+	// pubsubMaxSampleMessages caps how many messages a sample can pull
+	pubsubMaxSampleMessages = 20
+
+	// pubsubPreviewBytes caps how much of each message body is shown
+	pubsubPreviewBytes = 512
+)
+
+// registerPubSubTools registers all Pub/Sub related tools
+func registerPubSubTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get Pub/Sub message sample tool
+	getMessageSample := mcp.NewTool("get_pubsub_message_sample",
+		mcp.WithDescription("Peeks at messages on a Pub/Sub subscription without acknowledging them, showing attributes and a preview of the data. "+
+			"Pulled messages are leased until their ack deadline expires unless nack is set. Requires the server to run with read-write scopes."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("subscription",
+			mcp.Required(),
+			mcp.Description("The subscription ID or full resource name"),
+		),
+		mcp.WithNumber("max_messages",
+			mcp.Description(fmt.Sprintf("Maximum number of messages to pull (default: 5, max: %d)", pubsubMaxSampleMessages)),
+		),
+		mcp.WithBoolean("nack",
+			mcp.Description("Release the pulled messages immediately so they are redelivered to the real consumer without waiting for the ack deadline (default: false)"),
+		),
+	)
+
+	getMessageSampleHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetPubSubMessageSample(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getMessageSample, getMessageSampleHandler)
+
+	if err := registerPubSubPushErrorTools(s, authHandler); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// pubsubReceivedMessage is a message returned by a subscription pull
+type pubsubReceivedMessage struct {
+	AckID   string `json:"ackId"`
+	Message struct {
+		Data        string            `json:"data"`
+		Attributes  map[string]string `json:"attributes"`
+		MessageID   string            `json:"messageId"`
+		PublishTime string            `json:"publishTime"`
+		OrderingKey string            `json:"orderingKey"`
+	} `json:"message"`
+	DeliveryAttempt int `json:"deliveryAttempt"`
+}
+
+// pubsubSubscriptionPath returns the full resource name for a subscription
+func pubsubSubscriptionPath(projectID, subscription string) string {
+	if strings.HasPrefix(subscription, "projects/") {
+		return subscription
+	}
+	return fmt.Sprintf("projects/%s/subscriptions/%s", projectID, subscription)
+}
+
+// postPubSub sends a POST request to a Pub/Sub subscription method
+func postPubSub(ctx context.Context, client *http.Client, subscriptionPath, method string, body interface{}, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("error encoding request: %w", err)
+	}
+
+	apiURL := fmt.Sprintf("%s/%s:%s", gcpPubSubBaseURL, subscriptionPath, method)
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := doWithRetry(client, req)
+	if err != nil {
+		return fmt.Errorf("error making request to Pub/Sub API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error from Pub/Sub API: %s", resp.Status)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("error parsing response: %w", err)
+	}
+	return nil
+}
+
+// pullMessages pulls up to maxMessages from a subscription without waiting
+// for messages to arrive. The messages are not acknowledged.
+func pullMessages(ctx context.Context, client *http.Client, subscriptionPath string, maxMessages int) ([]pubsubReceivedMessage, error) {
+	body := map[string]interface{}{
+		"maxMessages":       maxMessages,
+		"returnImmediately": true,
+	}
+
+	var response struct {
+		ReceivedMessages []pubsubReceivedMessage `json:"receivedMessages"`
+	}
+	if err := postPubSub(ctx, client, subscriptionPath, "pull", body, &response); err != nil {
+		return nil, err
+	}
+	return response.ReceivedMessages, nil
+}
+
+// nackMessages releases pulled messages for immediate redelivery by setting
+// their ack deadline to zero
+func nackMessages(ctx context.Context, client *http.Client, subscriptionPath string, ackIDs []string) error {
+	body := map[string]interface{}{
+		"ackIds":             ackIDs,
+		"ackDeadlineSeconds": 0,
+	}
+	return postPubSub(ctx, client, subscriptionPath, "modifyAckDeadline", body, nil)
+}
+
+// previewMessageData decodes base64 message data and returns a size-capped
+// preview, describing binary payloads rather than printing them
+func previewMessageData(data string) string {
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return fmt.Sprintf("(undecodable data: %v)", err)
+	}
+	if len(decoded) == 0 {
+		return "(empty)"
+	}
+
+	preview := decoded
+	if len(preview) > pubsubPreviewBytes {
+		preview = preview[:pubsubPreviewBytes]
+		// Don't cut a multi-byte character in half
+		for len(preview) > 0 && !utf8.Valid(preview) {
+			preview = preview[:len(preview)-1]
+		}
+	}
+
+	if !utf8.Valid(preview) {
+		return fmt.Sprintf("(%d bytes of binary data)", len(decoded))
+	}
+
+	text := string(preview)
+	if len(preview) < len(decoded) {
+		text += fmt.Sprintf("\n... (truncated, %d bytes total)", len(decoded))
+	}
+	return text
+}
+
+// handleGetPubSubMessageSample handles the get_pubsub_message_sample tool request
+func handleGetPubSubMessageSample(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	subscription, ok := request.Params.Arguments["subscription"].(string)
+	if !ok || subscription == "" {
+		return mcp.NewToolResultError("subscription must be a non-empty string"), nil
+	}
+
+	maxMessages := 5
+	if val, ok := request.Params.Arguments["max_messages"].(float64); ok && val > 0 {
+		maxMessages = int(val)
+	}
+	if maxMessages > pubsubMaxSampleMessages {
+		maxMessages = pubsubMaxSampleMessages
+	}
+
+	nack, _ := request.Params.Arguments["nack"].(bool)
+
+	// Pulling leases messages, so it needs more than read-only access
+	if result := requireReadWrite(authHandler, "get_pubsub_message_sample"); result != nil {
+		return result, nil
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	subscriptionPath := pubsubSubscriptionPath(projectID, subscription)
+
+	messages, err := pullMessages(ctx, client, subscriptionPath, maxMessages)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error pulling messages: %v", err)), nil
+	}
+
+	if len(messages) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No messages available on %s. The backlog may be empty, or messages may be leased to another subscriber.", subscriptionPath)), nil
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# Message Sample from %s\n\n", subscriptionPath)
+	result += fmt.Sprintf("Pulled %d messages. They were **not** acknowledged.\n\n", len(messages))
+	result += "Note: On subscriptions with a dead-letter policy each sample counts as a delivery attempt.\n\n"
+
+	for i, msg := range messages {
+		result += fmt.Sprintf("## Message %d\n\n", i+1)
+		result += fmt.Sprintf("- **Message ID**: %s\n", msg.Message.MessageID)
+		result += fmt.Sprintf("- **Published**: %s\n", msg.Message.PublishTime)
+		if msg.Message.OrderingKey != "" {
+			result += fmt.Sprintf("- **Ordering Key**: %s\n", msg.Message.OrderingKey)
+		}
+		if msg.DeliveryAttempt > 0 {
+			result += fmt.Sprintf("- **Delivery Attempt**: %d\n", msg.DeliveryAttempt)
+		}
+
+		if len(msg.Message.Attributes) > 0 {
+			keys := make([]string, 0, len(msg.Message.Attributes))
+			for key := range msg.Message.Attributes {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			result += "- **Attributes**:\n"
+			for _, key := range keys {
+				result += fmt.Sprintf("  - %s: %s\n", key, msg.Message.Attributes[key])
+			}
+		}
+
+		result += "\n```\n" + previewMessageData(msg.Message.Data) + "\n```\n\n"
+	}
+
+	if nack {
+		ackIDs := make([]string, 0, len(messages))
+		for _, msg := range messages {
+			ackIDs = append(ackIDs, msg.AckID)
+		}
+		if err := nackMessages(ctx, client, subscriptionPath, ackIDs); err != nil {
+			result += fmt.Sprintf("**Warning**: Failed to release messages, they will be redelivered once the ack deadline expires: %v\n", err)
+		} else {
+			result += "The messages were released for immediate redelivery to the subscription's consumers.\n"
+		}
+	} else {
+		result += "The messages are leased to this sample until the subscription's ack deadline expires, after which they are redelivered to the subscription's consumers. Set nack to release them immediately.\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,221 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// elevatedPushErrorRate is the share of push requests failing above which a
+// push endpoint is flagged
+const elevatedPushErrorRate = 0.05
+
+// registerPubSubPushErrorTools registers tools that report push subscription
+// delivery errors
+func registerPubSubPushErrorTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get Pub/Sub subscription error rate tool
+	getSubscriptionErrorRate := mcp.NewTool("get_pubsub_subscription_error_rate",
+		mcp.WithDescription("Reports the error rate of a push subscription's endpoint over a time window, from push request counts split by response class and code, flagging elevated non-2xx responses. "+
+			"Tells a consumer that is returning errors apart from one that is too slow and hitting the ack deadline, either of which causes redelivery storms."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("subscription",
+			mcp.Required(),
+			mcp.Description("The subscription ID or full resource name"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range to analyse in hours (default: 1)"),
+		),
+	)
+
+	getSubscriptionErrorRateHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetPubSubSubscriptionErrorRate(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getSubscriptionErrorRate, getSubscriptionErrorRateHandler)
+
+	return nil
+}
+
+// pushErrorBreakdown summarises a push subscription's push requests by the
+// response_class label. The "ack" class is a successful delivery; every other
+// class is a failed one that Pub/Sub will redeliver.
+type pushErrorBreakdown struct {
+	Classes map[string]float64
+	Codes   map[string]float64
+	Total   float64
+	Errors  float64
+	// Slow counts requests that hit the ack deadline, as opposed to ones the
+	// endpoint answered with an error or that couldn't reach it
+	Slow float64
+}
+
+// newPushErrorBreakdown builds the breakdown from push_request_count series
+// grouped by response_class and response_code
+func newPushErrorBreakdown(series []timeSeries) pushErrorBreakdown {
+	b := pushErrorBreakdown{
+		Classes: sumSeriesByLabel(series, "response_class"),
+		Codes:   make(map[string]float64),
+	}
+	for class, count := range b.Classes {
+		b.Total += count
+		if class == "ack" {
+			continue
+		}
+		b.Errors += count
+		if class == "deadline_exceeded" {
+			b.Slow += count
+		}
+	}
+
+	// Only failed responses are worth breaking down by code
+	for _, ts := range series {
+		if ts.Metric.Labels["response_class"] == "ack" {
+			continue
+		}
+		for _, point := range ts.Points {
+			b.Codes[ts.Metric.Labels["response_code"]] += point.value()
+		}
+	}
+
+	return b
+}
+
+// errorRate returns the share of push requests that failed
+func (b pushErrorBreakdown) errorRate() float64 {
+	if b.Total == 0 {
+		return 0
+	}
+	return b.Errors / b.Total
+}
+
+// elevated reports whether the endpoint's error rate is elevated
+func (b pushErrorBreakdown) elevated() bool {
+	return b.errorRate() > elevatedPushErrorRate
+}
+
+// diagnosis summarises whether failed pushes came from the consumer erroring
+// or from it being too slow
+func (b pushErrorBreakdown) diagnosis() string {
+	switch {
+	case b.Errors == 0:
+		return "Every push was acknowledged."
+	case b.Slow >= 0.8*b.Errors:
+		return "Most failed pushes hit the ack deadline, so the consumer is too slow rather than erroring."
+	case b.Slow <= 0.2*b.Errors:
+		return "Most failed pushes were error responses or couldn't reach the endpoint, so the consumer is erroring rather than slow."
+	default:
+		return "Failed pushes are a mix of ack deadline timeouts and errors, so the consumer is both slow and erroring."
+	}
+}
+
+// handleGetPubSubSubscriptionErrorRate handles the get_pubsub_subscription_error_rate tool request
+func handleGetPubSubSubscriptionErrorRate(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	subscription, ok := request.Params.Arguments["subscription"].(string)
+	if !ok || subscription == "" {
+		return mcp.NewToolResultError("subscription must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	timeRangeHours := 1.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	// Monitoring labels subscriptions by ID, not full resource name
+	subscriptionPath := pubsubSubscriptionPath(projectID, subscription)
+	subscriptionID := subscriptionPath[strings.LastIndex(subscriptionPath, "/")+1:]
+
+	// Each series is reduced to a single total over the window
+	endTime := time.Now()
+	startTime := endTime.Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+	series, err := fetchTimeSeries(ctx, client, projectID, timeSeriesQuery{
+		Filter: fmt.Sprintf(`metric.type="pubsub.googleapis.com/subscription/push_request_count" AND resource.type="pubsub_subscription" AND resource.labels.subscription_id="%s"`,
+			subscriptionID),
+		StartTime:          startTime,
+		EndTime:            endTime,
+		AlignmentPeriod:    endTime.Sub(startTime),
+		PerSeriesAligner:   "ALIGN_DELTA",
+		CrossSeriesReducer: "REDUCE_SUM",
+		GroupByFields:      []string{"metric.labels.response_class", "metric.labels.response_code"},
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying push request counts: %v", err)), nil
+	}
+
+	breakdown := newPushErrorBreakdown(series)
+	if breakdown.Total == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No push requests were sent for subscription %s in the last %.1f hours. Check that it is a push subscription and that messages were published to its topic.", subscriptionID, timeRangeHours)), nil
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# Push Error Rate for Subscription %s\n\n", subscriptionID)
+	result += fmt.Sprintf("%.0f push requests in the last %.1f hours, %.0f (%.2f%%) of them failed.\n\n", breakdown.Total, timeRangeHours, breakdown.Errors, breakdown.errorRate()*100)
+	if breakdown.elevated() {
+		result += fmt.Sprintf("**The push endpoint's error rate is elevated** (above %.0f%%), so Pub/Sub is redelivering a large share of messages.\n\n", elevatedPushErrorRate*100)
+	}
+	result += breakdown.diagnosis() + "\n\n"
+
+	classes := make([]string, 0, len(breakdown.Classes))
+	for class := range breakdown.Classes {
+		classes = append(classes, class)
+	}
+	sort.SliceStable(classes, func(i, j int) bool {
+		return breakdown.Classes[classes[i]] > breakdown.Classes[classes[j]]
+	})
+
+	result += "## Responses by Class\n\n"
+	result += "| Class | Requests | Share |\n"
+	result += "| ----- | -------- | ----- |\n"
+	for _, class := range classes {
+		count := breakdown.Classes[class]
+		result += fmt.Sprintf("| %s | %.0f | %.2f%% |\n", class, count, count/breakdown.Total*100)
+	}
+	result += "\n"
+
+	if len(breakdown.Codes) > 0 {
+		codes := make([]string, 0, len(breakdown.Codes))
+		for code := range breakdown.Codes {
+			codes = append(codes, code)
+		}
+		sort.SliceStable(codes, func(i, j int) bool {
+			return breakdown.Codes[codes[i]] > breakdown.Codes[codes[j]]
+		})
+
+		result += "## Failed Responses by Code\n\n"
+		for _, code := range codes {
+			result += fmt.Sprintf("- **%s**: %.0f\n", valueOrDash(code), breakdown.Codes[code])
+		}
+		result += "\n"
+	}
+
+	if breakdown.Errors > 0 {
+		result += "## Recommended Actions\n\n"
+		result += "1. remote_server_4xx and remote_server_5xx responses come from the consumer; check its logs with query_logs for the failing requests\n"
+		result += "2. deadline_exceeded means the consumer took longer than the ack deadline; speed up processing, raise the ack deadline, or acknowledge before slow work\n"
+		result += "3. unreachable responses mean Pub/Sub couldn't connect; check the endpoint URL, DNS and TLS certificate, and that the service is running\n"
+		result += "4. Configure a retry policy with exponential backoff and a dead letter topic so failing messages don't cause redelivery storms\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
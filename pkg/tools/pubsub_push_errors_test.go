@@ -0,0 +1,82 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// pushRequests is a push_request_count series for a response class and code
+func pushRequests(class, code string, count float64) timeSeries {
+	return testSeries(map[string]string{"response_class": class, "response_code": code}, nil, count)
+}
+
+func TestPushErrorBreakdownDiagnosis(t *testing.T) {
+	tests := []struct {
+		name   string
+		series []timeSeries
+		want   string
+	}{
+		{name: "healthy", series: []timeSeries{pushRequests("ack", "success", 100)}, want: "Every push was acknowledged."},
+		{
+			name:   "slow",
+			series: []timeSeries{pushRequests("ack", "success", 100), pushRequests("deadline_exceeded", "deadline_exceeded", 40)},
+			want:   "Most failed pushes hit the ack deadline",
+		},
+		{
+			name:   "erroring",
+			series: []timeSeries{pushRequests("ack", "success", 100), pushRequests("remote_server_5xx", "500", 40), pushRequests("deadline_exceeded", "deadline_exceeded", 2)},
+			want:   "Most failed pushes were error responses",
+		},
+		{
+			name:   "both",
+			series: []timeSeries{pushRequests("remote_server_5xx", "500", 20), pushRequests("deadline_exceeded", "deadline_exceeded", 20)},
+			want:   "Failed pushes are a mix",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := newPushErrorBreakdown(tt.series).diagnosis(); !strings.HasPrefix(got, tt.want) {
+			t.Errorf("%s: diagnosis = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHandleGetPubSubSubscriptionErrorRate(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/projects/test-project/timeSeries" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		// The subscription is matched by ID, even when given as a full name
+		if filter := r.URL.Query().Get("filter"); !strings.Contains(filter, `resource.labels.subscription_id="orders-push"`) {
+			t.Errorf("filter doesn't match the subscription ID: %s", filter)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"timeSeries": []timeSeries{
+			pushRequests("ack", "success", 800),
+			pushRequests("remote_server_5xx", "503", 150),
+			pushRequests("remote_server_4xx", "429", 40),
+			pushRequests("deadline_exceeded", "deadline_exceeded", 10),
+		}})
+	}))
+
+	text := callTool(t, ctx, handleGetPubSubSubscriptionErrorRate, map[string]interface{}{
+		"project_id":   "test-project",
+		"subscription": "projects/test-project/subscriptions/orders-push",
+	})
+
+	for _, s := range []string{
+		"1000 push requests in the last 1.0 hours, 200 (20.00%) of them failed.",
+		"**The push endpoint's error rate is elevated** (above 5%)",
+		"Most failed pushes were error responses or couldn't reach the endpoint, so the consumer is erroring rather than slow.",
+		"| ack | 800 | 80.00% |\n| remote_server_5xx | 150 | 15.00% |\n| remote_server_4xx | 40 | 4.00% |\n| deadline_exceeded | 10 | 1.00% |\n",
+		"- **503**: 150\n- **429**: 40\n- **deadline_exceeded**: 10\n",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if strings.Contains(text, "**success**") {
+		t.Errorf("result breaks down acknowledged pushes by code:\n%s", text)
+	}
+}
@@ -0,0 +1,147 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+)
+
+// fakePubSubAPI serves a subscription with two messages and records the
+// method and body of every request
+type fakePubSubAPI struct {
+	calls  []string
+	bodies map[string]map[string]interface{}
+}
+
+// RoundTrip implements http.RoundTripper
+func (f *fakePubSubAPI) RoundTrip(req *http.Request) (*http.Response, error) {
+	_, method, _ := strings.Cut(req.URL.Path, ":")
+	f.calls = append(f.calls, method)
+
+	var body map[string]interface{}
+	json.NewDecoder(req.Body).Decode(&body)
+	if f.bodies == nil {
+		f.bodies = make(map[string]map[string]interface{})
+	}
+	f.bodies[method] = body
+
+	response := "{}"
+	if method == "pull" {
+		data := base64.StdEncoding.EncodeToString([]byte(`{"order_id": 42}`))
+		response = `{"receivedMessages": [
+			{"ackId": "ack-1", "message": {"data": "` + data + `", "messageId": "m-1", "attributes": {"source": "checkout"}}},
+			{"ackId": "ack-2", "message": {"data": "", "messageId": "m-2"}, "deliveryAttempt": 3}
+		]}`
+	}
+	return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(response))}, nil
+}
+
+func TestHandleGetPubSubMessageSample(t *testing.T) {
+	tests := []struct {
+		name      string
+		nack      bool
+		wantCalls []string
+	}{
+		{name: "leases without acknowledging", nack: false, wantCalls: []string{"pull"}},
+		{name: "nack releases the lease", nack: true, wantCalls: []string{"pull", "modifyAckDeadline"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakePubSubAPI{}
+			ctx := withGCPTransport(context.Background(), api)
+
+			result, err := handleGetPubSubMessageSample(ctx, newToolRequest(map[string]interface{}{
+				"project_id":   "test-project",
+				"subscription": "orders",
+				"max_messages": float64(50),
+				"nack":         tt.nack,
+			}), newTestAuthHandler(t, auth.ReadWriteScopes))
+			if err != nil {
+				t.Fatalf("handleGetPubSubMessageSample returned error: %v", err)
+			}
+			text, _ := resultText(result)
+			if result.IsError {
+				t.Fatalf("handleGetPubSubMessageSample returned tool error: %s", text)
+			}
+
+			if got := strings.Join(api.calls, ","); got != strings.Join(tt.wantCalls, ",") {
+				t.Errorf("calls = %s, want %s", got, strings.Join(tt.wantCalls, ","))
+			}
+			if _, ok := api.bodies["acknowledge"]; ok {
+				t.Error("messages were acknowledged")
+			}
+
+			// The pull returns immediately and is capped
+			pull := api.bodies["pull"]
+			if pull["returnImmediately"] != true || pull["maxMessages"] != float64(pubsubMaxSampleMessages) {
+				t.Errorf("pull body = %v", pull)
+			}
+			if tt.nack {
+				modify := api.bodies["modifyAckDeadline"]
+				if modify["ackDeadlineSeconds"] != float64(0) || len(modify["ackIds"].([]interface{})) != 2 {
+					t.Errorf("modifyAckDeadline body = %v, want both messages with a zero deadline", modify)
+				}
+			}
+
+			for _, s := range []string{"projects/test-project/subscriptions/orders", "source: checkout", `{"order_id": 42}`, "(empty)", "**Delivery Attempt**: 3"} {
+				if !strings.Contains(text, s) {
+					t.Errorf("result doesn't contain %q:\n%s", s, text)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleGetPubSubMessageSampleRequiresReadWrite(t *testing.T) {
+	api := &fakePubSubAPI{}
+	ctx := withGCPTransport(context.Background(), api)
+	authHandler := newTestAuthHandler(t, auth.ReadOnlyScopes)
+
+	result, err := handleGetPubSubMessageSample(ctx, newToolRequest(map[string]interface{}{
+		"project_id":   "test-project",
+		"subscription": "orders",
+	}), authHandler)
+	if err != nil {
+		t.Fatalf("handleGetPubSubMessageSample returned error: %v", err)
+	}
+
+	text, _ := resultText(result)
+	if !result.IsError || !strings.Contains(text, "-scopes=read-write") {
+		t.Errorf("read-only server didn't refuse: %s", text)
+	}
+	if len(api.calls) != 0 {
+		t.Errorf("read-only server sent %v", api.calls)
+	}
+	if authHandler.ReadWrite() {
+		t.Error("the sample upgraded the server to read-write")
+	}
+}
+
+func TestPreviewMessageData(t *testing.T) {
+	encode := func(b []byte) string { return base64.StdEncoding.EncodeToString(b) }
+
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{name: "text", data: encode([]byte("hello")), want: "hello"},
+		{name: "empty", data: "", want: "(empty)"},
+		{name: "binary", data: encode([]byte{0xff, 0xfe, 0x00}), want: "(3 bytes of binary data)"},
+		{name: "invalid base64", data: "not base64!", want: "(undecodable data: "},
+		{name: "truncated", data: encode([]byte(strings.Repeat("a", pubsubPreviewBytes+10))), want: strings.Repeat("a", pubsubPreviewBytes) + "\n... (truncated, 522 bytes total)"},
+	}
+
+	for _, tt := range tests {
+		if got := previewMessageData(tt.data); !strings.HasPrefix(got, tt.want) {
+			t.Errorf("%s: previewMessageData = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
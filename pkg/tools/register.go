@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/ivanvanderbyl/operable/pkg/auth"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -11,11 +12,26 @@ import (
 
 // RegisterTools registers all tools with the MCP server
 func RegisterTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Load output template overrides before any tool can render with them
+	if err := loadOutputTemplates(); err != nil {
+		return fmt.Errorf("error loading output templates: %w", err)
+	}
+
+	// Register project tools
+	if err := registerProjectTools(s, authHandler); err != nil {
+		return fmt.Errorf("error registering project tools: %w", err)
+	}
+
 	// Register GCP issues tool
 	if err := registerGCPIssuesTools(s, authHandler); err != nil {
 		return fmt.Errorf("error registering GCP issues tools: %w", err)
 	}
 
+	// Register project health summary tool
+	if err := registerHealthSummaryTools(s, authHandler); err != nil {
+		return fmt.Errorf("error registering health summary tools: %w", err)
+	}
+
 	// Register logging tools
 	if err := registerLoggingTools(s, authHandler); err != nil {
 		return fmt.Errorf("error registering logging tools: %w", err)
@@ -31,16 +47,107 @@ func RegisterTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
 		return fmt.Errorf("error registering monitoring tools: %w", err)
 	}
 
+	// Register trace tools
+	if err := registerTraceTools(s, authHandler); err != nil {
+		return fmt.Errorf("error registering trace tools: %w", err)
+	}
+
+	// Register Compute Engine tools
+	if err := registerComputeTools(s, authHandler); err != nil {
+		return fmt.Errorf("error registering Compute Engine tools: %w", err)
+	}
+
+	// Register network tools
+	if err := registerNetworkTools(s, authHandler); err != nil {
+		return fmt.Errorf("error registering network tools: %w", err)
+	}
+
+	// Register Cloud SQL tools
+	if err := registerCloudSQLTools(s, authHandler); err != nil {
+		return fmt.Errorf("error registering Cloud SQL tools: %w", err)
+	}
+
+	// Register Firestore tools
+	if err := registerFirestoreTools(s, authHandler); err != nil {
+		return fmt.Errorf("error registering Firestore tools: %w", err)
+	}
+
+	// Register Pub/Sub tools
+	if err := registerPubSubTools(s, authHandler); err != nil {
+		return fmt.Errorf("error registering Pub/Sub tools: %w", err)
+	}
+
+	// Register Dataflow tools
+	if err := registerDataflowTools(s, authHandler); err != nil {
+		return fmt.Errorf("error registering Dataflow tools: %w", err)
+	}
+
+	// Register billing tools
+	if err := registerBillingTools(s, authHandler); err != nil {
+		return fmt.Errorf("error registering billing tools: %w", err)
+	}
+
+	// Register governance tools
+	if err := registerGovernanceTools(s, authHandler); err != nil {
+		return fmt.Errorf("error registering governance tools: %w", err)
+	}
+
 	// Register documentation tools
 	if err := registerDocumentationTools(s); err != nil {
 		return fmt.Errorf("error registering documentation tools: %w", err)
 	}
 
+	// Register remediation tools, if enabled
+	if err := registerRemediationTools(s, authHandler); err != nil {
+		return fmt.Errorf("error registering remediation tools: %w", err)
+	}
+
+	// Register auth tools
+	if err := registerAuthTools(s, authHandler); err != nil {
+		return fmt.Errorf("error registering auth tools: %w", err)
+	}
+
+	// Register introspection tools
+	if err := registerIntrospectionTools(s); err != nil {
+		return fmt.Errorf("error registering introspection tools: %w", err)
+	}
+
 	return nil
 }
 
+// toolRegistry records the definition of every registered tool, so the
+// server can describe its own tools
+var toolRegistry = struct {
+	sync.RWMutex
+	tools map[string]mcp.Tool
+}{tools: make(map[string]mcp.Tool)}
+
+// registeredTool returns the definition of a registered tool by name
+func registeredTool(name string) (mcp.Tool, bool) {
+	toolRegistry.RLock()
+	defer toolRegistry.RUnlock()
+	tool, ok := toolRegistry.tools[name]
+	return tool, ok
+}
+
+// registeredToolNames returns the names of all registered tools
+func registeredToolNames() []string {
+	toolRegistry.RLock()
+	defer toolRegistry.RUnlock()
+	names := make([]string, 0, len(toolRegistry.tools))
+	for name := range toolRegistry.tools {
+		names = append(names, name)
+	}
+	return names
+}
+
 // AddToolSafe is a wrapper around AddTool that ignores the linting issue
 // This is a workaround for the linting issue with s.AddTool
+// It also records the tool in the registry used by describe_tool
 func AddToolSafe(s *server.MCPServer, tool mcp.Tool, handler func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	toolRegistry.Lock()
+	toolRegistry.tools[tool.Name] = tool
+	toolRegistry.Unlock()
+
 	s.AddTool(tool, handler)
 }
@@ -0,0 +1,226 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// remediationEnv must be set to "true" for remediation tools to be
+	// registered, so a read-only deployment can't invoke them by accident
+	remediationEnv = "OPERABLE_ENABLE_REMEDIATION"
+
+	// restartedAtAnnotation is the pod template annotation kubectl rollout
+	// restart sets to trigger a rollout
+	restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+	// revisionAnnotation is the annotation the deployment controller records
+	// the current revision in
+	revisionAnnotation = "deployment.kubernetes.io/revision"
+
+	// restartRevisionWait bounds how long restart_deployment waits for the
+	// controller to record the new revision
+	restartRevisionWait = 10 * time.Second
+)
+
+// remediationEnabled reports whether remediation tools are enabled
+func remediationEnabled() bool {
+	return os.Getenv(remediationEnv) == "true"
+}
+
+// requireReadWrite returns a tool error if the server wasn't started with
+// read-write scopes, or nil if it was. Tools never upgrade the scopes
+// themselves, so starting with -scopes=read-only is honoured.
+func requireReadWrite(authHandler *auth.OAuthHandler, tool string) *mcp.CallToolResult {
+	if authHandler.ReadWrite() {
+		return nil
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("%s changes resources and needs read-write permissions, but the server is running with read-only scopes. Restart it with -scopes=read-write to use it.", tool))
+}
+
+// registerRemediationTools registers tools that change workloads to resolve
+// incidents. They are only registered when OPERABLE_ENABLE_REMEDIATION is
+// true. force_delete_pod and drain_node are registered with the pod and node
+// tools behind the same check.
+func registerRemediationTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	if !remediationEnabled() {
+		return nil
+	}
+
+	// Register restart deployment tool
+	restartDeployment := mcp.NewTool("restart_deployment",
+		mcp.WithDescription("Performs a rolling restart of a Kubernetes Deployment in a GKE cluster, like kubectl rollout restart, by setting a restart annotation on its pod template. Pods are replaced according to the deployment's rollout strategy. Requires the server to run with read-write scopes."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The Kubernetes namespace"),
+		),
+		mcp.WithString("deployment_name",
+			mcp.Required(),
+			mcp.Description("The name of the deployment"),
+		),
+	)
+
+	restartDeploymentHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleRestartDeployment(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, restartDeployment, restartDeploymentHandler)
+
+	return nil
+}
+
+// kubeDeploymentRevision is the subset of a Deployment used to follow a
+// rollout
+type kubeDeploymentRevision struct {
+	Metadata struct {
+		Generation  int64             `json:"generation"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	Spec struct {
+		Paused bool `json:"paused"`
+	} `json:"spec"`
+	Status struct {
+		ObservedGeneration int64 `json:"observedGeneration"`
+	} `json:"status"`
+}
+
+// waitForRevision polls a deployment until the controller has observed
+// generation, returning the revision it recorded. It returns an empty
+// revision if that doesn't happen before the wait ends.
+func waitForRevision(ctx context.Context, kube *kubeClient, path string, generation int64, wait time.Duration) (string, error) {
+	deadline := time.Now().Add(wait)
+	for {
+		var deployment kubeDeploymentRevision
+		if err := kube.get(ctx, path, nil, &deployment); err != nil {
+			return "", err
+		}
+		if deployment.Status.ObservedGeneration >= generation {
+			return deployment.Metadata.Annotations[revisionAnnotation], nil
+		}
+		if time.Now().After(deadline) {
+			return "", nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// handleRestartDeployment handles the restart_deployment tool request
+func handleRestartDeployment(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	namespace, ok := request.Params.Arguments["namespace"].(string)
+	if !ok || namespace == "" {
+		return mcp.NewToolResultError("namespace must be a non-empty string"), nil
+	}
+
+	deploymentName, ok := request.Params.Arguments["deployment_name"].(string)
+	if !ok || deploymentName == "" {
+		return mcp.NewToolResultError("deployment_name must be a non-empty string"), nil
+	}
+
+	// Restarting is a mutating operation
+	if result := requireReadWrite(authHandler, "restart_deployment"); result != nil {
+		return result, nil
+	}
+
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error connecting to cluster: %v", err)), nil
+	}
+
+	deploymentPath := fmt.Sprintf("/apis/apps/v1/namespaces/%s/deployments/%s", namespace, deploymentName)
+
+	var before kubeDeploymentRevision
+	if err := kube.get(ctx, deploymentPath, nil, &before); err != nil {
+		if isKubeNotFound(err) {
+			return mcp.NewToolResultError(fmt.Sprintf("Deployment %s not found in namespace %s.", deploymentName, namespace)), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting deployment: %v", err)), nil
+	}
+
+	// Changing the pod template makes the controller roll out a new revision
+	restartedAt := time.Now().UTC().Format(time.RFC3339)
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{
+						restartedAtAnnotation: restartedAt,
+					},
+				},
+			},
+		},
+	}
+	var after kubeDeploymentRevision
+	if err := kube.do(ctx, "PATCH", deploymentPath, nil, "application/merge-patch+json", patch, &after); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error restarting deployment: %v", err)), nil
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# Restarted Deployment %s/%s\n\n", namespace, deploymentName)
+	result += fmt.Sprintf("- Set %s to %s on the pod template\n", restartedAtAnnotation, restartedAt)
+	result += fmt.Sprintf("- Previous revision: %s\n", valueOrDash(before.Metadata.Annotations[revisionAnnotation]))
+
+	if after.Spec.Paused {
+		result += "\nThe deployment is paused, so no pods will be replaced until it is resumed.\n"
+		return mcp.NewToolResultText(result), nil
+	}
+
+	revision, err := waitForRevision(ctx, kube, deploymentPath, after.Metadata.Generation, restartRevisionWait)
+	cancelled := isCancelled(err)
+	switch {
+	case cancelled:
+		result += "- New revision: not yet recorded when the call was cancelled\n"
+	case err != nil:
+		result += fmt.Sprintf("- New revision: unknown (%v)\n", err)
+	case revision == "":
+		result += fmt.Sprintf("- New revision: not yet recorded after %s\n", restartRevisionWait)
+	default:
+		result += fmt.Sprintf("- New revision: %s\n", revision)
+	}
+
+	result += "\nThe rollout replaces pods according to the deployment's strategy. Use get_cluster_events_stream to follow its progress.\n"
+
+	if cancelled {
+		result += cancelledNote
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,96 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// remediationToolNames are the tools that change workloads
+var remediationToolNames = []string{"restart_deployment", "force_delete_pod", "drain_node"}
+
+func TestRemediationToolsGated(t *testing.T) {
+	tests := []struct {
+		env        string
+		registered bool
+	}{
+		{env: "", registered: false},
+		{env: "false", registered: false},
+		{env: "true", registered: true},
+	}
+
+	for _, tt := range tests {
+		t.Run("env="+tt.env, func(t *testing.T) {
+			t.Setenv(remediationEnv, tt.env)
+
+			useEmptyToolRegistry(t)
+
+			s := server.NewMCPServer("test", "0.0.0")
+			authHandler := newTestAuthHandler(t, auth.ReadOnlyScopes)
+			for _, register := range []func(*server.MCPServer, *auth.OAuthHandler) error{
+				registerPodTools, registerNodeTools, registerRemediationTools,
+			} {
+				if err := register(s, authHandler); err != nil {
+					t.Fatalf("registering tools: %v", err)
+				}
+			}
+
+			for _, name := range remediationToolNames {
+				if _, ok := registeredTool(name); ok != tt.registered {
+					t.Errorf("%s registered = %t, want %t", name, ok, tt.registered)
+				}
+			}
+
+			// Read-only tools in the same files are always registered
+			for _, name := range []string{"get_terminating_pods", "get_recent_preemptions"} {
+				if _, ok := registeredTool(name); !ok {
+					t.Errorf("%s isn't registered", name)
+				}
+			}
+		})
+	}
+}
+
+func TestRemediationToolsRequireReadWrite(t *testing.T) {
+	handlers := map[string]func(context.Context, mcp.CallToolRequest, *auth.OAuthHandler) (*mcp.CallToolResult, error){
+		"restart_deployment": handleRestartDeployment,
+		"force_delete_pod":   handleForceDeletePod,
+		"drain_node":         handleDrainNode,
+	}
+
+	for _, name := range remediationToolNames {
+		t.Run(name, func(t *testing.T) {
+			clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}))
+
+			authHandler := newTestAuthHandler(t, auth.ReadOnlyScopes)
+			result, err := handlers[name](context.Background(), newToolRequest(map[string]interface{}{
+				"project_id":      "test-project",
+				"location":        "us-central1",
+				"cluster_name":    clusterName,
+				"namespace":       "default",
+				"deployment_name": "web",
+				"pod_name":        "web-0",
+				"node_name":       "node-1",
+				"confirm":         true,
+			}), authHandler)
+			if err != nil {
+				t.Fatalf("handler returned error: %v", err)
+			}
+
+			text, _ := resultText(result)
+			if !result.IsError || !strings.Contains(text, "-scopes=read-write") {
+				t.Errorf("read-only handler didn't refuse: %s", text)
+			}
+			if authHandler.ReadWrite() {
+				t.Error("handler upgraded to read-write scopes")
+			}
+		})
+	}
+}
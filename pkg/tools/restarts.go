@@ -0,0 +1,327 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerRestartTools registers tools that rank container restarts
+func registerRestartTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get recent restarts across cluster tool
+	getRecentRestarts := mcp.NewTool("get_recent_restarts_across_cluster",
+		mcp.WithDescription("Ranks the pods in a cluster (or namespace) by how many times their containers restarted within a recent window, with their last termination reasons, for a broad \"things are crashing\" signal"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("The Kubernetes namespace (if not provided, all namespaces are searched)"),
+		),
+		mcp.WithNumber("top_n",
+			mcp.Description("Maximum number of pods to return (default: 10)"),
+		),
+		mcp.WithString("window",
+			mcp.Description("How far back to count restarts, as a duration such as 30m or 6h (default: 1h, maximum: 24h)"),
+		),
+	)
+
+	getRecentRestartsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetRecentRestartsAcrossCluster(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getRecentRestarts, getRecentRestartsHandler)
+
+	return nil
+}
+
+// maxRestartWindow is the longest window restarts can be counted over, and
+// how long restart count snapshots are kept
+const maxRestartWindow = 24 * time.Hour
+
+// maxRestartSnapshots caps the snapshots kept per cluster
+const maxRestartSnapshots = 50
+
+// restartSnapshot records container restart counts at a point in time, keyed
+// by restartCountKey
+type restartSnapshot struct {
+	// Namespace is the namespace the snapshot covers, or empty for all
+	Namespace string
+	TakenAt   time.Time
+	Counts    map[string]int32
+}
+
+// restartCountKey identifies a container across snapshots. The pod UID is
+// used so a recreated pod with the same name isn't compared to its predecessor.
+func restartCountKey(podUID, container string) string {
+	return podUID + "/" + container
+}
+
+// newRestartSnapshot records the restart counts of every container in pods
+func newRestartSnapshot(pods []kubePod, namespace string, now time.Time) restartSnapshot {
+	snapshot := restartSnapshot{Namespace: namespace, TakenAt: now, Counts: make(map[string]int32)}
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			snapshot.Counts[restartCountKey(pod.Metadata.UID, status.Name)] = status.RestartCount
+		}
+	}
+	return snapshot
+}
+
+// restartHistory holds recent restart count snapshots per cluster, so later
+// calls can report how many restarts happened since an earlier one
+type restartHistory struct {
+	mu        sync.Mutex
+	snapshots map[string][]restartSnapshot
+}
+
+// restartSnapshots is the process-wide restart count history
+var restartSnapshots = &restartHistory{snapshots: make(map[string][]restartSnapshot)}
+
+// baseline returns the oldest snapshot of the cluster taken at or after since
+// that covers namespace, if any
+func (h *restartHistory) baseline(clusterKey, namespace string, since time.Time) (restartSnapshot, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, snapshot := range h.snapshots[clusterKey] {
+		if snapshot.TakenAt.Before(since) {
+			continue
+		}
+		if snapshot.Namespace == "" || snapshot.Namespace == namespace {
+			return snapshot, true
+		}
+	}
+	return restartSnapshot{}, false
+}
+
+// record adds a snapshot of the cluster, discarding those too old to be used
+func (h *restartHistory) record(clusterKey string, snapshot restartSnapshot) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	cutoff := snapshot.TakenAt.Add(-maxRestartWindow)
+	var kept []restartSnapshot
+	for _, existing := range h.snapshots[clusterKey] {
+		if !existing.TakenAt.Before(cutoff) {
+			kept = append(kept, existing)
+		}
+	}
+	kept = append(kept, snapshot)
+	if len(kept) > maxRestartSnapshots {
+		kept = kept[len(kept)-maxRestartSnapshots:]
+	}
+	h.snapshots[clusterKey] = kept
+}
+
+// podRestarts summarises the recent restarts of a pod's containers
+type podRestarts struct {
+	Namespace string
+	Name      string
+	Node      string
+	// Restarts is the number of restarts within the window
+	Restarts int32
+	// TotalRestarts is the number of restarts since the pod started
+	TotalRestarts int32
+	Containers    []string
+	LastReason    string
+	LastExitCode  int32
+	LastRestart   time.Time
+}
+
+// rankRestartingPods returns the pods that restarted within the window, most
+// restarts first. With a baseline snapshot, restarts are counted as the
+// increase since it was taken, and containers absent from it are new so all
+// their restarts count. Without one, a container's total restarts count if
+// its last termination finished at or after since.
+func rankRestartingPods(pods []kubePod, baseline *restartSnapshot, since time.Time) []podRestarts {
+	var ranked []podRestarts
+	for _, pod := range pods {
+		entry := podRestarts{
+			Namespace: pod.Metadata.Namespace,
+			Name:      pod.Metadata.Name,
+			Node:      pod.Spec.NodeName,
+		}
+
+		for _, status := range pod.Status.ContainerStatuses {
+			entry.TotalRestarts += status.RestartCount
+
+			var finishedAt time.Time
+			terminated := status.LastState.Terminated
+			if terminated != nil {
+				finishedAt, _ = time.Parse(time.RFC3339, terminated.FinishedAt)
+			}
+
+			var restarts int32
+			if baseline != nil {
+				restarts = status.RestartCount
+				if previous, ok := baseline.Counts[restartCountKey(pod.Metadata.UID, status.Name)]; ok {
+					restarts -= previous
+				}
+			} else if !finishedAt.IsZero() && !finishedAt.Before(since) {
+				restarts = status.RestartCount
+			}
+			if restarts <= 0 {
+				continue
+			}
+
+			entry.Restarts += restarts
+			entry.Containers = append(entry.Containers, status.Name)
+			if terminated != nil && (entry.LastRestart.IsZero() || finishedAt.After(entry.LastRestart)) {
+				entry.LastReason = terminated.Reason
+				entry.LastExitCode = terminated.ExitCode
+				entry.LastRestart = finishedAt
+			}
+		}
+
+		if entry.Restarts > 0 {
+			ranked = append(ranked, entry)
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Restarts != ranked[j].Restarts {
+			return ranked[i].Restarts > ranked[j].Restarts
+		}
+		if ranked[i].TotalRestarts != ranked[j].TotalRestarts {
+			return ranked[i].TotalRestarts > ranked[j].TotalRestarts
+		}
+		if ranked[i].Namespace != ranked[j].Namespace {
+			return ranked[i].Namespace < ranked[j].Namespace
+		}
+		return ranked[i].Name < ranked[j].Name
+	})
+
+	return ranked
+}
+
+// handleGetRecentRestartsAcrossCluster handles the get_recent_restarts_across_cluster tool request
+func handleGetRecentRestartsAcrossCluster(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+
+	// Get optional parameters with defaults
+	topN := 10
+	if val, ok := request.Params.Arguments["top_n"].(float64); ok && val > 0 {
+		topN = int(val)
+	}
+
+	window := time.Hour
+	if val, ok := request.Params.Arguments["window"].(string); ok && val != "" {
+		parsed, err := time.ParseDuration(val)
+		if err != nil || parsed <= 0 || parsed > maxRestartWindow {
+			return mcp.NewToolResultError("window must be a positive duration of at most 24h, such as 30m or 6h"), nil
+		}
+		window = parsed
+	}
+
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error connecting to cluster: %v", err)), nil
+	}
+
+	var podList struct {
+		Items []kubePod `json:"items"`
+	}
+	if err := kube.get(ctx, podsPath(namespace), nil, &podList); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing pods: %v", err)), nil
+	}
+
+	now := time.Now()
+	since := now.Add(-window)
+	clusterKey := clusterCacheKey(projectID, location, clusterName)
+
+	// Compare against the oldest snapshot within the window, then record this
+	// call's counts for later ones
+	var baselinePtr *restartSnapshot
+	baseline, hasBaseline := restartSnapshots.baseline(clusterKey, namespace, since)
+	if hasBaseline {
+		baselinePtr = &baseline
+	}
+	restartSnapshots.record(clusterKey, newRestartSnapshot(podList.Items, namespace, now))
+
+	ranked := rankRestartingPods(podList.Items, baselinePtr, since)
+
+	// Format the results
+	scope := "all namespaces"
+	if namespace != "" {
+		scope = "namespace " + namespace
+	}
+
+	if len(ranked) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No containers restarted in the last %s in %s of cluster %s.", window, scope, clusterName)), nil
+	}
+
+	result := fmt.Sprintf("# Most Restarting Pods in Cluster %s\n\n", clusterName)
+	if hasBaseline {
+		result += fmt.Sprintf("Restarts in %s since %s (the earliest sample within the last %s), ", scope, baseline.TakenAt.Format(time.RFC3339), window)
+	} else {
+		result += fmt.Sprintf("No earlier sample of this cluster covers the last %s, so counts are total restarts of containers whose last restart was within the window, in %s. ", window, scope)
+		result += "Call again later to get exact restart deltas. "
+	}
+	result += fmt.Sprintf("%d pods restarted", len(ranked))
+	if len(ranked) > topN {
+		result += fmt.Sprintf(", showing the top %d", topN)
+		ranked = ranked[:topN]
+	}
+	result += ".\n\n"
+
+	result += "| Pod | Restarts | Total | Containers | Last Reason | Last Restart | Node |\n"
+	result += "| --- | -------- | ----- | ---------- | ----------- | ------------ | ---- |\n"
+	for _, pod := range ranked {
+		reason := pod.LastReason
+		if reason == "" {
+			reason = "-"
+		} else {
+			reason = fmt.Sprintf("%s (exit %d)", reason, pod.LastExitCode)
+		}
+		lastRestart := "-"
+		if !pod.LastRestart.IsZero() {
+			lastRestart = pod.LastRestart.Format(time.RFC3339)
+		}
+		node := pod.Node
+		if node == "" {
+			node = "-"
+		}
+		result += fmt.Sprintf("| %s/%s | %d | %d | %s | %s | %s | %s |\n",
+			pod.Namespace, pod.Name, pod.Restarts, pod.TotalRestarts, strings.Join(pod.Containers, ", "), reason, lastRestart, node)
+	}
+
+	result += "\n## Recommended Actions\n\n"
+	result += "1. OOMKilled means the container exceeded its memory limit: raise the limit, or use query_metrics to look for a memory leak\n"
+	result += "2. Error or a non-zero exit code means the process crashed: check the previous container's logs with get_pod_logs\n"
+	result += "3. If many pods on one node are restarting, inspect the node with get_gke_node_problem_detector_events\n"
+	result += "4. Pods failing on startup may be missing configuration: check their references with get_config_map_and_secret_refs\n"
+
+	return mcp.NewToolResultText(result), nil
+}
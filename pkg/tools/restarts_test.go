@@ -0,0 +1,140 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// restartingPod is a pod whose only container has restarted restarts times,
+// last finishing at finishedAt
+func restartingPod(name, uid string, restarts int, finishedAt time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{"name": name, "namespace": "web", "uid": uid},
+		"spec":     map[string]interface{}{"nodeName": "node-1"},
+		"status": map[string]interface{}{"containerStatuses": []map[string]interface{}{{
+			"name":         "app",
+			"restartCount": restarts,
+			"lastState": map[string]interface{}{"terminated": map[string]interface{}{
+				"reason": "OOMKilled", "exitCode": 137, "finishedAt": finishedAt.Format(time.RFC3339),
+			}},
+		}}},
+	}
+}
+
+func decodePods(t *testing.T, pods ...map[string]interface{}) []kubePod {
+	t.Helper()
+	var decoded []kubePod
+	data, _ := json.Marshal(pods)
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("decoding pods: %v", err)
+	}
+	return decoded
+}
+
+func TestRankRestartingPods(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	since := now.Add(-time.Hour)
+	pods := decodePods(t,
+		restartingPod("few", "uid-few", 2, now.Add(-10*time.Minute)),
+		restartingPod("many", "uid-many", 9, now.Add(-5*time.Minute)),
+		restartingPod("old", "uid-old", 30, now.Add(-3*time.Hour)),
+		restartingPod("some", "uid-some", 5, now.Add(-20*time.Minute)),
+	)
+
+	// Without a baseline, containers that last restarted before the window
+	// don't count
+	var names []string
+	for _, pod := range rankRestartingPods(pods, nil, since) {
+		names = append(names, fmt.Sprintf("%s=%d", pod.Name, pod.Restarts))
+	}
+	if got, want := strings.Join(names, " "), "many=9 some=5 few=2"; got != want {
+		t.Errorf("rankRestartingPods without a baseline = %s, want %s", got, want)
+	}
+
+	// With one, only the increase counts and unseen containers count in full
+	baseline := restartSnapshot{TakenAt: since, Counts: map[string]int32{
+		restartCountKey("uid-many", "app"): 8,
+		restartCountKey("uid-some", "app"): 1,
+		restartCountKey("uid-old", "app"):  30,
+	}}
+	names = nil
+	for _, pod := range rankRestartingPods(pods, &baseline, since) {
+		names = append(names, fmt.Sprintf("%s=%d", pod.Name, pod.Restarts))
+	}
+	if got, want := strings.Join(names, " "), "some=4 few=2 many=1"; got != want {
+		t.Errorf("rankRestartingPods with a baseline = %s, want %s", got, want)
+	}
+}
+
+func TestRestartHistoryBaseline(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	history := &restartHistory{snapshots: make(map[string][]restartSnapshot)}
+	history.record("c", restartSnapshot{Namespace: "web", TakenAt: now.Add(-25 * time.Hour)})
+	history.record("c", restartSnapshot{Namespace: "api", TakenAt: now.Add(-50 * time.Minute)})
+	history.record("c", restartSnapshot{TakenAt: now.Add(-30 * time.Minute)})
+	history.record("c", restartSnapshot{Namespace: "web", TakenAt: now})
+
+	// Snapshots older than the maximum window are discarded on record
+	if n := len(history.snapshots["c"]); n != 3 {
+		t.Errorf("%d snapshots kept, want 3", n)
+	}
+
+	// A namespace's baseline can come from a cluster-wide snapshot
+	baseline, ok := history.baseline("c", "web", now.Add(-time.Hour))
+	if !ok || !baseline.TakenAt.Equal(now.Add(-30*time.Minute)) {
+		t.Errorf("baseline = %+v, %t, want the cluster-wide snapshot", baseline, ok)
+	}
+	if _, ok := history.baseline("other", "web", now.Add(-time.Hour)); ok {
+		t.Error("found a baseline for a cluster without snapshots")
+	}
+}
+
+func TestHandleGetRecentRestartsAcrossCluster(t *testing.T) {
+	saved := restartSnapshots
+	restartSnapshots = &restartHistory{snapshots: make(map[string][]restartSnapshot)}
+	t.Cleanup(func() { restartSnapshots = saved })
+
+	restarts := 3
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces/web/pods" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		now := time.Now()
+		writeJSON(w, http.StatusOK, map[string]interface{}{"items": []map[string]interface{}{
+			restartingPod("quiet", "uid-quiet", 1, now.Add(-2*time.Hour)),
+			restartingPod("worker", "uid-worker", 2, now.Add(-time.Minute)),
+			restartingPod("api", "uid-api", restarts, now.Add(-time.Minute)),
+		}})
+	}))
+
+	args := map[string]interface{}{"namespace": "web"}
+	text := callClusterTool(t, context.Background(), handleGetRecentRestartsAcrossCluster, clusterName, args)
+
+	for _, s := range []string{
+		"No earlier sample of this cluster covers the last 1h0m0s",
+		"2 pods restarted.",
+		"| web/api | 3 | 3 | app | OOMKilled (exit 137) |",
+		"| web/worker | 2 | 2 | app | OOMKilled (exit 137) |",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if strings.Index(text, "web/api") > strings.Index(text, "web/worker") {
+		t.Errorf("pods aren't ranked by restarts:\n%s", text)
+	}
+
+	// A second call counts restarts since the first
+	restarts = 4
+	text = callClusterTool(t, context.Background(), handleGetRecentRestartsAcrossCluster, clusterName, args)
+	for _, s := range []string{"(the earliest sample within the last 1h0m0s)", "1 pods restarted.", "| web/api | 1 | 4 | app |"} {
+		if !strings.Contains(text, s) {
+			t.Errorf("second result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
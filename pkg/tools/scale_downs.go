@@ -0,0 +1,270 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerScaleDownTools registers tools that report cluster autoscaler
+// scale-downs
+func registerScaleDownTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get recent autoscaler scale downs tool
+	getRecentScaleDowns := mcp.NewTool("get_recent_autoscaler_scale_downs",
+		mcp.WithDescription("Lists the nodes the cluster autoscaler removed within a recent window, from its visibility logs, with why each node was chosen (empty or underutilised, with its requested CPU and memory), the pods evicted from it and whether the removal succeeded. Use it to correlate a scale-down with pods going Pending or other capacity problems shortly after."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithString("window",
+			mcp.Description("How far back to look, as a duration such as 30m or 6h (default: 6h)"),
+		),
+	)
+
+	getRecentScaleDownsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetRecentAutoscalerScaleDowns(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getRecentScaleDowns, getRecentScaleDownsHandler)
+
+	return nil
+}
+
+// nodeScaleDown is a node the cluster autoscaler decided to remove
+type nodeScaleDown struct {
+	Time     time.Time
+	EventID  string
+	Node     string
+	NodePool string
+	// CPURatio and MemRatio are the node's requested CPU and memory as a
+	// percentage of its allocatable capacity, as reported by the autoscaler
+	CPURatio    string
+	MemRatio    string
+	EvictedPods []string
+	// EvictedTotal may exceed len(EvictedPods), as the logs only list a sample
+	EvictedTotal int
+}
+
+// reason explains why the autoscaler removed the node. It only removes
+// nodes that are empty or whose pods fit elsewhere and whose requests are
+// below the utilisation threshold.
+func (d nodeScaleDown) reason() string {
+	if d.EvictedTotal == 0 {
+		return "Empty node"
+	}
+	reason := "Underutilised"
+	var ratios []string
+	if d.CPURatio != "" {
+		ratios = append(ratios, fmt.Sprintf("CPU %s%%", d.CPURatio))
+	}
+	if d.MemRatio != "" {
+		ratios = append(ratios, fmt.Sprintf("memory %s%%", d.MemRatio))
+	}
+	if len(ratios) > 0 {
+		reason += fmt.Sprintf(" (%s requested)", strings.Join(ratios, ", "))
+	}
+	return reason
+}
+
+// parseScaleDownDecisions converts cluster autoscaler visibility decisions
+// into the nodes they removed, oldest first
+func parseScaleDownDecisions(entries []logEntry) []nodeScaleDown {
+	var scaleDowns []nodeScaleDown
+	for _, entry := range entries {
+		decision, ok := entry.JsonPayload["decision"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		scaleDown, ok := decision["scaleDown"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, payloadString(decision, "decideTime"))
+		if err != nil {
+			if t, err = time.Parse(time.RFC3339, entry.Timestamp); err != nil {
+				continue
+			}
+		}
+
+		for _, node := range payloadObjects(scaleDown, "nodesToBeRemoved") {
+			d := nodeScaleDown{
+				Time:     t,
+				EventID:  payloadString(decision, "eventId"),
+				Node:     payloadString(node, "node", "name"),
+				NodePool: payloadString(node, "node", "mig", "nodepool"),
+				CPURatio: payloadString(node, "node", "cpuRatio"),
+				MemRatio: payloadString(node, "node", "memRatio"),
+			}
+			for _, pod := range payloadObjects(node, "evictedPods") {
+				d.EvictedPods = append(d.EvictedPods, fmt.Sprintf("%s/%s", payloadString(pod, "namespace"), payloadString(pod, "name")))
+			}
+			d.EvictedTotal = len(d.EvictedPods)
+			if total, err := strconv.Atoi(payloadString(node, "evictedPodsTotalCount")); err == nil && total > d.EvictedTotal {
+				d.EvictedTotal = total
+			}
+			scaleDowns = append(scaleDowns, d)
+		}
+	}
+
+	sort.SliceStable(scaleDowns, func(i, j int) bool {
+		return scaleDowns[i].Time.Before(scaleDowns[j].Time)
+	})
+
+	return scaleDowns
+}
+
+// parseScaleDownResults maps decision event IDs to their outcome from
+// visibility result entries: an empty string when the decision succeeded, or
+// the autoscaler's error message ID when it failed
+func parseScaleDownResults(entries []logEntry) map[string]string {
+	results := make(map[string]string)
+	for _, entry := range entries {
+		for _, result := range payloadObjects(entry.JsonPayload, "resultInfo", "results") {
+			eventID := payloadString(result, "eventId")
+			if eventID == "" {
+				continue
+			}
+			results[eventID] = payloadString(result, "errorMsg", "messageId")
+		}
+	}
+	return results
+}
+
+// scaleDownOutcome describes whether a scale-down decision was carried out
+func scaleDownOutcome(eventID string, results map[string]string) string {
+	errorID, ok := results[eventID]
+	switch {
+	case !ok:
+		return "No result logged"
+	case errorID == "":
+		return "Removed"
+	default:
+		return "**Failed**: " + errorID
+	}
+}
+
+// handleGetRecentAutoscalerScaleDowns handles the get_recent_autoscaler_scale_downs tool request
+func handleGetRecentAutoscalerScaleDowns(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	window := 6 * time.Hour
+	if val, ok := request.Params.Arguments["window"].(string); ok && val != "" {
+		parsed, err := time.ParseDuration(val)
+		if err != nil || parsed <= 0 {
+			return mcp.NewToolResultError("window must be a positive duration, such as 30m or 6h"), nil
+		}
+		window = parsed
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	// Decisions and their results are logged as separate entries
+	startTime := time.Now().Add(-window)
+	entries, nextPageToken, err := fetchLogEntries(ctx, client, projectID, logQuery{
+		Filter: fmt.Sprintf(`log_id("container.googleapis.com/cluster-autoscaler-visibility")
+		AND resource.labels.project_id="%s"
+		AND resource.labels.location="%s"
+		AND resource.labels.cluster_name="%s"
+		AND (jsonPayload.decision.scaleDown:* OR jsonPayload.resultInfo:*)
+		AND timestamp >= "%s"`, projectID, location, clusterName, startTime.Format(time.RFC3339)),
+		PageSize: 1000,
+	})
+	cancelled := false
+	if err != nil {
+		if !partialOnCancel(err, len(entries)) {
+			return mcp.NewToolResultError(fmt.Sprintf("Error querying cluster autoscaler logs: %v", err)), nil
+		}
+		cancelled = true
+	}
+
+	scaleDowns := parseScaleDownDecisions(entries)
+	results := parseScaleDownResults(entries)
+
+	// Format the results
+	if len(scaleDowns) == 0 {
+		result := fmt.Sprintf("The cluster autoscaler removed no nodes from cluster %s in the last %s.", clusterName, window)
+		if cancelled {
+			result += cancelledNote
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+
+	result := fmt.Sprintf("# Cluster Autoscaler Scale-Downs in %s\n\n", clusterName)
+	result += fmt.Sprintf("The autoscaler decided to remove %d nodes in the last %s.\n\n", len(scaleDowns), window)
+	if nextPageToken != "" {
+		result += "The log entry limit was reached, so older scale-downs may be missing; shorten the window to see them.\n\n"
+	}
+
+	result += "| Time | Node | Node Pool | Reason | Evicted Pods | Outcome |\n"
+	result += "| ---- | ---- | --------- | ------ | ------------ | ------- |\n"
+	for _, d := range scaleDowns {
+		result += fmt.Sprintf("| %s | %s | %s | %s | %d | %s |\n",
+			d.Time.Format(time.RFC3339), valueOrDash(d.Node), valueOrDash(d.NodePool), d.reason(), d.EvictedTotal, scaleDownOutcome(d.EventID, results))
+	}
+	result += "\n"
+
+	var withPods []nodeScaleDown
+	for _, d := range scaleDowns {
+		if len(d.EvictedPods) > 0 {
+			withPods = append(withPods, d)
+		}
+	}
+	if len(withPods) > 0 {
+		result += "## Evicted Pods\n\n"
+		for _, d := range withPods {
+			result += fmt.Sprintf("- **%s**: %s", d.Node, strings.Join(d.EvictedPods, ", "))
+			if more := d.EvictedTotal - len(d.EvictedPods); more > 0 {
+				result += fmt.Sprintf(" and %d more", more)
+			}
+			result += "\n"
+		}
+		result += "\n"
+	}
+
+	result += "## Recommended Actions\n\n"
+	result += "1. Compare scale-down times with when pods went Pending; a scale-down shortly before means the cluster lost the capacity those pods needed\n"
+	result += "2. If the evicted pods had no room to reschedule, raise their resource requests to match usage so the autoscaler doesn't judge their nodes underutilised\n"
+	result += "3. Protect critical pods from scale-down with a PodDisruptionBudget or the cluster-autoscaler.kubernetes.io/safe-to-evict: \"false\" annotation\n"
+	result += "4. Frequent scale-downs followed by scale-ups suggest the autoscaling profile is too aggressive; consider the balanced profile or a higher node pool minimum\n"
+
+	if cancelled {
+		result += cancelledNote
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
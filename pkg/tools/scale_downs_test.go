@@ -0,0 +1,70 @@
+package tools
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// scaleDownDecision is a cluster autoscaler visibility entry deciding to
+// remove a node, evicting pods from it
+func scaleDownDecision(decideTime, eventID, node string, pods ...string) string {
+	var evicted []string
+	for _, pod := range pods {
+		namespace, name, _ := strings.Cut(pod, "/")
+		evicted = append(evicted, `{"namespace": "`+namespace+`", "name": "`+name+`"}`)
+	}
+	return `{"timestamp": "` + decideTime + `", "jsonPayload": {"decision": {"decideTime": "` + decideTime + `", "eventId": "` + eventID + `",
+		"scaleDown": {"nodesToBeRemoved": [{
+			"node": {"name": "` + node + `", "mig": {"nodepool": "default-pool"}, "cpuRatio": 23, "memRatio": 41},
+			"evictedPods": [` + strings.Join(evicted, ",") + `], "evictedPodsTotalCount": ` + strconv.Itoa(len(pods)) + `}]}}}}`
+}
+
+func TestNodeScaleDownReason(t *testing.T) {
+	tests := []struct {
+		scaleDown nodeScaleDown
+		want      string
+	}{
+		{scaleDown: nodeScaleDown{CPURatio: "5", MemRatio: "3"}, want: "Empty node"},
+		{scaleDown: nodeScaleDown{EvictedTotal: 2, CPURatio: "23", MemRatio: "41"}, want: "Underutilised (CPU 23%, memory 41% requested)"},
+		{scaleDown: nodeScaleDown{EvictedTotal: 2}, want: "Underutilised"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.scaleDown.reason(); got != tt.want {
+			t.Errorf("reason of %+v = %q, want %q", tt.scaleDown, got, tt.want)
+		}
+	}
+}
+
+func TestHandleGetRecentAutoscalerScaleDowns(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), fakeLogging(t, func(filter string) string {
+		for _, s := range []string{`log_id("container.googleapis.com/cluster-autoscaler-visibility")`, `resource.labels.cluster_name="prod"`} {
+			if !strings.Contains(filter, s) {
+				t.Errorf("filter doesn't contain %s: %s", s, filter)
+			}
+		}
+		// Newest first, with results logged after their decisions
+		return "[" + strings.Join([]string{
+			`{"timestamp": "2026-10-17T01:21:00Z", "jsonPayload": {"resultInfo": {"results": [{"eventId": "e2", "errorMsg": {"messageId": "scale.down.error.failed.to.evict.pods"}}]}}}`,
+			scaleDownDecision("2026-10-17T01:20:00Z", "e2", "gke-prod-default-pool-b", "web/api-1", "web/api-2"),
+			`{"timestamp": "2026-10-17T01:06:00Z", "jsonPayload": {"resultInfo": {"results": [{"eventId": "e1"}]}}}`,
+			scaleDownDecision("2026-10-17T01:05:00Z", "e1", "gke-prod-default-pool-a"),
+		}, ",") + "]"
+	}))
+
+	text := callClusterTool(t, ctx, handleGetRecentAutoscalerScaleDowns, "prod", nil)
+
+	// Oldest first, each with the outcome its result entry reported
+	for _, s := range []string{
+		"The autoscaler decided to remove 2 nodes in the last 6h0m0s.",
+		"| 2026-10-17T01:05:00Z | gke-prod-default-pool-a | default-pool | Empty node | 0 | Removed |\n" +
+			"| 2026-10-17T01:20:00Z | gke-prod-default-pool-b | default-pool | Underutilised (CPU 23%, memory 41% requested) | 2 | **Failed**: scale.down.error.failed.to.evict.pods |\n",
+		"## Evicted Pods\n\n- **gke-prod-default-pool-b**: web/api-1, web/api-2\n",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
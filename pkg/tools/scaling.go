@@ -0,0 +1,347 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerScalingTools registers tools that explain workload scaling
+func registerScalingTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get recent scaling events tool
+	getRecentScalingEvents := mcp.NewTool("get_recent_scaling_events",
+		mcp.WithDescription("Builds a single chronological timeline of a deployment's scaling history: HorizontalPodAutoscaler decisions, cluster autoscaler node scale-ups and scale-downs involving its pods, and manual replica changes from audit logs"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The Kubernetes namespace"),
+		),
+		mcp.WithString("deployment_name",
+			mcp.Required(),
+			mcp.Description("The name of the deployment"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range for scaling events in hours (default: 6)"),
+		),
+	)
+
+	getRecentScalingEventsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetRecentScalingEvents(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getRecentScalingEvents, getRecentScalingEventsHandler)
+
+	return nil
+}
+
+// Scaling event sources
+const (
+	scalingSourceHPA        = "HPA"
+	scalingSourceAutoscaler = "Cluster autoscaler"
+	scalingSourceManual     = "Manual"
+)
+
+// scalingEvent is an entry on a workload's scaling timeline
+type scalingEvent struct {
+	Time        time.Time
+	Source      string
+	Description string
+}
+
+// mergeScalingEvents merges events from several sources into one timeline,
+// oldest first. Events at the same time keep their source order.
+func mergeScalingEvents(sources ...[]scalingEvent) []scalingEvent {
+	var events []scalingEvent
+	for _, source := range sources {
+		events = append(events, source...)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+	return events
+}
+
+// parseHPAEvents converts Kubernetes events recorded against the given HPAs
+// into scaling events
+func parseHPAEvents(entries []logEntry, hpaNames []string) []scalingEvent {
+	var events []scalingEvent
+	for _, entry := range entries {
+		name := payloadString(entry.JsonPayload, "involvedObject", "name")
+		if !containsString(hpaNames, name) {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, entry.Timestamp)
+		if err != nil {
+			continue
+		}
+
+		description := fmt.Sprintf("%s: %s", payloadString(entry.JsonPayload, "reason"), payloadString(entry.JsonPayload, "message"))
+		if payloadString(entry.JsonPayload, "type") == "Warning" {
+			description = "**Warning** " + description
+		}
+
+		events = append(events, scalingEvent{Time: t, Source: scalingSourceHPA, Description: description})
+	}
+	return events
+}
+
+// isDeploymentPod reports whether a pod name and namespace belong to the
+// deployment, whose pods are named after it with a ReplicaSet and pod suffix
+func isDeploymentPod(pod map[string]interface{}, namespace, deploymentName string) bool {
+	return payloadString(pod, "namespace") == namespace &&
+		strings.HasPrefix(payloadString(pod, "name"), deploymentName+"-")
+}
+
+// parseAutoscalerDecisions converts cluster autoscaler visibility decisions
+// that involve the deployment's pods into scaling events. Scale-ups count when
+// one of its pods triggered them, scale-downs when they evicted one.
+func parseAutoscalerDecisions(entries []logEntry, namespace, deploymentName string) []scalingEvent {
+	var events []scalingEvent
+	for _, entry := range entries {
+		decision, ok := entry.JsonPayload["decision"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, payloadString(decision, "decideTime"))
+		if err != nil {
+			if t, err = time.Parse(time.RFC3339, entry.Timestamp); err != nil {
+				continue
+			}
+		}
+
+		if scaleUp, ok := decision["scaleUp"].(map[string]interface{}); ok {
+			triggered := 0
+			for _, pod := range payloadObjects(scaleUp, "triggeringPods") {
+				if isDeploymentPod(pod, namespace, deploymentName) {
+					triggered++
+				}
+			}
+			if triggered > 0 {
+				var migs []string
+				for _, mig := range payloadObjects(scaleUp, "increasedMigs") {
+					migs = append(migs, fmt.Sprintf("+%s node(s) in %s", payloadString(mig, "requestedNodes"), payloadString(mig, "mig", "name")))
+				}
+				events = append(events, scalingEvent{
+					Time:        t,
+					Source:      scalingSourceAutoscaler,
+					Description: fmt.Sprintf("Scale-up %s, triggered by %d pending pod(s) of the deployment", strings.Join(migs, ", "), triggered),
+				})
+			}
+		}
+
+		if scaleDown, ok := decision["scaleDown"].(map[string]interface{}); ok {
+			for _, node := range payloadObjects(scaleDown, "nodesToBeRemoved") {
+				evicted := 0
+				for _, pod := range payloadObjects(node, "evictedPods") {
+					if isDeploymentPod(pod, namespace, deploymentName) {
+						evicted++
+					}
+				}
+				if evicted > 0 {
+					events = append(events, scalingEvent{
+						Time:        t,
+						Source:      scalingSourceAutoscaler,
+						Description: fmt.Sprintf("Scale-down removing node %s, evicting %d pod(s) of the deployment", payloadString(node, "node", "name"), evicted),
+					})
+				}
+			}
+		}
+	}
+	return events
+}
+
+// parseManualScaleChanges converts audit log records of replica changes into
+// scaling events. Changes made by system controllers, such as the HPA, are
+// skipped as they are already covered by other sources.
+func parseManualScaleChanges(records []auditRecord) []scalingEvent {
+	var events []scalingEvent
+	for _, record := range records {
+		if record.StatusCode != 0 || strings.HasPrefix(record.Principal, "system:") {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, record.Timestamp)
+		if err != nil {
+			continue
+		}
+
+		replicas := payloadString(record.Request, "spec", "replicas")
+		if replicas == "" {
+			// Patches that don't touch the replica count aren't scaling changes
+			continue
+		}
+
+		events = append(events, scalingEvent{
+			Time:        t,
+			Source:      scalingSourceManual,
+			Description: fmt.Sprintf("Replicas set to %s by %s", replicas, record.Principal),
+		})
+	}
+	return events
+}
+
+// handleGetRecentScalingEvents handles the get_recent_scaling_events tool request
+func handleGetRecentScalingEvents(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	namespace, ok := request.Params.Arguments["namespace"].(string)
+	if !ok || namespace == "" {
+		return mcp.NewToolResultError("namespace must be a non-empty string"), nil
+	}
+
+	deploymentName, ok := request.Params.Arguments["deployment_name"].(string)
+	if !ok || deploymentName == "" {
+		return mcp.NewToolResultError("deployment_name must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	timeRangeHours := 6.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	startTime := time.Now().Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+	clusterFilter := fmt.Sprintf(`resource.labels.project_id="%s"
+		AND resource.labels.location="%s"
+		AND resource.labels.cluster_name="%s"`,
+		projectID, location, clusterName)
+
+	// Find the HPAs targeting the deployment, assuming the common convention
+	// of naming the HPA after the deployment if the cluster can't be reached
+	hpaNames := []string{deploymentName}
+	var notes []string
+	if kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName); err == nil {
+		var hpas struct {
+			Items []struct {
+				Metadata kubeObjectMeta `json:"metadata"`
+				Spec     struct {
+					ScaleTargetRef struct {
+						Kind string `json:"kind"`
+						Name string `json:"name"`
+					} `json:"scaleTargetRef"`
+				} `json:"spec"`
+			} `json:"items"`
+		}
+		if err := kube.get(ctx, fmt.Sprintf("/apis/autoscaling/v2/namespaces/%s/horizontalpodautoscalers", namespace), nil, &hpas); err == nil {
+			hpaNames = nil
+			for _, hpa := range hpas.Items {
+				if hpa.Spec.ScaleTargetRef.Kind == "Deployment" && hpa.Spec.ScaleTargetRef.Name == deploymentName {
+					hpaNames = append(hpaNames, hpa.Metadata.Name)
+				}
+			}
+		} else {
+			notes = append(notes, fmt.Sprintf("Could not list HPAs (%v); assumed an HPA named %s.", err, deploymentName))
+		}
+	} else {
+		notes = append(notes, fmt.Sprintf("Could not connect to the cluster (%v); assumed an HPA named %s.", err, deploymentName))
+	}
+
+	var hpaEvents []scalingEvent
+	if len(hpaNames) > 0 {
+		entries, _, err := fetchLogEntries(ctx, client, projectID, logQuery{
+			Filter: fmt.Sprintf(`log_id("events")
+		AND %s
+		AND jsonPayload.involvedObject.kind="HorizontalPodAutoscaler"
+		AND jsonPayload.involvedObject.namespace="%s"
+		AND timestamp >= "%s"`, clusterFilter, namespace, startTime.Format(time.RFC3339)),
+			PageSize: 1000,
+		})
+		if err != nil {
+			notes = append(notes, fmt.Sprintf("Could not query HPA events: %v", err))
+		}
+		hpaEvents = parseHPAEvents(entries, hpaNames)
+	}
+
+	entries, _, err := fetchLogEntries(ctx, client, projectID, logQuery{
+		Filter: fmt.Sprintf(`log_id("container.googleapis.com/cluster-autoscaler-visibility")
+		AND %s
+		AND jsonPayload.decision:*
+		AND timestamp >= "%s"`, clusterFilter, startTime.Format(time.RFC3339)),
+		PageSize: 1000,
+	})
+	if err != nil {
+		notes = append(notes, fmt.Sprintf("Could not query cluster autoscaler logs: %v", err))
+	}
+	autoscalerEvents := parseAutoscalerDecisions(entries, namespace, deploymentName)
+
+	// Replica changes go through the scale subresource (kubectl scale) or
+	// patches of the deployment (kubectl apply, edit or patch)
+	records, _, err := fetchAuditLogEntries(ctx, client, projectID, []string{auditLogActivity},
+		fmt.Sprintf(`resource.type="k8s_cluster"
+		AND %s
+		AND protoPayload.resourceName=~"^apps/v1/namespaces/%s/deployments/%s(/scale)?$"
+		AND protoPayload.methodName=~"\\.(patch|update)$"`, clusterFilter, namespace, deploymentName),
+		startTime, 1000)
+	if err != nil {
+		notes = append(notes, fmt.Sprintf("Could not query audit logs: %v", err))
+	}
+	manualEvents := parseManualScaleChanges(records)
+
+	events := mergeScalingEvents(hpaEvents, autoscalerEvents, manualEvents)
+
+	// Format the results
+	result := fmt.Sprintf("# Scaling Timeline for Deployment %s/%s\n\n", namespace, deploymentName)
+	for _, note := range notes {
+		result += fmt.Sprintf("Note: %s\n\n", note)
+	}
+
+	if len(events) == 0 {
+		result += fmt.Sprintf("No scaling events found in the last %.1f hours.\n", timeRangeHours)
+		return mcp.NewToolResultText(result), nil
+	}
+
+	result += fmt.Sprintf("Found %d scaling events in the last %.1f hours: %d from the HPA, %d from the cluster autoscaler and %d manual changes.\n\n",
+		len(events), timeRangeHours, len(hpaEvents), len(autoscalerEvents), len(manualEvents))
+
+	result += "| Time | Source | Event |\n"
+	result += "| ---- | ------ | ----- |\n"
+	for _, event := range events {
+		result += fmt.Sprintf("| %s | %s | %s |\n", event.Time.Format("2006-01-02 15:04:05"), event.Source, event.Description)
+	}
+
+	result += "\n## Recommended Actions\n\n"
+	result += "1. HPA scale-ups followed by autoscaler scale-ups show pods waiting for nodes; pending time between them is capacity lag\n"
+	result += "2. Manual changes on a deployment with an HPA are overridden by the HPA at its next sync, so set minReplicas or maxReplicas instead\n"
+	result += "3. HPA warnings such as FailedGetResourceMetric mean it can't see metrics and won't scale; check the metrics server or custom metrics adapter\n"
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,85 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMergeScalingEvents(t *testing.T) {
+	base := time.Date(2026, 10, 17, 1, 0, 0, 0, time.UTC)
+	at := func(minutes int, source string) scalingEvent {
+		return scalingEvent{Time: base.Add(time.Duration(minutes) * time.Minute), Source: source}
+	}
+
+	// Events at the same time keep the order of their sources
+	got := mergeScalingEvents(
+		[]scalingEvent{at(10, scalingSourceHPA), at(30, scalingSourceHPA)},
+		[]scalingEvent{at(5, scalingSourceAutoscaler), at(30, scalingSourceAutoscaler)},
+		[]scalingEvent{at(20, scalingSourceManual)},
+	)
+	want := []scalingEvent{
+		at(5, scalingSourceAutoscaler), at(10, scalingSourceHPA), at(20, scalingSourceManual),
+		at(30, scalingSourceHPA), at(30, scalingSourceAutoscaler),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("mergeScalingEvents = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestHandleGetRecentScalingEvents(t *testing.T) {
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items": [
+			{"metadata": {"name": "web-hpa"}, "spec": {"scaleTargetRef": {"kind": "Deployment", "name": "web"}}},
+			{"metadata": {"name": "api"}, "spec": {"scaleTargetRef": {"kind": "Deployment", "name": "api"}}}
+		]}`))
+	}))
+
+	ctx := withFakeGCP(context.Background(), fakeLogging(t, func(filter string) string {
+		switch {
+		case strings.Contains(filter, `log_id("events")`):
+			return `[
+				{"timestamp": "2026-10-17T01:20:00Z", "jsonPayload": {"involvedObject": {"name": "web-hpa"}, "reason": "SuccessfulRescale", "message": "New size: 6"}},
+				{"timestamp": "2026-10-17T01:05:00Z", "jsonPayload": {"involvedObject": {"name": "api"}, "reason": "SuccessfulRescale", "message": "New size: 2"}},
+				{"timestamp": "2026-10-17T01:00:00Z", "jsonPayload": {"involvedObject": {"name": "web-hpa"}, "reason": "FailedGetResourceMetric", "message": "missing metrics", "type": "Warning"}}
+			]`
+		case strings.Contains(filter, "cluster-autoscaler-visibility"):
+			return `[{"timestamp": "2026-10-17T01:11:00Z", "jsonPayload": {"decision": {"decideTime": "2026-10-17T01:10:00Z", "scaleUp": {
+				"triggeringPods": [{"namespace": "web", "name": "web-5d9c-abcde"}, {"namespace": "web", "name": "webhook-1"}],
+				"increasedMigs": [{"mig": {"name": "pool-1"}, "requestedNodes": 2}]
+			}}}}]`
+		case strings.Contains(filter, "cloudaudit.googleapis.com"):
+			return `[
+				{"timestamp": "2026-10-17T01:30:00Z", "protoPayload": {"authenticationInfo": {"principalEmail": "alice@example.com"}, "request": {"spec": {"replicas": 3}}}},
+				{"timestamp": "2026-10-17T01:25:00Z", "protoPayload": {"authenticationInfo": {"principalEmail": "system:serviceaccount:kube-system:horizontal-pod-autoscaler"}, "request": {"spec": {"replicas": 6}}}}
+			]`
+		}
+		t.Errorf("unexpected filter %s", filter)
+		return "[]"
+	}))
+
+	text := callClusterTool(t, ctx, handleGetRecentScalingEvents, clusterName, map[string]interface{}{
+		"namespace":       "web",
+		"deployment_name": "web",
+	})
+
+	// Events from every source are interleaved in time order
+	for _, s := range []string{
+		"Found 4 scaling events in the last 6.0 hours: 2 from the HPA, 1 from the cluster autoscaler and 1 manual changes.",
+		"| 2026-10-17 01:00:00 | HPA | **Warning** FailedGetResourceMetric: missing metrics |\n" +
+			"| 2026-10-17 01:10:00 | Cluster autoscaler | Scale-up +2 node(s) in pool-1, triggered by 1 pending pod(s) of the deployment |\n" +
+			"| 2026-10-17 01:20:00 | HPA | SuccessfulRescale: New size: 6 |\n" +
+			"| 2026-10-17 01:30:00 | Manual | Replicas set to 3 by alice@example.com |\n",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
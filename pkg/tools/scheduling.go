@@ -0,0 +1,367 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerSchedulingTools registers tools that explain pod scheduling failures
+func registerSchedulingTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get node taints and affinity conflicts tool
+	getSchedulingConflicts := mcp.NewTool("get_node_taints_and_affinity_conflicts",
+		mcp.WithDescription("Explains why a Pending pod can't be scheduled by comparing its tolerations, nodeSelector and required node affinity against every node, reporting which taint isn't tolerated, which selector has no match and which affinity can't be satisfied"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("The Kubernetes namespace"),
+		),
+		mcp.WithString("pod_name",
+			mcp.Required(),
+			mcp.Description("The name of the pod"),
+		),
+	)
+
+	getSchedulingConflictsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetNodeTaintsAndAffinityConflicts(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getSchedulingConflicts, getSchedulingConflictsHandler)
+
+	return nil
+}
+
+// kubeTaint is a node taint
+type kubeTaint struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Effect string `json:"effect"`
+}
+
+func (t kubeTaint) String() string {
+	if t.Value == "" {
+		return fmt.Sprintf("%s:%s", t.Key, t.Effect)
+	}
+	return fmt.Sprintf("%s=%s:%s", t.Key, t.Value, t.Effect)
+}
+
+// kubeToleration is a pod toleration
+type kubeToleration struct {
+	Key      string `json:"key"`
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+	Effect   string `json:"effect"`
+}
+
+// tolerates reports whether the toleration matches the taint. An empty key
+// with the Exists operator tolerates every taint.
+func (t kubeToleration) tolerates(taint kubeTaint) bool {
+	if t.Effect != "" && t.Effect != taint.Effect {
+		return false
+	}
+	if t.Operator == "Exists" {
+		return t.Key == "" || t.Key == taint.Key
+	}
+	return t.Key == taint.Key && t.Value == taint.Value
+}
+
+// kubeNodeSelectorRequirement is a node affinity match expression
+type kubeNodeSelectorRequirement struct {
+	Key      string   `json:"key"`
+	Operator string   `json:"operator"`
+	Values   []string `json:"values"`
+}
+
+func (r kubeNodeSelectorRequirement) String() string {
+	switch r.Operator {
+	case "Exists", "DoesNotExist":
+		return fmt.Sprintf("%s %s", r.Key, r.Operator)
+	}
+	return fmt.Sprintf("%s %s (%s)", r.Key, r.Operator, strings.Join(r.Values, ", "))
+}
+
+// matches reports whether the node labels satisfy the requirement
+func (r kubeNodeSelectorRequirement) matches(labels map[string]string) bool {
+	value, exists := labels[r.Key]
+	switch r.Operator {
+	case "In":
+		return exists && containsString(r.Values, value)
+	case "NotIn":
+		return !exists || !containsString(r.Values, value)
+	case "Exists":
+		return exists
+	case "DoesNotExist":
+		return !exists
+	case "Gt", "Lt":
+		if !exists || len(r.Values) != 1 {
+			return false
+		}
+		have, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false
+		}
+		want, err := strconv.ParseInt(r.Values[0], 10, 64)
+		if err != nil {
+			return false
+		}
+		if r.Operator == "Gt" {
+			return have > want
+		}
+		return have < want
+	}
+	return false
+}
+
+// kubePodScheduling is the subset of a core/v1 Pod that constrains which
+// nodes it can be scheduled on
+type kubePodScheduling struct {
+	Spec struct {
+		NodeSelector map[string]string `json:"nodeSelector"`
+		Tolerations  []kubeToleration  `json:"tolerations"`
+		Affinity     *struct {
+			NodeAffinity *struct {
+				Required *struct {
+					NodeSelectorTerms []struct {
+						MatchExpressions []kubeNodeSelectorRequirement `json:"matchExpressions"`
+					} `json:"nodeSelectorTerms"`
+				} `json:"requiredDuringSchedulingIgnoredDuringExecution"`
+			} `json:"nodeAffinity"`
+		} `json:"affinity"`
+	} `json:"spec"`
+	Status struct {
+		Phase      string `json:"phase"`
+		Conditions []struct {
+			Type    string `json:"type"`
+			Status  string `json:"status"`
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		} `json:"conditions"`
+	} `json:"status"`
+}
+
+// kubeNodeScheduling is the subset of a core/v1 Node used to check scheduling
+type kubeNodeScheduling struct {
+	Metadata kubeObjectMeta `json:"metadata"`
+	Spec     struct {
+		Unschedulable bool        `json:"unschedulable"`
+		Taints        []kubeTaint `json:"taints"`
+	} `json:"spec"`
+}
+
+// nodeSchedulingConflicts returns why the pod can't be placed on the node
+// based on taints, nodeSelector and required node affinity, or nothing if it
+// can. Other constraints, such as resources and pod affinity, aren't checked.
+func nodeSchedulingConflicts(pod kubePodScheduling, node kubeNodeScheduling) []string {
+	var conflicts []string
+
+	if node.Spec.Unschedulable {
+		cordon := kubeTaint{Key: "node.kubernetes.io/unschedulable", Effect: "NoSchedule"}
+		tolerated := false
+		for _, toleration := range pod.Spec.Tolerations {
+			if toleration.tolerates(cordon) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			conflicts = append(conflicts, "node is cordoned (unschedulable)")
+		}
+	}
+
+	for _, taint := range node.Spec.Taints {
+		// PreferNoSchedule is only a preference, so it never blocks scheduling
+		if taint.Effect == "PreferNoSchedule" {
+			continue
+		}
+		tolerated := false
+		for _, toleration := range pod.Spec.Tolerations {
+			if toleration.tolerates(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			conflicts = append(conflicts, fmt.Sprintf("taint %s is not tolerated", taint))
+		}
+	}
+
+	selectorKeys := make([]string, 0, len(pod.Spec.NodeSelector))
+	for key := range pod.Spec.NodeSelector {
+		selectorKeys = append(selectorKeys, key)
+	}
+	sort.Strings(selectorKeys)
+	for _, key := range selectorKeys {
+		if value, ok := node.Metadata.Labels[key]; !ok || value != pod.Spec.NodeSelector[key] {
+			conflicts = append(conflicts, fmt.Sprintf("nodeSelector %s=%s doesn't match", key, pod.Spec.NodeSelector[key]))
+		}
+	}
+
+	// Required node affinity terms are ORed; expressions within a term are ANDed
+	if affinity := pod.Spec.Affinity; affinity != nil && affinity.NodeAffinity != nil && affinity.NodeAffinity.Required != nil {
+		terms := affinity.NodeAffinity.Required.NodeSelectorTerms
+		var unmet []string
+		satisfied := false
+		for _, term := range terms {
+			termUnmet := ""
+			for _, expr := range term.MatchExpressions {
+				if !expr.matches(node.Metadata.Labels) {
+					termUnmet = expr.String()
+					break
+				}
+			}
+			if termUnmet == "" {
+				satisfied = true
+				break
+			}
+			unmet = append(unmet, termUnmet)
+		}
+		if len(terms) > 0 && !satisfied {
+			conflicts = append(conflicts, fmt.Sprintf("required node affinity not satisfied: %s", strings.Join(unmet, " OR ")))
+		}
+	}
+
+	return conflicts
+}
+
+// schedulingConflict counts the nodes that fail a constraint
+type schedulingConflict struct {
+	Reason string
+	Nodes  []string
+}
+
+// summariseSchedulingConflicts checks the pod against every node, returning
+// the nodes it could be placed on and each conflict with the nodes it rules
+// out, most widespread first
+func summariseSchedulingConflicts(pod kubePodScheduling, nodes []kubeNodeScheduling) ([]string, []schedulingConflict) {
+	var eligible []string
+	byReason := make(map[string]*schedulingConflict)
+	var order []string
+	for _, node := range nodes {
+		conflicts := nodeSchedulingConflicts(pod, node)
+		if len(conflicts) == 0 {
+			eligible = append(eligible, node.Metadata.Name)
+			continue
+		}
+		for _, reason := range conflicts {
+			conflict, ok := byReason[reason]
+			if !ok {
+				conflict = &schedulingConflict{Reason: reason}
+				byReason[reason] = conflict
+				order = append(order, reason)
+			}
+			conflict.Nodes = append(conflict.Nodes, node.Metadata.Name)
+		}
+	}
+
+	conflicts := make([]schedulingConflict, 0, len(order))
+	for _, reason := range order {
+		conflicts = append(conflicts, *byReason[reason])
+	}
+	sort.SliceStable(conflicts, func(i, j int) bool {
+		return len(conflicts[i].Nodes) > len(conflicts[j].Nodes)
+	})
+
+	return eligible, conflicts
+}
+
+// handleGetNodeTaintsAndAffinityConflicts handles the get_node_taints_and_affinity_conflicts tool request
+func handleGetNodeTaintsAndAffinityConflicts(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	namespace, ok := request.Params.Arguments["namespace"].(string)
+	if !ok || namespace == "" {
+		return mcp.NewToolResultError("namespace must be a non-empty string"), nil
+	}
+
+	podName, ok := request.Params.Arguments["pod_name"].(string)
+	if !ok || podName == "" {
+		return mcp.NewToolResultError("pod_name must be a non-empty string"), nil
+	}
+
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error connecting to cluster: %v", err)), nil
+	}
+
+	var pod kubePodScheduling
+	if err := kube.get(ctx, fmt.Sprintf("/api/v1/namespaces/%s/pods/%s", namespace, podName), nil, &pod); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting pod: %v", err)), nil
+	}
+
+	var nodes struct {
+		Items []kubeNodeScheduling `json:"items"`
+	}
+	if err := kube.get(ctx, "/api/v1/nodes", nil, &nodes); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing nodes: %v", err)), nil
+	}
+
+	eligible, conflicts := summariseSchedulingConflicts(pod, nodes.Items)
+
+	// Format the results
+	result := fmt.Sprintf("# Scheduling Conflicts for Pod %s/%s\n\n", namespace, podName)
+	result += fmt.Sprintf("- **Phase**: %s\n", pod.Status.Phase)
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == "PodScheduled" && cond.Status != "True" && cond.Message != "" {
+			result += fmt.Sprintf("- **Scheduler Message**: %s\n", cond.Message)
+		}
+	}
+	result += fmt.Sprintf("- **Nodes Matching Taints and Affinity**: %d of %d\n\n", len(eligible), len(nodes.Items))
+
+	if pod.Status.Phase != "Pending" {
+		result += "Note: The pod is not Pending, so it has already been scheduled; the conflicts below only show where else it could run.\n\n"
+	}
+
+	if len(conflicts) > 0 {
+		result += "## Conflicts\n\n"
+		for _, conflict := range conflicts {
+			examples := conflict.Nodes
+			if len(examples) > 3 {
+				examples = examples[:3]
+			}
+			result += fmt.Sprintf("- **%d nodes**: %s (e.g. %s)\n", len(conflict.Nodes), conflict.Reason, strings.Join(examples, ", "))
+		}
+		result += "\n"
+	}
+
+	result += "## Recommended Actions\n\n"
+	if len(eligible) > 0 {
+		result += fmt.Sprintf("1. %d nodes satisfy the pod's taints, nodeSelector and node affinity, so it is likely Pending for another reason: insufficient CPU or memory, pod affinity/anti-affinity, topology spread or a volume in another zone\n", len(eligible))
+		result += "2. Check the scheduler message above and the pod's events for the specific reason\n"
+	} else {
+		result += "1. No node satisfies the pod's constraints; add a matching toleration, fix the nodeSelector or affinity, or create a node pool with the required labels and taints\n"
+		result += "2. If the cluster autoscaler or node auto-provisioning is enabled, check that a node pool it can scale up would satisfy these constraints\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,99 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNodeSchedulingConflicts(t *testing.T) {
+	var pod kubePodScheduling
+	err := json.Unmarshal([]byte(`{"spec": {
+		"nodeSelector": {"pool": "gpu"},
+		"tolerations": [{"key": "nvidia.com/gpu", "operator": "Exists", "effect": "NoSchedule"}],
+		"affinity": {"nodeAffinity": {"requiredDuringSchedulingIgnoredDuringExecution": {"nodeSelectorTerms": [
+			{"matchExpressions": [{"key": "zone", "operator": "In", "values": ["us-central1-a"]}]},
+			{"matchExpressions": [{"key": "cpu-count", "operator": "Gt", "values": ["16"]}]}
+		]}}}
+	}}`), &pod)
+	if err != nil {
+		t.Fatalf("parsing pod: %v", err)
+	}
+
+	node := func(labels map[string]string, unschedulable bool, taints ...kubeTaint) kubeNodeScheduling {
+		var n kubeNodeScheduling
+		n.Metadata.Labels = labels
+		n.Spec.Unschedulable = unschedulable
+		n.Spec.Taints = taints
+		return n
+	}
+
+	tests := []struct {
+		name string
+		node kubeNodeScheduling
+		want []string
+	}{
+		{
+			name: "matching",
+			node: node(map[string]string{"pool": "gpu", "zone": "us-central1-a"}, false, kubeTaint{Key: "nvidia.com/gpu", Value: "present", Effect: "NoSchedule"}),
+		},
+		{
+			name: "second affinity term",
+			node: node(map[string]string{"pool": "gpu", "zone": "us-central1-b", "cpu-count": "32"}, false, kubeTaint{Key: "spot", Effect: "PreferNoSchedule"}),
+		},
+		{
+			name: "conflicting",
+			node: node(map[string]string{"pool": "default", "zone": "us-central1-b", "cpu-count": "8"}, true, kubeTaint{Key: "dedicated", Value: "db", Effect: "NoExecute"}),
+			want: []string{
+				"node is cordoned (unschedulable)",
+				"taint dedicated=db:NoExecute is not tolerated",
+				"nodeSelector pool=gpu doesn't match",
+				"required node affinity not satisfied: zone In (us-central1-a) OR cpu-count Gt (16)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := nodeSchedulingConflicts(pod, tt.node); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: nodeSchedulingConflicts = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHandleGetNodeTaintsAndAffinityConflicts(t *testing.T) {
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/namespaces/web/pods/train-0":
+			w.Write([]byte(`{"spec": {"nodeSelector": {"cloud.google.com/gke-nodepool": "gpu-pool"}}, "status": {"phase": "Pending",
+				"conditions": [{"type": "PodScheduled", "status": "False", "reason": "Unschedulable", "message": "0/3 nodes are available: 3 node(s) didn't match Pod's node affinity/selector."}]}}`))
+		case "/api/v1/nodes":
+			w.Write([]byte(`{"items": [
+				{"metadata": {"name": "node-a", "labels": {"cloud.google.com/gke-nodepool": "default-pool"}}},
+				{"metadata": {"name": "node-b", "labels": {"cloud.google.com/gke-nodepool": "default-pool"}}},
+				{"metadata": {"name": "node-c", "labels": {"cloud.google.com/gke-nodepool": "highmem-pool"}}, "spec": {"taints": [{"key": "highmem", "value": "true", "effect": "NoSchedule"}]}}
+			]}`))
+		default:
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"kind": "Status", "reason": "NotFound"})
+		}
+	}))
+
+	text := callClusterTool(t, context.Background(), handleGetNodeTaintsAndAffinityConflicts, clusterName, map[string]interface{}{
+		"namespace": "web",
+		"pod_name":  "train-0",
+	})
+
+	for _, s := range []string{
+		"- **Scheduler Message**: 0/3 nodes are available: 3 node(s) didn't match Pod's node affinity/selector.\n",
+		"- **Nodes Matching Taints and Affinity**: 0 of 3\n",
+		"- **3 nodes**: nodeSelector cloud.google.com/gke-nodepool=gpu-pool doesn't match (e.g. node-a, node-b, node-c)\n" +
+			"- **1 nodes**: taint highmem=true:NoSchedule is not tolerated (e.g. node-c)\n",
+		"1. No node satisfies the pod's constraints",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerSecurityBulletinTools registers tools for GKE security bulletins
+func registerSecurityBulletinTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get GKE security bulletins tool
+	getSecurityBulletins := mcp.NewTool("get_gke_security_bulletins",
+		mcp.WithDescription("Reports GKE security bulletins published for a cluster, with severity, affected and patched versions, flagging bulletins the cluster's current versions are affected by"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The location of the cluster"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The name of the cluster"),
+		),
+		mcp.WithNumber("time_range_days",
+			mcp.Description("How far back to look for bulletins in days (default: 90)"),
+		),
+	)
+
+	getSecurityBulletinsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetGKESecurityBulletins(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getSecurityBulletins, getSecurityBulletinsHandler)
+
+	return nil
+}
+
+// gkeVersion is a parsed GKE version such as 1.29.4-gke.1043002
+type gkeVersion struct {
+	Major, Minor, Patch, GKE int
+}
+
+// parseGKEVersion parses a GKE version string, accepting a missing patch or
+// gke suffix
+func parseGKEVersion(v string) (gkeVersion, error) {
+	var version gkeVersion
+
+	base, suffix, _ := strings.Cut(strings.TrimPrefix(v, "v"), "-gke.")
+	parts := strings.Split(base, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return version, fmt.Errorf("invalid GKE version %q", v)
+	}
+
+	fields := []*int{&version.Major, &version.Minor, &version.Patch}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return version, fmt.Errorf("invalid GKE version %q", v)
+		}
+		*fields[i] = n
+	}
+
+	if suffix != "" {
+		n, err := strconv.Atoi(suffix)
+		if err != nil {
+			return version, fmt.Errorf("invalid GKE version %q", v)
+		}
+		version.GKE = n
+	}
+
+	return version, nil
+}
+
+// minor returns the minor version, e.g. "1.29"
+func (v gkeVersion) minor() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// compare returns -1, 0 or 1 as v is older than, equal to or newer than o
+func (v gkeVersion) compare(o gkeVersion) int {
+	for _, d := range []int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch, v.GKE - o.GKE} {
+		if d < 0 {
+			return -1
+		}
+		if d > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// securityBulletin is a SecurityBulletinEvent cluster notification
+type securityBulletin struct {
+	BulletinID              string   `json:"bulletinId"`
+	CveIDs                  []string `json:"cveIds"`
+	Severity                string   `json:"severity"`
+	BulletinURI             string   `json:"bulletinUri"`
+	BriefDescription        string   `json:"briefDescription"`
+	ResourceTypeAffected    string   `json:"resourceTypeAffected"`
+	AffectedSupportedMinors []string `json:"affectedSupportedMinors"`
+	PatchedVersions         []string `json:"patchedVersions"`
+	SuggestedUpgradeTarget  string   `json:"suggestedUpgradeTarget"`
+	ManualStepsRequired     bool     `json:"manualStepsRequired"`
+
+	// Published is the time the notification was logged
+	Published string `json:"-"`
+}
+
+// affects reports whether a cluster version is affected by the bulletin: its
+// minor version is listed as affected and it is older than the patched
+// version for that minor, or no patch exists for it yet
+func (b securityBulletin) affects(version gkeVersion) bool {
+	if !containsString(b.AffectedSupportedMinors, version.minor()) {
+		return false
+	}
+
+	for _, patched := range b.PatchedVersions {
+		p, err := parseGKEVersion(patched)
+		if err != nil || p.minor() != version.minor() {
+			continue
+		}
+		return version.compare(p) < 0
+	}
+
+	return true
+}
+
+// parseSecurityBulletins extracts bulletins from cluster notification log
+// entries, keeping the newest notification for each bulletin
+func parseSecurityBulletins(entries []logEntry) []securityBulletin {
+	seen := map[string]bool{}
+	var bulletins []securityBulletin
+	for _, entry := range entries {
+		encoded, err := json.Marshal(entry.JsonPayload)
+		if err != nil {
+			continue
+		}
+
+		var bulletin securityBulletin
+		if err := json.Unmarshal(encoded, &bulletin); err != nil || bulletin.BulletinID == "" {
+			continue
+		}
+		if seen[bulletin.BulletinID] {
+			continue
+		}
+		seen[bulletin.BulletinID] = true
+
+		bulletin.Published = entry.Timestamp
+		bulletins = append(bulletins, bulletin)
+	}
+	return bulletins
+}
+
+// bulletinSeverityRank orders bulletin severities from most to least severe
+var bulletinSeverityRank = map[string]int{
+	"CRITICAL": 0,
+	"HIGH":     1,
+	"MEDIUM":   2,
+	"LOW":      3,
+}
+
+// handleGetGKESecurityBulletins handles the get_gke_security_bulletins tool request
+func handleGetGKESecurityBulletins(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	timeRangeDays := 90.0
+	if val, ok := request.Params.Arguments["time_range_days"].(float64); ok && val > 0 {
+		timeRangeDays = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	cluster, err := fetchCluster(ctx, client, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting cluster: %v", err)), nil
+	}
+
+	// Bulletins are published as cluster notifications in Cloud Logging
+	startTime := time.Now().Add(-time.Duration(timeRangeDays*24) * time.Hour)
+	filter := fmt.Sprintf(`logName="projects/%s/logs/container.googleapis.com%%2Fnotifications" AND resource.labels.cluster_name="%s" AND resource.labels.location="%s" AND jsonPayload.bulletinId:* AND timestamp>="%s"`,
+		projectID, clusterName, location, startTime.UTC().Format(time.RFC3339))
+
+	entries, _, err := fetchLogEntries(ctx, client, projectID, logQuery{Filter: filter, PageSize: 500})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying logs: %v", err)), nil
+	}
+
+	bulletins := parseSecurityBulletins(entries)
+	if len(bulletins) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No security bulletins were published for cluster %s in the last %.0f days.\n\n"+
+			"Bulletins are only recorded when cluster notifications are logged. See https://cloud.google.com/kubernetes-engine/security-bulletins for the full list.", clusterName, timeRangeDays)), nil
+	}
+
+	// The control plane and nodes can be on different versions, so check both
+	versions := map[string]string{"control plane": cluster.CurrentMasterVersion, "nodes": cluster.CurrentNodeVersion}
+
+	type bulletinRow struct {
+		Bulletin securityBulletin
+		Affected []string
+	}
+	var rows []bulletinRow
+	affectedCount := 0
+	for _, bulletin := range bulletins {
+		row := bulletinRow{Bulletin: bulletin}
+		for _, component := range []string{"control plane", "nodes"} {
+			version, err := parseGKEVersion(versions[component])
+			if err == nil && bulletin.affects(version) {
+				row.Affected = append(row.Affected, component)
+			}
+		}
+		if len(row.Affected) > 0 {
+			affectedCount++
+		}
+		rows = append(rows, row)
+	}
+
+	rank := func(severity string) int {
+		if r, ok := bulletinSeverityRank[severity]; ok {
+			return r
+		}
+		return len(bulletinSeverityRank)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if (len(rows[i].Affected) > 0) != (len(rows[j].Affected) > 0) {
+			return len(rows[i].Affected) > 0
+		}
+		return rank(rows[i].Bulletin.Severity) < rank(rows[j].Bulletin.Severity)
+	})
+
+	// Format the results
+	result := fmt.Sprintf("# Security Bulletins for Cluster %s\n\n", clusterName)
+	result += fmt.Sprintf("Cluster versions: %s (control plane) / %s (nodes)\n\n", cluster.CurrentMasterVersion, cluster.CurrentNodeVersion)
+	result += fmt.Sprintf("Found %d bulletins in the last %.0f days, %d affecting the current versions.\n\n", len(rows), timeRangeDays, affectedCount)
+
+	for _, row := range rows {
+		b := row.Bulletin
+		title := fmt.Sprintf("%s (%s)", b.BulletinID, b.Severity)
+		if len(row.Affected) > 0 {
+			title = fmt.Sprintf("**%s - AFFECTS %s**", title, strings.ToUpper(strings.Join(row.Affected, " and ")))
+		}
+		result += fmt.Sprintf("## %s\n\n", title)
+
+		if b.BriefDescription != "" {
+			result += b.BriefDescription + "\n\n"
+		}
+		result += fmt.Sprintf("- **Published**: %s\n", formatTime(b.Published))
+		if len(b.CveIDs) > 0 {
+			result += fmt.Sprintf("- **CVEs**: %s\n", strings.Join(b.CveIDs, ", "))
+		}
+		if b.ResourceTypeAffected != "" {
+			result += fmt.Sprintf("- **Affects**: %s\n", b.ResourceTypeAffected)
+		}
+		if len(b.AffectedSupportedMinors) > 0 {
+			result += fmt.Sprintf("- **Affected Minor Versions**: %s\n", strings.Join(b.AffectedSupportedMinors, ", "))
+		}
+		if len(b.PatchedVersions) > 0 {
+			result += fmt.Sprintf("- **Patched Versions**: %s\n", strings.Join(b.PatchedVersions, ", "))
+		}
+		if b.SuggestedUpgradeTarget != "" {
+			result += fmt.Sprintf("- **Suggested Upgrade Target**: %s\n", b.SuggestedUpgradeTarget)
+		}
+		if b.ManualStepsRequired {
+			result += "- **Manual steps required**: see the bulletin for remediation\n"
+		}
+		if b.BulletinURI != "" {
+			result += fmt.Sprintf("- **Bulletin**: %s\n", b.BulletinURI)
+		}
+		result += "\n"
+	}
+
+	if affectedCount > 0 {
+		result += "## Recommended Actions\n\n"
+		result += "1. Upgrade the affected components to a patched version, starting with the highest severity bulletins\n"
+		result += "2. Clusters on a release channel are auto-upgraded to patched versions; a forced upgrade during an incident may be this remediation rolling out\n"
+		result += "3. Follow any manual steps in the bulletin, as some mitigations aren't applied by upgrading alone\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
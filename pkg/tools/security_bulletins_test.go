@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParseGKEVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    gkeVersion
+		wantErr bool
+	}{
+		{version: "1.29.4-gke.1043002", want: gkeVersion{Major: 1, Minor: 29, Patch: 4, GKE: 1043002}},
+		{version: "v1.30.1", want: gkeVersion{Major: 1, Minor: 30, Patch: 1}},
+		{version: "1.28", want: gkeVersion{Major: 1, Minor: 28}},
+		{version: "1", wantErr: true},
+		{version: "1.29.x", wantErr: true},
+		{version: "1.29.4-gke.abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseGKEVersion(tt.version)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && got != tt.want) {
+			t.Errorf("parseGKEVersion(%q) = %+v, %v, want %+v (error %t)", tt.version, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestSecurityBulletinAffects(t *testing.T) {
+	bulletin := securityBulletin{
+		AffectedSupportedMinors: []string{"1.28", "1.29"},
+		PatchedVersions:         []string{"1.29.5-gke.100"},
+	}
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{version: "1.29.4-gke.1043002", want: true},
+		{version: "1.29.5-gke.100", want: false},
+		{version: "1.29.5-gke.200", want: false},
+		// No patch exists yet for 1.28
+		{version: "1.28.9-gke.100", want: true},
+		// 1.30 isn't an affected minor
+		{version: "1.30.0-gke.1", want: false},
+	}
+
+	for _, tt := range tests {
+		version, _ := parseGKEVersion(tt.version)
+		if got := bulletin.affects(version); got != tt.want {
+			t.Errorf("affects(%s) = %t, want %t", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestHandleGetGKESecurityBulletins(t *testing.T) {
+	bulletins := fakeLogging(t, func(filter string) string {
+		if !strings.Contains(filter, `resource.labels.cluster_name="prod"`) || !strings.Contains(filter, "jsonPayload.bulletinId:*") {
+			t.Errorf("unexpected filter %s", filter)
+		}
+		// Newest first, with an older notification for the same bulletin
+		return `[
+			{"timestamp": "2026-10-15T00:00:00Z", "jsonPayload": {"bulletinId": "GCP-2026-010", "severity": "LOW",
+			 "affectedSupportedMinors": ["1.29"], "patchedVersions": ["1.29.3-gke.1"]}},
+			{"timestamp": "2026-10-10T00:00:00Z", "jsonPayload": {"bulletinId": "GCP-2026-008", "severity": "HIGH", "cveIds": ["CVE-2026-1234"],
+			 "briefDescription": "A container escape in runc.", "affectedSupportedMinors": ["1.29"], "patchedVersions": ["1.29.5-gke.100"],
+			 "suggestedUpgradeTarget": "1.29.5-gke.100"}},
+			{"timestamp": "2026-10-05T00:00:00Z", "jsonPayload": {"bulletinId": "GCP-2026-007", "severity": "CRITICAL",
+			 "affectedSupportedMinors": ["1.28"], "patchedVersions": ["1.29.1-gke.1"], "manualStepsRequired": true}},
+			{"timestamp": "2026-10-01T00:00:00Z", "jsonPayload": {"bulletinId": "GCP-2026-008", "severity": "MEDIUM"}}
+		]`
+	})
+
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host == "container.googleapis.com" {
+			w.Write([]byte(`{"name": "prod", "currentMasterVersion": "1.29.4-gke.1043002", "currentNodeVersion": "1.28.9-gke.100"}`))
+			return
+		}
+		bulletins.ServeHTTP(w, r)
+	}))
+
+	text := callTool(t, ctx, handleGetGKESecurityBulletins, map[string]interface{}{
+		"project_id":   "test-project",
+		"location":     "us-central1",
+		"cluster_name": "prod",
+	})
+
+	if !strings.Contains(text, "Found 3 bulletins in the last 90 days, 2 affecting the current versions.") {
+		t.Errorf("result doesn't count the deduplicated bulletins:\n%s", text)
+	}
+
+	// Affecting bulletins come first, most severe first; the control plane and
+	// nodes are checked separately
+	var order []int
+	for _, heading := range []string{
+		"## **GCP-2026-007 (CRITICAL) - AFFECTS NODES**",
+		"## **GCP-2026-008 (HIGH) - AFFECTS CONTROL PLANE**",
+		"## GCP-2026-010 (LOW)\n",
+	} {
+		i := strings.Index(text, heading)
+		if i < 0 {
+			t.Errorf("result doesn't contain %q:\n%s", heading, text)
+		}
+		order = append(order, i)
+	}
+	if order[0] > order[1] || order[1] > order[2] {
+		t.Errorf("bulletins are out of order:\n%s", text)
+	}
+
+	for _, s := range []string{
+		"A container escape in runc.",
+		"- **CVEs**: CVE-2026-1234",
+		"- **Suggested Upgrade Target**: 1.29.5-gke.100",
+		"- **Manual steps required**",
+		"## Recommended Actions",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
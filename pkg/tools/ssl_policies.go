@@ -0,0 +1,312 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// defaultSSLPolicyName stands in for the policy used by target proxies that
+// don't set one. Google Cloud's default allows TLS 1.0 with the COMPATIBLE
+// profile.
+const defaultSSLPolicyName = "(Google Cloud default)"
+
+// registerSSLPolicyTools registers tools that report load balancer TLS
+// settings
+func registerSSLPolicyTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get SSL policy and TLS versions tool
+	getSSLPolicies := mcp.NewTool("get_ssl_policy_and_tls_versions",
+		mcp.WithDescription("Lists the project's SSL policies with their minimum TLS version and cipher profile, and which HTTPS and SSL target proxies use each, including proxies on the default policy. Flags policies permitting TLS 1.0 or 1.1, a security finding, and strict policies that explain older clients failing to connect."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+	)
+
+	getSSLPoliciesHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetSSLPolicyAndTLSVersions(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getSSLPolicies, getSSLPoliciesHandler)
+
+	return nil
+}
+
+// sslPolicy is the subset of a Compute Engine SslPolicy used by the tools
+type sslPolicy struct {
+	Name            string   `json:"name"`
+	SelfLink        string   `json:"selfLink"`
+	Region          string   `json:"region"`
+	Profile         string   `json:"profile"`
+	MinTLSVersion   string   `json:"minTlsVersion"`
+	EnabledFeatures []string `json:"enabledFeatures"`
+}
+
+// targetProxy is the subset of a Compute Engine target HTTPS or SSL proxy
+// used by the tools
+type targetProxy struct {
+	Name      string `json:"name"`
+	SelfLink  string `json:"selfLink"`
+	Region    string `json:"region"`
+	SSLPolicy string `json:"sslPolicy"`
+	// Kind is "HTTPS" or "SSL"
+	Kind string `json:"-"`
+}
+
+// weakTLS reports whether the policy accepts TLS 1.0 or 1.1. Policies that
+// don't set a minimum accept TLS 1.0.
+func (p sslPolicy) weakTLS() bool {
+	switch p.MinTLSVersion {
+	case "", "TLS_1_0", "TLS_1_1":
+		return true
+	default:
+		return false
+	}
+}
+
+// strict reports whether the policy may reject clients that only support
+// older protocol versions or ciphers
+func (p sslPolicy) strict() bool {
+	return p.MinTLSVersion == "TLS_1_3" || p.Profile == "RESTRICTED"
+}
+
+// minTLS returns the policy's minimum TLS version, defaulting to TLS 1.0
+func (p sslPolicy) minTLS() string {
+	if p.MinTLSVersion == "" {
+		return "TLS_1_0"
+	}
+	return p.MinTLSVersion
+}
+
+// profile returns the policy's profile, defaulting to COMPATIBLE
+func (p sslPolicy) profile() string {
+	if p.Profile == "" {
+		return "COMPATIBLE"
+	}
+	return p.Profile
+}
+
+// computeResourcePath strips the API prefix from a Compute Engine resource
+// URL, so references can be compared with self links
+func computeResourcePath(link string) string {
+	if i := strings.Index(link, "projects/"); i >= 0 {
+		return link[i:]
+	}
+	return link
+}
+
+// scopeName returns "global" or the region name of a resource
+func scopeName(region string) string {
+	if region == "" {
+		return "global"
+	}
+	return lastPathSegment(region)
+}
+
+// proxiesBySSLPolicy maps each SSL policy's resource path to the proxies
+// using it. Proxies without a policy are keyed by defaultSSLPolicyName.
+func proxiesBySSLPolicy(proxies []targetProxy) map[string][]targetProxy {
+	users := make(map[string][]targetProxy)
+	for _, proxy := range proxies {
+		key := defaultSSLPolicyName
+		if proxy.SSLPolicy != "" {
+			key = computeResourcePath(proxy.SSLPolicy)
+		}
+		users[key] = append(users[key], proxy)
+	}
+	return users
+}
+
+// fetchComputeList lists the items of a Compute Engine collection, following
+// pagination. Aggregated lists are flattened across scopes.
+func fetchComputeList[T any](ctx context.Context, client *http.Client, path, itemsKey string) ([]T, error) {
+	aggregated := strings.Contains(path, "/aggregated/")
+
+	var items []T
+	pageToken := ""
+	for {
+		apiURL := fmt.Sprintf("%s/%s?maxResults=500", gcpComputeBaseURL, path)
+		if pageToken != "" {
+			apiURL += "&pageToken=" + url.QueryEscape(pageToken)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+
+		resp, err := doWithRetry(client, req)
+		if err != nil {
+			return nil, fmt.Errorf("error making request to Compute Engine API: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("error from Compute Engine API: %s", resp.Status)
+		}
+
+		var response struct {
+			Items         json.RawMessage `json:"items"`
+			NextPageToken string          `json:"nextPageToken"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error parsing response: %w", err)
+		}
+
+		if len(response.Items) > 0 {
+			if aggregated {
+				var scoped map[string]map[string]json.RawMessage
+				if err := json.Unmarshal(response.Items, &scoped); err != nil {
+					return nil, fmt.Errorf("error parsing response: %w", err)
+				}
+				for _, scope := range scoped {
+					raw, ok := scope[itemsKey]
+					if !ok {
+						continue
+					}
+					var page []T
+					if err := json.Unmarshal(raw, &page); err != nil {
+						return nil, fmt.Errorf("error parsing response: %w", err)
+					}
+					items = append(items, page...)
+				}
+			} else {
+				var page []T
+				if err := json.Unmarshal(response.Items, &page); err != nil {
+					return nil, fmt.Errorf("error parsing response: %w", err)
+				}
+				items = append(items, page...)
+			}
+		}
+
+		pageToken = response.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+
+	return items, nil
+}
+
+// handleGetSSLPolicyAndTLSVersions handles the get_ssl_policy_and_tls_versions tool request
+func handleGetSSLPolicyAndTLSVersions(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	policies, err := fetchComputeList[sslPolicy](ctx, client, fmt.Sprintf("projects/%s/aggregated/sslPolicies", projectID), "sslPolicies")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing SSL policies: %v", err)), nil
+	}
+
+	httpsProxies, err := fetchComputeList[targetProxy](ctx, client, fmt.Sprintf("projects/%s/aggregated/targetHttpsProxies", projectID), "targetHttpsProxies")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing target HTTPS proxies: %v", err)), nil
+	}
+
+	// SSL proxies are global only
+	sslProxies, err := fetchComputeList[targetProxy](ctx, client, fmt.Sprintf("projects/%s/global/targetSslProxies", projectID), "items")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing target SSL proxies: %v", err)), nil
+	}
+
+	var proxies []targetProxy
+	for _, proxy := range httpsProxies {
+		proxy.Kind = "HTTPS"
+		proxies = append(proxies, proxy)
+	}
+	for _, proxy := range sslProxies {
+		proxy.Kind = "SSL"
+		proxies = append(proxies, proxy)
+	}
+	users := proxiesBySSLPolicy(proxies)
+
+	sort.SliceStable(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+
+	// Proxies without a policy use the default, which behaves like an unset
+	// policy
+	if len(users[defaultSSLPolicyName]) > 0 {
+		policies = append(policies, sslPolicy{Name: defaultSSLPolicyName, SelfLink: defaultSSLPolicyName})
+	}
+
+	// Format the results
+	if len(policies) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("Project %s has no SSL policies and no HTTPS or SSL target proxies.", projectID)), nil
+	}
+
+	result := fmt.Sprintf("# SSL Policies in Project %s\n\n", projectID)
+	result += "| Policy | Scope | Minimum TLS | Profile | Target Proxies | Status |\n"
+	result += "| ------ | ----- | ----------- | ------- | -------------- | ------ |\n"
+
+	var weak, strict []sslPolicy
+	for _, policy := range policies {
+		var names []string
+		for _, proxy := range users[computeResourcePath(policy.SelfLink)] {
+			names = append(names, fmt.Sprintf("%s (%s)", proxy.Name, proxy.Kind))
+		}
+
+		status := "OK"
+		switch {
+		case policy.weakTLS():
+			status = "**Allows TLS 1.0/1.1**"
+			weak = append(weak, policy)
+		case policy.strict():
+			status = "Strict"
+			strict = append(strict, policy)
+		}
+
+		inUse := "none"
+		if len(names) > 0 {
+			inUse = strings.Join(names, ", ")
+		}
+		result += fmt.Sprintf("| %s | %s | %s | %s | %s | %s |\n",
+			policy.Name, scopeName(policy.Region), policy.minTLS(), policy.profile(), inUse, status)
+	}
+	result += "\n"
+
+	if len(weak) > 0 {
+		result += "## Policies Allowing TLS 1.0 or 1.1\n\n"
+		for _, policy := range weak {
+			count := len(users[computeResourcePath(policy.SelfLink)])
+			result += fmt.Sprintf("- **%s**: accepts %s and above with the %s profile, used by %d target proxies\n", policy.Name, policy.minTLS(), policy.profile(), count)
+		}
+		result += "\nTLS 1.0 and 1.1 are deprecated and fail most compliance standards.\n\n"
+	}
+
+	if len(strict) > 0 {
+		result += "## Strict Policies\n\n"
+		for _, policy := range strict {
+			result += fmt.Sprintf("- **%s**: requires %s with the %s profile; clients without TLS 1.2 or modern ciphers fail the handshake before any request is logged\n", policy.Name, policy.minTLS(), policy.profile())
+		}
+		result += "\n"
+	}
+
+	if len(weak) > 0 || len(strict) > 0 {
+		result += "## Recommended Actions\n\n"
+		result += "1. Attach a policy with a TLS_1_2 minimum and the MODERN profile to proxies using weak policies or the default, checking first that no clients still need TLS 1.0 or 1.1\n"
+		result += "2. If older clients can't connect to a load balancer with a strict policy, relax it to TLS_1_2 with the MODERN or COMPATIBLE profile\n"
+		result += "3. Use a CUSTOM profile to enable only the ciphers your clients need when neither MODERN nor RESTRICTED fits\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
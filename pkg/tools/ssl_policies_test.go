@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSSLPolicyTLS(t *testing.T) {
+	tests := []struct {
+		policy     sslPolicy
+		wantWeak   bool
+		wantStrict bool
+	}{
+		{policy: sslPolicy{}, wantWeak: true},
+		{policy: sslPolicy{MinTLSVersion: "TLS_1_1", Profile: "MODERN"}, wantWeak: true},
+		{policy: sslPolicy{MinTLSVersion: "TLS_1_2", Profile: "MODERN"}},
+		{policy: sslPolicy{MinTLSVersion: "TLS_1_2", Profile: "RESTRICTED"}, wantStrict: true},
+		{policy: sslPolicy{MinTLSVersion: "TLS_1_3"}, wantStrict: true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.policy.weakTLS(); got != tt.wantWeak {
+			t.Errorf("weakTLS of %+v = %v, want %v", tt.policy, got, tt.wantWeak)
+		}
+		if got := tt.policy.strict(); got != tt.wantStrict {
+			t.Errorf("strict of %+v = %v, want %v", tt.policy, got, tt.wantStrict)
+		}
+	}
+}
+
+func TestHandleGetSSLPolicyAndTLSVersions(t *testing.T) {
+	const prefix = "https://www.googleapis.com/compute/v1/projects/test-project/"
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/compute/v1/projects/test-project/aggregated/sslPolicies":
+			w.Write([]byte(`{"items": {
+				"global": {"sslPolicies": [
+					{"name": "legacy-clients", "selfLink": "` + prefix + `global/sslPolicies/legacy-clients", "profile": "COMPATIBLE", "minTlsVersion": "TLS_1_0"},
+					{"name": "modern", "selfLink": "` + prefix + `global/sslPolicies/modern", "profile": "MODERN", "minTlsVersion": "TLS_1_2"}
+				]},
+				"regions/us-central1": {"warning": {"code": "NO_RESULTS_ON_PAGE"}}
+			}}`))
+		case "/compute/v1/projects/test-project/aggregated/targetHttpsProxies":
+			w.Write([]byte(`{"items": {
+				"global": {"targetHttpsProxies": [
+					{"name": "shop-https", "sslPolicy": "` + prefix + `global/sslPolicies/legacy-clients"},
+					{"name": "api-https", "sslPolicy": "` + prefix + `global/sslPolicies/modern"}
+				]}
+			}}`))
+		case "/compute/v1/projects/test-project/global/targetSslProxies":
+			w.Write([]byte(`{"items": [{"name": "db-ssl"}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	text := callTool(t, ctx, handleGetSSLPolicyAndTLSVersions, map[string]interface{}{"project_id": "test-project"})
+
+	// The SSL proxy without a policy is on the default, which also allows TLS 1.0
+	for _, s := range []string{
+		"| legacy-clients | global | TLS_1_0 | COMPATIBLE | shop-https (HTTPS) | **Allows TLS 1.0/1.1** |\n" +
+			"| modern | global | TLS_1_2 | MODERN | api-https (HTTPS) | OK |\n" +
+			"| (Google Cloud default) | global | TLS_1_0 | COMPATIBLE | db-ssl (SSL) | **Allows TLS 1.0/1.1** |\n",
+		"- **legacy-clients**: accepts TLS_1_0 and above with the COMPATIBLE profile, used by 1 target proxies\n",
+		"## Recommended Actions",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if strings.Contains(text, "## Strict Policies") {
+		t.Errorf("result reports strict policies:\n%s", text)
+	}
+}
@@ -0,0 +1,176 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Formats accepted by the output_format parameter
+const (
+	outputMarkdown = "markdown"
+	outputJSON     = "json"
+)
+
+// withOutputFormat adds the output_format parameter to tools that can return
+// their structured data as JSON instead of Markdown
+func withOutputFormat() mcp.ToolOption {
+	return mcp.WithString("output_format",
+		mcp.Description("Output format: \"markdown\" (default) for reading, or \"json\" for the structured data as indented JSON"),
+		mcp.Enum(outputMarkdown, outputJSON),
+	)
+}
+
+// outputFormat returns the output format requested by a tool call
+func outputFormat(request mcp.CallToolRequest) (string, error) {
+	format, _ := request.Params.Arguments["output_format"].(string)
+	switch format {
+	case "", outputMarkdown:
+		return outputMarkdown, nil
+	case outputJSON:
+		return outputJSON, nil
+	default:
+		return "", fmt.Errorf("output_format must be %q or %q, got %q", outputMarkdown, outputJSON, format)
+	}
+}
+
+// jsonResult returns data as indented JSON in a text result, for tool calls
+// that asked for output_format json
+func jsonResult(data interface{}) (*mcp.CallToolResult, error) {
+	encoded, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error encoding JSON output: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(encoded)), nil
+}
+
+// templateFuncs are the functions available to output templates
+var templateFuncs = template.FuncMap{
+	"add": func(a, b int) int { return a + b },
+	"json": func(v interface{}) (string, error) {
+		encoded, err := json.MarshalIndent(v, "", "  ")
+		return string(encoded), err
+	},
+}
+
+// defaultTemplates render each templated tool's output. They match the
+// output the tools have always produced, so behaviour is unchanged unless an
+// operator overrides them.
+var defaultTemplates = map[string]string{
+	"query_logs": `{{if not .Entries}}No logs found matching the filter criteria.{{else -}}
+Found {{len .Entries}} log entries matching the filter criteria:
+
+{{range $i, $e := .Entries}}### Log Entry {{add $i 1}}
+- **Timestamp**: {{$e.Timestamp}}
+- **Severity**: {{$e.Severity}}
+- **Log Name**: {{$e.LogName}}
+- **Resource Type**: {{$e.Resource.Type}}
+{{if $e.Resource.Labels}}- **Resource Labels**:
+{{range $k, $v := $e.Resource.Labels}}  - {{$k}}: {{$v}}
+{{end}}{{end -}}
+{{if $e.Labels}}- **Labels**:
+{{range $k, $v := $e.Labels}}  - {{$k}}: {{$v}}
+{{end}}{{end -}}
+- **Payload**:
+{{if $e.TextPayload}}` + "```" + `
+{{$e.TextPayload}}
+` + "```" + `
+{{else if $e.JsonPayload}}` + "```json" + `
+{{json $e.JsonPayload}}
+` + "```" + `
+{{else}}No payload
+{{end}}
+{{end}}{{if .HasMore}}Note: There are more log entries available. Refine your filter or increase max_results to see more.
+{{end}}{{end}}`,
+
+	"list_clusters": `{{if not .Clusters -}}
+No GKE clusters found in project {{.ProjectID}}{{if .Location}} in location {{.Location}}{{end}}{{if .Locations}} in locations {{range $i, $l := .Locations}}{{if $i}}, {{end}}{{$l}}{{end}}{{end}}{{if .LabelSelector}} matching labels {{.LabelSelector}}{{end}}.
+{{- else -}}
+Found {{len .Clusters}} GKE clusters in project {{.ProjectID}}{{if .Location}} in location {{.Location}}{{end}}{{if .Locations}} in locations {{range $i, $l := .Locations}}{{if $i}}, {{end}}{{$l}}{{end}}{{end}}{{if .LabelSelector}} matching labels {{.LabelSelector}}{{end}}:
+
+{{range $i, $c := .Clusters}}### {{add $i 1}}. Cluster: {{$c.Name}}
+- **Location**: {{$c.Location}}
+- **Status**: {{$c.Status}}
+- **Node Count**: {{$c.NodeCount}}
+- **Kubernetes Version**: {{$c.MasterVersion}} (master) / {{$c.NodeVersion}} (nodes)
+- **Endpoint**: {{$c.Endpoint}}
+- **Network**: {{$c.Network}}
+- **Subnetwork**: {{$c.Subnetwork}}
+- **Pod CIDR**: {{$c.ClusterIpv4Cidr}}
+- **Service CIDR**: {{$c.ServicesIpv4Cidr}}
+- **Created**: {{$c.CreateTime}}
+{{if $c.Description}}- **Description**: {{$c.Description}}
+{{end}}
+{{end}}{{end}}{{if .LocationErrors}}{{if not .Clusters}}
+
+{{end}}**Some locations couldn't be listed**, so clusters in them are missing:
+{{range .LocationErrors}}- **{{.Location}}**: {{.Error}}
+{{end}}{{end}}`,
+}
+
+// outputTemplates holds the parsed template for each templated tool,
+// including any operator overrides
+var outputTemplates = mustParseTemplates(defaultTemplates)
+
+// mustParseTemplates parses the built-in templates, which are known to be valid
+func mustParseTemplates(sources map[string]string) map[string]*template.Template {
+	parsed := make(map[string]*template.Template, len(sources))
+	for name, source := range sources {
+		parsed[name] = template.Must(template.New(name).Funcs(templateFuncs).Parse(source))
+	}
+	return parsed
+}
+
+// loadOutputTemplates applies template overrides from the directory named by
+// OPERABLE_TEMPLATES_DIR. Each file is named after the tool it renders, such
+// as query_logs.tmpl, and receives the same data as the default template.
+func loadOutputTemplates() error {
+	dir := os.Getenv("OPERABLE_TEMPLATES_DIR")
+	if dir == "" {
+		return nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return fmt.Errorf("error listing templates: %w", err)
+	}
+
+	for _, path := range files {
+		name := strings.TrimSuffix(filepath.Base(path), ".tmpl")
+		if _, ok := defaultTemplates[name]; !ok {
+			return fmt.Errorf("template %s doesn't match a templated tool, expected one of: query_logs, list_clusters", path)
+		}
+
+		source, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading template: %w", err)
+		}
+
+		tmpl, err := template.New(name).Funcs(templateFuncs).Parse(string(source))
+		if err != nil {
+			return fmt.Errorf("error parsing template %s: %w", path, err)
+		}
+		outputTemplates[name] = tmpl
+	}
+
+	return nil
+}
+
+// renderOutput renders a tool's data with its template
+func renderOutput(tool string, data interface{}) (string, error) {
+	tmpl, ok := outputTemplates[tool]
+	if !ok {
+		return "", fmt.Errorf("no output template for %s", tool)
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("error rendering output: %w", err)
+	}
+	return out.String(), nil
+}
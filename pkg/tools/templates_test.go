@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+)
+
+// useTemplates points OPERABLE_TEMPLATES_DIR at a directory holding files,
+// and restores the default templates when the test ends
+func useTemplates(t *testing.T, files map[string]string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o600); err != nil {
+			t.Fatalf("writing template: %v", err)
+		}
+	}
+	t.Setenv("OPERABLE_TEMPLATES_DIR", dir)
+	t.Cleanup(func() { outputTemplates = mustParseTemplates(defaultTemplates) })
+}
+
+// queryTestLogs calls query_logs against a Logging API returning two entries
+func queryTestLogs(t *testing.T) string {
+	t.Helper()
+
+	ctx := withGCPTransport(context.Background(), roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body := `{"entries": [
+			{"timestamp": "2026-10-17T01:00:00Z", "severity": "ERROR", "textPayload": "connection refused"},
+			{"timestamp": "2026-10-17T01:00:05Z", "severity": "WARNING", "textPayload": "retrying"}
+		]}`
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(body))}, nil
+	}))
+
+	result, err := handleQueryLogs(ctx, newToolRequest(map[string]interface{}{
+		"project_id": "test-project",
+		"filter":     "severity>=WARNING",
+	}), newTestAuthHandler(t, auth.ReadOnlyScopes), builtinLogPresets)
+	if err != nil {
+		t.Fatalf("handleQueryLogs returned error: %v", err)
+	}
+	text, _ := resultText(result)
+	if result.IsError {
+		t.Fatalf("handleQueryLogs returned tool error: %s", text)
+	}
+	return text
+}
+
+func TestQueryLogsDefaultTemplate(t *testing.T) {
+	text := queryTestLogs(t)
+	for _, s := range []string{"Found 2 log entries", "### Log Entry 1", "- **Severity**: ERROR", "connection refused"} {
+		if !strings.Contains(text, s) {
+			t.Errorf("default output doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
+
+func TestQueryLogsCustomTemplate(t *testing.T) {
+	useTemplates(t, map[string]string{
+		"query_logs.tmpl": `{{range .Entries}}{{.Timestamp}} [{{.Severity}}] {{.TextPayload}}
+{{end}}`,
+	})
+	if err := loadOutputTemplates(); err != nil {
+		t.Fatalf("loadOutputTemplates: %v", err)
+	}
+
+	want := "2026-10-17T01:00:00Z [ERROR] connection refused\n2026-10-17T01:00:05Z [WARNING] retrying\n"
+	if text := queryTestLogs(t); text != want {
+		t.Errorf("custom template output:\n%s\nwant:\n%s", text, want)
+	}
+
+	// Tools without an override keep their default template
+	out, err := renderOutput("list_clusters", listClustersView{ProjectID: "test-project"})
+	if err != nil {
+		t.Fatalf("renderOutput: %v", err)
+	}
+	if !strings.HasPrefix(out, "No GKE clusters found in project test-project") {
+		t.Errorf("list_clusters output changed: %q", out)
+	}
+}
+
+func TestLoadOutputTemplatesErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{name: "unknown tool", files: map[string]string{"list_buckets.tmpl": "{{.}}"}, wantErr: "doesn't match a templated tool"},
+		{name: "invalid template", files: map[string]string{"query_logs.tmpl": "{{range .Entries}"}, wantErr: "error parsing template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTemplates(t, tt.files)
+			err := loadOutputTemplates()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("loadOutputTemplates error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
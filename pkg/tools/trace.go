@@ -0,0 +1,652 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// GCP Cloud Trace API base URL
+	gcpTraceBaseURL = "https://cloudtrace.googleapis.com/v1"
+
+	// maxTracesAnalysed caps how many traces are fetched for analysis
+	maxTracesAnalysed = 500
+)
+
+// errTraceUnavailable is returned when the Cloud Trace API is disabled or the
+// caller can't read traces in the project
+var errTraceUnavailable = errors.New("the Cloud Trace API is not enabled or you don't have permission to read traces in this project")
+
+// registerTraceTools registers all Cloud Trace related tools
+func registerTraceTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get cross service error propagation tool
+	getErrorPropagation := mcp.NewTool("get_cross_service_error_propagation",
+		mcp.WithDescription("Analyses recent Cloud Trace traces containing errors (or exceeding a latency threshold) and reports the services and spans where failures originate, with the call path leading to them, to find which downstream dependency is the root cause"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("service",
+			mcp.Description("Only analyse traces that pass through this service"),
+		),
+		mcp.WithNumber("min_latency_ms",
+			mcp.Description("Analyse traces slower than this many milliseconds instead of HTTP 5xx traces; slow traces without errors are attributed to the span with the most time of its own"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range for traces in hours (default: 1)"),
+		),
+	)
+
+	getErrorPropagationHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetCrossServiceErrorPropagation(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getErrorPropagation, getErrorPropagationHandler)
+
+	// Register get service dependencies tool
+	getServiceDependencies := mcp.NewTool("get_service_dependencies",
+		mcp.WithDescription("Infers which services call a service (upstream callers) and which services it calls (downstream dependencies) from recent Cloud Trace spans, with call volumes and error counts, to scope an incident's blast radius"),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("service",
+			mcp.Required(),
+			mcp.Description("The service name as it appears in traces (e.g. its service.name, App Engine module or container name)"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range for traces in hours (default: 1)"),
+		),
+	)
+
+	getServiceDependenciesHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetServiceDependencies(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getServiceDependencies, getServiceDependenciesHandler)
+
+	return nil
+}
+
+// traceSpan is a span of a Cloud Trace v1 trace
+type traceSpan struct {
+	SpanID       string            `json:"spanId"`
+	ParentSpanID string            `json:"parentSpanId"`
+	Name         string            `json:"name"`
+	StartTime    string            `json:"startTime"`
+	EndTime      string            `json:"endTime"`
+	Labels       map[string]string `json:"labels"`
+}
+
+// duration returns how long the span took, or zero if its times are invalid
+func (s traceSpan) duration() time.Duration {
+	start, err := time.Parse(time.RFC3339Nano, s.StartTime)
+	if err != nil {
+		return 0
+	}
+	end, err := time.Parse(time.RFC3339Nano, s.EndTime)
+	if err != nil {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// traceServiceLabels are the span labels that name the service emitting a
+// span, in order of preference
+var traceServiceLabels = []string{"service.name", "g.co/gae/app/module", "g.co/r/k8s_container/container_name", "/component"}
+
+// service returns the name of the service that emitted the span
+func (s traceSpan) service() string {
+	for _, label := range traceServiceLabels {
+		if v := s.Labels[label]; v != "" {
+			return v
+		}
+	}
+	return "unknown"
+}
+
+// isError reports whether the span recorded a failure, either as an HTTP 5xx
+// status or through the error labels set by tracing libraries
+func (s traceSpan) isError() bool {
+	if code, err := strconv.Atoi(s.Labels["/http/status_code"]); err == nil && code >= 500 {
+		return true
+	}
+	if s.Labels["/error/message"] != "" || s.Labels["/error/name"] != "" || s.Labels["error"] == "true" {
+		return true
+	}
+	if code := s.Labels["g.co/status/code"]; code != "" && code != "0" {
+		return true
+	}
+	return false
+}
+
+// trace is a Cloud Trace v1 trace
+type trace struct {
+	TraceID string      `json:"traceId"`
+	Spans   []traceSpan `json:"spans"`
+}
+
+// fetchTraces lists complete traces in the time range matching the filter, up
+// to limit traces
+func fetchTraces(ctx context.Context, client *http.Client, projectID, filter string, startTime, endTime time.Time, limit int) ([]trace, error) {
+	var traces []trace
+	pageToken := ""
+	for len(traces) < limit {
+		params := url.Values{}
+		params.Set("view", "COMPLETE")
+		params.Set("startTime", startTime.Format(time.RFC3339))
+		params.Set("endTime", endTime.Format(time.RFC3339))
+		params.Set("pageSize", "100")
+		if filter != "" {
+			params.Set("filter", filter)
+		}
+		if pageToken != "" {
+			params.Set("pageToken", pageToken)
+		}
+
+		apiURL := fmt.Sprintf("%s/projects/%s/traces?%s", gcpTraceBaseURL, projectID, params.Encode())
+		req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+
+		resp, err := doWithRetry(client, req)
+		if err != nil {
+			return nil, fmt.Errorf("error making request to Cloud Trace API: %w", err)
+		}
+
+		if resp.StatusCode == http.StatusForbidden {
+			resp.Body.Close()
+			return nil, errTraceUnavailable
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("error from Cloud Trace API: %s", resp.Status)
+		}
+
+		var response struct {
+			Traces        []trace `json:"traces"`
+			NextPageToken string  `json:"nextPageToken"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error parsing response: %w", err)
+		}
+
+		traces = append(traces, response.Traces...)
+		pageToken = response.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+
+	if len(traces) > limit {
+		traces = traces[:limit]
+	}
+	return traces, nil
+}
+
+// traceOrigin is where a problem in a trace originated, with the call path
+// from the root span to it
+type traceOrigin struct {
+	Service string
+	Span    string
+	Error   bool
+	Path    []string
+}
+
+// findTraceOrigin returns where a trace's failure originated: the deepest
+// error span whose children all succeeded. Traces without errors are
+// attributed to the span with the most time of its own when slow is set.
+func findTraceOrigin(t trace, slow bool) (traceOrigin, bool) {
+	byID := make(map[string]traceSpan, len(t.Spans))
+	children := make(map[string][]traceSpan)
+	for _, span := range t.Spans {
+		byID[span.SpanID] = span
+		children[span.ParentSpanID] = append(children[span.ParentSpanID], span)
+	}
+
+	var origin *traceSpan
+	depthOf := func(span traceSpan) int {
+		depth := 0
+		for p, ok := byID[span.ParentSpanID]; ok && depth < len(t.Spans); p, ok = byID[p.ParentSpanID] {
+			depth++
+		}
+		return depth
+	}
+
+	bestDepth := -1
+	for i, span := range t.Spans {
+		if !span.isError() {
+			continue
+		}
+		childFailed := false
+		for _, child := range children[span.SpanID] {
+			if child.isError() {
+				childFailed = true
+				break
+			}
+		}
+		if childFailed {
+			continue
+		}
+		if depth := depthOf(span); depth > bestDepth {
+			bestDepth = depth
+			origin = &t.Spans[i]
+		}
+	}
+
+	isError := origin != nil
+	if origin == nil && slow {
+		// Attribute latency to the span with the most time not spent in children
+		var bestSelf time.Duration = -1
+		for i, span := range t.Spans {
+			self := span.duration()
+			for _, child := range children[span.SpanID] {
+				self -= child.duration()
+			}
+			if self > bestSelf {
+				bestSelf = self
+				origin = &t.Spans[i]
+			}
+		}
+	}
+
+	if origin == nil {
+		return traceOrigin{}, false
+	}
+
+	var path []string
+	seen := make(map[string]bool)
+	for span, ok := *origin, true; ok && !seen[span.SpanID]; span, ok = byID[span.ParentSpanID] {
+		seen[span.SpanID] = true
+		path = append([]string{fmt.Sprintf("%s (%s)", span.service(), span.Name)}, path...)
+	}
+
+	return traceOrigin{Service: origin.service(), Span: origin.Name, Error: isError, Path: path}, true
+}
+
+// traceOriginGroup counts traces that share an origin
+type traceOriginGroup struct {
+	traceOrigin
+	Count       int
+	SampleTrace string
+}
+
+// groupTraceOrigins finds the origin of each trace and groups traces by
+// origin, most common first
+func groupTraceOrigins(traces []trace, slow bool) []traceOriginGroup {
+	groups := make(map[string]*traceOriginGroup)
+	var order []string
+	for _, t := range traces {
+		origin, ok := findTraceOrigin(t, slow)
+		if !ok {
+			continue
+		}
+		key := fmt.Sprintf("%s|%s|%t|%s", origin.Service, origin.Span, origin.Error, strings.Join(origin.Path, ">"))
+		group, ok := groups[key]
+		if !ok {
+			group = &traceOriginGroup{traceOrigin: origin, SampleTrace: t.TraceID}
+			groups[key] = group
+			order = append(order, key)
+		}
+		group.Count++
+	}
+
+	result := make([]traceOriginGroup, 0, len(order))
+	for _, key := range order {
+		result = append(result, *groups[key])
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Count > result[j].Count
+	})
+
+	return result
+}
+
+// traceHasService reports whether any span of the trace belongs to service
+func traceHasService(t trace, service string) bool {
+	for _, span := range t.Spans {
+		if span.service() == service {
+			return true
+		}
+	}
+	return false
+}
+
+// handleGetCrossServiceErrorPropagation handles the get_cross_service_error_propagation tool request
+func handleGetCrossServiceErrorPropagation(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	service, _ := request.Params.Arguments["service"].(string)
+
+	// Get optional parameters with defaults
+	minLatencyMs := 0.0
+	if val, ok := request.Params.Arguments["min_latency_ms"].(float64); ok && val > 0 {
+		minLatencyMs = val
+	}
+
+	timeRangeHours := 1.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	endTime := time.Now()
+	startTime := endTime.Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+
+	// Cloud Trace filters can only match label value prefixes, so fetch traces
+	// with an HTTP 5xx span (or slow traces) and find errors client-side
+	filter := "/http/status_code:5"
+	if minLatencyMs > 0 {
+		filter = fmt.Sprintf("latency:%dms", int64(minLatencyMs))
+	}
+
+	traces, err := fetchTraces(ctx, client, projectID, filter, startTime, endTime, maxTracesAnalysed)
+	if errors.Is(err, errTraceUnavailable) {
+		return mcp.NewToolResultText(fmt.Sprintf("Trace data is unavailable for project %s: %v. Enable the Cloud Trace API and instrument services to use this tool.", projectID, err)), nil
+	}
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing traces: %v", err)), nil
+	}
+
+	if service != "" {
+		var matching []trace
+		for _, t := range traces {
+			if traceHasService(t, service) {
+				matching = append(matching, t)
+			}
+		}
+		traces = matching
+	}
+
+	scope := ""
+	if service != "" {
+		scope = fmt.Sprintf(" through service %s", service)
+	}
+
+	groups := groupTraceOrigins(traces, minLatencyMs > 0)
+	if len(groups) == 0 {
+		kind := "erroring"
+		if minLatencyMs > 0 {
+			kind = fmt.Sprintf("slower than %.0fms", minLatencyMs)
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("No %s traces%s found in project %s in the last %.1f hours. If services are instrumented, there may be no failures; otherwise no trace data is being collected.", kind, scope, projectID, timeRangeHours)), nil
+	}
+
+	analysed := 0
+	perService := make(map[string]int)
+	for _, group := range groups {
+		analysed += group.Count
+		perService[group.Service] += group.Count
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# Cross-Service Error Propagation in Project %s\n\n", projectID)
+	result += fmt.Sprintf("Analysed %d problem traces%s from the last %.1f hours.\n\n", analysed, scope, timeRangeHours)
+	if len(traces) >= maxTracesAnalysed {
+		result += fmt.Sprintf("Note: Only the first %d matching traces were analysed.\n\n", maxTracesAnalysed)
+	}
+
+	result += "## Originating Services\n\n"
+	services := make([]string, 0, len(perService))
+	for name := range perService {
+		services = append(services, name)
+	}
+	sort.Slice(services, func(i, j int) bool {
+		return perService[services[i]] > perService[services[j]]
+	})
+	for _, name := range services {
+		result += fmt.Sprintf("- **%s**: %d traces (%.0f%%)\n", name, perService[name], float64(perService[name])*100/float64(analysed))
+	}
+
+	result += "\n## Failure Origins\n\n"
+	for _, group := range groups {
+		kind := "error"
+		if !group.Error {
+			kind = "latency"
+		}
+		result += fmt.Sprintf("### %s: %s (%s, %d traces)\n\n", group.Service, group.Span, kind, group.Count)
+		result += fmt.Sprintf("- **Call Path**: %s\n", strings.Join(group.Path, " → "))
+		result += fmt.Sprintf("- **Sample Trace**: %s\n\n", group.SampleTrace)
+	}
+
+	result += "## Recommended Actions\n\n"
+	result += fmt.Sprintf("1. Start with %s, where most failures originate; services earlier in the call path are likely only propagating its errors\n", services[0])
+	result += "2. Open a sample trace in the Cloud Trace console to see the failing span's labels and timing in full\n"
+	result += "3. Check the originating service's logs and recent deployments around the time of the failures\n"
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// minDependencyTraces is the fewest traces through a service for its
+// dependencies to be considered representative
+const minDependencyTraces = 10
+
+// serviceEdge is a caller to callee relationship between two services
+type serviceEdge struct {
+	Caller string
+	Callee string
+	Calls  int
+	Errors int
+}
+
+// spanServices returns the service of each span in a trace. Spans without a
+// service label belong to the same service as their parent, as they are
+// usually internal spans of the same process.
+func spanServices(t trace) map[string]string {
+	byID := make(map[string]traceSpan, len(t.Spans))
+	for _, span := range t.Spans {
+		byID[span.SpanID] = span
+	}
+
+	services := make(map[string]string, len(t.Spans))
+	var resolve func(span traceSpan, depth int) string
+	resolve = func(span traceSpan, depth int) string {
+		if service, ok := services[span.SpanID]; ok {
+			return service
+		}
+		service := span.service()
+		if service == "unknown" && depth < len(t.Spans) {
+			if parent, ok := byID[span.ParentSpanID]; ok {
+				service = resolve(parent, depth+1)
+			}
+		}
+		services[span.SpanID] = service
+		return service
+	}
+
+	for _, span := range t.Spans {
+		resolve(span, 0)
+	}
+	return services
+}
+
+// serviceDependencies infers the edges into and out of service from the spans
+// of traces. A call is a span whose parent belongs to a different service.
+// Both lists are sorted by call volume, highest first.
+func serviceDependencies(traces []trace, service string) (upstream, downstream []serviceEdge) {
+	edges := make(map[[2]string]*serviceEdge)
+	for _, t := range traces {
+		services := spanServices(t)
+		byID := make(map[string]traceSpan, len(t.Spans))
+		for _, span := range t.Spans {
+			byID[span.SpanID] = span
+		}
+
+		for _, span := range t.Spans {
+			parent, ok := byID[span.ParentSpanID]
+			if !ok {
+				continue
+			}
+			caller, callee := services[parent.SpanID], services[span.SpanID]
+			if caller == callee || (caller != service && callee != service) {
+				continue
+			}
+
+			key := [2]string{caller, callee}
+			edge, ok := edges[key]
+			if !ok {
+				edge = &serviceEdge{Caller: caller, Callee: callee}
+				edges[key] = edge
+			}
+			edge.Calls++
+			if span.isError() {
+				edge.Errors++
+			}
+		}
+	}
+
+	for _, edge := range edges {
+		if edge.Callee == service {
+			upstream = append(upstream, *edge)
+		} else {
+			downstream = append(downstream, *edge)
+		}
+	}
+
+	byCalls := func(edges []serviceEdge, other func(serviceEdge) string) {
+		sort.Slice(edges, func(i, j int) bool {
+			if edges[i].Calls != edges[j].Calls {
+				return edges[i].Calls > edges[j].Calls
+			}
+			return other(edges[i]) < other(edges[j])
+		})
+	}
+	byCalls(upstream, func(e serviceEdge) string { return e.Caller })
+	byCalls(downstream, func(e serviceEdge) string { return e.Callee })
+
+	return upstream, downstream
+}
+
+// handleGetServiceDependencies handles the get_service_dependencies tool request
+func handleGetServiceDependencies(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	service, ok := request.Params.Arguments["service"].(string)
+	if !ok || service == "" {
+		return mcp.NewToolResultError("service must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	timeRangeHours := 1.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	endTime := time.Now()
+	startTime := endTime.Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+
+	// The service can be named by several labels, which Cloud Trace filters
+	// can't match together, so traces are filtered client-side
+	traces, err := fetchTraces(ctx, client, projectID, "", startTime, endTime, maxTracesAnalysed)
+	if errors.Is(err, errTraceUnavailable) {
+		return mcp.NewToolResultText(fmt.Sprintf("Trace data is unavailable for project %s: %v. Enable the Cloud Trace API and instrument services to use this tool.", projectID, err)), nil
+	}
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing traces: %v", err)), nil
+	}
+
+	var matching []trace
+	seen := make(map[string]bool)
+	for _, t := range traces {
+		found := false
+		for _, name := range spanServices(t) {
+			seen[name] = true
+			found = found || name == service
+		}
+		if found {
+			matching = append(matching, t)
+		}
+	}
+
+	// Format the results
+	if len(matching) == 0 {
+		result := fmt.Sprintf("No traces through service %s found in the %d traces sampled from project %s in the last %.1f hours.", service, len(traces), projectID, timeRangeHours)
+		if len(seen) > 0 {
+			names := make([]string, 0, len(seen))
+			for name := range seen {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			result += fmt.Sprintf("\n\nServices seen in traces: %s", strings.Join(names, ", "))
+		} else {
+			result += " No trace data is being collected, or the time range is too short."
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+
+	upstream, downstream := serviceDependencies(matching, service)
+
+	result := fmt.Sprintf("# Dependencies of %s\n\n", service)
+	result += fmt.Sprintf("Inferred from %d traces through %s (of %d sampled) in the last %.1f hours.\n\n", len(matching), service, len(traces), timeRangeHours)
+	if len(matching) < minDependencyTraces {
+		result += fmt.Sprintf("Note: With fewer than %d traces, rarely used dependencies may be missing and call volumes are rough.\n\n", minDependencyTraces)
+	}
+	if len(traces) >= maxTracesAnalysed {
+		result += fmt.Sprintf("Note: Only the first %d traces were sampled, so volumes are relative rather than totals.\n\n", maxTracesAnalysed)
+	}
+
+	result += "## Upstream Callers\n\n"
+	if len(upstream) == 0 {
+		result += fmt.Sprintf("No traced service calls %s; it is an entry point, or its callers aren't instrumented.\n\n", service)
+	} else {
+		result += "| Caller | Calls | Errors |\n"
+		result += "| ------ | ----- | ------ |\n"
+		for _, edge := range upstream {
+			result += fmt.Sprintf("| %s | %d | %d |\n", edge.Caller, edge.Calls, edge.Errors)
+		}
+		result += "\n"
+	}
+
+	result += "## Downstream Dependencies\n\n"
+	if len(downstream) == 0 {
+		result += fmt.Sprintf("%s makes no traced calls to other services.\n\n", service)
+	} else {
+		result += "| Callee | Calls | Errors |\n"
+		result += "| ------ | ----- | ------ |\n"
+		for _, edge := range downstream {
+			result += fmt.Sprintf("| %s | %d | %d |\n", edge.Callee, edge.Calls, edge.Errors)
+		}
+		result += "\n"
+	}
+
+	result += "## Recommended Actions\n\n"
+	result += fmt.Sprintf("1. If %s is failing, its upstream callers are the blast radius; check them for errors\n", service)
+	result += "2. Downstream dependencies with errors may be the cause rather than a symptom; trace failures to their origin with get_cross_service_error_propagation\n"
+	result += "3. Services that don't propagate trace context appear as missing edges, so treat this as a lower bound\n"
+
+	return mcp.NewToolResultText(result), nil
+}
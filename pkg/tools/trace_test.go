@@ -0,0 +1,171 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testErrorTrace is a checkout request that fails in the payments service;
+// the frontend and checkout services only propagate its error
+const testErrorTrace = `{"traceId": "trace-1", "spans": [
+	{"spanId": "1", "name": "GET /checkout", "labels": {"service.name": "frontend", "/http/status_code": "500"}},
+	{"spanId": "2", "parentSpanId": "1", "name": "POST /orders", "labels": {"service.name": "checkout", "/http/status_code": "502"}},
+	{"spanId": "3", "parentSpanId": "2", "name": "Charge", "labels": {"service.name": "payments", "/error/message": "card processor timeout"}},
+	{"spanId": "4", "parentSpanId": "3", "name": "SELECT cards", "labels": {"service.name": "payments-db"}},
+	{"spanId": "5", "parentSpanId": "2", "name": "Reserve", "labels": {"service.name": "inventory", "/http/status_code": "200"}}
+]}`
+
+func TestFindTraceOrigin(t *testing.T) {
+	tr := trace{Spans: []traceSpan{
+		{SpanID: "1", Name: "GET /checkout", Labels: map[string]string{"service.name": "frontend", "/http/status_code": "500"}},
+		{SpanID: "2", ParentSpanID: "1", Name: "Charge", Labels: map[string]string{"service.name": "payments", "error": "true"}},
+		{SpanID: "3", ParentSpanID: "1", Name: "Reserve", Labels: map[string]string{"service.name": "inventory"}},
+	}}
+
+	origin, ok := findTraceOrigin(tr, false)
+	want := traceOrigin{Service: "payments", Span: "Charge", Error: true, Path: []string{"frontend (GET /checkout)", "payments (Charge)"}}
+	if !ok || !reflect.DeepEqual(origin, want) {
+		t.Errorf("findTraceOrigin = %+v, %t, want %+v", origin, ok, want)
+	}
+
+	// A trace without errors has no origin unless latency is being analysed
+	healthy := trace{Spans: []traceSpan{
+		{SpanID: "1", Name: "GET /", StartTime: "2026-10-17T01:00:00Z", EndTime: "2026-10-17T01:00:02Z", Labels: map[string]string{"service.name": "frontend"}},
+		{SpanID: "2", ParentSpanID: "1", Name: "Query", StartTime: "2026-10-17T01:00:00Z", EndTime: "2026-10-17T01:00:01.5Z", Labels: map[string]string{"service.name": "db"}},
+	}}
+	if origin, ok := findTraceOrigin(healthy, false); ok {
+		t.Errorf("findTraceOrigin of a healthy trace = %+v, want none", origin)
+	}
+	if origin, _ := findTraceOrigin(healthy, true); origin.Service != "db" || origin.Error {
+		t.Errorf("findTraceOrigin of a slow trace = %+v, want latency in db", origin)
+	}
+}
+
+func TestHandleGetCrossServiceErrorPropagation(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host+r.URL.Path != "cloudtrace.googleapis.com/v1/projects/test-project/traces" {
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if filter := r.URL.Query().Get("filter"); filter != "/http/status_code:5" {
+			t.Errorf("filter = %q, want traces with 5xx spans", filter)
+		}
+		w.Write([]byte(`{"traces": [` + testErrorTrace + `]}`))
+	}))
+
+	text := callTool(t, ctx, handleGetCrossServiceErrorPropagation, map[string]interface{}{
+		"project_id": "test-project",
+		"service":    "checkout",
+	})
+
+	for _, s := range []string{
+		"Analysed 1 problem traces through service checkout",
+		"- **payments**: 1 traces (100%)\n",
+		"### payments: Charge (error, 1 traces)\n\n" +
+			"- **Call Path**: frontend (GET /checkout) → checkout (POST /orders) → payments (Charge)\n" +
+			"- **Sample Trace**: trace-1\n",
+		"1. Start with payments, where most failures originate",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
+
+func TestHandleGetCrossServiceErrorPropagationNoTraceData(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+
+	text := callTool(t, ctx, handleGetCrossServiceErrorPropagation, map[string]interface{}{"project_id": "test-project"})
+
+	if !strings.Contains(text, "Trace data is unavailable for project test-project") {
+		t.Errorf("result doesn't explain that trace data is unavailable:\n%s", text)
+	}
+}
+
+// testInternalSpanTrace is an order placed through the mobile API, where
+// checkout calls inventory from an internal span without a service label
+const testInternalSpanTrace = `{"traceId": "trace-2", "spans": [
+	{"spanId": "1", "name": "POST /v1/orders", "labels": {"service.name": "mobile-api"}},
+	{"spanId": "2", "parentSpanId": "1", "name": "POST /orders", "labels": {"service.name": "checkout"}},
+	{"spanId": "3", "parentSpanId": "2", "name": "reserveAll"},
+	{"spanId": "4", "parentSpanId": "3", "name": "Reserve", "labels": {"service.name": "inventory", "/http/status_code": "503"}}
+]}`
+
+func TestServiceDependencies(t *testing.T) {
+	traces := []trace{
+		decodeJSON[trace](t, testErrorTrace),
+		decodeJSON[trace](t, testInternalSpanTrace),
+		decodeJSON[trace](t, testErrorTrace),
+	}
+
+	upstream, downstream := serviceDependencies(traces, "checkout")
+
+	wantUpstream := []serviceEdge{
+		{Caller: "frontend", Callee: "checkout", Calls: 2, Errors: 2},
+		{Caller: "mobile-api", Callee: "checkout", Calls: 1},
+	}
+	if !reflect.DeepEqual(upstream, wantUpstream) {
+		t.Errorf("upstream = %+v, want %+v", upstream, wantUpstream)
+	}
+
+	// payments calling payments-db doesn't involve checkout, and the internal
+	// span is attributed to checkout rather than being a service of its own
+	wantDownstream := []serviceEdge{
+		{Caller: "checkout", Callee: "inventory", Calls: 3, Errors: 1},
+		{Caller: "checkout", Callee: "payments", Calls: 2, Errors: 2},
+	}
+	if !reflect.DeepEqual(downstream, wantDownstream) {
+		t.Errorf("downstream = %+v, want %+v", downstream, wantDownstream)
+	}
+}
+
+func TestHandleGetServiceDependencies(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host+r.URL.Path != "cloudtrace.googleapis.com/v1/projects/test-project/traces" {
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"traces": [` + testErrorTrace + `,` + testInternalSpanTrace + `,
+			{"traceId": "trace-3", "spans": [{"spanId": "1", "name": "GET /healthz", "labels": {"service.name": "frontend"}}]}
+		]}`))
+	}))
+
+	text := callTool(t, ctx, handleGetServiceDependencies, map[string]interface{}{
+		"project_id": "test-project",
+		"service":    "payments",
+	})
+
+	for _, s := range []string{
+		"Inferred from 1 traces through payments (of 3 sampled) in the last 1.0 hours.",
+		"Note: With fewer than 10 traces, rarely used dependencies may be missing",
+		"## Upstream Callers\n\n| Caller | Calls | Errors |\n| ------ | ----- | ------ |\n| checkout | 1 | 1 |\n",
+		"## Downstream Dependencies\n\n| Callee | Calls | Errors |\n| ------ | ----- | ------ |\n| payments-db | 1 | 0 |\n",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
+
+func TestHandleGetServiceDependenciesUnknownService(t *testing.T) {
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"traces": [` + testErrorTrace + `]}`))
+	}))
+
+	text := callTool(t, ctx, handleGetServiceDependencies, map[string]interface{}{
+		"project_id": "test-project",
+		"service":    "billing",
+	})
+
+	// The services that were seen help correct a misspelt name
+	if !strings.Contains(text, "Services seen in traces: checkout, frontend, inventory, payments, payments-db") {
+		t.Errorf("result doesn't list the services seen:\n%s", text)
+	}
+}
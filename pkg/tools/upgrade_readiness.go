@@ -0,0 +1,181 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+)
+
+// gkeTimeWindow is a maintenance exclusion window of a GKE cluster
+type gkeTimeWindow struct {
+	StartTime                   string `json:"startTime"`
+	EndTime                     string `json:"endTime"`
+	MaintenanceExclusionOptions struct {
+		Scope string `json:"scope"`
+	} `json:"maintenanceExclusionOptions"`
+}
+
+// maintenanceExclusion is a maintenance exclusion in effect
+type maintenanceExclusion struct {
+	Name  string
+	Start time.Time
+	End   time.Time
+	Scope string
+}
+
+// activeExclusions returns the exclusions in effect at now, ending soonest
+// first. Exclusions without a scope block all upgrades.
+func activeExclusions(exclusions map[string]gkeTimeWindow, now time.Time) []maintenanceExclusion {
+	var active []maintenanceExclusion
+	for name, window := range exclusions {
+		start, err := time.Parse(time.RFC3339, window.StartTime)
+		if err != nil {
+			continue
+		}
+		end, err := time.Parse(time.RFC3339, window.EndTime)
+		if err != nil {
+			continue
+		}
+		if now.Before(start) || !now.Before(end) {
+			continue
+		}
+
+		scope := window.MaintenanceExclusionOptions.Scope
+		if scope == "" {
+			scope = "NO_UPGRADES"
+		}
+		active = append(active, maintenanceExclusion{Name: name, Start: start, End: end, Scope: scope})
+	}
+
+	sort.SliceStable(active, func(i, j int) bool {
+		return active[i].End.Before(active[j].End)
+	})
+
+	return active
+}
+
+// noSurgeNodePools returns the node pools upgraded with surge upgrades that
+// add no surge nodes, so each node is drained before its replacement exists.
+// Pools that don't report upgrade settings use GKE's default surge of one.
+func noSurgeNodePools(pools []gkeNodePool) []string {
+	var names []string
+	for _, pool := range pools {
+		if pool.UpgradeSettings == nil || pool.UpgradeSettings.Strategy == "BLUE_GREEN" {
+			continue
+		}
+		if pool.UpgradeSettings.MaxSurge == 0 {
+			names = append(names, pool.Name)
+		}
+	}
+	return names
+}
+
+// upgradeReadiness collects the signals that can block or slow a cluster
+// upgrade
+type upgradeReadiness struct {
+	ActiveExclusions []maintenanceExclusion
+	NoSurgePools     []string
+	BlockingPDBs     []pdbStatus
+	// Errors are the checks that couldn't be made
+	Errors []string
+}
+
+// blocked reports whether an upgrade is blocked. Checks that failed are
+// treated as blocking, since the cluster can't be shown to be ready.
+func (r upgradeReadiness) blocked() bool {
+	return len(r.ActiveExclusions) > 0 || len(r.BlockingPDBs) > 0 || len(r.Errors) > 0
+}
+
+// checkUpgradeReadiness gathers a cluster's active maintenance exclusions,
+// node pools without surge and blocking PodDisruptionBudgets
+func checkUpgradeReadiness(ctx context.Context, authHandler *auth.OAuthHandler, projectID, location, clusterName string, exclusions map[string]gkeTimeWindow) upgradeReadiness {
+	readiness := upgradeReadiness{
+		ActiveExclusions: activeExclusions(exclusions, time.Now()),
+	}
+
+	client, err := authHandler.GetClient(ctx)
+	if err == nil {
+		var pools []gkeNodePool
+		pools, err = fetchNodePools(ctx, client, projectID, location, clusterName)
+		readiness.NoSurgePools = noSurgeNodePools(pools)
+	}
+	if err != nil {
+		readiness.Errors = append(readiness.Errors, fmt.Sprintf("node pools: %v", err))
+	}
+
+	kube, err := newKubeClientForCluster(ctx, authHandler, projectID, location, clusterName)
+	if err == nil {
+		var pdbs struct {
+			Items []kubePodDisruptionBudget `json:"items"`
+		}
+		err = kube.get(ctx, pdbsPath(""), nil, &pdbs)
+		for _, status := range evaluateDisruptionBudgets(pdbs.Items) {
+			if status.blocking() {
+				readiness.BlockingPDBs = append(readiness.BlockingPDBs, status)
+			}
+		}
+	}
+	if err != nil {
+		readiness.Errors = append(readiness.Errors, fmt.Sprintf("PodDisruptionBudgets: %v", err))
+	}
+
+	return readiness
+}
+
+// formatUpgradeReadiness formats the upgrade readiness section of
+// get_cluster_info
+func formatUpgradeReadiness(r upgradeReadiness) string {
+	result := "\n## Upgrade Readiness\n\n"
+	switch {
+	case r.blocked():
+		result += "**NO-GO**: an upgrade would be blocked or stall.\n\n"
+	case len(r.NoSurgePools) > 0:
+		result += "**GO**, with risks: nothing blocks an upgrade, but some node pools lose capacity while upgrading.\n\n"
+	default:
+		result += "**GO**: nothing blocks an upgrade.\n\n"
+	}
+
+	if len(r.ActiveExclusions) > 0 {
+		result += "### Active Maintenance Exclusions\n\n"
+		for _, exclusion := range r.ActiveExclusions {
+			result += fmt.Sprintf("- **%s** (%s) until %s\n", exclusion.Name, exclusion.Scope, exclusion.End.Format(time.RFC3339))
+		}
+		result += "\n"
+	}
+
+	if len(r.BlockingPDBs) > 0 {
+		result += "### Blocking PodDisruptionBudgets\n\n"
+		for _, status := range r.BlockingPDBs {
+			result += fmt.Sprintf("- **%s/%s** (%s): %s\n", status.Namespace, status.Name, status.Budget, status.reason())
+		}
+		result += "\n"
+	}
+
+	if len(r.NoSurgePools) > 0 {
+		result += "### Node Pools Without Surge\n\n"
+		for _, name := range r.NoSurgePools {
+			result += fmt.Sprintf("- **%s**: maxSurge is 0, so nodes are drained before replacements are created\n", name)
+		}
+		result += "\n"
+	}
+
+	if len(r.Errors) > 0 {
+		result += "### Checks That Failed\n\n"
+		for _, e := range r.Errors {
+			result += fmt.Sprintf("- %s\n", e)
+		}
+		result += "\n"
+	}
+
+	if r.blocked() || len(r.NoSurgePools) > 0 {
+		result += "### Recommended Actions\n\n"
+		result += "1. Active maintenance exclusions hold back automatic upgrades until they end; remove the exclusion or upgrade manually if the upgrade can't wait\n"
+		result += "2. Blocking PodDisruptionBudgets stall node drains for up to an hour per node; fix unhealthy pods or relax the budgets first, and check get_pod_disruption_budget_status\n"
+		result += "3. Set maxSurge to at least 1 on node pools without surge so workloads keep their capacity while nodes are replaced\n"
+	}
+
+	return result
+}
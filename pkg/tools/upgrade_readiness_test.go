@@ -0,0 +1,93 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestActiveExclusions(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	exclusions := decodeJSON[map[string]gkeTimeWindow](t, `{
+		"black-friday": {"startTime": "2026-10-10T00:00:00Z", "endTime": "2026-12-01T00:00:00Z", "maintenanceExclusionOptions": {"scope": "NO_MINOR_UPGRADES"}},
+		"freeze": {"startTime": "2026-10-16T00:00:00Z", "endTime": "2026-10-20T00:00:00Z"},
+		"past": {"startTime": "2026-09-01T00:00:00Z", "endTime": "2026-09-02T00:00:00Z"},
+		"future": {"startTime": "2026-11-01T00:00:00Z", "endTime": "2026-11-02T00:00:00Z"}
+	}`)
+
+	// Ending soonest first, with an unscoped exclusion blocking all upgrades
+	want := []maintenanceExclusion{
+		{Name: "freeze", Start: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), End: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC), Scope: "NO_UPGRADES"},
+		{Name: "black-friday", Start: time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC), End: time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC), Scope: "NO_MINOR_UPGRADES"},
+	}
+	if got := activeExclusions(exclusions, now); !reflect.DeepEqual(got, want) {
+		t.Errorf("activeExclusions = %+v, want %+v", got, want)
+	}
+}
+
+func TestNoSurgeNodePools(t *testing.T) {
+	pools := decodeJSON[[]gkeNodePool](t, `[
+		{"name": "default-pool"},
+		{"name": "no-surge", "upgradeSettings": {"maxSurge": 0, "maxUnavailable": 1}},
+		{"name": "surge", "upgradeSettings": {"maxSurge": 1}},
+		{"name": "blue-green", "upgradeSettings": {"strategy": "BLUE_GREEN"}}
+	]`)
+
+	if got := noSurgeNodePools(pools); !reflect.DeepEqual(got, []string{"no-surge"}) {
+		t.Errorf("noSurgeNodePools = %v, want [no-surge]", got)
+	}
+}
+
+func TestHandleGetClusterInfoUpgradeReadiness(t *testing.T) {
+	clusterName := newFakeCluster(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/policy/v1/poddisruptionbudgets" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"items": [
+			{"metadata": {"namespace": "db", "name": "postgres"}, "spec": {"minAvailable": 3},
+			 "status": {"currentHealthy": 3, "desiredHealthy": 3, "expectedPods": 3, "disruptionsAllowed": 0}},
+			{"metadata": {"namespace": "web", "name": "frontend"}, "spec": {"maxUnavailable": 1},
+			 "status": {"currentHealthy": 4, "desiredHealthy": 3, "expectedPods": 4, "disruptionsAllowed": 1}}
+		]}`))
+	}))
+
+	// Add a maintenance exclusion that is in effect to the cached cluster
+	cacheKey := clusterCacheKey("test-project", "us-central1", clusterName)
+	cluster, _, _ := clusterCache.Get(cacheKey)
+	end := time.Now().Add(72 * time.Hour).UTC().Truncate(time.Second)
+	cluster.MaintenancePolicy.Window.MaintenanceExclusions = map[string]gkeTimeWindow{
+		"release-freeze": {StartTime: time.Now().Add(-time.Hour).UTC().Format(time.RFC3339), EndTime: end.Format(time.RFC3339)},
+	}
+	clusterCache.Set(cacheKey, cluster)
+
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/test-project/locations/us-central1/clusters/"+clusterName+"/nodePools" {
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"nodePools": [
+			{"name": "default-pool", "upgradeSettings": {"maxSurge": 1}},
+			{"name": "gpu-pool", "upgradeSettings": {"maxSurge": 0, "maxUnavailable": 1}}
+		]}`))
+	}))
+
+	text := callClusterTool(t, ctx, handleGetClusterInfo, clusterName, map[string]interface{}{"upgrade_readiness": true})
+
+	for _, s := range []string{
+		"**NO-GO**: an upgrade would be blocked or stall.",
+		"- **release-freeze** (NO_UPGRADES) until " + end.Format(time.RFC3339) + "\n",
+		"### Blocking PodDisruptionBudgets\n\n- **db/postgres** (minAvailable: 3): the budget requires every matching pod to stay up",
+		"- **gpu-pool**: maxSurge is 0",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if strings.Contains(text, "web/frontend") || strings.Contains(text, "Checks That Failed") {
+		t.Errorf("result includes a non-blocking budget or a failed check:\n%s", text)
+	}
+}
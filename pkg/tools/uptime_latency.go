@@ -0,0 +1,229 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerUptimeLatencyTools registers tools that report uptime check latency
+func registerUptimeLatencyTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get endpoint latency from uptime checks tool
+	getUptimeLatency := mcp.NewTool("get_endpoint_latency_from_uptime_checks",
+		mcp.WithDescription("Reports an uptime check's request latency per checker region, with p50 and p95 over the time range, flagging regions whose latency regressed in the most recent part of the range. Use it for slow-but-up incidents that pass/fail uptime results don't show."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("check_id",
+			mcp.Required(),
+			mcp.Description("The uptime check ID, the last segment of its resource name"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range to analyse in hours (default: 6)"),
+		),
+	)
+
+	getUptimeLatencyHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetEndpointLatencyFromUptimeChecks(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getUptimeLatency, getUptimeLatencyHandler)
+
+	return nil
+}
+
+const (
+	// uptimeRecentFraction is the trailing fraction of the time range compared
+	// against the rest of it
+	uptimeRecentFraction = 0.25
+	// uptimeRegressionRatio is how many times the baseline p95 the recent p95
+	// must reach to be flagged as a regression
+	uptimeRegressionRatio = 1.5
+	// uptimeRegressionMinIncrease is the smallest rise in p95, in
+	// milliseconds, flagged as a regression, so jitter on fast checks isn't
+	// flagged
+	uptimeRegressionMinIncrease = 50.0
+)
+
+// uptimeRegionLatency is an uptime check's latency from one checker region,
+// in milliseconds
+type uptimeRegionLatency struct {
+	Region string
+	// All holds every sample in the time range, Baseline and Recent split
+	// them before and after the start of the recent period
+	All      []float64
+	Baseline []float64
+	Recent   []float64
+}
+
+// percentile returns the p-th percentile (0-100) of values using the nearest
+// rank method, or 0 when there are none
+func percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+// regressed reports whether the region's recent p95 rose by at least the
+// regression ratio and minimum increase over its baseline
+func (l uptimeRegionLatency) regressed() bool {
+	if len(l.Baseline) == 0 || len(l.Recent) == 0 {
+		return false
+	}
+	baseline, recent := percentile(l.Baseline, 95), percentile(l.Recent, 95)
+	return recent >= baseline*uptimeRegressionRatio && recent-baseline >= uptimeRegressionMinIncrease
+}
+
+// uptimeLatencyByRegion groups request_latency points by checker region,
+// splitting them at recentStart. Regions are sorted by name.
+func uptimeLatencyByRegion(series []timeSeries, recentStart time.Time) []uptimeRegionLatency {
+	regions := make(map[string]*uptimeRegionLatency)
+	for _, ts := range series {
+		name := ts.Metric.Labels["checker_location"]
+		region, ok := regions[name]
+		if !ok {
+			region = &uptimeRegionLatency{Region: name}
+			regions[name] = region
+		}
+		for _, point := range ts.Points {
+			t, err := time.Parse(time.RFC3339, point.Interval.EndTime)
+			if err != nil {
+				continue
+			}
+			v := point.value()
+			region.All = append(region.All, v)
+			if t.Before(recentStart) {
+				region.Baseline = append(region.Baseline, v)
+			} else {
+				region.Recent = append(region.Recent, v)
+			}
+		}
+	}
+
+	latencies := make([]uptimeRegionLatency, 0, len(regions))
+	for _, region := range regions {
+		if len(region.All) > 0 {
+			latencies = append(latencies, *region)
+		}
+	}
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i].Region < latencies[j].Region
+	})
+	return latencies
+}
+
+// handleGetEndpointLatencyFromUptimeChecks handles the get_endpoint_latency_from_uptime_checks tool request
+func handleGetEndpointLatencyFromUptimeChecks(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	checkID, ok := request.Params.Arguments["check_id"].(string)
+	if !ok || checkID == "" {
+		return mcp.NewToolResultError("check_id must be a non-empty string"), nil
+	}
+	// Accept the full resource name as well as the ID
+	checkID = lastPathSegment(checkID)
+
+	// Get optional parameters with defaults
+	timeRangeHours := 6.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	// Calculate time range
+	endTime := time.Now()
+	window := time.Duration(timeRangeHours * float64(time.Hour))
+	startTime := endTime.Add(-window)
+	recentStart := endTime.Add(-time.Duration(float64(window) * uptimeRecentFraction))
+
+	// Raw samples are kept so percentiles are taken over individual checks
+	series, err := fetchTimeSeries(ctx, client, projectID, timeSeriesQuery{
+		Filter:    fmt.Sprintf(`metric.type="monitoring.googleapis.com/uptime_check/request_latency" AND metric.labels.check_id="%s"`, checkID),
+		StartTime: startTime,
+		EndTime:   endTime,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying uptime check latency: %v", err)), nil
+	}
+
+	latencies := uptimeLatencyByRegion(series, recentStart)
+	if len(latencies) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No latency samples were recorded for uptime check %s in the last %.1f hours. Check the check ID and that the check is enabled.", checkID, timeRangeHours)), nil
+	}
+
+	var regressed []uptimeRegionLatency
+	for _, latency := range latencies {
+		if latency.regressed() {
+			regressed = append(regressed, latency)
+		}
+	}
+
+	// Format the results
+	result := fmt.Sprintf("# Uptime Check Latency for %s\n\n", checkID)
+	result += fmt.Sprintf("Latency per checker region over the last %.1f hours, comparing the most recent %s with the earlier baseline.\n\n", timeRangeHours, endTime.Sub(recentStart).Round(time.Minute))
+
+	if len(regressed) > 0 {
+		result += "**Latency regressed in:**\n\n"
+		for _, latency := range regressed {
+			result += fmt.Sprintf("- **%s**: p95 rose from %.0f ms to %.0f ms\n", latency.Region, percentile(latency.Baseline, 95), percentile(latency.Recent, 95))
+		}
+		result += "\n"
+	}
+
+	result += "| Region | Samples | p50 | p95 | Baseline p95 | Recent p95 | Status |\n"
+	result += "| ------ | ------- | --- | --- | ------------ | ---------- | ------ |\n"
+	for _, latency := range latencies {
+		baseline, recent := "N/A", "N/A"
+		if len(latency.Baseline) > 0 {
+			baseline = fmt.Sprintf("%.0f ms", percentile(latency.Baseline, 95))
+		}
+		if len(latency.Recent) > 0 {
+			recent = fmt.Sprintf("%.0f ms", percentile(latency.Recent, 95))
+		}
+		status := "OK"
+		if latency.regressed() {
+			status = "**Regressed**"
+		}
+		result += fmt.Sprintf("| %s | %d | %.0f ms | %.0f ms | %s | %s | %s |\n",
+			valueOrDash(latency.Region), len(latency.All), percentile(latency.All, 50), percentile(latency.All, 95), baseline, recent, status)
+	}
+
+	if len(regressed) > 0 {
+		result += "\n## Recommended Actions\n\n"
+		if len(regressed) == len(latencies) {
+			result += "1. Latency rose from every region, which points at the endpoint itself; check its backends' latency, load and recent deployments\n"
+		} else {
+			result += "1. Latency rose from only some regions, which points at the network path or the load balancer serving those regions; compare with the unaffected regions\n"
+		}
+		result += "2. Use get_load_balancer_5xx_breakdown or the backend's request latency metrics to see whether real users are affected too\n"
+		result += "3. Check for a recent deployment, configuration change or dependency slowdown that lines up with when latency rose\n"
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
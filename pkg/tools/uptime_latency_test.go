@@ -0,0 +1,95 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	values := []float64{50, 10, 40, 20, 30}
+	for _, tt := range []struct {
+		p    float64
+		want float64
+	}{{p: 50, want: 30}, {p: 95, want: 50}, {p: 0, want: 10}} {
+		if got := percentile(values, tt.p); got != tt.want {
+			t.Errorf("percentile(%v, %.0f) = %.0f, want %.0f", values, tt.p, got, tt.want)
+		}
+	}
+	if got := percentile(nil, 95); got != 0 {
+		t.Errorf("percentile of no values = %.0f, want 0", got)
+	}
+}
+
+// latencySeries is a request_latency series from a checker region with a
+// sample of each latency, one every ten minutes ending at end
+func latencySeries(region string, end time.Time, latencies ...float64) timeSeries {
+	var ts timeSeries
+	ts.Metric.Labels = map[string]string{"checker_location": region, "check_id": "shop-home"}
+	for i, latency := range latencies {
+		var point timeSeriesPoint
+		point.Value.DoubleValue = &latency
+		point.Interval.EndTime = end.Add(-time.Duration(len(latencies)-1-i) * 10 * time.Minute).Format(time.RFC3339)
+		ts.Points = append(ts.Points, point)
+	}
+	return ts
+}
+
+func TestUptimeRegionLatencyRegressed(t *testing.T) {
+	end := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	recentStart := end.Add(-25 * time.Minute)
+
+	latencies := uptimeLatencyByRegion([]timeSeries{
+		latencySeries("usa-oregon", end, 100, 110, 105, 400, 420, 390),
+		// Doubled, but too fast for the rise to matter
+		latencySeries("europe-belgium", end, 10, 12, 11, 25, 24, 26),
+	}, recentStart)
+
+	if len(latencies) != 2 || latencies[0].Region != "europe-belgium" || len(latencies[1].Recent) != 3 {
+		t.Fatalf("uptimeLatencyByRegion = %+v, want two regions by name with three recent samples", latencies)
+	}
+	if latencies[0].regressed() {
+		t.Error("europe-belgium regressed, want a small rise ignored")
+	}
+	if !latencies[1].regressed() {
+		t.Error("usa-oregon didn't regress, want its p95 rise flagged")
+	}
+}
+
+func TestHandleGetEndpointLatencyFromUptimeChecks(t *testing.T) {
+	end := time.Now().UTC().Truncate(time.Second)
+
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/projects/test-project/timeSeries" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		if filter := r.URL.Query().Get("filter"); !strings.Contains(filter, `metric.labels.check_id="shop-home"`) {
+			t.Errorf("filter doesn't match the check ID: %s", filter)
+		}
+		// Samples over the last hour, so the last two are in its recent
+		// quarter
+		writeJSON(w, http.StatusOK, map[string]interface{}{"timeSeries": []timeSeries{
+			latencySeries("usa-oregon", end, 120, 130, 125, 120, 310, 330),
+			latencySeries("europe-belgium", end, 140, 150, 145, 140, 150, 145),
+		}})
+	}))
+
+	text := callTool(t, ctx, handleGetEndpointLatencyFromUptimeChecks, map[string]interface{}{
+		"project_id":       "test-project",
+		"check_id":         "projects/test-project/uptimeCheckConfigs/shop-home",
+		"time_range_hours": 1.0,
+	})
+
+	for _, s := range []string{
+		"**Latency regressed in:**\n\n- **usa-oregon**: p95 rose from 130 ms to 330 ms\n",
+		"| europe-belgium | 6 | 145 ms | 150 ms | 150 ms | 150 ms | OK |\n" +
+			"| usa-oregon | 6 | 125 ms | 330 ms | 130 ms | 330 ms | **Regressed** |\n",
+		"1. Latency rose from only some regions, which points at the network path",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+}
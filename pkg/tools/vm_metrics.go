@@ -0,0 +1,397 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// vmMetricBuckets is the number of aligned points requested per metric,
+	// so long windows aren't returned at one-minute resolution
+	vmMetricBuckets = 60
+
+	// highCPUUtilisation is the CPU utilisation, as a fraction, above which an
+	// instance is flagged
+	highCPUUtilisation = 0.9
+
+	// highUsedPercent is the memory or disk space usage, as a percentage,
+	// above which an instance is flagged
+	highUsedPercent = 90.0
+)
+
+// registerVMMetricsTools registers tools that report Compute Engine instance
+// health metrics
+func registerVMMetricsTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get VM metrics tool
+	getVMMetrics := mcp.NewTool("get_vm_metrics",
+		mcp.WithDescription("Reports a Compute Engine instance's CPU utilisation, memory usage, disk space usage and disk throughput over a time window, with current, average and peak values. Flags high CPU, high memory, full disks and throttled disk operations. Memory and disk space come from the Ops Agent, and are reported as unavailable when the agent isn't installed."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("zone",
+			mcp.Required(),
+			mcp.Description("The zone of the instance"),
+		),
+		mcp.WithString("instance_name",
+			mcp.Required(),
+			mcp.Description("The name of the instance"),
+		),
+		mcp.WithNumber("time_range_hours",
+			mcp.Description("Time range to report in hours (default: 1)"),
+		),
+	)
+
+	getVMMetricsHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetVMMetrics(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getVMMetrics, getVMMetricsHandler)
+
+	return nil
+}
+
+// fetchComputeInstanceID returns the numeric ID of an instance, which
+// instance metrics are labelled with
+func fetchComputeInstanceID(ctx context.Context, client *http.Client, projectID, zone, name string) (string, error) {
+	apiURL := fmt.Sprintf("%s/projects/%s/zones/%s/instances/%s", gcpComputeBaseURL, projectID, url.PathEscape(zone), url.PathEscape(name))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := doWithRetry(client, req)
+	if err != nil {
+		return "", fmt.Errorf("error making request to Compute API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("instance %s was not found in zone %s", name, zone)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error from Compute API: %s", resp.Status)
+	}
+
+	var instance struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&instance); err != nil {
+		return "", fmt.Errorf("error parsing response: %w", err)
+	}
+
+	return instance.ID, nil
+}
+
+// vmMetricSummary summarises the points of a metric over the window
+type vmMetricSummary struct {
+	Current float64
+	Average float64
+	Peak    float64
+	HasData bool
+}
+
+// summariseVMMetric summarises the points of a single aligned time series.
+// The Monitoring API returns points newest first.
+func summariseVMMetric(ts timeSeries) vmMetricSummary {
+	var summary vmMetricSummary
+	if len(ts.Points) == 0 {
+		return summary
+	}
+
+	summary.HasData = true
+	summary.Current = ts.Points[0].value()
+	summary.Peak = summary.Current
+	total := 0.0
+	for _, point := range ts.Points {
+		v := point.value()
+		total += v
+		if v > summary.Peak {
+			summary.Peak = v
+		}
+	}
+	summary.Average = total / float64(len(ts.Points))
+
+	return summary
+}
+
+// add adds other to the summary. Peaks of different metrics may not
+// coincide, so the combined peak is an upper bound.
+func (s *vmMetricSummary) add(other vmMetricSummary) {
+	if !other.HasData {
+		return
+	}
+	s.HasData = true
+	s.Current += other.Current
+	s.Average += other.Average
+	s.Peak += other.Peak
+}
+
+// firstVMMetric summarises the first series with points, for queries that
+// reduce to a single series
+func firstVMMetric(series []timeSeries) vmMetricSummary {
+	for _, ts := range series {
+		if summary := summariseVMMetric(ts); summary.HasData {
+			return summary
+		}
+	}
+	return vmMetricSummary{}
+}
+
+// diskSpaceUsage is the space used on one of an instance's disks
+type diskSpaceUsage struct {
+	Device string
+	vmMetricSummary
+}
+
+// summariseDiskSpace summarises Ops Agent disk usage series by device,
+// fullest first
+func summariseDiskSpace(series []timeSeries) []diskSpaceUsage {
+	var usage []diskSpaceUsage
+	for _, ts := range series {
+		summary := summariseVMMetric(ts)
+		if !summary.HasData {
+			continue
+		}
+		usage = append(usage, diskSpaceUsage{Device: valueOrDash(ts.Metric.Labels["device"]), vmMetricSummary: summary})
+	}
+
+	sort.SliceStable(usage, func(i, j int) bool {
+		return usage[i].Current > usage[j].Current
+	})
+
+	return usage
+}
+
+// vmMetricsReport is the metrics of an instance over the window
+type vmMetricsReport struct {
+	CPU vmMetricSummary
+	// Memory is the percentage of memory used, from the Ops Agent
+	Memory    vmMetricSummary
+	DiskSpace []diskSpaceUsage
+	// Disk throughput is summed across the instance's disks, per second
+	ReadBytes      vmMetricSummary
+	WriteBytes     vmMetricSummary
+	ReadOps        vmMetricSummary
+	WriteOps       vmMetricSummary
+	ThrottledBytes vmMetricSummary
+	ThrottledOps   vmMetricSummary
+}
+
+// hasAgentMetrics reports whether the Ops Agent reported any metrics, which
+// memory and disk space usage depend on
+func (r vmMetricsReport) hasAgentMetrics() bool {
+	return r.Memory.HasData || len(r.DiskSpace) > 0
+}
+
+// findings returns the problems the metrics show
+func (r vmMetricsReport) findings() []string {
+	var findings []string
+	if r.CPU.HasData && r.CPU.Current >= highCPUUtilisation {
+		findings = append(findings, fmt.Sprintf("CPU utilisation is %.1f%%", r.CPU.Current*100))
+	} else if r.CPU.HasData && r.CPU.Peak >= highCPUUtilisation {
+		findings = append(findings, fmt.Sprintf("CPU utilisation peaked at %.1f%% in the window", r.CPU.Peak*100))
+	}
+	if r.Memory.HasData && r.Memory.Current >= highUsedPercent {
+		findings = append(findings, fmt.Sprintf("Memory usage is %.1f%%", r.Memory.Current))
+	}
+	for _, disk := range r.DiskSpace {
+		if disk.Current >= highUsedPercent {
+			findings = append(findings, fmt.Sprintf("Disk %s is %.1f%% full", disk.Device, disk.Current))
+		}
+	}
+	if r.ThrottledOps.Peak > 0 || r.ThrottledBytes.Peak > 0 {
+		findings = append(findings, "Disk operations were throttled, so the instance hit its persistent disk I/O limits")
+	}
+	return findings
+}
+
+// vmMetricRow formats a metric summary as a table row, with format applied
+// to each value
+func vmMetricRow(name string, summary vmMetricSummary, format func(float64) string) string {
+	if !summary.HasData {
+		return fmt.Sprintf("| %s | N/A | N/A | N/A |\n", name)
+	}
+	return fmt.Sprintf("| %s | %s | %s | %s |\n", name, format(summary.Current), format(summary.Average), format(summary.Peak))
+}
+
+// formatByteRate formats a rate in bytes per second
+func formatByteRate(v float64) string {
+	switch {
+	case v >= 1<<30:
+		return fmt.Sprintf("%.1f GiB/s", v/(1<<30))
+	case v >= 1<<20:
+		return fmt.Sprintf("%.1f MiB/s", v/(1<<20))
+	case v >= 1<<10:
+		return fmt.Sprintf("%.1f KiB/s", v/(1<<10))
+	default:
+		return fmt.Sprintf("%.0f B/s", v)
+	}
+}
+
+// handleGetVMMetrics handles the get_vm_metrics tool request
+func handleGetVMMetrics(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	zone, ok := request.Params.Arguments["zone"].(string)
+	if !ok || zone == "" {
+		return mcp.NewToolResultError("zone must be a non-empty string"), nil
+	}
+
+	instanceName, ok := request.Params.Arguments["instance_name"].(string)
+	if !ok || instanceName == "" {
+		return mcp.NewToolResultError("instance_name must be a non-empty string"), nil
+	}
+
+	// Get optional parameters with defaults
+	timeRangeHours := 1.0
+	if val, ok := request.Params.Arguments["time_range_hours"].(float64); ok && val > 0 {
+		timeRangeHours = val
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	// Agent metrics aren't labelled with the instance name, so match every
+	// metric on the instance ID
+	instanceID, err := fetchComputeInstanceID(ctx, client, projectID, zone, instanceName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting instance: %v", err)), nil
+	}
+
+	// Calculate time range, split into equal buckets of at least a minute
+	endTime := time.Now()
+	startTime := endTime.Add(-time.Duration(timeRangeHours * float64(time.Hour)))
+	period := endTime.Sub(startTime) / vmMetricBuckets
+	if period < time.Minute {
+		period = time.Minute
+	}
+
+	query := func(metricType, extraFilter, aligner, reducer string) ([]timeSeries, error) {
+		filter := fmt.Sprintf(`metric.type="%s" AND resource.type="gce_instance" AND resource.labels.instance_id="%s"`, metricType, instanceID)
+		if extraFilter != "" {
+			filter += " AND " + extraFilter
+		}
+		return fetchTimeSeries(ctx, client, projectID, timeSeriesQuery{
+			Filter:             filter,
+			StartTime:          startTime,
+			EndTime:            endTime,
+			AlignmentPeriod:    period,
+			PerSeriesAligner:   aligner,
+			CrossSeriesReducer: reducer,
+		})
+	}
+
+	var report vmMetricsReport
+
+	series, err := query("compute.googleapis.com/instance/cpu/utilization", "", "ALIGN_MEAN", "")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying CPU utilisation: %v", err)), nil
+	}
+	report.CPU = firstVMMetric(series)
+
+	series, err = query("agent.googleapis.com/memory/percent_used", `metric.labels.state="used"`, "ALIGN_MEAN", "")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying memory usage: %v", err)), nil
+	}
+	report.Memory = firstVMMetric(series)
+
+	series, err = query("agent.googleapis.com/disk/percent_used", `metric.labels.state="used"`, "ALIGN_MEAN", "")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error querying disk space usage: %v", err)), nil
+	}
+	report.DiskSpace = summariseDiskSpace(series)
+
+	// Disk counters are per device, so sum their rates across the instance.
+	// Throttled reads and writes count towards the same limits, so they are
+	// added together.
+	rates := []struct {
+		metricTypes []string
+		summary     *vmMetricSummary
+	}{
+		{[]string{"compute.googleapis.com/instance/disk/read_bytes_count"}, &report.ReadBytes},
+		{[]string{"compute.googleapis.com/instance/disk/write_bytes_count"}, &report.WriteBytes},
+		{[]string{"compute.googleapis.com/instance/disk/read_ops_count"}, &report.ReadOps},
+		{[]string{"compute.googleapis.com/instance/disk/write_ops_count"}, &report.WriteOps},
+		{[]string{"compute.googleapis.com/instance/disk/throttled_read_bytes_count", "compute.googleapis.com/instance/disk/throttled_write_bytes_count"}, &report.ThrottledBytes},
+		{[]string{"compute.googleapis.com/instance/disk/throttled_read_ops_count", "compute.googleapis.com/instance/disk/throttled_write_ops_count"}, &report.ThrottledOps},
+	}
+	for _, rate := range rates {
+		for _, metricType := range rate.metricTypes {
+			series, err := query(metricType, "", "ALIGN_RATE", "REDUCE_SUM")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Error querying %s: %v", metricType, err)), nil
+			}
+			rate.summary.add(firstVMMetric(series))
+		}
+	}
+
+	// Format the results
+	percent := func(v float64) string { return fmt.Sprintf("%.1f%%", v) }
+	perSecond := func(v float64) string { return fmt.Sprintf("%.1f/s", v) }
+
+	result := fmt.Sprintf("# Metrics for Instance %s\n\n", instanceName)
+	result += fmt.Sprintf("Zone %s, over the last %.1f hours at %s resolution.\n\n", zone, timeRangeHours, period)
+
+	if !report.CPU.HasData {
+		result += "No CPU metrics were reported in the window. The instance may have been stopped.\n\n"
+	}
+
+	findings := report.findings()
+	if len(findings) > 0 {
+		result += "## Findings\n\n"
+		for _, finding := range findings {
+			result += fmt.Sprintf("- **%s**\n", finding)
+		}
+		result += "\n"
+	}
+
+	result += "## Usage\n\n"
+	result += "| Metric | Current | Average | Peak |\n"
+	result += "| ------ | ------- | ------- | ---- |\n"
+	result += vmMetricRow("CPU utilisation", report.CPU, func(v float64) string { return percent(v * 100) })
+	if report.Memory.HasData {
+		result += vmMetricRow("Memory used", report.Memory, percent)
+	}
+	for _, disk := range report.DiskSpace {
+		result += vmMetricRow(fmt.Sprintf("Disk %s used", disk.Device), disk.vmMetricSummary, percent)
+	}
+	result += vmMetricRow("Disk read throughput", report.ReadBytes, formatByteRate)
+	result += vmMetricRow("Disk write throughput", report.WriteBytes, formatByteRate)
+	result += vmMetricRow("Disk read operations", report.ReadOps, perSecond)
+	result += vmMetricRow("Disk write operations", report.WriteOps, perSecond)
+	result += vmMetricRow("Throttled disk throughput", report.ThrottledBytes, formatByteRate)
+	result += vmMetricRow("Throttled disk operations", report.ThrottledOps, perSecond)
+	result += "\n"
+
+	if !report.hasAgentMetrics() {
+		result += "Memory and disk space usage aren't available because the Ops Agent isn't reporting from this instance. Compute Engine can't see inside the guest, so install the Ops Agent to collect them.\n\n"
+	} else if !report.Memory.HasData {
+		result += "Memory usage isn't available; check the Ops Agent's metrics configuration on this instance.\n\n"
+	}
+
+	result += "## Recommended Actions\n\n"
+	result += "1. Sustained high CPU means the instance needs a larger machine type, or its workload should be spread across a managed instance group\n"
+	result += "2. High memory usage risks the guest's OOM killer; check the instance's serial port output or system logs with query_logs\n"
+	result += "3. Full disks cause write failures; clean up logs and temporary files or resize the disk\n"
+	result += "4. Throttled disk operations mean the disk's IOPS or throughput limit was reached; use a larger disk, a faster disk type or more vCPUs, as limits scale with each\n"
+
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,93 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// vmSeries is an instance metric series with values newest first, labelled
+// with device if it is set
+func vmSeries(device string, values ...float64) timeSeries {
+	var ts timeSeries
+	if device != "" {
+		ts.Metric.Labels = map[string]string{"device": device}
+	}
+	for _, v := range values {
+		var point timeSeriesPoint
+		point.Value.DoubleValue = &v
+		ts.Points = append(ts.Points, point)
+	}
+	return ts
+}
+
+func TestVMMetricsReportFindings(t *testing.T) {
+	report := vmMetricsReport{
+		CPU:       summariseVMMetric(vmSeries("", 0.4, 0.95, 0.5)),
+		Memory:    summariseVMMetric(vmSeries("", 93, 80)),
+		DiskSpace: summariseDiskSpace([]timeSeries{vmSeries("sda1", 40), vmSeries("sdb", 97.5), vmSeries("sdc")}),
+	}
+	report.ThrottledOps.add(summariseVMMetric(vmSeries("", 0, 12)))
+
+	if report.DiskSpace[0].Device != "sdb" || len(report.DiskSpace) != 2 {
+		t.Errorf("disk space = %+v, want sdb first and sdc without data left out", report.DiskSpace)
+	}
+
+	want := []string{
+		"CPU utilisation peaked at 95.0% in the window",
+		"Memory usage is 93.0%",
+		"Disk sdb is 97.5% full",
+		"Disk operations were throttled, so the instance hit its persistent disk I/O limits",
+	}
+	if got := report.findings(); !reflect.DeepEqual(got, want) {
+		t.Errorf("findings = %q, want %q", got, want)
+	}
+}
+
+func TestHandleGetVMMetrics(t *testing.T) {
+	series := map[string][]timeSeries{
+		"compute.googleapis.com/instance/cpu/utilization":       {vmSeries("", 0.95, 0.5, 0.4)},
+		"compute.googleapis.com/instance/disk/read_bytes_count": {vmSeries("", 2<<20, 1<<20)},
+	}
+
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Host + r.URL.Path {
+		case "compute.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a/instances/batch-1":
+			w.Write([]byte(`{"id": "1234", "name": "batch-1"}`))
+		case "monitoring.googleapis.com/v3/projects/test-project/timeSeries":
+			filter := r.URL.Query().Get("filter")
+			if !strings.Contains(filter, `resource.labels.instance_id="1234"`) {
+				t.Errorf("filter doesn't match the instance ID: %s", filter)
+			}
+			// No Ops Agent, so agent metrics have no series
+			metricType, _, _ := strings.Cut(strings.TrimPrefix(filter, `metric.type="`), `"`)
+			writeJSON(w, http.StatusOK, map[string]interface{}{"timeSeries": series[metricType]})
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	text := callTool(t, ctx, handleGetVMMetrics, map[string]interface{}{
+		"project_id":    "test-project",
+		"zone":          "us-central1-a",
+		"instance_name": "batch-1",
+	})
+
+	for _, s := range []string{
+		"## Findings\n\n- **CPU utilisation is 95.0%**\n\n",
+		"| CPU utilisation | 95.0% | 61.7% | 95.0% |\n",
+		"| Disk read throughput | 2.0 MiB/s | 1.5 MiB/s | 2.0 MiB/s |\n",
+		"| Disk write throughput | N/A | N/A | N/A |\n",
+		"Memory and disk space usage aren't available because the Ops Agent isn't reporting from this instance.",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if strings.Contains(text, "| Memory used |") {
+		t.Errorf("result includes memory usage without the Ops Agent:\n%s", text)
+	}
+}
@@ -0,0 +1,87 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+)
+
+// ClusterRef identifies a GKE cluster
+type ClusterRef struct {
+	ProjectID string
+	Location  string
+	Name      string
+}
+
+func (r ClusterRef) String() string {
+	return clusterCacheKey(r.ProjectID, r.Location, r.Name)
+}
+
+// ParseClusterRefs parses a comma-separated list of clusters in the form
+// project/location/cluster
+func ParseClusterRefs(s string) ([]ClusterRef, error) {
+	var refs []ClusterRef
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(item, "/")
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("invalid cluster %q: expected project/location/cluster", item)
+		}
+		refs = append(refs, ClusterRef{ProjectID: parts[0], Location: parts[1], Name: parts[2]})
+	}
+	return refs, nil
+}
+
+// WarmClusterCache fetches the metadata of the given clusters, and of every
+// cluster in the given projects, into the cluster cache so the first tool call
+// against them doesn't pay for the lookup. It is meant to run in the
+// background at startup: failures are logged and otherwise ignored.
+func WarmClusterCache(ctx context.Context, authHandler *auth.OAuthHandler, clusters []ClusterRef, projectIDs []string) {
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		log.Printf("cluster cache warmup: error getting authenticated client: %v", err)
+		return
+	}
+	warmClusterCache(ctx, client, clusters, projectIDs)
+}
+
+// warmClusterCache caches the metadata of clusters, and of every cluster in
+// projectIDs, using client
+func warmClusterCache(ctx context.Context, client *http.Client, clusters []ClusterRef, projectIDs []string) {
+	for _, projectID := range projectIDs {
+		summaries, err := fetchClusters(ctx, client, projectID, "")
+		if err != nil {
+			log.Printf("cluster cache warmup: error listing clusters in %s: %v", projectID, err)
+			continue
+		}
+		for _, summary := range summaries {
+			clusters = append(clusters, ClusterRef{ProjectID: projectID, Location: summary.Location, Name: summary.Name})
+		}
+	}
+
+	var warmed atomic.Int32
+	var wg sync.WaitGroup
+	for _, ref := range clusters {
+		wg.Add(1)
+		go func(ref ClusterRef) {
+			defer wg.Done()
+			if _, err := cachedCluster(ctx, client, ref.ProjectID, ref.Location, ref.Name); err != nil {
+				log.Printf("cluster cache warmup: error fetching %s: %v", ref, err)
+				return
+			}
+			warmed.Add(1)
+		}(ref)
+	}
+	wg.Wait()
+
+	log.Printf("cluster cache warmup: warmed %d of %d cluster(s)", warmed.Load(), len(clusters))
+}
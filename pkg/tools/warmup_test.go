@@ -0,0 +1,107 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParseClusterRefs(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    []ClusterRef
+		wantErr bool
+	}{
+		{s: "", want: nil},
+		{s: "prod/us-central1/web", want: []ClusterRef{{ProjectID: "prod", Location: "us-central1", Name: "web"}}},
+		{s: " prod/us-central1/web , staging/europe-west1-b/api,", want: []ClusterRef{
+			{ProjectID: "prod", Location: "us-central1", Name: "web"},
+			{ProjectID: "staging", Location: "europe-west1-b", Name: "api"},
+		}},
+		{s: "prod/web", wantErr: true},
+		{s: "prod//web", wantErr: true},
+		{s: "prod/us-central1/web/extra", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseClusterRefs(tt.s)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseClusterRefs(%q) error = %v, want error %t", tt.s, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseClusterRefs(%q) = %+v, want %+v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestWarmClusterCache(t *testing.T) {
+	clusters := []ClusterRef{
+		{ProjectID: "warm-project", Location: "us-central1", Name: "web"},
+		{ProjectID: "warm-project", Location: "europe-west1", Name: "api"},
+		{ProjectID: "other-project", Location: "us-east1", Name: "batch"},
+		{ProjectID: "other-project", Location: "us-east1", Name: "deleted"},
+	}
+	t.Cleanup(func() {
+		for _, ref := range clusters {
+			clusterCache.Invalidate(ref.String())
+		}
+	})
+
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		requests[req.URL.Path]++
+		mu.Unlock()
+
+		var body interface{}
+		switch path := strings.TrimPrefix(req.URL.Path, "/v1/projects/"); path {
+		case "warm-project/locations/-/clusters":
+			body = map[string]interface{}{"clusters": []map[string]string{
+				{"name": "web", "location": "us-central1"},
+				{"name": "api", "location": "europe-west1"},
+			}}
+		case "warm-project/locations/us-central1/clusters/web",
+			"warm-project/locations/europe-west1/clusters/api",
+			"other-project/locations/us-east1/clusters/batch":
+			body = map[string]string{"name": path[strings.LastIndex(path, "/")+1:], "status": "RUNNING"}
+		default:
+			return &http.Response{StatusCode: http.StatusNotFound, Status: "404 Not Found", Body: io.NopCloser(strings.NewReader("{}"))}, nil
+		}
+		encoded, _ := json.Marshal(body)
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(string(encoded)))}, nil
+	})}
+
+	// Clusters in warm-project are found by listing the project, and the
+	// others are named explicitly
+	warmClusterCache(context.Background(), client, clusters[2:], []string{"warm-project"})
+
+	for _, ref := range clusters[:3] {
+		cluster, _, ok := clusterCache.Get(ref.String())
+		if !ok || cluster.Name != ref.Name || cluster.Status != "RUNNING" {
+			t.Errorf("%s cached as %+v, %t, want the fetched cluster", ref, cluster, ok)
+		}
+	}
+
+	// A cluster that can't be fetched is skipped without affecting the rest
+	if _, _, ok := clusterCache.Get(clusters[3].String()); ok {
+		t.Errorf("%s was cached although it couldn't be fetched", clusters[3])
+	}
+
+	// Tool calls against a warmed cluster are served from the cache, so each
+	// path is only ever requested once
+	if _, err := cachedCluster(context.Background(), client, "warm-project", "us-central1", "web"); err != nil {
+		t.Fatalf("cachedCluster: %v", err)
+	}
+	for path, n := range requests {
+		if n != 1 {
+			t.Errorf("%s requested %d times, want 1", path, n)
+		}
+	}
+}
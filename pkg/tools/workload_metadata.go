@@ -0,0 +1,196 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ivanvanderbyl/operable/pkg/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerWorkloadMetadataTools registers tools that report how pods reach
+// the metadata server
+func registerWorkloadMetadataTools(s *server.MCPServer, authHandler *auth.OAuthHandler) error {
+	// Register get GKE workload metadata config tool
+	getWorkloadMetadataConfig := mcp.NewTool("get_gke_workload_metadata_config",
+		mcp.WithDescription("Reports each node pool's workload metadata mode, flagging pools that expose the node's Compute Engine metadata server to pods. Exposed metadata lets pods read the node's service account credentials and bootstrap configuration, and stops Workload Identity working for pods on those nodes."),
+		mcp.WithString("project_id",
+			mcp.Required(),
+			mcp.Description("The Google Cloud project ID"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("The GKE cluster location"),
+		),
+		mcp.WithString("cluster_name",
+			mcp.Required(),
+			mcp.Description("The GKE cluster name"),
+		),
+	)
+
+	getWorkloadMetadataConfigHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetGKEWorkloadMetadataConfig(ctx, request, authHandler)
+	}
+
+	AddToolSafe(s, getWorkloadMetadataConfig, getWorkloadMetadataConfigHandler)
+
+	return nil
+}
+
+// poolMetadataConfig is how a node pool's pods reach the metadata server
+type poolMetadataConfig struct {
+	Name string
+	// Mode is the effective workload metadata mode: GKE_METADATA when pods
+	// are served by the GKE metadata server, GCE_METADATA when they reach the
+	// node's Compute Engine metadata server
+	Mode string
+	// Configured is false when the pool doesn't set a mode
+	Configured bool
+	// LegacyEndpoints is true when the pool re-enables the deprecated v0.1
+	// and v1beta1 metadata endpoints
+	LegacyEndpoints bool
+}
+
+// exposed reports whether pods on the pool can reach the node's metadata
+func (c poolMetadataConfig) exposed() bool {
+	return c.Mode != "GKE_METADATA" || c.LegacyEndpoints
+}
+
+// evaluateWorkloadMetadata returns the metadata configuration of each node
+// pool. Pools that don't set a mode, or set MODE_UNSPECIFIED, expose the
+// node's metadata like GCE_METADATA.
+func evaluateWorkloadMetadata(pools []gkeNodePool) []poolMetadataConfig {
+	configs := make([]poolMetadataConfig, 0, len(pools))
+	for _, pool := range pools {
+		config := poolMetadataConfig{
+			Name:            pool.Name,
+			Mode:            "GCE_METADATA",
+			LegacyEndpoints: pool.Config.Metadata["disable-legacy-endpoints"] == "false",
+		}
+		if wmc := pool.Config.WorkloadMetadataConfig; wmc != nil && wmc.Mode != "" && wmc.Mode != "MODE_UNSPECIFIED" {
+			config.Mode = wmc.Mode
+			config.Configured = true
+		}
+		configs = append(configs, config)
+	}
+	return configs
+}
+
+// handleGetGKEWorkloadMetadataConfig handles the get_gke_workload_metadata_config tool request
+func handleGetGKEWorkloadMetadataConfig(ctx context.Context, request mcp.CallToolRequest, authHandler *auth.OAuthHandler) (*mcp.CallToolResult, error) {
+	// Extract parameters
+	projectID, ok := request.Params.Arguments["project_id"].(string)
+	if !ok || projectID == "" {
+		return mcp.NewToolResultError("project_id must be a non-empty string"), nil
+	}
+
+	location, ok := request.Params.Arguments["location"].(string)
+	if !ok || location == "" {
+		return mcp.NewToolResultError("location must be a non-empty string"), nil
+	}
+
+	clusterName, ok := request.Params.Arguments["cluster_name"].(string)
+	if !ok || clusterName == "" {
+		return mcp.NewToolResultError("cluster_name must be a non-empty string"), nil
+	}
+
+	// Get HTTP client with authentication
+	client, err := authHandler.GetClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting authenticated client: %v", err)), nil
+	}
+
+	cluster, err := fetchCluster(ctx, client, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting cluster: %v", err)), nil
+	}
+
+	// Autopilot always serves pods from the GKE metadata server
+	if cluster.Autopilot.Enabled {
+		return mcp.NewToolResultText(fmt.Sprintf("Cluster %s is an Autopilot cluster, so every node uses the GKE metadata server and Workload Identity is always enabled.", clusterName)), nil
+	}
+
+	pools, err := fetchNodePools(ctx, client, projectID, location, clusterName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing node pools: %v", err)), nil
+	}
+
+	configs := evaluateWorkloadMetadata(pools)
+	workloadPool := cluster.WorkloadIdentityConfig.WorkloadPool
+
+	// Format the results
+	result := fmt.Sprintf("# Workload Metadata Config for Cluster %s\n\n", clusterName)
+	if workloadPool != "" {
+		result += fmt.Sprintf("- **Workload Identity**: enabled (workload pool %s)\n\n", workloadPool)
+	} else {
+		result += "- **Workload Identity**: not enabled\n\n"
+	}
+
+	if len(configs) == 0 {
+		result += "The cluster has no node pools.\n"
+		return mcp.NewToolResultText(result), nil
+	}
+
+	var exposed []poolMetadataConfig
+	result += "| Node Pool | Mode | Legacy Endpoints | Status |\n"
+	result += "| --------- | ---- | ---------------- | ------ |\n"
+	for _, config := range configs {
+		mode := config.Mode
+		if !config.Configured {
+			mode += " (default)"
+		}
+		legacy := "disabled"
+		if config.LegacyEndpoints {
+			legacy = "**enabled**"
+		}
+		status := "OK"
+		if config.exposed() {
+			status = "**Metadata exposed**"
+			exposed = append(exposed, config)
+		}
+		result += fmt.Sprintf("| %s | %s | %s | %s |\n", config.Name, mode, legacy, status)
+	}
+	result += "\n"
+
+	if len(exposed) == 0 {
+		result += "Every node pool serves pods from the GKE metadata server, so pods can't read node credentials.\n"
+		return mcp.NewToolResultText(result), nil
+	}
+
+	result += "## Findings\n\n"
+	for _, config := range exposed {
+		if config.Mode != "GKE_METADATA" {
+			result += fmt.Sprintf("- **%s**: pods can read the node's service account token and kube-env bootstrap credentials from the Compute Engine metadata server", config.Name)
+			if workloadPool != "" {
+				result += ", and Workload Identity doesn't work for pods on these nodes; they authenticate as the node's service account instead"
+			}
+			result += "\n"
+		}
+		if config.LegacyEndpoints {
+			result += fmt.Sprintf("- **%s**: the deprecated v0.1 and v1beta1 metadata endpoints are enabled, which don't require the Metadata-Flavor header and are easier to reach through request forgery\n", config.Name)
+		}
+	}
+	result += "\n"
+
+	var actions []string
+	if workloadPool == "" {
+		actions = append(actions, "Enable Workload Identity on the cluster, then set the GKE_METADATA mode on each node pool")
+	} else {
+		actions = append(actions, "Set the GKE_METADATA mode on each flagged node pool with gcloud container node-pools update --workload-metadata=GKE_METADATA; nodes are recreated, so plan for the disruption")
+	}
+	actions = append(actions, "Before switching, check that workloads on the pool have a Kubernetes service account bound to an IAM service account, as they lose the node's credentials")
+	for _, config := range exposed {
+		if config.LegacyEndpoints {
+			actions = append(actions, "Remove the disable-legacy-endpoints=false node metadata so the legacy endpoints are disabled")
+			break
+		}
+	}
+
+	result += "## Recommended Actions\n\n"
+	for i, action := range actions {
+		result += fmt.Sprintf("%d. %s\n", i+1, action)
+	}
+
+	return mcp.NewToolResultText(result), nil
+}
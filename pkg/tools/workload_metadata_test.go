@@ -0,0 +1,76 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testMetadataPools are node pools with differing workload metadata configs
+const testMetadataPools = `[
+	{"name": "secure", "config": {"workloadMetadataConfig": {"mode": "GKE_METADATA"}}},
+	{"name": "legacy", "config": {"workloadMetadataConfig": {"mode": "GKE_METADATA"}, "metadata": {"disable-legacy-endpoints": "false"}}},
+	{"name": "exposed", "config": {"workloadMetadataConfig": {"mode": "GCE_METADATA"}}},
+	{"name": "unset", "config": {"workloadMetadataConfig": {"mode": "MODE_UNSPECIFIED"}}}
+]`
+
+func TestEvaluateWorkloadMetadata(t *testing.T) {
+	configs := evaluateWorkloadMetadata(decodeJSON[[]gkeNodePool](t, testMetadataPools))
+
+	want := []poolMetadataConfig{
+		{Name: "secure", Mode: "GKE_METADATA", Configured: true},
+		{Name: "legacy", Mode: "GKE_METADATA", Configured: true, LegacyEndpoints: true},
+		{Name: "exposed", Mode: "GCE_METADATA", Configured: true},
+		{Name: "unset", Mode: "GCE_METADATA"},
+	}
+	if !reflect.DeepEqual(configs, want) {
+		t.Errorf("evaluateWorkloadMetadata = %+v, want %+v", configs, want)
+	}
+
+	var exposed []string
+	for _, config := range configs {
+		if config.exposed() {
+			exposed = append(exposed, config.Name)
+		}
+	}
+	if !reflect.DeepEqual(exposed, []string{"legacy", "exposed", "unset"}) {
+		t.Errorf("exposed pools = %v, want legacy, exposed and unset", exposed)
+	}
+}
+
+func TestHandleGetGKEWorkloadMetadataConfig(t *testing.T) {
+	const clusterPath = "/v1/projects/test-project/locations/us-central1/clusters/prod"
+	ctx := withFakeGCP(context.Background(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case clusterPath:
+			w.Write([]byte(`{"name": "prod", "workloadIdentityConfig": {"workloadPool": "test-project.svc.id.goog"}}`))
+		case clusterPath + "/nodePools":
+			w.Write([]byte(`{"nodePools": ` + testMetadataPools + `}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	text := callClusterTool(t, ctx, handleGetGKEWorkloadMetadataConfig, "prod", nil)
+
+	for _, s := range []string{
+		"- **Workload Identity**: enabled (workload pool test-project.svc.id.goog)",
+		"| secure | GKE_METADATA | disabled | OK |\n" +
+			"| legacy | GKE_METADATA | **enabled** | **Metadata exposed** |\n" +
+			"| exposed | GCE_METADATA | disabled | **Metadata exposed** |\n" +
+			"| unset | GCE_METADATA (default) | disabled | **Metadata exposed** |\n",
+		"- **exposed**: pods can read the node's service account token and kube-env bootstrap credentials from the Compute Engine metadata server, and Workload Identity doesn't work for pods on these nodes",
+		"- **legacy**: the deprecated v0.1 and v1beta1 metadata endpoints are enabled",
+		"3. Remove the disable-legacy-endpoints=false node metadata",
+	} {
+		if !strings.Contains(text, s) {
+			t.Errorf("result doesn't contain %q:\n%s", s, text)
+		}
+	}
+	if strings.Contains(text, "- **secure**") {
+		t.Errorf("result flags a pool using the GKE metadata server:\n%s", text)
+	}
+}
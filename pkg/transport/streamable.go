@@ -0,0 +1,293 @@
+// Package transport provides MCP transports not available in mcp-go
+package transport
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// StreamableEndpoint is the path the streamable HTTP transport serves
+	StreamableEndpoint = "/mcp"
+
+	// sessionHeader carries the session ID assigned on initialize
+	sessionHeader = "Mcp-Session-Id"
+
+	// maxMessageBytes caps the size of a request body
+	maxMessageBytes = 4 << 20
+
+	// sessionIdleTimeout is how long a session lasts without a request, since
+	// clients don't always end their sessions with a DELETE
+	sessionIdleTimeout = 30 * time.Minute
+
+	// maxSessions caps the number of open sessions. Starting a session beyond
+	// it ends the one that has been idle longest.
+	maxSessions = 1000
+)
+
+// StreamableHTTPServer serves an MCP server over the streamable HTTP
+// transport. Every JSON-RPC message is POSTed to a single endpoint and its
+// response is returned in the HTTP response body. Server-initiated streams
+// aren't supported, so a GET to the endpoint is rejected as the
+// specification allows.
+type StreamableHTTPServer struct {
+	server  *server.MCPServer
+	baseURL string
+	srv     *http.Server
+
+	// sessions maps open session IDs to when they were last used
+	mu          sync.Mutex
+	sessions    map[string]time.Time
+	idleTimeout time.Duration
+	maxSessions int
+}
+
+// NewStreamableHTTPServer creates a streamable HTTP server for s. The path
+// of baseURL, if any, prefixes the endpoint.
+func NewStreamableHTTPServer(s *server.MCPServer, baseURL string) *StreamableHTTPServer {
+	h := &StreamableHTTPServer{
+		server:      s,
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		sessions:    make(map[string]time.Time),
+		idleTimeout: sessionIdleTimeout,
+		maxSessions: maxSessions,
+	}
+	h.srv = &http.Server{Handler: h}
+	return h
+}
+
+// EndpointURL returns the URL clients connect to
+func (h *StreamableHTTPServer) EndpointURL() string {
+	return h.baseURL + StreamableEndpoint
+}
+
+// Start listens on addr and serves requests until Shutdown is called
+func (h *StreamableHTTPServer) Start(addr string) error {
+	h.srv.Addr = addr
+	return h.srv.ListenAndServe()
+}
+
+// Shutdown gracefully stops the server, ending all sessions
+func (h *StreamableHTTPServer) Shutdown(ctx context.Context) error {
+	h.mu.Lock()
+	clear(h.sessions)
+	h.mu.Unlock()
+	return h.srv.Shutdown(ctx)
+}
+
+// ServeHTTP implements http.Handler
+func (h *StreamableHTTPServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != h.endpointPath() {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		h.handlePost(w, r)
+	case http.MethodDelete:
+		h.handleDelete(w, r)
+	default:
+		w.Header().Set("Allow", "POST, DELETE")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// endpointPath returns the path of the endpoint, under the path of the
+// base URL
+func (h *StreamableHTTPServer) endpointPath() string {
+	if parsed, err := url.Parse(h.baseURL); err == nil {
+		return strings.TrimSuffix(parsed.Path, "/") + StreamableEndpoint
+	}
+	return StreamableEndpoint
+}
+
+// handlePost handles a JSON-RPC message or batch of messages
+func (h *StreamableHTTPServer) handlePost(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxMessageBytes+1))
+	if err != nil {
+		writeJSONRPCError(w, http.StatusBadRequest, mcp.PARSE_ERROR, "Error reading request body")
+		return
+	}
+	if len(body) > maxMessageBytes {
+		writeJSONRPCError(w, http.StatusRequestEntityTooLarge, mcp.INVALID_REQUEST, "Request body too large")
+		return
+	}
+
+	messages, batch, err := splitMessages(body)
+	if err != nil {
+		writeJSONRPCError(w, http.StatusBadRequest, mcp.PARSE_ERROR, "Parse error")
+		return
+	}
+
+	// Sessions start with initialize, and later requests naming a session must
+	// name a known one
+	sessionID := r.Header.Get(sessionHeader)
+	if containsInitialize(messages) {
+		sessionID, err = newSessionID()
+		if err != nil {
+			writeJSONRPCError(w, http.StatusInternalServerError, mcp.INTERNAL_ERROR, "Error creating session")
+			return
+		}
+		h.startSession(sessionID)
+	} else if sessionID != "" {
+		if !h.touchSession(sessionID) {
+			http.Error(w, "Session not found", http.StatusNotFound)
+			return
+		}
+	}
+
+	ctx := h.server.WithContext(r.Context(), server.NotificationContext{
+		ClientID:  sessionID,
+		SessionID: sessionID,
+	})
+
+	var responses []mcp.JSONRPCMessage
+	for _, message := range messages {
+		if response := h.server.HandleMessage(ctx, message); response != nil {
+			responses = append(responses, response)
+		}
+	}
+
+	if sessionID != "" {
+		w.Header().Set(sessionHeader, sessionID)
+	}
+
+	// Notifications and responses from the client get no reply
+	if len(responses) == 0 {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if batch {
+		json.NewEncoder(w).Encode(responses)
+	} else {
+		json.NewEncoder(w).Encode(responses[0])
+	}
+}
+
+// handleDelete ends a session
+func (h *StreamableHTTPServer) handleDelete(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.Header.Get(sessionHeader)
+	if sessionID == "" {
+		http.Error(w, "Missing session ID", http.StatusBadRequest)
+		return
+	}
+	if !h.touchSession(sessionID) {
+		http.Error(w, "Session not found", http.StatusNotFound)
+		return
+	}
+	h.mu.Lock()
+	delete(h.sessions, sessionID)
+	h.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// startSession records a new session. Expired sessions are removed first,
+// and if the cap is still reached the session idle longest is ended.
+func (h *StreamableHTTPServer) startSession(sessionID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	var oldestID string
+	var oldest time.Time
+	for id, lastUsed := range h.sessions {
+		if now.Sub(lastUsed) > h.idleTimeout {
+			delete(h.sessions, id)
+			continue
+		}
+		if oldestID == "" || lastUsed.Before(oldest) {
+			oldestID, oldest = id, lastUsed
+		}
+	}
+	if len(h.sessions) >= h.maxSessions {
+		delete(h.sessions, oldestID)
+	}
+
+	h.sessions[sessionID] = now
+}
+
+// touchSession reports whether a session is open, marking it as used. An
+// expired session is removed.
+func (h *StreamableHTTPServer) touchSession(sessionID string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	lastUsed, ok := h.sessions[sessionID]
+	if !ok {
+		return false
+	}
+	now := time.Now()
+	if now.Sub(lastUsed) > h.idleTimeout {
+		delete(h.sessions, sessionID)
+		return false
+	}
+	h.sessions[sessionID] = now
+	return true
+}
+
+// splitMessages splits a request body into its JSON-RPC messages, reporting
+// whether it was a batch
+func splitMessages(body []byte) ([]json.RawMessage, bool, error) {
+	trimmed := strings.TrimSpace(string(body))
+	if strings.HasPrefix(trimmed, "[") {
+		var messages []json.RawMessage
+		if err := json.Unmarshal(body, &messages); err != nil {
+			return nil, false, err
+		}
+		if len(messages) == 0 {
+			return nil, false, fmt.Errorf("empty batch")
+		}
+		return messages, true, nil
+	}
+
+	var message json.RawMessage
+	if err := json.Unmarshal(body, &message); err != nil {
+		return nil, false, err
+	}
+	return []json.RawMessage{message}, false, nil
+}
+
+// containsInitialize reports whether any of the messages is an initialize
+// request
+func containsInitialize(messages []json.RawMessage) bool {
+	for _, message := range messages {
+		var base struct {
+			Method string `json:"method"`
+		}
+		if json.Unmarshal(message, &base) == nil && base.Method == "initialize" {
+			return true
+		}
+	}
+	return false
+}
+
+// newSessionID returns a random session ID
+func newSessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// writeJSONRPCError writes a JSON-RPC error response with no request ID
+func writeJSONRPCError(w http.ResponseWriter, status, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(mcp.NewJSONRPCError(nil, code, message, nil))
+}
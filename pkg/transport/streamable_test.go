@@ -0,0 +1,173 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const initializeMessage = `{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": {"protocolVersion": "2024-11-05", "capabilities": {}, "clientInfo": {"name": "test", "version": "0.0.0"}}}`
+
+const pingMessage = `{"jsonrpc": "2.0", "id": 2, "method": "ping"}`
+
+func newTestStreamableServer() *StreamableHTTPServer {
+	return NewStreamableHTTPServer(server.NewMCPServer("test", "0.0.0"), "http://localhost:8080")
+}
+
+// send makes a request to the endpoint, naming sessionID if it's set
+func send(h *StreamableHTTPServer, method, sessionID, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, "/mcp", strings.NewReader(body))
+	if sessionID != "" {
+		r.Header.Set(sessionHeader, sessionID)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+// initialize starts a session and returns its ID
+func initialize(t *testing.T, h *StreamableHTTPServer) string {
+	t.Helper()
+
+	w := send(h, http.MethodPost, "", initializeMessage)
+	if w.Code != http.StatusOK {
+		t.Fatalf("initialize status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	sessionID := w.Header().Get(sessionHeader)
+	if sessionID == "" {
+		t.Fatal("initialize didn't return a session ID")
+	}
+	return sessionID
+}
+
+func TestStreamableInitialize(t *testing.T) {
+	h := newTestStreamableServer()
+
+	w := send(h, http.MethodPost, "", initializeMessage)
+	if w.Code != http.StatusOK || w.Header().Get(sessionHeader) == "" {
+		t.Fatalf("initialize = %d with session %q, want %d with a session ID", w.Code, w.Header().Get(sessionHeader), http.StatusOK)
+	}
+
+	var response struct {
+		ID     int `json:"id"`
+		Result struct {
+			ServerInfo struct {
+				Name string `json:"name"`
+			} `json:"serverInfo"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || response.ID != 1 || response.Result.ServerInfo.Name != "test" {
+		t.Errorf("initialize response = %s, want the server info", w.Body)
+	}
+
+	// Later requests in the session are answered and keep the header
+	sessionID := w.Header().Get(sessionHeader)
+	w = send(h, http.MethodPost, sessionID, pingMessage)
+	if w.Code != http.StatusOK || w.Header().Get(sessionHeader) != sessionID {
+		t.Errorf("ping = %d with session %q, want %d with %q", w.Code, w.Header().Get(sessionHeader), http.StatusOK, sessionID)
+	}
+}
+
+func TestStreamableUnknownSession(t *testing.T) {
+	h := newTestStreamableServer()
+
+	if w := send(h, http.MethodPost, "not-a-session", pingMessage); w.Code != http.StatusNotFound {
+		t.Errorf("unknown session status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestStreamableDelete(t *testing.T) {
+	h := newTestStreamableServer()
+	sessionID := initialize(t, h)
+
+	if w := send(h, http.MethodDelete, sessionID, ""); w.Code != http.StatusNoContent {
+		t.Fatalf("DELETE status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+
+	// The session is gone once deleted
+	if w := send(h, http.MethodPost, sessionID, pingMessage); w.Code != http.StatusNotFound {
+		t.Errorf("request to a deleted session status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+	if w := send(h, http.MethodDelete, sessionID, ""); w.Code != http.StatusNotFound {
+		t.Errorf("second DELETE status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+	if w := send(h, http.MethodDelete, "", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("DELETE without a session status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestStreamableSessionExpiry(t *testing.T) {
+	h := newTestStreamableServer()
+	idle := initialize(t, h)
+	active := initialize(t, h)
+
+	// Backdate the idle session past the timeout
+	h.mu.Lock()
+	h.sessions[idle] = time.Now().Add(-h.idleTimeout - time.Minute)
+	h.mu.Unlock()
+
+	if w := send(h, http.MethodPost, idle, pingMessage); w.Code != http.StatusNotFound {
+		t.Errorf("expired session status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+	if w := send(h, http.MethodPost, active, pingMessage); w.Code != http.StatusOK {
+		t.Errorf("active session status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.sessions[idle]; ok || len(h.sessions) != 1 {
+		t.Errorf("sessions = %v, want only the active session", h.sessions)
+	}
+}
+
+func TestStreamableSessionCap(t *testing.T) {
+	h := newTestStreamableServer()
+	h.maxSessions = 2
+
+	first := initialize(t, h)
+	second := initialize(t, h)
+
+	// Using the first session makes the second the one idle longest
+	h.mu.Lock()
+	h.sessions[second] = time.Now().Add(-time.Minute)
+	h.mu.Unlock()
+	send(h, http.MethodPost, first, pingMessage)
+
+	third := initialize(t, h)
+
+	for sessionID, want := range map[string]int{first: http.StatusOK, second: http.StatusNotFound, third: http.StatusOK} {
+		if w := send(h, http.MethodPost, sessionID, pingMessage); w.Code != want {
+			t.Errorf("session %s status = %d, want %d", sessionID, w.Code, want)
+		}
+	}
+}
+
+func TestStreamableShutdown(t *testing.T) {
+	h := newTestStreamableServer()
+
+	errc := make(chan error, 1)
+	go func() { errc <- h.Start("127.0.0.1:0") }()
+
+	sessionID := initialize(t, h)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := h.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		t.Errorf("Start returned %v, want %v", err, http.ErrServerClosed)
+	}
+
+	// Shutting down ends every session
+	if w := send(h, http.MethodPost, sessionID, pingMessage); w.Code != http.StatusNotFound {
+		t.Errorf("session after shutdown status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}